@@ -0,0 +1,117 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/alicloud"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/aws"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/azure"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/azure-private"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/cloudflare"
+	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/google"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/infoblox"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/netlify"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/openstack"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// check-permissions probes the provider API permissions needed by the DNS controller
+// for the credentials of a provider secret.
+func main() {
+	ptype := flag.String("type", "", "provider type (e.g. aws-route53)")
+	secretFile := flag.String("secret", "", "file containing the provider secret manifest")
+	configFile := flag.String("provider-config", "", "optional file containing the provider config (JSON)")
+	flag.Parse()
+
+	if *ptype == "" || *secretFile == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	missing, err := check(*ptype, *secretFile, *configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(2)
+	}
+	if len(missing) > 0 {
+		for _, m := range missing {
+			fmt.Printf("missing permission: %s\n", m)
+		}
+		os.Exit(1)
+	}
+	fmt.Println("all permissions available")
+}
+
+func check(ptype, secretFile, configFile string) ([]string, error) {
+	props, err := readSecret(secretFile)
+	if err != nil {
+		return nil, err
+	}
+	var providerConfig *runtime.RawExtension
+	if configFile != "" {
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			return nil, err
+		}
+		providerConfig = &runtime.RawExtension{Raw: data}
+	}
+
+	handler, err := provider.NewStandaloneHandler(context.Background(), logger.New(), compound.Factory, ptype, props, providerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create handler: %w", err)
+	}
+	defer handler.Release()
+
+	zones, err := handler.GetZones()
+	if err != nil {
+		return []string{fmt.Sprintf("list hosted zones: %s", err)}, nil
+	}
+	for _, zone := range zones {
+		fmt.Printf("checking zone %s (%s)\n", zone.Id().ID, zone.Domain())
+	}
+	return provider.CheckPermissions(handler, zones), nil
+}
+
+func readSecret(file string) (utils.Properties, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	if err := yaml.Unmarshal(data, secret); err != nil {
+		return nil, fmt.Errorf("invalid secret manifest %s: %w", file, err)
+	}
+	props := utils.Properties{}
+	for k, v := range secret.Data {
+		props[k] = string(v)
+	}
+	for k, v := range secret.StringData {
+		props[k] = v
+	}
+	return props, nil
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/alicloud"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/aws"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/azure"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/azure-private"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/cloudflare"
+	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/google"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/infoblox"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/netlify"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/openstack"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/selection"
+)
+
+// check-provider performs read-only API calls with the credentials of a DNS provider
+// and optionally creates and deletes a temporary TXT record.
+func main() {
+	providerFile := flag.String("provider", "", "file containing the DNSProvider manifest (may also contain the secret)")
+	secretFile := flag.String("secret", "", "file containing the provider secret manifest")
+	writeTest := flag.String("write-test", "", "optional DNS name for a temporary TXT record created and deleted in an included hosted zone (must not exist)")
+	flag.Parse()
+
+	if *providerFile == "" || flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	files := []string{*providerFile}
+	if *secretFile != "" {
+		files = append(files, *secretFile)
+	}
+	p, props, err := provider.ReadProviderManifests(files...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(2)
+	}
+	if !check(p, props, *writeTest) {
+		os.Exit(1)
+	}
+}
+
+type checker struct {
+	failed bool
+}
+
+func (this *checker) report(err error, format string, args ...interface{}) bool {
+	status := "ok  "
+	if err != nil {
+		status = "FAIL"
+		this.failed = true
+	}
+	fmt.Printf("%s  %s", status, fmt.Sprintf(format, args...))
+	if err != nil {
+		fmt.Printf(": %s", err)
+	}
+	fmt.Println()
+	return err == nil
+}
+
+func check(p *api.DNSProvider, props utils.Properties, writeTest string) bool {
+	c := &checker{}
+	fmt.Printf("provider %s (%s)\n", p.Name, p.Spec.Type)
+	if !c.report(provider.ValidateProviderSecret(compound.Factory, p.Spec.Type, props), "validate secret") {
+		return false
+	}
+	handler, err := provider.NewStandaloneHandler(context.Background(), logger.New(), compound.Factory, p.Spec.Type, props, p.Spec.ProviderConfig)
+	if !c.report(err, "create handler") {
+		return false
+	}
+	defer handler.Release()
+
+	zones, err := handler.GetZones()
+	if !c.report(err, "list hosted zones (%d found)", len(zones)) {
+		return false
+	}
+	lzones := make([]selection.LightDNSHostedZone, len(zones))
+	for i, z := range zones {
+		lzones[i] = z
+	}
+	result := selection.CalcZoneAndDomainSelection(p.Spec, lzones)
+	for _, w := range result.Warnings {
+		fmt.Printf("      warning: %s\n", w)
+	}
+	if result.Error != "" {
+		c.report(fmt.Errorf("%s", result.Error), "select hosted zones")
+		return false
+	}
+	included := provider.DNSHostedZones{}
+	for _, z := range zones {
+		if result.ZoneSel.Include.Contains(z.Id().ID) {
+			included = append(included, z)
+			fmt.Printf("      zone %s (%s) included\n", z.Id().ID, z.Domain())
+		} else {
+			fmt.Printf("      zone %s (%s) excluded\n", z.Id().ID, z.Domain())
+		}
+	}
+	for _, z := range included {
+		state, err := handler.GetZoneState(z)
+		if err == nil {
+			c.report(nil, "read records of zone %s (%d record sets)", z.Id().ID, len(state.GetDNSSets()))
+		} else {
+			c.report(err, "read records of zone %s", z.Id().ID)
+		}
+	}
+	if writeTest != "" {
+		c.writeTest(handler, included, dns.NormalizeHostname(writeTest))
+	}
+	return !c.failed
+}
+
+// writeTest creates a temporary TXT record set in the best matching hosted zone and deletes it again.
+func (this *checker) writeTest(handler provider.DNSHandler, zones provider.DNSHostedZones, name string) {
+	var zone provider.DNSHostedZone
+	for _, z := range zones {
+		if z.Match(name) > 0 && (zone == nil || z.Match(name) > zone.Match(name)) {
+			zone = z
+		}
+	}
+	if zone == nil {
+		this.report(fmt.Errorf("no included hosted zone found"), "write test for %s", name)
+		return
+	}
+	setName := dns.DNSSetName{DNSName: name}
+	state, err := handler.GetZoneState(zone)
+	if !this.report(err, "read records of zone %s", zone.Id().ID) {
+		return
+	}
+	if state.GetDNSSets()[setName] != nil {
+		this.report(fmt.Errorf("record set already exists"), "write test for %s", name)
+		return
+	}
+	set := dns.NewDNSSet(setName, nil)
+	set.SetRecordSet(dns.RS_TXT, 60, fmt.Sprintf("%q", "dns-controller-manager check-provider "+time.Now().UTC().Format(time.RFC3339)))
+	if !this.report(execute(handler, zone, state, provider.R_CREATE, nil, set), "create TXT record set %s", name) {
+		return
+	}
+	// the zone state is read again, as some handlers need the provider ids of the records for deletion
+	state, err = handler.GetZoneState(zone)
+	if !this.report(err, "read records of zone %s", zone.Id().ID) {
+		return
+	}
+	if state.GetDNSSets()[setName] == nil {
+		fmt.Printf("      warning: created record set not yet visible in zone\n")
+	}
+	this.report(execute(handler, zone, state, provider.R_DELETE, set, nil), "delete TXT record set %s", name)
+}
+
+func execute(handler provider.DNSHandler, zone provider.DNSHostedZone, state provider.DNSZoneState, action string, old, new *dns.DNSSet) error {
+	done := &doneHandler{}
+	req := provider.NewChangeRequest(action, dns.RS_TXT, old, new, done)
+	if err := handler.ExecuteRequests(logger.New(), zone, state, []*provider.ChangeRequest{req}); err != nil {
+		return err
+	}
+	return done.err
+}
+
+type doneHandler struct {
+	err error
+}
+
+func (this *doneHandler) SetInvalid(err error) { this.err = err }
+func (this *doneHandler) Failed(err error)     { this.err = err }
+func (this *doneHandler) Throttled()           { this.err = fmt.Errorf("throttled") }
+func (this *doneHandler) Succeeded()           {}
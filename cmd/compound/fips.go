@@ -0,0 +1,34 @@
+//go:build boringcrypto
+
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package main
+
+import (
+	"crypto/boring"
+	// restricts TLS to FIPS-approved versions, cipher suites, curves and signature algorithms
+	_ "crypto/tls/fipsonly"
+	"fmt"
+	"os"
+)
+
+func init() {
+	if !boring.Enabled() {
+		fmt.Fprintln(os.Stderr, "FIPS build: BoringCrypto module not enabled")
+		os.Exit(1)
+	}
+}
@@ -29,7 +29,12 @@ import (
 	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
 
 	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	_ "github.com/gardener/external-dns-management/pkg/controller/acmesolver"
 	_ "github.com/gardener/external-dns-management/pkg/controller/annotation/annotations"
+	_ "github.com/gardener/external-dns-management/pkg/controller/challenge"
+	_ "github.com/gardener/external-dns-management/pkg/controller/dnsswitch"
+	_ "github.com/gardener/external-dns-management/pkg/controller/election"
+	_ "github.com/gardener/external-dns-management/pkg/controller/entryset"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/alicloud"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/aws"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/azure"
@@ -46,6 +51,7 @@ import (
 	_ "github.com/gardener/external-dns-management/pkg/controller/source/dnsentry"
 	_ "github.com/gardener/external-dns-management/pkg/controller/source/ingress"
 	_ "github.com/gardener/external-dns-management/pkg/controller/source/service"
+	_ "github.com/gardener/external-dns-management/pkg/controller/trafficshift"
 	dnsprovider "github.com/gardener/external-dns-management/pkg/dns/provider"
 	dnssource "github.com/gardener/external-dns-management/pkg/dns/source"
 	_ "github.com/gardener/external-dns-management/pkg/server/pprof"
@@ -86,5 +92,8 @@ func main() {
 		fmt.Println(Version)
 		os.Exit(0)
 	}
+	if len(os.Args) >= 2 && os.Args[1] == "validate" {
+		os.Exit(validate(os.Args[2:]))
+	}
 	controllermanager.Start("dns-controller-manager", "dns controller manager", "nothing", migrateExtensionsIngress)
 }
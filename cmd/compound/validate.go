@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
+	dnsprovider "github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+type fileList []string
+
+func (l *fileList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *fileList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// validate validates DNSEntry and DNSProvider manifests (and the provider secrets given as manifests)
+// without a cluster and returns the exit code.
+func validate(args []string) int {
+	flags := flag.NewFlagSet("dns-controller-manager validate", flag.ExitOnError)
+	files := fileList{}
+	flags.Var(&files, "f", "manifest file or directory (recursively all *.yaml, *.yml and *.json files), '-' for stdin (repeatable)")
+	disableNameValidation := flags.Bool("disable-dns-name-validation", false, "disables the validation of the DNS names of the entries")
+	flags.Parse(args)
+	if len(files) == 0 || flags.NArg() > 0 {
+		flags.Usage()
+		return 2
+	}
+
+	validator := dnsprovider.NewManifestValidator(compound.Factory, !*disableNameValidation)
+	for _, f := range files {
+		if err := addManifests(validator, f); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %s\n", err)
+			return 2
+		}
+	}
+	errors := 0
+	for _, issue := range validator.Validate() {
+		if !issue.Warning {
+			errors++
+		}
+		fmt.Println(issue.Error())
+	}
+	fmt.Printf("%d objects validated, %d errors\n", validator.Objects(), errors)
+	if errors > 0 {
+		return 1
+	}
+	return 0
+}
+
+func addManifests(validator *dnsprovider.ManifestValidator, path string) error {
+	if path == "-" {
+		return validator.Add("stdin", os.Stdin)
+	}
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(p) {
+		case ".yaml", ".yml", ".json":
+		default:
+			if p != path {
+				return nil
+			}
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return validator.Add(p, f)
+	})
+}
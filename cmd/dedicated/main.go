@@ -27,6 +27,8 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	_ "github.com/gardener/external-dns-management/pkg/controller/annotation/annotations"
+	_ "github.com/gardener/external-dns-management/pkg/controller/election"
+	_ "github.com/gardener/external-dns-management/pkg/controller/entryset"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/alicloud/controller"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/aws/controller"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/azure-private/controller"
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gardener/external-dns-management/pkg/dns/debugstate"
+)
+
+// dump-state prints the in-memory state of the DNS controllers served by the debug state endpoint
+// or the diff of a hosted zone served by the debug diff endpoint.
+func main() {
+	server := flag.String("server", "http://localhost:8080", "URL of the HTTP server of the DNS controller manager")
+	tokenFile := flag.String("token-file", "", "file containing the bearer token for the debug state endpoint")
+	zone := flag.String("zone", "", "optional id of a hosted zone to restrict the dump to")
+	diff := flag.Bool("diff", false, "print the changes needed to bring the records at the DNS provider to the desired state of the hosted zone given by --zone")
+	providerType := flag.String("type", "", "optional provider type of the hosted zone for ambiguous zone ids (only used with --diff)")
+	raw := flag.Bool("json", false, "print the state as JSON")
+	flag.Parse()
+
+	if *tokenFile == "" || *diff && *zone == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	token, err := os.ReadFile(*tokenFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+	var data []byte
+	if *diff {
+		data, err = debugstate.ReadDiff(*server, strings.TrimSpace(string(token)), *zone, *providerType)
+	} else {
+		data, err = debugstate.Read(*server, strings.TrimSpace(string(token)), *zone)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+	if *raw {
+		fmt.Print(string(data))
+		return
+	}
+	if *diff {
+		diffs := []*debugstate.ZoneDiff{}
+		if err := json.Unmarshal(data, &diffs); err != nil {
+			fmt.Fprintf(os.Stderr, "error: invalid response: %s\n", err)
+			os.Exit(1)
+		}
+		failed := false
+		for _, d := range diffs {
+			failed = !printDiff(d) || failed
+		}
+		if failed {
+			os.Exit(1)
+		}
+		return
+	}
+	states := []*debugstate.State{}
+	if err := json.Unmarshal(data, &states); err != nil {
+		fmt.Fprintf(os.Stderr, "error: invalid response: %s\n", err)
+		os.Exit(1)
+	}
+	for _, state := range states {
+		printState(state)
+	}
+}
+
+// printDiff prints the changes of a hosted zone and returns false if the diff failed.
+func printDiff(diff *debugstate.ZoneDiff) bool {
+	fmt.Printf("controller %s\n", diff.Controller)
+	fmt.Printf("zone %s/%s (%s)\n", diff.ProviderType, diff.ID, diff.Domain)
+	if diff.Error != "" {
+		fmt.Printf("  error: %s\n", diff.Error)
+		return false
+	}
+	for _, c := range diff.Changes {
+		entry := c.Entry
+		if entry == "" {
+			entry = "orphaned"
+		}
+		fmt.Printf("  %s\t(%s)\n", c.Description, entry)
+	}
+	for _, c := range diff.Conflicts {
+		fmt.Printf("  conflict %s: %s\n", c.Entry, c.Message)
+	}
+	fmt.Printf("  %d changes, %d conflicts\n", len(diff.Changes), len(diff.Conflicts))
+	return true
+}
+
+func printState(state *debugstate.State) {
+	fmt.Printf("controller %s\n", state.Controller)
+	for _, zone := range state.Zones {
+		flags := ""
+		if zone.Private {
+			flags += " [private]"
+		}
+		if zone.Busy {
+			flags += " [busy]"
+		}
+		fmt.Printf("zone %s/%s (%s)%s\n", zone.ProviderType, zone.ID, zone.Domain, flags)
+		fmt.Printf("  providers: %s\n", strings.Join(zone.Providers, ", "))
+		if !zone.Next.IsZero() {
+			fmt.Printf("  next reconciliation: %s\n", zone.Next.Format(time.RFC3339))
+		}
+		printEntries(zone.Entries)
+		if len(zone.OutstandingRequests) > 0 {
+			fmt.Println("  outstanding requests")
+			for _, c := range zone.OutstandingRequests {
+				fmt.Printf("    %s\n", c.Description)
+			}
+		}
+	}
+	if len(state.UnassignedEntries) > 0 {
+		fmt.Println("entries without hosted zone")
+		printEntries(state.UnassignedEntries)
+	}
+	if len(state.BlockingEntries) > 0 {
+		fmt.Println("entries blocking zone reconciliations")
+		for name, since := range state.BlockingEntries {
+			fmt.Printf("  %s since %s\n", name, since.Format(time.RFC3339))
+		}
+	}
+}
+
+func printEntries(entries []debugstate.Entry) {
+	for _, e := range entries {
+		flags := ""
+		if !e.Valid {
+			flags += " [invalid]"
+		}
+		if e.ChangePending {
+			flags += " [change pending]"
+		}
+		if e.Deleting {
+			flags += " [deleting]"
+		}
+		fmt.Printf("  %s\t%s\t%s%s", e.Name, e.DNSName, e.State, flags)
+		if e.Message != "" {
+			fmt.Printf("\t%s", e.Message)
+		}
+		fmt.Println()
+	}
+}
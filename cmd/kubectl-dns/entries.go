@@ -0,0 +1,263 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns/debugstate"
+)
+
+func runListEntries(args []string) error {
+	o := newOptions("list entries")
+	zone := o.flags.String("zone", "", "optional id of a hosted zone to restrict the list to")
+	if _, err := o.parse(args); err != nil {
+		return err
+	}
+	client, namespace, err := o.client()
+	if err != nil {
+		return err
+	}
+	entries, err := listEntries(client, namespace)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tDNS\tZONE\tPROVIDER\tSTATE")
+	for _, e := range entries {
+		if *zone != "" && deref(e.Status.Zone, "") != *zone {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Namespace, e.Name, e.Spec.DNSName,
+			deref(e.Status.Zone, "-"), deref(e.Status.Provider, "-"), deref(&e.Status.State, "-"))
+	}
+	return w.Flush()
+}
+
+func getEntry(o *options, args []string) (*api.DNSEntry, error) {
+	args, err := o.parse(args)
+	if err != nil {
+		return nil, err
+	}
+	name, err := o.singleArg(args, "entry name")
+	if err != nil {
+		return nil, err
+	}
+	client, namespace, err := o.client()
+	if err != nil {
+		return nil, err
+	}
+	return client.DNSEntries(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func runDescribeEntry(args []string) error {
+	o := newOptions("describe entry")
+	entry, err := getEntry(o, args)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Name:           %s\n", entry.Name)
+	fmt.Printf("Namespace:      %s\n", entry.Namespace)
+	fmt.Printf("DNS name:       %s\n", entry.Spec.DNSName)
+	if entry.Spec.TargetRef != nil {
+		fmt.Printf("Target ref:     %s %s\n", entry.Spec.TargetRef.Kind, entry.Spec.TargetRef.Name)
+	}
+	if len(entry.Spec.Targets) > 0 {
+		fmt.Printf("Targets:        %s\n", strings.Join(entry.Spec.Targets, ", "))
+	}
+	if len(entry.Spec.Text) > 0 {
+		fmt.Printf("Text:           %s\n", strings.Join(entry.Spec.Text, ", "))
+	}
+	if entry.Spec.TTL != nil {
+		fmt.Printf("TTL:            %d\n", *entry.Spec.TTL)
+	}
+	fmt.Printf("Owner id:       %s\n", deref(entry.Spec.OwnerId, "<default of the DNS controller>"))
+	fmt.Println("Status:")
+	fmt.Printf("  State:        %s\n", deref(&entry.Status.State, "-"))
+	fmt.Printf("  Message:      %s\n", deref(entry.Status.Message, "-"))
+	fmt.Printf("  Provider:     %s (%s)\n", deref(entry.Status.Provider, "-"), deref(entry.Status.ProviderType, "-"))
+	fmt.Printf("  Zone:         %s\n", deref(entry.Status.Zone, "-"))
+	if entry.Status.TTL != nil {
+		fmt.Printf("  TTL:          %d\n", *entry.Status.TTL)
+	}
+	fmt.Printf("  Targets:      %s\n", strings.Join(entry.Status.Targets, ", "))
+	if entry.Status.LastUptimeTime != nil {
+		fmt.Printf("  Last update:  %s\n", entry.Status.LastUptimeTime.Format(time.RFC3339))
+	}
+	for _, c := range entry.Status.Conditions {
+		fmt.Printf("  Condition:    %s=%s (%s) %s\n", c.Type, c.Status, c.Reason, c.Message)
+	}
+	return nil
+}
+
+func runPlan(args []string) error {
+	o := newOptions("plan")
+	server := o.flags.String("server", "http://localhost:8080", "URL of the HTTP server of the DNS controller manager")
+	tokenFile := o.flags.String("token-file", "", "optional file containing the bearer token for the debug state endpoint")
+	entry, err := getEntry(o, args)
+	if err != nil {
+		return err
+	}
+	client, _, err := o.client()
+	if err != nil {
+		return err
+	}
+	providers, err := listProviders(client, "")
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("entry %s/%s (%s)\n", entry.Namespace, entry.Name, entry.Spec.DNSName)
+	fmt.Printf("  state: %s %s\n", deref(&entry.Status.State, "-"), deref(entry.Status.Message, ""))
+
+	fmt.Println("zone assignment:")
+	fmt.Printf("  assigned: provider %s, zone %s\n", deref(entry.Status.Provider, "-"), deref(entry.Status.Zone, "-"))
+	matches := responsibleProviders(entry.Spec.DNSName, entry.Namespace, entry.Spec.Provider, providers)
+	if len(matches) == 0 {
+		fmt.Println("  no ready provider responsible for the DNS name")
+	}
+	for i, m := range matches {
+		selected := ""
+		if i == 0 {
+			selected = " (selected)"
+		}
+		fmt.Printf("  responsible: provider %s (%s, priority %d, matching domain length %d)%s\n",
+			m.name(), m.provider.Spec.Type, m.priority(), m.length, selected)
+	}
+
+	fmt.Println("ownership:")
+	ownerId := deref(entry.Spec.OwnerId, "")
+	if ownerId == "" {
+		fmt.Println("  owner id: default of the DNS controller")
+	} else {
+		fmt.Printf("  owner id: %s, %s\n", ownerId, ownerState(client, ownerId))
+	}
+
+	fmt.Println("pending changes:")
+	changes := pendingChanges(entry, matches)
+	if len(changes) == 0 {
+		fmt.Println("  none")
+	}
+	for _, c := range changes {
+		fmt.Printf("  %s\n", c)
+	}
+
+	if *tokenFile != "" {
+		return printControllerView(*server, *tokenFile, entry)
+	}
+	return nil
+}
+
+// pendingChanges compares the spec of the entry with the state observed by the DNS controller.
+func pendingChanges(entry *api.DNSEntry, matches []providerMatch) []string {
+	changes := []string{}
+	if entry.DeletionTimestamp != nil {
+		if entry.Spec.DeletionPolicy != nil && *entry.Spec.DeletionPolicy == api.DeletionPolicyRetain {
+			changes = append(changes, "remove ownership of the records (deletion policy Retain)")
+		} else {
+			changes = append(changes, "delete the records")
+		}
+		return changes
+	}
+	if entry.Generation != entry.Status.ObservedGeneration {
+		changes = append(changes, fmt.Sprintf("reconcile spec change (generation %d, observed %d)", entry.Generation, entry.Status.ObservedGeneration))
+	}
+	if len(matches) > 0 && entry.Status.Provider != nil && *entry.Status.Provider != matches[0].name() {
+		changes = append(changes, fmt.Sprintf("move from provider %s to %s", *entry.Status.Provider, matches[0].name()))
+	}
+	if entry.Spec.TTL != nil && (entry.Status.TTL == nil || *entry.Spec.TTL != *entry.Status.TTL) {
+		changes = append(changes, fmt.Sprintf("set TTL %d", *entry.Spec.TTL))
+	}
+	if len(entry.Spec.Targets) > 0 && entry.Spec.TargetRef == nil && entry.Spec.Reference == nil &&
+		(entry.Spec.ResolveTargetsToAddresses == nil || !*entry.Spec.ResolveTargetsToAddresses) {
+		desired := map[string]bool{}
+		for _, t := range entry.Spec.Targets {
+			desired[t] = true
+		}
+		for _, t := range entry.Status.Targets {
+			if !desired[t] {
+				changes = append(changes, "remove target "+t)
+			}
+			delete(desired, t)
+		}
+		for _, t := range entry.Spec.Targets {
+			if desired[t] {
+				changes = append(changes, "add target "+t)
+			}
+		}
+	}
+	switch entry.Status.State {
+	case "":
+		changes = append(changes, "initial reconciliation")
+	case api.STATE_PENDING, api.STATE_ERROR:
+		changes = append(changes, "retry of the zone reconciliation ("+entry.Status.State+")")
+	}
+	return changes
+}
+
+// printControllerView prints the in-memory state of the entry read from the debug state endpoint.
+func printControllerView(server, tokenFile string, entry *api.DNSEntry) error {
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return err
+	}
+	data, err := debugstate.Read(server, strings.TrimSpace(string(token)), deref(entry.Status.Zone, ""))
+	if err != nil {
+		return err
+	}
+	states := []*debugstate.State{}
+	if err := json.Unmarshal(data, &states); err != nil {
+		return fmt.Errorf("invalid response: %s", err)
+	}
+	name := entry.Namespace + "/" + entry.Name
+	fmt.Println("controller view:")
+	found := false
+	for _, state := range states {
+		for _, zone := range state.Zones {
+			for _, e := range zone.Entries {
+				if e.Name != name {
+					continue
+				}
+				found = true
+				fmt.Printf("  controller %s, zone %s/%s (busy: %t, next reconciliation: %s)\n",
+					state.Controller, zone.ProviderType, zone.ID, zone.Busy, zone.Next.Format(time.RFC3339))
+				fmt.Printf("  state %s, valid: %t, change pending: %t, deleting: %t\n", e.State, e.Valid, e.ChangePending, e.Deleting)
+			}
+		}
+		for _, e := range state.UnassignedEntries {
+			if e.Name == name {
+				found = true
+				fmt.Printf("  controller %s: no hosted zone (%s)\n", state.Controller, e.Message)
+			}
+		}
+		if since, ok := state.BlockingEntries[name]; ok {
+			fmt.Printf("  blocking zone reconciliations since %s\n", since.Format(time.RFC3339))
+		}
+	}
+	if !found {
+		fmt.Println("  entry unknown to the DNS controllers")
+	}
+	return nil
+}
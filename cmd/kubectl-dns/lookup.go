@@ -0,0 +1,95 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+func runLookup(args []string) error {
+	o := newOptions("lookup")
+	resolve := o.flags.Bool("resolve", false, "resolve the DNS name with the local resolver")
+	args, err := o.parse(args)
+	if err != nil {
+		return err
+	}
+	name, err := o.singleArg(args, "DNS name")
+	if err != nil {
+		return err
+	}
+	name = normalizeDNSName(name)
+	client, namespace, err := o.client()
+	if err != nil {
+		return err
+	}
+	entries, err := listEntries(client, namespace)
+	if err != nil {
+		return err
+	}
+	providers, err := listProviders(client, "")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("entries:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	found := false
+	for _, e := range entries {
+		dnsName := normalizeDNSName(e.Spec.DNSName)
+		if dnsName != name && !(strings.HasPrefix(dnsName, "*.") && dnsutils.Match(name, dnsName[2:]) && name != dnsName[2:]) {
+			continue
+		}
+		found = true
+		fmt.Fprintf(w, "  %s/%s\t%s\t%s\t%s\t%s\n", e.Namespace, e.Name, e.Spec.DNSName,
+			deref(e.Status.Zone, "-"), deref(&e.Status.State, "-"), strings.Join(e.Status.Targets, ","))
+	}
+	w.Flush()
+	if !found {
+		fmt.Println("  none")
+	}
+
+	fmt.Println("responsible providers:")
+	matches := responsibleProviders(name, namespace, nil, providers)
+	if len(matches) == 0 {
+		fmt.Println("  none")
+	}
+	for _, m := range matches {
+		fmt.Printf("  %s (%s, priority %d, zones %s)\n", m.name(), m.provider.Spec.Type, m.priority(),
+			strings.Join(m.provider.Status.Zones.Included, ","))
+	}
+
+	if *resolve {
+		fmt.Println("resolved:")
+		if cname, err := net.LookupCNAME(name); err == nil && normalizeDNSName(cname) != name {
+			fmt.Printf("  CNAME %s\n", cname)
+		}
+		addrs, err := net.LookupHost(name)
+		if err != nil {
+			fmt.Printf("  %s\n", err)
+		}
+		for _, a := range addrs {
+			fmt.Printf("  %s\n", a)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/gardener/external-dns-management/pkg/client/dns/clientset/versioned"
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/client/dns/clientset/versioned/typed/dns/v1alpha1"
+)
+
+const usage = `kubectl-dns shows the view of the DNS controller manager on DNS entries and providers.
+
+Usage:
+  kubectl dns list entries [--zone <zone id>] [-n <namespace> | -A]
+  kubectl dns describe entry <name> [-n <namespace>]
+  kubectl dns lookup <dns name> [-n <namespace> | -A]
+  kubectl dns providers status [-n <namespace> | -A]
+  kubectl dns plan <entry name> [-n <namespace>] [--server <url> --token-file <file>]
+
+Common flags:
+  --kubeconfig <file>   path of the kubeconfig file
+  --context <name>      name of the kubeconfig context to use
+  -n, --namespace <ns>  namespace (default: namespace of the kubeconfig context)
+  -A                    all namespaces (list, lookup and providers status)
+`
+
+// kubectl-dns is a kubectl plugin rendering the view of the DNS controller manager
+// on DNS entries and providers (zone assignment, ownership and pending changes).
+func main() {
+	args := os.Args[1:]
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Print(usage)
+		return
+	}
+	var err error
+	switch {
+	case matchCommand(args, "list", "entries"):
+		err = runListEntries(args[2:])
+	case matchCommand(args, "describe", "entry"):
+		err = runDescribeEntry(args[2:])
+	case matchCommand(args, "lookup"):
+		err = runLookup(args[1:])
+	case matchCommand(args, "providers", "status"):
+		err = runProvidersStatus(args[2:])
+	case matchCommand(args, "plan"):
+		err = runPlan(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", strings.Join(args, " "), usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func matchCommand(args []string, command ...string) bool {
+	if len(args) < len(command) {
+		return false
+	}
+	for i, c := range command {
+		if args[i] != c {
+			return false
+		}
+	}
+	return true
+}
+
+// options are the flags common to all commands.
+type options struct {
+	flags         *flag.FlagSet
+	kubeconfig    string
+	context       string
+	namespace     string
+	allNamespaces bool
+}
+
+func newOptions(name string) *options {
+	o := &options{flags: flag.NewFlagSet("kubectl dns "+name, flag.ExitOnError)}
+	o.flags.StringVar(&o.kubeconfig, "kubeconfig", "", "path of the kubeconfig file")
+	o.flags.StringVar(&o.context, "context", "", "name of the kubeconfig context to use")
+	o.flags.StringVar(&o.namespace, "namespace", "", "namespace (default: namespace of the kubeconfig context)")
+	o.flags.StringVar(&o.namespace, "n", "", "namespace (shorthand)")
+	o.flags.BoolVar(&o.allNamespaces, "A", false, "all namespaces")
+	return o
+}
+
+// parse parses the flags, which may be given before and after the positional arguments.
+func (o *options) parse(args []string) ([]string, error) {
+	positional := []string{}
+	for {
+		if err := o.flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = o.flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// client creates the client for the DNS resources and determines the namespace to use
+// (empty for all namespaces).
+func (o *options) client() (dnsv1alpha1.DnsV1alpha1Interface, string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = o.kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: o.context}
+	cfg := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+	restConfig, err := cfg.ClientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	cs, err := versioned.NewForConfig(restConfig)
+	if err != nil {
+		return nil, "", err
+	}
+	namespace := o.namespace
+	if o.allNamespaces {
+		namespace = ""
+	} else if namespace == "" {
+		namespace, _, err = cfg.Namespace()
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	return cs.DnsV1alpha1(), namespace, nil
+}
+
+func (o *options) singleArg(args []string, what string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("exactly one %s expected", what)
+	}
+	return args[0], nil
+}
+
+var ctx = context.Background()
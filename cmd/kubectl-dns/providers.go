@@ -0,0 +1,70 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+func runProvidersStatus(args []string) error {
+	o := newOptions("providers status")
+	if _, err := o.parse(args); err != nil {
+		return err
+	}
+	client, namespace, err := o.client()
+	if err != nil {
+		return err
+	}
+	providers, err := listProviders(client, namespace)
+	if err != nil {
+		return err
+	}
+	// entries of all namespaces may use a provider, the count is omitted if they cannot be listed
+	counts := map[string]int{}
+	entries, err := listEntries(client, "")
+	if err == nil {
+		for _, e := range entries {
+			if e.Status.Provider != nil {
+				counts[*e.Status.Provider]++
+			}
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tTYPE\tSTATE\tPRIORITY\tDOMAINS\tZONES\tENTRIES\tMESSAGE")
+	for i := range providers {
+		p := providerMatch{provider: &providers[i]}
+		count := "-"
+		if err == nil {
+			count = fmt.Sprintf("%d", counts[p.name()])
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n", p.provider.Namespace, p.provider.Name, p.provider.Spec.Type,
+			deref(&p.provider.Status.State, "-"), p.priority(), list(p.provider.Status.Domains.Included),
+			list(p.provider.Status.Zones.Included), count, deref(p.provider.Status.Message, ""))
+	}
+	return w.Flush()
+}
+
+func list(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ",")
+}
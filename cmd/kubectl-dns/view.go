@@ -0,0 +1,142 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/client/dns/clientset/versioned/typed/dns/v1alpha1"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// providerMatch is a provider responsible for a DNS name.
+type providerMatch struct {
+	provider *api.DNSProvider
+	length   int
+}
+
+func (m providerMatch) name() string {
+	return objectName(&m.provider.ObjectMeta)
+}
+
+func (m providerMatch) priority() int {
+	if m.provider.Spec.Priority == nil {
+		return 0
+	}
+	return *m.provider.Spec.Priority
+}
+
+func objectName(meta *metav1.ObjectMeta) string {
+	return meta.Namespace + "/" + meta.Name
+}
+
+func normalizeDNSName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+func listEntries(client dnsv1alpha1.DnsV1alpha1Interface, namespace string) ([]api.DNSEntry, error) {
+	list, err := client.DNSEntries(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return objectName(&list.Items[i].ObjectMeta) < objectName(&list.Items[j].ObjectMeta)
+	})
+	return list.Items, nil
+}
+
+func listProviders(client dnsv1alpha1.DnsV1alpha1Interface, namespace string) ([]api.DNSProvider, error) {
+	list, err := client.DNSProviders(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return objectName(&list.Items[i].ObjectMeta) < objectName(&list.Items[j].ObjectMeta)
+	})
+	return list.Items, nil
+}
+
+// responsibleProviders returns the ready providers responsible for a DNS name used in the given namespace
+// in the order of preference of the DNS controller (higher priority first, then longer matching domain).
+// The optional provider name restricts the result to this provider, an empty namespace
+// ignores the allowed namespaces of the providers. Providers with a namespace selector are kept,
+// as the labels of the namespace are not evaluated.
+func responsibleProviders(dnsName, namespace string, restriction *string, providers []api.DNSProvider) []providerMatch {
+	dnsName = normalizeDNSName(dnsName)
+	matches := []providerMatch{}
+	for i := range providers {
+		p := &providers[i]
+		if p.Status.State != api.STATE_READY {
+			continue
+		}
+		if restriction != nil && *restriction != "" && *restriction != objectName(&p.ObjectMeta) && *restriction != p.Name {
+			continue
+		}
+		if namespace != "" && p.Namespace != namespace && len(p.Spec.AllowedNamespaces) > 0 && p.Spec.AllowedNamespaceSelector == nil && !utils.NewStringSet(p.Spec.AllowedNamespaces...).Contains(namespace) {
+			continue
+		}
+		ilen := dnsutils.MatchSet(dnsName, utils.NewStringSet(p.Status.Domains.Included...))
+		elen := dnsutils.MatchSet(dnsName, utils.NewStringSet(p.Status.Domains.Excluded...))
+		if ilen > elen {
+			matches = append(matches, providerMatch{provider: p, length: ilen})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].priority() != matches[j].priority() {
+			return matches[i].priority() > matches[j].priority()
+		}
+		return matches[i].length > matches[j].length
+	})
+	return matches
+}
+
+// ownerState describes the state of an owner id as seen by the DNS controller.
+func ownerState(client dnsv1alpha1.DnsV1alpha1Interface, ownerId string) string {
+	list, err := client.DNSOwners("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "unknown (" + err.Error() + ")"
+	}
+	for _, o := range list.Items {
+		if o.Spec.OwnerId != ownerId {
+			continue
+		}
+		switch {
+		case o.Spec.Active != nil && !*o.Spec.Active:
+			return "inactive (DNSOwner " + o.Name + ")"
+		case o.Spec.ValidFrom != nil && o.Spec.ValidFrom.Time.After(time.Now()):
+			return "inactive until " + o.Spec.ValidFrom.Time.Format(time.RFC3339) + " (DNSOwner " + o.Name + ")"
+		case o.Spec.ValidUntil != nil && o.Spec.ValidUntil.Time.Before(time.Now()):
+			return "expired at " + o.Spec.ValidUntil.Time.Format(time.RFC3339) + " (DNSOwner " + o.Name + ")"
+		default:
+			return "active (DNSOwner " + o.Name + ")"
+		}
+	}
+	return "no DNSOwner object (active if it is the identifier of the DNS controller)"
+}
+
+func deref(s *string, def string) string {
+	if s == nil || *s == "" {
+		return def
+	}
+	return *s
+}
@@ -0,0 +1,288 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/alicloud"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/aws"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/azure"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/azure-private"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/cloudflare"
+	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/google"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/infoblox"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/netlify"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/openstack"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/selection"
+)
+
+type options struct {
+	from, fromSecret string
+	to, toSecret     string
+	zone, targetZone string
+	ownerIds         string
+	newOwnerId       string
+	flatten          bool
+	move             bool
+	dryRun           bool
+}
+
+// migrate-records copies (or moves) the managed record sets of a hosted zone
+// from one DNS provider to a hosted zone of another DNS provider.
+func main() {
+	opts := &options{}
+	flag.StringVar(&opts.from, "from", "", "file containing the source DNSProvider manifest (may also contain the secret)")
+	flag.StringVar(&opts.fromSecret, "from-secret", "", "file containing the secret manifest of the source provider")
+	flag.StringVar(&opts.to, "to", "", "file containing the target DNSProvider manifest (may also contain the secret)")
+	flag.StringVar(&opts.toSecret, "to-secret", "", "file containing the secret manifest of the target provider")
+	flag.StringVar(&opts.zone, "zone", "", "id of the source hosted zone")
+	flag.StringVar(&opts.targetZone, "target-zone", "", "id of the target hosted zone (default: the included zone of the target provider with the domain of the source zone)")
+	flag.StringVar(&opts.ownerIds, "owner-ids", "", "comma separated owner ids of the record sets to migrate (default: all managed record sets)")
+	flag.StringVar(&opts.newOwnerId, "new-owner-id", "", "owner id of the migrated record sets (default: unchanged)")
+	flag.BoolVar(&opts.flatten, "flatten", false, "merge weighted record sets into a single record set if the target does not support the routing policy")
+	flag.BoolVar(&opts.move, "move", false, "delete the migrated record sets in the source zone")
+	flag.BoolVar(&opts.dryRun, "dry-run", false, "only print the planned changes")
+	flag.Parse()
+
+	if opts.from == "" || opts.to == "" || opts.zone == "" || flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	ok, err := migrate(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(2)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+func migrate(opts *options) (bool, error) {
+	log := logger.New()
+	source, sourceZone, err := open(log, opts.from, opts.fromSecret, func(z provider.DNSHostedZone) bool {
+		return z.Id().ID == opts.zone
+	})
+	if err != nil {
+		return false, fmt.Errorf("source: %w", err)
+	}
+	defer source.Release()
+	target, targetZone, err := open(log, opts.to, opts.toSecret, func(z provider.DNSHostedZone) bool {
+		if opts.targetZone != "" {
+			return z.Id().ID == opts.targetZone
+		}
+		return z.Domain() == sourceZone.Domain()
+	})
+	if err != nil {
+		return false, fmt.Errorf("target: %w", err)
+	}
+	defer target.Release()
+	fmt.Printf("migrating %s/%s (%s) to %s/%s (%s)\n", sourceZone.Id().ProviderType, sourceZone.Id().ID, sourceZone.Domain(),
+		targetZone.Id().ProviderType, targetZone.Id().ID, targetZone.Domain())
+
+	sourceState, err := source.GetZoneState(sourceZone)
+	if err != nil {
+		return false, fmt.Errorf("reading source zone: %w", err)
+	}
+	targetState, err := target.GetZoneState(targetZone)
+	if err != nil {
+		return false, fmt.Errorf("reading target zone: %w", err)
+	}
+
+	sets := dns.DNSSets{}
+	outside := []string{}
+	for name, set := range sourceState.GetDNSSets() {
+		if targetZone.Match(name.DNSName) > 0 {
+			sets[name] = set
+		} else if set.GetOwner() != "" {
+			outside = append(outside, name.String())
+		}
+	}
+	migrationOpts := provider.MigrationOptions{
+		NewOwnerId: opts.newOwnerId,
+		Flatten:    opts.flatten,
+		Target:     target,
+	}
+	if opts.ownerIds != "" {
+		migrationOpts.OwnerIds = utils.NewStringSetByArray(strings.Split(opts.ownerIds, ","))
+	}
+	plan := provider.PlanMigration(sets, targetState.GetDNSSets(), migrationOpts)
+	printPlan(plan, outside)
+	if opts.dryRun {
+		return true, nil
+	}
+
+	ok := true
+	var reqs []*provider.ChangeRequest
+	dones := map[*provider.Migration]*doneHandler{}
+	for _, m := range plan.Migrations {
+		done := &doneHandler{}
+		dones[m] = done
+		for ty := range m.Target.Sets {
+			reqs = append(reqs, provider.NewChangeRequest(provider.R_CREATE, ty, nil, m.Target, done))
+		}
+	}
+	if len(reqs) > 0 {
+		if err := target.ExecuteRequests(log, targetZone, targetState, reqs); err != nil {
+			fmt.Printf("error: creating record sets in target zone: %s\n", err)
+			ok = false
+		}
+	}
+	migrated := []*dns.DNSSet{}
+	for _, m := range plan.Migrations {
+		if err := dones[m].err; err != nil {
+			fmt.Printf("FAIL  create %s: %s\n", m.Target.Name, err)
+			ok = false
+			continue
+		}
+		fmt.Printf("ok    create %s\n", m.Target.Name)
+		migrated = append(migrated, m.Sources...)
+	}
+	if !opts.move {
+		return ok, nil
+	}
+
+	for _, name := range plan.Unchanged {
+		// unchanged record sets keep their name, as they are only detected for matching names
+		migrated = append(migrated, sourceState.GetDNSSets()[name])
+	}
+	reqs = nil
+	deletions := map[dns.DNSSetName]*doneHandler{}
+	for _, set := range migrated {
+		if set == nil {
+			continue
+		}
+		done := &doneHandler{}
+		deletions[set.Name] = done
+		for ty := range set.Sets {
+			reqs = append(reqs, provider.NewChangeRequest(provider.R_DELETE, ty, set, nil, done))
+		}
+	}
+	if len(reqs) > 0 {
+		if err := source.ExecuteRequests(log, sourceZone, sourceState, reqs); err != nil {
+			fmt.Printf("error: deleting record sets in source zone: %s\n", err)
+			ok = false
+		}
+	}
+	names := make([]dns.DNSSetName, 0, len(deletions))
+	for name := range deletions {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i].String() < names[j].String() })
+	for _, name := range names {
+		if err := deletions[name].err; err != nil {
+			fmt.Printf("FAIL  delete %s in source zone: %s\n", name, err)
+			ok = false
+		} else {
+			fmt.Printf("ok    delete %s in source zone\n", name)
+		}
+	}
+	return ok, nil
+}
+
+// open creates the handler for a provider manifest and selects an included hosted zone.
+func open(log logger.LogContext, file, secretFile string, match func(z provider.DNSHostedZone) bool) (provider.DNSHandler, provider.DNSHostedZone, error) {
+	files := []string{file}
+	if secretFile != "" {
+		files = append(files, secretFile)
+	}
+	p, props, err := provider.ReadProviderManifests(files...)
+	if err != nil {
+		return nil, nil, err
+	}
+	handler, err := provider.NewStandaloneHandler(context.Background(), log, compound.Factory, p.Spec.Type, props, p.Spec.ProviderConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create handler: %w", err)
+	}
+	zone, err := selectZone(handler, p, match)
+	if err != nil {
+		handler.Release()
+		return nil, nil, err
+	}
+	return handler, zone, nil
+}
+
+func selectZone(handler provider.DNSHandler, p *api.DNSProvider, match func(z provider.DNSHostedZone) bool) (provider.DNSHostedZone, error) {
+	zones, err := handler.GetZones()
+	if err != nil {
+		return nil, fmt.Errorf("list hosted zones: %w", err)
+	}
+	lzones := make([]selection.LightDNSHostedZone, len(zones))
+	for i, z := range zones {
+		lzones[i] = z
+	}
+	result := selection.CalcZoneAndDomainSelection(p.Spec, lzones)
+	for _, z := range zones {
+		if result.ZoneSel.Include.Contains(z.Id().ID) && match(z) {
+			return z, nil
+		}
+	}
+	return nil, fmt.Errorf("no matching hosted zone included by provider %s", p.Name)
+}
+
+func printPlan(plan *provider.MigrationPlan, outside []string) {
+	for _, m := range plan.Migrations {
+		types := []string{}
+		for ty := range m.Target.Sets {
+			types = append(types, ty)
+		}
+		sort.Strings(types)
+		fmt.Printf("plan  create %s (%s)", m.Target.Name, strings.Join(types, ", "))
+		if m.Note != "" {
+			fmt.Printf(": %s", m.Note)
+		}
+		fmt.Println()
+	}
+	for _, name := range plan.Unchanged {
+		fmt.Printf("      unchanged %s\n", name)
+	}
+	skipped := make([]string, 0, len(plan.Skipped))
+	for name, reason := range plan.Skipped {
+		skipped = append(skipped, fmt.Sprintf("%s: %s", name, reason))
+	}
+	sort.Strings(skipped)
+	sort.Strings(outside)
+	for _, name := range outside {
+		skipped = append(skipped, fmt.Sprintf("%s: not in target zone", name))
+	}
+	for _, s := range skipped {
+		fmt.Printf("skip  %s\n", s)
+	}
+	fmt.Printf("%d record sets to create, %d unchanged, %d skipped\n", len(plan.Migrations), len(plan.Unchanged), len(skipped))
+}
+
+type doneHandler struct {
+	err error
+}
+
+func (this *doneHandler) SetInvalid(err error) { this.err = err }
+func (this *doneHandler) Failed(err error)     { this.err = err }
+func (this *doneHandler) Throttled()           { this.err = fmt.Errorf("throttled") }
+func (this *doneHandler) Succeeded()           {}
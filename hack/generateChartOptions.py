@@ -29,7 +29,7 @@ def toCamelCase(name):
   return str
 
 excluded = {"name", "help", "identifier", "dry-run"}
-excludedPattern = [re.compile(".*cache-dir$"), re.compile(".*blocked-zone$"), re.compile(".*remote-access-.+")]
+excludedPattern = [re.compile(".*cache-dir$"), re.compile(".*blocked-zone$"), re.compile(".*remote-access-.+"), re.compile(".*admission-webhook-.+")]
 
 def isExcluded(name):
   if name == "" or name in excluded:
@@ -58,6 +58,10 @@ spec:
     - jsonPath: .spec.resourceRef.namespace
       name: RefNamespace
       type: string
+    - jsonPath: .spec.resourceSelector.kind
+      name: SelectorKind
+      priority: 2000
+      type: string
     - jsonPath: .status.active
       name: Active
       type: boolean
@@ -87,6 +91,8 @@ spec:
                   type: string
                 type: object
               resourceRef:
+                description: reference to a single annotated object, either resourceRef
+                  or resourceSelector must be specified
                 properties:
                   apiVersion:
                     description: API Version of the annotated object
@@ -105,9 +111,72 @@ spec:
                 - apiVersion
                 - kind
                 type: object
+              resourceSelector:
+                description: selector for a set of annotated objects, either resourceRef
+                  or resourceSelector must be specified
+                properties:
+                  apiVersion:
+                    description: API Version of the annotated objects
+                    type: string
+                  kind:
+                    description: 'Kind of the annotated objects More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+                    type: string
+                  labelSelector:
+                    description: Label selector for the annotated objects. If not
+                      specified, all objects of the given kind in the namespace are
+                      selected.
+                    properties:
+                      matchExpressions:
+                        description: matchExpressions is a list of label selector
+                          requirements. The requirements are ANDed.
+                        items:
+                          description: A label selector requirement is a selector
+                            that contains values, a key, and an operator that relates
+                            the key and values.
+                          properties:
+                            key:
+                              description: key is the label key that the selector
+                                applies to.
+                              type: string
+                            operator:
+                              description: operator represents a key's relationship
+                                to a set of values. Valid operators are In, NotIn,
+                                Exists and DoesNotExist.
+                              type: string
+                            values:
+                              description: values is an array of string values. If
+                                the operator is In or NotIn, the values array must
+                                be non-empty. If the operator is Exists or DoesNotExist,
+                                the values array must be empty. This array is replaced
+                                during a strategic merge patch.
+                              items:
+                                type: string
+                              type: array
+                          required:
+                          - key
+                          - operator
+                          type: object
+                        type: array
+                      matchLabels:
+                        additionalProperties:
+                          type: string
+                        description: matchLabels is a map of {key,value} pairs. A
+                          single {key,value} in the matchLabels map is equivalent
+                          to an element of matchExpressions, whose key field is "key",
+                          the operator is "In", and the values array contains only
+                          "value". The requirements are ANDed.
+                        type: object
+                    type: object
+                  namespace:
+                    description: Namespace of the annotated objects Defaulted by the
+                      namespace of the containing resource.
+                    type: string
+                required:
+                - apiVersion
+                - kind
+                type: object
             required:
             - annotations
-            - resourceRef
             type: object
           status:
             properties:
@@ -119,6 +188,9 @@ spec:
                 description: In case of a configuration problem this field describes
                   the reason
                 type: string
+              observedGeneration:
+                format: int64
+                type: integer
             type: object
         required:
         - spec
@@ -143,77 +215,34 @@ metadata:
   annotations:
     controller-gen.kubebuilder.io/version: v0.8.0
   creationTimestamp: null
-  name: dnsentries.dns.gardener.cloud
+  name: dnschallenges.dns.gardener.cloud
 spec:
   group: dns.gardener.cloud
   names:
-    kind: DNSEntry
-    listKind: DNSEntryList
-    plural: dnsentries
+    kind: DNSChallenge
+    listKind: DNSChallengeList
+    plural: dnschallenges
     shortNames:
-    - dnse
-    singular: dnsentry
+    - dnsch
+    singular: dnschallenge
   scope: Namespaced
   versions:
   - additionalPrinterColumns:
-    - description: FQDN of DNS Entry
-      jsonPath: .spec.dnsName
+    - jsonPath: .spec.dnsName
       name: DNS
       type: string
-    - description: provider type
-      jsonPath: .status.providerType
-      name: TYPE
-      type: string
-    - description: assigned provider (namespace/name)
-      jsonPath: .status.provider
-      name: PROVIDER
-      type: string
-    - description: entry status
-      jsonPath: .status.state
+    - jsonPath: .status.state
       name: STATUS
       type: string
-    - description: entry creation timestamp
-      jsonPath: .metadata.creationTimestamp
-      name: AGE
+    - jsonPath: .status.propagated
+      name: Propagated
+      type: boolean
+    - jsonPath: .status.expiration
+      name: Expiration
+      type: date
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
       type: date
-    - description: effective targets
-      jsonPath: .status.targets
-      name: TARGETS
-      type: string
-    - description: owner id used to tag entries in external DNS system
-      jsonPath: .spec.ownerId
-      name: OWNERID
-      type: string
-    - description: time to live
-      jsonPath: .status.ttl
-      name: TTL
-      priority: 2000
-      type: integer
-    - description: zone id
-      jsonPath: .status.zone
-      name: ZONE
-      priority: 2000
-      type: string
-    - description: routing policy type
-      jsonPath: .status.routingPolicy.type
-      name: POLICY_TYPE
-      priority: 2000
-      type: string
-    - description: routing policy set identifier
-      jsonPath: .status.routingPolicy.setIdentifier
-      name: POLICY_SETID
-      priority: 2000
-      type: string
-    - description: routing policy parameters
-      jsonPath: .status.routingPolicy.parameters
-      name: POLICY_PARAMS
-      priority: 2000
-      type: string
-    - description: message describing the reason for the state
-      jsonPath: .status.message
-      name: MESSAGE
-      priority: 2000
-      type: string
     name: v1alpha1
     schema:
       openAPIV3Schema:
@@ -232,72 +261,119 @@ spec:
             type: object
           spec:
             properties:
-              cnameLookupInterval:
-                description: lookup interval for CNAMEs that must be resolved to IP
-                  addresses
-                format: int64
-                type: integer
               dnsName:
-                description: full qualified domain name
+                description: full qualified domain name of the challenge record,
+                  it must start with _acme-challenge.
                 type: string
+              lifetimeSeconds:
+                description: 'lifetime of the challenge in seconds, the challenge
+                  is deleted afterwards (default: 3600)'
+                format: int64
+                minimum: 1
+                type: integer
               ownerId:
-                description: owner id used to tag entries in external DNS system
+                description: owner id used to tag the record in external DNS system
+                type: string
+              provider:
+                description: optional provider (namespace/name) to use exclusively
+                  for the record
                 type: string
-              reference:
-                description: reference to base entry used to inherit attributes from
-                properties:
-                  name:
-                    description: name of the referenced DNSEntry object
-                    type: string
-                  namespace:
-                    description: namespace of the referenced DNSEntry object
-                    type: string
-                required:
-                - name
-                type: object
-              routingPolicy:
-                description: optional routing policy
-                properties:
-                  parameters:
-                    additionalProperties:
-                      type: string
-                    description: Policy specific parameters
-                    type: object
-                  setIdentifier:
-                    description: SetIdentifier is the identifier of the record set
-                    type: string
-                  type:
-                    description: Policy is the policy type. Allowed values are provider
-                      dependent, e.g. ` + "`" + `weighted` + "`" + `
-                    type: string
-                required:
-                - parameters
-                - setIdentifier
-                - type
-                type: object
-              targets:
-                description: target records (CNAME or A records), either text or targets
-                  must be specified
-                items:
-                  type: string
-                type: array
-              text:
-                description: text records, either text or targets must be specified
-                items:
-                  type: string
-                type: array
               ttl:
-                description: time to live for records in external DNS system
+                description: 'time to live of the TXT record (default: 60)'
                 format: int64
                 type: integer
+              values:
+                description: values of the TXT record (e.g. the digests of the key
+                  authorizations)
+                items:
+                  type: string
+                minItems: 1
+                type: array
+              zone:
+                description: optional id of the hosted zone to use exclusively for
+                  the record
+                type: string
             required:
             - dnsName
+            - values
             type: object
           status:
             properties:
-              lastUpdateTime:
-                description: lastUpdateTime contains the timestamp of the last status
-                  update
+              conditions:
+                description: conditions ` + "`" + `Ready` + "`" + `, ` + "`" + `Reconciling` + "`" + ` and ` + "`" + `Stalled` + "`" + ` of the
+                  challenge
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    ` + "`" + `json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"` + "`" + ` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
+              expiration:
+                description: time after which the challenge is deleted
                 format: date-time
                 type: string
               message:
@@ -306,46 +382,13 @@ spec:
               observedGeneration:
                 format: int64
                 type: integer
-              provider:
-                description: assigned provider
-                type: string
-              providerType:
-                description: provider type used for the entry
-                type: string
-              routingPolicy:
-                description: effective routing policy
-                properties:
-                  parameters:
-                    additionalProperties:
-                      type: string
-                    description: Policy specific parameters
-                    type: object
-                  setIdentifier:
-                    description: SetIdentifier is the identifier of the record set
-                    type: string
-                  type:
-                    description: Policy is the policy type. Allowed values are provider
-                      dependent, e.g. ` + "`" + `weighted` + "`" + `
-                    type: string
-                required:
-                - parameters
-                - setIdentifier
-                - type
-                type: object
+              propagated:
+                description: true if the record is resolvable at the authoritative
+                  name servers
+                type: boolean
               state:
-                description: entry state
-                type: string
-              targets:
-                description: effective targets generated for the entry
-                items:
-                  type: string
-                type: array
-              ttl:
-                description: time to live used for the entry
-                format: int64
-                type: integer
-              zone:
-                description: zone used for the entry
+                description: state of the challenge, Ready if the record is applied
+                  and propagated
                 type: string
             type: object
         required:
@@ -371,28 +414,39 @@ metadata:
   annotations:
     controller-gen.kubebuilder.io/version: v0.8.0
   creationTimestamp: null
-  name: dnshostedzonepolicies.dns.gardener.cloud
+  name: dnsclassprofiles.dns.gardener.cloud
 spec:
   group: dns.gardener.cloud
   names:
-    kind: DNSHostedZonePolicy
-    listKind: DNSHostedZonePolicyList
-    plural: dnshostedzonepolicies
+    kind: DNSClassProfile
+    listKind: DNSClassProfileList
+    plural: dnsclassprofiles
     shortNames:
-    - dnshzp
-    singular: dnshostedzonepolicy
+    - dnscp
+    singular: dnsclassprofile
   scope: Cluster
   versions:
   - additionalPrinterColumns:
-    - jsonPath: .status.count
-      name: Zone Count
+    - jsonPath: .spec.ttl
+      name: TTL
       type: integer
+    - jsonPath: .spec.ownerId
+      name: OWNERID
+      type: string
+    - jsonPath: .spec.dryRun
+      name: DRYRUN
+      type: boolean
+    - jsonPath: .status.state
+      name: STATUS
+      type: string
     - jsonPath: .metadata.creationTimestamp
-      name: Age
+      name: AGE
       type: date
     name: v1alpha1
     schema:
       openAPIV3Schema:
+        description: DNSClassProfile is the configuration profile of a DNS class.
+          The name of the object is the DNS class the profile is applied to.
         properties:
           apiVersion:
             description: 'APIVersion defines the versioned schema of this representation
@@ -407,74 +461,124 @@ spec:
           metadata:
             type: object
           spec:
+            description: DNSClassProfileSpec specifies the settings for all entries
+              of a DNS class
             properties:
-              policy:
-                description: ZonePolicy specifies zone specific policy
-                properties:
-                  zoneStateCacheTTL:
-                    description: ZoneStateCacheTTL specifies the TTL for the zone
-                      state cache
-                    type: string
-                type: object
-              selector:
-                description: ZoneSelector specifies the selector for the DNS hosted
-                  zones
-                properties:
-                  domainNames:
-                    description: DomainNames selects by base domain name of hosted
-                      zone. Policy will be applied to zones with matching base domain
-                    items:
+              allowedProviders:
+                description: AllowedProviders restricts the providers usable by entries
+                  of the class. Providers are given as <namespace>/<name>, all providers
+                  are allowed if empty.
+                items:
+                  type: string
+                type: array
+              dryRun:
+                description: DryRun prevents changes of DNS records for all entries
+                  of the class
+                type: boolean
+              duplicatePolicy:
+                description: DuplicatePolicy specifies the handling of entries of
+                  the class claiming the same DNS name, a policy of the hosted zone
+                  takes precedence
+                enum:
+                - reject-newer
+                - reject-older
+                - merge-targets
+                - priority-by-annotation
+                type: string
+              ownerId:
+                description: OwnerId is the default owner id for entries without
+                  owner id
+                type: string
+              ttl:
+                description: TTL is the default time to live for entries without
+                  TTL
+                format: int64
+                type: integer
+            type: object
+          status:
+            properties:
+              conditions:
+                description: conditions ` + "`" + `Ready` + "`" + `, ` + "`" + `Reconciling` + "`" + ` and ` + "`" + `Stalled` + "`" + ` of the
+                  class profile
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    ` + "`" + `json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"` + "`" + ` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
                       type: string
-                    type: array
-                  providerTypes:
-                    description: ProviderTypes selects by provider types
-                    items:
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
                       type: string
-                    type: array
-                  zoneIDs:
-                    description: ZoneIDs selects by provider dependent zone ID
-                    items:
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
                       type: string
-                    type: array
-                type: object
-            required:
-            - policy
-            - selector
-            type: object
-          status:
-            properties:
-              count:
-                description: Number of zones this policy is applied to
-                type: integer
-              lastStatusUpdateTime:
-                description: LastStatusUpdateTime contains the timestamp of the last
-                  status update
-                format: date-time
-                type: string
-              message:
-                description: In case of a configuration problem this field describes
-                  the reason
-                type: string
-              zones:
-                description: Indicates that annotation is observed by a DNS sorce
-                  controller
-                items:
-                  properties:
-                    domainName:
-                      description: Domain name of the zone
-                      type: string
-                    providerType:
-                      description: Provider type of the zone
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
                       type: string
-                    zoneID:
-                      description: ID of the zone
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
                       type: string
                   required:
-                  - domainName
-                  - providerType
-                  - zoneID
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
                   type: object
                 type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
+              message:
+                description: message describing the reason for the state
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+              state:
+                description: state of the profile
+                type: string
             type: object
         required:
         - spec
@@ -499,53 +603,39 @@ metadata:
   annotations:
     controller-gen.kubebuilder.io/version: v0.8.0
   creationTimestamp: null
-  name: dnslocks.dns.gardener.cloud
+  name: dnselections.dns.gardener.cloud
 spec:
   group: dns.gardener.cloud
   names:
-    kind: DNSLock
-    listKind: DNSLockList
-    plural: dnslocks
+    kind: DNSElection
+    listKind: DNSElectionList
+    plural: dnselections
     shortNames:
-    - dnsl
-    singular: dnslock
+    - dnsel
+    singular: dnselection
   scope: Namespaced
   versions:
   - additionalPrinterColumns:
-    - description: FQDN of DNS Entry
+    - description: FQDN of the election lock record
       jsonPath: .spec.dnsName
       name: DNS
       type: string
-    - description: provider type
-      jsonPath: .status.providerType
-      name: TYPE
-      type: string
-    - description: assigned provider (namespace/name)
-      jsonPath: .status.provider
-      name: PROVIDER
+    - description: current leader of the election
+      jsonPath: .status.leader
+      name: LEADER
       type: string
-    - description: entry status
+    - description: candidate of this cluster is the leader
+      jsonPath: .status.isLeader
+      name: ISLEADER
+      type: boolean
+    - description: election status
       jsonPath: .status.state
       name: STATUS
       type: string
-    - description: entry creation timestamp
+    - description: creation timestamp
       jsonPath: .metadata.creationTimestamp
       name: AGE
       type: date
-    - description: owner group id used to tag entries in external DNS system
-      jsonPath: .spec.ownerGroupId
-      name: OWNERID
-      type: string
-    - description: time to live
-      jsonPath: .status.ttl
-      name: TTL
-      priority: 2000
-      type: integer
-    - description: zone id
-      jsonPath: .status.zone
-      name: ZONE
-      priority: 2000
-      type: string
     - description: message describing the reason for the state
       jsonPath: .status.message
       name: MESSAGE
@@ -569,49 +659,131 @@ spec:
             type: object
           spec:
             properties:
-              attributes:
-                additionalProperties:
-                  type: string
-                description: attribute values (must be compatible with DNS TXT records)
-                type: object
+              candidate:
+                description: 'identity of the candidate of this cluster (default:
+                  identifier of the controller)'
+                type: string
               dnsName:
-                description: full qualified domain name
+                description: full qualified domain name of the lock record shared
+                  by all candidates
                 type: string
               lockId:
-                description: owner group for collaboration of multiple controller
-                type: string
-              timestamp:
-                description: Activation time stamp
-                format: date-time
+                description: lock id of the election, must be identical for all
+                  candidates
                 type: string
+              lookup:
+                description: Lookup overwrites the lookup of the lock records configured
+                  for the controller
+                properties:
+                  resolvers:
+                    description: Resolvers are the addresses (host[:port]) of the
+                      resolvers queried for the lock records
+                    items:
+                      type: string
+                    type: array
+                  retries:
+                    description: Retries is the number of retries of a failed lookup
+                    type: integer
+                  timeout:
+                    description: Timeout is the timeout of a single lookup
+                    type: string
+                type: object
               ttl:
-                description: time to live for records in external DNS system
+                description: time to live of the lock record, the leadership expires
+                  after three times the TTL without renewal
                 format: int64
                 type: integer
             required:
             - dnsName
-            - timestamp
-            - ttl
             type: object
           status:
             properties:
-              attributes:
-                additionalProperties:
-                  type: string
-                description: attribute values found in DNS
-                type: object
-              firstFailedDNSLookup:
-                description: First failed DNS looup
-                format: date-time
+              conditions:
+                description: conditions ` + "`" + `Ready` + "`" + `, ` + "`" + `Reconciling` + "`" + ` and ` + "`" + `Stalled` + "`" + ` of the
+                  election
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    ` + "`" + `json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"` + "`" + ` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
+              isLeader:
+                description: the candidate of this cluster is the current leader
+                type: boolean
+              leader:
+                description: identity of the current leader
                 type: string
-              lastUpdateTime:
-                description: lastUpdateTime contains the timestamp of the last status
-                  update
+              leaderTransitionTime:
+                description: time of the last change of the leader
                 format: date-time
                 type: string
-              lockId:
-                description: owner group for collaboration of multiple controller
-                  found in DNS
+              leaseExpiration:
+                description: expiration time of the leadership if not renewed
+                format: date-time
                 type: string
               message:
                 description: message describing the reason for the state
@@ -619,25 +791,8 @@ spec:
               observedGeneration:
                 format: int64
                 type: integer
-              provider:
-                description: assigned provider
-                type: string
-              providerType:
-                description: provider type used for the entry
-                type: string
               state:
-                description: entry state
-                type: string
-              timestamp:
-                description: Activation time stamp found in DNS
-                format: date-time
-                type: string
-              ttl:
-                description: time to live used for the entry
-                format: int64
-                type: integer
-              zone:
-                description: zone used for the entry
+                description: state of the election
                 type: string
             type: object
         required:
@@ -663,36 +818,2897 @@ metadata:
   annotations:
     controller-gen.kubebuilder.io/version: v0.8.0
   creationTimestamp: null
-  name: dnsowners.dns.gardener.cloud
+  name: dnsentries.dns.gardener.cloud
 spec:
   group: dns.gardener.cloud
   names:
-    kind: DNSOwner
-    listKind: DNSOwnerList
-    plural: dnsowners
+    kind: DNSEntry
+    listKind: DNSEntryList
+    plural: dnsentries
     shortNames:
-    - dnso
-    singular: dnsowner
-  scope: Cluster
+    - dnse
+    singular: dnsentry
+  scope: Namespaced
   versions:
   - additionalPrinterColumns:
-    - jsonPath: .spec.ownerId
-      name: OwnerId
+    - description: FQDN of DNS Entry
+      jsonPath: .spec.dnsName
+      name: DNS
       type: string
-    - jsonPath: .status.active
-      name: Active
-      type: boolean
-    - jsonPath: .status.entries.amount
-      name: Usages
+    - description: provider type
+      jsonPath: .status.providerType
+      name: TYPE
+      type: string
+    - description: assigned provider (namespace/name)
+      jsonPath: .status.provider
+      name: PROVIDER
+      type: string
+    - description: entry status
+      jsonPath: .status.state
+      name: STATUS
+      type: string
+    - description: entry creation timestamp
+      jsonPath: .metadata.creationTimestamp
+      name: AGE
+      type: date
+    - description: effective targets
+      jsonPath: .status.targets
+      name: TARGETS
+      type: string
+    - description: owner id used to tag entries in external DNS system
+      jsonPath: .spec.ownerId
+      name: OWNERID
+      type: string
+    - description: time to live
+      jsonPath: .status.ttl
+      name: TTL
+      priority: 2000
       type: integer
-    - description: expiration date
-      format: date-time
-      jsonPath: .spec.validUntil
-      name: Valid
+    - description: zone id
+      jsonPath: .status.zone
+      name: ZONE
+      priority: 2000
+      type: string
+    - description: routing policy type
+      jsonPath: .status.routingPolicy.type
+      name: POLICY_TYPE
+      priority: 2000
+      type: string
+    - description: routing policy set identifier
+      jsonPath: .status.routingPolicy.setIdentifier
+      name: POLICY_SETID
+      priority: 2000
+      type: string
+    - description: routing policy parameters
+      jsonPath: .status.routingPolicy.parameters
+      name: POLICY_PARAMS
+      priority: 2000
+      type: string
+    - description: message describing the reason for the state
+      jsonPath: .status.message
+      name: MESSAGE
+      priority: 2000
+      type: string
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              activateAt:
+                description: optional time to create the DNS records of the entry,
+                  they are not created before
+                format: date-time
+                type: string
+              cnameLookupInterval:
+                description: lookup interval for CNAMEs that must be resolved to IP
+                  addresses
+                format: int64
+                type: integer
+              deactivateAt:
+                description: optional time to delete the DNS records of the entry,
+                  the entry itself is kept
+                format: date-time
+                type: string
+              deletionPolicy:
+                description: 'policy for the DNS records on deletion of the entry:
+                  ` + "`" + `Delete` + "`" + ` (default) deletes the records, ` + "`" + `Retain` + "`" + ` keeps them and
+                  only removes the ownership record'
+                enum:
+                - Delete
+                - Retain
+                type: string
+              dnsName:
+                description: full qualified domain name
+                type: string
+              lifetimeSeconds:
+                description: optional lifetime of the entry in seconds after its
+                  creation, the entry and its DNS records are deleted by the controller
+                  afterwards (not related to the TTL of the records)
+                format: int64
+                minimum: 1
+                type: integer
+              ownerId:
+                description: owner id used to tag entries in external DNS system
+                type: string
+              provider:
+                description: optional provider (namespace/name) to use exclusively
+                  for this entry, if several providers are responsible for the domain
+                type: string
+              reference:
+                description: reference to base entry used to inherit attributes from
+                properties:
+                  name:
+                    description: name of the referenced DNSEntry object
+                    type: string
+                  namespace:
+                    description: namespace of the referenced DNSEntry object
+                    type: string
+                required:
+                - name
+                type: object
+              resolveTargetsToAddresses:
+                description: if true, hostname targets are resolved to their IP addresses
+                  (A/AAAA records) instead of using CNAME records. The addresses are
+                  refreshed with the CNAME lookup interval.
+                type: boolean
+              routingPolicy:
+                description: optional routing policy
+                properties:
+                  parameters:
+                    additionalProperties:
+                      type: string
+                    description: Policy specific parameters
+                    type: object
+                  setIdentifier:
+                    description: SetIdentifier is the identifier of the record set
+                    type: string
+                  type:
+                    description: Policy is the policy type. Allowed values are provider
+                      dependent, e.g. ` + "`" + `weighted` + "`" + `
+                    type: string
+                required:
+                - parameters
+                - setIdentifier
+                - type
+                type: object
+              targetRef:
+                description: reference to a cluster object (Service, Node, Gateway
+                  or DNSEntry) whose addresses are used as targets
+                properties:
+                  apiVersion:
+                    description: API version of the referenced object, defaults to
+                      ` + "`" + `v1` + "`" + ` for kinds ` + "`" + `Service` + "`" + ` and ` + "`" + `Node` + "`" + `
+                    type: string
+                  kind:
+                    description: kind of the referenced object (` + "`" + `Service` + "`" + `, ` + "`" + `Node` + "`" + `,
+                      ` + "`" + `Gateway` + "`" + ` or ` + "`" + `DNSEntry` + "`" + `)
+                    type: string
+                  name:
+                    description: name of the referenced object
+                    type: string
+                  namespace:
+                    description: namespace of the referenced object, defaults to the
+                      namespace of the entry
+                    type: string
+                required:
+                - kind
+                - name
+                type: object
+              targets:
+                description: target records (CNAME or A records), either text or targets
+                  must be specified
+                items:
+                  type: string
+                type: array
+              text:
+                description: text records, either text or targets must be specified
+                items:
+                  type: string
+                type: array
+              ttl:
+                description: time to live for records in external DNS system
+                format: int64
+                type: integer
+              zone:
+                description: optional id of the hosted zone to use exclusively for
+                  this entry, if the domain is served by several zones (e.g. a public
+                  and a private zone)
+                type: string
+            required:
+            - dnsName
+            type: object
+          status:
+            properties:
+              conditions:
+                description: conditions of the entry, the condition ` + "`" + `Ready` + "`" + ` reports
+                  whether the records are applied and propagated (see also ` + "`" + `Reconciling` + "`" + `
+                  and ` + "`" + `Stalled` + "`" + `), the condition ` + "`" + `Propagated` + "`" + ` reports the resolution
+                  of the records by the name servers (requires option --propagation-check-timeout)
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    ` + "`" + `json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"` + "`" + ` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
+              lastUpdateTime:
+                description: lastUpdateTime contains the timestamp of the last status
+                  update
+                format: date-time
+                type: string
+              message:
+                description: message describing the reason for the state
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+              provider:
+                description: assigned provider
+                type: string
+              providerRecords:
+                description: record sets as applied at the provider by the last successful
+                  reconciliation (without the ownership records of the controller)
+                properties:
+                  dnsName:
+                    description: DNS name of the record sets
+                    type: string
+                  recordSets:
+                    description: record sets by record type
+                    items:
+                      description: ProviderRecordSet describes a record set as applied
+                        at the provider
+                      properties:
+                        ttl:
+                          description: time to live of the record set
+                          format: int64
+                          type: integer
+                        type:
+                          description: record type (A, AAAA, CNAME, TXT)
+                          type: string
+                        values:
+                          description: values of the records
+                          items:
+                            type: string
+                          type: array
+                      required:
+                      - ttl
+                      - type
+                      - values
+                      type: object
+                    type: array
+                  routingPolicy:
+                    description: routing policy of the record sets
+                    properties:
+                      parameters:
+                        additionalProperties:
+                          type: string
+                        description: Policy specific parameters
+                        type: object
+                      setIdentifier:
+                        description: SetIdentifier is the identifier of the record
+                          set
+                        type: string
+                      type:
+                        description: Policy is the policy type. Allowed values are
+                          provider dependent, e.g. ` + "`" + `weighted` + "`" + `
+                        type: string
+                    required:
+                    - parameters
+                    - setIdentifier
+                    - type
+                    type: object
+                  setIdentifier:
+                    description: identifier of the record sets used by the routing
+                      policy
+                    type: string
+                  zone:
+                    description: id of the hosted zone containing the records
+                    type: string
+                required:
+                - dnsName
+                - zone
+                type: object
+              providerType:
+                description: provider type used for the entry
+                type: string
+              routingPolicy:
+                description: effective routing policy
+                properties:
+                  parameters:
+                    additionalProperties:
+                      type: string
+                    description: Policy specific parameters
+                    type: object
+                  setIdentifier:
+                    description: SetIdentifier is the identifier of the record set
+                    type: string
+                  type:
+                    description: Policy is the policy type. Allowed values are provider
+                      dependent, e.g. ` + "`" + `weighted` + "`" + `
+                    type: string
+                required:
+                - parameters
+                - setIdentifier
+                - type
+                type: object
+              sourceCluster:
+                description: id of the cluster of the source object the entry has
+                  been generated for, if it is an additional source cluster of the source
+                  controllers
+                type: string
+              state:
+                description: entry state
+                type: string
+              targets:
+                description: effective targets generated for the entry
+                items:
+                  type: string
+                type: array
+              ttl:
+                description: time to live used for the entry
+                format: int64
+                type: integer
+              zone:
+                description: zone used for the entry
+                type: string
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+  - additionalPrinterColumns:
+    - description: FQDN of DNS Entry
+      jsonPath: .spec.dnsName
+      name: DNS
+      type: string
+    - description: provider type
+      jsonPath: .status.providerType
+      name: TYPE
+      type: string
+    - description: assigned provider (namespace/name)
+      jsonPath: .status.provider
+      name: PROVIDER
+      type: string
+    - description: readiness of the entry
+      jsonPath: .status.conditions[?(@.type=="Ready")].status
+      name: READY
+      type: string
+    - description: entry creation timestamp
+      jsonPath: .metadata.creationTimestamp
+      name: AGE
+      type: date
+    - description: owner id used to tag entries in external DNS system
+      jsonPath: .spec.ownerId
+      name: OWNERID
+      type: string
+    - description: time to live
+      jsonPath: .status.ttl
+      name: TTL
+      priority: 2000
+      type: integer
+    - description: zone id
+      jsonPath: .status.zone
+      name: ZONE
+      priority: 2000
+      type: string
+    - description: reason of the readiness state
+      jsonPath: .status.conditions[?(@.type=="Ready")].reason
+      name: REASON
+      priority: 2000
+      type: string
+    name: v1beta1
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              activateAt:
+                description: optional time to create the DNS records of the entry,
+                  they are not created before
+                format: date-time
+                type: string
+              cnameLookupInterval:
+                description: lookup interval for host names that must be resolved
+                  to IP addresses
+                format: int64
+                type: integer
+              deactivateAt:
+                description: optional time to delete the DNS records of the entry,
+                  the entry itself is kept
+                format: date-time
+                type: string
+              deletionPolicy:
+                description: 'policy for the DNS records on deletion of the entry:
+                  ` + "`" + `Delete` + "`" + ` (default) deletes the records, ` + "`" + `Retain` + "`" + ` keeps them and
+                  only removes the ownership record'
+                enum:
+                - Delete
+                - Retain
+                type: string
+              dnsName:
+                description: full qualified domain name
+                type: string
+              lifetimeSeconds:
+                description: optional lifetime of the entry in seconds after its
+                  creation, the entry and its DNS records are deleted by the controller
+                  afterwards (not related to the TTL of the records)
+                format: int64
+                minimum: 1
+                type: integer
+              ownerId:
+                description: owner id used to tag entries in external DNS system
+                type: string
+              provider:
+                description: optional provider (namespace/name) to use exclusively
+                  for this entry, if several providers are responsible for the domain
+                type: string
+              records:
+                description: records of the entry, either text or addresses and host
+                  names must be specified
+                properties:
+                  addresses:
+                    description: IP addresses (A or AAAA records)
+                    items:
+                      type: string
+                    type: array
+                  hostnames:
+                    description: host names (CNAME records or resolved to addresses)
+                    items:
+                      type: string
+                    type: array
+                  text:
+                    description: text records (TXT records)
+                    items:
+                      type: string
+                    type: array
+                type: object
+              reference:
+                description: reference to base entry used to inherit attributes from
+                properties:
+                  name:
+                    description: name of the referenced DNSEntry object
+                    type: string
+                  namespace:
+                    description: namespace of the referenced DNSEntry object
+                    type: string
+                required:
+                - name
+                type: object
+              resolveTargetsToAddresses:
+                description: if true, host names are resolved to their IP addresses
+                  (A/AAAA records) instead of using CNAME records.
+                type: boolean
+              routingPolicy:
+                description: optional routing policy
+                properties:
+                  parameters:
+                    additionalProperties:
+                      type: string
+                    description: Policy specific parameters
+                    type: object
+                  setIdentifier:
+                    description: SetIdentifier is the identifier of the record set
+                    type: string
+                  type:
+                    description: Policy is the policy type. Allowed values are provider
+                      dependent, e.g. ` + "`" + `weighted` + "`" + `
+                    type: string
+                required:
+                - parameters
+                - setIdentifier
+                - type
+                type: object
+              targetRef:
+                description: reference to a cluster object (Service, Node, Gateway
+                  or DNSEntry) whose addresses are used as records
+                properties:
+                  apiVersion:
+                    description: API version of the referenced object, defaults to
+                      ` + "`" + `v1` + "`" + ` for kinds ` + "`" + `Service` + "`" + ` and ` + "`" + `Node` + "`" + `
+                    type: string
+                  kind:
+                    description: kind of the referenced object (` + "`" + `Service` + "`" + `, ` + "`" + `Node` + "`" + `,
+                      ` + "`" + `Gateway` + "`" + ` or ` + "`" + `DNSEntry` + "`" + `)
+                    type: string
+                  name:
+                    description: name of the referenced object
+                    type: string
+                  namespace:
+                    description: namespace of the referenced object, defaults to the
+                      namespace of the entry
+                    type: string
+                required:
+                - kind
+                - name
+                type: object
+              ttl:
+                description: time to live for records in external DNS system
+                format: int64
+                type: integer
+              zone:
+                description: optional id of the hosted zone to use exclusively for
+                  this entry, if the domain is served by several zones (e.g. a public
+                  and a private zone)
+                type: string
+            required:
+            - dnsName
+            type: object
+          status:
+            properties:
+              conditions:
+                description: conditions of the entry, the condition ` + "`" + `Ready` + "`" + ` reports
+                  the provisioning state
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    ` + "`" + `json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"` + "`" + ` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
+              lastUpdateTime:
+                description: lastUpdateTime contains the timestamp of the last status
+                  update
+                format: date-time
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+              provider:
+                description: assigned provider
+                type: string
+              providerRecords:
+                description: record sets as applied at the provider by the last successful
+                  reconciliation (without the ownership records of the controller)
+                properties:
+                  dnsName:
+                    description: DNS name of the record sets
+                    type: string
+                  recordSets:
+                    description: record sets by record type
+                    items:
+                      description: ProviderRecordSet describes a record set as applied
+                        at the provider
+                      properties:
+                        ttl:
+                          description: time to live of the record set
+                          format: int64
+                          type: integer
+                        type:
+                          description: record type (A, AAAA, CNAME, TXT)
+                          type: string
+                        values:
+                          description: values of the records
+                          items:
+                            type: string
+                          type: array
+                      required:
+                      - ttl
+                      - type
+                      - values
+                      type: object
+                    type: array
+                  routingPolicy:
+                    description: routing policy of the record sets
+                    properties:
+                      parameters:
+                        additionalProperties:
+                          type: string
+                        description: Policy specific parameters
+                        type: object
+                      setIdentifier:
+                        description: SetIdentifier is the identifier of the record
+                          set
+                        type: string
+                      type:
+                        description: Policy is the policy type. Allowed values are
+                          provider dependent, e.g. ` + "`" + `weighted` + "`" + `
+                        type: string
+                    required:
+                    - parameters
+                    - setIdentifier
+                    - type
+                    type: object
+                  setIdentifier:
+                    description: identifier of the record sets used by the routing
+                      policy
+                    type: string
+                  zone:
+                    description: id of the hosted zone containing the records
+                    type: string
+                required:
+                - dnsName
+                - zone
+                type: object
+              providerType:
+                description: provider type used for the entry
+                type: string
+              routingPolicy:
+                description: effective routing policy
+                properties:
+                  parameters:
+                    additionalProperties:
+                      type: string
+                    description: Policy specific parameters
+                    type: object
+                  setIdentifier:
+                    description: SetIdentifier is the identifier of the record set
+                    type: string
+                  type:
+                    description: Policy is the policy type. Allowed values are provider
+                      dependent, e.g. ` + "`" + `weighted` + "`" + `
+                    type: string
+                required:
+                - parameters
+                - setIdentifier
+                - type
+                type: object
+              sourceCluster:
+                description: id of the cluster of the source object the entry has
+                  been generated for, if it is an additional source cluster of the source
+                  controllers
+                type: string
+              targets:
+                description: effective targets generated for the entry
+                items:
+                  type: string
+                type: array
+              ttl:
+                description: time to live used for the entry
+                format: int64
+                type: integer
+              zone:
+                description: zone used for the entry
+                type: string
+            type: object
+        required:
+        - spec
+        type: object
+    served: false
+    storage: false
+    subresources:
+      status: {}
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: []
+  storedVersions: []
+  `
+	utils.Must(registry.RegisterCRD(data))
+	data = `
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.8.0
+  creationTimestamp: null
+  name: dnsentrysets.dns.gardener.cloud
+spec:
+  group: dns.gardener.cloud
+  names:
+    kind: DNSEntrySet
+    listKind: DNSEntrySetList
+    plural: dnsentrysets
+    shortNames:
+    - dnses
+    singular: dnsentryset
+  scope: Namespaced
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .spec.template.domain
+      name: Domain
+      type: string
+    - jsonPath: .status.entries
+      name: Entries
+      type: integer
+    - jsonPath: .status.ready
+      name: Ready
+      type: integer
+    - jsonPath: .status.state
+      name: STATUS
+      type: string
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
+      type: date
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              entries:
+                description: list of DNS records to generate DNS entries for
+                items:
+                  properties:
+                    name:
+                      description: name of the DNS record, the domain of the template
+                        is appended if specified
+                      type: string
+                    targets:
+                      description: target records (CNAME or A records), either text
+                        or targets must be specified
+                      items:
+                        type: string
+                      type: array
+                    text:
+                      description: text records, either text or targets must be specified
+                      items:
+                        type: string
+                      type: array
+                    ttl:
+                      description: time to live, overwrites the value of the template
+                      format: int64
+                      type: integer
+                  required:
+                  - name
+                  type: object
+                type: array
+              template:
+                description: template with common settings for all generated DNS entries
+                properties:
+                  annotations:
+                    additionalProperties:
+                      type: string
+                    description: annotations added to the generated DNS entries
+                    type: object
+                  cnameLookupInterval:
+                    description: lookup interval for CNAMEs that must be resolved
+                      to IP addresses
+                    format: int64
+                    type: integer
+                  domain:
+                    description: domain appended to the names of the entries
+                    type: string
+                  labels:
+                    additionalProperties:
+                      type: string
+                    description: labels added to the generated DNS entries
+                    type: object
+                  ownerId:
+                    description: owner id used to tag entries in external DNS system
+                    type: string
+                  provider:
+                    description: optional provider (namespace/name) to use exclusively
+                      for the entries
+                    type: string
+                  ttl:
+                    description: time to live for records in external DNS system
+                    format: int64
+                    type: integer
+                  zone:
+                    description: optional id of the hosted zone to use exclusively
+                      for the entries
+                    type: string
+                type: object
+              zoneFile:
+                description: zone file with additional DNS records to generate DNS
+                  entries for
+                properties:
+                  configMap:
+                    description: name of the config map in the namespace of the entry
+                      set containing the zone file
+                    type: string
+                  key:
+                    description: 'key of the zone file in the config map (default:
+                      zonefile)'
+                    type: string
+                  origin:
+                    description: 'origin for relative names in the zone file (default:
+                      domain of the template)'
+                    type: string
+                required:
+                - configMap
+                type: object
+            type: object
+          status:
+            properties:
+              conditions:
+                description: conditions ` + "`" + `Ready` + "`" + `, ` + "`" + `Reconciling` + "`" + ` and ` + "`" + `Stalled` + "`" + ` of the
+                  entry set
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    ` + "`" + `json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"` + "`" + ` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
+              entries:
+                description: number of generated DNS entries
+                type: integer
+              message:
+                description: message describing the reason for the state
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+              ready:
+                description: number of generated DNS entries in state Ready
+                type: integer
+              state:
+                description: state of the entry set
+                type: string
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: []
+  storedVersions: []
+  `
+	utils.Must(registry.RegisterCRD(data))
+	data = `
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.8.0
+  creationTimestamp: null
+  name: dnshealthchecks.dns.gardener.cloud
+spec:
+  group: dns.gardener.cloud
+  names:
+    kind: DNSHealthCheck
+    listKind: DNSHealthCheckList
+    plural: dnshealthchecks
+    shortNames:
+    - dnshc
+    singular: dnshealthcheck
+  scope: Namespaced
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .spec.type
+      name: TYPE
+      type: string
+    - jsonPath: .spec.provider
+      name: PROVIDER
+      type: string
+    - jsonPath: .status.healthCheckID
+      name: HEALTHCHECKID
+      type: string
+    - jsonPath: .status.state
+      name: STATUS
+      type: string
+    - jsonPath: .metadata.creationTimestamp
+      name: AGE
+      type: date
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              failureThreshold:
+                description: number of consecutive failed checks to consider the endpoint
+                  unhealthy (default 3)
+                type: integer
+              fqdn:
+                description: domain name of the checked endpoint, either the domain
+                  name or the IP address must be set
+                type: string
+              ipAddress:
+                description: IP address of the checked endpoint
+                type: string
+              port:
+                description: port of the checked endpoint (default 80 for HTTP and
+                  TCP, 443 for HTTPS)
+                type: integer
+              provider:
+                description: provider (namespace/name or name in the namespace of
+                  the health check) used to create the health check
+                type: string
+              requestInterval:
+                description: interval in seconds between two checks of a checker (provider
+                  dependent, 10 or 30 for AWS Route 53, default 30)
+                type: integer
+              resourcePath:
+                description: path requested by HTTP and HTTPS health checks
+                type: string
+              searchString:
+                description: string expected in the response body of HTTP and HTTPS
+                  health checks
+                type: string
+              type:
+                description: protocol of the health check
+                enum:
+                - HTTP
+                - HTTPS
+                - TCP
+                type: string
+            required:
+            - provider
+            - type
+            type: object
+          status:
+            properties:
+              conditions:
+                description: conditions ` + "`" + `Ready` + "`" + `, ` + "`" + `Reconciling` + "`" + ` and ` + "`" + `Stalled` + "`" + ` of the
+                  health check
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    ` + "`" + `json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"` + "`" + ` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
+              healthCheckID:
+                description: provider specific id of the created health check
+                type: string
+              message:
+                description: message describing the reason for the state
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+              state:
+                description: state of the health check
+                type: string
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: []
+  storedVersions: []
+  `
+	utils.Must(registry.RegisterCRD(data))
+	data = `
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.8.0
+  creationTimestamp: null
+  name: dnshostedzonepolicies.dns.gardener.cloud
+spec:
+  group: dns.gardener.cloud
+  names:
+    kind: DNSHostedZonePolicy
+    listKind: DNSHostedZonePolicyList
+    plural: dnshostedzonepolicies
+    shortNames:
+    - dnshzp
+    singular: dnshostedzonepolicy
+  scope: Cluster
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .status.count
+      name: Zone Count
+      type: integer
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
+      type: date
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              policy:
+                description: ZonePolicy specifies zone specific policy
+                properties:
+                  allowedRecordTypes:
+                    description: AllowedRecordTypes restricts the record types of
+                      the DNS entries in the zone (A, AAAA, CNAME, TXT)
+                    items:
+                      type: string
+                    type: array
+                  apex:
+                    description: Apex restricts entries for the base domain of the
+                      zone (apex) to dedicated namespaces or owners
+                    properties:
+                      allowedNamespaces:
+                        description: AllowedNamespaces lists namespaces still allowed
+                          to use the domain names
+                        items:
+                          type: string
+                        type: array
+                      allowedOwnerIds:
+                        description: AllowedOwnerIds lists owner ids of entries still
+                          allowed to use the domain names
+                        items:
+                          type: string
+                        type: array
+                      forbidden:
+                        description: Forbidden forbids the domain names
+                        type: boolean
+                    type: object
+                  clampTTL:
+                    description: ClampTTL clamps the TTL of the DNS entries to MinTTL
+                      and MaxTTL instead of rejecting them
+                    type: boolean
+                  duplicatePolicy:
+                    description: DuplicatePolicy specifies the handling of entries
+                      claiming the same DNS name in the zone
+                    enum:
+                    - reject-newer
+                    - reject-older
+                    - merge-targets
+                    - priority-by-annotation
+                    type: string
+                  forbidWildcards:
+                    description: ForbidWildcards forbids wildcard domain names in
+                      the zone
+                    type: boolean
+                  maxTTL:
+                    description: MaxTTL is the maximum TTL of the DNS entries in the
+                      zone
+                    format: int64
+                    minimum: 1
+                    type: integer
+                  minTTL:
+                    description: MinTTL is the minimum TTL of the DNS entries in the
+                      zone
+                    format: int64
+                    minimum: 1
+                    type: integer
+                  wildcards:
+                    description: Wildcards restricts wildcard domain names in the
+                      zone to dedicated namespaces or owners
+                    properties:
+                      allowedNamespaces:
+                        description: AllowedNamespaces lists namespaces still allowed
+                          to use the domain names
+                        items:
+                          type: string
+                        type: array
+                      allowedOwnerIds:
+                        description: AllowedOwnerIds lists owner ids of entries still
+                          allowed to use the domain names
+                        items:
+                          type: string
+                        type: array
+                      forbidden:
+                        description: Forbidden forbids the domain names
+                        type: boolean
+                    type: object
+                  zoneStateCacheTTL:
+                    description: ZoneStateCacheTTL specifies the TTL for the zone
+                      state cache
+                    type: string
+                type: object
+              selector:
+                description: ZoneSelector specifies the selector for the DNS hosted
+                  zones
+                properties:
+                  domainNames:
+                    description: DomainNames selects by base domain name of hosted
+                      zone. Policy will be applied to zones with matching base domain
+                    items:
+                      type: string
+                    type: array
+                  providerTypes:
+                    description: ProviderTypes selects by provider types
+                    items:
+                      type: string
+                    type: array
+                  zoneIDs:
+                    description: ZoneIDs selects by provider dependent zone ID
+                    items:
+                      type: string
+                    type: array
+                type: object
+            required:
+            - policy
+            - selector
+            type: object
+          status:
+            properties:
+              count:
+                description: Number of zones this policy is applied to
+                type: integer
+              lastStatusUpdateTime:
+                description: LastStatusUpdateTime contains the timestamp of the last
+                  status update
+                format: date-time
+                type: string
+              message:
+                description: In case of a configuration problem this field describes
+                  the reason
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+              zones:
+                description: Indicates that annotation is observed by a DNS sorce
+                  controller
+                items:
+                  properties:
+                    domainName:
+                      description: Domain name of the zone
+                      type: string
+                    providerType:
+                      description: Provider type of the zone
+                      type: string
+                    zoneID:
+                      description: ID of the zone
+                      type: string
+                  required:
+                  - domainName
+                  - providerType
+                  - zoneID
+                  type: object
+                type: array
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: []
+  storedVersions: []
+  `
+	utils.Must(registry.RegisterCRD(data))
+	data = `
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.8.0
+  creationTimestamp: null
+  name: dnslocks.dns.gardener.cloud
+spec:
+  group: dns.gardener.cloud
+  names:
+    kind: DNSLock
+    listKind: DNSLockList
+    plural: dnslocks
+    shortNames:
+    - dnsl
+    singular: dnslock
+  scope: Namespaced
+  versions:
+  - additionalPrinterColumns:
+    - description: FQDN of DNS Entry
+      jsonPath: .spec.dnsName
+      name: DNS
+      type: string
+    - description: provider type
+      jsonPath: .status.providerType
+      name: TYPE
+      type: string
+    - description: assigned provider (namespace/name)
+      jsonPath: .status.provider
+      name: PROVIDER
+      type: string
+    - description: entry status
+      jsonPath: .status.state
+      name: STATUS
+      type: string
+    - description: current holder of the lock lease
+      jsonPath: .status.holder
+      name: HOLDER
+      type: string
+    - description: entry creation timestamp
+      jsonPath: .metadata.creationTimestamp
+      name: AGE
+      type: date
+    - description: owner group id used to tag entries in external DNS system
+      jsonPath: .spec.ownerGroupId
+      name: OWNERID
+      type: string
+    - description: time to live
+      jsonPath: .status.ttl
+      name: TTL
+      priority: 2000
+      type: integer
+    - description: zone id
+      jsonPath: .status.zone
+      name: ZONE
+      priority: 2000
+      type: string
+    - description: message describing the reason for the state
+      jsonPath: .status.message
+      name: MESSAGE
+      priority: 2000
+      type: string
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              attributes:
+                additionalProperties:
+                  type: string
+                description: attribute values (must be compatible with DNS TXT records)
+                type: object
+              dnsName:
+                description: full qualified domain name
+                type: string
+              holder:
+                description: 'Holder is the identity of the lease holder (default:
+                  identifier of the controller)'
+                type: string
+              lease:
+                description: 'Lease enables lease semantics: the holder renews the
+                  timestamp periodically and other holders can claim the lock once
+                  the lease is expired'
+                type: boolean
+              lockId:
+                description: owner group for collaboration of multiple controller
+                type: string
+              lookup:
+                description: Lookup overwrites the lookup of the lock records for
+                  the status checks configured for the controller
+                properties:
+                  resolvers:
+                    description: Resolvers are the addresses (host[:port]) of the
+                      resolvers queried for the lock records
+                    items:
+                      type: string
+                    type: array
+                  retries:
+                    description: Retries is the number of retries of a failed lookup
+                    type: integer
+                  timeout:
+                    description: Timeout is the timeout of a single lookup
+                    type: string
+                type: object
+              timestamp:
+                description: Activation time stamp
+                format: date-time
+                type: string
+              ttl:
+                description: time to live for records in external DNS system
+                format: int64
+                type: integer
+            required:
+            - dnsName
+            - timestamp
+            - ttl
+            type: object
+          status:
+            properties:
+              attributes:
+                additionalProperties:
+                  type: string
+                description: attribute values found in DNS
+                type: object
+              conditions:
+                description: conditions ` + "`" + `Ready` + "`" + `, ` + "`" + `Reconciling` + "`" + ` and ` + "`" + `Stalled` + "`" + ` of the
+                  lock
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    ` + "`" + `json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"` + "`" + ` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
+              firstFailedDNSLookup:
+                description: First failed DNS looup
+                format: date-time
+                type: string
+              holder:
+                description: current holder of the lock lease found in DNS
+                type: string
+              lastUpdateTime:
+                description: lastUpdateTime contains the timestamp of the last status
+                  update
+                format: date-time
+                type: string
+              leaseExpiration:
+                description: expiration time of the lock lease
+                format: date-time
+                type: string
+              lockId:
+                description: owner group for collaboration of multiple controller
+                  found in DNS
+                type: string
+              message:
+                description: message describing the reason for the state
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+              provider:
+                description: assigned provider
+                type: string
+              providerType:
+                description: provider type used for the entry
+                type: string
+              state:
+                description: entry state
+                type: string
+              timestamp:
+                description: Activation time stamp found in DNS
+                format: date-time
+                type: string
+              ttl:
+                description: time to live used for the entry
+                format: int64
+                type: integer
+              zone:
+                description: zone used for the entry
+                type: string
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: []
+  storedVersions: []
+  `
+	utils.Must(registry.RegisterCRD(data))
+	data = `
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.8.0
+  creationTimestamp: null
+  name: dnsowners.dns.gardener.cloud
+spec:
+  group: dns.gardener.cloud
+  names:
+    kind: DNSOwner
+    listKind: DNSOwnerList
+    plural: dnsowners
+    shortNames:
+    - dnso
+    singular: dnsowner
+  scope: Cluster
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .spec.ownerId
+      name: OwnerId
+      type: string
+    - jsonPath: .status.active
+      name: Active
+      type: boolean
+    - jsonPath: .status.entries.amount
+      name: Usages
+      type: integer
+    - description: expiration date
+      format: date-time
+      jsonPath: .spec.validUntil
+      name: Valid
+      type: string
+    - description: creation timestamp
+      jsonPath: .metadata.creationTimestamp
+      name: AGE
+      type: date
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              active:
+                description: state of the ownerid for the DNS controller observing
+                  entry using this owner id (default:true)
+                type: boolean
+              deactivationPolicy:
+                description: 'optional cleanup of the DNS records carrying the owner
+                  id when the owner is deactivated or deleted: ` + "`" + `Delete` + "`" + ` deletes the
+                  records, ` + "`" + `Retain` + "`" + ` keeps them and only removes the ownership records
+                  (records are left untouched if not set, or if the owner id is handed
+                  over with a validity window)'
+                enum:
+                - Delete
+                - Retain
+                type: string
+              dnsActivation:
+                description: Optional activation info for controlling the owner activation
+                  remotely via DNS TXT record
+                properties:
+                  dnsName:
+                    description: DNS name for controlling the owner activation remotely
+                      via DNS TXT record
+                    type: string
+                  value:
+                    description: Optional value for the DNS activation record used
+                      to activate this owner The default is the id of the cluster
+                      used to read the owner object
+                    type: string
+                required:
+                - dnsName
+                type: object
+              ownerId:
+                description: owner id used to tag entries in external DNS system
+                type: string
+              validFrom:
+                description: optional time this owner should be active from if active
+                  flag is not false
+                format: date-time
+                type: string
+              validUntil:
+                description: optional time this owner should be active if active flag
+                  is not false
+                format: date-time
+                type: string
+            required:
+            - ownerId
+            type: object
+          status:
+            properties:
+              active:
+                description: state of the ownerid for the DNS controller observing
+                  entry using this owner id
+                type: boolean
+              entries:
+                description: Entry statistic for this owner id
+                properties:
+                  amount:
+                    description: number of entries using this owner id
+                    type: integer
+                  providers:
+                    additionalProperties:
+                      type: integer
+                    description: number of entries per provider (namespace/name)
+                    type: object
+                  types:
+                    additionalProperties:
+                      type: integer
+                    description: number of entries per provider type
+                    type: object
+                  zones:
+                    additionalProperties:
+                      type: integer
+                    description: number of entries per hosted zone id
+                    type: object
+                type: object
+              lastChangeTime:
+                description: time of the last change applied to the DNS records of this
+                  owner id
+                format: date-time
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: []
+  storedVersions: []
+  `
+	utils.Must(registry.RegisterCRD(data))
+	data = `
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.8.0
+  creationTimestamp: null
+  name: dnspolicies.dns.gardener.cloud
+spec:
+  group: dns.gardener.cloud
+  names:
+    kind: DNSPolicy
+    listKind: DNSPolicyList
+    plural: dnspolicies
+    shortNames:
+    - dnspol
+    singular: dnspolicy
+  scope: Cluster
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .status.state
+      name: STATUS
+      type: string
+    - jsonPath: .metadata.creationTimestamp
+      name: AGE
+      type: date
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            description: DNSPolicySpec specifies constraints all DNS entries must
+              fulfill
+            properties:
+              allowedDomains:
+                description: AllowedDomains restricts the domain names usable by entries
+                  of the selected namespaces. If rules are given, an entry is only
+                  valid if its domain name matches a suffix of a rule selecting the
+                  namespace of the entry.
+                items:
+                  description: DomainRule specifies the domain suffixes allowed for
+                    a set of namespaces
+                  properties:
+                    domainSuffixes:
+                      description: DomainSuffixes lists the allowed domain suffixes
+                      items:
+                        type: string
+                      type: array
+                    namespaces:
+                      description: Namespaces selects the namespaces the rule is applied
+                        to (all namespaces if empty)
+                      items:
+                        type: string
+                      type: array
+                  required:
+                  - domainSuffixes
+                  type: object
+                type: array
+              defaults:
+                description: Defaults specifies default values set for entries of
+                  the selected namespaces by the mutating admission webhook. The first
+                  rule selecting the namespace of an entry is used.
+                items:
+                  description: EntryDefaults specifies default values for entries
+                    of a set of namespaces
+                  properties:
+                    class:
+                      description: Class is the default DNS class for entries without
+                        class annotation
+                      type: string
+                    namespaces:
+                      description: Namespaces selects the namespaces the defaults
+                        are applied to (all namespaces if empty)
+                      items:
+                        type: string
+                      type: array
+                    ownerId:
+                      description: OwnerId is the default owner id for entries without
+                        owner id
+                      type: string
+                    ttl:
+                      description: TTL is the default time to live for entries without
+                        TTL
+                      format: int64
+                      type: integer
+                  type: object
+                type: array
+              forbiddenRecordTypes:
+                description: ForbiddenRecordTypes lists the record types (A, AAAA,
+                  CNAME, TXT) entries must not result in
+                items:
+                  type: string
+                type: array
+              maxTTL:
+                description: MaxTTL is the maximum TTL in seconds allowed for entries
+                format: int64
+                type: integer
+              minTTL:
+                description: MinTTL is the minimum TTL in seconds allowed for entries
+                format: int64
+                type: integer
+              quotas:
+                description: Quotas limits the number of entries of the selected namespaces.
+                  The first quota selecting the namespace of an entry is used.
+                items:
+                  description: NamespaceQuota limits the number of entries for a set
+                    of namespaces
+                  properties:
+                    maxEntries:
+                      description: MaxEntries is the maximum number of DNS entries
+                        per namespace
+                      minimum: 0
+                      type: integer
+                    namespaces:
+                      description: Namespaces selects the namespaces the quota is
+                        applied to, each namespace is limited separately (all namespaces
+                        if empty)
+                      items:
+                        type: string
+                      type: array
+                  required:
+                  - maxEntries
+                  type: object
+                type: array
+              wildcards:
+                description: Wildcards restricts the usage of wildcard domain names
+                properties:
+                  allowedNamespaces:
+                    description: AllowedNamespaces lists namespaces still allowed
+                      to use wildcard domain names
+                    items:
+                      type: string
+                    type: array
+                  forbidden:
+                    description: Forbidden forbids wildcard domain names
+                    type: boolean
+                type: object
+            type: object
+          status:
+            properties:
+              conditions:
+                description: conditions ` + "`" + `Ready` + "`" + `, ` + "`" + `Reconciling` + "`" + ` and ` + "`" + `Stalled` + "`" + ` of the
+                  policy
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    ` + "`" + `json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"` + "`" + ` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
+              message:
+                description: message describing the reason for the state
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+              state:
+                description: state of the policy
+                type: string
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: []
+  storedVersions: []
+  `
+	utils.Must(registry.RegisterCRD(data))
+	data = `
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.8.0
+  creationTimestamp: null
+  name: dnsproviders.dns.gardener.cloud
+spec:
+  group: dns.gardener.cloud
+  names:
+    kind: DNSProvider
+    listKind: DNSProviderList
+    plural: dnsproviders
+    shortNames:
+    - dnspr
+    singular: dnsprovider
+  scope: Namespaced
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .spec.type
+      name: TYPE
+      type: string
+    - jsonPath: .status.state
+      name: STATUS
+      type: string
+    - description: creation timestamp
+      jsonPath: .metadata.creationTimestamp
+      name: AGE
+      type: date
+    - description: included domains
+      jsonPath: .status.domains.included
+      name: INCLUDED_DOMAINS
+      type: string
+    - description: included zones
+      jsonPath: .status.zones.included
+      name: INCLUDED_ZONES
+      priority: 2000
+      type: string
+    - description: message describing the reason for the state
+      jsonPath: .status.message
+      name: MESSAGE
+      priority: 2000
+      type: string
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              allowedNamespaceSelector:
+                description: label selector for namespaces whose DNS entries may use
+                  the provider in addition to the namespace of the provider and the namespaces
+                  listed in allowedNamespaces (all namespaces if neither is specified)
+                properties:
+                  matchExpressions:
+                    description: matchExpressions is a list of label selector
+                      requirements. The requirements are ANDed.
+                    items:
+                      description: A label selector requirement is a selector
+                        that contains values, a key, and an operator that relates
+                        the key and values.
+                      properties:
+                        key:
+                          description: key is the label key that the selector
+                            applies to.
+                          type: string
+                        operator:
+                          description: operator represents a key's relationship
+                            to a set of values. Valid operators are In, NotIn,
+                            Exists and DoesNotExist.
+                          type: string
+                        values:
+                          description: values is an array of string values. If
+                            the operator is In or NotIn, the values array must
+                            be non-empty. If the operator is Exists or DoesNotExist,
+                            the values array must be empty. This array is replaced
+                            during a strategic merge patch.
+                          items:
+                            type: string
+                          type: array
+                      required:
+                      - key
+                      - operator
+                      type: object
+                    type: array
+                  matchLabels:
+                    additionalProperties:
+                      type: string
+                    description: matchLabels is a map of {key,value} pairs. A
+                      single {key,value} in the matchLabels map is equivalent
+                      to an element of matchExpressions, whose key field is "key",
+                      the operator is "In", and the values array contains only
+                      "value". The requirements are ANDed.
+                    type: object
+                type: object
+              allowedNamespaces:
+                description: namespaces whose DNS entries may use the provider in
+                  addition to the namespace of the provider (all namespaces if neither
+                  allowedNamespaces nor allowedNamespaceSelector is specified)
+                items:
+                  type: string
+                type: array
+              caBundle:
+                description: optional PEM encoded CA certificates trusted in addition
+                  to the system roots when connecting to the API of the external DNS
+                  system (e.g. for TLS-intercepting proxies)
+                format: byte
+                type: string
+              defaultDeletionPolicy:
+                description: default deletion policy for DNS entries if not specified
+                  explicitly
+                enum:
+                - Delete
+                - Retain
+                type: string
+              defaultTTL:
+                description: default TTL used for DNS entries if not specified explicitly
+                format: int64
+                type: integer
+              domains:
+                description: desired selection of usable domains (by default all zones
+                  and domains in those zones will be served)
+                properties:
+                  exclude:
+                    description: values that should be ignored (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                  include:
+                    description: values that should be observed (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                type: object
+              priority:
+                description: 'priority of the provider if multiple providers are responsible
+                  for a DNS name (providers with higher priority are preferred, even
+                  over providers with a longer matching domain, default: 0)'
+                type: integer
+              providerConfig:
+                description: optional additional provider specific configuration values
+                type: object
+                x-kubernetes-preserve-unknown-fields: true
+              proxyURL:
+                description: optional URL of an HTTP(S) or SOCKS5 proxy used to reach
+                  the API of the external DNS system (supported for the provider types
+                  aws-route53, azure-dns, azure-private-dns, cloudflare-dns, google-clouddns
+                  and openstack-designate)
+                type: string
+              rateLimit:
+                description: rate limit for create/update operations on DNSEntries
+                  assigned to this provider
+                properties:
+                  burst:
+                    description: Burst allows bursts of up to 'burst' to exceed the
+                      rate defined by 'RequestsPerDay', while still maintaining a
+                      smoothed rate of 'RequestsPerDay'
+                    type: integer
+                  requestsPerDay:
+                    description: RequestsPerDay is create/update request rate per
+                      DNS entry given by requests per day
+                    type: integer
+                required:
+                - burst
+                - requestsPerDay
+                type: object
+              recordImport:
+                description: import of existing records not owned by any DNS controller
+                  as DNSEntry objects
+                properties:
+                  externalDNS:
+                    description: migrates also the records owned by kubernetes-sigs/external-dns
+                      with the owner id given by the option --external-dns-owner-id
+                      (requires option --external-dns-registry)
+                    type: boolean
+                  namespace:
+                    description: 'namespace of the generated DNSEntry objects (default:
+                      namespace of the provider)'
+                    type: string
+                  ownerId:
+                    description: owner id of the generated DNSEntry objects
+                    type: string
+                  zones:
+                    description: 'ids of the hosted zones to import records from (default:
+                      all included zones)'
+                    items:
+                      type: string
+                    type: array
+                type: object
+              secondarySecretRef:
+                description: optional secondary access credential used as failover
+                  if the primary credential is rejected (e.g. during a credential
+                  rotation), it may also specify a secondary endpoint for provider
+                  types configured by the secret
+                properties:
+                  name:
+                    description: name is unique within a namespace to reference a
+                      secret resource.
+                    type: string
+                  namespace:
+                    description: namespace defines the space within which the secret
+                      name must be unique.
+                    type: string
+                type: object
+              secretRef:
+                description: access credential for the external DNS system of the
+                  given type
+                properties:
+                  name:
+                    description: name is unique within a namespace to reference a
+                      secret resource.
+                    type: string
+                  namespace:
+                    description: namespace defines the space within which the secret
+                      name must be unique.
+                    type: string
+                type: object
+              sync:
+                description: optional settings for the synchronization with the external
+                  DNS system (overriding the defaults of the provider type)
+                properties:
+                  resyncPeriod:
+                    description: period of the reconciliation of the provider, e.g.
+                      to detect new hosted zones
+                    type: string
+                  zoneStateCacheTTL:
+                    description: time-to-live of the cached records of the hosted
+                      zones (the zoneStateCacheTTL of a DNSHostedZonePolicy takes
+                      precedence)
+                    type: string
+                  zonesCacheTTL:
+                    description: time-to-live of the cached list of hosted zones of
+                      the account
+                    type: string
+                type: object
+              targetCIDRs:
+                description: CIDR ranges restricting the IP addresses published as
+                  targets of DNS entries by the provider
+                properties:
+                  allowed:
+                    description: CIDR ranges of allowed IP address targets (all addresses
+                      if not specified)
+                    items:
+                      type: string
+                    type: array
+                  denied:
+                    description: CIDR ranges of denied IP address targets (taking
+                      precedence over allowed ranges)
+                    items:
+                      type: string
+                    type: array
+                type: object
+              type:
+                description: type of the provider (selecting the responsible type
+                  of DNS controller)
+                type: string
+              upsertOnly:
+                description: if true, DNS records are only created and updated, but
+                  never deleted at the provider (upsert-only policy)
+                type: boolean
+              vault:
+                description: access credential read from HashiCorp Vault using the
+                  Kubernetes auth method (alternative to secretRef, requires option
+                  --vault-address)
+                properties:
+                  keys:
+                    additionalProperties:
+                      type: string
+                    description: 'optional mapping of keys of the Vault secret to
+                      the credential keys expected by the provider type (e.g. ` + "`" + `access_key:
+                      AWS_ACCESS_KEY_ID` + "`" + `), unmapped keys are used unchanged'
+                    type: object
+                  path:
+                    description: path of the secret in Vault, e.g. ` + "`" + `secret/data/dns/aws` + "`" + `
+                      for a secret of the KV version 2 engine or ` + "`" + `aws/creds/dns` + "`" + ` for
+                      dynamic credentials
+                    type: string
+                  role:
+                    description: role of the Kubernetes auth method used for the login
+                    type: string
+                required:
+                - path
+                - role
+                type: object
+              zones:
+                description: desired selection of usable domains the domain selection
+                  is used for served zones, only (by default all zones will be served)
+                properties:
+                  exclude:
+                    description: values that should be ignored (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                  include:
+                    description: values that should be observed (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                type: object
+            type: object
+          status:
+            properties:
+              conditions:
+                description: conditions ` + "`" + `Ready` + "`" + `, ` + "`" + `Reconciling` + "`" + ` and ` + "`" + `Stalled` + "`" + ` of the
+                  provider, the condition ` + "`" + `Ready` + "`" + ` requires discovered hosted zones
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    ` + "`" + `json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"` + "`" + ` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
+              defaultTTL:
+                description: actually used default TTL for DNS entries
+                format: int64
+                type: integer
+              domains:
+                description: actually served domain selection
+                properties:
+                  excluded:
+                    description: Excluded values (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                  included:
+                    description: included values (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                type: object
+              lastUpdateTime:
+                description: lastUpdateTime contains the timestamp of the last status
+                  update
+                format: date-time
+                type: string
+              message:
+                description: message describing the reason for the actual state of
+                  the provider
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+              rateLimit:
+                description: actually used rate limit for create/update operations
+                  on DNSEntries assigned to this provider
+                properties:
+                  burst:
+                    description: Burst allows bursts of up to 'burst' to exceed the
+                      rate defined by 'RequestsPerDay', while still maintaining a
+                      smoothed rate of 'RequestsPerDay'
+                    type: integer
+                  requestsPerDay:
+                    description: RequestsPerDay is create/update request rate per
+                      DNS entry given by requests per day
+                    type: integer
+                required:
+                - burst
+                - requestsPerDay
+                type: object
+              state:
+                description: state of the provider
+                type: string
+              zones:
+                description: actually served zones
+                properties:
+                  excluded:
+                    description: Excluded values (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                  included:
+                    description: included values (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                type: object
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: []
+  storedVersions: []
+  `
+	utils.Must(registry.RegisterCRD(data))
+	data = `
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.8.0
+  creationTimestamp: null
+  name: dnsswitches.dns.gardener.cloud
+spec:
+  group: dns.gardener.cloud
+  names:
+    kind: DNSSwitch
+    listKind: DNSSwitchList
+    plural: dnsswitches
+    shortNames:
+    - dnssw
+    singular: dnsswitch
+  scope: Namespaced
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .spec.dnsName
+      name: DNS
+      type: string
+    - jsonPath: .spec.active
+      name: Desired
+      type: string
+    - jsonPath: .status.active
+      name: Active
+      type: string
+    - jsonPath: .status.state
+      name: STATUS
+      type: string
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
+      type: date
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              active:
+                description: desired active side (blue or green)
+                enum:
+                - blue
+                - green
+                type: string
+              blue:
+                description: records of the blue side
+                properties:
+                  entry:
+                    description: name of a DNS entry in the namespace of the switch
+                      whose targets or text are used
+                    type: string
+                  targets:
+                    description: target records (CNAME or A records)
+                    items:
+                      type: string
+                    type: array
+                  text:
+                    description: text records
+                    items:
+                      type: string
+                    type: array
+                type: object
+              dnsName:
+                description: full qualified domain name whose records are switched
+                  between the sides
+                type: string
+              green:
+                description: records of the green side
+                properties:
+                  entry:
+                    description: name of a DNS entry in the namespace of the switch
+                      whose targets or text are used
+                    type: string
+                  targets:
+                    description: target records (CNAME or A records)
+                    items:
+                      type: string
+                    type: array
+                  text:
+                    description: text records
+                    items:
+                      type: string
+                    type: array
+                type: object
+              ownerId:
+                description: owner id used to tag entries in external DNS system
+                type: string
+              provider:
+                description: optional provider (namespace/name) to use exclusively
+                  for the generated entry
+                type: string
+              ttl:
+                description: time to live for records in external DNS system
+                format: int64
+                type: integer
+              zone:
+                description: optional id of the hosted zone to use exclusively for
+                  the generated entry
+                type: string
+            required:
+            - active
+            - blue
+            - dnsName
+            - green
+            type: object
+          status:
+            properties:
+              active:
+                description: side whose records have been applied to the generated
+                  entry
+                type: string
+              conditions:
+                description: conditions ` + "`" + `Ready` + "`" + `, ` + "`" + `Reconciling` + "`" + ` and ` + "`" + `Stalled` + "`" + ` of the
+                  switch
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    ` + "`" + `json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"` + "`" + ` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
+              history:
+                description: history of the switches, the latest switch first
+                items:
+                  description: DNSSwitchHistoryItem records a switch between the
+                    sides.
+                  properties:
+                    active:
+                      description: side switched to
+                      type: string
+                    records:
+                      description: targets or text records applied by the switch
+                      items:
+                        type: string
+                      type: array
+                    time:
+                      description: time of the switch
+                      format: date-time
+                      type: string
+                  required:
+                  - active
+                  - time
+                  type: object
+                type: array
+              message:
+                description: message describing the reason for the state
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+              state:
+                description: state of the switch
+                type: string
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: []
+  storedVersions: []
+  `
+	utils.Must(registry.RegisterCRD(data))
+	data = `
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.8.0
+  creationTimestamp: null
+  name: dnstrafficshifts.dns.gardener.cloud
+spec:
+  group: dns.gardener.cloud
+  names:
+    kind: DNSTrafficShift
+    listKind: DNSTrafficShiftList
+    plural: dnstrafficshifts
+    shortNames:
+    - dnsts
+    singular: dnstrafficshift
+  scope: Namespaced
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .spec.from
+      name: From
+      type: string
+    - jsonPath: .spec.to
+      name: To
+      type: string
+    - jsonPath: .status.weight
+      name: Weight
+      type: integer
+    - jsonPath: .status.state
+      name: STATUS
       type: string
-    - description: creation timestamp
-      jsonPath: .metadata.creationTimestamp
-      name: AGE
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
       type: date
     name: v1alpha1
     schema:
@@ -712,55 +3728,149 @@ spec:
             type: object
           spec:
             properties:
-              active:
-                description: state of the ownerid for the DNS controller observing
-                  entry using this owner id (default:true)
-                type: boolean
-              dnsActivation:
-                description: Optional activation info for controlling the owner activation
-                  remotely via DNS TXT record
+              from:
+                description: name of the DNS entry with weighted routing policy receiving
+                  the traffic before the shift
+                type: string
+              healthCheck:
+                description: optional health check of the new targets, the shift
+                  is aborted and the traffic is shifted back if the health check
+                  fails repeatedly
                 properties:
-                  dnsName:
-                    description: DNS name for controlling the owner activation remotely
-                      via DNS TXT record
+                  failureThreshold:
+                    description: 'number of consecutive failed checks aborting the shift
+                      (default: 3)'
+                    format: int64
+                    minimum: 1
+                    type: integer
+                  period:
+                    description: 'period of the checks during the shift (default:
+                      1m)'
                     type: string
-                  value:
-                    description: Optional value for the DNS activation record used
-                      to activate this owner The default is the id of the cluster
-                      used to read the owner object
+                  timeout:
+                    description: 'timeout of a single check (default: 10s)'
+                    type: string
+                  url:
+                    description: HTTP or HTTPS URL expected to respond with a 2xx
+                      status code
                     type: string
                 required:
-                - dnsName
+                - url
                 type: object
-              ownerId:
-                description: owner id used to tag entries in external DNS system
+              interval:
+                description: 'interval between two steps (default: 10m)'
                 type: string
-              validUntil:
-                description: optional time this owner should be active if active flag
-                  is not false
-                format: date-time
+              stepWeight:
+                description: 'percentage of the traffic shifted with every step
+                  (default: 10)'
+                format: int64
+                maximum: 100
+                minimum: 1
+                type: integer
+              to:
+                description: name of the DNS entry with weighted routing policy receiving
+                  the traffic after the shift
                 type: string
             required:
-            - ownerId
+            - from
+            - to
             type: object
           status:
             properties:
-              active:
-                description: state of the ownerid for the DNS controller observing
-                  entry using this owner id
-                type: boolean
-              entries:
-                description: Entry statistic for this owner id
-                properties:
-                  amount:
-                    description: number of entries using this owner id
-                    type: integer
-                  types:
-                    additionalProperties:
+              conditions:
+                description: conditions ` + "`" + `Ready` + "`" + `, ` + "`" + `Reconciling` + "`" + ` and ` + "`" + `Stalled` + "`" + ` of the
+                  traffic shift
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    ` + "`" + `json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"` + "`" + ` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
                       type: integer
-                    description: number of entries per provider type
-                    type: object
-                type: object
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
+              healthCheckFailures:
+                description: number of consecutive failed health checks
+                format: int64
+                type: integer
+              lastStep:
+                description: time of the last step
+                format: date-time
+                type: string
+              message:
+                description: message describing the reason for the state
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+              state:
+                description: state of the traffic shift
+                type: string
+              weight:
+                description: current weight of the target entry in percent
+                format: int64
+                type: integer
             type: object
         required:
         - spec
@@ -785,43 +3895,34 @@ metadata:
   annotations:
     controller-gen.kubebuilder.io/version: v0.8.0
   creationTimestamp: null
-  name: dnsproviders.dns.gardener.cloud
+  name: dnszones.dns.gardener.cloud
 spec:
   group: dns.gardener.cloud
   names:
-    kind: DNSProvider
-    listKind: DNSProviderList
-    plural: dnsproviders
+    kind: DNSZone
+    listKind: DNSZoneList
+    plural: dnszones
     shortNames:
-    - dnspr
-    singular: dnsprovider
+    - dnsz
+    singular: dnszone
   scope: Namespaced
   versions:
   - additionalPrinterColumns:
-    - jsonPath: .spec.type
-      name: TYPE
+    - jsonPath: .spec.domainName
+      name: DOMAIN
+      type: string
+    - jsonPath: .spec.provider
+      name: PROVIDER
+      type: string
+    - jsonPath: .status.zoneID
+      name: ZONEID
       type: string
     - jsonPath: .status.state
       name: STATUS
       type: string
-    - description: creation timestamp
-      jsonPath: .metadata.creationTimestamp
+    - jsonPath: .metadata.creationTimestamp
       name: AGE
       type: date
-    - description: included domains
-      jsonPath: .status.domains.included
-      name: INCLUDED_DOMAINS
-      type: string
-    - description: included zones
-      jsonPath: .status.zones.included
-      name: INCLUDED_ZONES
-      priority: 2000
-      type: string
-    - description: message describing the reason for the state
-      jsonPath: .status.message
-      name: MESSAGE
-      priority: 2000
-      type: string
     name: v1alpha1
     schema:
       openAPIV3Schema:
@@ -840,145 +3941,124 @@ spec:
             type: object
           spec:
             properties:
-              defaultTTL:
-                description: default TTL used for DNS entries if not specified explicitly
-                format: int64
-                type: integer
-              domains:
-                description: desired selection of usable domains (by default all zones
-                  and domains in those zones will be served)
-                properties:
-                  exclude:
-                    description: values that should be ignored (domains or zones)
-                    items:
-                      type: string
-                    type: array
-                  include:
-                    description: values that should be observed (domains or zones)
-                    items:
-                      type: string
-                    type: array
-                type: object
-              providerConfig:
-                description: optional additional provider specific configuration values
-                type: object
-                x-kubernetes-preserve-unknown-fields: true
-              rateLimit:
-                description: rate limit for create/update operations on DNSEntries
-                  assigned to this provider
-                properties:
-                  burst:
-                    description: Burst allows bursts of up to 'burst' to exceed the
-                      rate defined by 'RequestsPerDay', while still maintaining a
-                      smoothed rate of 'RequestsPerDay'
-                    type: integer
-                  requestsPerDay:
-                    description: RequestsPerDay is create/update request rate per
-                      DNS entry given by requests per day
-                    type: integer
-                required:
-                - burst
-                - requestsPerDay
-                type: object
-              secretRef:
-                description: access credential for the external DNS system of the
-                  given type
-                properties:
-                  name:
-                    description: name is unique within a namespace to reference a
-                      secret resource.
-                    type: string
-                  namespace:
-                    description: namespace defines the space within which the secret
-                      name must be unique.
-                    type: string
-                type: object
-              type:
-                description: type of the provider (selecting the responsible type
-                  of DNS controller)
+              dnssec:
+                description: enables DNSSEC signing of the hosted zone (if supported
+                  by the provider)
+                type: boolean
+              domainName:
+                description: domain name of the hosted zone (cannot be changed)
                 type: string
-              zones:
-                description: desired selection of usable domains the domain selection
-                  is used for served zones, only (by default all zones will be served)
-                properties:
-                  exclude:
-                    description: values that should be ignored (domains or zones)
-                    items:
-                      type: string
-                    type: array
-                  include:
-                    description: values that should be observed (domains or zones)
-                    items:
-                      type: string
-                    type: array
-                type: object
+              private:
+                description: if true, a private hosted zone is created
+                type: boolean
+              privateNetworks:
+                description: provider specific identifiers of the private networks
+                  the private hosted zone is attached to (e.g. ` + "`" + `<region>/<vpc-id>` + "`" + `
+                  for AWS Route 53 or the network URL for Google CloudDNS)
+                items:
+                  type: string
+                type: array
+              provider:
+                description: provider (namespace/name or name in the namespace of
+                  the zone) used to create the hosted zone
+                type: string
+            required:
+            - domainName
+            - provider
             type: object
           status:
             properties:
-              defaultTTL:
-                description: actually used default TTL for DNS entries
-                format: int64
-                type: integer
-              domains:
-                description: actually served domain selection
-                properties:
-                  excluded:
-                    description: Excluded values (domains or zones)
-                    items:
+              conditions:
+                description: conditions ` + "`" + `Ready` + "`" + `, ` + "`" + `Reconciling` + "`" + ` and ` + "`" + `Stalled` + "`" + ` of the
+                  zone
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    ` + "`" + `json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"` + "`" + ` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
                       type: string
-                    type: array
-                  included:
-                    description: included values (domains or zones)
-                    items:
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
                       type: string
-                    type: array
-                type: object
-              lastUpdateTime:
-                description: lastUpdateTime contains the timestamp of the last status
-                  update
-                format: date-time
-                type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
               message:
-                description: message describing the reason for the actual state of
-                  the provider
+                description: message describing the reason for the state
                 type: string
+              nameServers:
+                description: name servers of the hosted zone to be used for the delegation
+                  in the parent zone
+                items:
+                  type: string
+                type: array
               observedGeneration:
                 format: int64
                 type: integer
-              rateLimit:
-                description: actually used rate limit for create/update operations
-                  on DNSEntries assigned to this provider
-                properties:
-                  burst:
-                    description: Burst allows bursts of up to 'burst' to exceed the
-                      rate defined by 'RequestsPerDay', while still maintaining a
-                      smoothed rate of 'RequestsPerDay'
-                    type: integer
-                  requestsPerDay:
-                    description: RequestsPerDay is create/update request rate per
-                      DNS entry given by requests per day
-                    type: integer
-                required:
-                - burst
-                - requestsPerDay
-                type: object
               state:
-                description: state of the provider
+                description: state of the hosted zone
+                type: string
+              zoneID:
+                description: provider specific id of the created hosted zone
                 type: string
-              zones:
-                description: actually served zones
-                properties:
-                  excluded:
-                    description: Excluded values (domains or zones)
-                    items:
-                      type: string
-                    type: array
-                  included:
-                    description: included values (domains or zones)
-                    items:
-                      type: string
-                    type: array
-                type: object
             type: object
         required:
         - spec
@@ -1079,6 +4159,9 @@ spec:
                 description: Creation timestamp of the certificate
                 format: date-time
                 type: string
+              observedGeneration:
+                format: int64
+                type: integer
               recreating:
                 description: Indicates if certificate should be recreated and replaced
                   in the secret
@@ -38,6 +38,7 @@ type DNSAnnotationList struct {
 // +kubebuilder:printcolumn:name=RefKind,JSONPath=".spec.resourceRef.kind",type=string
 // +kubebuilder:printcolumn:name=RefName,JSONPath=".spec.resourceRef.name",type=string
 // +kubebuilder:printcolumn:name=RefNamespace,JSONPath=".spec.resourceRef.namespace",type=string
+// +kubebuilder:printcolumn:name=SelectorKind,JSONPath=".spec.resourceSelector.kind",type=string,priority=2000
 // +kubebuilder:printcolumn:name=Active,JSONPath=".status.active",type=boolean
 // +kubebuilder:printcolumn:name=Age,JSONPath=".metadata.creationTimestamp",type=date
 // +genclient
@@ -52,8 +53,13 @@ type DNSAnnotation struct {
 }
 
 type DNSAnnotationSpec struct {
-	ResourceRef ResourceReference `json:"resourceRef"`
-	Annotations map[string]string `json:"annotations"`
+	// reference to a single annotated object, either resourceRef or resourceSelector must be specified
+	// +optional
+	ResourceRef ResourceReference `json:"resourceRef,omitempty"`
+	// selector for a set of annotated objects, either resourceRef or resourceSelector must be specified
+	// +optional
+	ResourceSelector *ResourceSelector `json:"resourceSelector,omitempty"`
+	Annotations      map[string]string `json:"annotations"`
 }
 
 type ResourceReference struct {
@@ -71,7 +77,25 @@ type ResourceReference struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+type ResourceSelector struct {
+	// API Version of the annotated objects
+	APIVersion string `json:"apiVersion"`
+	// Kind of the annotated objects
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+	// Namespace of the annotated objects
+	// Defaulted by the namespace of the containing resource.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Label selector for the annotated objects. If not specified, all objects of
+	// the given kind in the namespace are selected.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
 type DNSAnnotationStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// Indicates that annotation is observed by a DNS sorce controller
 	// +optional
 	Active bool `json:"active,omitempty"`
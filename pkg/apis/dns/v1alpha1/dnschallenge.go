@@ -0,0 +1,97 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSChallengeList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSChallenge `json:"items"`
+}
+
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,path=dnschallenges,shortName=dnsch,singular=dnschallenge
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name=DNS,JSONPath=".spec.dnsName",type=string
+// +kubebuilder:printcolumn:name=STATUS,JSONPath=".status.state",type=string
+// +kubebuilder:printcolumn:name=Propagated,JSONPath=".status.propagated",type=boolean
+// +kubebuilder:printcolumn:name=Expiration,JSONPath=".status.expiration",type=date
+// +kubebuilder:printcolumn:name=Age,JSONPath=".metadata.creationTimestamp",type=date
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSChallenge struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSChallengeSpec `json:"spec"`
+	// +optional
+	Status DNSChallengeStatus `json:"status,omitempty"`
+}
+
+type DNSChallengeSpec struct {
+	// full qualified domain name of the challenge record, it must start with _acme-challenge.
+	DNSName string `json:"dnsName"`
+	// values of the TXT record (e.g. the digests of the key authorizations)
+	// +kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
+	// time to live of the TXT record (default: 60)
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+	// lifetime of the challenge in seconds, the challenge is deleted afterwards (default: 3600)
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	LifetimeSeconds *int64 `json:"lifetimeSeconds,omitempty"`
+	// owner id used to tag the record in external DNS system
+	// +optional
+	OwnerId *string `json:"ownerId,omitempty"`
+	// optional provider (namespace/name) to use exclusively for the record
+	// +optional
+	Provider *string `json:"provider,omitempty"`
+	// optional id of the hosted zone to use exclusively for the record
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+}
+
+type DNSChallengeStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// state of the challenge, Ready if the record is applied and propagated
+	// +optional
+	State string `json:"state,omitempty"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
+	// true if the record is resolvable at the authoritative name servers
+	// +optional
+	Propagated bool `json:"propagated,omitempty"`
+	// time after which the challenge is deleted
+	// +optional
+	Expiration *metav1.Time `json:"expiration,omitempty"`
+	// conditions `Ready`, `Reconciling` and `Stalled` of the challenge
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
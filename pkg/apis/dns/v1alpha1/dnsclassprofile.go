@@ -0,0 +1,91 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSClassProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSClassProfile `json:"items"`
+}
+
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,path=dnsclassprofiles,shortName=dnscp,singular=dnsclassprofile
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name=TTL,JSONPath=".spec.ttl",type=integer
+// +kubebuilder:printcolumn:name=OWNERID,JSONPath=".spec.ownerId",type=string
+// +kubebuilder:printcolumn:name=DRYRUN,JSONPath=".spec.dryRun",type=boolean
+// +kubebuilder:printcolumn:name=STATUS,JSONPath=".status.state",type=string
+// +kubebuilder:printcolumn:name=AGE,JSONPath=".metadata.creationTimestamp",type=date
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSClassProfile is the configuration profile of a DNS class.
+// The name of the object is the DNS class the profile is applied to.
+type DNSClassProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSClassProfileSpec `json:"spec"`
+	// +optional
+	Status DNSClassProfileStatus `json:"status,omitempty"`
+}
+
+// DNSClassProfileSpec specifies the settings for all entries of a DNS class
+type DNSClassProfileSpec struct {
+	// TTL is the default time to live for entries without TTL
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+	// OwnerId is the default owner id for entries without owner id
+	// +optional
+	OwnerId *string `json:"ownerId,omitempty"`
+	// AllowedProviders restricts the providers usable by entries of the class.
+	// Providers are given as <namespace>/<name>, all providers are allowed if empty.
+	// +optional
+	AllowedProviders []string `json:"allowedProviders,omitempty"`
+	// DryRun prevents changes of DNS records for all entries of the class
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+	// DuplicatePolicy specifies the handling of entries of the class claiming the same DNS name,
+	// a policy of the hosted zone takes precedence
+	// +kubebuilder:validation:Enum=reject-newer;reject-older;merge-targets;priority-by-annotation
+	// +optional
+	DuplicatePolicy *DuplicatePolicy `json:"duplicatePolicy,omitempty"`
+}
+
+type DNSClassProfileStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// state of the profile
+	// +optional
+	State string `json:"state,omitempty"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
+	// conditions `Ready`, `Reconciling` and `Stalled` of the class profile
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
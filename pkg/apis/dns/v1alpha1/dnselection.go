@@ -0,0 +1,97 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSElectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSElection `json:"items"`
+}
+
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,path=dnselections,shortName=dnsel,singular=dnselection
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name=DNS,JSONPath=".spec.dnsName",type=string,description="FQDN of the election lock record"
+// +kubebuilder:printcolumn:name=LEADER,JSONPath=".status.leader",type=string,description="current leader of the election"
+// +kubebuilder:printcolumn:name=ISLEADER,JSONPath=".status.isLeader",type=boolean,description="candidate of this cluster is the leader"
+// +kubebuilder:printcolumn:name=STATUS,JSONPath=".status.state",type=string,description="election status"
+// +kubebuilder:printcolumn:name=AGE,JSONPath=".metadata.creationTimestamp",type=date,description="creation timestamp"
+// +kubebuilder:printcolumn:name=MESSAGE,JSONPath=".status.message",type=string,priority=2000,description="message describing the reason for the state"
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSElection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSElectionSpec `json:"spec"`
+	// +optional
+	Status DNSElectionStatus `json:"status,omitempty"`
+}
+
+type DNSElectionSpec struct {
+	// full qualified domain name of the lock record shared by all candidates
+	DNSName string `json:"dnsName"`
+	// lock id of the election, must be identical for all candidates
+	// +optional
+	LockId *string `json:"lockId,omitempty"`
+	// identity of the candidate of this cluster (default: identifier of the controller)
+	// +optional
+	Candidate *string `json:"candidate,omitempty"`
+	// time to live of the lock record, the leadership expires after three times the TTL without renewal
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+	// Lookup overwrites the lookup of the lock records configured for the controller
+	// +optional
+	Lookup *DNSLockLookup `json:"lookup,omitempty"`
+}
+
+type DNSElectionStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// state of the election
+	// +optional
+	State string `json:"state,omitempty"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
+	// identity of the current leader
+	// +optional
+	Leader *string `json:"leader,omitempty"`
+	// the candidate of this cluster is the current leader
+	// +optional
+	IsLeader bool `json:"isLeader"`
+	// expiration time of the leadership if not renewed
+	// +optional
+	LeaseExpiration *metav1.Time `json:"leaseExpiration,omitempty"`
+	// time of the last change of the leader
+	// +optional
+	LeaderTransitionTime *metav1.Time `json:"leaderTransitionTime,omitempty"`
+	// conditions `Ready`, `Reconciling` and `Stalled` of the election
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
@@ -64,6 +64,9 @@ type DNSEntrySpec struct {
 	// reference to base entry used to inherit attributes from
 	// +optional
 	Reference *EntryReference `json:"reference,omitempty"`
+	// reference to a cluster object (Service, Node, Gateway or DNSEntry) whose addresses are used as targets
+	// +optional
+	TargetRef *TargetReference `json:"targetRef,omitempty"`
 	// owner id used to tag entries in external DNS system
 	// +optional
 	OwnerId *string `json:"ownerId,omitempty"`
@@ -73,6 +76,10 @@ type DNSEntrySpec struct {
 	// lookup interval for CNAMEs that must be resolved to IP addresses
 	// +optional
 	CNameLookupInterval *int64 `json:"cnameLookupInterval,omitempty"`
+	// if true, hostname targets are resolved to their IP addresses (A/AAAA records) instead of using CNAME records.
+	// The addresses are refreshed with the CNAME lookup interval.
+	// +optional
+	ResolveTargetsToAddresses *bool `json:"resolveTargetsToAddresses,omitempty"`
 	// text records, either text or targets must be specified
 	// +optional
 	Text []string `json:"text,omitempty"`
@@ -82,8 +89,42 @@ type DNSEntrySpec struct {
 	// optional routing policy
 	// +optional
 	RoutingPolicy *RoutingPolicy `json:"routingPolicy,omitempty"`
+	// optional provider (namespace/name) to use exclusively for this entry,
+	// if several providers are responsible for the domain
+	// +optional
+	Provider *string `json:"provider,omitempty"`
+	// optional id of the hosted zone to use exclusively for this entry,
+	// if the domain is served by several zones (e.g. a public and a private zone)
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+	// policy for the DNS records on deletion of the entry: `Delete` (default) deletes the records,
+	// `Retain` keeps them and only removes the ownership record
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// optional time to create the DNS records of the entry, they are not created before
+	// +optional
+	ActivateAt *metav1.Time `json:"activateAt,omitempty"`
+	// optional time to delete the DNS records of the entry, the entry itself is kept
+	// +optional
+	DeactivateAt *metav1.Time `json:"deactivateAt,omitempty"`
+	// optional lifetime of the entry in seconds after its creation, the entry and its DNS records
+	// are deleted by the controller afterwards (not related to the TTL of the records)
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	LifetimeSeconds *int64 `json:"lifetimeSeconds,omitempty"`
 }
 
+// DeletionPolicy describes the handling of the DNS records on deletion of an entry.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the DNS records together with the entry.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain keeps the DNS records, only the ownership record is removed.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
 type DNSEntryStatus struct {
 	DNSBaseStatus `json:",inline"`
 	// effective targets generated for the entry
@@ -92,6 +133,21 @@ type DNSEntryStatus struct {
 	// effective routing policy
 	// +optional
 	RoutingPolicy *RoutingPolicy `json:"routingPolicy,omitempty"`
+	// conditions of the entry, the condition `Ready` reports whether the records are applied and propagated
+	// (see also `Reconciling` and `Stalled`), the condition `Propagated` reports the resolution of the records
+	// by the name servers (requires option --propagation-check-timeout)
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// id of the cluster of the source object the entry has been generated for,
+	// if it is an additional source cluster of the source controllers
+	// +optional
+	SourceCluster string `json:"sourceCluster,omitempty"`
+	// record sets as applied at the provider by the last successful reconciliation
+	// (without the ownership records of the controller)
+	// +optional
+	ProviderRecords *ProviderRecords `json:"providerRecords,omitempty"`
 }
 
 type DNSBaseStatus struct {
@@ -128,6 +184,19 @@ type EntryReference struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+type TargetReference struct {
+	// API version of the referenced object, defaults to `v1` for kinds `Service` and `Node`
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+	// kind of the referenced object (`Service`, `Node`, `Gateway` or `DNSEntry`)
+	Kind string `json:"kind"`
+	// name of the referenced object
+	Name string `json:"name"`
+	// namespace of the referenced object, defaults to the namespace of the entry
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 type RoutingPolicy struct {
 	// Policy is the policy type. Allowed values are provider dependent, e.g. `weighted`
 	Type string `json:"type"`
@@ -136,3 +205,30 @@ type RoutingPolicy struct {
 	// Policy specific parameters
 	Parameters map[string]string `json:"parameters"`
 }
+
+// ProviderRecords describes the record sets of an entry as applied at the provider
+type ProviderRecords struct {
+	// id of the hosted zone containing the records
+	Zone string `json:"zone"`
+	// DNS name of the record sets
+	DNSName string `json:"dnsName"`
+	// identifier of the record sets used by the routing policy
+	// +optional
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	// routing policy of the record sets
+	// +optional
+	RoutingPolicy *RoutingPolicy `json:"routingPolicy,omitempty"`
+	// record sets by record type
+	// +optional
+	RecordSets []ProviderRecordSet `json:"recordSets,omitempty"`
+}
+
+// ProviderRecordSet describes a record set as applied at the provider
+type ProviderRecordSet struct {
+	// record type (A, AAAA, CNAME, TXT)
+	Type string `json:"type"`
+	// time to live of the record set
+	TTL int64 `json:"ttl"`
+	// values of the records
+	Values []string `json:"values"`
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSEntrySetList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSEntrySet `json:"items"`
+}
+
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,path=dnsentrysets,shortName=dnses,singular=dnsentryset
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name=Domain,JSONPath=".spec.template.domain",type=string
+// +kubebuilder:printcolumn:name=Entries,JSONPath=".status.entries",type=integer
+// +kubebuilder:printcolumn:name=Ready,JSONPath=".status.ready",type=integer
+// +kubebuilder:printcolumn:name=STATUS,JSONPath=".status.state",type=string
+// +kubebuilder:printcolumn:name=Age,JSONPath=".metadata.creationTimestamp",type=date
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSEntrySet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSEntrySetSpec `json:"spec"`
+	// +optional
+	Status DNSEntrySetStatus `json:"status,omitempty"`
+}
+
+type DNSEntrySetSpec struct {
+	// template with common settings for all generated DNS entries
+	// +optional
+	Template DNSEntrySetTemplate `json:"template,omitempty"`
+	// list of DNS records to generate DNS entries for
+	// +optional
+	Entries []DNSEntrySetItem `json:"entries,omitempty"`
+	// zone file with additional DNS records to generate DNS entries for
+	// +optional
+	ZoneFile *ZoneFileSource `json:"zoneFile,omitempty"`
+}
+
+type ZoneFileSource struct {
+	// name of the config map in the namespace of the entry set containing the zone file
+	ConfigMap string `json:"configMap"`
+	// key of the zone file in the config map (default: zonefile)
+	// +optional
+	Key string `json:"key,omitempty"`
+	// origin for relative names in the zone file (default: domain of the template)
+	// +optional
+	Origin string `json:"origin,omitempty"`
+}
+
+type DNSEntrySetTemplate struct {
+	// labels added to the generated DNS entries
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// annotations added to the generated DNS entries
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// domain appended to the names of the entries
+	// +optional
+	Domain string `json:"domain,omitempty"`
+	// owner id used to tag entries in external DNS system
+	// +optional
+	OwnerId *string `json:"ownerId,omitempty"`
+	// time to live for records in external DNS system
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+	// lookup interval for CNAMEs that must be resolved to IP addresses
+	// +optional
+	CNameLookupInterval *int64 `json:"cnameLookupInterval,omitempty"`
+	// optional provider (namespace/name) to use exclusively for the entries
+	// +optional
+	Provider *string `json:"provider,omitempty"`
+	// optional id of the hosted zone to use exclusively for the entries
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+}
+
+type DNSEntrySetItem struct {
+	// name of the DNS record, the domain of the template is appended if specified
+	Name string `json:"name"`
+	// time to live, overwrites the value of the template
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+	// text records, either text or targets must be specified
+	// +optional
+	Text []string `json:"text,omitempty"`
+	// target records (CNAME or A records), either text or targets must be specified
+	// +optional
+	Targets []string `json:"targets,omitempty"`
+}
+
+type DNSEntrySetStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// state of the entry set
+	// +optional
+	State string `json:"state,omitempty"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
+	// number of generated DNS entries
+	// +optional
+	Entries int `json:"entries"`
+	// number of generated DNS entries in state Ready
+	// +optional
+	Ready int `json:"ready"`
+	// conditions `Ready`, `Reconciling` and `Stalled` of the entry set
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
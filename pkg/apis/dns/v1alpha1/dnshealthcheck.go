@@ -0,0 +1,105 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSHealthCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSHealthCheck `json:"items"`
+}
+
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,path=dnshealthchecks,shortName=dnshc,singular=dnshealthcheck
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name=TYPE,JSONPath=".spec.type",type=string
+// +kubebuilder:printcolumn:name=PROVIDER,JSONPath=".spec.provider",type=string
+// +kubebuilder:printcolumn:name=HEALTHCHECKID,JSONPath=".status.healthCheckID",type=string
+// +kubebuilder:printcolumn:name=STATUS,JSONPath=".status.state",type=string
+// +kubebuilder:printcolumn:name=AGE,JSONPath=".metadata.creationTimestamp",type=date
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSHealthCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSHealthCheckSpec `json:"spec"`
+	// +optional
+	Status DNSHealthCheckStatus `json:"status,omitempty"`
+}
+
+const (
+	HealthCheckTypeHTTP  = "HTTP"
+	HealthCheckTypeHTTPS = "HTTPS"
+	HealthCheckTypeTCP   = "TCP"
+)
+
+type DNSHealthCheckSpec struct {
+	// provider (namespace/name or name in the namespace of the health check) used to create the health check
+	Provider string `json:"provider"`
+	// protocol of the health check
+	// +kubebuilder:validation:Enum=HTTP;HTTPS;TCP
+	Type string `json:"type"`
+	// domain name of the checked endpoint, either the domain name or the IP address must be set
+	// +optional
+	FQDN string `json:"fqdn,omitempty"`
+	// IP address of the checked endpoint
+	// +optional
+	IPAddress string `json:"ipAddress,omitempty"`
+	// port of the checked endpoint (default 80 for HTTP and TCP, 443 for HTTPS)
+	// +optional
+	Port *int `json:"port,omitempty"`
+	// path requested by HTTP and HTTPS health checks
+	// +optional
+	ResourcePath string `json:"resourcePath,omitempty"`
+	// string expected in the response body of HTTP and HTTPS health checks
+	// +optional
+	SearchString string `json:"searchString,omitempty"`
+	// interval in seconds between two checks of a checker (provider dependent, 10 or 30 for AWS Route 53, default 30)
+	// +optional
+	RequestInterval *int `json:"requestInterval,omitempty"`
+	// number of consecutive failed checks to consider the endpoint unhealthy (default 3)
+	// +optional
+	FailureThreshold *int `json:"failureThreshold,omitempty"`
+}
+
+type DNSHealthCheckStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// state of the health check
+	// +optional
+	State string `json:"state,omitempty"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
+	// provider specific id of the created health check
+	// +optional
+	HealthCheckID string `json:"healthCheckID,omitempty"`
+	// conditions `Ready`, `Reconciling` and `Stalled` of the health check
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
@@ -72,9 +72,66 @@ type ZonePolicy struct {
 	// ZoneStateCacheTTL specifies the TTL for the zone state cache
 	// +optional
 	ZoneStateCacheTTL *metav1.Duration `json:"zoneStateCacheTTL,omitempty"`
+	// MinTTL is the minimum TTL of the DNS entries in the zone
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinTTL *int64 `json:"minTTL,omitempty"`
+	// MaxTTL is the maximum TTL of the DNS entries in the zone
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTTL *int64 `json:"maxTTL,omitempty"`
+	// ClampTTL clamps the TTL of the DNS entries to MinTTL and MaxTTL instead of rejecting them
+	// +optional
+	ClampTTL bool `json:"clampTTL,omitempty"`
+	// AllowedRecordTypes restricts the record types of the DNS entries in the zone (A, AAAA, CNAME, TXT)
+	// +optional
+	AllowedRecordTypes []string `json:"allowedRecordTypes,omitempty"`
+	// ForbidWildcards forbids wildcard domain names in the zone
+	// +optional
+	ForbidWildcards bool `json:"forbidWildcards,omitempty"`
+	// Wildcards restricts wildcard domain names in the zone to dedicated namespaces or owners
+	// +optional
+	Wildcards *NameRestriction `json:"wildcards,omitempty"`
+	// Apex restricts entries for the base domain of the zone (apex) to dedicated namespaces or owners
+	// +optional
+	Apex *NameRestriction `json:"apex,omitempty"`
+	// DuplicatePolicy specifies the handling of entries claiming the same DNS name in the zone
+	// +kubebuilder:validation:Enum=reject-newer;reject-older;merge-targets;priority-by-annotation
+	// +optional
+	DuplicatePolicy *DuplicatePolicy `json:"duplicatePolicy,omitempty"`
 }
 
+// NameRestriction specifies the usage of dedicated domain names of a hosted zone
+type NameRestriction struct {
+	// Forbidden forbids the domain names
+	// +optional
+	Forbidden bool `json:"forbidden,omitempty"`
+	// AllowedNamespaces lists namespaces still allowed to use the domain names
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// AllowedOwnerIds lists owner ids of entries still allowed to use the domain names
+	// +optional
+	AllowedOwnerIds []string `json:"allowedOwnerIds,omitempty"`
+}
+
+// DuplicatePolicy describes the handling of entries claiming the same DNS name.
+type DuplicatePolicy string
+
+const (
+	// DuplicatePolicyRejectNewer keeps the DNS name for the older entry, the newer one is rejected (default).
+	DuplicatePolicyRejectNewer DuplicatePolicy = "reject-newer"
+	// DuplicatePolicyRejectOlder moves the DNS name to the newer entry, the older one is rejected.
+	DuplicatePolicyRejectOlder DuplicatePolicy = "reject-older"
+	// DuplicatePolicyMergeTargets merges the targets of all entries into the record sets of the DNS name.
+	DuplicatePolicyMergeTargets DuplicatePolicy = "merge-targets"
+	// DuplicatePolicyPriorityByAnnotation keeps the DNS name for the entry with the highest priority
+	// given by the annotation `dns.gardener.cloud/duplicate-priority`, the older entry wins for equal priorities.
+	DuplicatePolicyPriorityByAnnotation DuplicatePolicy = "priority-by-annotation"
+)
+
 type DNSHostedZonePolicyStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// Number of zones this policy is applied to
 	// +optional
 	Count *int `json:"count,omitempty"`
@@ -38,6 +38,7 @@ type DNSLockList struct {
 // +kubebuilder:printcolumn:name=TYPE,JSONPath=".status.providerType",type=string,description="provider type"
 // +kubebuilder:printcolumn:name=PROVIDER,JSONPath=".status.provider",type=string,description="assigned provider (namespace/name)"
 // +kubebuilder:printcolumn:name=STATUS,JSONPath=".status.state",type=string,description="entry status"
+// +kubebuilder:printcolumn:name=HOLDER,JSONPath=".status.holder",type=string,description="current holder of the lock lease"
 // +kubebuilder:printcolumn:name=AGE,JSONPath=".metadata.creationTimestamp",type=date,description="entry creation timestamp"
 // +kubebuilder:printcolumn:name=OWNERID,JSONPath=".spec.ownerGroupId",type=string,description="owner group id used to tag entries in external DNS system"
 // +kubebuilder:printcolumn:name=TTL,JSONPath=".status.ttl",type=integer,priority=2000,description="time to live"
@@ -67,6 +68,28 @@ type DNSLockSpec struct {
 	// attribute values (must be compatible with DNS TXT records)
 	// +optional
 	Attributes map[string]string `json:"attributes,omitempty"`
+	// Lease enables lease semantics: the holder renews the timestamp periodically
+	// and other holders can claim the lock once the lease is expired
+	// +optional
+	Lease *bool `json:"lease,omitempty"`
+	// Holder is the identity of the lease holder (default: identifier of the controller)
+	// +optional
+	Holder *string `json:"holder,omitempty"`
+	// Lookup overwrites the lookup of the lock records for the status checks configured for the controller
+	// +optional
+	Lookup *DNSLockLookup `json:"lookup,omitempty"`
+}
+
+type DNSLockLookup struct {
+	// Resolvers are the addresses (host[:port]) of the resolvers queried for the lock records
+	// +optional
+	Resolvers []string `json:"resolvers,omitempty"`
+	// Timeout is the timeout of a single lookup
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Retries is the number of retries of a failed lookup
+	// +optional
+	Retries *int `json:"retries,omitempty"`
 }
 
 type DNSLockStatus struct {
@@ -84,4 +107,16 @@ type DNSLockStatus struct {
 	// First failed DNS looup
 	// +optional
 	FirstFailedDNSLookup *metav1.Time `json:"firstFailedDNSLookup,omitempty"`
+
+	// current holder of the lock lease found in DNS
+	// +optional
+	Holder *string `json:"holder,omitempty"`
+	// expiration time of the lock lease
+	// +optional
+	LeaseExpiration *metav1.Time `json:"leaseExpiration,omitempty"`
+	// conditions `Ready`, `Reconciling` and `Stalled` of the lock
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
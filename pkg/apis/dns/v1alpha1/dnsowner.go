@@ -61,9 +61,18 @@ type DNSOwnerSpec struct {
 	// +optional
 	DNSActivation *DNSActivation `json:"dnsActivation,omitempty"`
 
+	// +optional
+	// optional time this owner should be active from if active flag is not false
+	ValidFrom *metav1.Time `json:"validFrom,omitempty"`
 	// +optional
 	// optional time this owner should be active if active flag is not false
 	ValidUntil *metav1.Time `json:"validUntil,omitempty"`
+	// optional cleanup of the DNS records carrying the owner id when the owner is deactivated or deleted:
+	// `Delete` deletes the records, `Retain` keeps them and only removes the ownership records
+	// (records are left untouched if not set, or if the owner id is handed over with a validity window)
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	DeactivationPolicy *DeletionPolicy `json:"deactivationPolicy,omitempty"`
 }
 
 // DNSActivation carries the optinal informatio required to control the
@@ -78,12 +87,17 @@ type DNSActivation struct {
 }
 
 type DNSOwnerStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// state of the ownerid for the DNS controller observing entry using this owner id
 	// +optional
 	Active *bool `json:"active,omitempty"`
 	// Entry statistic for this owner id
 	// +optional
 	Entries DNSOwnerStatusEntries `json:"entries,omitempty"`
+	// time of the last change applied to the DNS records of this owner id
+	// +optional
+	LastChangeTime *metav1.Time `json:"lastChangeTime,omitempty"`
 }
 
 type DNSOwnerStatusEntries struct {
@@ -93,4 +107,10 @@ type DNSOwnerStatusEntries struct {
 	// number of entries per provider type
 	// +optional
 	ByType map[string]int `json:"types,omitempty"`
+	// number of entries per provider (namespace/name)
+	// +optional
+	ByProvider map[string]int `json:"providers,omitempty"`
+	// number of entries per hosted zone id
+	// +optional
+	ByZone map[string]int `json:"zones,omitempty"`
 }
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSPolicy `json:"items"`
+}
+
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,path=dnspolicies,shortName=dnspol,singular=dnspolicy
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name=STATUS,JSONPath=".status.state",type=string
+// +kubebuilder:printcolumn:name=AGE,JSONPath=".metadata.creationTimestamp",type=date
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSPolicySpec `json:"spec"`
+	// +optional
+	Status DNSPolicyStatus `json:"status,omitempty"`
+}
+
+// DNSPolicySpec specifies constraints all DNS entries must fulfill
+type DNSPolicySpec struct {
+	// AllowedDomains restricts the domain names usable by entries of the selected namespaces.
+	// If rules are given, an entry is only valid if its domain name matches a suffix
+	// of a rule selecting the namespace of the entry.
+	// +optional
+	AllowedDomains []DomainRule `json:"allowedDomains,omitempty"`
+	// MinTTL is the minimum TTL in seconds allowed for entries
+	// +optional
+	MinTTL *int64 `json:"minTTL,omitempty"`
+	// MaxTTL is the maximum TTL in seconds allowed for entries
+	// +optional
+	MaxTTL *int64 `json:"maxTTL,omitempty"`
+	// ForbiddenRecordTypes lists the record types (A, AAAA, CNAME, TXT) entries must not result in
+	// +optional
+	ForbiddenRecordTypes []string `json:"forbiddenRecordTypes,omitempty"`
+	// Wildcards restricts the usage of wildcard domain names
+	// +optional
+	Wildcards *WildcardRestriction `json:"wildcards,omitempty"`
+	// Defaults specifies default values set for entries of the selected namespaces by the mutating admission webhook.
+	// The first rule selecting the namespace of an entry is used.
+	// +optional
+	Defaults []EntryDefaults `json:"defaults,omitempty"`
+	// Quotas limits the number of entries of the selected namespaces.
+	// The first quota selecting the namespace of an entry is used.
+	// +optional
+	Quotas []NamespaceQuota `json:"quotas,omitempty"`
+}
+
+// NamespaceQuota limits the number of entries for a set of namespaces
+type NamespaceQuota struct {
+	// Namespaces selects the namespaces the quota is applied to, each namespace is limited separately (all namespaces if empty)
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// MaxEntries is the maximum number of DNS entries per namespace
+	// +kubebuilder:validation:Minimum=0
+	MaxEntries int `json:"maxEntries"`
+}
+
+// EntryDefaults specifies default values for entries of a set of namespaces
+type EntryDefaults struct {
+	// Namespaces selects the namespaces the defaults are applied to (all namespaces if empty)
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// TTL is the default time to live for entries without TTL
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+	// OwnerId is the default owner id for entries without owner id
+	// +optional
+	OwnerId *string `json:"ownerId,omitempty"`
+	// Class is the default DNS class for entries without class annotation
+	// +optional
+	Class *string `json:"class,omitempty"`
+}
+
+// DomainRule specifies the domain suffixes allowed for a set of namespaces
+type DomainRule struct {
+	// Namespaces selects the namespaces the rule is applied to (all namespaces if empty)
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// DomainSuffixes lists the allowed domain suffixes
+	DomainSuffixes []string `json:"domainSuffixes"`
+}
+
+// WildcardRestriction specifies the usage of wildcard domain names
+type WildcardRestriction struct {
+	// Forbidden forbids wildcard domain names
+	// +optional
+	Forbidden bool `json:"forbidden,omitempty"`
+	// AllowedNamespaces lists namespaces still allowed to use wildcard domain names
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+type DNSPolicyStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// state of the policy
+	// +optional
+	State string `json:"state,omitempty"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
+	// conditions `Ready`, `Reconciling` and `Stalled` of the policy
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
@@ -63,6 +63,24 @@ type DNSProviderSpec struct {
 	ProviderConfig *runtime.RawExtension `json:"providerConfig,omitempty"`
 	// access credential for the external DNS system of the given type
 	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+	// optional secondary access credential used as failover if the primary credential is rejected
+	// (e.g. during a credential rotation), it may also specify a secondary endpoint for provider types
+	// configured by the secret
+	// +optional
+	SecondarySecretRef *corev1.SecretReference `json:"secondarySecretRef,omitempty"`
+	// access credential read from HashiCorp Vault using the Kubernetes auth method
+	// (alternative to secretRef, requires option --vault-address)
+	// +optional
+	Vault *VaultReference `json:"vault,omitempty"`
+	// optional URL of an HTTP(S) or SOCKS5 proxy used to reach the API of the external DNS system
+	// (supported for the provider types aws-route53, azure-dns, azure-private-dns, cloudflare-dns,
+	// google-clouddns and openstack-designate)
+	// +optional
+	ProxyURL *string `json:"proxyURL,omitempty"`
+	// optional PEM encoded CA certificates trusted in addition to the system roots
+	// when connecting to the API of the external DNS system (e.g. for TLS-intercepting proxies)
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
 	// desired selection of usable domains
 	// (by default all zones and domains in those zones will be served)
 	// +optional
@@ -75,9 +93,79 @@ type DNSProviderSpec struct {
 	// default TTL used for DNS entries if not specified explicitly
 	// +optional
 	DefaultTTL *int64 `json:"defaultTTL,omitempty"`
+	// default deletion policy for DNS entries if not specified explicitly
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	DefaultDeletionPolicy *DeletionPolicy `json:"defaultDeletionPolicy,omitempty"`
+	// if true, DNS records are only created and updated, but never deleted at the provider (upsert-only policy)
+	// +optional
+	UpsertOnly *bool `json:"upsertOnly,omitempty"`
+	// namespaces whose DNS entries may use the provider in addition to the namespace of the provider
+	// (all namespaces if neither allowedNamespaces nor allowedNamespaceSelector is specified)
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// label selector for namespaces whose DNS entries may use the provider in addition to the namespace of the provider
+	// and the namespaces listed in allowedNamespaces (all namespaces if neither is specified)
+	// +optional
+	AllowedNamespaceSelector *metav1.LabelSelector `json:"allowedNamespaceSelector,omitempty"`
+	// CIDR ranges restricting the IP addresses published as targets of DNS entries by the provider
+	// +optional
+	TargetCIDRs *TargetCIDRs `json:"targetCIDRs,omitempty"`
+	// priority of the provider if multiple providers are responsible for a DNS name
+	// (providers with higher priority are preferred, even over providers with a longer matching domain, default: 0)
+	// +optional
+	Priority *int `json:"priority,omitempty"`
 	// rate limit for create/update operations on DNSEntries assigned to this provider
 	// +optional
 	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// import of existing records not owned by any DNS controller as DNSEntry objects
+	// +optional
+	RecordImport *RecordImport `json:"recordImport,omitempty"`
+	// optional settings for the synchronization with the external DNS system
+	// (overriding the defaults of the provider type)
+	// +optional
+	Sync *ProviderSync `json:"sync,omitempty"`
+}
+
+type ProviderSync struct {
+	// time-to-live of the cached list of hosted zones of the account
+	// +optional
+	ZonesCacheTTL *metav1.Duration `json:"zonesCacheTTL,omitempty"`
+	// time-to-live of the cached records of the hosted zones
+	// (the zoneStateCacheTTL of a DNSHostedZonePolicy takes precedence)
+	// +optional
+	ZoneStateCacheTTL *metav1.Duration `json:"zoneStateCacheTTL,omitempty"`
+	// period of the reconciliation of the provider, e.g. to detect new hosted zones
+	// +optional
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+}
+
+type RecordImport struct {
+	// namespace of the generated DNSEntry objects (default: namespace of the provider)
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+	// owner id of the generated DNSEntry objects
+	// +optional
+	OwnerId *string `json:"ownerId,omitempty"`
+	// ids of the hosted zones to import records from (default: all included zones)
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+	// migrates also the records owned by kubernetes-sigs/external-dns with the owner id given by
+	// the option --external-dns-owner-id (requires option --external-dns-registry)
+	// +optional
+	ExternalDNS *bool `json:"externalDNS,omitempty"`
+}
+
+type VaultReference struct {
+	// path of the secret in Vault, e.g. `secret/data/dns/aws` for a secret of the KV version 2 engine
+	// or `aws/creds/dns` for dynamic credentials
+	Path string `json:"path"`
+	// role of the Kubernetes auth method used for the login
+	Role string `json:"role"`
+	// optional mapping of keys of the Vault secret to the credential keys expected by the provider type
+	// (e.g. `access_key: AWS_ACCESS_KEY_ID`), unmapped keys are used unchanged
+	// +optional
+	Keys map[string]string `json:"keys,omitempty"`
 }
 
 type RateLimit struct {
@@ -88,6 +176,15 @@ type RateLimit struct {
 	Burst int `json:"burst"`
 }
 
+type TargetCIDRs struct {
+	// CIDR ranges of allowed IP address targets (all addresses if not specified)
+	// +optional
+	Allowed []string `json:"allowed,omitempty"`
+	// CIDR ranges of denied IP address targets (taking precedence over allowed ranges)
+	// +optional
+	Denied []string `json:"denied,omitempty"`
+}
+
 type DNSSelection struct {
 	// values that should be observed (domains or zones)
 	// + optional
@@ -120,6 +217,11 @@ type DNSProviderStatus struct {
 	// actually used rate limit for create/update operations on DNSEntries assigned to this provider
 	// +optional
 	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// conditions `Ready`, `Reconciling` and `Stalled` of the provider, the condition `Ready` requires discovered hosted zones
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 type DNSSelectionStatus struct {
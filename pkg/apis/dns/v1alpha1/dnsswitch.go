@@ -0,0 +1,132 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSSwitchList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSSwitch `json:"items"`
+}
+
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,path=dnsswitches,shortName=dnssw,singular=dnsswitch
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name=DNS,JSONPath=".spec.dnsName",type=string
+// +kubebuilder:printcolumn:name=Desired,JSONPath=".spec.active",type=string
+// +kubebuilder:printcolumn:name=Active,JSONPath=".status.active",type=string
+// +kubebuilder:printcolumn:name=STATUS,JSONPath=".status.state",type=string
+// +kubebuilder:printcolumn:name=Age,JSONPath=".metadata.creationTimestamp",type=date
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSSwitch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSSwitchSpec `json:"spec"`
+	// +optional
+	Status DNSSwitchStatus `json:"status,omitempty"`
+}
+
+type DNSSwitchSpec struct {
+	// full qualified domain name whose records are switched between the sides
+	DNSName string `json:"dnsName"`
+	// owner id used to tag entries in external DNS system
+	// +optional
+	OwnerId *string `json:"ownerId,omitempty"`
+	// time to live for records in external DNS system
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+	// optional provider (namespace/name) to use exclusively for the generated entry
+	// +optional
+	Provider *string `json:"provider,omitempty"`
+	// optional id of the hosted zone to use exclusively for the generated entry
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+	// records of the blue side
+	Blue DNSSwitchSide `json:"blue"`
+	// records of the green side
+	Green DNSSwitchSide `json:"green"`
+	// desired active side (blue or green)
+	// +kubebuilder:validation:Enum=blue;green
+	Active DNSSwitchSideName `json:"active"`
+}
+
+// DNSSwitchSide describes the records of a side of a DNS switch, either given
+// by targets or text, or taken from a DNS entry.
+type DNSSwitchSide struct {
+	// target records (CNAME or A records)
+	// +optional
+	Targets []string `json:"targets,omitempty"`
+	// text records
+	// +optional
+	Text []string `json:"text,omitempty"`
+	// name of a DNS entry in the namespace of the switch whose targets or text are used
+	// +optional
+	Entry *string `json:"entry,omitempty"`
+}
+
+// DNSSwitchSideName is the name of a side of a DNS switch.
+type DNSSwitchSideName string
+
+const (
+	// DNSSwitchBlue is the blue side of a DNS switch.
+	DNSSwitchBlue DNSSwitchSideName = "blue"
+	// DNSSwitchGreen is the green side of a DNS switch.
+	DNSSwitchGreen DNSSwitchSideName = "green"
+)
+
+type DNSSwitchStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// state of the switch
+	// +optional
+	State string `json:"state,omitempty"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
+	// side whose records have been applied to the generated entry
+	// +optional
+	Active DNSSwitchSideName `json:"active,omitempty"`
+	// history of the switches, the latest switch first
+	// +optional
+	History []DNSSwitchHistoryItem `json:"history,omitempty"`
+	// conditions `Ready`, `Reconciling` and `Stalled` of the switch
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// DNSSwitchHistoryItem records a switch between the sides.
+type DNSSwitchHistoryItem struct {
+	// side switched to
+	Active DNSSwitchSideName `json:"active"`
+	// time of the switch
+	Time metav1.Time `json:"time"`
+	// targets or text records applied by the switch
+	// +optional
+	Records []string `json:"records,omitempty"`
+}
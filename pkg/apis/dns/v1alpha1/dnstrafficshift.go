@@ -0,0 +1,111 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSTrafficShiftList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSTrafficShift `json:"items"`
+}
+
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,path=dnstrafficshifts,shortName=dnsts,singular=dnstrafficshift
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name=From,JSONPath=".spec.from",type=string
+// +kubebuilder:printcolumn:name=To,JSONPath=".spec.to",type=string
+// +kubebuilder:printcolumn:name=Weight,JSONPath=".status.weight",type=integer
+// +kubebuilder:printcolumn:name=STATUS,JSONPath=".status.state",type=string
+// +kubebuilder:printcolumn:name=Age,JSONPath=".metadata.creationTimestamp",type=date
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSTrafficShift struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSTrafficShiftSpec `json:"spec"`
+	// +optional
+	Status DNSTrafficShiftStatus `json:"status,omitempty"`
+}
+
+type DNSTrafficShiftSpec struct {
+	// name of the DNS entry with weighted routing policy receiving the traffic before the shift
+	From string `json:"from"`
+	// name of the DNS entry with weighted routing policy receiving the traffic after the shift
+	To string `json:"to"`
+	// percentage of the traffic shifted with every step (default: 10)
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	StepWeight *int64 `json:"stepWeight,omitempty"`
+	// interval between two steps (default: 10m)
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// optional health check of the new targets, the shift is aborted and the traffic
+	// is shifted back if the health check fails repeatedly
+	// +optional
+	HealthCheck *DNSTrafficShiftHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// DNSTrafficShiftHealthCheck describes an HTTP health check of the new targets.
+type DNSTrafficShiftHealthCheck struct {
+	// HTTP or HTTPS URL expected to respond with a 2xx status code
+	URL string `json:"url"`
+	// timeout of a single check (default: 10s)
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// period of the checks during the shift (default: 1m)
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+	// number of consecutive failed checks aborting the shift (default: 3)
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int64 `json:"failureThreshold,omitempty"`
+}
+
+type DNSTrafficShiftStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// state of the traffic shift
+	// +optional
+	State string `json:"state,omitempty"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
+	// current weight of the target entry in percent
+	// +optional
+	Weight int64 `json:"weight,omitempty"`
+	// time of the last step
+	// +optional
+	LastStep *metav1.Time `json:"lastStep,omitempty"`
+	// number of consecutive failed health checks
+	// +optional
+	HealthCheckFailures int64 `json:"healthCheckFailures,omitempty"`
+	// conditions `Ready`, `Reconciling` and `Stalled` of the traffic shift
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
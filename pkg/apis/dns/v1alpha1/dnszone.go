@@ -0,0 +1,90 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSZoneList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSZone `json:"items"`
+}
+
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,path=dnszones,shortName=dnsz,singular=dnszone
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name=DOMAIN,JSONPath=".spec.domainName",type=string
+// +kubebuilder:printcolumn:name=PROVIDER,JSONPath=".spec.provider",type=string
+// +kubebuilder:printcolumn:name=ZONEID,JSONPath=".status.zoneID",type=string
+// +kubebuilder:printcolumn:name=STATUS,JSONPath=".status.state",type=string
+// +kubebuilder:printcolumn:name=AGE,JSONPath=".metadata.creationTimestamp",type=date
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSZone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSZoneSpec `json:"spec"`
+	// +optional
+	Status DNSZoneStatus `json:"status,omitempty"`
+}
+
+type DNSZoneSpec struct {
+	// domain name of the hosted zone (cannot be changed)
+	DomainName string `json:"domainName"`
+	// provider (namespace/name or name in the namespace of the zone) used to create the hosted zone
+	Provider string `json:"provider"`
+	// if true, a private hosted zone is created
+	// +optional
+	Private bool `json:"private,omitempty"`
+	// provider specific identifiers of the private networks the private hosted zone is attached to
+	// (e.g. `<region>/<vpc-id>` for AWS Route 53 or the network URL for Google CloudDNS)
+	// +optional
+	PrivateNetworks []string `json:"privateNetworks,omitempty"`
+	// enables DNSSEC signing of the hosted zone (if supported by the provider)
+	// +optional
+	DNSSEC bool `json:"dnssec,omitempty"`
+}
+
+type DNSZoneStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// state of the hosted zone
+	// +optional
+	State string `json:"state,omitempty"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
+	// provider specific id of the created hosted zone
+	// +optional
+	ZoneID string `json:"zoneID,omitempty"`
+	// name servers of the hosted zone to be used for the delegation in the parent zone
+	// +optional
+	NameServers []string `json:"nameServers,omitempty"`
+	// conditions `Ready`, `Reconciling` and `Stalled` of the zone
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
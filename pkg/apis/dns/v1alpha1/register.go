@@ -30,9 +30,18 @@ const (
 	DNSOwnerKind            = "DNSOwner"
 	DNSProviderKind         = "DNSProvider"
 	DNSEntryKind            = "DNSEntry"
+	DNSEntrySetKind         = "DNSEntrySet"
+	DNSElectionKind         = "DNSElection"
 	DNSLockKind             = "DNSLock"
 	DNSAnnotationKind       = "DNSAnnotation"
 	DNSHostedZonePolicyKind = "DNSHostedZonePolicy"
+	DNSZoneKind             = "DNSZone"
+	DNSPolicyKind           = "DNSPolicy"
+	DNSClassProfileKind     = "DNSClassProfile"
+	DNSSwitchKind           = "DNSSwitch"
+	DNSTrafficShiftKind     = "DNSTrafficShift"
+	DNSChallengeKind        = "DNSChallenge"
+	DNSHealthCheckKind      = "DNSHealthCheck"
 
 	RemoteAccessCertificateKind = "RemoteAccessCertificate"
 )
@@ -64,12 +73,30 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&DNSProviderList{},
 		&DNSEntry{},
 		&DNSEntryList{},
+		&DNSEntrySet{},
+		&DNSEntrySetList{},
 		&DNSAnnotation{},
 		&DNSLock{},
 		&DNSLockList{},
+		&DNSElection{},
+		&DNSElectionList{},
 		&DNSAnnotationList{},
 		&DNSHostedZonePolicy{},
 		&DNSHostedZonePolicyList{},
+		&DNSZone{},
+		&DNSZoneList{},
+		&DNSPolicy{},
+		&DNSPolicyList{},
+		&DNSClassProfile{},
+		&DNSClassProfileList{},
+		&DNSSwitch{},
+		&DNSSwitchList{},
+		&DNSTrafficShift{},
+		&DNSTrafficShiftList{},
+		&DNSChallenge{},
+		&DNSChallengeList{},
+		&DNSHealthCheck{},
+		&DNSHealthCheckList{},
 		&RemoteAccessCertificate{},
 		&RemoteAccessCertificateList{},
 	)
@@ -73,6 +73,8 @@ const (
 )
 
 type RemoteAccessCertificateStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// Creation timestamp of the certificate
 	// +optional
 	NotBefore *metav1.Time `json:"notBefore,omitempty"`
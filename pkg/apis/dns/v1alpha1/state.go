@@ -22,3 +22,48 @@ const STATE_INVALID = "Invalid"
 const STATE_STALE = "Stale"
 const STATE_READY = "Ready"
 const STATE_DELETING = "Deleting"
+const STATE_INACTIVE = "Inactive"
+const STATE_SHIFTING = "Shifting"
+const STATE_COMPLETED = "Completed"
+const STATE_ABORTED = "Aborted"
+
+// CONDITION_READY is the condition type reporting whether an object is ready.
+// Together with the conditions CONDITION_RECONCILING and CONDITION_STALLED it follows the
+// conventions of kstatus, so that GitOps tools can evaluate the health of the objects.
+const CONDITION_READY = "Ready"
+
+// CONDITION_RECONCILING is the condition type reporting that an object is still being reconciled.
+const CONDITION_RECONCILING = "Reconciling"
+
+// CONDITION_STALLED is the condition type reporting that the reconciliation of an object cannot make progress.
+const CONDITION_STALLED = "Stalled"
+
+// reasons of the condition Ready of an entry which is applied but not yet ready
+const (
+	REASON_PROPAGATING    = "Propagating"
+	REASON_NOT_PROPAGATED = "NotPropagated"
+)
+
+// REASON_NO_HOSTED_ZONES is the reason of the condition Ready of a provider without discovered hosted zones.
+const REASON_NO_HOSTED_ZONES = "NoHostedZones"
+
+// CONDITION_PROPAGATED is the condition type of an entry reporting the resolution of its records by the name servers.
+const CONDITION_PROPAGATED = "Propagated"
+
+// reasons of the condition Propagated of an entry
+const (
+	PROPAGATION_PENDING     = "Pending"
+	PROPAGATION_RESOLVED    = "Resolved"
+	PROPAGATION_TIMEOUT     = "Timeout"
+	PROPAGATION_CHECKFAILED = "CheckFailed"
+	PROPAGATION_NOTCHECKED  = "NotChecked"
+)
+
+// CONDITION_STUCK is the condition type of an entry reporting why it has not become ready for a long time.
+const CONDITION_STUCK = "Stuck"
+
+// CONDITION_OWNER_CONFLICT is the condition type of an entry reporting that its record set is repeatedly changed by another owner.
+const CONDITION_OWNER_CONFLICT = "OwnerConflict"
+
+// CONDITION_TTL_CLAMPED is the condition type of an entry reporting that its requested TTL has been clamped to the configured bounds.
+const CONDITION_TTL_CLAMPED = "TTLClamped"
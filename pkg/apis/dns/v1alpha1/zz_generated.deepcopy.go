@@ -22,8 +22,8 @@ limitations under the License.
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -113,6 +113,11 @@ func (in *DNSAnnotationList) DeepCopyObject() runtime.Object {
 func (in *DNSAnnotationSpec) DeepCopyInto(out *DNSAnnotationSpec) {
 	*out = *in
 	out.ResourceRef = in.ResourceRef
+	if in.ResourceSelector != nil {
+		in, out := &in.ResourceSelector, &out.ResourceSelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
@@ -195,7 +200,7 @@ func (in *DNSBaseStatus) DeepCopy() *DNSBaseStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSEntry) DeepCopyInto(out *DNSEntry) {
+func (in *DNSChallenge) DeepCopyInto(out *DNSChallenge) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -204,18 +209,18 @@ func (in *DNSEntry) DeepCopyInto(out *DNSEntry) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntry.
-func (in *DNSEntry) DeepCopy() *DNSEntry {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSChallenge.
+func (in *DNSChallenge) DeepCopy() *DNSChallenge {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSEntry)
+	out := new(DNSChallenge)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSEntry) DeepCopyObject() runtime.Object {
+func (in *DNSChallenge) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -223,13 +228,13 @@ func (in *DNSEntry) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSEntryList) DeepCopyInto(out *DNSEntryList) {
+func (in *DNSChallengeList) DeepCopyInto(out *DNSChallengeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DNSEntry, len(*in))
+		*out = make([]DNSChallenge, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -237,18 +242,18 @@ func (in *DNSEntryList) DeepCopyInto(out *DNSEntryList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntryList.
-func (in *DNSEntryList) DeepCopy() *DNSEntryList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSChallengeList.
+func (in *DNSChallengeList) DeepCopy() *DNSChallengeList {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSEntryList)
+	out := new(DNSChallengeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSEntryList) DeepCopyObject() runtime.Object {
+func (in *DNSChallengeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -256,85 +261,85 @@ func (in *DNSEntryList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSEntrySpec) DeepCopyInto(out *DNSEntrySpec) {
+func (in *DNSChallengeSpec) DeepCopyInto(out *DNSChallengeSpec) {
 	*out = *in
-	if in.Reference != nil {
-		in, out := &in.Reference, &out.Reference
-		*out = new(EntryReference)
-		**out = **in
-	}
-	if in.OwnerId != nil {
-		in, out := &in.OwnerId, &out.OwnerId
-		*out = new(string)
-		**out = **in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	if in.TTL != nil {
 		in, out := &in.TTL, &out.TTL
 		*out = new(int64)
 		**out = **in
 	}
-	if in.CNameLookupInterval != nil {
-		in, out := &in.CNameLookupInterval, &out.CNameLookupInterval
+	if in.LifetimeSeconds != nil {
+		in, out := &in.LifetimeSeconds, &out.LifetimeSeconds
 		*out = new(int64)
 		**out = **in
 	}
-	if in.Text != nil {
-		in, out := &in.Text, &out.Text
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.OwnerId != nil {
+		in, out := &in.OwnerId, &out.OwnerId
+		*out = new(string)
+		**out = **in
 	}
-	if in.Targets != nil {
-		in, out := &in.Targets, &out.Targets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(string)
+		**out = **in
 	}
-	if in.RoutingPolicy != nil {
-		in, out := &in.RoutingPolicy, &out.RoutingPolicy
-		*out = new(RoutingPolicy)
-		(*in).DeepCopyInto(*out)
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntrySpec.
-func (in *DNSEntrySpec) DeepCopy() *DNSEntrySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSChallengeSpec.
+func (in *DNSChallengeSpec) DeepCopy() *DNSChallengeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSEntrySpec)
+	out := new(DNSChallengeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSEntryStatus) DeepCopyInto(out *DNSEntryStatus) {
+func (in *DNSChallengeStatus) DeepCopyInto(out *DNSChallengeStatus) {
 	*out = *in
-	in.DNSBaseStatus.DeepCopyInto(&out.DNSBaseStatus)
-	if in.Targets != nil {
-		in, out := &in.Targets, &out.Targets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
 	}
-	if in.RoutingPolicy != nil {
-		in, out := &in.RoutingPolicy, &out.RoutingPolicy
-		*out = new(RoutingPolicy)
-		(*in).DeepCopyInto(*out)
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntryStatus.
-func (in *DNSEntryStatus) DeepCopy() *DNSEntryStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSChallengeStatus.
+func (in *DNSChallengeStatus) DeepCopy() *DNSChallengeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSEntryStatus)
+	out := new(DNSChallengeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSHostedZonePolicy) DeepCopyInto(out *DNSHostedZonePolicy) {
+func (in *DNSClassProfile) DeepCopyInto(out *DNSClassProfile) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -343,18 +348,18 @@ func (in *DNSHostedZonePolicy) DeepCopyInto(out *DNSHostedZonePolicy) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZonePolicy.
-func (in *DNSHostedZonePolicy) DeepCopy() *DNSHostedZonePolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSClassProfile.
+func (in *DNSClassProfile) DeepCopy() *DNSClassProfile {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSHostedZonePolicy)
+	out := new(DNSClassProfile)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSHostedZonePolicy) DeepCopyObject() runtime.Object {
+func (in *DNSClassProfile) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -362,13 +367,13 @@ func (in *DNSHostedZonePolicy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSHostedZonePolicyList) DeepCopyInto(out *DNSHostedZonePolicyList) {
+func (in *DNSClassProfileList) DeepCopyInto(out *DNSClassProfileList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DNSHostedZonePolicy, len(*in))
+		*out = make([]DNSClassProfile, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -376,18 +381,18 @@ func (in *DNSHostedZonePolicyList) DeepCopyInto(out *DNSHostedZonePolicyList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZonePolicyList.
-func (in *DNSHostedZonePolicyList) DeepCopy() *DNSHostedZonePolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSClassProfileList.
+func (in *DNSClassProfileList) DeepCopy() *DNSClassProfileList {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSHostedZonePolicyList)
+	out := new(DNSClassProfileList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSHostedZonePolicyList) DeepCopyObject() runtime.Object {
+func (in *DNSClassProfileList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -395,60 +400,71 @@ func (in *DNSHostedZonePolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSHostedZonePolicySpec) DeepCopyInto(out *DNSHostedZonePolicySpec) {
+func (in *DNSClassProfileSpec) DeepCopyInto(out *DNSClassProfileSpec) {
 	*out = *in
-	in.Selector.DeepCopyInto(&out.Selector)
-	in.Policy.DeepCopyInto(&out.Policy)
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.OwnerId != nil {
+		in, out := &in.OwnerId, &out.OwnerId
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowedProviders != nil {
+		in, out := &in.AllowedProviders, &out.AllowedProviders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DuplicatePolicy != nil {
+		in, out := &in.DuplicatePolicy, &out.DuplicatePolicy
+		*out = new(DuplicatePolicy)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZonePolicySpec.
-func (in *DNSHostedZonePolicySpec) DeepCopy() *DNSHostedZonePolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSClassProfileSpec.
+func (in *DNSClassProfileSpec) DeepCopy() *DNSClassProfileSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSHostedZonePolicySpec)
+	out := new(DNSClassProfileSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSHostedZonePolicyStatus) DeepCopyInto(out *DNSHostedZonePolicyStatus) {
+func (in *DNSClassProfileStatus) DeepCopyInto(out *DNSClassProfileStatus) {
 	*out = *in
-	if in.Count != nil {
-		in, out := &in.Count, &out.Count
-		*out = new(int)
-		**out = **in
-	}
-	if in.Zones != nil {
-		in, out := &in.Zones, &out.Zones
-		*out = make([]ZoneInfo, len(*in))
-		copy(*out, *in)
-	}
-	if in.LastStatusUpdateTime != nil {
-		in, out := &in.LastStatusUpdateTime, &out.LastStatusUpdateTime
-		*out = (*in).DeepCopy()
-	}
 	if in.Message != nil {
 		in, out := &in.Message, &out.Message
 		*out = new(string)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZonePolicyStatus.
-func (in *DNSHostedZonePolicyStatus) DeepCopy() *DNSHostedZonePolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSClassProfileStatus.
+func (in *DNSClassProfileStatus) DeepCopy() *DNSClassProfileStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSHostedZonePolicyStatus)
+	out := new(DNSClassProfileStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSLock) DeepCopyInto(out *DNSLock) {
+func (in *DNSElection) DeepCopyInto(out *DNSElection) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -457,18 +473,18 @@ func (in *DNSLock) DeepCopyInto(out *DNSLock) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLock.
-func (in *DNSLock) DeepCopy() *DNSLock {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSElection.
+func (in *DNSElection) DeepCopy() *DNSElection {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSLock)
+	out := new(DNSElection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSLock) DeepCopyObject() runtime.Object {
+func (in *DNSElection) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -476,13 +492,13 @@ func (in *DNSLock) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSLockList) DeepCopyInto(out *DNSLockList) {
+func (in *DNSElectionList) DeepCopyInto(out *DNSElectionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DNSLock, len(*in))
+		*out = make([]DNSElection, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -490,18 +506,18 @@ func (in *DNSLockList) DeepCopyInto(out *DNSLockList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLockList.
-func (in *DNSLockList) DeepCopy() *DNSLockList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSElectionList.
+func (in *DNSElectionList) DeepCopy() *DNSElectionList {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSLockList)
+	out := new(DNSElectionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSLockList) DeepCopyObject() runtime.Object {
+func (in *DNSElectionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -509,73 +525,84 @@ func (in *DNSLockList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSLockSpec) DeepCopyInto(out *DNSLockSpec) {
+func (in *DNSElectionSpec) DeepCopyInto(out *DNSElectionSpec) {
 	*out = *in
 	if in.LockId != nil {
 		in, out := &in.LockId, &out.LockId
 		*out = new(string)
 		**out = **in
 	}
-	in.Timestamp.DeepCopyInto(&out.Timestamp)
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Candidate != nil {
+		in, out := &in.Candidate, &out.Candidate
+		*out = new(string)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Lookup != nil {
+		in, out := &in.Lookup, &out.Lookup
+		*out = new(DNSLockLookup)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLockSpec.
-func (in *DNSLockSpec) DeepCopy() *DNSLockSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSElectionSpec.
+func (in *DNSElectionSpec) DeepCopy() *DNSElectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSLockSpec)
+	out := new(DNSElectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSLockStatus) DeepCopyInto(out *DNSLockStatus) {
+func (in *DNSElectionStatus) DeepCopyInto(out *DNSElectionStatus) {
 	*out = *in
-	in.DNSBaseStatus.DeepCopyInto(&out.DNSBaseStatus)
-	if in.Timestamp != nil {
-		in, out := &in.Timestamp, &out.Timestamp
-		*out = (*in).DeepCopy()
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
 	}
-	if in.LockId != nil {
-		in, out := &in.LockId, &out.LockId
+	if in.Leader != nil {
+		in, out := &in.Leader, &out.Leader
 		*out = new(string)
 		**out = **in
 	}
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.LeaseExpiration != nil {
+		in, out := &in.LeaseExpiration, &out.LeaseExpiration
+		*out = (*in).DeepCopy()
 	}
-	if in.FirstFailedDNSLookup != nil {
-		in, out := &in.FirstFailedDNSLookup, &out.FirstFailedDNSLookup
+	if in.LeaderTransitionTime != nil {
+		in, out := &in.LeaderTransitionTime, &out.LeaderTransitionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLockStatus.
-func (in *DNSLockStatus) DeepCopy() *DNSLockStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSElectionStatus.
+func (in *DNSElectionStatus) DeepCopy() *DNSElectionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSLockStatus)
+	out := new(DNSElectionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSOwner) DeepCopyInto(out *DNSOwner) {
+func (in *DNSEntry) DeepCopyInto(out *DNSEntry) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -584,18 +611,18 @@ func (in *DNSOwner) DeepCopyInto(out *DNSOwner) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwner.
-func (in *DNSOwner) DeepCopy() *DNSOwner {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntry.
+func (in *DNSEntry) DeepCopy() *DNSEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSOwner)
+	out := new(DNSEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSOwner) DeepCopyObject() runtime.Object {
+func (in *DNSEntry) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -603,13 +630,13 @@ func (in *DNSOwner) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSOwnerList) DeepCopyInto(out *DNSOwnerList) {
+func (in *DNSEntryList) DeepCopyInto(out *DNSEntryList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DNSOwner, len(*in))
+		*out = make([]DNSEntry, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -617,18 +644,18 @@ func (in *DNSOwnerList) DeepCopyInto(out *DNSOwnerList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwnerList.
-func (in *DNSOwnerList) DeepCopy() *DNSOwnerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntryList.
+func (in *DNSEntryList) DeepCopy() *DNSEntryList {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSOwnerList)
+	out := new(DNSEntryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSOwnerList) DeepCopyObject() runtime.Object {
+func (in *DNSEntryList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -636,82 +663,339 @@ func (in *DNSOwnerList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSOwnerSpec) DeepCopyInto(out *DNSOwnerSpec) {
+func (in *DNSEntrySet) DeepCopyInto(out *DNSEntrySet) {
 	*out = *in
-	if in.Active != nil {
-		in, out := &in.Active, &out.Active
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DNSActivation != nil {
-		in, out := &in.DNSActivation, &out.DNSActivation
-		*out = new(DNSActivation)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ValidUntil != nil {
-		in, out := &in.ValidUntil, &out.ValidUntil
-		*out = (*in).DeepCopy()
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwnerSpec.
-func (in *DNSOwnerSpec) DeepCopy() *DNSOwnerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntrySet.
+func (in *DNSEntrySet) DeepCopy() *DNSEntrySet {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSOwnerSpec)
+	out := new(DNSEntrySet)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSOwnerStatus) DeepCopyInto(out *DNSOwnerStatus) {
-	*out = *in
-	if in.Active != nil {
-		in, out := &in.Active, &out.Active
-		*out = new(bool)
-		**out = **in
-	}
-	in.Entries.DeepCopyInto(&out.Entries)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwnerStatus.
-func (in *DNSOwnerStatus) DeepCopy() *DNSOwnerStatus {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSEntrySet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(DNSOwnerStatus)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSOwnerStatusEntries) DeepCopyInto(out *DNSOwnerStatusEntries) {
+func (in *DNSEntrySetItem) DeepCopyInto(out *DNSEntrySetItem) {
 	*out = *in
-	if in.ByType != nil {
-		in, out := &in.ByType, &out.ByType
-		*out = make(map[string]int, len(*in))
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Text != nil {
+		in, out := &in.Text, &out.Text
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntrySetItem.
+func (in *DNSEntrySetItem) DeepCopy() *DNSEntrySetItem {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSEntrySetItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSEntrySetList) DeepCopyInto(out *DNSEntrySetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSEntrySet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntrySetList.
+func (in *DNSEntrySetList) DeepCopy() *DNSEntrySetList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSEntrySetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSEntrySetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSEntrySetSpec) DeepCopyInto(out *DNSEntrySetSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]DNSEntrySetItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ZoneFile != nil {
+		in, out := &in.ZoneFile, &out.ZoneFile
+		*out = new(ZoneFileSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntrySetSpec.
+func (in *DNSEntrySetSpec) DeepCopy() *DNSEntrySetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSEntrySetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSEntrySetStatus) DeepCopyInto(out *DNSEntrySetStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntrySetStatus.
+func (in *DNSEntrySetStatus) DeepCopy() *DNSEntrySetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSEntrySetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSEntrySetTemplate) DeepCopyInto(out *DNSEntrySetTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
+	if in.OwnerId != nil {
+		in, out := &in.OwnerId, &out.OwnerId
+		*out = new(string)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CNameLookupInterval != nil {
+		in, out := &in.CNameLookupInterval, &out.CNameLookupInterval
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(string)
+		**out = **in
+	}
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntrySetTemplate.
+func (in *DNSEntrySetTemplate) DeepCopy() *DNSEntrySetTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSEntrySetTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSEntrySpec) DeepCopyInto(out *DNSEntrySpec) {
+	*out = *in
+	if in.Reference != nil {
+		in, out := &in.Reference, &out.Reference
+		*out = new(EntryReference)
+		**out = **in
+	}
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(TargetReference)
+		**out = **in
+	}
+	if in.OwnerId != nil {
+		in, out := &in.OwnerId, &out.OwnerId
+		*out = new(string)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CNameLookupInterval != nil {
+		in, out := &in.CNameLookupInterval, &out.CNameLookupInterval
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ResolveTargetsToAddresses != nil {
+		in, out := &in.ResolveTargetsToAddresses, &out.ResolveTargetsToAddresses
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Text != nil {
+		in, out := &in.Text, &out.Text
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RoutingPolicy != nil {
+		in, out := &in.RoutingPolicy, &out.RoutingPolicy
+		*out = new(RoutingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(string)
+		**out = **in
+	}
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(DeletionPolicy)
+		**out = **in
+	}
+	if in.ActivateAt != nil {
+		in, out := &in.ActivateAt, &out.ActivateAt
+		*out = (*in).DeepCopy()
+	}
+	if in.DeactivateAt != nil {
+		in, out := &in.DeactivateAt, &out.DeactivateAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LifetimeSeconds != nil {
+		in, out := &in.LifetimeSeconds, &out.LifetimeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntrySpec.
+func (in *DNSEntrySpec) DeepCopy() *DNSEntrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSEntrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSEntryStatus) DeepCopyInto(out *DNSEntryStatus) {
+	*out = *in
+	in.DNSBaseStatus.DeepCopyInto(&out.DNSBaseStatus)
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RoutingPolicy != nil {
+		in, out := &in.RoutingPolicy, &out.RoutingPolicy
+		*out = new(RoutingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProviderRecords != nil {
+		in, out := &in.ProviderRecords, &out.ProviderRecords
+		*out = new(ProviderRecords)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwnerStatusEntries.
-func (in *DNSOwnerStatusEntries) DeepCopy() *DNSOwnerStatusEntries {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntryStatus.
+func (in *DNSEntryStatus) DeepCopy() *DNSEntryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSOwnerStatusEntries)
+	out := new(DNSEntryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSProvider) DeepCopyInto(out *DNSProvider) {
+func (in *DNSHealthCheck) DeepCopyInto(out *DNSHealthCheck) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -720,18 +1004,18 @@ func (in *DNSProvider) DeepCopyInto(out *DNSProvider) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProvider.
-func (in *DNSProvider) DeepCopy() *DNSProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHealthCheck.
+func (in *DNSHealthCheck) DeepCopy() *DNSHealthCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSProvider)
+	out := new(DNSHealthCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSProvider) DeepCopyObject() runtime.Object {
+func (in *DNSHealthCheck) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -739,13 +1023,13 @@ func (in *DNSProvider) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSProviderList) DeepCopyInto(out *DNSProviderList) {
+func (in *DNSHealthCheckList) DeepCopyInto(out *DNSHealthCheckList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DNSProvider, len(*in))
+		*out = make([]DNSHealthCheck, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -753,171 +1037,1616 @@ func (in *DNSProviderList) DeepCopyInto(out *DNSProviderList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderList.
-func (in *DNSProviderList) DeepCopy() *DNSProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHealthCheckList.
+func (in *DNSHealthCheckList) DeepCopy() *DNSHealthCheckList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHealthCheckList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSHealthCheckList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHealthCheckSpec) DeepCopyInto(out *DNSHealthCheckSpec) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int)
+		**out = **in
+	}
+	if in.RequestInterval != nil {
+		in, out := &in.RequestInterval, &out.RequestInterval
+		*out = new(int)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHealthCheckSpec.
+func (in *DNSHealthCheckSpec) DeepCopy() *DNSHealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHealthCheckStatus) DeepCopyInto(out *DNSHealthCheckStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHealthCheckStatus.
+func (in *DNSHealthCheckStatus) DeepCopy() *DNSHealthCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHealthCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHostedZonePolicy) DeepCopyInto(out *DNSHostedZonePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZonePolicy.
+func (in *DNSHostedZonePolicy) DeepCopy() *DNSHostedZonePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHostedZonePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSHostedZonePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHostedZonePolicyList) DeepCopyInto(out *DNSHostedZonePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSHostedZonePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZonePolicyList.
+func (in *DNSHostedZonePolicyList) DeepCopy() *DNSHostedZonePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHostedZonePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSHostedZonePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHostedZonePolicySpec) DeepCopyInto(out *DNSHostedZonePolicySpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	in.Policy.DeepCopyInto(&out.Policy)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZonePolicySpec.
+func (in *DNSHostedZonePolicySpec) DeepCopy() *DNSHostedZonePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHostedZonePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHostedZonePolicyStatus) DeepCopyInto(out *DNSHostedZonePolicyStatus) {
+	*out = *in
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(int)
+		**out = **in
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ZoneInfo, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastStatusUpdateTime != nil {
+		in, out := &in.LastStatusUpdateTime, &out.LastStatusUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZonePolicyStatus.
+func (in *DNSHostedZonePolicyStatus) DeepCopy() *DNSHostedZonePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHostedZonePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLock) DeepCopyInto(out *DNSLock) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLock.
+func (in *DNSLock) DeepCopy() *DNSLock {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSLock) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLockList) DeepCopyInto(out *DNSLockList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSLock, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLockList.
+func (in *DNSLockList) DeepCopy() *DNSLockList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLockList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSLockList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLockLookup) DeepCopyInto(out *DNSLockLookup) {
+	*out = *in
+	if in.Resolvers != nil {
+		in, out := &in.Resolvers, &out.Resolvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLockLookup.
+func (in *DNSLockLookup) DeepCopy() *DNSLockLookup {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLockLookup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLockSpec) DeepCopyInto(out *DNSLockSpec) {
+	*out = *in
+	if in.LockId != nil {
+		in, out := &in.LockId, &out.LockId
+		*out = new(string)
+		**out = **in
+	}
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Lease != nil {
+		in, out := &in.Lease, &out.Lease
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Holder != nil {
+		in, out := &in.Holder, &out.Holder
+		*out = new(string)
+		**out = **in
+	}
+	if in.Lookup != nil {
+		in, out := &in.Lookup, &out.Lookup
+		*out = new(DNSLockLookup)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLockSpec.
+func (in *DNSLockSpec) DeepCopy() *DNSLockSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLockSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSLockStatus) DeepCopyInto(out *DNSLockStatus) {
+	*out = *in
+	in.DNSBaseStatus.DeepCopyInto(&out.DNSBaseStatus)
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LockId != nil {
+		in, out := &in.LockId, &out.LockId
+		*out = new(string)
+		**out = **in
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FirstFailedDNSLookup != nil {
+		in, out := &in.FirstFailedDNSLookup, &out.FirstFailedDNSLookup
+		*out = (*in).DeepCopy()
+	}
+	if in.Holder != nil {
+		in, out := &in.Holder, &out.Holder
+		*out = new(string)
+		**out = **in
+	}
+	if in.LeaseExpiration != nil {
+		in, out := &in.LeaseExpiration, &out.LeaseExpiration
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSLockStatus.
+func (in *DNSLockStatus) DeepCopy() *DNSLockStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSLockStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOwner) DeepCopyInto(out *DNSOwner) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwner.
+func (in *DNSOwner) DeepCopy() *DNSOwner {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOwner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSOwner) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOwnerList) DeepCopyInto(out *DNSOwnerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSOwner, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwnerList.
+func (in *DNSOwnerList) DeepCopy() *DNSOwnerList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOwnerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSOwnerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOwnerSpec) DeepCopyInto(out *DNSOwnerSpec) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DNSActivation != nil {
+		in, out := &in.DNSActivation, &out.DNSActivation
+		*out = new(DNSActivation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ValidFrom != nil {
+		in, out := &in.ValidFrom, &out.ValidFrom
+		*out = (*in).DeepCopy()
+	}
+	if in.ValidUntil != nil {
+		in, out := &in.ValidUntil, &out.ValidUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.DeactivationPolicy != nil {
+		in, out := &in.DeactivationPolicy, &out.DeactivationPolicy
+		*out = new(DeletionPolicy)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwnerSpec.
+func (in *DNSOwnerSpec) DeepCopy() *DNSOwnerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOwnerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOwnerStatus) DeepCopyInto(out *DNSOwnerStatus) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = new(bool)
+		**out = **in
+	}
+	in.Entries.DeepCopyInto(&out.Entries)
+	if in.LastChangeTime != nil {
+		in, out := &in.LastChangeTime, &out.LastChangeTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwnerStatus.
+func (in *DNSOwnerStatus) DeepCopy() *DNSOwnerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOwnerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOwnerStatusEntries) DeepCopyInto(out *DNSOwnerStatusEntries) {
+	*out = *in
+	if in.ByType != nil {
+		in, out := &in.ByType, &out.ByType
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ByProvider != nil {
+		in, out := &in.ByProvider, &out.ByProvider
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ByZone != nil {
+		in, out := &in.ByZone, &out.ByZone
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwnerStatusEntries.
+func (in *DNSOwnerStatusEntries) DeepCopy() *DNSOwnerStatusEntries {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOwnerStatusEntries)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSPolicy) DeepCopyInto(out *DNSPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPolicy.
+func (in *DNSPolicy) DeepCopy() *DNSPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSPolicyList) DeepCopyInto(out *DNSPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPolicyList.
+func (in *DNSPolicyList) DeepCopy() *DNSPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSPolicySpec) DeepCopyInto(out *DNSPolicySpec) {
+	*out = *in
+	if in.AllowedDomains != nil {
+		in, out := &in.AllowedDomains, &out.AllowedDomains
+		*out = make([]DomainRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MinTTL != nil {
+		in, out := &in.MinTTL, &out.MinTTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxTTL != nil {
+		in, out := &in.MaxTTL, &out.MaxTTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ForbiddenRecordTypes != nil {
+		in, out := &in.ForbiddenRecordTypes, &out.ForbiddenRecordTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Wildcards != nil {
+		in, out := &in.Wildcards, &out.Wildcards
+		*out = new(WildcardRestriction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = make([]EntryDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = make([]NamespaceQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPolicySpec.
+func (in *DNSPolicySpec) DeepCopy() *DNSPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSPolicyStatus) DeepCopyInto(out *DNSPolicyStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSPolicyStatus.
+func (in *DNSPolicyStatus) DeepCopy() *DNSPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProvider) DeepCopyInto(out *DNSProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProvider.
+func (in *DNSProvider) DeepCopy() *DNSProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderList) DeepCopyInto(out *DNSProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderList.
+func (in *DNSProviderList) DeepCopy() *DNSProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderSpec) DeepCopyInto(out *DNSProviderSpec) {
+	*out = *in
+	if in.ProviderConfig != nil {
+		in, out := &in.ProviderConfig, &out.ProviderConfig
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+	if in.SecondarySecretRef != nil {
+		in, out := &in.SecondarySecretRef, &out.SecondarySecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxyURL != nil {
+		in, out := &in.ProxyURL, &out.ProxyURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = new(DNSSelection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = new(DNSSelection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultTTL != nil {
+		in, out := &in.DefaultTTL, &out.DefaultTTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefaultDeletionPolicy != nil {
+		in, out := &in.DefaultDeletionPolicy, &out.DefaultDeletionPolicy
+		*out = new(DeletionPolicy)
+		**out = **in
+	}
+	if in.UpsertOnly != nil {
+		in, out := &in.UpsertOnly, &out.UpsertOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedNamespaceSelector != nil {
+		in, out := &in.AllowedNamespaceSelector, &out.AllowedNamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetCIDRs != nil {
+		in, out := &in.TargetCIDRs, &out.TargetCIDRs
+		*out = new(TargetCIDRs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		**out = **in
+	}
+	if in.RecordImport != nil {
+		in, out := &in.RecordImport, &out.RecordImport
+		*out = new(RecordImport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(ProviderSync)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderSpec.
+func (in *DNSProviderSpec) DeepCopy() *DNSProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSProviderStatus) DeepCopyInto(out *DNSProviderStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastUptimeTime != nil {
+		in, out := &in.LastUptimeTime, &out.LastUptimeTime
+		*out = (*in).DeepCopy()
+	}
+	in.Domains.DeepCopyInto(&out.Domains)
+	in.Zones.DeepCopyInto(&out.Zones)
+	if in.DefaultTTL != nil {
+		in, out := &in.DefaultTTL, &out.DefaultTTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderStatus.
+func (in *DNSProviderStatus) DeepCopy() *DNSProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSelection) DeepCopyInto(out *DNSSelection) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSelection.
+func (in *DNSSelection) DeepCopy() *DNSSelection {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSelection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSelectionStatus) DeepCopyInto(out *DNSSelectionStatus) {
+	*out = *in
+	if in.Included != nil {
+		in, out := &in.Included, &out.Included
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Excluded != nil {
+		in, out := &in.Excluded, &out.Excluded
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSelectionStatus.
+func (in *DNSSelectionStatus) DeepCopy() *DNSSelectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSelectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSwitch) DeepCopyInto(out *DNSSwitch) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSwitch.
+func (in *DNSSwitch) DeepCopy() *DNSSwitch {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSwitch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSSwitch) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSwitchHistoryItem) DeepCopyInto(out *DNSSwitchHistoryItem) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSwitchHistoryItem.
+func (in *DNSSwitchHistoryItem) DeepCopy() *DNSSwitchHistoryItem {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSwitchHistoryItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSwitchList) DeepCopyInto(out *DNSSwitchList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSSwitch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSwitchList.
+func (in *DNSSwitchList) DeepCopy() *DNSSwitchList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSwitchList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSSwitchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSwitchSide) DeepCopyInto(out *DNSSwitchSide) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Text != nil {
+		in, out := &in.Text, &out.Text
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Entry != nil {
+		in, out := &in.Entry, &out.Entry
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSwitchSide.
+func (in *DNSSwitchSide) DeepCopy() *DNSSwitchSide {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSwitchSide)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSwitchSpec) DeepCopyInto(out *DNSSwitchSpec) {
+	*out = *in
+	if in.OwnerId != nil {
+		in, out := &in.OwnerId, &out.OwnerId
+		*out = new(string)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(string)
+		**out = **in
+	}
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	in.Blue.DeepCopyInto(&out.Blue)
+	in.Green.DeepCopyInto(&out.Green)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSwitchSpec.
+func (in *DNSSwitchSpec) DeepCopy() *DNSSwitchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSwitchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSwitchStatus) DeepCopyInto(out *DNSSwitchStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]DNSSwitchHistoryItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSwitchStatus.
+func (in *DNSSwitchStatus) DeepCopy() *DNSSwitchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSwitchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSTrafficShift) DeepCopyInto(out *DNSTrafficShift) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSTrafficShift.
+func (in *DNSTrafficShift) DeepCopy() *DNSTrafficShift {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSTrafficShift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSTrafficShift) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSTrafficShiftHealthCheck) DeepCopyInto(out *DNSTrafficShiftHealthCheck) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSTrafficShiftHealthCheck.
+func (in *DNSTrafficShiftHealthCheck) DeepCopy() *DNSTrafficShiftHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSTrafficShiftHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSTrafficShiftList) DeepCopyInto(out *DNSTrafficShiftList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSTrafficShift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSTrafficShiftList.
+func (in *DNSTrafficShiftList) DeepCopy() *DNSTrafficShiftList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSTrafficShiftList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSTrafficShiftList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSTrafficShiftSpec) DeepCopyInto(out *DNSTrafficShiftSpec) {
+	*out = *in
+	if in.StepWeight != nil {
+		in, out := &in.StepWeight, &out.StepWeight
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(DNSTrafficShiftHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSTrafficShiftSpec.
+func (in *DNSTrafficShiftSpec) DeepCopy() *DNSTrafficShiftSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSTrafficShiftSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSTrafficShiftStatus) DeepCopyInto(out *DNSTrafficShiftStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastStep != nil {
+		in, out := &in.LastStep, &out.LastStep
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSTrafficShiftStatus.
+func (in *DNSTrafficShiftStatus) DeepCopy() *DNSTrafficShiftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSTrafficShiftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
+func (in *DNSZone) DeepCopy() *DNSZone {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSZone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneList.
+func (in *DNSZoneList) DeepCopy() *DNSZoneList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZoneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
+	*out = *in
+	if in.PrivateNetworks != nil {
+		in, out := &in.PrivateNetworks, &out.PrivateNetworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneSpec.
+func (in *DNSZoneSpec) DeepCopy() *DNSZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.NameServers != nil {
+		in, out := &in.NameServers, &out.NameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneStatus.
+func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainRule) DeepCopyInto(out *DomainRule) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DomainSuffixes != nil {
+		in, out := &in.DomainSuffixes, &out.DomainSuffixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainRule.
+func (in *DomainRule) DeepCopy() *DomainRule {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntryDefaults) DeepCopyInto(out *EntryDefaults) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.OwnerId != nil {
+		in, out := &in.OwnerId, &out.OwnerId
+		*out = new(string)
+		**out = **in
+	}
+	if in.Class != nil {
+		in, out := &in.Class, &out.Class
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntryDefaults.
+func (in *EntryDefaults) DeepCopy() *EntryDefaults {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSProviderList)
+	out := new(EntryDefaults)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSProviderList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntryReference) DeepCopyInto(out *EntryReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntryReference.
+func (in *EntryReference) DeepCopy() *EntryReference {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(EntryReference)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSProviderSpec) DeepCopyInto(out *DNSProviderSpec) {
+func (in *NameRestriction) DeepCopyInto(out *NameRestriction) {
 	*out = *in
-	if in.ProviderConfig != nil {
-		in, out := &in.ProviderConfig, &out.ProviderConfig
-		*out = new(runtime.RawExtension)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.SecretReference)
-		**out = **in
-	}
-	if in.Domains != nil {
-		in, out := &in.Domains, &out.Domains
-		*out = new(DNSSelection)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Zones != nil {
-		in, out := &in.Zones, &out.Zones
-		*out = new(DNSSelection)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DefaultTTL != nil {
-		in, out := &in.DefaultTTL, &out.DefaultTTL
-		*out = new(int64)
-		**out = **in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.RateLimit != nil {
-		in, out := &in.RateLimit, &out.RateLimit
-		*out = new(RateLimit)
-		**out = **in
+	if in.AllowedOwnerIds != nil {
+		in, out := &in.AllowedOwnerIds, &out.AllowedOwnerIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderSpec.
-func (in *DNSProviderSpec) DeepCopy() *DNSProviderSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NameRestriction.
+func (in *NameRestriction) DeepCopy() *NameRestriction {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSProviderSpec)
+	out := new(NameRestriction)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSProviderStatus) DeepCopyInto(out *DNSProviderStatus) {
+func (in *NamespaceQuota) DeepCopyInto(out *NamespaceQuota) {
 	*out = *in
-	if in.Message != nil {
-		in, out := &in.Message, &out.Message
-		*out = new(string)
-		**out = **in
-	}
-	if in.LastUptimeTime != nil {
-		in, out := &in.LastUptimeTime, &out.LastUptimeTime
-		*out = (*in).DeepCopy()
-	}
-	in.Domains.DeepCopyInto(&out.Domains)
-	in.Zones.DeepCopyInto(&out.Zones)
-	if in.DefaultTTL != nil {
-		in, out := &in.DefaultTTL, &out.DefaultTTL
-		*out = new(int64)
-		**out = **in
-	}
-	if in.RateLimit != nil {
-		in, out := &in.RateLimit, &out.RateLimit
-		*out = new(RateLimit)
-		**out = **in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSProviderStatus.
-func (in *DNSProviderStatus) DeepCopy() *DNSProviderStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceQuota.
+func (in *NamespaceQuota) DeepCopy() *NamespaceQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSProviderStatus)
+	out := new(NamespaceQuota)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSSelection) DeepCopyInto(out *DNSSelection) {
+func (in *ProviderRecordSet) DeepCopyInto(out *ProviderRecordSet) {
 	*out = *in
-	if in.Include != nil {
-		in, out := &in.Include, &out.Include
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Exclude != nil {
-		in, out := &in.Exclude, &out.Exclude
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSelection.
-func (in *DNSSelection) DeepCopy() *DNSSelection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderRecordSet.
+func (in *ProviderRecordSet) DeepCopy() *ProviderRecordSet {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSSelection)
+	out := new(ProviderRecordSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSSelectionStatus) DeepCopyInto(out *DNSSelectionStatus) {
+func (in *ProviderRecords) DeepCopyInto(out *ProviderRecords) {
 	*out = *in
-	if in.Included != nil {
-		in, out := &in.Included, &out.Included
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.RoutingPolicy != nil {
+		in, out := &in.RoutingPolicy, &out.RoutingPolicy
+		*out = new(RoutingPolicy)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Excluded != nil {
-		in, out := &in.Excluded, &out.Excluded
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.RecordSets != nil {
+		in, out := &in.RecordSets, &out.RecordSets
+		*out = make([]ProviderRecordSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSelectionStatus.
-func (in *DNSSelectionStatus) DeepCopy() *DNSSelectionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderRecords.
+func (in *ProviderRecords) DeepCopy() *ProviderRecords {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSSelectionStatus)
+	out := new(ProviderRecords)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EntryReference) DeepCopyInto(out *EntryReference) {
+func (in *ProviderSync) DeepCopyInto(out *ProviderSync) {
 	*out = *in
+	if in.ZonesCacheTTL != nil {
+		in, out := &in.ZonesCacheTTL, &out.ZonesCacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ZoneStateCacheTTL != nil {
+		in, out := &in.ZoneStateCacheTTL, &out.ZoneStateCacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ResyncPeriod != nil {
+		in, out := &in.ResyncPeriod, &out.ResyncPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntryReference.
-func (in *EntryReference) DeepCopy() *EntryReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderSync.
+func (in *ProviderSync) DeepCopy() *ProviderSync {
 	if in == nil {
 		return nil
 	}
-	out := new(EntryReference)
+	out := new(ProviderSync)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -938,6 +2667,42 @@ func (in *RateLimit) DeepCopy() *RateLimit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordImport) DeepCopyInto(out *RecordImport) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.OwnerId != nil {
+		in, out := &in.OwnerId, &out.OwnerId
+		*out = new(string)
+		**out = **in
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExternalDNS != nil {
+		in, out := &in.ExternalDNS, &out.ExternalDNS
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordImport.
+func (in *RecordImport) DeepCopy() *RecordImport {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteAccessCertificate) DeepCopyInto(out *RemoteAccessCertificate) {
 	*out = *in
@@ -1060,6 +2825,27 @@ func (in *ResourceReference) DeepCopy() *ResourceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSelector.
+func (in *ResourceSelector) DeepCopy() *ResourceSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoutingPolicy) DeepCopyInto(out *RoutingPolicy) {
 	*out = *in
@@ -1083,6 +2869,108 @@ func (in *RoutingPolicy) DeepCopy() *RoutingPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetCIDRs) DeepCopyInto(out *TargetCIDRs) {
+	*out = *in
+	if in.Allowed != nil {
+		in, out := &in.Allowed, &out.Allowed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Denied != nil {
+		in, out := &in.Denied, &out.Denied
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetCIDRs.
+func (in *TargetCIDRs) DeepCopy() *TargetCIDRs {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetCIDRs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetReference) DeepCopyInto(out *TargetReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetReference.
+func (in *TargetReference) DeepCopy() *TargetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultReference) DeepCopyInto(out *VaultReference) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultReference.
+func (in *VaultReference) DeepCopy() *VaultReference {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WildcardRestriction) DeepCopyInto(out *WildcardRestriction) {
+	*out = *in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WildcardRestriction.
+func (in *WildcardRestriction) DeepCopy() *WildcardRestriction {
+	if in == nil {
+		return nil
+	}
+	out := new(WildcardRestriction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneFileSource) DeepCopyInto(out *ZoneFileSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneFileSource.
+func (in *ZoneFileSource) DeepCopy() *ZoneFileSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneFileSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZoneInfo) DeepCopyInto(out *ZoneInfo) {
 	*out = *in
@@ -1104,7 +2992,37 @@ func (in *ZonePolicy) DeepCopyInto(out *ZonePolicy) {
 	*out = *in
 	if in.ZoneStateCacheTTL != nil {
 		in, out := &in.ZoneStateCacheTTL, &out.ZoneStateCacheTTL
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinTTL != nil {
+		in, out := &in.MinTTL, &out.MinTTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxTTL != nil {
+		in, out := &in.MaxTTL, &out.MaxTTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AllowedRecordTypes != nil {
+		in, out := &in.AllowedRecordTypes, &out.AllowedRecordTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Wildcards != nil {
+		in, out := &in.Wildcards, &out.Wildcards
+		*out = new(NameRestriction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Apex != nil {
+		in, out := &in.Apex, &out.Apex
+		*out = new(NameRestriction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DuplicatePolicy != nil {
+		in, out := &in.DuplicatePolicy, &out.DuplicatePolicy
+		*out = new(DuplicatePolicy)
 		**out = **in
 	}
 	return
@@ -0,0 +1,330 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+// ConvertObject converts a serialized DNSEntry to the desired API version.
+// Objects already having the desired version are returned unchanged.
+func ConvertObject(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	meta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, meta); err != nil {
+		return nil, err
+	}
+	if meta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+	if meta.Kind != DNSEntryKind {
+		return nil, fmt.Errorf("conversion of kind %s not supported", meta.Kind)
+	}
+	switch {
+	case meta.APIVersion == v1alpha1.SchemeGroupVersion.String() && desiredAPIVersion == SchemeGroupVersion.String():
+		in := &v1alpha1.DNSEntry{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return nil, err
+		}
+		out := &DNSEntry{}
+		ConvertFromV1alpha1(in, out)
+		return json.Marshal(out)
+	case meta.APIVersion == SchemeGroupVersion.String() && desiredAPIVersion == v1alpha1.SchemeGroupVersion.String():
+		in := &DNSEntry{}
+		if err := json.Unmarshal(raw, in); err != nil {
+			return nil, err
+		}
+		out := &v1alpha1.DNSEntry{}
+		ConvertToV1alpha1(in, out)
+		return json.Marshal(out)
+	}
+	return nil, fmt.Errorf("conversion from %s to %s not supported", meta.APIVersion, desiredAPIVersion)
+}
+
+// ConvertFromV1alpha1 converts a v1alpha1 DNSEntry to the v1beta1 version.
+// The targets are split into addresses and host names, the state is mapped to the Ready condition.
+func ConvertFromV1alpha1(in *v1alpha1.DNSEntry, out *DNSEntry) {
+	out.TypeMeta = metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: DNSEntryKind}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+
+	spec := &in.Spec
+	out.Spec = DNSEntrySpec{
+		DNSName:                   spec.DNSName,
+		OwnerId:                   copyString(spec.OwnerId),
+		TTL:                       copyInt64(spec.TTL),
+		CNameLookupInterval:       copyInt64(spec.CNameLookupInterval),
+		ResolveTargetsToAddresses: copyBool(spec.ResolveTargetsToAddresses),
+		Provider:                  copyString(spec.Provider),
+		Zone:                      copyString(spec.Zone),
+	}
+	if spec.Reference != nil {
+		out.Spec.Reference = &EntryReference{Name: spec.Reference.Name, Namespace: spec.Reference.Namespace}
+	}
+	if ref := spec.TargetRef; ref != nil {
+		out.Spec.TargetRef = &TargetReference{APIVersion: ref.APIVersion, Kind: ref.Kind, Name: ref.Name, Namespace: ref.Namespace}
+	}
+	if len(spec.Targets) > 0 || len(spec.Text) > 0 {
+		records := &RecordSets{}
+		for _, t := range spec.Targets {
+			if net.ParseIP(t) != nil {
+				records.Addresses = append(records.Addresses, t)
+			} else {
+				records.Hostnames = append(records.Hostnames, t)
+			}
+		}
+		records.Text = append(records.Text, spec.Text...)
+		out.Spec.Records = records
+	}
+	out.Spec.RoutingPolicy = routingPolicyFromV1alpha1(spec.RoutingPolicy)
+	if spec.DeletionPolicy != nil {
+		policy := DeletionPolicy(*spec.DeletionPolicy)
+		out.Spec.DeletionPolicy = &policy
+	}
+	out.Spec.ActivateAt = spec.ActivateAt.DeepCopy()
+	out.Spec.DeactivateAt = spec.DeactivateAt.DeepCopy()
+	out.Spec.LifetimeSeconds = copyInt64(spec.LifetimeSeconds)
+
+	status := &in.Status
+	out.Status = DNSEntryStatus{
+		ObservedGeneration: status.ObservedGeneration,
+		LastUpdateTime:     status.LastUptimeTime.DeepCopy(),
+		ProviderType:       copyString(status.ProviderType),
+		Provider:           copyString(status.Provider),
+		Zone:               copyString(status.Zone),
+		TTL:                copyInt64(status.TTL),
+		Targets:            append([]string(nil), status.Targets...),
+		RoutingPolicy:      routingPolicyFromV1alpha1(status.RoutingPolicy),
+		SourceCluster:      status.SourceCluster,
+		ProviderRecords:    providerRecordsFromV1alpha1(status.ProviderRecords),
+	}
+	if cond := findCondition(status.Conditions, ConditionTypeReady); cond != nil {
+		out.Status.Conditions = []metav1.Condition{*cond.DeepCopy()}
+	} else if cond := stateCondition(in); cond != nil {
+		out.Status.Conditions = []metav1.Condition{*cond}
+	}
+	for _, cond := range status.Conditions {
+		if cond.Type != ConditionTypeReady {
+			out.Status.Conditions = append(out.Status.Conditions, *cond.DeepCopy())
+		}
+	}
+}
+
+// ConvertToV1alpha1 converts a v1beta1 DNSEntry to the v1alpha1 version.
+func ConvertToV1alpha1(in *DNSEntry, out *v1alpha1.DNSEntry) {
+	out.TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: v1alpha1.DNSEntryKind}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+
+	spec := &in.Spec
+	out.Spec = v1alpha1.DNSEntrySpec{
+		DNSName:                   spec.DNSName,
+		OwnerId:                   copyString(spec.OwnerId),
+		TTL:                       copyInt64(spec.TTL),
+		CNameLookupInterval:       copyInt64(spec.CNameLookupInterval),
+		ResolveTargetsToAddresses: copyBool(spec.ResolveTargetsToAddresses),
+		Provider:                  copyString(spec.Provider),
+		Zone:                      copyString(spec.Zone),
+	}
+	if spec.Reference != nil {
+		out.Spec.Reference = &v1alpha1.EntryReference{Name: spec.Reference.Name, Namespace: spec.Reference.Namespace}
+	}
+	if ref := spec.TargetRef; ref != nil {
+		out.Spec.TargetRef = &v1alpha1.TargetReference{APIVersion: ref.APIVersion, Kind: ref.Kind, Name: ref.Name, Namespace: ref.Namespace}
+	}
+	if records := spec.Records; records != nil {
+		out.Spec.Targets = append(append([]string(nil), records.Addresses...), records.Hostnames...)
+		out.Spec.Text = append([]string(nil), records.Text...)
+		if len(out.Spec.Targets) == 0 {
+			out.Spec.Targets = nil
+		}
+		if len(out.Spec.Text) == 0 {
+			out.Spec.Text = nil
+		}
+	}
+	out.Spec.RoutingPolicy = routingPolicyToV1alpha1(spec.RoutingPolicy)
+	if spec.DeletionPolicy != nil {
+		policy := v1alpha1.DeletionPolicy(*spec.DeletionPolicy)
+		out.Spec.DeletionPolicy = &policy
+	}
+	out.Spec.ActivateAt = spec.ActivateAt.DeepCopy()
+	out.Spec.DeactivateAt = spec.DeactivateAt.DeepCopy()
+	out.Spec.LifetimeSeconds = copyInt64(spec.LifetimeSeconds)
+
+	status := &in.Status
+	out.Status = v1alpha1.DNSEntryStatus{
+		DNSBaseStatus: v1alpha1.DNSBaseStatus{
+			ObservedGeneration: status.ObservedGeneration,
+			LastUptimeTime:     status.LastUpdateTime.DeepCopy(),
+			ProviderType:       copyString(status.ProviderType),
+			Provider:           copyString(status.Provider),
+			Zone:               copyString(status.Zone),
+			TTL:                copyInt64(status.TTL),
+		},
+		Targets:         append([]string(nil), status.Targets...),
+		RoutingPolicy:   routingPolicyToV1alpha1(status.RoutingPolicy),
+		SourceCluster:   status.SourceCluster,
+		ProviderRecords: providerRecordsToV1alpha1(status.ProviderRecords),
+	}
+	if len(out.Status.Targets) == 0 {
+		out.Status.Targets = nil
+	}
+	for _, cond := range status.Conditions {
+		if cond.Type == ConditionTypeReady {
+			out.Status.State = cond.Reason
+			if cond.Reason == v1alpha1.REASON_PROPAGATING || cond.Reason == v1alpha1.REASON_NOT_PROPAGATED {
+				// records are applied, but not yet resolved by the name servers
+				out.Status.State = v1alpha1.STATE_READY
+			}
+			if cond.Message != "" {
+				msg := cond.Message
+				out.Status.Message = &msg
+			}
+		} else {
+			out.Status.Conditions = append(out.Status.Conditions, *cond.DeepCopy())
+		}
+	}
+	// keep the condition Ready maintained by the controller if it cannot be derived from the state
+	if cond := findCondition(status.Conditions, ConditionTypeReady); cond != nil {
+		if derived := stateCondition(out); derived == nil || !reflect.DeepEqual(*derived, *cond) {
+			out.Status.Conditions = append([]metav1.Condition{*cond.DeepCopy()}, out.Status.Conditions...)
+		}
+	}
+}
+
+// stateCondition derives the condition Ready from the state of a v1alpha1 DNSEntry
+// without a condition Ready.
+func stateCondition(in *v1alpha1.DNSEntry) *metav1.Condition {
+	status := &in.Status
+	if status.State == "" {
+		return nil
+	}
+	cond := &metav1.Condition{
+		Type:               ConditionTypeReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: status.ObservedGeneration,
+		LastTransitionTime: in.CreationTimestamp,
+		Reason:             status.State,
+	}
+	if status.State == v1alpha1.STATE_READY {
+		cond.Status = metav1.ConditionTrue
+	}
+	if status.LastUptimeTime != nil {
+		cond.LastTransitionTime = *status.LastUptimeTime
+	}
+	if status.Message != nil {
+		cond.Message = *status.Message
+	}
+	return cond
+}
+
+func findCondition(conditions []metav1.Condition, ctype string) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == ctype {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+func routingPolicyFromV1alpha1(in *v1alpha1.RoutingPolicy) *RoutingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := &RoutingPolicy{Type: in.Type, SetIdentifier: in.SetIdentifier}
+	if in.Parameters != nil {
+		out.Parameters = map[string]string{}
+		for k, v := range in.Parameters {
+			out.Parameters[k] = v
+		}
+	}
+	return out
+}
+
+func routingPolicyToV1alpha1(in *RoutingPolicy) *v1alpha1.RoutingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := &v1alpha1.RoutingPolicy{Type: in.Type, SetIdentifier: in.SetIdentifier}
+	if in.Parameters != nil {
+		out.Parameters = map[string]string{}
+		for k, v := range in.Parameters {
+			out.Parameters[k] = v
+		}
+	}
+	return out
+}
+
+func providerRecordsFromV1alpha1(in *v1alpha1.ProviderRecords) *ProviderRecords {
+	if in == nil {
+		return nil
+	}
+	out := &ProviderRecords{
+		Zone:          in.Zone,
+		DNSName:       in.DNSName,
+		SetIdentifier: in.SetIdentifier,
+		RoutingPolicy: routingPolicyFromV1alpha1(in.RoutingPolicy),
+	}
+	for _, rs := range in.RecordSets {
+		out.RecordSets = append(out.RecordSets, ProviderRecordSet{Type: rs.Type, TTL: rs.TTL, Values: append([]string(nil), rs.Values...)})
+	}
+	return out
+}
+
+func providerRecordsToV1alpha1(in *ProviderRecords) *v1alpha1.ProviderRecords {
+	if in == nil {
+		return nil
+	}
+	out := &v1alpha1.ProviderRecords{
+		Zone:          in.Zone,
+		DNSName:       in.DNSName,
+		SetIdentifier: in.SetIdentifier,
+		RoutingPolicy: routingPolicyToV1alpha1(in.RoutingPolicy),
+	}
+	for _, rs := range in.RecordSets {
+		out.RecordSets = append(out.RecordSets, v1alpha1.ProviderRecordSet{Type: rs.Type, TTL: rs.TTL, Values: append([]string(nil), rs.Values...)})
+	}
+	return out
+}
+
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
+
+func copyInt64(i *int64) *int64 {
+	if i == nil {
+		return nil
+	}
+	v := *i
+	return &v
+}
+
+func copyBool(b *bool) *bool {
+	if b == nil {
+		return nil
+	}
+	v := *b
+	return &v
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1beta1_test
+
+import (
+	"encoding/json"
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	. "github.com/gardener/external-dns-management/pkg/apis/dns/v1beta1"
+)
+
+var _ = ginkgov2.Describe("DNSEntry conversion", func() {
+	ttl := int64(300)
+	msg := "dns entry active"
+	now := metav1.Now()
+	retain := v1alpha1.DeletionPolicyRetain
+	zone := "Z123"
+	deactivateAt := metav1.NewTime(now.Add(time.Hour).Truncate(time.Second))
+
+	alpha := &v1alpha1.DNSEntry{
+		ObjectMeta: metav1.ObjectMeta{Name: "e", Namespace: "ns", Generation: 2},
+		Spec: v1alpha1.DNSEntrySpec{
+			DNSName: "a.example.com",
+			TTL:     &ttl,
+			Targets: []string{"1.2.3.4", "2001:db8::1", "b.example.com"},
+			RoutingPolicy: &v1alpha1.RoutingPolicy{
+				Type:          "weighted",
+				SetIdentifier: "id",
+				Parameters:    map[string]string{"weight": "10"},
+			},
+			DeletionPolicy:  &retain,
+			Zone:            &zone,
+			DeactivateAt:    &deactivateAt,
+			LifetimeSeconds: &ttl,
+		},
+		Status: v1alpha1.DNSEntryStatus{
+			DNSBaseStatus: v1alpha1.DNSBaseStatus{
+				ObservedGeneration: 2,
+				State:              v1alpha1.STATE_READY,
+				Message:            &msg,
+				LastUptimeTime:     &now,
+				TTL:                &ttl,
+			},
+			Targets:       []string{"1.2.3.4", "2001:db8::1", "b.example.com"},
+			SourceCluster: "fleet-member-1",
+			ProviderRecords: &v1alpha1.ProviderRecords{
+				Zone:    zone,
+				DNSName: "a.example.com",
+				RecordSets: []v1alpha1.ProviderRecordSet{
+					{Type: "A", TTL: ttl, Values: []string{"1.2.3.4"}},
+					{Type: "AAAA", TTL: ttl, Values: []string{"2001:db8::1"}},
+				},
+			},
+		},
+	}
+
+	ginkgov2.It("converts v1alpha1 to v1beta1", func() {
+		beta := &DNSEntry{}
+		ConvertFromV1alpha1(alpha, beta)
+		Ω(beta.APIVersion).Should(Equal("dns.gardener.cloud/v1beta1"))
+		Ω(beta.Spec.Records).Should(Equal(&RecordSets{
+			Addresses: []string{"1.2.3.4", "2001:db8::1"},
+			Hostnames: []string{"b.example.com"},
+		}))
+		Ω(beta.Spec.RoutingPolicy.Parameters).Should(Equal(map[string]string{"weight": "10"}))
+		Ω(*beta.Spec.DeletionPolicy).Should(Equal(DeletionPolicyRetain))
+		Ω(*beta.Spec.Zone).Should(Equal(zone))
+		Ω(beta.Spec.DeactivateAt).Should(Equal(&deactivateAt))
+		Ω(beta.Spec.ActivateAt).Should(BeNil())
+		Ω(*beta.Spec.LifetimeSeconds).Should(Equal(ttl))
+		Ω(beta.Status.Conditions).Should(HaveLen(1))
+		Ω(beta.Status.Conditions[0].Status).Should(Equal(metav1.ConditionTrue))
+		Ω(beta.Status.Conditions[0].Reason).Should(Equal(v1alpha1.STATE_READY))
+		Ω(beta.Status.Conditions[0].Message).Should(Equal(msg))
+	})
+
+	ginkgov2.It("converts back to v1alpha1 without loss", func() {
+		beta := &DNSEntry{}
+		ConvertFromV1alpha1(alpha, beta)
+		back := &v1alpha1.DNSEntry{}
+		ConvertToV1alpha1(beta, back)
+		Ω(back.APIVersion).Should(Equal("dns.gardener.cloud/v1alpha1"))
+		Ω(back.Spec).Should(Equal(alpha.Spec))
+		Ω(back.Status).Should(Equal(alpha.Status))
+	})
+
+	ginkgov2.It("keeps additional conditions", func() {
+		in := alpha.DeepCopy()
+		in.Status.Conditions = []metav1.Condition{{
+			Type:               v1alpha1.CONDITION_PROPAGATED,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: 2,
+			LastTransitionTime: now,
+			Reason:             "Resolved",
+		}}
+		beta := &DNSEntry{}
+		ConvertFromV1alpha1(in, beta)
+		Ω(beta.Status.Conditions).Should(HaveLen(2))
+		Ω(beta.Status.Conditions[1]).Should(Equal(in.Status.Conditions[0]))
+
+		back := &v1alpha1.DNSEntry{}
+		ConvertToV1alpha1(beta, back)
+		Ω(back.Status).Should(Equal(in.Status))
+	})
+
+	ginkgov2.It("keeps the condition Ready maintained by the controller", func() {
+		in := alpha.DeepCopy()
+		ready := metav1.Condition{
+			Type:               v1alpha1.CONDITION_READY,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: 2,
+			LastTransitionTime: now,
+			Reason:             v1alpha1.REASON_PROPAGATING,
+			Message:            "waiting for resolution of records by name servers",
+		}
+		in.Status.Conditions = []metav1.Condition{ready}
+		beta := &DNSEntry{}
+		ConvertFromV1alpha1(in, beta)
+		Ω(beta.Status.Conditions).Should(Equal([]metav1.Condition{ready}))
+
+		back := &v1alpha1.DNSEntry{}
+		ConvertToV1alpha1(beta, back)
+		Ω(back.Status.State).Should(Equal(v1alpha1.STATE_READY))
+		Ω(back.Status.Conditions).Should(Equal([]metav1.Condition{ready}))
+	})
+
+	ginkgov2.It("converts text records and missing state", func() {
+		in := &v1alpha1.DNSEntry{Spec: v1alpha1.DNSEntrySpec{DNSName: "t.example.com", Text: []string{"foo"}}}
+		beta := &DNSEntry{}
+		ConvertFromV1alpha1(in, beta)
+		Ω(beta.Spec.Records).Should(Equal(&RecordSets{Text: []string{"foo"}}))
+		Ω(beta.Status.Conditions).Should(BeEmpty())
+
+		back := &v1alpha1.DNSEntry{}
+		ConvertToV1alpha1(beta, back)
+		Ω(back.Spec).Should(Equal(in.Spec))
+	})
+
+	ginkgov2.It("converts serialized objects", func() {
+		in := alpha.DeepCopy()
+		in.TypeMeta = metav1.TypeMeta{APIVersion: "dns.gardener.cloud/v1alpha1", Kind: "DNSEntry"}
+		raw, err := json.Marshal(in)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		converted, err := ConvertObject(raw, "dns.gardener.cloud/v1beta1")
+		Ω(err).ShouldNot(HaveOccurred())
+		beta := &DNSEntry{}
+		Ω(json.Unmarshal(converted, beta)).Should(Succeed())
+		Ω(beta.Spec.Records.Hostnames).Should(Equal([]string{"b.example.com"}))
+
+		same, err := ConvertObject(converted, "dns.gardener.cloud/v1beta1")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(same).Should(Equal(converted))
+
+		_, err = ConvertObject([]byte(`{"apiVersion":"dns.gardener.cloud/v1alpha1","kind":"DNSProvider"}`), "dns.gardener.cloud/v1beta1")
+		Ω(err).Should(MatchError("conversion of kind DNSProvider not supported"))
+	})
+})
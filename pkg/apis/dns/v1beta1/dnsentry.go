@@ -0,0 +1,229 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionTypeReady is the condition type reporting the readiness of an entry
+const ConditionTypeReady = "Ready"
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSEntryList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSEntry `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,path=dnsentries,shortName=dnse,singular=dnsentry
+// +kubebuilder:subresource:status
+// +kubebuilder:unservedversion
+// +kubebuilder:printcolumn:name=DNS,description="FQDN of DNS Entry",JSONPath=".spec.dnsName",type=string
+// +kubebuilder:printcolumn:name=TYPE,JSONPath=".status.providerType",type=string,description="provider type"
+// +kubebuilder:printcolumn:name=PROVIDER,JSONPath=".status.provider",type=string,description="assigned provider (namespace/name)"
+// +kubebuilder:printcolumn:name=READY,JSONPath=".status.conditions[?(@.type==\"Ready\")].status",type=string,description="readiness of the entry"
+// +kubebuilder:printcolumn:name=AGE,JSONPath=".metadata.creationTimestamp",type=date,description="entry creation timestamp"
+// +kubebuilder:printcolumn:name=OWNERID,JSONPath=".spec.ownerId",type=string,description="owner id used to tag entries in external DNS system"
+// +kubebuilder:printcolumn:name=TTL,JSONPath=".status.ttl",type=integer,priority=2000,description="time to live"
+// +kubebuilder:printcolumn:name=ZONE,JSONPath=".status.zone",type=string,priority=2000,description="zone id"
+// +kubebuilder:printcolumn:name=REASON,JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",type=string,priority=2000,description="reason of the readiness state"
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSEntry struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSEntrySpec `json:"spec"`
+	// +optional
+	Status DNSEntryStatus `json:"status,omitempty"`
+}
+
+type DNSEntrySpec struct {
+	// full qualified domain name
+	DNSName string `json:"dnsName"`
+	// reference to base entry used to inherit attributes from
+	// +optional
+	Reference *EntryReference `json:"reference,omitempty"`
+	// reference to a cluster object (Service, Node, Gateway or DNSEntry) whose addresses are used as records
+	// +optional
+	TargetRef *TargetReference `json:"targetRef,omitempty"`
+	// owner id used to tag entries in external DNS system
+	// +optional
+	OwnerId *string `json:"ownerId,omitempty"`
+	// time to live for records in external DNS system
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+	// lookup interval for host names that must be resolved to IP addresses
+	// +optional
+	CNameLookupInterval *int64 `json:"cnameLookupInterval,omitempty"`
+	// if true, host names are resolved to their IP addresses (A/AAAA records) instead of using CNAME records.
+	// +optional
+	ResolveTargetsToAddresses *bool `json:"resolveTargetsToAddresses,omitempty"`
+	// records of the entry, either text or addresses and host names must be specified
+	// +optional
+	Records *RecordSets `json:"records,omitempty"`
+	// optional routing policy
+	// +optional
+	RoutingPolicy *RoutingPolicy `json:"routingPolicy,omitempty"`
+	// optional provider (namespace/name) to use exclusively for this entry,
+	// if several providers are responsible for the domain
+	// +optional
+	Provider *string `json:"provider,omitempty"`
+	// optional id of the hosted zone to use exclusively for this entry,
+	// if the domain is served by several zones (e.g. a public and a private zone)
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+	// policy for the DNS records on deletion of the entry: `Delete` (default) deletes the records,
+	// `Retain` keeps them and only removes the ownership record
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// optional time to create the DNS records of the entry, they are not created before
+	// +optional
+	ActivateAt *metav1.Time `json:"activateAt,omitempty"`
+	// optional time to delete the DNS records of the entry, the entry itself is kept
+	// +optional
+	DeactivateAt *metav1.Time `json:"deactivateAt,omitempty"`
+	// optional lifetime of the entry in seconds after its creation, the entry and its DNS records
+	// are deleted by the controller afterwards (not related to the TTL of the records)
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	LifetimeSeconds *int64 `json:"lifetimeSeconds,omitempty"`
+}
+
+// DeletionPolicy describes the handling of the DNS records on deletion of an entry.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the DNS records together with the entry.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain keeps the DNS records, only the ownership record is removed.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// RecordSets specifies the records of an entry structured by their kind
+type RecordSets struct {
+	// IP addresses (A or AAAA records)
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+	// host names (CNAME records or resolved to addresses)
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+	// text records (TXT records)
+	// +optional
+	Text []string `json:"text,omitempty"`
+}
+
+type DNSEntryStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// conditions of the entry, the condition `Ready` reports the provisioning state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// lastUpdateTime contains the timestamp of the last status update
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// provider type used for the entry
+	// +optional
+	ProviderType *string `json:"providerType,omitempty"`
+	// assigned provider
+	// +optional
+	Provider *string `json:"provider,omitempty"`
+	// zone used for the entry
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+	// time to live used for the entry
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+	// effective targets generated for the entry
+	// +optional
+	Targets []string `json:"targets,omitempty"`
+	// effective routing policy
+	// +optional
+	RoutingPolicy *RoutingPolicy `json:"routingPolicy,omitempty"`
+	// id of the cluster of the source object the entry has been generated for,
+	// if it is an additional source cluster of the source controllers
+	// +optional
+	SourceCluster string `json:"sourceCluster,omitempty"`
+	// record sets as applied at the provider by the last successful reconciliation
+	// (without the ownership records of the controller)
+	// +optional
+	ProviderRecords *ProviderRecords `json:"providerRecords,omitempty"`
+}
+
+type EntryReference struct {
+	// name of the referenced DNSEntry object
+	Name string `json:"name"`
+	// namespace of the referenced DNSEntry object
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type TargetReference struct {
+	// API version of the referenced object, defaults to `v1` for kinds `Service` and `Node`
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+	// kind of the referenced object (`Service`, `Node`, `Gateway` or `DNSEntry`)
+	Kind string `json:"kind"`
+	// name of the referenced object
+	Name string `json:"name"`
+	// namespace of the referenced object, defaults to the namespace of the entry
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type RoutingPolicy struct {
+	// Policy is the policy type. Allowed values are provider dependent, e.g. `weighted`
+	Type string `json:"type"`
+	// SetIdentifier is the identifier of the record set
+	SetIdentifier string `json:"setIdentifier"`
+	// Policy specific parameters
+	Parameters map[string]string `json:"parameters"`
+}
+
+// ProviderRecords describes the record sets of an entry as applied at the provider
+type ProviderRecords struct {
+	// id of the hosted zone containing the records
+	Zone string `json:"zone"`
+	// DNS name of the record sets
+	DNSName string `json:"dnsName"`
+	// identifier of the record sets used by the routing policy
+	// +optional
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	// routing policy of the record sets
+	// +optional
+	RoutingPolicy *RoutingPolicy `json:"routingPolicy,omitempty"`
+	// record sets by record type
+	// +optional
+	RecordSets []ProviderRecordSet `json:"recordSets,omitempty"`
+}
+
+// ProviderRecordSet describes a record set as applied at the provider
+type ProviderRecordSet struct {
+	// record type (A, AAAA, CNAME, TXT)
+	Type string `json:"type"`
+	// time to live of the record set
+	TTL int64 `json:"ttl"`
+	// values of the records
+	Values []string `json:"values"`
+}
@@ -29,11 +29,20 @@ import (
 type DnsV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	DNSAnnotationsGetter
+	DNSChallengesGetter
+	DNSClassProfilesGetter
+	DNSElectionsGetter
 	DNSEntriesGetter
+	DNSEntrySetsGetter
+	DNSHealthChecksGetter
 	DNSHostedZonePoliciesGetter
 	DNSLocksGetter
 	DNSOwnersGetter
+	DNSPoliciesGetter
 	DNSProvidersGetter
+	DNSSwitchesGetter
+	DNSTrafficShiftsGetter
+	DNSZonesGetter
 	RemoteAccessCertificatesGetter
 }
 
@@ -46,10 +55,30 @@ func (c *DnsV1alpha1Client) DNSAnnotations(namespace string) DNSAnnotationInterf
 	return newDNSAnnotations(c, namespace)
 }
 
+func (c *DnsV1alpha1Client) DNSChallenges(namespace string) DNSChallengeInterface {
+	return newDNSChallenges(c, namespace)
+}
+
+func (c *DnsV1alpha1Client) DNSClassProfiles(namespace string) DNSClassProfileInterface {
+	return newDNSClassProfiles(c, namespace)
+}
+
+func (c *DnsV1alpha1Client) DNSElections(namespace string) DNSElectionInterface {
+	return newDNSElections(c, namespace)
+}
+
 func (c *DnsV1alpha1Client) DNSEntries(namespace string) DNSEntryInterface {
 	return newDNSEntries(c, namespace)
 }
 
+func (c *DnsV1alpha1Client) DNSEntrySets(namespace string) DNSEntrySetInterface {
+	return newDNSEntrySets(c, namespace)
+}
+
+func (c *DnsV1alpha1Client) DNSHealthChecks(namespace string) DNSHealthCheckInterface {
+	return newDNSHealthChecks(c, namespace)
+}
+
 func (c *DnsV1alpha1Client) DNSHostedZonePolicies(namespace string) DNSHostedZonePolicyInterface {
 	return newDNSHostedZonePolicies(c, namespace)
 }
@@ -62,10 +91,26 @@ func (c *DnsV1alpha1Client) DNSOwners(namespace string) DNSOwnerInterface {
 	return newDNSOwners(c, namespace)
 }
 
+func (c *DnsV1alpha1Client) DNSPolicies(namespace string) DNSPolicyInterface {
+	return newDNSPolicies(c, namespace)
+}
+
 func (c *DnsV1alpha1Client) DNSProviders(namespace string) DNSProviderInterface {
 	return newDNSProviders(c, namespace)
 }
 
+func (c *DnsV1alpha1Client) DNSSwitches(namespace string) DNSSwitchInterface {
+	return newDNSSwitches(c, namespace)
+}
+
+func (c *DnsV1alpha1Client) DNSTrafficShifts(namespace string) DNSTrafficShiftInterface {
+	return newDNSTrafficShifts(c, namespace)
+}
+
+func (c *DnsV1alpha1Client) DNSZones(namespace string) DNSZoneInterface {
+	return newDNSZones(c, namespace)
+}
+
 func (c *DnsV1alpha1Client) RemoteAccessCertificates(namespace string) RemoteAccessCertificateInterface {
 	return newRemoteAccessCertificates(c, namespace)
 }
@@ -32,10 +32,30 @@ func (c *FakeDnsV1alpha1) DNSAnnotations(namespace string) v1alpha1.DNSAnnotatio
 	return &FakeDNSAnnotations{c, namespace}
 }
 
+func (c *FakeDnsV1alpha1) DNSChallenges(namespace string) v1alpha1.DNSChallengeInterface {
+	return &FakeDNSChallenges{c, namespace}
+}
+
+func (c *FakeDnsV1alpha1) DNSClassProfiles(namespace string) v1alpha1.DNSClassProfileInterface {
+	return &FakeDNSClassProfiles{c, namespace}
+}
+
+func (c *FakeDnsV1alpha1) DNSElections(namespace string) v1alpha1.DNSElectionInterface {
+	return &FakeDNSElections{c, namespace}
+}
+
 func (c *FakeDnsV1alpha1) DNSEntries(namespace string) v1alpha1.DNSEntryInterface {
 	return &FakeDNSEntries{c, namespace}
 }
 
+func (c *FakeDnsV1alpha1) DNSEntrySets(namespace string) v1alpha1.DNSEntrySetInterface {
+	return &FakeDNSEntrySets{c, namespace}
+}
+
+func (c *FakeDnsV1alpha1) DNSHealthChecks(namespace string) v1alpha1.DNSHealthCheckInterface {
+	return &FakeDNSHealthChecks{c, namespace}
+}
+
 func (c *FakeDnsV1alpha1) DNSHostedZonePolicies(namespace string) v1alpha1.DNSHostedZonePolicyInterface {
 	return &FakeDNSHostedZonePolicies{c, namespace}
 }
@@ -48,10 +68,26 @@ func (c *FakeDnsV1alpha1) DNSOwners(namespace string) v1alpha1.DNSOwnerInterface
 	return &FakeDNSOwners{c, namespace}
 }
 
+func (c *FakeDnsV1alpha1) DNSPolicies(namespace string) v1alpha1.DNSPolicyInterface {
+	return &FakeDNSPolicies{c, namespace}
+}
+
 func (c *FakeDnsV1alpha1) DNSProviders(namespace string) v1alpha1.DNSProviderInterface {
 	return &FakeDNSProviders{c, namespace}
 }
 
+func (c *FakeDnsV1alpha1) DNSSwitches(namespace string) v1alpha1.DNSSwitchInterface {
+	return &FakeDNSSwitches{c, namespace}
+}
+
+func (c *FakeDnsV1alpha1) DNSTrafficShifts(namespace string) v1alpha1.DNSTrafficShiftInterface {
+	return &FakeDNSTrafficShifts{c, namespace}
+}
+
+func (c *FakeDnsV1alpha1) DNSZones(namespace string) v1alpha1.DNSZoneInterface {
+	return &FakeDNSZones{c, namespace}
+}
+
 func (c *FakeDnsV1alpha1) RemoteAccessCertificates(namespace string) v1alpha1.RemoteAccessCertificateInterface {
 	return &FakeRemoteAccessCertificates{c, namespace}
 }
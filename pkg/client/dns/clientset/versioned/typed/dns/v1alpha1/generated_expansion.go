@@ -20,14 +20,32 @@ package v1alpha1
 
 type DNSAnnotationExpansion interface{}
 
+type DNSChallengeExpansion interface{}
+
+type DNSClassProfileExpansion interface{}
+
+type DNSElectionExpansion interface{}
+
 type DNSEntryExpansion interface{}
 
+type DNSEntrySetExpansion interface{}
+
+type DNSHealthCheckExpansion interface{}
+
 type DNSHostedZonePolicyExpansion interface{}
 
 type DNSLockExpansion interface{}
 
 type DNSOwnerExpansion interface{}
 
+type DNSPolicyExpansion interface{}
+
 type DNSProviderExpansion interface{}
 
+type DNSSwitchExpansion interface{}
+
+type DNSTrafficShiftExpansion interface{}
+
+type DNSZoneExpansion interface{}
+
 type RemoteAccessCertificateExpansion interface{}
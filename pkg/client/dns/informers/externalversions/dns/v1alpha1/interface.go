@@ -26,16 +26,34 @@ import (
 type Interface interface {
 	// DNSAnnotations returns a DNSAnnotationInformer.
 	DNSAnnotations() DNSAnnotationInformer
+	// DNSChallenges returns a DNSChallengeInformer.
+	DNSChallenges() DNSChallengeInformer
+	// DNSClassProfiles returns a DNSClassProfileInformer.
+	DNSClassProfiles() DNSClassProfileInformer
+	// DNSElections returns a DNSElectionInformer.
+	DNSElections() DNSElectionInformer
 	// DNSEntries returns a DNSEntryInformer.
 	DNSEntries() DNSEntryInformer
+	// DNSEntrySets returns a DNSEntrySetInformer.
+	DNSEntrySets() DNSEntrySetInformer
+	// DNSHealthChecks returns a DNSHealthCheckInformer.
+	DNSHealthChecks() DNSHealthCheckInformer
 	// DNSHostedZonePolicies returns a DNSHostedZonePolicyInformer.
 	DNSHostedZonePolicies() DNSHostedZonePolicyInformer
 	// DNSLocks returns a DNSLockInformer.
 	DNSLocks() DNSLockInformer
 	// DNSOwners returns a DNSOwnerInformer.
 	DNSOwners() DNSOwnerInformer
+	// DNSPolicies returns a DNSPolicyInformer.
+	DNSPolicies() DNSPolicyInformer
 	// DNSProviders returns a DNSProviderInformer.
 	DNSProviders() DNSProviderInformer
+	// DNSSwitches returns a DNSSwitchInformer.
+	DNSSwitches() DNSSwitchInformer
+	// DNSTrafficShifts returns a DNSTrafficShiftInformer.
+	DNSTrafficShifts() DNSTrafficShiftInformer
+	// DNSZones returns a DNSZoneInformer.
+	DNSZones() DNSZoneInformer
 	// RemoteAccessCertificates returns a RemoteAccessCertificateInformer.
 	RemoteAccessCertificates() RemoteAccessCertificateInformer
 }
@@ -56,11 +74,36 @@ func (v *version) DNSAnnotations() DNSAnnotationInformer {
 	return &dNSAnnotationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DNSChallenges returns a DNSChallengeInformer.
+func (v *version) DNSChallenges() DNSChallengeInformer {
+	return &dNSChallengeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DNSClassProfiles returns a DNSClassProfileInformer.
+func (v *version) DNSClassProfiles() DNSClassProfileInformer {
+	return &dNSClassProfileInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DNSElections returns a DNSElectionInformer.
+func (v *version) DNSElections() DNSElectionInformer {
+	return &dNSElectionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DNSEntries returns a DNSEntryInformer.
 func (v *version) DNSEntries() DNSEntryInformer {
 	return &dNSEntryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DNSEntrySets returns a DNSEntrySetInformer.
+func (v *version) DNSEntrySets() DNSEntrySetInformer {
+	return &dNSEntrySetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DNSHealthChecks returns a DNSHealthCheckInformer.
+func (v *version) DNSHealthChecks() DNSHealthCheckInformer {
+	return &dNSHealthCheckInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DNSHostedZonePolicies returns a DNSHostedZonePolicyInformer.
 func (v *version) DNSHostedZonePolicies() DNSHostedZonePolicyInformer {
 	return &dNSHostedZonePolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -76,11 +119,31 @@ func (v *version) DNSOwners() DNSOwnerInformer {
 	return &dNSOwnerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DNSPolicies returns a DNSPolicyInformer.
+func (v *version) DNSPolicies() DNSPolicyInformer {
+	return &dNSPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DNSProviders returns a DNSProviderInformer.
 func (v *version) DNSProviders() DNSProviderInformer {
 	return &dNSProviderInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DNSSwitches returns a DNSSwitchInformer.
+func (v *version) DNSSwitches() DNSSwitchInformer {
+	return &dNSSwitchInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DNSTrafficShifts returns a DNSTrafficShiftInformer.
+func (v *version) DNSTrafficShifts() DNSTrafficShiftInformer {
+	return &dNSTrafficShiftInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DNSZones returns a DNSZoneInformer.
+func (v *version) DNSZones() DNSZoneInformer {
+	return &dNSZoneInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // RemoteAccessCertificates returns a RemoteAccessCertificateInformer.
 func (v *version) RemoteAccessCertificates() RemoteAccessCertificateInformer {
 	return &remoteAccessCertificateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
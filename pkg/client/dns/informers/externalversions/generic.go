@@ -55,16 +55,34 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=dns.gardener.cloud, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("dnsannotations"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSAnnotations().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dnschallenges"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSChallenges().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dnsclassprofiles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSClassProfiles().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dnselections"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSElections().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("dnsentries"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSEntries().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dnsentrysets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSEntrySets().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dnshealthchecks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSHealthChecks().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("dnshostedzonepolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSHostedZonePolicies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("dnslocks"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSLocks().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("dnsowners"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSOwners().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dnspolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSPolicies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("dnsproviders"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSProviders().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dnsswitches"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSSwitches().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dnstrafficshifts"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSTrafficShifts().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dnszones"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSZones().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("remoteaccesscertificates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().RemoteAccessCertificates().Informer()}, nil
 
@@ -26,6 +26,30 @@ type DNSAnnotationListerExpansion interface{}
 // DNSAnnotationNamespaceLister.
 type DNSAnnotationNamespaceListerExpansion interface{}
 
+// DNSChallengeListerExpansion allows custom methods to be added to
+// DNSChallengeLister.
+type DNSChallengeListerExpansion interface{}
+
+// DNSChallengeNamespaceListerExpansion allows custom methods to be added to
+// DNSChallengeNamespaceLister.
+type DNSChallengeNamespaceListerExpansion interface{}
+
+// DNSClassProfileListerExpansion allows custom methods to be added to
+// DNSClassProfileLister.
+type DNSClassProfileListerExpansion interface{}
+
+// DNSClassProfileNamespaceListerExpansion allows custom methods to be added to
+// DNSClassProfileNamespaceLister.
+type DNSClassProfileNamespaceListerExpansion interface{}
+
+// DNSElectionListerExpansion allows custom methods to be added to
+// DNSElectionLister.
+type DNSElectionListerExpansion interface{}
+
+// DNSElectionNamespaceListerExpansion allows custom methods to be added to
+// DNSElectionNamespaceLister.
+type DNSElectionNamespaceListerExpansion interface{}
+
 // DNSEntryListerExpansion allows custom methods to be added to
 // DNSEntryLister.
 type DNSEntryListerExpansion interface{}
@@ -34,6 +58,22 @@ type DNSEntryListerExpansion interface{}
 // DNSEntryNamespaceLister.
 type DNSEntryNamespaceListerExpansion interface{}
 
+// DNSEntrySetListerExpansion allows custom methods to be added to
+// DNSEntrySetLister.
+type DNSEntrySetListerExpansion interface{}
+
+// DNSEntrySetNamespaceListerExpansion allows custom methods to be added to
+// DNSEntrySetNamespaceLister.
+type DNSEntrySetNamespaceListerExpansion interface{}
+
+// DNSHealthCheckListerExpansion allows custom methods to be added to
+// DNSHealthCheckLister.
+type DNSHealthCheckListerExpansion interface{}
+
+// DNSHealthCheckNamespaceListerExpansion allows custom methods to be added to
+// DNSHealthCheckNamespaceLister.
+type DNSHealthCheckNamespaceListerExpansion interface{}
+
 // DNSHostedZonePolicyListerExpansion allows custom methods to be added to
 // DNSHostedZonePolicyLister.
 type DNSHostedZonePolicyListerExpansion interface{}
@@ -58,6 +98,14 @@ type DNSOwnerListerExpansion interface{}
 // DNSOwnerNamespaceLister.
 type DNSOwnerNamespaceListerExpansion interface{}
 
+// DNSPolicyListerExpansion allows custom methods to be added to
+// DNSPolicyLister.
+type DNSPolicyListerExpansion interface{}
+
+// DNSPolicyNamespaceListerExpansion allows custom methods to be added to
+// DNSPolicyNamespaceLister.
+type DNSPolicyNamespaceListerExpansion interface{}
+
 // DNSProviderListerExpansion allows custom methods to be added to
 // DNSProviderLister.
 type DNSProviderListerExpansion interface{}
@@ -66,6 +114,30 @@ type DNSProviderListerExpansion interface{}
 // DNSProviderNamespaceLister.
 type DNSProviderNamespaceListerExpansion interface{}
 
+// DNSSwitchListerExpansion allows custom methods to be added to
+// DNSSwitchLister.
+type DNSSwitchListerExpansion interface{}
+
+// DNSSwitchNamespaceListerExpansion allows custom methods to be added to
+// DNSSwitchNamespaceLister.
+type DNSSwitchNamespaceListerExpansion interface{}
+
+// DNSTrafficShiftListerExpansion allows custom methods to be added to
+// DNSTrafficShiftLister.
+type DNSTrafficShiftListerExpansion interface{}
+
+// DNSTrafficShiftNamespaceListerExpansion allows custom methods to be added to
+// DNSTrafficShiftNamespaceLister.
+type DNSTrafficShiftNamespaceListerExpansion interface{}
+
+// DNSZoneListerExpansion allows custom methods to be added to
+// DNSZoneLister.
+type DNSZoneListerExpansion interface{}
+
+// DNSZoneNamespaceListerExpansion allows custom methods to be added to
+// DNSZoneNamespaceLister.
+type DNSZoneNamespaceListerExpansion interface{}
+
 // RemoteAccessCertificateListerExpansion allows custom methods to be added to
 // RemoteAccessCertificateLister.
 type RemoteAccessCertificateListerExpansion interface{}
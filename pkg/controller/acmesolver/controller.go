@@ -0,0 +1,427 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package acmesolver
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/resources/apiextensions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/crds"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/server/acmesolver"
+)
+
+const CONTROLLER = "dnsacmesolver"
+
+const (
+	OPT_PORT                   = "acme-solver-port"
+	OPT_CERT_DIR               = "acme-solver-cert-dir"
+	OPT_CLIENT_CA              = "acme-solver-client-ca"
+	OPT_CLIENT_ALLOWED_NAMES   = "acme-solver-client-allowed-names"
+	OPT_ALLOW_CONFIG_NAMESPACE = "acme-solver-allow-config-namespace"
+	OPT_GROUP_NAME             = "acme-solver-group-name"
+	OPT_NAME                   = "acme-solver-name"
+)
+
+// The request header client CA of the aggregation layer is read from this config map by default.
+const (
+	AUTHENTICATION_CONFIGMAP_NAMESPACE = "kube-system"
+	AUTHENTICATION_CONFIGMAP_NAME      = "extension-apiserver-authentication"
+	KEY_REQUESTHEADER_CLIENT_CA        = "requestheader-client-ca-file"
+	KEY_REQUESTHEADER_ALLOWED_NAMES    = "requestheader-allowed-names"
+)
+
+// LABEL_ACME_CHALLENGE is set on the DNS entries created for ACME DNS-01 challenges
+const LABEL_ACME_CHALLENGE = dns.ANNOTATION_GROUP + "/acme-challenge"
+
+// CHALLENGE_PREFIX is the required prefix of the DNS names of challenge records
+const CHALLENGE_PREFIX = "_acme-challenge."
+
+// DEFAULT_TTL is the default time to live of challenge records
+const DEFAULT_TTL = 120
+
+var entryGroupKind = resources.NewGroupKind(api.GroupName, api.DNSEntryKind)
+
+func init() {
+	crds.AddToRegistry(apiextensions.DefaultRegistry())
+
+	controller.Configure(CONTROLLER).
+		Reconciler(Create).
+		DefaultWorkerPool(1, 0).
+		DefaultedIntOption(OPT_PORT, 9444, "port of the ACME DNS-01 solver webhook server for cert-manager").
+		DefaultedStringOption(OPT_CERT_DIR, "", "directory containing the certificate (tls.crt) and key (tls.key) of the ACME DNS-01 solver webhook server").
+		DefaultedStringOption(OPT_CLIENT_CA, "", "file containing the CA for client certificates of the kube-apiserver accessing the ACME DNS-01 solver (default: request header client CA of config map kube-system/extension-apiserver-authentication)").
+		DefaultedStringOption(OPT_CLIENT_ALLOWED_NAMES, "", "comma separated list of accepted common names of the client certificates of the kube-apiserver (default: request header allowed names of config map kube-system/extension-apiserver-authentication)").
+		DefaultedBoolOption(OPT_ALLOW_CONFIG_NAMESPACE, false, "allow issuers to create the challenge entries in other namespaces than the namespace of the issuer resources").
+		DefaultedStringOption(OPT_GROUP_NAME, "acme.dns.gardener.cloud", "API group name of the ACME DNS-01 solver referenced by cert-manager issuers").
+		DefaultedStringOption(OPT_NAME, "gardener-dns", "solver name of the ACME DNS-01 solver referenced by cert-manager issuers").
+		CustomResourceDefinitions(entryGroupKind).
+		MainResource(api.GroupName, api.DNSEntryKind, challengeEntrySelection).
+		ActivateExplicitly().
+		MustRegister()
+}
+
+// challengeEntrySelection restricts the main resource to the challenge entries.
+func challengeEntrySelection(c controller.Interface) (string, resources.TweakListOptionsFunc) {
+	return "", func(options *metav1.ListOptions) {
+		options.LabelSelector = LABEL_ACME_CHALLENGE
+	}
+}
+
+type reconciler struct {
+	reconcile.DefaultReconciler
+	controller controller.Interface
+	config     *acmesolver.Config
+	solver     *Solver
+}
+
+var _ reconcile.Interface = &reconciler{}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func Create(controller controller.Interface) (reconcile.Interface, error) {
+	entries, err := controller.GetMainCluster().Resources().GetByExample(&api.DNSEntry{})
+	if err != nil {
+		return nil, err
+	}
+	config := &acmesolver.Config{}
+	if config.Port, err = controller.GetIntOption(OPT_PORT); err != nil {
+		return nil, err
+	}
+	config.CertDir, _ = controller.GetStringOption(OPT_CERT_DIR)
+	if config.CertDir == "" {
+		return nil, fmt.Errorf("missing %s for ACME DNS-01 solver", OPT_CERT_DIR)
+	}
+	config.GroupName, _ = controller.GetStringOption(OPT_GROUP_NAME)
+	config.SolverName, _ = controller.GetStringOption(OPT_NAME)
+	if config.GroupName == "" || config.SolverName == "" {
+		return nil, fmt.Errorf("%s and %s must be set", OPT_GROUP_NAME, OPT_NAME)
+	}
+	if config.ClientCA, config.AllowedNames, err = clientAuthentication(controller); err != nil {
+		return nil, err
+	}
+	allowConfigNamespace, _ := controller.GetBoolOption(OPT_ALLOW_CONFIG_NAMESPACE)
+	return &reconciler{
+		controller: controller,
+		config:     config,
+		solver:     &Solver{logger: controller, entries: entries, allowConfigNamespace: allowConfigNamespace},
+	}, nil
+}
+
+// clientAuthentication determines the CA and the accepted common names for the client certificates
+// of the kube-apiserver. By default, the request header client CA of the aggregation layer is used.
+func clientAuthentication(controller controller.Interface) ([]byte, []string, error) {
+	var names []string
+	if value, _ := controller.GetStringOption(OPT_CLIENT_ALLOWED_NAMES); value != "" {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	if file, _ := controller.GetStringOption(OPT_CLIENT_CA); file != "" {
+		ca, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot read client CA for ACME DNS-01 solver: %w", err)
+		}
+		return ca, names, nil
+	}
+	cm := &corev1.ConfigMap{}
+	_, err := controller.GetMainCluster().Resources().GetObjectInto(resources.NewObjectName(AUTHENTICATION_CONFIGMAP_NAMESPACE, AUTHENTICATION_CONFIGMAP_NAME), cm)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read client CA for ACME DNS-01 solver (use option %s): %w", OPT_CLIENT_CA, err)
+	}
+	ca, defaultNames, err := RequestHeaderAuthentication(cm)
+	if err != nil {
+		return nil, nil, err
+	}
+	if names == nil {
+		names = defaultNames
+	}
+	return ca, names, nil
+}
+
+// RequestHeaderAuthentication returns the request header client CA and the allowed common names
+// of the authentication config map of the kube-apiserver.
+func RequestHeaderAuthentication(cm *corev1.ConfigMap) ([]byte, []string, error) {
+	ca := cm.Data[KEY_REQUESTHEADER_CLIENT_CA]
+	if ca == "" {
+		return nil, nil, fmt.Errorf("%s missing in config map %s/%s", KEY_REQUESTHEADER_CLIENT_CA, cm.Namespace, cm.Name)
+	}
+	var names []string
+	if value := cm.Data[KEY_REQUESTHEADER_ALLOWED_NAMES]; value != "" {
+		if err := json.Unmarshal([]byte(value), &names); err != nil {
+			return nil, nil, fmt.Errorf("invalid %s in config map %s/%s: %w", KEY_REQUESTHEADER_ALLOWED_NAMES, cm.Namespace, cm.Name, err)
+		}
+	}
+	return []byte(ca), names, nil
+}
+
+func (this *reconciler) Setup() error {
+	this.controller.Infof("starting ACME DNS-01 solver %s/%s", this.config.GroupName, this.config.SolverName)
+	return acmesolver.StartServer(this.controller.GetContext(), this.controller, this.config, this.solver)
+}
+
+func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	// challenge entries are maintained by the solver requests, only report their state
+	entry := obj.Data().(*api.DNSEntry)
+	if entry.Status.ObservedGeneration == entry.Generation && entry.Status.State != "" {
+		logger.Infof("challenge entry for %s is %s", entry.Spec.DNSName, entry.Status.State)
+	}
+	return reconcile.Succeeded(logger)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Solver presents DNS-01 challenges as text records of DNS entries.
+// All challenges for the same DNS name share a single entry.
+type Solver struct {
+	logger  logger.LogContext
+	entries resources.Interface
+	lock    sync.Mutex
+	// allowConfigNamespace allows the solver config to choose the namespace of the challenge entries
+	allowConfigNamespace bool
+}
+
+var _ acmesolver.Solver = &Solver{}
+
+func (this *Solver) Present(req *acmesolver.ChallengeRequest) error {
+	desired, err := DesiredEntry(req, this.allowConfigNamespace)
+	if err != nil {
+		return err
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	cur := &api.DNSEntry{}
+	obj, err := this.entries.GetInto(resources.NewObjectName(desired.Namespace, desired.Name), cur)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		this.logger.Infof("creating challenge entry %s/%s for %s", desired.Namespace, desired.Name, desired.Spec.DNSName)
+		_, err = this.entries.Create(desired)
+		return err
+	}
+	if !IsChallengeEntry(cur) {
+		return fmt.Errorf("entry %s/%s is no challenge entry (label %s missing)", cur.Namespace, cur.Name, LABEL_ACME_CHALLENGE)
+	}
+	if cur.DeletionTimestamp != nil {
+		// cert-manager retries failed requests, the entry is created again once the deletion is completed
+		return fmt.Errorf("challenge entry %s/%s is being deleted, retry later", cur.Namespace, cur.Name)
+	}
+	if cur.Spec.DNSName != desired.Spec.DNSName {
+		return fmt.Errorf("entry %s/%s is already used for %s", cur.Namespace, cur.Name, cur.Spec.DNSName)
+	}
+	if cur.Status.ObservedGeneration == cur.Generation && (cur.Status.State == api.STATE_INVALID || cur.Status.State == api.STATE_ERROR) {
+		msg := ""
+		if cur.Status.Message != nil {
+			msg = *cur.Status.Message
+		}
+		return fmt.Errorf("entry %s/%s is %s: %s", cur.Namespace, cur.Name, cur.Status.State, msg)
+	}
+	_, err = obj.Modify(func(data resources.ObjectData) (bool, error) {
+		return AddKey(data.(*api.DNSEntry), req.Key), nil
+	})
+	return err
+}
+
+func (this *Solver) CleanUp(req *acmesolver.ChallengeRequest) error {
+	desired, err := DesiredEntry(req, this.allowConfigNamespace)
+	if err != nil {
+		return err
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	cur := &api.DNSEntry{}
+	obj, err := this.entries.GetInto(resources.NewObjectName(desired.Namespace, desired.Name), cur)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !IsChallengeEntry(cur) {
+		return fmt.Errorf("entry %s/%s is no challenge entry (label %s missing)", cur.Namespace, cur.Name, LABEL_ACME_CHALLENGE)
+	}
+	if cur.Spec.DNSName != desired.Spec.DNSName {
+		return nil
+	}
+	if len(cur.Spec.Text) == 0 || (len(cur.Spec.Text) == 1 && cur.Spec.Text[0] == req.Key) {
+		this.logger.Infof("deleting challenge entry %s/%s for %s", cur.Namespace, cur.Name, cur.Spec.DNSName)
+		if err := obj.Delete(); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	_, err = obj.Modify(func(data resources.ObjectData) (bool, error) {
+		return RemoveKey(data.(*api.DNSEntry), req.Key), nil
+	})
+	return err
+}
+
+// IsChallengeEntry returns true if the entry is maintained by the solver.
+func IsChallengeEntry(entry *api.DNSEntry) bool {
+	_, ok := entry.Labels[LABEL_ACME_CHALLENGE]
+	return ok
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// SolverConfig is the solver configuration given in the cert-manager issuer.
+type SolverConfig struct {
+	// Namespace of the challenge entries (default: namespace of the issuer resources).
+	// Other namespaces are only accepted with the option --acme-solver-allow-config-namespace.
+	Namespace string `json:"namespace,omitempty"`
+	// Class is the DNS class of the challenge entries
+	Class string `json:"class,omitempty"`
+	// TTL of the challenge records (default: 120)
+	TTL *int64 `json:"ttl,omitempty"`
+	// OwnerId of the challenge entries
+	OwnerId *string `json:"ownerId,omitempty"`
+	// Provider (namespace/name) to use exclusively for the challenge entries,
+	// it must be located in the namespace of the challenge entries
+	Provider *string `json:"provider,omitempty"`
+	// Zone id to use exclusively for the challenge entries
+	Zone *string `json:"zone,omitempty"`
+}
+
+// ParseConfig parses the solver configuration of a challenge request.
+func ParseConfig(req *acmesolver.ChallengeRequest) (*SolverConfig, error) {
+	cfg := &SolverConfig{}
+	if req.Config != nil && len(req.Config.Raw) > 0 {
+		if err := json.Unmarshal(req.Config.Raw, cfg); err != nil {
+			return nil, fmt.Errorf("invalid solver config: %w", err)
+		}
+	}
+	if cfg.TTL != nil && *cfg.TTL <= 0 {
+		return nil, fmt.Errorf("invalid solver config: ttl must be greater than zero")
+	}
+	return cfg, nil
+}
+
+// DesiredEntry returns the DNS entry presenting the key of the challenge.
+// The namespace of the solver config is only accepted if it is the namespace of the
+// issuer resources, or if other namespaces are explicitly allowed.
+func DesiredEntry(req *acmesolver.ChallengeRequest, allowConfigNamespace bool) (*api.DNSEntry, error) {
+	if req.Type != "" && req.Type != "dns-01" {
+		return nil, fmt.Errorf("unsupported challenge type %q", req.Type)
+	}
+	dnsName := strings.TrimSuffix(req.ResolvedFQDN, ".")
+	if !strings.HasPrefix(dnsName, CHALLENGE_PREFIX) {
+		return nil, fmt.Errorf("DNS name %q of challenge must start with %s", dnsName, CHALLENGE_PREFIX)
+	}
+	if req.Key == "" {
+		return nil, fmt.Errorf("challenge key missing")
+	}
+	cfg, err := ParseConfig(req)
+	if err != nil {
+		return nil, err
+	}
+	namespace := req.ResourceNamespace
+	if cfg.Namespace != "" && cfg.Namespace != namespace {
+		if !allowConfigNamespace {
+			return nil, fmt.Errorf("namespace %q of solver config not allowed (namespace of issuer resources is %q)", cfg.Namespace, namespace)
+		}
+		namespace = cfg.Namespace
+	}
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace for challenge entry missing")
+	}
+	if cfg.Provider != nil {
+		name, err := provider.ParsePinnedProviderName(*cfg.Provider, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("invalid provider in solver config: %w", err)
+		}
+		if name.Namespace() != namespace {
+			return nil, fmt.Errorf("provider %s of solver config must be in namespace %s of the challenge entry", name, namespace)
+		}
+	}
+	ttl := int64(DEFAULT_TTL)
+	if cfg.TTL != nil {
+		ttl = *cfg.TTL
+	}
+
+	entry := &api.DNSEntry{}
+	entry.Name = EntryName(dnsName)
+	entry.Namespace = namespace
+	entry.Labels = map[string]string{LABEL_ACME_CHALLENGE: "true"}
+	if cfg.Class != "" {
+		entry.Annotations = map[string]string{dns.CLASS_ANNOTATION: cfg.Class}
+	}
+	entry.Spec = api.DNSEntrySpec{
+		DNSName:  dnsName,
+		OwnerId:  cfg.OwnerId,
+		TTL:      &ttl,
+		Provider: cfg.Provider,
+		Zone:     cfg.Zone,
+		Text:     []string{req.Key},
+	}
+	return entry, nil
+}
+
+// EntryName returns the name of the challenge entry for a DNS name.
+func EntryName(dnsName string) string {
+	h := fnv.New32a()
+	h.Write([]byte(dnsName))
+	return fmt.Sprintf("acme-challenge-%08x", h.Sum32())
+}
+
+// AddKey adds the key of a challenge to the text records of the entry.
+func AddKey(entry *api.DNSEntry, key string) bool {
+	for _, t := range entry.Spec.Text {
+		if t == key {
+			return false
+		}
+	}
+	entry.Spec.Text = append(entry.Spec.Text, key)
+	return true
+}
+
+// RemoveKey removes the key of a challenge from the text records of the entry.
+func RemoveKey(entry *api.DNSEntry, key string) bool {
+	var text []string
+	for _, t := range entry.Spec.Text {
+		if t != key {
+			text = append(text, t)
+		}
+	}
+	if len(text) == len(entry.Spec.Text) {
+		return false
+	}
+	entry.Spec.Text = text
+	return true
+}
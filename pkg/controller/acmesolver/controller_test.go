@@ -0,0 +1,213 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package acmesolver
+
+import (
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/server/acmesolver"
+)
+
+func TestDesiredEntry(t *testing.T) {
+	RegisterTestingT(t)
+
+	req := &acmesolver.ChallengeRequest{
+		Action:            acmesolver.ActionPresent,
+		Type:              "dns-01",
+		Key:               "key1",
+		ResourceNamespace: "cert-manager",
+		ResolvedFQDN:      "_acme-challenge.www.example.com.",
+	}
+	entry, err := DesiredEntry(req, false)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(entry.Name).Should(Equal(EntryName("_acme-challenge.www.example.com")))
+	Ω(entry.Namespace).Should(Equal("cert-manager"))
+	Ω(entry.Labels).Should(HaveKey(LABEL_ACME_CHALLENGE))
+	Ω(entry.Annotations).Should(BeEmpty())
+	Ω(entry.Spec.DNSName).Should(Equal("_acme-challenge.www.example.com"))
+	Ω(*entry.Spec.TTL).Should(Equal(int64(DEFAULT_TTL)))
+	Ω(entry.Spec.Text).Should(Equal([]string{"key1"}))
+
+	req.Config = &apiextensionsv1.JSON{Raw: []byte(`{"namespace":"dns","class":"garden","ttl":60,"provider":"dns/aws"}`)}
+	_, err = DesiredEntry(req, false)
+	Ω(err).Should(MatchError(`namespace "dns" of solver config not allowed (namespace of issuer resources is "cert-manager")`))
+	entry, err = DesiredEntry(req, true)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(entry.Namespace).Should(Equal("dns"))
+	Ω(entry.Annotations).Should(HaveKeyWithValue(dns.CLASS_ANNOTATION, "garden"))
+	Ω(*entry.Spec.TTL).Should(Equal(int64(60)))
+	Ω(*entry.Spec.Provider).Should(Equal("dns/aws"))
+
+	req.Config = &apiextensionsv1.JSON{Raw: []byte(`{"namespace":"cert-manager","provider":"aws"}`)}
+	entry, err = DesiredEntry(req, false)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(entry.Namespace).Should(Equal("cert-manager"))
+	Ω(*entry.Spec.Provider).Should(Equal("aws"))
+
+	req.Config = &apiextensionsv1.JSON{Raw: []byte(`{"provider":"dns/aws"}`)}
+	_, err = DesiredEntry(req, true)
+	Ω(err).Should(MatchError("provider dns/aws of solver config must be in namespace cert-manager of the challenge entry"))
+
+	req.Config = &apiextensionsv1.JSON{Raw: []byte(`{"ttl":0}`)}
+	_, err = DesiredEntry(req, false)
+	Ω(err).Should(HaveOccurred())
+
+	req.Config = nil
+	req.ResolvedFQDN = "www.example.com."
+	_, err = DesiredEntry(req, false)
+	Ω(err).Should(HaveOccurred())
+
+	req.ResolvedFQDN = "_acme-challenge.www.example.com."
+	req.Type = "http-01"
+	_, err = DesiredEntry(req, false)
+	Ω(err).Should(HaveOccurred())
+}
+
+func TestKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	entry := &api.DNSEntry{}
+	entry.Spec.Text = []string{"key1"}
+	Ω(AddKey(entry, "key2")).Should(BeTrue())
+	Ω(AddKey(entry, "key2")).Should(BeFalse())
+	Ω(entry.Spec.Text).Should(Equal([]string{"key1", "key2"}))
+
+	Ω(RemoveKey(entry, "key1")).Should(BeTrue())
+	Ω(RemoveKey(entry, "key1")).Should(BeFalse())
+	Ω(entry.Spec.Text).Should(Equal([]string{"key2"}))
+}
+
+func TestRequestHeaderAuthentication(t *testing.T) {
+	RegisterTestingT(t)
+
+	cm := &corev1.ConfigMap{}
+	cm.Namespace = AUTHENTICATION_CONFIGMAP_NAMESPACE
+	cm.Name = AUTHENTICATION_CONFIGMAP_NAME
+	_, _, err := RequestHeaderAuthentication(cm)
+	Ω(err).Should(MatchError("requestheader-client-ca-file missing in config map kube-system/extension-apiserver-authentication"))
+
+	cm.Data = map[string]string{
+		KEY_REQUESTHEADER_CLIENT_CA:     "-----BEGIN CERTIFICATE-----",
+		KEY_REQUESTHEADER_ALLOWED_NAMES: `["front-proxy-client","aggregator"]`,
+	}
+	ca, names, err := RequestHeaderAuthentication(cm)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(string(ca)).Should(Equal("-----BEGIN CERTIFICATE-----"))
+	Ω(names).Should(Equal([]string{"front-proxy-client", "aggregator"}))
+
+	cm.Data[KEY_REQUESTHEADER_ALLOWED_NAMES] = "[]"
+	_, names, err = RequestHeaderAuthentication(cm)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(names).Should(BeEmpty())
+
+	cm.Data[KEY_REQUESTHEADER_ALLOWED_NAMES] = "front-proxy-client"
+	_, _, err = RequestHeaderAuthentication(cm)
+	Ω(err).Should(HaveOccurred())
+}
+
+// testEntries serves a single DNS entry, all other entries are not found.
+type testEntries struct {
+	resources.Interface
+	entry   *api.DNSEntry
+	created bool
+	deleted bool
+}
+
+func (this *testEntries) GetInto(name resources.ObjectName, data resources.ObjectData) (resources.Object, error) {
+	if this.entry == nil || this.entry.Namespace != name.Namespace() || this.entry.Name != name.Name() {
+		return nil, errors.NewNotFound(schema.GroupResource{Group: api.GroupName, Resource: "dnsentries"}, name.Name())
+	}
+	this.entry.DeepCopyInto(data.(*api.DNSEntry))
+	return &testEntry{entries: this}, nil
+}
+
+func (this *testEntries) Create(data resources.ObjectData) (resources.Object, error) {
+	this.entry = data.(*api.DNSEntry)
+	this.created = true
+	return &testEntry{entries: this}, nil
+}
+
+type testEntry struct {
+	resources.Object
+	entries *testEntries
+}
+
+func (this *testEntry) Modify(modifier resources.Modifier) (bool, error) {
+	return modifier(this.entries.entry)
+}
+
+func (this *testEntry) Delete() error {
+	this.entries.deleted = true
+	return nil
+}
+
+func TestSolver(t *testing.T) {
+	RegisterTestingT(t)
+
+	req := &acmesolver.ChallengeRequest{
+		Action:            acmesolver.ActionPresent,
+		Type:              "dns-01",
+		Key:               "key2",
+		ResourceNamespace: "cert-manager",
+		ResolvedFQDN:      "_acme-challenge.www.example.com.",
+	}
+	existing := func() *api.DNSEntry {
+		entry, err := DesiredEntry(req, false)
+		Ω(err).ShouldNot(HaveOccurred())
+		entry.Spec.Text = []string{"key1"}
+		return entry
+	}
+
+	entries := &testEntries{entry: existing()}
+	solver := &Solver{logger: logger.New(), entries: entries}
+	Ω(solver.Present(req)).Should(Succeed())
+	Ω(entries.entry.Spec.Text).Should(Equal([]string{"key1", "key2"}))
+	Ω(solver.CleanUp(req)).Should(Succeed())
+	Ω(entries.entry.Spec.Text).Should(Equal([]string{"key1"}))
+	Ω(entries.deleted).Should(BeFalse())
+
+	// entries without challenge label are never touched
+	entries = &testEntries{entry: existing()}
+	delete(entries.entry.Labels, LABEL_ACME_CHALLENGE)
+	solver.entries = entries
+	Ω(solver.Present(req)).Should(MatchError(ContainSubstring("is no challenge entry")))
+	Ω(solver.CleanUp(req)).Should(MatchError(ContainSubstring("is no challenge entry")))
+	Ω(entries.entry.Spec.Text).Should(Equal([]string{"key1"}))
+	Ω(entries.deleted).Should(BeFalse())
+
+	// challenge entries being deleted are presented again after the deletion
+	entries = &testEntries{entry: existing()}
+	entries.entry.DeletionTimestamp = &metav1.Time{}
+	solver.entries = entries
+	Ω(solver.Present(req)).Should(MatchError(ContainSubstring("is being deleted, retry later")))
+	Ω(entries.entry.Spec.Text).Should(Equal([]string{"key1"}))
+	entries.entry = nil
+	Ω(solver.Present(req)).Should(Succeed())
+	Ω(entries.created).Should(BeTrue())
+	Ω(entries.entry.Spec.Text).Should(Equal([]string{"key2"}))
+}
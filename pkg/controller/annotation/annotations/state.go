@@ -27,6 +27,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/controller-manager-library/pkg/resources/abstract"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
@@ -53,6 +54,7 @@ type Annotations map[string]string
 type State struct {
 	lock        sync.RWMutex
 	annotations map[resources.ClusterObjectKey]*ObjectState
+	selectors   map[resources.ClusterObjectKey]*SelectorState
 	kinds       map[schema.GroupKind]*ObjectKind
 }
 
@@ -68,6 +70,23 @@ type AnnotationState struct {
 	time        metav1.Time
 }
 
+// SelectorState describes the annotations for a set of objects selected by kind, namespace and labels
+type SelectorState struct {
+	AnnotationState
+	kind      schema.GroupKind
+	namespace string
+	selector  labels.Selector
+}
+
+func (this *SelectorState) Matches(obj resources.Object) bool {
+	return this.kind == obj.GroupKind() && this.namespace == obj.GetNamespace() && this.selector.Matches(labels.Set(obj.GetLabels()))
+}
+
+func (this *SelectorState) equivalentTo(s *SelectorState) bool {
+	return this.kind == s.kind && this.namespace == s.namespace && this.selector.String() == s.selector.String() &&
+		reflect.DeepEqual(this.annotations, s.annotations)
+}
+
 type ObjectKind struct {
 	// map of referenced resources to referencing watch resources
 	objects  map[resources.ClusterObjectKey]*ObjectState
@@ -83,6 +102,7 @@ func (this *ObjectKind) notify(obj resources.ClusterObjectKey) {
 func NewWatches() *State {
 	return &State{
 		annotations: map[resources.ClusterObjectKey]*ObjectState{},
+		selectors:   map[resources.ClusterObjectKey]*SelectorState{},
 		kinds:       map[schema.GroupKind]*ObjectKind{},
 	}
 }
@@ -136,43 +156,101 @@ func (this *State) GetInfoFor(key resources.ClusterObjectKey) Annotations {
 		times := map[string]*metav1.Time{}
 		annos := Annotations{}
 		for _, w := range o.annotations {
-			t := w.time
-			for k, v := range w.annotations {
-				if times[k] == nil || times[k].Before(&w.time) {
-					annos[k] = v
-					times[k] = &t
-				}
-			}
+			w.mergeInto(annos, times)
 		}
 		return annos
 	}
 	return nil
 }
 
+// GetInfoForObject returns the annotations for an object given by object reference
+// or by a resource selector matching the object.
+func (this *State) GetInfoForObject(obj resources.Object) Annotations {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	times := map[string]*metav1.Time{}
+	annos := Annotations{}
+	if o := this.getStateFor(obj.ClusterKey()); o != nil {
+		for _, w := range o.annotations {
+			w.mergeInto(annos, times)
+		}
+	}
+	for _, s := range this.selectors {
+		if s.Matches(obj) {
+			s.mergeInto(annos, times)
+		}
+	}
+	if len(annos) == 0 {
+		return nil
+	}
+	return annos
+}
+
+// mergeInto adds the annotations, the newest annotation object wins for conflicting keys.
+func (this *AnnotationState) mergeInto(annos Annotations, times map[string]*metav1.Time) {
+	t := this.time
+	for k, v := range this.annotations {
+		if times[k] == nil || times[k].Before(&this.time) {
+			annos[k] = v
+			times[k] = &t
+		}
+	}
+}
+
 func (this *State) SetActive(key resources.ClusterObjectKey, active bool) error {
 	this.lock.RLock()
 	defer this.lock.RUnlock()
 
 	if o := this.getStateFor(key); o != nil {
 		for _, a := range o.annotations {
-			_, err := a.annotation.ModifyStatus(func(data resources.ObjectData) (bool, error) {
-				anno := data.(*api.DNSAnnotation)
-				if anno.Status.Active != active {
-					anno.Status.Active = active
-					return true, nil
-				}
-				return false, nil
-			})
-			if err != nil {
-				logger.Infof("FAILED to update status: %s", err)
+			if err := a.setActive(active); err != nil {
 				return err
 			}
-			a.annotation.Data().(*api.DNSAnnotation).Status.Active = active
 		}
 	}
 	return nil
 }
 
+// SetActiveForObject sets the active state for all annotation objects relevant for the given object.
+// Annotation objects using a resource selector are only activated, as they are shared by several objects.
+func (this *State) SetActiveForObject(obj resources.Object, active bool) error {
+	if err := this.SetActive(obj.ClusterKey(), active); err != nil {
+		return err
+	}
+	if !active {
+		return nil
+	}
+
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	for _, s := range this.selectors {
+		if s.Matches(obj) && !s.annotation.Data().(*api.DNSAnnotation).Status.Active {
+			if err := s.setActive(true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (this *AnnotationState) setActive(active bool) error {
+	_, err := this.annotation.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		anno := data.(*api.DNSAnnotation)
+		if anno.Status.Active != active {
+			anno.Status.Active = active
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		logger.Infof("FAILED to update status: %s", err)
+		return err
+	}
+	this.annotation.Data().(*api.DNSAnnotation).Status.Active = active
+	return nil
+}
+
 func (this *State) getStateFor(key resources.ClusterObjectKey) *ObjectState {
 	if kind := this.kinds[key.GroupKind()]; kind != nil {
 		return kind.objects[key]
@@ -182,6 +260,9 @@ func (this *State) getStateFor(key resources.ClusterObjectKey) *ObjectState {
 
 func (this *State) Add(logger logger.LogContext, annotation resources.Object) error {
 	if w, ok := annotation.Data().(*api.DNSAnnotation); ok {
+		if w.Spec.ResourceSelector != nil {
+			return this.addSelector(logger, annotation, w)
+		}
 		cluster := annotation.GetCluster().GetId()
 		key, err := Ref(cluster, w)
 		if err != nil {
@@ -193,8 +274,9 @@ func (this *State) Add(logger logger.LogContext, annotation resources.Object) er
 				msg = fmt.Sprintf("invalid ref: %s", err)
 			}
 			a := data.(*api.DNSAnnotation)
-			if a.Status.Message != msg {
+			if a.Status.Message != msg || a.Status.ObservedGeneration != a.Generation {
 				a.Status.Message = msg
+				a.Status.ObservedGeneration = a.Generation
 				return true, nil
 			}
 			return false, nil
@@ -203,6 +285,7 @@ func (this *State) Add(logger logger.LogContext, annotation resources.Object) er
 		this.lock.Lock()
 		defer this.lock.Unlock()
 
+		this.removeSelector(logger, annotation.ClusterKey())
 		if old := this.annotations[annotation.ClusterKey()]; old != nil {
 			if old.object == key {
 				old.annotations[annotation.ClusterKey()].annotation = annotation
@@ -225,11 +308,96 @@ func (this *State) Add(logger logger.LogContext, annotation resources.Object) er
 	return nil
 }
 
+func (this *State) addSelector(logger logger.LogContext, annotation resources.Object, w *api.DNSAnnotation) error {
+	kind, namespace, selector, err := Selector(w)
+	_, err2 := annotation.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		msg := ""
+		if err != nil {
+			msg = fmt.Sprintf("invalid resource selector: %s", err)
+		}
+		a := data.(*api.DNSAnnotation)
+		if a.Status.Message != msg || a.Status.ObservedGeneration != a.Generation {
+			a.Status.Message = msg
+			a.Status.ObservedGeneration = a.Generation
+			return true, nil
+		}
+		return false, nil
+	})
+	if err == nil {
+		err = err2
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	key := annotation.ClusterKey()
+	if old := this.annotations[key]; old != nil {
+		this.removeAnnotations(logger, key, old.object)
+	}
+	if err != nil {
+		this.removeSelector(logger, key)
+		return err
+	}
+
+	state := &SelectorState{
+		AnnotationState: AnnotationState{
+			annotation,
+			w.Spec.Annotations,
+			w.CreationTimestamp,
+		},
+		kind:      kind,
+		namespace: namespace,
+		selector:  selector,
+	}
+	if old := this.selectors[key]; old != nil {
+		if old.equivalentTo(state) {
+			old.annotation = annotation
+			return nil
+		}
+		this.removeSelector(logger, key)
+	}
+	logger.Infof("enforce DNS source annotations for %s in namespace %s selected by %q", kind, namespace, selector)
+	this.selectors[key] = state
+	this.notifySelected(logger, state)
+	return nil
+}
+
+func (this *State) removeSelector(logger logger.LogContext, watch resources.ClusterObjectKey) {
+	if old := this.selectors[watch]; old != nil {
+		logger.Infof("remove annotation enforcement for %s in namespace %s selected by %q", old.kind, old.namespace, old.selector)
+		delete(this.selectors, watch)
+		this.notifySelected(logger, old)
+	}
+}
+
+// notifySelected notifies the registered handlers about all objects matching the selector.
+func (this *State) notifySelected(logger logger.LogContext, s *SelectorState) {
+	wk := this.kinds[s.kind]
+	if wk == nil || len(wk.handlers) == 0 {
+		return
+	}
+	res, err := s.annotation.GetCluster().Resources().GetByGK(s.kind)
+	if err != nil {
+		logger.Warnf("cannot get resource for %s: %s", s.kind, err)
+		return
+	}
+	list, err := res.Namespace(s.namespace).ListCached(s.selector)
+	if err != nil {
+		logger.Warnf("cannot list %s in namespace %s: %s", s.kind, s.namespace, err)
+		return
+	}
+	for _, o := range list {
+		wk.notify(o.ClusterKey())
+	}
+}
+
 func (this *State) Remove(logger logger.LogContext, watch resources.ClusterObjectKey) {
 	if watch.GroupKind() == WatchResourceKind {
 		this.lock.Lock()
 		defer this.lock.Unlock()
 
+		this.removeSelector(logger, watch)
+
 		if old := this.annotations[watch]; old != nil {
 			this.removeAnnotations(logger, watch, old.object)
 		}
@@ -301,9 +469,38 @@ func Ref(cluster string, watch *api.DNSAnnotation) (resources.ClusterObjectKey,
 	if watch.Spec.ResourceRef.Namespace != "" {
 		namespace = watch.Spec.ResourceRef.Namespace
 	}
+	if watch.Spec.ResourceRef.Kind == "" {
+		return resources.ClusterObjectKey{}, fmt.Errorf("either resourceRef or resourceSelector must be specified")
+	}
 	gv, err := schema.ParseGroupVersion(watch.Spec.ResourceRef.APIVersion)
 	if err != nil {
 		return resources.ClusterObjectKey{}, err
 	}
 	return resources.NewClusterKey(cluster, resources.NewGroupKind(gv.Group, watch.Spec.ResourceRef.Kind), namespace, watch.Spec.ResourceRef.Name), nil
 }
+
+func Selector(watch *api.DNSAnnotation) (schema.GroupKind, string, labels.Selector, error) {
+	spec := watch.Spec.ResourceSelector
+	if watch.Spec.ResourceRef != (api.ResourceReference{}) {
+		return schema.GroupKind{}, "", nil, fmt.Errorf("resourceRef and resourceSelector are mutually exclusive")
+	}
+	namespace := watch.Namespace
+	if spec.Namespace != "" {
+		namespace = spec.Namespace
+	}
+	gv, err := schema.ParseGroupVersion(spec.APIVersion)
+	if err != nil {
+		return schema.GroupKind{}, "", nil, err
+	}
+	if spec.Kind == "" {
+		return schema.GroupKind{}, "", nil, fmt.Errorf("kind must be specified")
+	}
+	selector := labels.Everything()
+	if spec.LabelSelector != nil {
+		selector, err = metav1.LabelSelectorAsSelector(spec.LabelSelector)
+		if err != nil {
+			return schema.GroupKind{}, "", nil, err
+		}
+	}
+	return resources.NewGroupKind(gv.Group, spec.Kind), namespace, selector, nil
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package annotations
+
+import (
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+type annotationObject struct {
+	resources.Object
+	data *api.DNSAnnotation
+}
+
+func (o *annotationObject) Data() resources.ObjectData {
+	return o.data
+}
+
+func (o *annotationObject) ClusterKey() resources.ClusterObjectKey {
+	return resources.NewClusterKey("default", resources.NewGroupKind(api.GroupName, api.DNSAnnotationKind), o.data.Namespace, o.data.Name)
+}
+
+func (o *annotationObject) ModifyStatus(modifier resources.Modifier) (bool, error) {
+	return modifier(o.data)
+}
+
+func selectorAnnotation() *api.DNSAnnotation {
+	anno := &api.DNSAnnotation{}
+	anno.Namespace = "default"
+	anno.Name = "anno"
+	anno.Generation = 1
+	anno.Spec.ResourceSelector = &api.ResourceSelector{
+		APIVersion: "v1",
+		Kind:       "Service",
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app": "test"},
+		},
+	}
+	anno.Spec.Annotations = map[string]string{"dns.gardener.cloud/dnsnames": "*"}
+	return anno
+}
+
+func TestSelector(t *testing.T) {
+	RegisterTestingT(t)
+
+	anno := selectorAnnotation()
+	gk, namespace, selector, err := Selector(anno)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(gk).Should(Equal(resources.NewGroupKind("", "Service")))
+	Ω(namespace).Should(Equal("default"))
+	Ω(selector.String()).Should(Equal("app=test"))
+
+	anno.Spec.ResourceRef = api.ResourceReference{APIVersion: "v1", Kind: "Service", Name: "svc"}
+	_, _, _, err = Selector(anno)
+	Ω(err).Should(MatchError("resourceRef and resourceSelector are mutually exclusive"))
+}
+
+func TestAddRejectsRefAndSelector(t *testing.T) {
+	RegisterTestingT(t)
+
+	state := NewWatches()
+	anno := selectorAnnotation()
+	obj := &annotationObject{data: anno}
+	Ω(state.Add(logger.New(), obj)).Should(Succeed())
+	Ω(state.selectors).Should(HaveKey(obj.ClusterKey()))
+	Ω(anno.Status.Message).Should(BeEmpty())
+
+	anno.Spec.ResourceRef = api.ResourceReference{APIVersion: "v1", Kind: "Service", Name: "svc"}
+	anno.Generation = 2
+	Ω(state.Add(logger.New(), obj)).ShouldNot(Succeed())
+	Ω(state.selectors).ShouldNot(HaveKey(obj.ClusterKey()))
+	Ω(state.annotations).ShouldNot(HaveKey(obj.ClusterKey()))
+	Ω(anno.Status.Message).Should(Equal("invalid resource selector: resourceRef and resourceSelector are mutually exclusive"))
+	Ω(anno.Status.ObservedGeneration).Should(Equal(int64(2)))
+}
@@ -0,0 +1,311 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package challenge
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources/apiextensions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/crds"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+const CONTROLLER = "dnschallenge"
+
+// LABEL_CHALLENGE is set on the generated DNS entry and contains the name of the challenge
+const LABEL_CHALLENGE = dns.ANNOTATION_GROUP + "/challenge"
+
+// ENTRY_SUFFIX is appended to the name of the challenge for the generated DNS entry
+const ENTRY_SUFFIX = "-challenge"
+
+// CHALLENGE_PREFIX is the required prefix of the DNS names of challenges
+const CHALLENGE_PREFIX = "_acme-challenge."
+
+// DEFAULT_TTL is the default time to live of challenge records
+const DEFAULT_TTL = 60
+
+// DEFAULT_LIFETIME is the default lifetime of a challenge
+const DEFAULT_LIFETIME = time.Hour
+
+var challengeGroupKind = resources.NewGroupKind(api.GroupName, api.DNSChallengeKind)
+var entryGroupKind = resources.NewGroupKind(api.GroupName, api.DNSEntryKind)
+
+func init() {
+	crds.AddToRegistry(apiextensions.DefaultRegistry())
+
+	controller.Configure(CONTROLLER).
+		Reconciler(Create).
+		DefaultWorkerPool(2, 10*time.Minute).
+		CustomResourceDefinitions(challengeGroupKind, entryGroupKind).
+		MainResource(api.GroupName, api.DNSChallengeKind).
+		Watches(
+			controller.NewResourceKey(api.GroupName, api.DNSEntryKind),
+		).
+		ActivateExplicitly().
+		MustRegister()
+}
+
+type reconciler struct {
+	reconcile.DefaultReconciler
+	controller controller.Interface
+	entries    resources.Interface
+}
+
+var _ reconcile.Interface = &reconciler{}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func Create(controller controller.Interface) (reconcile.Interface, error) {
+	entries, err := controller.GetMainCluster().Resources().GetByExample(&api.DNSEntry{})
+	if err != nil {
+		return nil, err
+	}
+	return &reconciler{
+		controller: controller,
+		entries:    entries,
+	}, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	switch data := obj.Data().(type) {
+	case *api.DNSChallenge:
+		if obj.IsDeleting() {
+			// the generated entry is deleted by the garbage collector
+			return reconcile.Succeeded(logger)
+		}
+		delay, err := this.reconcileChallenge(logger, obj, data)
+		if err != nil {
+			return reconcile.Delay(logger, err)
+		}
+		if delay > 0 {
+			return reconcile.Succeeded(logger).RescheduleAfter(delay)
+		}
+	case *api.DNSEntry:
+		if name := data.Labels[LABEL_CHALLENGE]; name != "" {
+			this.controller.EnqueueKey(resources.NewClusterKey(obj.GetCluster().GetId(), challengeGroupKind, obj.GetNamespace(), name))
+		}
+	}
+	return reconcile.Succeeded(logger)
+}
+
+func (this *reconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	if key.GroupKind() == entryGroupKind && strings.HasSuffix(key.Name(), ENTRY_SUFFIX) {
+		// the labels of the deleted entry are not available anymore, so check for the generated name
+		name := strings.TrimSuffix(key.Name(), ENTRY_SUFFIX)
+		this.controller.EnqueueKey(resources.NewClusterKey(key.Cluster(), challengeGroupKind, key.Namespace(), name))
+	}
+	return reconcile.Succeeded(logger)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// reconcileChallenge maintains the entry of a challenge and returns the delay
+// until the challenge expires.
+func (this *reconciler) reconcileChallenge(logger logger.LogContext, obj resources.Object, challenge *api.DNSChallenge) (time.Duration, error) {
+	expiration := Expiration(challenge)
+	remaining := time.Until(expiration)
+	if remaining <= 0 {
+		logger.Infof("challenge for %s expired, deleting it", challenge.Spec.DNSName)
+		obj.Eventf(corev1.EventTypeNormal, "expired", "challenge expired at %s", expiration.UTC().Format(time.RFC3339))
+		if err := obj.Delete(); err != nil && !errors.IsNotFound(err) {
+			return 0, err
+		}
+		return 0, nil
+	}
+	status := &api.DNSChallengeStatus{Expiration: &metav1.Time{Time: expiration}}
+
+	desired, err := DesiredEntry(challenge)
+	if err != nil {
+		return remaining, this.updateStatus(logger, obj, withState(status, api.STATE_INVALID, err.Error()))
+	}
+	cur, err := this.entries.Namespace(desired.Namespace).GetCached(desired.Name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return 0, err
+		}
+		logger.Infof("creating entry %s for %s", desired.Name, desired.Spec.DNSName)
+		resources.SetOwnerReference(desired, obj.GetOwnerReference())
+		if _, err := this.entries.Create(desired); err != nil {
+			msg := fmt.Sprintf("cannot create entry %s: %s", desired.Name, err)
+			if err2 := this.updateStatus(logger, obj, withState(status, api.STATE_ERROR, msg)); err2 != nil {
+				return 0, err2
+			}
+			return 0, err
+		}
+		return remaining, this.updateStatus(logger, obj, withState(status, api.STATE_PENDING, "entry created"))
+	}
+
+	entry := cur.Data().(*api.DNSEntry)
+	if entry.Labels[LABEL_CHALLENGE] != challenge.Name {
+		msg := fmt.Sprintf("entry %s is not managed by this challenge", desired.Name)
+		return remaining, this.updateStatus(logger, obj, withState(status, api.STATE_ERROR, msg))
+	}
+	mod, err := cur.Modify(func(data resources.ObjectData) (bool, error) {
+		return updateEntry(data.(*api.DNSEntry), desired), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if mod {
+		return remaining, this.updateStatus(logger, obj, withState(status, api.STATE_PENDING, "entry updated"))
+	}
+	var msg string
+	status.State, msg, status.Propagated = ChallengeState(entry)
+	return remaining, this.updateStatus(logger, obj, withState(status, status.State, msg))
+}
+
+func (this *reconciler) updateStatus(logger logger.LogContext, obj resources.Object, desired *api.DNSChallengeStatus) error {
+	_, err := obj.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSChallenge).Status
+		desired.ObservedGeneration = data.GetGeneration()
+		desired.Conditions = append([]metav1.Condition(nil), status.Conditions...)
+		dnsutils.SetStateConditions(&desired.Conditions, desired.ObservedGeneration, desired.State, desired.Message)
+		if reflect.DeepEqual(status, desired) {
+			return false, nil
+		}
+		if status.State != desired.State || status.Message == nil || *status.Message != *desired.Message {
+			logger.Infof("update state to %s: %s", desired.State, *desired.Message)
+		}
+		*status = *desired
+		return true, nil
+	})
+	return err
+}
+
+func withState(status *api.DNSChallengeStatus, state, msg string) *api.DNSChallengeStatus {
+	status.State = state
+	status.Message = &msg
+	return status
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Expiration returns the time after which the challenge is deleted.
+func Expiration(challenge *api.DNSChallenge) time.Time {
+	lifetime := DEFAULT_LIFETIME
+	if challenge.Spec.LifetimeSeconds != nil {
+		lifetime = time.Duration(*challenge.Spec.LifetimeSeconds) * time.Second
+	}
+	return challenge.CreationTimestamp.Add(lifetime)
+}
+
+// DesiredEntry returns the DNS entry generated for the challenge.
+func DesiredEntry(challenge *api.DNSChallenge) (*api.DNSEntry, error) {
+	spec := &challenge.Spec
+	dnsName := strings.TrimSuffix(spec.DNSName, ".")
+	if !strings.HasPrefix(dnsName, CHALLENGE_PREFIX) {
+		return nil, fmt.Errorf("dnsName must start with %s", CHALLENGE_PREFIX)
+	}
+	if len(spec.Values) == 0 {
+		return nil, fmt.Errorf("values missing")
+	}
+	for i, v := range spec.Values {
+		if v == "" {
+			return nil, fmt.Errorf("value %d must not be empty", i+1)
+		}
+	}
+	if spec.LifetimeSeconds != nil && *spec.LifetimeSeconds <= 0 {
+		return nil, fmt.Errorf("lifetimeSeconds must be greater than zero")
+	}
+	ttl := int64(DEFAULT_TTL)
+	if spec.TTL != nil {
+		if *spec.TTL <= 0 {
+			return nil, fmt.Errorf("ttl must be greater than zero")
+		}
+		ttl = *spec.TTL
+	}
+
+	entry := &api.DNSEntry{}
+	entry.Name = challenge.Name + ENTRY_SUFFIX
+	entry.Namespace = challenge.Namespace
+	entry.Labels = map[string]string{LABEL_CHALLENGE: challenge.Name}
+	entry.Spec = api.DNSEntrySpec{
+		DNSName:  dnsName,
+		OwnerId:  spec.OwnerId,
+		TTL:      &ttl,
+		Provider: spec.Provider,
+		Zone:     spec.Zone,
+		Text:     append([]string{}, spec.Values...),
+	}
+	return entry, nil
+}
+
+// ChallengeState derives the state of a challenge from its entry.
+// A challenge is ready if the entry is ready and its records are propagated,
+// or if the propagation is not checked.
+func ChallengeState(entry *api.DNSEntry) (string, string, bool) {
+	if entry.Status.ObservedGeneration != entry.Generation || entry.Status.State == "" {
+		return api.STATE_PENDING, fmt.Sprintf("waiting for entry %s", entry.Name), false
+	}
+	if entry.Status.State != api.STATE_READY {
+		state := entry.Status.State
+		if state != api.STATE_ERROR && state != api.STATE_INVALID {
+			state = api.STATE_PENDING
+		}
+		msg := fmt.Sprintf("entry %s is %s", entry.Name, entry.Status.State)
+		if entry.Status.Message != nil {
+			msg += ": " + *entry.Status.Message
+		}
+		return state, msg, false
+	}
+	cond := meta.FindStatusCondition(entry.Status.Conditions, api.CONDITION_PROPAGATED)
+	if cond == nil || cond.ObservedGeneration != entry.Generation {
+		return api.STATE_READY, "record applied, propagation not checked", false
+	}
+	switch {
+	case cond.Status == metav1.ConditionTrue:
+		return api.STATE_READY, "record applied and propagated", true
+	case cond.Reason == api.PROPAGATION_PENDING:
+		return api.STATE_PENDING, "waiting for propagation of the record", false
+	case cond.Reason == api.PROPAGATION_TIMEOUT:
+		return api.STATE_ERROR, fmt.Sprintf("record not propagated: %s", cond.Message), false
+	default:
+		return api.STATE_READY, fmt.Sprintf("record applied, propagation not verified: %s", cond.Message), false
+	}
+}
+
+func updateEntry(entry *api.DNSEntry, desired *api.DNSEntry) bool {
+	mod := false
+	for k, v := range desired.Labels {
+		if entry.Labels[k] != v {
+			resources.SetLabel(entry, k, v)
+			mod = true
+		}
+	}
+	if !reflect.DeepEqual(entry.Spec, desired.Spec) {
+		entry.Spec = desired.Spec
+		mod = true
+	}
+	return mod
+}
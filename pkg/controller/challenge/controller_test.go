@@ -0,0 +1,131 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package challenge
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+func newChallenge() *api.DNSChallenge {
+	challenge := &api.DNSChallenge{}
+	challenge.Name = "foo"
+	challenge.Namespace = "default"
+	challenge.Spec.DNSName = "_acme-challenge.foo.example.com."
+	challenge.Spec.Values = []string{"token"}
+	return challenge
+}
+
+func TestDesiredEntry(t *testing.T) {
+	RegisterTestingT(t)
+
+	challenge := newChallenge()
+	entry, err := DesiredEntry(challenge)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(entry.Name).Should(Equal("foo-challenge"))
+	Ω(entry.Namespace).Should(Equal("default"))
+	Ω(entry.Labels).Should(HaveKeyWithValue(LABEL_CHALLENGE, "foo"))
+	Ω(entry.Spec.DNSName).Should(Equal("_acme-challenge.foo.example.com"))
+	Ω(entry.Spec.Text).Should(Equal([]string{"token"}))
+	Ω(*entry.Spec.TTL).Should(Equal(int64(DEFAULT_TTL)))
+
+	ttl := int64(120)
+	challenge.Spec.TTL = &ttl
+	entry, err = DesiredEntry(challenge)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(*entry.Spec.TTL).Should(Equal(ttl))
+
+	challenge.Spec.DNSName = "foo.example.com"
+	_, err = DesiredEntry(challenge)
+	Ω(err).Should(HaveOccurred())
+
+	challenge = newChallenge()
+	challenge.Spec.Values = nil
+	_, err = DesiredEntry(challenge)
+	Ω(err).Should(HaveOccurred())
+
+	challenge.Spec.Values = []string{""}
+	_, err = DesiredEntry(challenge)
+	Ω(err).Should(HaveOccurred())
+}
+
+func TestExpiration(t *testing.T) {
+	RegisterTestingT(t)
+
+	created := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	challenge := newChallenge()
+	challenge.CreationTimestamp = metav1.Time{Time: created}
+	Ω(Expiration(challenge)).Should(Equal(created.Add(DEFAULT_LIFETIME)))
+
+	lifetime := int64(300)
+	challenge.Spec.LifetimeSeconds = &lifetime
+	Ω(Expiration(challenge)).Should(Equal(created.Add(5 * time.Minute)))
+}
+
+func TestChallengeState(t *testing.T) {
+	RegisterTestingT(t)
+
+	entry := &api.DNSEntry{}
+	entry.Name = "foo-challenge"
+	entry.Generation = 2
+	entry.Status.ObservedGeneration = 1
+	entry.Status.State = api.STATE_READY
+	state, _, propagated := ChallengeState(entry)
+	Ω(state).Should(Equal(api.STATE_PENDING))
+	Ω(propagated).Should(BeFalse())
+
+	entry.Status.ObservedGeneration = 2
+	entry.Status.State = api.STATE_ERROR
+	state, _, _ = ChallengeState(entry)
+	Ω(state).Should(Equal(api.STATE_ERROR))
+
+	entry.Status.State = api.STATE_READY
+	state, _, propagated = ChallengeState(entry)
+	Ω(state).Should(Equal(api.STATE_READY))
+	Ω(propagated).Should(BeFalse())
+
+	cond := metav1.Condition{
+		Type:               api.CONDITION_PROPAGATED,
+		Status:             metav1.ConditionFalse,
+		Reason:             api.PROPAGATION_PENDING,
+		ObservedGeneration: 2,
+	}
+	entry.Status.Conditions = []metav1.Condition{cond}
+	state, _, propagated = ChallengeState(entry)
+	Ω(state).Should(Equal(api.STATE_PENDING))
+	Ω(propagated).Should(BeFalse())
+
+	entry.Status.Conditions[0].Reason = api.PROPAGATION_TIMEOUT
+	state, _, _ = ChallengeState(entry)
+	Ω(state).Should(Equal(api.STATE_ERROR))
+
+	entry.Status.Conditions[0].Reason = api.PROPAGATION_RESOLVED
+	entry.Status.Conditions[0].Status = metav1.ConditionTrue
+	state, _, propagated = ChallengeState(entry)
+	Ω(state).Should(Equal(api.STATE_READY))
+	Ω(propagated).Should(BeTrue())
+
+	// condition of an outdated generation is ignored
+	entry.Status.Conditions[0].ObservedGeneration = 1
+	_, _, propagated = ChallengeState(entry)
+	Ω(propagated).Should(BeFalse())
+}
@@ -0,0 +1,360 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dnsswitch
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources/apiextensions"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/crds"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+const CONTROLLER = "dnsswitch"
+
+// LABEL_SWITCH is set on the generated DNS entry and contains the name of the switch
+const LABEL_SWITCH = dns.ANNOTATION_GROUP + "/switch"
+
+// ENTRY_SUFFIX is appended to the name of the switch for the generated DNS entry
+const ENTRY_SUFFIX = "-switch"
+
+// MAX_HISTORY is the maximum number of switches kept in the status
+const MAX_HISTORY = 10
+
+var switchGroupKind = resources.NewGroupKind(api.GroupName, api.DNSSwitchKind)
+var entryGroupKind = resources.NewGroupKind(api.GroupName, api.DNSEntryKind)
+
+func init() {
+	crds.AddToRegistry(apiextensions.DefaultRegistry())
+
+	controller.Configure(CONTROLLER).
+		Reconciler(Create).
+		DefaultWorkerPool(2, 10*time.Minute).
+		CustomResourceDefinitions(switchGroupKind, entryGroupKind).
+		MainResource(api.GroupName, api.DNSSwitchKind).
+		Watches(
+			controller.NewResourceKey(api.GroupName, api.DNSEntryKind),
+		).
+		ActivateExplicitly().
+		MustRegister()
+}
+
+type reconciler struct {
+	reconcile.DefaultReconciler
+	controller controller.Interface
+	switches   resources.Interface
+	entries    resources.Interface
+}
+
+var _ reconcile.Interface = &reconciler{}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func Create(controller controller.Interface) (reconcile.Interface, error) {
+	switches, err := controller.GetMainCluster().Resources().GetByExample(&api.DNSSwitch{})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := controller.GetMainCluster().Resources().GetByExample(&api.DNSEntry{})
+	if err != nil {
+		return nil, err
+	}
+	return &reconciler{
+		controller: controller,
+		switches:   switches,
+		entries:    entries,
+	}, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	switch data := obj.Data().(type) {
+	case *api.DNSSwitch:
+		if obj.IsDeleting() {
+			// the generated entry is deleted by the garbage collector
+			return reconcile.Succeeded(logger)
+		}
+		err := this.reconcileSwitch(logger, obj, data)
+		return reconcile.DelayOnError(logger, err)
+	case *api.DNSEntry:
+		if name := data.Labels[LABEL_SWITCH]; name != "" {
+			this.controller.EnqueueKey(resources.NewClusterKey(obj.GetCluster().GetId(), switchGroupKind, obj.GetNamespace(), name))
+			return reconcile.Succeeded(logger)
+		}
+		return this.enqueueReferencingSwitches(logger, obj.ClusterKey())
+	}
+	return reconcile.Succeeded(logger)
+}
+
+func (this *reconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	if key.GroupKind() != entryGroupKind {
+		return reconcile.Succeeded(logger)
+	}
+	if status := this.enqueueReferencingSwitches(logger, key); status.IsFailed() {
+		return status
+	}
+	// the labels of the deleted entry are not available anymore, so check for the generated name
+	if name := SwitchForEntryName(key.Name()); name != "" {
+		this.controller.EnqueueKey(resources.NewClusterKey(key.Cluster(), switchGroupKind, key.Namespace(), name))
+	}
+	return reconcile.Succeeded(logger)
+}
+
+// enqueueReferencingSwitches enqueues all switches in the namespace of the
+// given DNS entry using it as a side.
+func (this *reconciler) enqueueReferencingSwitches(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	switches, err := this.switches.Namespace(key.Namespace()).ListCached(labels.Everything())
+	if err != nil {
+		return reconcile.Delay(logger, err)
+	}
+	for _, obj := range switches {
+		sw := obj.Data().(*api.DNSSwitch)
+		if References(sw, key.Name()) {
+			this.controller.EnqueueKey(obj.ClusterKey())
+		}
+	}
+	return reconcile.Succeeded(logger)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (this *reconciler) reconcileSwitch(logger logger.LogContext, obj resources.Object, sw *api.DNSSwitch) error {
+	side := Side(sw, sw.Spec.Active)
+	if side == nil {
+		return this.updateStatus(logger, obj, api.STATE_INVALID, fmt.Sprintf("invalid active side %q", sw.Spec.Active), "", nil)
+	}
+	for _, name := range []api.DNSSwitchSideName{api.DNSSwitchBlue, api.DNSSwitchGreen} {
+		if err := ValidateSide(Side(sw, name)); err != nil {
+			return this.updateStatus(logger, obj, api.STATE_INVALID, fmt.Sprintf("%s side: %s", name, err), "", nil)
+		}
+	}
+
+	targets, text := side.Targets, side.Text
+	if side.Entry != nil {
+		ref := &api.DNSEntry{}
+		if _, err := this.entries.GetInto(resources.NewObjectName(sw.Namespace, *side.Entry), ref); err != nil {
+			msg := fmt.Sprintf("cannot get entry %s of %s side: %s", *side.Entry, sw.Spec.Active, err)
+			if err2 := this.updateStatus(logger, obj, api.STATE_ERROR, msg, "", nil); err2 != nil {
+				return err2
+			}
+			if errors.IsNotFound(err) {
+				// the switch is enqueued again once the entry is created
+				return nil
+			}
+			return err
+		}
+		targets, text = ref.Spec.Targets, ref.Spec.Text
+		if len(targets) == 0 && len(text) == 0 {
+			return this.updateStatus(logger, obj, api.STATE_INVALID,
+				fmt.Sprintf("entry %s of %s side has neither targets nor text", *side.Entry, sw.Spec.Active), "", nil)
+		}
+	}
+
+	desired := DesiredEntry(sw, targets, text)
+	entryObj, err := this.entries.Namespace(desired.Namespace).GetCached(desired.Name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		logger.Infof("creating entry %s for %s side", desired.Name, sw.Spec.Active)
+		resources.SetOwnerReference(desired, obj.GetOwnerReference())
+		if _, err := this.entries.Create(desired); err != nil {
+			msg := fmt.Sprintf("cannot create entry %s: %s", desired.Name, err)
+			if err2 := this.updateStatus(logger, obj, api.STATE_ERROR, msg, "", nil); err2 != nil {
+				return err2
+			}
+			return err
+		}
+		return this.updateStatus(logger, obj, api.STATE_PENDING, "entry created", sw.Spec.Active, Records(targets, text))
+	}
+
+	cur := entryObj.Data().(*api.DNSEntry)
+	mod, err := entryObj.Modify(func(data resources.ObjectData) (bool, error) {
+		return updateEntry(data.(*api.DNSEntry), desired), nil
+	})
+	if err != nil {
+		msg := fmt.Sprintf("cannot update entry %s: %s", desired.Name, err)
+		if err2 := this.updateStatus(logger, obj, api.STATE_ERROR, msg, "", nil); err2 != nil {
+			return err2
+		}
+		return err
+	}
+	if mod {
+		logger.Infof("switched entry %s to %s side", desired.Name, sw.Spec.Active)
+		return this.updateStatus(logger, obj, api.STATE_PENDING, "entry updated", sw.Spec.Active, Records(targets, text))
+	}
+
+	state := cur.Status.State
+	msg := fmt.Sprintf("entry %s is %s", cur.Name, state)
+	if state == "" {
+		state = api.STATE_PENDING
+		msg = fmt.Sprintf("entry %s is pending", cur.Name)
+	}
+	if cur.Status.Message != nil && state != api.STATE_READY {
+		msg += ": " + *cur.Status.Message
+	}
+	return this.updateStatus(logger, obj, state, msg, sw.Spec.Active, Records(targets, text))
+}
+
+// updateStatus updates the status of the switch. If active is set and
+// differs from the side recorded in the status, the switch is added to the
+// history.
+func (this *reconciler) updateStatus(logger logger.LogContext, obj resources.Object, state, msg string, active api.DNSSwitchSideName, records []string) error {
+	_, err := obj.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSSwitch).Status
+		mod := status.State != state || status.Message == nil || *status.Message != msg ||
+			status.ObservedGeneration != data.GetGeneration()
+		if mod {
+			logger.Infof("update state to %s: %s", state, msg)
+			status.State = state
+			status.Message = &msg
+			status.ObservedGeneration = data.GetGeneration()
+		}
+		mod = dnsutils.SetStateConditions(&status.Conditions, status.ObservedGeneration, state, &msg) || mod
+		if active != "" && status.Active != active {
+			logger.Infof("switched from %q to %q", status.Active, active)
+			RecordSwitch(status, active, records, metav1.Now())
+			mod = true
+		}
+		return mod, nil
+	})
+	return err
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Side returns the side of the switch with the given name or nil for an
+// unknown name.
+func Side(sw *api.DNSSwitch, name api.DNSSwitchSideName) *api.DNSSwitchSide {
+	switch name {
+	case api.DNSSwitchBlue:
+		return &sw.Spec.Blue
+	case api.DNSSwitchGreen:
+		return &sw.Spec.Green
+	}
+	return nil
+}
+
+// ValidateSide checks that a side is either given by targets or text, or by
+// an entry reference.
+func ValidateSide(side *api.DNSSwitchSide) error {
+	records := len(side.Targets) > 0 || len(side.Text) > 0
+	switch {
+	case side.Entry != nil && *side.Entry == "":
+		return fmt.Errorf("entry name must not be empty")
+	case side.Entry != nil && records:
+		return fmt.Errorf("entry reference cannot be combined with targets or text")
+	case side.Entry == nil && !records:
+		return fmt.Errorf("targets, text or entry missing")
+	case len(side.Targets) > 0 && len(side.Text) > 0:
+		return fmt.Errorf("targets and text cannot be combined")
+	}
+	return nil
+}
+
+// References checks whether one of the sides of the switch references the
+// given DNS entry.
+func References(sw *api.DNSSwitch, entry string) bool {
+	for _, side := range []*api.DNSSwitchSide{&sw.Spec.Blue, &sw.Spec.Green} {
+		if side.Entry != nil && *side.Entry == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// EntryName returns the name of the DNS entry generated for a switch.
+func EntryName(switchName string) string {
+	return switchName + ENTRY_SUFFIX
+}
+
+// SwitchForEntryName returns the name of the switch for the name of a
+// generated DNS entry or an empty string for other entries.
+func SwitchForEntryName(entryName string) string {
+	if entryName == ENTRY_SUFFIX || !strings.HasSuffix(entryName, ENTRY_SUFFIX) {
+		return ""
+	}
+	return strings.TrimSuffix(entryName, ENTRY_SUFFIX)
+}
+
+// DesiredEntry returns the DNS entry generated for the switch with the
+// records of the active side.
+func DesiredEntry(sw *api.DNSSwitch, targets, text []string) *api.DNSEntry {
+	entry := &api.DNSEntry{}
+	entry.Name = EntryName(sw.Name)
+	entry.Namespace = sw.Namespace
+	entry.Labels = map[string]string{LABEL_SWITCH: sw.Name}
+	entry.Spec = api.DNSEntrySpec{
+		DNSName:  sw.Spec.DNSName,
+		OwnerId:  sw.Spec.OwnerId,
+		TTL:      sw.Spec.TTL,
+		Provider: sw.Spec.Provider,
+		Zone:     sw.Spec.Zone,
+		Targets:  targets,
+		Text:     text,
+	}
+	return entry
+}
+
+// Records returns the records applied for the given targets or text.
+func Records(targets, text []string) []string {
+	if len(targets) > 0 {
+		return append([]string{}, targets...)
+	}
+	return append([]string{}, text...)
+}
+
+// RecordSwitch sets the active side of the status and adds the switch as
+// first item of the history. The history is limited to MAX_HISTORY items.
+func RecordSwitch(status *api.DNSSwitchStatus, active api.DNSSwitchSideName, records []string, now metav1.Time) {
+	status.Active = active
+	item := api.DNSSwitchHistoryItem{Active: active, Time: now, Records: records}
+	status.History = append([]api.DNSSwitchHistoryItem{item}, status.History...)
+	if len(status.History) > MAX_HISTORY {
+		status.History = status.History[:MAX_HISTORY]
+	}
+}
+
+func updateEntry(entry *api.DNSEntry, desired *api.DNSEntry) bool {
+	mod := false
+	for k, v := range desired.Labels {
+		if entry.Labels[k] != v {
+			resources.SetLabel(entry, k, v)
+			mod = true
+		}
+	}
+	if !reflect.DeepEqual(entry.Spec, desired.Spec) {
+		entry.Spec = desired.Spec
+		mod = true
+	}
+	return mod
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dnsswitch
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+func TestValidateSide(t *testing.T) {
+	RegisterTestingT(t)
+
+	entry := "blue-entry"
+	empty := ""
+	Ω(ValidateSide(&api.DNSSwitchSide{Targets: []string{"1.1.1.1"}})).Should(Succeed())
+	Ω(ValidateSide(&api.DNSSwitchSide{Text: []string{"blue"}})).Should(Succeed())
+	Ω(ValidateSide(&api.DNSSwitchSide{Entry: &entry})).Should(Succeed())
+	Ω(ValidateSide(&api.DNSSwitchSide{})).ShouldNot(Succeed())
+	Ω(ValidateSide(&api.DNSSwitchSide{Entry: &empty})).ShouldNot(Succeed())
+	Ω(ValidateSide(&api.DNSSwitchSide{Entry: &entry, Targets: []string{"1.1.1.1"}})).ShouldNot(Succeed())
+	Ω(ValidateSide(&api.DNSSwitchSide{Targets: []string{"1.1.1.1"}, Text: []string{"blue"}})).ShouldNot(Succeed())
+}
+
+func TestDesiredEntry(t *testing.T) {
+	RegisterTestingT(t)
+
+	ttl := int64(60)
+	sw := &api.DNSSwitch{}
+	sw.Name = "app"
+	sw.Namespace = "default"
+	sw.Spec.DNSName = "app.example.com"
+	sw.Spec.TTL = &ttl
+	sw.Spec.Blue.Targets = []string{"1.1.1.1"}
+	sw.Spec.Green.Targets = []string{"2.2.2.2"}
+	sw.Spec.Active = api.DNSSwitchGreen
+
+	side := Side(sw, sw.Spec.Active)
+	entry := DesiredEntry(sw, side.Targets, side.Text)
+	Ω(entry.Name).Should(Equal("app-switch"))
+	Ω(entry.Namespace).Should(Equal("default"))
+	Ω(entry.Labels).Should(HaveKeyWithValue(LABEL_SWITCH, "app"))
+	Ω(entry.Spec.DNSName).Should(Equal("app.example.com"))
+	Ω(*entry.Spec.TTL).Should(Equal(ttl))
+	Ω(entry.Spec.Targets).Should(Equal([]string{"2.2.2.2"}))
+
+	Ω(Side(sw, "red")).Should(BeNil())
+	Ω(SwitchForEntryName(entry.Name)).Should(Equal("app"))
+	Ω(SwitchForEntryName("app")).Should(BeEmpty())
+	Ω(SwitchForEntryName(ENTRY_SUFFIX)).Should(BeEmpty())
+}
+
+func TestReferences(t *testing.T) {
+	RegisterTestingT(t)
+
+	entry := "green-entry"
+	sw := &api.DNSSwitch{}
+	sw.Spec.Blue.Targets = []string{"1.1.1.1"}
+	sw.Spec.Green.Entry = &entry
+	Ω(References(sw, "green-entry")).Should(BeTrue())
+	Ω(References(sw, "blue-entry")).Should(BeFalse())
+}
+
+func TestRecordSwitch(t *testing.T) {
+	RegisterTestingT(t)
+
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	status := &api.DNSSwitchStatus{}
+	RecordSwitch(status, api.DNSSwitchBlue, []string{"1.1.1.1"}, metav1.NewTime(now))
+	RecordSwitch(status, api.DNSSwitchGreen, []string{"2.2.2.2"}, metav1.NewTime(now.Add(time.Minute)))
+	Ω(status.Active).Should(Equal(api.DNSSwitchGreen))
+	Ω(status.History).Should(HaveLen(2))
+	Ω(status.History[0].Active).Should(Equal(api.DNSSwitchGreen))
+	Ω(status.History[0].Records).Should(Equal([]string{"2.2.2.2"}))
+	Ω(status.History[1].Active).Should(Equal(api.DNSSwitchBlue))
+
+	for i := 0; i < MAX_HISTORY; i++ {
+		RecordSwitch(status, api.DNSSwitchBlue, []string{fmt.Sprintf("10.0.0.%d", i)}, metav1.NewTime(now))
+	}
+	Ω(status.History).Should(HaveLen(MAX_HISTORY))
+	Ω(status.History[0].Records).Should(Equal([]string{fmt.Sprintf("10.0.0.%d", MAX_HISTORY-1)}))
+}
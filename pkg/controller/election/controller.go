@@ -0,0 +1,321 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package election
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources/apiextensions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/crds"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+const CONTROLLER = "dnselection"
+
+// OPT_IDENTIFIER is the option for the default candidate of elections.
+// It is shared with the DNS controllers, so by default the candidate is
+// the holder identity used by the DNS controller for lock leases.
+const OPT_IDENTIFIER = "identifier"
+
+// LABEL_ELECTION is set on the DNS lock of an election and contains the name of the election
+const LABEL_ELECTION = dns.ANNOTATION_GROUP + "/election"
+
+// ATTR_ELECTION is the attribute of the lock record containing the name of the election
+const ATTR_ELECTION = "election"
+
+// DEFAULT_TTL is the default time to live of the lock record of an election
+const DEFAULT_TTL = 120
+
+var electionGroupKind = resources.NewGroupKind(api.GroupName, api.DNSElectionKind)
+var lockGroupKind = resources.NewGroupKind(api.GroupName, api.DNSLockKind)
+
+func init() {
+	crds.AddToRegistry(apiextensions.DefaultRegistry())
+
+	controller.Configure(CONTROLLER).
+		Reconciler(Create).
+		DefaultWorkerPool(2, 10*time.Minute).
+		DefaultedStringOption(OPT_IDENTIFIER, "dnscontroller", "Identifier used as default candidate of DNS elections").
+		CustomResourceDefinitions(electionGroupKind, lockGroupKind).
+		MainResource(api.GroupName, api.DNSElectionKind).
+		WorkerPool("locks", 2, 0).
+		Watches(
+			controller.NewResourceKey(api.GroupName, api.DNSLockKind),
+		).
+		ActivateExplicitly().
+		MustRegister()
+}
+
+type reconciler struct {
+	reconcile.DefaultReconciler
+	controller controller.Interface
+	locks      resources.Interface
+	ident      string
+}
+
+var _ reconcile.Interface = &reconciler{}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func Create(controller controller.Interface) (reconcile.Interface, error) {
+	locks, err := controller.GetMainCluster().Resources().GetByExample(&api.DNSLock{})
+	if err != nil {
+		return nil, err
+	}
+	ident, err := controller.GetStringOption(OPT_IDENTIFIER)
+	if err != nil {
+		return nil, err
+	}
+	return &reconciler{
+		controller: controller,
+		locks:      locks,
+		ident:      ident,
+	}, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	switch data := obj.Data().(type) {
+	case *api.DNSElection:
+		if obj.IsDeleting() {
+			// the lock is deleted by the garbage collector, which releases the leadership
+			return reconcile.Succeeded(logger)
+		}
+		err := this.reconcileElection(logger, obj, data)
+		return reconcile.DelayOnError(logger, err)
+	case *api.DNSLock:
+		if name := data.Labels[LABEL_ELECTION]; name != "" {
+			this.controller.EnqueueKey(resources.NewClusterKey(obj.GetCluster().GetId(), electionGroupKind, obj.GetNamespace(), name))
+		}
+	}
+	return reconcile.Succeeded(logger)
+}
+
+func (this *reconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	if key.GroupKind() == lockGroupKind {
+		// the lock of an election has the name of the election
+		this.controller.EnqueueKey(resources.NewClusterKey(key.Cluster(), electionGroupKind, key.Namespace(), key.Name()))
+	}
+	return reconcile.Succeeded(logger)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (this *reconciler) reconcileElection(logger logger.LogContext, obj resources.Object, election *api.DNSElection) error {
+	candidate := Candidate(election, this.ident)
+	desired, err := DesiredLock(election, candidate, time.Now())
+	if err != nil {
+		return this.updateStatus(logger, obj, &Result{State: api.STATE_INVALID, Message: err.Error()})
+	}
+
+	cur, err := this.locks.Get(desired)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		logger.Infof("creating lock %s for %s", desired.Name, desired.Spec.DNSName)
+		resources.SetOwnerReference(desired, obj.GetOwnerReference())
+		if _, err := this.locks.Create(desired); err != nil {
+			msg := fmt.Sprintf("cannot create lock %s: %s", desired.Name, err)
+			if err2 := this.updateStatus(logger, obj, &Result{State: api.STATE_ERROR, Message: msg}); err2 != nil {
+				return err2
+			}
+			return fmt.Errorf("%s", msg)
+		}
+		return this.updateStatus(logger, obj, &Result{State: api.STATE_PENDING, Message: "waiting for lock"})
+	}
+
+	lock := cur.Data().(*api.DNSLock)
+	if lock.Labels[LABEL_ELECTION] != election.Name {
+		msg := fmt.Sprintf("lock %s is not managed by this election", desired.Name)
+		return this.updateStatus(logger, obj, &Result{State: api.STATE_ERROR, Message: msg})
+	}
+	_, err = cur.Modify(func(data resources.ObjectData) (bool, error) {
+		return updateLock(data.(*api.DNSLock), desired), nil
+	})
+	if err != nil {
+		return err
+	}
+	return this.updateStatus(logger, obj, LockResult(lock, candidate))
+}
+
+func (this *reconciler) updateStatus(logger logger.LogContext, obj resources.Object, result *Result) error {
+	var prev *string
+	_, err := obj.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSElection).Status
+		prev = status.Leader
+		mod := utils.ModificationState{}
+		mod.AssureStringValue(&status.State, result.State)
+		mod.AssureStringPtrPtr(&status.Message, &result.Message)
+		if utils.StringValue(status.Leader) != utils.StringValue(result.Leader) {
+			now := metav1.Now()
+			status.LeaderTransitionTime = &now
+			mod.Modify(true)
+		}
+		mod.AssureStringPtrPtr(&status.Leader, result.Leader)
+		mod.AssureBoolValue(&status.IsLeader, result.IsLeader)
+		if !reflect.DeepEqual(status.LeaseExpiration, result.LeaseExpiration) {
+			status.LeaseExpiration = result.LeaseExpiration
+			mod.Modify(true)
+		}
+		mod.AssureInt64Value(&status.ObservedGeneration, data.GetGeneration())
+		mod.Modify(dnsutils.SetStateConditions(&status.Conditions, status.ObservedGeneration, status.State, status.Message))
+		if mod.IsModified() {
+			logger.Infof("update state to %s: %s", result.State, result.Message)
+		}
+		return mod.IsModified(), nil
+	})
+	if err == nil && result.Leader != nil && utils.StringValue(prev) != *result.Leader {
+		if result.IsLeader {
+			obj.Eventf(corev1.EventTypeNormal, "leader", "became leader of election %s", obj.GetName())
+		} else {
+			obj.Eventf(corev1.EventTypeNormal, "leader", "leader of election is %s", *result.Leader)
+		}
+	}
+	return err
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Result is the state of an election derived from its DNS lock.
+type Result struct {
+	State           string
+	Message         string
+	Leader          *string
+	IsLeader        bool
+	LeaseExpiration *metav1.Time
+}
+
+// Candidate returns the identity of the candidate of an election or the given default identity.
+func Candidate(election *api.DNSElection, def string) string {
+	if s := utils.StringValue(election.Spec.Candidate); s != "" {
+		return s
+	}
+	return def
+}
+
+// DesiredLock returns the DNS lock with lease semantics used to perform the election.
+// The timestamp is only used for newly created locks, it is ignored for leases.
+func DesiredLock(election *api.DNSElection, candidate string, now time.Time) (*api.DNSLock, error) {
+	dnsName := strings.TrimSuffix(election.Spec.DNSName, ".")
+	if dnsName == "" {
+		return nil, fmt.Errorf("dnsName missing")
+	}
+	if candidate == "" || strings.ContainsAny(candidate, " \t=\"") {
+		return nil, fmt.Errorf("invalid candidate %q", candidate)
+	}
+	ttl := int64(DEFAULT_TTL)
+	if election.Spec.TTL != nil {
+		ttl = *election.Spec.TTL
+		if ttl <= 0 {
+			return nil, fmt.Errorf("ttl must be positive")
+		}
+	}
+
+	lease := true
+	lock := &api.DNSLock{}
+	lock.Name = election.Name
+	lock.Namespace = election.Namespace
+	lock.Labels = map[string]string{LABEL_ELECTION: election.Name}
+	lock.Spec = api.DNSLockSpec{
+		DNSName:    dnsName,
+		LockId:     election.Spec.LockId,
+		TTL:        ttl,
+		Timestamp:  metav1.NewTime(now),
+		Attributes: map[string]string{ATTR_ELECTION: election.Name},
+		Lease:      &lease,
+		Holder:     &candidate,
+		Lookup:     election.Spec.Lookup,
+	}
+	return lock, nil
+}
+
+// LockResult derives the state of an election from the status of its DNS lock.
+func LockResult(lock *api.DNSLock, candidate string) *Result {
+	status := &lock.Status
+	if status.ObservedGeneration != lock.Generation || status.State == "" {
+		return &Result{State: api.STATE_PENDING, Message: "waiting for lock"}
+	}
+	result := &Result{
+		State:           status.State,
+		Message:         utils.StringValue(status.Message),
+		Leader:          status.Holder,
+		LeaseExpiration: status.LeaseExpiration,
+	}
+	if status.State != api.STATE_READY {
+		if result.Message == "" {
+			result.Message = fmt.Sprintf("lock is %s", status.State)
+		}
+		return result
+	}
+	if result.Leader == nil {
+		result.State = api.STATE_PENDING
+		result.Message = "leader not yet known"
+		return result
+	}
+	result.IsLeader = *result.Leader == candidate
+	if result.IsLeader {
+		result.Message = "candidate is leader"
+	} else {
+		result.Message = fmt.Sprintf("leader is %s", *result.Leader)
+	}
+	return result
+}
+
+// IsLeader reports whether the candidate of the cluster is the leader of the election.
+// It can be used by other controllers consuming the election. The leadership is only
+// reported for an up-to-date status whose lease is not expired at the given time.
+func IsLeader(election *api.DNSElection, now time.Time) bool {
+	status := &election.Status
+	if status.State != api.STATE_READY || !status.IsLeader || status.ObservedGeneration != election.Generation {
+		return false
+	}
+	return status.LeaseExpiration == nil || now.Before(status.LeaseExpiration.Time)
+}
+
+func updateLock(lock *api.DNSLock, desired *api.DNSLock) bool {
+	mod := false
+	for k, v := range desired.Labels {
+		if lock.Labels[k] != v {
+			resources.SetLabel(lock, k, v)
+			mod = true
+		}
+	}
+	spec := desired.Spec
+	spec.Timestamp = lock.Spec.Timestamp
+	if !reflect.DeepEqual(lock.Spec, spec) {
+		lock.Spec = spec
+		mod = true
+	}
+	return mod
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package election
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+func TestDesiredLock(t *testing.T) {
+	RegisterTestingT(t)
+
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	election := &api.DNSElection{}
+	election.Name = "leader"
+	election.Namespace = "default"
+	election.Spec.DNSName = "leader.example.com."
+
+	lock, err := DesiredLock(election, Candidate(election, "cluster-a"), now)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(lock.Name).Should(Equal("leader"))
+	Ω(lock.Labels).Should(HaveKeyWithValue(LABEL_ELECTION, "leader"))
+	Ω(lock.Spec.DNSName).Should(Equal("leader.example.com"))
+	Ω(lock.Spec.TTL).Should(Equal(int64(DEFAULT_TTL)))
+	Ω(*lock.Spec.Lease).Should(BeTrue())
+	Ω(*lock.Spec.Holder).Should(Equal("cluster-a"))
+	Ω(lock.Spec.Timestamp.Time).Should(Equal(now))
+
+	other := "cluster b"
+	election.Spec.Candidate = &other
+	_, err = DesiredLock(election, Candidate(election, "cluster-a"), now)
+	Ω(err).Should(HaveOccurred())
+
+	ttl := int64(0)
+	election.Spec.Candidate = nil
+	election.Spec.TTL = &ttl
+	_, err = DesiredLock(election, "cluster-a", now)
+	Ω(err).Should(HaveOccurred())
+}
+
+func TestLockResult(t *testing.T) {
+	RegisterTestingT(t)
+
+	lock := &api.DNSLock{}
+	lock.Generation = 1
+	Ω(LockResult(lock, "cluster-a").State).Should(Equal(api.STATE_PENDING))
+
+	holder := "cluster-b"
+	expiration := metav1.NewTime(time.Date(2022, 6, 1, 12, 6, 0, 0, time.UTC))
+	lock.Status.ObservedGeneration = 1
+	lock.Status.State = api.STATE_READY
+	lock.Status.Holder = &holder
+	lock.Status.LeaseExpiration = &expiration
+
+	result := LockResult(lock, "cluster-a")
+	Ω(result.State).Should(Equal(api.STATE_READY))
+	Ω(*result.Leader).Should(Equal("cluster-b"))
+	Ω(result.IsLeader).Should(BeFalse())
+	Ω(result.Message).Should(Equal("leader is cluster-b"))
+
+	result = LockResult(lock, "cluster-b")
+	Ω(result.IsLeader).Should(BeTrue())
+	Ω(result.LeaseExpiration).Should(Equal(&expiration))
+
+	lock.Status.State = api.STATE_STALE
+	result = LockResult(lock, "cluster-b")
+	Ω(result.State).Should(Equal(api.STATE_STALE))
+	Ω(result.IsLeader).Should(BeFalse())
+}
+
+func TestIsLeader(t *testing.T) {
+	RegisterTestingT(t)
+
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	expiration := metav1.NewTime(now.Add(time.Minute))
+	election := &api.DNSElection{}
+	election.Generation = 2
+	election.Status = api.DNSElectionStatus{
+		ObservedGeneration: 2,
+		State:              api.STATE_READY,
+		IsLeader:           true,
+		LeaseExpiration:    &expiration,
+	}
+	Ω(IsLeader(election, now)).Should(BeTrue())
+	Ω(IsLeader(election, now.Add(2*time.Minute))).Should(BeFalse())
+
+	election.Generation = 3
+	Ω(IsLeader(election, now)).Should(BeFalse())
+}
@@ -0,0 +1,525 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package entryset
+
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources/apiextensions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/crds"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+const CONTROLLER = "dnsentryset"
+
+// LABEL_ENTRYSET is set on generated DNS entries and contains the name of the entry set
+const LABEL_ENTRYSET = dns.ANNOTATION_GROUP + "/entryset"
+
+// ANNOTATION_APPLIED_LABELS is set on generated DNS entries and lists the keys of the labels applied from the template
+const ANNOTATION_APPLIED_LABELS = dns.ANNOTATION_GROUP + "/entryset-applied-labels"
+
+// ANNOTATION_APPLIED_ANNOTATIONS is set on generated DNS entries and lists the keys of the annotations applied from the template
+const ANNOTATION_APPLIED_ANNOTATIONS = dns.ANNOTATION_GROUP + "/entryset-applied-annotations"
+
+// DEFAULT_ZONEFILE_KEY is the default key of the zone file in the config map
+const DEFAULT_ZONEFILE_KEY = "zonefile"
+
+var entrySetGroupKind = resources.NewGroupKind(api.GroupName, api.DNSEntrySetKind)
+var entryGroupKind = resources.NewGroupKind(api.GroupName, api.DNSEntryKind)
+
+func init() {
+	crds.AddToRegistry(apiextensions.DefaultRegistry())
+
+	controller.Configure(CONTROLLER).
+		Reconciler(Create).
+		DefaultWorkerPool(2, 10*time.Minute).
+		CustomResourceDefinitions(entrySetGroupKind, entryGroupKind).
+		MainResource(api.GroupName, api.DNSEntrySetKind).
+		WorkerPool("entries", 2, 0).
+		Watches(
+			controller.NewResourceKey(api.GroupName, api.DNSEntryKind),
+		).
+		ActivateExplicitly().
+		MustRegister()
+}
+
+type reconciler struct {
+	reconcile.DefaultReconciler
+	controller controller.Interface
+	sets       resources.Interface
+	entries    resources.Interface
+	configmaps resources.Interface
+}
+
+var _ reconcile.Interface = &reconciler{}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func Create(controller controller.Interface) (reconcile.Interface, error) {
+	sets, err := controller.GetMainCluster().Resources().GetByExample(&api.DNSEntrySet{})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := controller.GetMainCluster().Resources().GetByExample(&api.DNSEntry{})
+	if err != nil {
+		return nil, err
+	}
+	configmaps, err := controller.GetMainCluster().Resources().GetByExample(&corev1.ConfigMap{})
+	if err != nil {
+		return nil, err
+	}
+	return &reconciler{
+		controller: controller,
+		sets:       sets,
+		entries:    entries,
+		configmaps: configmaps,
+	}, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	switch data := obj.Data().(type) {
+	case *api.DNSEntrySet:
+		if obj.IsDeleting() {
+			// generated entries are deleted by the garbage collector
+			return reconcile.Succeeded(logger)
+		}
+		err := this.reconcileSet(logger, obj, data)
+		return reconcile.DelayOnError(logger, err)
+	case *api.DNSEntry:
+		if name := data.Labels[LABEL_ENTRYSET]; name != "" {
+			this.controller.EnqueueKey(resources.NewClusterKey(obj.GetCluster().GetId(), entrySetGroupKind, obj.GetNamespace(), name))
+		}
+	}
+	return reconcile.Succeeded(logger)
+}
+
+func (this *reconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	if key.GroupKind() != entryGroupKind {
+		return reconcile.Succeeded(logger)
+	}
+	// the labels of the deleted entry are not available anymore, so check for the name prefix
+	sets, err := this.sets.Namespace(key.Namespace()).ListCached(labels.Everything())
+	if err != nil {
+		return reconcile.Delay(logger, err)
+	}
+	for _, set := range sets {
+		if IsEntryNameOf(set.GetName(), key.Name()) {
+			this.controller.EnqueueKey(set.ClusterKey())
+		}
+	}
+	return reconcile.Succeeded(logger)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (this *reconciler) reconcileSet(logger logger.LogContext, obj resources.Object, set *api.DNSEntrySet) error {
+	if len(set.Spec.Entries) == 0 && set.Spec.ZoneFile == nil {
+		return this.updateStatus(logger, obj, api.STATE_INVALID, "entries or zone file missing", 0, 0)
+	}
+	var skipped []string
+	if set.Spec.ZoneFile != nil {
+		records, err := this.readZoneFile(set)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				if err2 := this.updateStatus(logger, obj, api.STATE_ERROR, err.Error(), 0, 0); err2 != nil {
+					return err2
+				}
+				return err
+			}
+			return this.updateStatus(logger, obj, api.STATE_INVALID, err.Error(), 0, 0)
+		}
+		var items []api.DNSEntrySetItem
+		items, skipped = ZoneFileEntries(zoneFileOrigin(set), records)
+		set = set.DeepCopy()
+		set.Spec.Entries = append(set.Spec.Entries, items...)
+	}
+	desired, err := DesiredEntries(set)
+	if err != nil {
+		return this.updateStatus(logger, obj, api.STATE_INVALID, err.Error(), 0, 0)
+	}
+
+	list, err := this.entries.Namespace(set.Namespace).ListCached(labels.SelectorFromSet(labels.Set{LABEL_ENTRYSET: set.Name}))
+	if err != nil {
+		return err
+	}
+	existing := map[string]resources.Object{}
+	current := map[string]*api.DNSEntry{}
+	for _, e := range list {
+		existing[e.GetName()] = e
+		current[e.GetName()] = e.Data().(*api.DNSEntry)
+	}
+
+	ready := 0
+	for name := range desired {
+		if cur := current[name]; cur != nil && cur.Status.State == api.STATE_READY {
+			ready++
+		}
+	}
+	var errs []string
+	changes := DiffEntries(desired, current)
+	for _, name := range changes.Create {
+		entry := desired[name]
+		logger.Infof("creating entry %s for %s", name, entry.Spec.DNSName)
+		resources.SetOwnerReference(entry, obj.GetOwnerReference())
+		if _, err := this.entries.Create(entry); err != nil {
+			errs = append(errs, fmt.Sprintf("cannot create entry %s: %s", name, err))
+		}
+	}
+	for _, name := range changes.Update {
+		logger.Infof("updating entry %s", name)
+		_, err := existing[name].Modify(func(data resources.ObjectData) (bool, error) {
+			return updateEntry(data.(*api.DNSEntry), desired[name]), nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("cannot update entry %s: %s", name, err))
+		}
+	}
+	for _, name := range changes.Delete {
+		logger.Infof("deleting obsolete entry %s", name)
+		if err := existing[name].Delete(); err != nil && !errors.IsNotFound(err) {
+			errs = append(errs, fmt.Sprintf("cannot delete entry %s: %s", name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		msg := strings.Join(errs, ", ")
+		if err := this.updateStatus(logger, obj, api.STATE_ERROR, msg, len(desired), ready); err != nil {
+			return err
+		}
+		return fmt.Errorf("%s", msg)
+	}
+	state := api.STATE_PENDING
+	msg := fmt.Sprintf("%d of %d entries ready", ready, len(desired))
+	if ready == len(desired) {
+		state = api.STATE_READY
+	}
+	if len(skipped) > 0 {
+		msg += fmt.Sprintf(", skipped unsupported zone file records of type %s", strings.Join(skipped, ", "))
+	}
+	return this.updateStatus(logger, obj, state, msg, len(desired), ready)
+}
+
+func (this *reconciler) updateStatus(logger logger.LogContext, obj resources.Object, state, msg string, entries, ready int) error {
+	_, err := obj.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSEntrySet).Status
+		mod := status.State != state || status.Message == nil || *status.Message != msg ||
+			status.Entries != entries || status.Ready != ready || status.ObservedGeneration != data.GetGeneration()
+		if mod {
+			logger.Infof("update state to %s: %s", state, msg)
+			status.State = state
+			status.Message = &msg
+			status.Entries = entries
+			status.Ready = ready
+			status.ObservedGeneration = data.GetGeneration()
+		}
+		mod = dnsutils.SetStateConditions(&status.Conditions, status.ObservedGeneration, state, &msg) || mod
+		return mod, nil
+	})
+	return err
+}
+
+// readZoneFile reads and parses the zone file referenced by the entry set.
+func (this *reconciler) readZoneFile(set *api.DNSEntrySet) ([]dns.ZoneFileRecord, error) {
+	src := set.Spec.ZoneFile
+	key := src.Key
+	if key == "" {
+		key = DEFAULT_ZONEFILE_KEY
+	}
+	cm := &corev1.ConfigMap{}
+	if _, err := this.configmaps.GetInto(resources.NewObjectName(set.Namespace, src.ConfigMap), cm); err != nil {
+		return nil, err
+	}
+	data, ok := cm.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in config map %s", key, src.ConfigMap)
+	}
+	records, err := dns.ParseZoneFile(strings.NewReader(data), zoneFileOrigin(set))
+	if err != nil {
+		return nil, fmt.Errorf("invalid zone file in config map %s: %w", src.ConfigMap, err)
+	}
+	return records, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func zoneFileOrigin(set *api.DNSEntrySet) string {
+	origin := set.Spec.ZoneFile.Origin
+	if origin == "" {
+		origin = set.Spec.Template.Domain
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(origin, "."), ".")
+}
+
+// ZoneFileEntries converts the records of a zone file into entry set items.
+// SOA and NS records of the zone apex are maintained by the DNS provider and
+// ignored. Records not expressible by DNS entries are skipped, the types of
+// skipped records are returned.
+func ZoneFileEntries(origin string, records []dns.ZoneFileRecord) ([]api.DNSEntrySetItem, []string) {
+	var items []*api.DNSEntrySetItem
+	byName := map[string]*api.DNSEntrySetItem{}
+	skipped := utils.StringSet{}
+	for _, r := range records {
+		switch r.Type {
+		case dns.RS_A, dns.RS_AAAA, dns.RS_CNAME, dns.RS_TXT:
+		case "SOA", dns.RS_NS:
+			if r.Name == origin {
+				continue
+			}
+			skipped.Add(r.Type)
+			continue
+		default:
+			skipped.Add(r.Type)
+			continue
+		}
+		item := byName[r.Name]
+		if item == nil {
+			item = &api.DNSEntrySetItem{Name: r.Name}
+			byName[r.Name] = item
+			items = append(items, item)
+		}
+		if r.TTL > 0 && (item.TTL == nil || r.TTL < *item.TTL) {
+			ttl := r.TTL
+			item.TTL = &ttl
+		}
+		if r.Type == dns.RS_TXT {
+			item.Text = append(item.Text, strings.Join(r.Data, ""))
+		} else {
+			item.Targets = append(item.Targets, r.Data[0])
+		}
+	}
+
+	result := make([]api.DNSEntrySetItem, 0, len(items))
+	for _, item := range items {
+		if len(item.Targets) > 0 && len(item.Text) > 0 {
+			// a DNS entry contains either targets or text records
+			item.Text = nil
+			skipped.Add(dns.RS_TXT)
+		}
+		result = append(result, *item)
+	}
+	types := skipped.AsArray()
+	sort.Strings(types)
+	return result, types
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// DesiredEntries expands the template of a DNS entry set into DNS entries
+// with names derived from the DNS names.
+func DesiredEntries(set *api.DNSEntrySet) (map[string]*api.DNSEntry, error) {
+	tmpl := &set.Spec.Template
+	domain := strings.TrimSuffix(strings.TrimPrefix(tmpl.Domain, "."), ".")
+
+	entries := map[string]*api.DNSEntry{}
+	dnsNames := map[string]int{}
+	for i, item := range set.Spec.Entries {
+		dnsName := strings.TrimSuffix(item.Name, ".")
+		if dnsName == "" {
+			return nil, fmt.Errorf("name missing for entry %d", i)
+		}
+		if domain != "" && dnsName != domain && !strings.HasSuffix(dnsName, "."+domain) {
+			dnsName = dnsName + "." + domain
+		}
+		if j, ok := dnsNames[dnsName]; ok {
+			return nil, fmt.Errorf("entries %d and %d have the same DNS name %s", j, i, dnsName)
+		}
+		dnsNames[dnsName] = i
+		if len(item.Targets) == 0 && len(item.Text) == 0 {
+			return nil, fmt.Errorf("targets or text missing for %s", dnsName)
+		}
+
+		name := EntryName(set.Name, dnsName)
+		if other := entries[name]; other != nil {
+			return nil, fmt.Errorf("DNS names %s and %s result in the same entry name %s, please rename one of them", other.Spec.DNSName, dnsName, name)
+		}
+		entry := &api.DNSEntry{}
+		entry.Name = name
+		entry.Namespace = set.Namespace
+		entry.Labels = map[string]string{}
+		for k, v := range tmpl.Labels {
+			entry.Labels[k] = v
+		}
+		entry.Labels[LABEL_ENTRYSET] = set.Name
+		if len(tmpl.Annotations) > 0 || len(tmpl.Labels) > 0 {
+			entry.Annotations = map[string]string{}
+			for k, v := range tmpl.Annotations {
+				entry.Annotations[k] = v
+			}
+			setAppliedKeys(entry.Annotations, ANNOTATION_APPLIED_LABELS, tmpl.Labels)
+			setAppliedKeys(entry.Annotations, ANNOTATION_APPLIED_ANNOTATIONS, tmpl.Annotations)
+		}
+		entry.Spec = api.DNSEntrySpec{
+			DNSName:             dnsName,
+			OwnerId:             tmpl.OwnerId,
+			TTL:                 tmpl.TTL,
+			CNameLookupInterval: tmpl.CNameLookupInterval,
+			Provider:            tmpl.Provider,
+			Zone:                tmpl.Zone,
+			Targets:             item.Targets,
+			Text:                item.Text,
+		}
+		if item.TTL != nil {
+			entry.Spec.TTL = item.TTL
+		}
+		entries[entry.Name] = entry
+	}
+	return entries, nil
+}
+
+// EntryName returns the name of the generated DNS entry for a DNS name.
+// It is stable against reordering the entries of the set.
+func EntryName(setName, dnsName string) string {
+	h := fnv.New32a()
+	h.Write([]byte(dnsName))
+	return fmt.Sprintf("%s-%08x", setName, h.Sum32())
+}
+
+// IsEntryNameOf checks whether an entry name has the form of the names generated
+// for the given entry set.
+func IsEntryNameOf(setName, name string) bool {
+	if len(name) != len(setName)+9 || !strings.HasPrefix(name, setName+"-") {
+		return false
+	}
+	for _, c := range name[len(setName)+1:] {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// EntryChanges contains the names of the entries to create, update or delete to
+// reach the desired entries of an entry set.
+type EntryChanges struct {
+	Create []string
+	Update []string
+	Delete []string
+}
+
+// DiffEntries compares the desired entries of an entry set with the current ones.
+func DiffEntries(desired, current map[string]*api.DNSEntry) *EntryChanges {
+	changes := &EntryChanges{}
+	for name, entry := range desired {
+		cur := current[name]
+		if cur == nil {
+			changes.Create = append(changes.Create, name)
+		} else if updateEntry(cur.DeepCopy(), entry) {
+			changes.Update = append(changes.Update, name)
+		}
+	}
+	for name := range current {
+		if _, ok := desired[name]; !ok {
+			changes.Delete = append(changes.Delete, name)
+		}
+	}
+	sort.Strings(changes.Create)
+	sort.Strings(changes.Update)
+	sort.Strings(changes.Delete)
+	return changes
+}
+
+// setAppliedKeys records the keys of the applied template labels or annotations in the given annotation.
+func setAppliedKeys(annos map[string]string, annotation string, applied map[string]string) {
+	if len(applied) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(applied))
+	for k := range applied {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	annos[annotation] = strings.Join(keys, ",")
+}
+
+// appliedKeys returns the keys of the template labels or annotations applied to an entry before.
+func appliedKeys(entry *api.DNSEntry, annotation string) []string {
+	value := entry.Annotations[annotation]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// updateEntry adjusts the labels, annotations and spec fields of a generated entry owned by the entry set.
+// Labels and annotations applied from an older version of the template are removed, foreign ones are kept.
+// Spec fields of the template unset in the entry set are reset (defaults are applied by the defaulting webhook).
+func updateEntry(entry *api.DNSEntry, desired *api.DNSEntry) bool {
+	mod := &utils.ModificationState{}
+	for _, k := range appliedKeys(entry, ANNOTATION_APPLIED_LABELS) {
+		if _, ok := desired.Labels[k]; !ok && k != LABEL_ENTRYSET {
+			if _, ok := entry.Labels[k]; ok {
+				delete(entry.Labels, k)
+				mod.Modify(true)
+			}
+		}
+	}
+	for _, k := range append(appliedKeys(entry, ANNOTATION_APPLIED_ANNOTATIONS), ANNOTATION_APPLIED_LABELS, ANNOTATION_APPLIED_ANNOTATIONS) {
+		if _, ok := desired.Annotations[k]; !ok {
+			if _, ok := entry.Annotations[k]; ok {
+				delete(entry.Annotations, k)
+				mod.Modify(true)
+			}
+		}
+	}
+	for k, v := range desired.Labels {
+		if entry.Labels[k] != v {
+			resources.SetLabel(entry, k, v)
+			mod.Modify(true)
+		}
+	}
+	for k, v := range desired.Annotations {
+		if entry.Annotations[k] != v {
+			resources.SetAnnotation(entry, k, v)
+			mod.Modify(true)
+		}
+	}
+	spec := &entry.Spec
+	mod.AssureStringValue(&spec.DNSName, desired.Spec.DNSName)
+	if !reflect.DeepEqual(spec.Targets, desired.Spec.Targets) {
+		spec.Targets = desired.Spec.Targets
+		mod.Modify(true)
+	}
+	if !reflect.DeepEqual(spec.Text, desired.Spec.Text) {
+		spec.Text = desired.Spec.Text
+		mod.Modify(true)
+	}
+	mod.AssureStringPtrPtr(&spec.OwnerId, desired.Spec.OwnerId)
+	mod.AssureInt64PtrPtr(&spec.TTL, desired.Spec.TTL)
+	mod.AssureInt64PtrPtr(&spec.CNameLookupInterval, desired.Spec.CNameLookupInterval)
+	mod.AssureStringPtrPtr(&spec.Provider, desired.Spec.Provider)
+	mod.AssureStringPtrPtr(&spec.Zone, desired.Spec.Zone)
+	return mod.IsModified()
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package entryset
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+func testEntrySet(items ...api.DNSEntrySetItem) *api.DNSEntrySet {
+	ttl := int64(300)
+	set := &api.DNSEntrySet{}
+	set.Name = "myset"
+	set.Namespace = "default"
+	set.Spec.Template.Domain = "example.com"
+	set.Spec.Template.TTL = &ttl
+	set.Spec.Template.Labels = map[string]string{"app": "test"}
+	set.Spec.Entries = items
+	return set
+}
+
+func TestEntryName(t *testing.T) {
+	RegisterTestingT(t)
+
+	name := EntryName("myset", "www.example.com")
+	Ω(name).Should(MatchRegexp("^myset-[0-9a-f]{8}$"))
+	Ω(EntryName("myset", "www.example.com")).Should(Equal(name))
+	Ω(EntryName("myset", "api.example.com")).ShouldNot(Equal(name))
+
+	Ω(IsEntryNameOf("myset", name)).Should(BeTrue())
+	Ω(IsEntryNameOf("my", name)).Should(BeFalse())
+	Ω(IsEntryNameOf("myset", "myset-other")).Should(BeFalse())
+	Ω(IsEntryNameOf("myset", "myset-0123456g")).Should(BeFalse())
+	Ω(IsEntryNameOf("myset", EntryName("myset-a", "www.example.com"))).Should(BeFalse())
+}
+
+func TestDesiredEntries(t *testing.T) {
+	RegisterTestingT(t)
+
+	itemTTL := int64(60)
+	set := testEntrySet(
+		api.DNSEntrySetItem{Name: "www", Targets: []string{"1.2.3.4"}},
+		api.DNSEntrySetItem{Name: "txt.example.com.", Text: []string{"hello"}, TTL: &itemTTL},
+	)
+	entries, err := DesiredEntries(set)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(entries).Should(HaveLen(2))
+
+	www := entries[EntryName("myset", "www.example.com")]
+	Ω(www).ShouldNot(BeNil())
+	Ω(www.Namespace).Should(Equal("default"))
+	Ω(www.Labels).Should(Equal(map[string]string{"app": "test", LABEL_ENTRYSET: "myset"}))
+	Ω(www.Annotations).Should(Equal(map[string]string{ANNOTATION_APPLIED_LABELS: "app"}))
+	Ω(www.Spec.DNSName).Should(Equal("www.example.com"))
+	Ω(www.Spec.Targets).Should(Equal([]string{"1.2.3.4"}))
+	Ω(*www.Spec.TTL).Should(Equal(int64(300)))
+
+	txt := entries[EntryName("myset", "txt.example.com")]
+	Ω(txt).ShouldNot(BeNil())
+	Ω(txt.Spec.Text).Should(Equal([]string{"hello"}))
+	Ω(*txt.Spec.TTL).Should(Equal(int64(60)))
+
+	_, err = DesiredEntries(testEntrySet(
+		api.DNSEntrySetItem{Name: "www", Targets: []string{"1.2.3.4"}},
+		api.DNSEntrySetItem{Name: "www.example.com", Targets: []string{"1.2.3.5"}},
+	))
+	Ω(err).Should(MatchError("entries 0 and 1 have the same DNS name www.example.com"))
+
+	_, err = DesiredEntries(testEntrySet(api.DNSEntrySetItem{Name: "www"}))
+	Ω(err).Should(MatchError("targets or text missing for www.example.com"))
+}
+
+func TestDesiredEntriesNameCollision(t *testing.T) {
+	RegisterTestingT(t)
+
+	// both DNS names have the FNV-1a hash 18f0cbb2
+	set := testEntrySet(
+		api.DNSEntrySetItem{Name: "host53866", Targets: []string{"1.2.3.4"}},
+		api.DNSEntrySetItem{Name: "host1018390", Targets: []string{"1.2.3.5"}},
+	)
+	_, err := DesiredEntries(set)
+	Ω(err).Should(MatchError("DNS names host53866.example.com and host1018390.example.com result in the same entry name myset-18f0cbb2, please rename one of them"))
+}
+
+func TestDiffEntries(t *testing.T) {
+	RegisterTestingT(t)
+
+	desired, err := DesiredEntries(testEntrySet(
+		api.DNSEntrySetItem{Name: "a", Targets: []string{"1.1.1.1"}},
+		api.DNSEntrySetItem{Name: "b", Targets: []string{"2.2.2.2"}},
+		api.DNSEntrySetItem{Name: "c", Targets: []string{"3.3.3.3"}},
+	))
+	Ω(err).ShouldNot(HaveOccurred())
+	nameA := EntryName("myset", "a.example.com")
+	nameB := EntryName("myset", "b.example.com")
+	nameC := EntryName("myset", "c.example.com")
+	obsolete := EntryName("myset", "d.example.com")
+
+	changes := DiffEntries(desired, nil)
+	Ω(changes.Create).Should(ConsistOf(nameA, nameB, nameC))
+	Ω(changes.Update).Should(BeEmpty())
+	Ω(changes.Delete).Should(BeEmpty())
+
+	current := map[string]*api.DNSEntry{
+		nameA:    desired[nameA].DeepCopy(),
+		nameB:    desired[nameB].DeepCopy(),
+		obsolete: {},
+	}
+	current[nameB].Spec.Targets = []string{"9.9.9.9"}
+	changes = DiffEntries(desired, current)
+	Ω(changes.Create).Should(Equal([]string{nameC}))
+	Ω(changes.Update).Should(Equal([]string{nameB}))
+	Ω(changes.Delete).Should(Equal([]string{obsolete}))
+
+	// all entries are obsolete for an empty set
+	changes = DiffEntries(map[string]*api.DNSEntry{}, current)
+	Ω(changes.Delete).Should(ConsistOf(nameA, nameB, obsolete))
+}
+
+func TestUpdateEntry(t *testing.T) {
+	RegisterTestingT(t)
+
+	set := testEntrySet(api.DNSEntrySetItem{Name: "www", Targets: []string{"1.2.3.4"}})
+	set.Spec.Template.Annotations = map[string]string{"note": "test"}
+	desired, err := DesiredEntries(set)
+	Ω(err).ShouldNot(HaveOccurred())
+	name := EntryName("myset", "www.example.com")
+	Ω(desired[name].Annotations).Should(Equal(map[string]string{
+		"note":                         "test",
+		ANNOTATION_APPLIED_LABELS:      "app",
+		ANNOTATION_APPLIED_ANNOTATIONS: "note",
+	}))
+
+	// fields not part of the template and foreign labels and annotations are kept
+	entry := desired[name].DeepCopy()
+	entry.Spec.RoutingPolicy = &api.RoutingPolicy{Type: "weighted", SetIdentifier: "a"}
+	entry.Labels["other"] = "value"
+	entry.Annotations["other"] = "value"
+	Ω(updateEntry(entry, desired[name])).Should(BeFalse())
+	Ω(DiffEntries(desired, map[string]*api.DNSEntry{name: entry}).Update).Should(BeEmpty())
+
+	// owned fields are reconciled
+	entry.Spec.Targets = []string{"5.6.7.8"}
+	entry.Labels["app"] = "changed"
+	Ω(updateEntry(entry, desired[name])).Should(BeTrue())
+	Ω(entry.Spec.Targets).Should(Equal([]string{"1.2.3.4"}))
+	Ω(entry.Labels).Should(Equal(map[string]string{"app": "test", LABEL_ENTRYSET: "myset", "other": "value"}))
+	Ω(entry.Spec.RoutingPolicy).ShouldNot(BeNil())
+
+	// fields unset in the template are reset
+	ttl := int64(120)
+	owner := "owner"
+	entry.Spec.TTL = &ttl
+	entry.Spec.OwnerId = &owner
+	set.Spec.Template.TTL = nil
+	desired, err = DesiredEntries(set)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(updateEntry(entry, desired[name])).Should(BeTrue())
+	Ω(entry.Spec.TTL).Should(BeNil())
+	Ω(entry.Spec.OwnerId).Should(BeNil())
+
+	// labels and annotations removed from the template are removed
+	set.Spec.Template.Labels = map[string]string{"tier": "web"}
+	set.Spec.Template.Annotations = nil
+	desired, err = DesiredEntries(set)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(updateEntry(entry, desired[name])).Should(BeTrue())
+	Ω(entry.Labels).Should(Equal(map[string]string{"tier": "web", LABEL_ENTRYSET: "myset", "other": "value"}))
+	Ω(entry.Annotations).Should(Equal(map[string]string{"other": "value", ANNOTATION_APPLIED_LABELS: "tier"}))
+	Ω(updateEntry(entry, desired[name])).Should(BeFalse())
+
+	set.Spec.Template.Labels = nil
+	desired, err = DesiredEntries(set)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(updateEntry(entry, desired[name])).Should(BeTrue())
+	Ω(entry.Labels).Should(Equal(map[string]string{LABEL_ENTRYSET: "myset", "other": "value"}))
+	Ω(entry.Annotations).Should(Equal(map[string]string{"other": "value"}))
+}
+
+func TestZoneFileEntries(t *testing.T) {
+	RegisterTestingT(t)
+
+	records := []dns.ZoneFileRecord{
+		{Name: "example.com", Type: "SOA", TTL: 3600, Data: []string{"ns1.example.com."}},
+		{Name: "www.example.com", Type: dns.RS_A, TTL: 300, Data: []string{"1.2.3.4"}},
+		{Name: "www.example.com", Type: dns.RS_A, TTL: 60, Data: []string{"1.2.3.5"}},
+		{Name: "mail.example.com", Type: "MX", TTL: 300, Data: []string{"10", "mx.example.com."}},
+	}
+	items, skipped := ZoneFileEntries("example.com", records)
+	Ω(items).Should(HaveLen(1))
+	Ω(items[0].Name).Should(Equal("www.example.com"))
+	Ω(items[0].Targets).Should(Equal([]string{"1.2.3.4", "1.2.3.5"}))
+	Ω(*items[0].TTL).Should(Equal(int64(60)))
+	Ω(skipped).Should(Equal([]string{"MX"}))
+}
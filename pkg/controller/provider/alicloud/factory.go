@@ -24,13 +24,15 @@ import (
 const TYPE_CODE = "alicloud-dns"
 
 var rateLimiterDefaults = provider.RateLimiterOptions{
-	Enabled: true,
-	QPS:     25,
-	Burst:   1,
+	Enabled:  true,
+	QPS:      25,
+	Burst:    1,
+	Adaptive: true,
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler, true).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetSecretValidator(provider.RequiredSecretKeys([]string{"ACCESS_KEY_ID", "accessKeyID"}, []string{"ACCESS_KEY_SECRET", "accessKeySecret"}))
 
 func init() {
 	compound.MustRegister(Factory)
@@ -17,6 +17,11 @@
 package aws
 
 import (
+	"fmt"
+	"strconv"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
 	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 )
@@ -24,9 +29,10 @@ import (
 const TYPE_CODE = "aws-route53"
 
 var rateLimiterDefaults = provider.RateLimiterOptions{
-	Enabled: true,
-	QPS:     9,
-	Burst:   10,
+	Enabled:  true,
+	QPS:      9,
+	Burst:    10,
+	Adaptive: true,
 }
 
 var advancedDefaults = provider.AdvancedOptions{
@@ -36,7 +42,25 @@ var advancedDefaults = provider.AdvancedOptions{
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
 	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.
-		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults))
+		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults)).
+	SetSecretValidator(validateSecret)
+
+// validateSecret requires static credentials unless the chain of credential providers is used.
+func validateSecret(props utils.Properties) error {
+	if props["AWS_USE_CREDENTIALS_CHAIN"] != "" {
+		useChain, err := strconv.ParseBool(props["AWS_USE_CREDENTIALS_CHAIN"])
+		if err != nil {
+			return fmt.Errorf("invalid value for AWS_USE_CREDENTIALS_CHAIN: %s", err)
+		}
+		if useChain {
+			if props["AWS_ACCESS_KEY_ID"] != "" || props["accessKeyID"] != "" {
+				return fmt.Errorf("explicit credentials (AWS_ACCESS_KEY_ID or accessKeyID) cannot be used together with AWS_USE_CREDENTIALS_CHAIN=true")
+			}
+			return nil
+		}
+	}
+	return provider.RequiredSecretKeys([]string{"AWS_ACCESS_KEY_ID", "accessKeyID"}, []string{"AWS_SECRET_ACCESS_KEY", "secretAccessKey"})(props)
+}
 
 func init() {
 	compound.MustRegister(Factory)
@@ -108,6 +108,7 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 		Credentials: creds,
 		Endpoint:    endpoint, // temporary workaround for AWS problem
 		MaxRetries:  &maxRetries,
+		HTTPClient:  c.Transport.HTTPClient(),
 	})
 	if err != nil {
 		return nil, err
@@ -262,6 +263,41 @@ func (h *Handler) handleRecordSets(zone provider.DNSHostedZone, f func(rs *route
 	return forwarded, err
 }
 
+var _ provider.RoutingPolicyChecker = &Handler{}
+
+// CheckRoutingPolicy checks whether the routing policy of the record set can be mapped to a Route53 resource record set.
+func (h *Handler) CheckRoutingPolicy(set *dns.DNSSet) error {
+	return addRoutingPolicy(&route53.ResourceRecordSet{}, set.Name, set.RoutingPolicy)
+}
+
+var _ provider.PermissionChecker = &Handler{}
+
+// CheckChangePermission probes the permission for route53:ChangeResourceRecordSets by deleting a non-existing
+// record set. If permitted, the request is rejected as invalid change batch without modifying the zone.
+func (h *Handler) CheckChangePermission(zone provider.DNSHostedZone) error {
+	params := &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zone.Id().ID),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{{
+				Action: aws.String(route53.ChangeActionDelete),
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					Name:            aws.String(provider.PermissionCheckName(zone)),
+					Type:            aws.String(route53.RRTypeTxt),
+					TTL:             aws.Int64(60),
+					ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("\"permission-check\"")}},
+				},
+			}},
+		},
+	}
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_UPDATERECORDS, 1)
+	h.config.RateLimiter.Accept()
+	_, err := h.r53.ChangeResourceRecordSets(params)
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "AccessDenied" {
+		return fmt.Errorf("route53:ChangeResourceRecordSets denied")
+	}
+	return nil
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
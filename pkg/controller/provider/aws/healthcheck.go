@@ -0,0 +1,157 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+var _ provider.DNSHealthCheckManagement = &Handler{}
+
+func healthCheckType(spec *provider.HealthCheckSpec) string {
+	if spec.SearchString != "" && spec.Type != route53.HealthCheckTypeTcp {
+		return spec.Type + "_STR_MATCH"
+	}
+	return spec.Type
+}
+
+func (h *Handler) CreateHealthCheck(logger logger.LogContext, spec *provider.HealthCheckSpec) (string, error) {
+	config := &route53.HealthCheckConfig{
+		Type:             aws.String(healthCheckType(spec)),
+		Port:             aws.Int64(int64(spec.Port)),
+		RequestInterval:  aws.Int64(int64(spec.RequestInterval)),
+		FailureThreshold: aws.Int64(int64(spec.FailureThreshold)),
+	}
+	if spec.FQDN != "" {
+		config.FullyQualifiedDomainName = aws.String(spec.FQDN)
+	}
+	if spec.IPAddress != "" {
+		config.IPAddress = aws.String(spec.IPAddress)
+	}
+	if spec.ResourcePath != "" {
+		config.ResourcePath = aws.String(spec.ResourcePath)
+	}
+	if spec.SearchString != "" {
+		config.SearchString = aws.String(spec.SearchString)
+	}
+	if spec.Type == route53.HealthCheckTypeHttps {
+		config.EnableSNI = aws.Bool(spec.FQDN != "")
+	}
+
+	h.config.RateLimiter.Accept()
+	out, err := h.r53.CreateHealthCheck(&route53.CreateHealthCheckInput{
+		CallerReference:   aws.String(spec.Reference),
+		HealthCheckConfig: config,
+	})
+	if err != nil {
+		if a, ok := err.(awserr.Error); !ok || a.Code() != route53.ErrCodeHealthCheckAlreadyExists {
+			return "", err
+		}
+		// health check has already been created for this caller reference
+		id, err := h.findHealthCheckByCallerReference(spec.Reference)
+		if err != nil {
+			return "", err
+		}
+		return id, h.UpdateHealthCheck(logger, id, spec)
+	}
+	return aws.StringValue(out.HealthCheck.Id), nil
+}
+
+func (h *Handler) UpdateHealthCheck(logger logger.LogContext, id string, spec *provider.HealthCheckSpec) error {
+	h.config.RateLimiter.Accept()
+	out, err := h.r53.GetHealthCheck(&route53.GetHealthCheckInput{HealthCheckId: aws.String(id)})
+	if err != nil {
+		return err
+	}
+	current := out.HealthCheck.HealthCheckConfig
+	if aws.StringValue(current.Type) != healthCheckType(spec) {
+		return fmt.Errorf("type of health check %s cannot be changed from %s to %s", id, aws.StringValue(current.Type), healthCheckType(spec))
+	}
+	if aws.Int64Value(current.RequestInterval) != int64(spec.RequestInterval) {
+		return fmt.Errorf("request interval of health check %s cannot be changed", id)
+	}
+
+	input := &route53.UpdateHealthCheckInput{
+		HealthCheckId:      aws.String(id),
+		HealthCheckVersion: out.HealthCheck.HealthCheckVersion,
+		Port:               aws.Int64(int64(spec.Port)),
+		FailureThreshold:   aws.Int64(int64(spec.FailureThreshold)),
+	}
+	var reset []*string
+	setOrReset := func(value string, target **string, element string) {
+		if value != "" {
+			*target = aws.String(value)
+		} else {
+			reset = append(reset, aws.String(element))
+		}
+	}
+	setOrReset(spec.FQDN, &input.FullyQualifiedDomainName, route53.ResettableElementNameFullyQualifiedDomainName)
+	setOrReset(spec.ResourcePath, &input.ResourcePath, route53.ResettableElementNameResourcePath)
+	if spec.IPAddress != "" {
+		input.IPAddress = aws.String(spec.IPAddress)
+	}
+	if spec.SearchString != "" {
+		input.SearchString = aws.String(spec.SearchString)
+	}
+	if spec.Type == route53.HealthCheckTypeHttps {
+		input.EnableSNI = aws.Bool(spec.FQDN != "")
+	}
+	input.ResetElements = reset
+
+	h.config.RateLimiter.Accept()
+	_, err = h.r53.UpdateHealthCheck(input)
+	return err
+}
+
+func (h *Handler) DeleteHealthCheck(logger logger.LogContext, id string) error {
+	h.config.RateLimiter.Accept()
+	_, err := h.r53.DeleteHealthCheck(&route53.DeleteHealthCheckInput{HealthCheckId: aws.String(id)})
+	if err != nil {
+		if a, ok := err.(awserr.Error); ok && a.Code() == route53.ErrCodeNoSuchHealthCheck {
+			logger.Infof("health check %s already deleted", id)
+			return nil
+		}
+	}
+	return err
+}
+
+func (h *Handler) findHealthCheckByCallerReference(reference string) (string, error) {
+	input := &route53.ListHealthChecksInput{}
+	for {
+		h.config.RateLimiter.Accept()
+		out, err := h.r53.ListHealthChecks(input)
+		if err != nil {
+			return "", err
+		}
+		for _, hc := range out.HealthChecks {
+			if aws.StringValue(hc.CallerReference) == reference {
+				return aws.StringValue(hc.Id), nil
+			}
+		}
+		if !aws.BoolValue(out.IsTruncated) {
+			return "", fmt.Errorf("health check with caller reference %s not found", reference)
+		}
+		input.Marker = out.NextMarker
+	}
+}
@@ -36,15 +36,16 @@ func addRoutingPolicy(rrset *route53.ResourceRecordSet, name dns.DNSSetName, rou
 		return fmt.Errorf("set identifier set, but routing policy missing")
 	}
 
-	var keys []string
+	var keys, optional []string
 	switch routingPolicy.Type {
 	case dns.RoutingPolicyWeighted:
 		keys = []string{"weight"}
+		optional = []string{dns.RoutingPolicyParamHealthCheckID}
 	default:
 		return fmt.Errorf("unsupported routing policy type %s", routingPolicy.Type)
 	}
 
-	if err := routingPolicy.CheckParameterKeys(keys); err != nil {
+	if err := routingPolicy.CheckParameterKeys(keys, optional...); err != nil {
 		return err
 	}
 
@@ -57,6 +58,11 @@ func addRoutingPolicy(rrset *route53.ResourceRecordSet, name dns.DNSSetName, rou
 				return fmt.Errorf("invalid value for spec.routingPolicy.parameters.weight: %s", value)
 			}
 			rrset.Weight = aws.Int64(v)
+		case dns.RoutingPolicyParamHealthCheckID:
+			if value == "" {
+				return fmt.Errorf("invalid value for spec.routingPolicy.parameters.%s: must not be empty", key)
+			}
+			rrset.HealthCheckId = aws.String(value)
 		}
 	}
 
@@ -69,7 +75,11 @@ func extractRoutingPolicy(rrset *route53.ResourceRecordSet) *dns.RoutingPolicy {
 	}
 
 	if rrset.Weight != nil {
-		return dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, "weight", strconv.FormatInt(*rrset.Weight, 10))
+		policy := dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, "weight", strconv.FormatInt(*rrset.Weight, 10))
+		if rrset.HealthCheckId != nil {
+			policy.Parameters[dns.RoutingPolicyParamHealthCheckID] = *rrset.HealthCheckId
+		}
+		return policy
 	}
 	// ignore unsupported routing policy
 	return nil
@@ -0,0 +1,198 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+var _ provider.DNSZoneManagement = &Handler{}
+
+type vpcKey struct {
+	region string
+	id     string
+}
+
+func (k vpcKey) String() string {
+	return k.region + "/" + k.id
+}
+
+func parseVPCs(networks []string) ([]vpcKey, error) {
+	var vpcs []vpcKey
+	for _, n := range networks {
+		parts := strings.Split(n, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid private network %q (expected <region>/<vpc-id>)", n)
+		}
+		vpcs = append(vpcs, vpcKey{region: parts[0], id: parts[1]})
+	}
+	return vpcs, nil
+}
+
+func validateHostedZoneSpec(spec *provider.HostedZoneSpec) ([]vpcKey, error) {
+	if spec.DNSSEC {
+		return nil, fmt.Errorf("DNSSEC signing is not supported for %s hosted zones", TYPE_CODE)
+	}
+	vpcs, err := parseVPCs(spec.PrivateNetworks)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Private && len(vpcs) == 0 {
+		return nil, fmt.Errorf("private hosted zone requires at least one private network")
+	}
+	if !spec.Private && len(vpcs) > 0 {
+		return nil, fmt.Errorf("private networks are only supported for private hosted zones")
+	}
+	return vpcs, nil
+}
+
+func (h *Handler) CreateHostedZone(logger logger.LogContext, spec *provider.HostedZoneSpec) (*provider.HostedZoneInfo, error) {
+	vpcs, err := validateHostedZoneSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	input := &route53.CreateHostedZoneInput{
+		Name:            aws.String(spec.Domain),
+		CallerReference: aws.String(spec.Reference),
+		HostedZoneConfig: &route53.HostedZoneConfig{
+			Comment:     aws.String("managed by dns controller"),
+			PrivateZone: aws.Bool(spec.Private),
+		},
+	}
+	if len(vpcs) > 0 {
+		input.VPC = &route53.VPC{VPCId: aws.String(vpcs[0].id), VPCRegion: aws.String(vpcs[0].region)}
+	}
+
+	var zoneid string
+	h.config.RateLimiter.Accept()
+	out, err := h.r53.CreateHostedZone(input)
+	if err != nil {
+		if a, ok := err.(awserr.Error); !ok || a.Code() != route53.ErrCodeHostedZoneAlreadyExists {
+			return nil, err
+		}
+		// zone has already been created for this caller reference
+		zoneid, err = h.findHostedZoneByCallerReference(spec.Domain, spec.Reference)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		zoneid = lastPathComponent(aws.StringValue(out.HostedZone.Id))
+	}
+	return h.UpdateHostedZone(logger, zoneid, spec)
+}
+
+func (h *Handler) UpdateHostedZone(logger logger.LogContext, zoneid string, spec *provider.HostedZoneSpec) (*provider.HostedZoneInfo, error) {
+	vpcs, err := validateHostedZoneSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	out, err := h.GetZoneByName(zoneid)
+	if err != nil {
+		return nil, err
+	}
+	if err := provider.CheckHostedZoneDomain(zoneid, aws.StringValue(out.HostedZone.Name), spec); err != nil {
+		return nil, err
+	}
+	private := out.HostedZone.Config != nil && aws.BoolValue(out.HostedZone.Config.PrivateZone)
+	if private != spec.Private {
+		return nil, fmt.Errorf("hosted zone %s cannot be changed between private and public", zoneid)
+	}
+
+	if private {
+		current := map[vpcKey]bool{}
+		for _, v := range out.VPCs {
+			current[vpcKey{region: aws.StringValue(v.VPCRegion), id: aws.StringValue(v.VPCId)}] = true
+		}
+		desired := map[vpcKey]bool{}
+		for _, v := range vpcs {
+			desired[v] = true
+			if !current[v] {
+				logger.Infof("associating VPC %s with hosted zone %s", v, zoneid)
+				if _, err := h.AssociateVPCWithHostedZone(v.id, v.region, zoneid); err != nil {
+					return nil, err
+				}
+			}
+		}
+		for v := range current {
+			if !desired[v] {
+				logger.Infof("disassociating VPC %s from hosted zone %s", v, zoneid)
+				if _, err := h.DisassociateVPCFromHostedZone(v.id, v.region, zoneid); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	info := &provider.HostedZoneInfo{ZoneID: zoneid}
+	if out.DelegationSet != nil {
+		for _, ns := range out.DelegationSet.NameServers {
+			info.NameServers = append(info.NameServers, aws.StringValue(ns))
+		}
+	}
+	return info, nil
+}
+
+func (h *Handler) DeleteHostedZone(logger logger.LogContext, zoneid string) error {
+	h.config.RateLimiter.Accept()
+	_, err := h.r53.DeleteHostedZone(&route53.DeleteHostedZoneInput{Id: aws.String(zoneid)})
+	if err != nil {
+		if a, ok := err.(awserr.Error); ok && a.Code() == route53.ErrCodeNoSuchHostedZone {
+			logger.Infof("hosted zone %s already deleted", zoneid)
+			return nil
+		}
+	}
+	return err
+}
+
+func (h *Handler) findHostedZoneByCallerReference(domain, reference string) (string, error) {
+	name := dns.AlignHostname(domain)
+	input := &route53.ListHostedZonesByNameInput{DNSName: aws.String(name)}
+	for {
+		h.config.RateLimiter.Accept()
+		out, err := h.r53.ListHostedZonesByName(input)
+		if err != nil {
+			return "", err
+		}
+		for _, z := range out.HostedZones {
+			if aws.StringValue(z.Name) != name {
+				return "", fmt.Errorf("hosted zone for %s with caller reference %s not found", domain, reference)
+			}
+			if aws.StringValue(z.CallerReference) == reference {
+				return lastPathComponent(aws.StringValue(z.Id)), nil
+			}
+		}
+		if !aws.BoolValue(out.IsTruncated) {
+			return "", fmt.Errorf("hosted zone for %s with caller reference %s not found", domain, reference)
+		}
+		input.DNSName = out.NextDNSName
+		input.HostedZoneId = out.NextHostedZoneId
+	}
+}
+
+func lastPathComponent(id string) string {
+	comp := strings.Split(id, "/")
+	return comp[len(comp)-1]
+}
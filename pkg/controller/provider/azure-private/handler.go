@@ -18,6 +18,7 @@ package azureprivate
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -34,10 +35,30 @@ import (
 type Handler struct {
 	provider.DefaultDNSHandler
 	config        provider.DNSHandlerConfig
+	azConfig      AzurePrivateConfig
 	cache         provider.ZoneCache
 	ctx           context.Context
 	zonesClient   *azure.PrivateZonesClient
 	recordsClient *azure.RecordSetsClient
+	linksClient   *azure.VirtualNetworkLinksClient
+}
+
+// AzurePrivateConfig is the provider specific configuration (`spec.providerConfig`) of an azure-private-dns provider.
+type AzurePrivateConfig struct {
+	// ResourceGroup is the resource group used for private DNS zones created for DNSZone objects
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+	// VirtualNetworkLinks are maintained for the private DNS zones of the provider
+	VirtualNetworkLinks []VirtualNetworkLinkConfig `json:"virtualNetworkLinks,omitempty"`
+}
+
+// VirtualNetworkLinkConfig declares a virtual network to be linked to private DNS zones.
+type VirtualNetworkLinkConfig struct {
+	// VirtualNetwork is the resource id of the virtual network
+	VirtualNetwork string `json:"virtualNetwork"`
+	// Zones restricts the link to the given zone ids (<resourceGroup>/<zoneName>), default is all zones of the provider
+	Zones []string `json:"zones,omitempty"`
+	// RegistrationEnabled enables the auto-registration of virtual machine records
+	RegistrationEnabled bool `json:"registrationEnabled,omitempty"`
 }
 
 var _ provider.DNSHandler = &Handler{}
@@ -47,6 +68,15 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 		DefaultDNSHandler: provider.NewDefaultDNSHandler(TYPE_CODE),
 		config:            *c,
 	}
+	if c.Config != nil {
+		err := json.Unmarshal(c.Config.Raw, &h.azConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal azure-private-dns providerConfig failed with: %s", err)
+		}
+		if err := validateVirtualNetworkLinks(h.azConfig.VirtualNetworkLinks); err != nil {
+			return nil, fmt.Errorf("invalid azure-private-dns providerConfig: %s", err)
+		}
+	}
 
 	h.ctx = c.Context
 
@@ -57,9 +87,12 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 
 	zonesClient := azure.NewPrivateZonesClient(subscriptionID)
 	recordsClient := azure.NewRecordSetsClient(subscriptionID)
+	linksClient := azure.NewVirtualNetworkLinksClient(subscriptionID)
 
 	zonesClient.Authorizer = authorizer
 	recordsClient.Authorizer = authorizer
+	linksClient.Authorizer = authorizer
+	utils.SetSender(c, &zonesClient.Client, &recordsClient.Client, &linksClient.Client)
 
 	// dummy call to check authentication
 	var one int32 = 1
@@ -72,6 +105,7 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 
 	h.zonesClient = &zonesClient
 	h.recordsClient = &recordsClient
+	h.linksClient = &linksClient
 
 	h.cache, err = c.ZoneCacheFactory.CreateZoneCache(provider.CacheZoneState, c.Metrics, h.getZones, h.getZoneState)
 	if err != nil {
@@ -119,6 +153,7 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 		zones = append(zones, hostedZone)
 	}
 
+	h.reconcileVirtualNetworkLinks(zones)
 	return zones, nil
 }
 
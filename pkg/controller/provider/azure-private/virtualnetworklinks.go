@@ -0,0 +1,193 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package azureprivate
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	azure "github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/controller/provider/azure/utils"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// tagManagedLink marks virtual network links created by the dns controller
+const tagManagedLink = "dns-controller-managed"
+
+var vnetIDPattern = regexp.MustCompile("(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft.Network/virtualNetworks/[^/]+$")
+
+func validateVirtualNetworkLinks(links []VirtualNetworkLinkConfig) error {
+	for i, l := range links {
+		if !vnetIDPattern.MatchString(l.VirtualNetwork) {
+			return fmt.Errorf("virtualNetworkLinks[%d]: invalid virtual network resource id %q", i, l.VirtualNetwork)
+		}
+	}
+	return nil
+}
+
+// virtualNetworkLinkName creates a valid and unique name for the link of a virtual network.
+func virtualNetworkLinkName(vnetID string) string {
+	hash := sha1.Sum([]byte(strings.ToLower(vnetID)))
+	name := strings.ToLower(vnetID[strings.LastIndex(vnetID, "/")+1:])
+	if len(name) > 60 {
+		name = name[:60]
+	}
+	return "dns-" + name + "-" + hex.EncodeToString(hash[:])[:8]
+}
+
+// desiredVirtualNetworkLinks returns the configured links applicable for the given zone.
+func (h *Handler) desiredVirtualNetworkLinks(zoneID string) []VirtualNetworkLinkConfig {
+	var links []VirtualNetworkLinkConfig
+	for _, l := range h.azConfig.VirtualNetworkLinks {
+		if len(l.Zones) == 0 {
+			links = append(links, l)
+			continue
+		}
+		for _, z := range l.Zones {
+			if strings.EqualFold(z, zoneID) {
+				links = append(links, l)
+				break
+			}
+		}
+	}
+	return links
+}
+
+// reconcileVirtualNetworkLinks creates the virtual network links declared in the provider config
+// which are missing for the given zones. Existing links are never removed, as they may be
+// maintained by other providers or manually.
+func (h *Handler) reconcileVirtualNetworkLinks(zones provider.DNSHostedZones) {
+	for _, zone := range zones {
+		desired := h.desiredVirtualNetworkLinks(zone.Id().ID)
+		if len(desired) == 0 {
+			continue
+		}
+		resourceGroup, zoneName := utils.SplitZoneID(zone.Id().ID)
+		existing, err := h.listVirtualNetworkLinks(resourceGroup, zoneName)
+		if err != nil {
+			h.config.Logger.Warnf("cannot list virtual network links of zone %s: %s", zone.Id(), err)
+			continue
+		}
+		for _, l := range desired {
+			if existing[strings.ToLower(l.VirtualNetwork)] != nil {
+				continue
+			}
+			h.config.Logger.Infof("linking virtual network %s with zone %s", l.VirtualNetwork, zone.Id())
+			if err := h.createVirtualNetworkLink(resourceGroup, zoneName, l.VirtualNetwork, l.RegistrationEnabled); err != nil {
+				h.config.Logger.Warnf("linking virtual network %s with zone %s failed: %s", l.VirtualNetwork, zone.Id(), err)
+			}
+		}
+	}
+}
+
+// listVirtualNetworkLinks returns the links of a zone by lower case virtual network id.
+func (h *Handler) listVirtualNetworkLinks(resourceGroup, zoneName string) (map[string]*azure.VirtualNetworkLink, error) {
+	links := map[string]*azure.VirtualNetworkLink{}
+	h.config.RateLimiter.Accept()
+	results, err := h.linksClient.ListComplete(h.ctx, resourceGroup, zoneName, nil)
+	if err != nil {
+		return nil, err
+	}
+	for ; results.NotDone(); results.Next() {
+		item := results.Value()
+		if item.VirtualNetworkLinkProperties != nil && item.VirtualNetwork != nil && item.VirtualNetwork.ID != nil {
+			links[strings.ToLower(*item.VirtualNetwork.ID)] = &item
+		}
+	}
+	return links, nil
+}
+
+func (h *Handler) createVirtualNetworkLink(resourceGroup, zoneName, vnetID string, registrationEnabled bool) error {
+	link := azure.VirtualNetworkLink{
+		Location: to.StringPtr("global"),
+		Tags:     map[string]*string{tagManagedLink: to.StringPtr("true")},
+		VirtualNetworkLinkProperties: &azure.VirtualNetworkLinkProperties{
+			VirtualNetwork:      &azure.SubResource{ID: to.StringPtr(vnetID)},
+			RegistrationEnabled: to.BoolPtr(registrationEnabled),
+		},
+	}
+	h.config.RateLimiter.Accept()
+	future, err := h.linksClient.CreateOrUpdate(h.ctx, resourceGroup, zoneName, virtualNetworkLinkName(vnetID), link, "", "*")
+	if err != nil {
+		return err
+	}
+	return future.WaitForCompletionRef(h.ctx, h.linksClient.Client)
+}
+
+func (h *Handler) deleteVirtualNetworkLink(resourceGroup, zoneName, linkName string) error {
+	h.config.RateLimiter.Accept()
+	future, err := h.linksClient.Delete(h.ctx, resourceGroup, zoneName, linkName, "")
+	if err != nil {
+		if statusCode(err) == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+	return future.WaitForCompletionRef(h.ctx, h.linksClient.Client)
+}
+
+// syncVirtualNetworkLinks adjusts the links of a zone to the given virtual networks.
+// Only links created by the dns controller and not declared in the provider config are deleted.
+func (h *Handler) syncVirtualNetworkLinks(logger logger.LogContext, resourceGroup, zoneName string, vnets []string) error {
+	existing, err := h.listVirtualNetworkLinks(resourceGroup, zoneName)
+	if err != nil {
+		return err
+	}
+	desired := map[string]bool{}
+	for _, l := range h.desiredVirtualNetworkLinks(utils.MakeZoneID(resourceGroup, zoneName)) {
+		desired[strings.ToLower(l.VirtualNetwork)] = true
+	}
+	for _, vnet := range vnets {
+		desired[strings.ToLower(vnet)] = true
+		if existing[strings.ToLower(vnet)] == nil {
+			logger.Infof("linking virtual network %s with zone %s", vnet, zoneName)
+			if err := h.createVirtualNetworkLink(resourceGroup, zoneName, vnet, false); err != nil {
+				return fmt.Errorf("linking virtual network %s failed: %s", vnet, err)
+			}
+		}
+	}
+	for vnet, link := range existing {
+		if !desired[vnet] && isManagedLink(link) {
+			logger.Infof("unlinking virtual network %s from zone %s", vnet, zoneName)
+			if err := h.deleteVirtualNetworkLink(resourceGroup, zoneName, to.String(link.Name)); err != nil {
+				return fmt.Errorf("unlinking virtual network %s failed: %s", vnet, err)
+			}
+		}
+	}
+	return nil
+}
+
+func isManagedLink(link *azure.VirtualNetworkLink) bool {
+	return link.Tags != nil && to.String(link.Tags[tagManagedLink]) == "true"
+}
+
+func statusCode(err error) int {
+	if derr, ok := err.(autorest.DetailedError); ok {
+		if code, ok := derr.StatusCode.(int); ok {
+			return code
+		}
+	}
+	return 0
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package azureprivate
+
+import (
+	"strings"
+	"testing"
+)
+
+const testVNet = "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/My-VNet"
+
+func TestVirtualNetworkLinkName(t *testing.T) {
+	name := virtualNetworkLinkName(testVNet)
+	if !strings.HasPrefix(name, "dns-my-vnet-") || len(name) != len("dns-my-vnet-")+8 {
+		t.Errorf("unexpected link name %s", name)
+	}
+	if other := virtualNetworkLinkName(strings.ToUpper(testVNet)); other != name {
+		t.Errorf("link name must not depend on case: %s != %s", other, name)
+	}
+	if other := virtualNetworkLinkName(strings.Replace(testVNet, "rg1", "rg2", 1)); other == name {
+		t.Errorf("link names of different virtual networks must differ: %s", name)
+	}
+	long := virtualNetworkLinkName(testVNet + strings.Repeat("x", 100))
+	if len(long) > 80 {
+		t.Errorf("link name too long: %s", long)
+	}
+}
+
+func TestValidateVirtualNetworkLinks(t *testing.T) {
+	if err := validateVirtualNetworkLinks([]VirtualNetworkLinkConfig{{VirtualNetwork: testVNet}}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	for _, vnet := range []string{"", "My-VNet", "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks"} {
+		if err := validateVirtualNetworkLinks([]VirtualNetworkLinkConfig{{VirtualNetwork: vnet}}); err == nil {
+			t.Errorf("expected error for %q", vnet)
+		}
+	}
+}
+
+func TestDesiredVirtualNetworkLinks(t *testing.T) {
+	h := &Handler{azConfig: AzurePrivateConfig{VirtualNetworkLinks: []VirtualNetworkLinkConfig{
+		{VirtualNetwork: testVNet},
+		{VirtualNetwork: testVNet + "2", Zones: []string{"rg1/example.com"}},
+	}}}
+	if links := h.desiredVirtualNetworkLinks("RG1/example.com"); len(links) != 2 {
+		t.Errorf("expected 2 links, got %d", len(links))
+	}
+	if links := h.desiredVirtualNetworkLinks("rg1/other.com"); len(links) != 1 || links[0].VirtualNetwork != testVNet {
+		t.Errorf("expected 1 link, got %v", links)
+	}
+}
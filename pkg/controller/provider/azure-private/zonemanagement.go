@@ -0,0 +1,130 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package azureprivate
+
+import (
+	"fmt"
+	"net/http"
+
+	azure "github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/controller/provider/azure/utils"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+var _ provider.DNSZoneManagement = &Handler{}
+
+// tagReference stores the reference of the DNSZone object a private zone has been created for
+const tagReference = "dns-controller-reference"
+
+func validateHostedZoneSpec(spec *provider.HostedZoneSpec) error {
+	if !spec.Private {
+		return fmt.Errorf("%s only supports private hosted zones", TYPE_CODE)
+	}
+	if spec.DNSSEC {
+		return fmt.Errorf("DNSSEC signing is not supported for %s hosted zones", TYPE_CODE)
+	}
+	return validateVirtualNetworkLinks(virtualNetworkLinks(spec.PrivateNetworks))
+}
+
+func virtualNetworkLinks(vnets []string) []VirtualNetworkLinkConfig {
+	var links []VirtualNetworkLinkConfig
+	for _, vnet := range vnets {
+		links = append(links, VirtualNetworkLinkConfig{VirtualNetwork: vnet})
+	}
+	return links
+}
+
+func (h *Handler) CreateHostedZone(logger logger.LogContext, spec *provider.HostedZoneSpec) (*provider.HostedZoneInfo, error) {
+	if err := validateHostedZoneSpec(spec); err != nil {
+		return nil, err
+	}
+	if h.azConfig.ResourceGroup == "" {
+		return nil, fmt.Errorf("resourceGroup must be set in the providerConfig to create private DNS zones")
+	}
+	zoneName := dns.NormalizeHostname(spec.Domain)
+	zone := azure.PrivateZone{
+		Location: to.StringPtr("global"),
+		Tags:     map[string]*string{tagReference: to.StringPtr(spec.Reference)},
+	}
+	h.config.RateLimiter.Accept()
+	future, err := h.zonesClient.CreateOrUpdate(h.ctx, h.azConfig.ResourceGroup, zoneName, zone, "", "*")
+	if err == nil {
+		err = future.WaitForCompletionRef(h.ctx, h.zonesClient.Client)
+	}
+	if err != nil {
+		if statusCode(err) != http.StatusPreconditionFailed {
+			return nil, err
+		}
+		// zone is already existing, it can only be adopted if it has been created for this reference
+		h.config.RateLimiter.Accept()
+		existing, gerr := h.zonesClient.Get(h.ctx, h.azConfig.ResourceGroup, zoneName)
+		if gerr != nil {
+			return nil, gerr
+		}
+		if existing.Tags == nil || to.String(existing.Tags[tagReference]) != spec.Reference {
+			return nil, fmt.Errorf("private DNS zone %s already exists in resource group %s", zoneName, h.azConfig.ResourceGroup)
+		}
+	}
+	return h.UpdateHostedZone(logger, utils.MakeZoneID(h.azConfig.ResourceGroup, zoneName), spec)
+}
+
+func (h *Handler) UpdateHostedZone(logger logger.LogContext, zoneid string, spec *provider.HostedZoneSpec) (*provider.HostedZoneInfo, error) {
+	if err := validateHostedZoneSpec(spec); err != nil {
+		return nil, err
+	}
+	resourceGroup, zoneName := utils.SplitZoneID(zoneid)
+	if err := provider.CheckHostedZoneDomain(zoneid, zoneName, spec); err != nil {
+		return nil, err
+	}
+	if err := h.syncVirtualNetworkLinks(logger, resourceGroup, zoneName, spec.PrivateNetworks); err != nil {
+		return nil, err
+	}
+	return &provider.HostedZoneInfo{ZoneID: zoneid}, nil
+}
+
+func (h *Handler) DeleteHostedZone(logger logger.LogContext, zoneid string) error {
+	resourceGroup, zoneName := utils.SplitZoneID(zoneid)
+	// a private zone can only be deleted without virtual network links
+	links, err := h.listVirtualNetworkLinks(resourceGroup, zoneName)
+	if err != nil {
+		if statusCode(err) == http.StatusNotFound {
+			logger.Infof("hosted zone %s already deleted", zoneid)
+			return nil
+		}
+		return err
+	}
+	for vnet, link := range links {
+		logger.Infof("unlinking virtual network %s from zone %s", vnet, zoneName)
+		if err := h.deleteVirtualNetworkLink(resourceGroup, zoneName, to.String(link.Name)); err != nil {
+			return fmt.Errorf("unlinking virtual network %s failed: %s", vnet, err)
+		}
+	}
+	h.config.RateLimiter.Accept()
+	future, err := h.zonesClient.Delete(h.ctx, resourceGroup, zoneName, "")
+	if err != nil {
+		if statusCode(err) == http.StatusNotFound {
+			logger.Infof("hosted zone %s already deleted", zoneid)
+			return nil
+		}
+		return err
+	}
+	return future.WaitForCompletionRef(h.ctx, h.zonesClient.Client)
+}
@@ -17,6 +17,7 @@
 package azure
 
 import (
+	"github.com/gardener/external-dns-management/pkg/controller/provider/azure/utils"
 	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 )
@@ -24,13 +25,15 @@ import (
 const TYPE_CODE = "azure-dns"
 
 var rateLimiterDefaults = provider.RateLimiterOptions{
-	Enabled: true,
-	QPS:     50,
-	Burst:   10,
+	Enabled:  true,
+	QPS:      50,
+	Burst:    10,
+	Adaptive: true,
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetSecretValidator(utils.ValidateSecret)
 
 func init() {
 	compound.MustRegister(Factory)
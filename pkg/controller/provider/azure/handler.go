@@ -60,6 +60,7 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 
 	zonesClient.Authorizer = authorizer
 	recordsClient.Authorizer = authorizer
+	utils.SetSender(c, &zonesClient.Client, &recordsClient.Client)
 
 	// dummy call to check authentication
 	var one int32 = 1
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
@@ -60,6 +61,10 @@ func SplitZoneID(zoneid string) (string, string) {
 	return parts[0], parts[1]
 }
 
+// ValidateSecret checks the required credentials of a provider secret.
+var ValidateSecret = provider.RequiredSecretKeys([]string{"AZURE_SUBSCRIPTION_ID", "subscriptionID"},
+	[]string{"AZURE_CLIENT_ID", "clientID"}, []string{"AZURE_CLIENT_SECRET", "clientSecret"}, []string{"AZURE_TENANT_ID", "tenantID"})
+
 // GetSubscriptionIDAndAuthorizer extracts credentials from config
 func GetSubscriptionIDAndAuthorizer(c *provider.DNSHandlerConfig) (subscriptionID string, authorizer autorest.Authorizer, err error) {
 	subscriptionID, err = c.GetRequiredProperty("AZURE_SUBSCRIPTION_ID", "subscriptionID")
@@ -81,10 +86,30 @@ func GetSubscriptionIDAndAuthorizer(c *provider.DNSHandlerConfig) (subscriptionI
 		return
 	}
 
-	authorizer, err = auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID).Authorizer()
+	ccc := auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID)
+	if client := c.Transport.HTTPClient(); client != nil {
+		// token requests must use the configured proxy and CAs, too
+		var spt *adal.ServicePrincipalToken
+		spt, err = ccc.ServicePrincipalToken()
+		if err == nil {
+			spt.SetSender(client)
+			authorizer = autorest.NewBearerAuthorizer(spt)
+		}
+	} else {
+		authorizer, err = ccc.Authorizer()
+	}
 	if err != nil {
 		err = perrs.WrapAsHandlerError(err, "Creating Azure authorizer with client credentials failed")
 		return
 	}
 	return
 }
+
+// SetSender configures the http client of the DNSProvider transport settings (if any) for the given clients.
+func SetSender(c *provider.DNSHandlerConfig, clients ...*autorest.Client) {
+	if client := c.Transport.HTTPClient(); client != nil {
+		for _, cl := range clients {
+			cl.Sender = client
+		}
+	}
+}
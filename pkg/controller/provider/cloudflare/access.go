@@ -17,6 +17,8 @@
 package cloudflare
 
 import (
+	"net/http"
+
 	"github.com/cloudflare/cloudflare-go"
 	"k8s.io/client-go/util/flowcontrol"
 
@@ -37,8 +39,12 @@ type access struct {
 	rateLimiter flowcontrol.RateLimiter
 }
 
-func NewAccess(apiToken string, metrics provider.Metrics, rateLimiter flowcontrol.RateLimiter) (Access, error) {
-	api, err := cloudflare.NewWithAPIToken(apiToken)
+func NewAccess(apiToken string, metrics provider.Metrics, rateLimiter flowcontrol.RateLimiter, client *http.Client) (Access, error) {
+	var opts []cloudflare.Option
+	if client != nil {
+		opts = append(opts, cloudflare.HTTPClient(client))
+	}
+	api, err := cloudflare.NewWithAPIToken(apiToken, opts...)
 	if err != nil {
 		return nil, err
 	}
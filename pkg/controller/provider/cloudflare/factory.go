@@ -24,13 +24,15 @@ import (
 const TYPE_CODE = "cloudflare-dns"
 
 var rateLimiterDefaults = provider.RateLimiterOptions{
-	Enabled: true,
-	QPS:     50,
-	Burst:   10,
+	Enabled:  true,
+	QPS:      50,
+	Burst:    10,
+	Adaptive: true,
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetSecretValidator(provider.RequiredSecretKeys([]string{"CLOUDFLARE_API_TOKEN", "apiToken"}))
 
 func init() {
 	compound.MustRegister(Factory)
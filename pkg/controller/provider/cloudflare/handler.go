@@ -53,7 +53,7 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	//	return nil, err
 	//}
 
-	access, err := NewAccess(apiToken, c.Metrics, c.RateLimiter)
+	access, err := NewAccess(apiToken, c.Metrics, c.RateLimiter, c.Transport.HTTPClient())
 	if err != nil {
 		return nil, err
 	}
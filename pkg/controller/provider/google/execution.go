@@ -17,6 +17,8 @@
 package google
 
 import (
+	"fmt"
+
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	googledns "google.golang.org/api/dns/v1"
@@ -35,25 +37,36 @@ type Execution struct {
 	handler *Handler
 	zone    provider.DNSHostedZone
 
-	change *googledns.Change
-	done   []provider.DoneHandler
+	change    *googledns.Change
+	done      map[string][]provider.DoneHandler
+	batchSize int
 
 	routingPolicyChanges routingPolicyChanges
 }
 
+// changeBatch is a part of the changes submitted as single Cloud DNS change.
+type changeBatch struct {
+	change *googledns.Change
+	done   []provider.DoneHandler
+}
+
 func NewExecution(logger logger.LogContext, h *Handler, zone provider.DNSHostedZone) *Execution {
 	change := &googledns.Change{
 		Additions: []*googledns.ResourceRecordSet{},
 		Deletions: []*googledns.ResourceRecordSet{},
 	}
-	return &Execution{
+	exec := &Execution{
 		LogContext:           logger,
 		handler:              h,
 		zone:                 zone,
 		change:               change,
-		done:                 []provider.DoneHandler{},
+		done:                 map[string][]provider.DoneHandler{},
 		routingPolicyChanges: routingPolicyChanges{},
 	}
+	if h != nil {
+		exec.batchSize = h.batchSize
+	}
+	return exec
 }
 
 func (this *Execution) addChange(req *provider.ChangeRequest) {
@@ -99,7 +112,7 @@ func (this *Execution) addChange(req *provider.ChangeRequest) {
 
 func (this *Execution) addAddition(set *googledns.ResourceRecordSet, done provider.DoneHandler) {
 	if done != nil {
-		this.done = append(this.done, done)
+		this.done[set.Name] = append(this.done[set.Name], done)
 	}
 	if set.RoutingPolicy == nil {
 		this.change.Additions = append(this.change.Additions, set)
@@ -111,7 +124,7 @@ func (this *Execution) addAddition(set *googledns.ResourceRecordSet, done provid
 
 func (this *Execution) addDeletion(set *googledns.ResourceRecordSet, done provider.DoneHandler) {
 	if done != nil {
-		this.done = append(this.done, done)
+		this.done[set.Name] = append(this.done[set.Name], done)
 	}
 	if set.RoutingPolicy == nil {
 		this.change.Deletions = append(this.change.Deletions, set)
@@ -157,35 +170,107 @@ func (this *Execution) submitChanges(metrics provider.Metrics) error {
 	err := this.prepareSubmission(rrsetGetter)
 	if err != nil {
 		this.Error(err)
-		for _, d := range this.done {
-			if d != nil {
+		for _, done := range this.done {
+			for _, d := range done {
 				d.Failed(err)
 			}
 		}
 		return err
 	}
 
-	metrics.AddZoneRequests(this.zone.Id().ID, provider.M_UPDATERECORDS, 1)
-	this.handler.config.RateLimiter.Accept()
-	if _, err := this.handler.service.Changes.Create(projectID, zoneName, this.change).Do(); err != nil {
-		this.Error(err)
-		for _, d := range this.done {
-			if d != nil {
-				d.Failed(err)
+	batches := this.batches()
+	if len(batches) == 0 {
+		for _, done := range this.done {
+			for _, d := range done {
+				d.Succeeded()
 			}
 		}
-		return err
+		return nil
 	}
-
-	for _, d := range this.done {
-		if d != nil {
+	if len(batches) > 1 {
+		this.Infof("require %d batches for %d changes", len(batches), len(this.change.Additions)+len(this.change.Deletions))
+	}
+	failed := 0
+	var lastErr error
+	for i, b := range batches {
+		if len(batches) > 1 {
+			this.Infof("processing batch %d for zone %s with %d changes", i+1, this.zone.Id(), len(b.change.Additions)+len(b.change.Deletions))
+		}
+		metrics.AddZoneRequests(this.zone.Id().ID, provider.M_UPDATERECORDS, 1)
+		this.handler.config.RateLimiter.Accept()
+		if _, err := this.handler.service.Changes.Create(projectID, zoneName, b.change).Do(); err != nil {
+			this.Error(err)
+			for _, d := range b.done {
+				d.Failed(err)
+			}
+			failed++
+			lastErr = err
+			continue
+		}
+		for _, d := range b.done {
 			d.Succeeded()
 		}
+		this.Infof("%d records in zone %s were successfully updated", len(b.change.Additions)+len(b.change.Deletions), this.zone.Id())
+	}
+	if failed == 1 && len(batches) == 1 {
+		return lastErr
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d change batches failed: %w", failed, len(batches), lastErr)
 	}
-	this.Infof("%d records in zone %s were successfully updated", len(this.change.Additions)+len(this.change.Deletions), this.zone.Id())
 	return nil
 }
 
+// batches splits the prepared change into changes with at most batchSize additions and deletions.
+// All additions and deletions for a DNS name are kept in the same batch, as Cloud DNS
+// requires replaced record sets to be deleted and added in a single change.
+func (this *Execution) batches() []*changeBatch {
+	var names []string
+	deletions := map[string][]*googledns.ResourceRecordSet{}
+	additions := map[string][]*googledns.ResourceRecordSet{}
+	for _, c := range this.change.Deletions {
+		if _, ok := deletions[c.Name]; !ok {
+			names = append(names, c.Name)
+		}
+		deletions[c.Name] = append(deletions[c.Name], c)
+	}
+	for _, c := range this.change.Additions {
+		if _, ok := deletions[c.Name]; !ok {
+			if _, ok := additions[c.Name]; !ok {
+				names = append(names, c.Name)
+			}
+		}
+		additions[c.Name] = append(additions[c.Name], c)
+	}
+
+	var batches []*changeBatch
+	var batch *changeBatch
+	for _, name := range names {
+		size := len(deletions[name]) + len(additions[name])
+		if batch == nil || (this.batchSize > 0 && len(batch.change.Additions)+len(batch.change.Deletions)+size > this.batchSize) {
+			batch = &changeBatch{change: &googledns.Change{
+				Additions: []*googledns.ResourceRecordSet{},
+				Deletions: []*googledns.ResourceRecordSet{},
+			}}
+			batches = append(batches, batch)
+		}
+		batch.change.Deletions = append(batch.change.Deletions, deletions[name]...)
+		batch.change.Additions = append(batch.change.Additions, additions[name]...)
+		batch.done = append(batch.done, this.done[name]...)
+	}
+	if len(batches) > 0 {
+		// requests without remaining changes (e.g. merged routing policies) complete with the first batch
+		for name, done := range this.done {
+			if _, ok := deletions[name]; !ok {
+				if _, ok := additions[name]; !ok {
+					batches[0].done = append(batches[0].done, done...)
+				}
+			}
+		}
+	}
+	return batches
+}
+
 func isNotFound(err error) bool {
 	if ge, ok := err.(*googleapi.Error); ok {
 		return ge.Code == 404
@@ -337,6 +337,62 @@ var _ = Describe("Execution", func() {
 	)
 })
 
+var _ = Describe("Change batches", func() {
+	var (
+		dnsset1    = makeDNSSet("x1.example.org", dns.RS_A, 301, "1.1.1.1")
+		dnsset2old = makeDNSSet("x2.example.org", dns.RS_A, 302, "1.1.1.2")
+		dnsset2new = makeDNSSet("x2.example.org", dns.RS_A, 303, "1.1.1.3")
+		dnsset4    = makeDNSSet("x4.example.org", dns.RS_A, 304, "1.1.1.4")
+		dnsset5    = makeDNSSet("x5.example.org", dns.RS_A, 305, "1.1.1.5")
+	)
+
+	prepareBatches := func(batchSize int) []*changeBatch {
+		reqs := []*provider.ChangeRequest{
+			{Action: provider.R_CREATE, Type: dns.RS_A, Addition: dnsset1, Done: &testDoneHandler{}},
+			{Action: provider.R_UPDATE, Type: dns.RS_A, Addition: dnsset2new, Deletion: dnsset2old, Done: &testDoneHandler{}},
+			{Action: provider.R_DELETE, Type: dns.RS_A, Deletion: dnsset4, Done: &testDoneHandler{}},
+			{Action: provider.R_CREATE, Type: dns.RS_A, Addition: dnsset5, Done: &testDoneHandler{}},
+		}
+		zone := provider.NewDNSHostedZone(TYPE_CODE, "test", "example.org", "", nil, false)
+		exec := NewExecution(logger.NewContext("", "TestEnv"), nil, zone)
+		exec.batchSize = batchSize
+		for _, r := range reqs {
+			exec.addChange(r)
+		}
+		Expect(exec.prepareSubmission(nil)).To(Succeed())
+		return exec.batches()
+	}
+
+	batchNames := func(b *changeBatch) []string {
+		var names []string
+		for _, c := range b.change.Deletions {
+			names = append(names, "-"+c.Name)
+		}
+		for _, c := range b.change.Additions {
+			names = append(names, "+"+c.Name)
+		}
+		return names
+	}
+
+	It("submits all changes in a single batch without limit", func() {
+		batches := prepareBatches(0)
+		Expect(batches).To(HaveLen(1))
+		Expect(batchNames(batches[0])).To(ConsistOf("-x2.example.org.", "-x4.example.org.", "+x1.example.org.", "+x2.example.org.", "+x5.example.org."))
+		Expect(batches[0].done).To(HaveLen(4))
+	})
+
+	It("splits the changes keeping the changes of a DNS name together", func() {
+		batches := prepareBatches(2)
+		Expect(batches).To(HaveLen(3))
+		Expect(batchNames(batches[0])).To(Equal([]string{"-x2.example.org.", "+x2.example.org."}))
+		Expect(batchNames(batches[1])).To(Equal([]string{"-x4.example.org.", "+x1.example.org."}))
+		Expect(batchNames(batches[2])).To(Equal([]string{"+x5.example.org."}))
+		Expect(batches[0].done).To(HaveLen(1))
+		Expect(batches[1].done).To(HaveLen(2))
+		Expect(batches[2].done).To(HaveLen(1))
+	})
+})
+
 func makeDNSSet(dnsName, typ string, ttl int64, targets ...string) *dns.DNSSet {
 	set := dns.NewDNSSet(dns.DNSSetName{DNSName: dnsName}, nil)
 	set.SetRecordSet(typ, ttl, targets...)
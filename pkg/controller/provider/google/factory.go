@@ -24,13 +24,23 @@ import (
 const TYPE_CODE = "google-clouddns"
 
 var rateLimiterDefaults = provider.RateLimiterOptions{
-	Enabled: true,
-	QPS:     100,
-	Burst:   20,
+	Enabled:  true,
+	QPS:      100,
+	Burst:    20,
+	Adaptive: true,
+}
+
+// advancedDefaults limits the change batches to the number of record set additions and deletions
+// accepted by Cloud DNS for a single change.
+var advancedDefaults = provider.AdvancedOptions{
+	BatchSize:  1000,
+	MaxRetries: 7,
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.
+		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults)).
+	SetSecretValidator(provider.RequiredSecretKeys([]string{"serviceaccount.json"}))
 
 func init() {
 	compound.MustRegister(Factory)
@@ -29,6 +29,7 @@ import (
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
 
@@ -46,6 +47,7 @@ type Handler struct {
 	ctx         context.Context
 	service     *googledns.Service
 	rateLimiter flowcontrol.RateLimiter
+	batchSize   int
 }
 
 const epsilon = 0.00001
@@ -60,6 +62,11 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 		config:            *config,
 		rateLimiter:       config.RateLimiter,
 	}
+	if config.Options != nil {
+		advancedConfig := config.Options.AdvancedOptions.GetAdvancedConfig()
+		config.Logger.Infof("advanced options: %s", advancedConfig)
+		h.batchSize = advancedConfig.BatchSize
+	}
 	scopes := []string{
 		//	"https://www.googleapis.com/auth/compute",
 		//	"https://www.googleapis.com/auth/cloud-platform",
@@ -72,9 +79,11 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 		return nil, fmt.Errorf("'serviceaccount.json' required in secret")
 	}
 
-	//c:=*http.DefaultClient
-	//h.ctx=context.WithValue(config.Context,oauth2.HTTPClient,&c)
 	h.ctx = config.Context
+	if client := config.Transport.HTTPClient(); client != nil {
+		// used by oauth2 for token requests and as base transport of the service client
+		h.ctx = context.WithValue(h.ctx, oauth2.HTTPClient, client)
+	}
 
 	h.credentials, err = google.CredentialsFromJSON(h.ctx, []byte(json), scopes...)
 	//cfg, err:=google.JWTConfigFromJSON([]byte(json))
@@ -218,6 +227,37 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
+var _ provider.RoutingPolicyChecker = &Handler{}
+
+// CheckRoutingPolicy checks whether the routing policy of the record set is supported by Cloud DNS.
+func (h *Handler) CheckRoutingPolicy(set *dns.DNSSet) error {
+	_, err := extractRoutingPolicy(set)
+	return err
+}
+
+var _ provider.PermissionChecker = &Handler{}
+
+// CheckChangePermission probes the permission for dns.changes.create by deleting a non-existing
+// record set. If permitted, the request is rejected as not found without modifying the zone.
+func (h *Handler) CheckChangePermission(zone provider.DNSHostedZone) error {
+	projectID, zoneName := SplitZoneID(zone.Id().ID)
+	change := &googledns.Change{
+		Deletions: []*googledns.ResourceRecordSet{{
+			Name:    dns.AlignHostname(provider.PermissionCheckName(zone)),
+			Type:    dns.RS_TXT,
+			Ttl:     60,
+			Rrdatas: []string{"\"permission-check\""},
+		}},
+	}
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_UPDATERECORDS, 1)
+	h.config.RateLimiter.Accept()
+	_, err := h.service.Changes.Create(projectID, zoneName, change).Do()
+	if ge, ok := err.(*googleapi.Error); ok && (ge.Code == http.StatusForbidden || ge.Code == http.StatusUnauthorized) {
+		return fmt.Errorf("dns.changes.create denied")
+	}
+	return nil
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package google
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	googledns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+var _ provider.DNSZoneManagement = &Handler{}
+
+var invalidZoneNameChars = regexp.MustCompile("[^a-z0-9-]")
+
+// managedZoneName creates a valid and unique name for a managed zone.
+func managedZoneName(spec *provider.HostedZoneSpec) string {
+	ref := strings.ReplaceAll(spec.Reference, "-", "")
+	if len(ref) > 8 {
+		ref = ref[:8]
+	}
+	name := "dns-" + invalidZoneNameChars.ReplaceAllString(strings.ReplaceAll(strings.ToLower(dns.NormalizeHostname(spec.Domain)), ".", "-"), "")
+	if len(name) > 54 {
+		name = name[:54]
+	}
+	return strings.TrimSuffix(name, "-") + "-" + ref
+}
+
+func visibility(private bool) string {
+	if private {
+		return "private"
+	}
+	return "public"
+}
+
+func dnssecState(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
+
+func (h *Handler) CreateHostedZone(logger logger.LogContext, spec *provider.HostedZoneSpec) (*provider.HostedZoneInfo, error) {
+	if !spec.Private && len(spec.PrivateNetworks) > 0 {
+		return nil, fmt.Errorf("private networks are only supported for private hosted zones")
+	}
+	zone := &googledns.ManagedZone{
+		Name:        managedZoneName(spec),
+		DnsName:     dns.AlignHostname(spec.Domain),
+		Description: "managed by dns controller",
+		Visibility:  visibility(spec.Private),
+	}
+	if spec.Private {
+		zone.PrivateVisibilityConfig = privateVisibilityConfig(spec.PrivateNetworks)
+	} else {
+		zone.DnssecConfig = &googledns.ManagedZoneDnsSecConfig{State: dnssecState(spec.DNSSEC)}
+	}
+	if spec.Private && spec.DNSSEC {
+		return nil, fmt.Errorf("DNSSEC signing is not supported for private hosted zones")
+	}
+
+	h.config.RateLimiter.Accept()
+	created, err := h.service.ManagedZones.Create(h.credentials.ProjectID, zone).Context(h.ctx).Do()
+	if err != nil {
+		if gerr, ok := err.(*googleapi.Error); !ok || gerr.Code != http.StatusConflict {
+			return nil, err
+		}
+		// zone has already been created for this reference
+		return h.UpdateHostedZone(logger, h.makeZoneID(zone.Name), spec)
+	}
+	return &provider.HostedZoneInfo{ZoneID: h.makeZoneID(created.Name), NameServers: created.NameServers}, nil
+}
+
+func (h *Handler) UpdateHostedZone(logger logger.LogContext, zoneid string, spec *provider.HostedZoneSpec) (*provider.HostedZoneInfo, error) {
+	project, name := SplitZoneID(zoneid)
+	h.config.RateLimiter.Accept()
+	zone, err := h.service.ManagedZones.Get(project, name).Context(h.ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	if err := provider.CheckHostedZoneDomain(zoneid, zone.DnsName, spec); err != nil {
+		return nil, err
+	}
+	if zone.Visibility != visibility(spec.Private) {
+		return nil, fmt.Errorf("hosted zone %s cannot be changed between private and public", zoneid)
+	}
+
+	patch := &googledns.ManagedZone{}
+	modified := false
+	if spec.Private {
+		if !reflect.DeepEqual(networkURLs(zone.PrivateVisibilityConfig), networkURLs(privateVisibilityConfig(spec.PrivateNetworks))) {
+			patch.PrivateVisibilityConfig = privateVisibilityConfig(spec.PrivateNetworks)
+			modified = true
+		}
+	} else {
+		current := zone.DnssecConfig != nil && zone.DnssecConfig.State == "on"
+		if current != spec.DNSSEC {
+			patch.DnssecConfig = &googledns.ManagedZoneDnsSecConfig{State: dnssecState(spec.DNSSEC)}
+			modified = true
+		}
+	}
+	if modified {
+		logger.Infof("updating settings of hosted zone %s", zoneid)
+		h.config.RateLimiter.Accept()
+		if _, err := h.service.ManagedZones.Patch(project, name, patch).Context(h.ctx).Do(); err != nil {
+			return nil, err
+		}
+	}
+	return &provider.HostedZoneInfo{ZoneID: zoneid, NameServers: zone.NameServers}, nil
+}
+
+func (h *Handler) DeleteHostedZone(logger logger.LogContext, zoneid string) error {
+	project, name := SplitZoneID(zoneid)
+	h.config.RateLimiter.Accept()
+	err := h.service.ManagedZones.Delete(project, name).Context(h.ctx).Do()
+	if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == http.StatusNotFound {
+		logger.Infof("hosted zone %s already deleted", zoneid)
+		return nil
+	}
+	return err
+}
+
+func privateVisibilityConfig(networks []string) *googledns.ManagedZonePrivateVisibilityConfig {
+	cfg := &googledns.ManagedZonePrivateVisibilityConfig{}
+	for _, n := range networks {
+		cfg.Networks = append(cfg.Networks, &googledns.ManagedZonePrivateVisibilityConfigNetwork{NetworkUrl: n})
+	}
+	return cfg
+}
+
+func networkURLs(cfg *googledns.ManagedZonePrivateVisibilityConfig) []string {
+	urls := []string{}
+	if cfg != nil {
+		for _, n := range cfg.Networks {
+			urls = append(urls, n.NetworkUrl)
+		}
+	}
+	sort.Strings(urls)
+	return urls
+}
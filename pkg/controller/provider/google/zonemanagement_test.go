@@ -0,0 +1,35 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package google
+
+import (
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Zone management", func() {
+	It("creates valid managed zone names", func() {
+		spec := &provider.HostedZoneSpec{Domain: "Sub.Example.org.", Reference: "0123abcd-4567-89ef"}
+		Expect(managedZoneName(spec)).To(Equal("dns-sub-example-org-0123abcd"))
+
+		spec = &provider.HostedZoneSpec{Domain: "a-very-long-subdomain-name.another-long-component.example.org", Reference: "0123abcd"}
+		name := managedZoneName(spec)
+		Expect(len(name)).To(BeNumerically("<=", 63))
+		Expect(name).To(HaveSuffix("-0123abcd"))
+	})
+})
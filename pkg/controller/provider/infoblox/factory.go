@@ -24,7 +24,8 @@ import (
 
 const TYPE_CODE = "infoblox-dns"
 
-var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler)
+var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
+	SetSecretValidator(provider.RequiredSecretKeys([]string{"USERNAME", "username"}, []string{"PASSWORD", "password"}))
 
 func init() {
 	compound.MustRegister(Factory)
@@ -24,9 +24,10 @@ import (
 const TYPE_CODE = "mock-inmemory"
 
 var rateLimiterDefaults = provider.RateLimiterOptions{
-	Enabled: true,
-	QPS:     100,
-	Burst:   20,
+	Enabled:  true,
+	QPS:      100,
+	Burst:    20,
+	Adaptive: true,
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
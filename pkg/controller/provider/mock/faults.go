@@ -0,0 +1,210 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+const (
+	OP_GETZONES        = "getZones"
+	OP_GETZONESTATE    = "getZoneState"
+	OP_EXECUTEREQUESTS = "executeRequests"
+)
+
+// MockFaults configures faults injected into the provider calls of the mock provider
+// to simulate the behaviour of real DNS providers in functional tests.
+type MockFaults struct {
+	// Latency is added to every provider call (e.g. "200ms").
+	Latency string `json:"latency,omitempty"`
+	// LatencyJitter is the maximum random latency added to Latency.
+	LatencyJitter string `json:"latencyJitter,omitempty"`
+	// ErrorRate is the probability (0..1) that a provider call fails.
+	ErrorRate float64 `json:"errorRate,omitempty"`
+	// ThrottleRate is the probability (0..1) that a provider call is rejected with a throttling response.
+	ThrottleRate float64 `json:"throttleRate,omitempty"`
+	// PartialFailureRate is the probability (0..1) that a single change request fails,
+	// while the other change requests of the same call are applied.
+	PartialFailureRate float64 `json:"partialFailureRate,omitempty"`
+	// Operations restricts the faults to the given provider calls (getZones, getZoneState, executeRequests).
+	// All calls are affected if empty.
+	Operations []string `json:"operations,omitempty"`
+	// Seed makes the random faults reproducible if not zero.
+	Seed int64 `json:"seed,omitempty"`
+}
+
+type faults struct {
+	latency            time.Duration
+	latencyJitter      time.Duration
+	errorRate          float64
+	throttleRate       float64
+	partialFailureRate float64
+	operations         map[string]bool
+}
+
+func (this *MockFaults) parse() (*faults, error) {
+	f := &faults{
+		errorRate:          this.ErrorRate,
+		throttleRate:       this.ThrottleRate,
+		partialFailureRate: this.PartialFailureRate,
+	}
+	var err error
+	if this.Latency != "" {
+		if f.latency, err = time.ParseDuration(this.Latency); err != nil || f.latency < 0 {
+			return nil, fmt.Errorf("invalid latency %q", this.Latency)
+		}
+	}
+	if this.LatencyJitter != "" {
+		if f.latencyJitter, err = time.ParseDuration(this.LatencyJitter); err != nil || f.latencyJitter < 0 {
+			return nil, fmt.Errorf("invalid latencyJitter %q", this.LatencyJitter)
+		}
+	}
+	for name, rate := range map[string]float64{"errorRate": this.ErrorRate, "throttleRate": this.ThrottleRate, "partialFailureRate": this.PartialFailureRate} {
+		if rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("%s must be between 0 and 1", name)
+		}
+	}
+	if this.ErrorRate+this.ThrottleRate > 1 {
+		return nil, fmt.Errorf("sum of errorRate and throttleRate must not exceed 1")
+	}
+	if len(this.Operations) > 0 {
+		f.operations = map[string]bool{}
+		for _, op := range this.Operations {
+			switch op {
+			case OP_GETZONES, OP_GETZONESTATE, OP_EXECUTEREQUESTS:
+				f.operations[op] = true
+			default:
+				return nil, fmt.Errorf("invalid operation %q", op)
+			}
+		}
+	}
+	return f, nil
+}
+
+// faultInjector injects the faults configured in the provider config or in a faults file.
+// The faults file (e.g. mounted from a config map) overrides the provider config and is
+// reloaded on modification, so that the faults can be changed while a test is running.
+type faultInjector struct {
+	lock     sync.Mutex
+	random   *rand.Rand
+	config   *faults
+	file     string
+	modTime  time.Time
+	fromFile *faults
+	sleep    func(time.Duration)
+}
+
+func newFaultInjector(config *MockFaults, file string) (*faultInjector, error) {
+	seed := time.Now().UnixNano()
+	this := &faultInjector{file: file, sleep: time.Sleep}
+	if config != nil {
+		f, err := config.parse()
+		if err != nil {
+			return nil, fmt.Errorf("invalid faults: %w", err)
+		}
+		this.config = f
+		if config.Seed != 0 {
+			seed = config.Seed
+		}
+	}
+	this.random = rand.New(rand.NewSource(seed))
+	return this, nil
+}
+
+// current returns the active faults, reloading the faults file if it has been modified.
+func (this *faultInjector) current() *faults {
+	if this.file == "" {
+		return this.config
+	}
+	fi, err := os.Stat(this.file)
+	if err != nil {
+		this.fromFile = nil
+		this.modTime = time.Time{}
+		return this.config
+	}
+	if !fi.ModTime().Equal(this.modTime) {
+		this.modTime = fi.ModTime()
+		this.fromFile = nil
+		mf := &MockFaults{}
+		data, err := os.ReadFile(this.file)
+		if err == nil {
+			err = json.Unmarshal(data, mf)
+		}
+		if err == nil {
+			this.fromFile, err = mf.parse()
+		}
+		if err != nil {
+			logger.Warnf("ignoring mock faults file %s: %s", this.file, err)
+		} else {
+			logger.Infof("loaded mock faults from %s", this.file)
+		}
+	}
+	if this.fromFile != nil {
+		return this.fromFile
+	}
+	return this.config
+}
+
+// Inject delays the provider call and returns an injected error or throttling error.
+func (this *faultInjector) Inject(op string) error {
+	this.lock.Lock()
+	f := this.current()
+	if f == nil || f.operations != nil && !f.operations[op] {
+		this.lock.Unlock()
+		return nil
+	}
+	delay := f.latency
+	if f.latencyJitter > 0 {
+		delay += time.Duration(this.random.Int63n(int64(f.latencyJitter)))
+	}
+	r := this.random.Float64()
+	this.lock.Unlock()
+
+	if delay > 0 {
+		this.sleep(delay)
+	}
+	switch {
+	case r < f.throttleRate:
+		return perrs.NewThrottlingError(fmt.Errorf("mock %s: rate exceeded (injected)", op))
+	case r < f.throttleRate+f.errorRate:
+		return fmt.Errorf("mock %s: internal error (injected)", op)
+	}
+	return nil
+}
+
+// FailRequest returns an injected error for a single change request.
+func (this *faultInjector) FailRequest() error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	f := this.current()
+	if f == nil || f.operations != nil && !f.operations[OP_EXECUTEREQUESTS] || f.partialFailureRate == 0 {
+		return nil
+	}
+	if this.random.Float64() < f.partialFailureRate {
+		return fmt.Errorf("mock change request failed (injected)")
+	}
+	return nil
+}
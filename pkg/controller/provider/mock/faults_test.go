@@ -0,0 +1,83 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package mock
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+func TestFaultsValidation(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, f := range []*MockFaults{
+		{Latency: "x"},
+		{ErrorRate: 1.5},
+		{ErrorRate: 0.6, ThrottleRate: 0.6},
+		{Operations: []string{"unknown"}},
+	} {
+		_, err := newFaultInjector(f, "")
+		Ω(err).Should(HaveOccurred())
+	}
+}
+
+func TestFaultsInjection(t *testing.T) {
+	RegisterTestingT(t)
+
+	injector, err := newFaultInjector(&MockFaults{Latency: "1s", ThrottleRate: 1, Operations: []string{OP_GETZONES}}, "")
+	Ω(err).ShouldNot(HaveOccurred())
+	var slept time.Duration
+	injector.sleep = func(d time.Duration) { slept += d }
+
+	err = injector.Inject(OP_GETZONES)
+	Ω(perrs.IsThrottlingError(err)).Should(BeTrue())
+	Ω(slept).Should(Equal(time.Second))
+	Ω(injector.Inject(OP_GETZONESTATE)).Should(Succeed())
+	Ω(injector.FailRequest()).Should(Succeed())
+
+	injector, err = newFaultInjector(&MockFaults{ErrorRate: 1, PartialFailureRate: 1, Seed: 1}, "")
+	Ω(err).ShouldNot(HaveOccurred())
+	err = injector.Inject(OP_EXECUTEREQUESTS)
+	Ω(err).Should(HaveOccurred())
+	Ω(perrs.IsThrottlingError(err)).Should(BeFalse())
+	Ω(injector.FailRequest()).ShouldNot(Succeed())
+}
+
+func TestFaultsFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	file := filepath.Join(t.TempDir(), "faults.json")
+	injector, err := newFaultInjector(nil, file)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(injector.Inject(OP_GETZONES)).Should(Succeed())
+
+	Ω(os.WriteFile(file, []byte(`{"errorRate": 1}`), 0600)).Should(Succeed())
+	Ω(injector.Inject(OP_GETZONES)).ShouldNot(Succeed())
+
+	Ω(os.WriteFile(file, []byte(`{"errorRate": 0}`), 0600)).Should(Succeed())
+	Ω(os.Chtimes(file, time.Now(), time.Now().Add(time.Minute))).Should(Succeed())
+	Ω(injector.Inject(OP_GETZONES)).Should(Succeed())
+
+	Ω(os.Remove(file)).Should(Succeed())
+	Ω(injector.Inject(OP_GETZONES)).Should(Succeed())
+}
@@ -21,11 +21,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"k8s.io/client-go/util/flowcontrol"
 
+	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 )
 
 type Handler struct {
@@ -36,6 +39,10 @@ type Handler struct {
 	mock        *provider.InMemory
 	mockConfig  MockConfig
 	rateLimiter flowcontrol.RateLimiter
+	faults      *faultInjector
+
+	lock         sync.Mutex
+	healthChecks map[string]provider.HealthCheckSpec
 }
 
 type MockZone struct {
@@ -49,9 +56,15 @@ type MockConfig struct {
 	Zones           []MockZone `json:"zones"`
 	FailGetZones    bool       `json:"failGetZones"`
 	FailDeleteEntry bool       `json:"failDeleteEntry"`
+	// Faults are injected into the provider calls
+	Faults *MockFaults `json:"faults,omitempty"`
+	// FaultsFile is an optional JSON file with MockFaults overriding Faults (reloaded on modification)
+	FaultsFile string `json:"faultsFile,omitempty"`
 }
 
 var _ provider.DNSHandler = &Handler{}
+var _ provider.DNSZoneManagement = &Handler{}
+var _ provider.DNSHealthCheckManagement = &Handler{}
 
 // TestMock allows tests to access mocked DNSHosted Zones
 var TestMock = map[string]*provider.InMemory{}
@@ -64,6 +77,7 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 		config:            *config,
 		mock:              mock,
 		rateLimiter:       config.RateLimiter,
+		healthChecks:      map[string]provider.HealthCheckSpec{},
 	}
 
 	err := json.Unmarshal(config.Config.Raw, &h.mockConfig)
@@ -71,7 +85,12 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 		return nil, fmt.Errorf("unmarshal mock providerConfig failed with: %s", err)
 	}
 
-	TestMock[h.mockConfig.Name] = mock
+	h.faults, err = newFaultInjector(h.mockConfig.Faults, h.mockConfig.FaultsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	registerMock(h.mockConfig.Name, mock)
 
 	for _, mockZone := range h.mockConfig.Zones {
 		if mockZone.DNSName != "" {
@@ -107,6 +126,9 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 	if h.mockConfig.FailGetZones {
 		return nil, fmt.Errorf("forced error by mockConfig.FailGetZones")
 	}
+	if err := h.faults.Inject(OP_GETZONES); err != nil {
+		return nil, err
+	}
 	h.config.RateLimiter.Accept()
 	zones := h.mock.GetZones()
 	return zones, nil
@@ -117,6 +139,9 @@ func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneSta
 }
 
 func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
+	if err := h.faults.Inject(OP_GETZONESTATE); err != nil {
+		return nil, err
+	}
 	h.config.RateLimiter.Accept()
 	return h.mock.CloneZoneState(zone)
 }
@@ -132,13 +157,27 @@ func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHos
 }
 
 func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	if err := h.faults.Inject(OP_EXECUTEREQUESTS); err != nil {
+		logger.Infof("Apply failed with %s", err.Error())
+		for _, r := range reqs {
+			if r.Done == nil {
+				continue
+			}
+			if perrs.IsThrottlingError(err) {
+				r.Done.Throttled()
+			} else {
+				r.Done.Failed(err)
+			}
+		}
+		return err
+	}
 	var succeeded, failed int
 	for _, r := range reqs {
 		h.config.RateLimiter.Accept()
 		var err error
 		if h.mockConfig.FailDeleteEntry && r.Action == provider.R_DELETE {
 			err = fmt.Errorf("forced error by mockConfig.FailDeleteEntry")
-		} else {
+		} else if err = h.faults.FailRequest(); err == nil {
 			err = h.mock.Apply(zone.Id(), r, h.config.Metrics)
 		}
 		if err != nil {
@@ -164,3 +203,67 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 
 	return nil
 }
+
+func (h *Handler) CreateHostedZone(logger logger.LogContext, spec *provider.HostedZoneSpec) (*provider.HostedZoneInfo, error) {
+	prefix := ""
+	if spec.Private {
+		prefix = ":private:"
+	}
+	zoneID := prefix + spec.Domain
+	hostedZone := provider.NewDNSHostedZone(h.ProviderType(), zoneID, spec.Domain, "", []string{}, spec.Private)
+	if !h.mock.AddZone(hostedZone) {
+		logger.Infof("mock DNSZone %s[%s] already existing", spec.Domain, zoneID)
+	}
+	return h.hostedZoneInfo(zoneID), nil
+}
+
+func (h *Handler) UpdateHostedZone(logger logger.LogContext, zoneid string, spec *provider.HostedZoneSpec) (*provider.HostedZoneInfo, error) {
+	zone := h.mock.FindHostedZone(dns.NewZoneID(h.ProviderType(), zoneid))
+	if zone == nil {
+		return nil, fmt.Errorf("mock DNSZone %s not found", zoneid)
+	}
+	if err := provider.CheckHostedZoneDomain(zoneid, zone.Domain(), spec); err != nil {
+		return nil, err
+	}
+	return h.hostedZoneInfo(zoneid), nil
+}
+
+func (h *Handler) DeleteHostedZone(logger logger.LogContext, zoneid string) error {
+	h.mock.DeleteZone(dns.NewZoneID(h.ProviderType(), zoneid))
+	return nil
+}
+
+func (h *Handler) hostedZoneInfo(zoneid string) *provider.HostedZoneInfo {
+	return &provider.HostedZoneInfo{
+		ZoneID:      zoneid,
+		NameServers: []string{"ns1.mock.local", "ns2.mock.local"},
+	}
+}
+
+func (h *Handler) CreateHealthCheck(logger logger.LogContext, spec *provider.HealthCheckSpec) (string, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	id := "hc-" + spec.Reference
+	if _, ok := h.healthChecks[id]; ok {
+		logger.Infof("mock health check %s already existing", id)
+	}
+	h.healthChecks[id] = *spec
+	return id, nil
+}
+
+func (h *Handler) UpdateHealthCheck(logger logger.LogContext, id string, spec *provider.HealthCheckSpec) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if _, ok := h.healthChecks[id]; !ok {
+		return fmt.Errorf("mock health check %s not found", id)
+	}
+	h.healthChecks[id] = *spec
+	return nil
+}
+
+func (h *Handler) DeleteHealthCheck(logger logger.LogContext, id string) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.healthChecks, id)
+	return nil
+}
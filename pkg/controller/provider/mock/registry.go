@@ -0,0 +1,49 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package mock
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+var registryLock sync.Mutex
+
+func registerMock(name string, mock *provider.InMemory) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	TestMock[name] = mock
+}
+
+// InMemories returns the in-memory stores of all mock-inmemory providers.
+func InMemories() []*provider.InMemory {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	names := make([]string, 0, len(TestMock))
+	for name := range TestMock {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	mocks := make([]*provider.InMemory, 0, len(names))
+	for _, name := range names {
+		mocks = append(mocks, TestMock[name])
+	}
+	return mocks
+}
@@ -24,13 +24,15 @@ import (
 const TYPE_CODE = "netlify-dns"
 
 var rateLimiterDefaults = provider.RateLimiterOptions{
-	Enabled: true,
-	QPS:     50,
-	Burst:   10,
+	Enabled:  true,
+	QPS:      50,
+	Burst:    10,
+	Adaptive: true,
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetSecretValidator(provider.RequiredSecretKeys([]string{"NETLIFY_AUTH_TOKEN", "NETLIFY_API_TOKEN"}))
 
 func init() {
 	compound.MustRegister(Factory)
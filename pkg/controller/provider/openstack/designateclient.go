@@ -39,6 +39,9 @@ type designateClientInterface interface {
 	// ForEachZone calls handler for each zone managed by the Designate
 	ForEachZone(handler func(zone *zones.Zone) error) error
 
+	// GetZone returns the given DNS zone
+	GetZone(zoneID string) (*zones.Zone, error)
+
 	// ForEachRecordSet calls handler for each recordset in the given DNS zone
 	ForEachRecordSet(zoneID string, handler func(recordSet *recordsets.RecordSet) error) error
 
@@ -70,6 +73,7 @@ type clientAuthConfig struct {
 	CACert     string
 	ClientCert string
 	ClientKey  string
+	Transport  *provider.TransportConfig
 }
 
 // authenticate in OpenStack and obtain Designate service endpoint
@@ -93,13 +97,17 @@ func createDesignateServiceClient(logger logger.LogContext, clientAuthConfig *cl
 		return nil, err
 	}
 
-	tlscfg := &tls.Config{}
+	tlscfg := &tls.Config{RootCAs: clientAuthConfig.Transport.RootCAs()}
 
 	if clientAuthConfig.CACert != "" {
 		caCertPool := x509.NewCertPool()
+		if tlscfg.RootCAs != nil {
+			caCertPool = tlscfg.RootCAs.Clone()
+		}
 		caCertPool.AppendCertsFromPEM([]byte(clientAuthConfig.CACert))
 		tlscfg.RootCAs = caCertPool
 	}
+	clientAuthConfig.Transport.ApplyTLSPolicy(tlscfg)
 	if clientAuthConfig.Insecure {
 		tlscfg.InsecureSkipVerify = true
 	}
@@ -114,6 +122,7 @@ func createDesignateServiceClient(logger logger.LogContext, clientAuthConfig *cl
 	}
 
 	transport := &http.Transport{
+		Proxy: clientAuthConfig.Transport.Proxy(),
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
 			KeepAlive: 30 * time.Second,
@@ -165,6 +174,12 @@ func (c designateClient) ForEachZone(handler func(zone *zones.Zone) error) error
 	)
 }
 
+// GetZone returns the given DNS zone
+func (c designateClient) GetZone(zoneID string) (*zones.Zone, error) {
+	c.metrics.AddZoneRequests(zoneID, provider.M_GETZONE, 1)
+	return zones.Get(c.serviceClient, zoneID).Extract()
+}
+
 // ForEachRecordSet calls handler for each recordset in the given DNS zone
 func (c designateClient) ForEachRecordSet(zoneID string, handler func(recordSet *recordsets.RecordSet) error) error {
 	return c.ForEachRecordSetFilterByTypeAndName(zoneID, "", "", handler)
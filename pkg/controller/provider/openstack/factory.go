@@ -17,6 +17,8 @@
 package openstack
 
 import (
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
 	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 )
@@ -24,13 +26,21 @@ import (
 const TYPE_CODE = "openstack-designate"
 
 var rateLimiterDefaults = provider.RateLimiterOptions{
-	Enabled: true,
-	QPS:     100,
-	Burst:   20,
+	Enabled:  true,
+	QPS:      100,
+	Burst:    20,
+	Adaptive: true,
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetSecretValidator(validateSecret)
+
+// validateSecret checks the authentication properties without authenticating at Keystone.
+func validateSecret(props utils.Properties) error {
+	_, err := readAuthConfig(&provider.DNSHandlerConfig{Properties: props})
+	return err
+}
 
 func init() {
 	compound.MustRegister(Factory)
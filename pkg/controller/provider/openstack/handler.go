@@ -19,6 +19,7 @@ package openstack
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
@@ -61,7 +62,7 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 		client:            designateClient{serviceClient: serviceClient, metrics: config.Metrics},
 	}
 
-	h.cache, err = config.ZoneCacheFactory.CreateZoneCache(provider.CacheZoneState, config.Metrics, h.getZones, h.getZoneState)
+	h.cache, err = config.ZoneCacheFactory.CreateVersionedZoneCache(provider.CacheZoneState, config.Metrics, h.getZones, h.getZoneState, h.getZoneVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +137,7 @@ func readAuthConfig(c *provider.DNSHandlerConfig) (*clientAuthConfig, error) {
 		ClientCert: clientCert,
 		ClientKey:  clientKey,
 		Insecure:   insecure == "true" || insecure == "yes",
+		Transport:  c.Transport,
 	}
 
 	return &authConfig, nil
@@ -229,6 +231,19 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
+// getZoneVersion returns the serial number of the zone, which is incremented by Designate on every change.
+func (h *Handler) getZoneVersion(zone provider.DNSHostedZone, cache provider.ZoneCache) (string, error) {
+	h.config.RateLimiter.Accept()
+	z, err := h.client.GetZone(zone.Id().ID)
+	if err != nil {
+		return "", err
+	}
+	if z.Serial == 0 {
+		return "", nil
+	}
+	return strconv.Itoa(z.Serial), nil
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
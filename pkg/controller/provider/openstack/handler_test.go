@@ -90,6 +90,14 @@ func (c *designateMockClient) ForEachZone(handler func(zone *zones.Zone) error)
 	return nil
 }
 
+func (c *designateMockClient) GetZone(zoneID string) (*zones.Zone, error) {
+	tz := c.tzmap[zoneID]
+	if tz == nil {
+		return nil, fmt.Errorf("Zone %s not found", zoneID)
+	}
+	return tz.zone, nil
+}
+
 func (c *designateMockClient) ForEachRecordSet(zoneID string, handler func(recordSet *recordsets.RecordSet) error) error {
 	return c.ForEachRecordSetFilterByTypeAndName(zoneID, "", "", handler)
 }
@@ -140,6 +148,7 @@ func (c *designateMockClient) CreateRecordSet(zoneID string, opts recordsets.Cre
 	}
 	rssub[opts.Type] = &rs
 	tz.id2rs[rs.ID] = &rs
+	tz.zone.Serial++
 	return rs.ID, nil
 }
 
@@ -156,12 +165,13 @@ func (c *designateMockClient) getRecordSet(zoneID, recordSetID string) (*testzon
 }
 
 func (c *designateMockClient) UpdateRecordSet(zoneID, recordSetID string, opts recordsets.UpdateOpts) error {
-	_, rs, err := c.getRecordSet(zoneID, recordSetID)
+	tz, rs, err := c.getRecordSet(zoneID, recordSetID)
 	if err != nil {
 		return err
 	}
 	rs.TTL = *opts.TTL
 	rs.Records = opts.Records
+	tz.zone.Serial++
 	return nil
 }
 
@@ -172,6 +182,7 @@ func (c *designateMockClient) DeleteRecordSet(zoneID, recordSetID string) error
 	}
 	delete(tz.id2rs, recordSetID)
 	delete(tz.rsmap[rs.Name], rs.Type)
+	tz.zone.Serial++
 	return nil
 }
 
@@ -199,7 +210,7 @@ func newMockHandler(mockZones ...*zones.Zone) *Handler {
 	}
 
 	cacheFactory := provider.NewTestZoneCacheFactory(60*time.Second, 0*time.Second)
-	cache, _ := cacheFactory.CreateZoneCache(provider.CacheZoneState, mockMetrics, h.getZones, h.getZoneState)
+	cache, _ := cacheFactory.CreateVersionedZoneCache(provider.CacheZoneState, mockMetrics, h.getZones, h.getZoneState, h.getZoneVersion)
 	h.cache = cache
 	h.config.Options = &provider.FactoryOptions{
 		GenericFactoryOptions: provider.GenericFactoryOptions{},
@@ -433,3 +444,29 @@ func TestGetZoneStateAndExecuteRequests(t *testing.T) {
 	Ω(actualDnssets2[sub4]).Should(Equal(expectedDnssets2[sub4]))
 	Ω(actualDnssets2).Should(Equal(expectedDnssets2))
 }
+
+func TestGetZoneStateSkipsUnchangedZone(t *testing.T) {
+	RegisterTestingT(t)
+	h := newPreparedMockHandler(t)
+
+	hostedZone, err := getDNSHostedZone(h, "z1")
+	Ω(err).Should(BeNil(), "Get Zone z1 failed")
+
+	_, err = h.client.CreateRecordSet("z1", recordsets.CreateOpts{Name: "sub1.z1.test.", TTL: 300, Type: "A", Records: []string{"1.2.3.4"}})
+	Ω(err).Should(BeNil())
+	zoneState, err := h.GetZoneState(hostedZone)
+	Ω(err).Should(BeNil())
+	Ω(len(zoneState.GetDNSSets())).Should(Equal(1))
+
+	// modification without incrementing the serial number is not seen
+	tz := hostedZone.(*testzone)
+	tz.rsmap["sub2.z1.test."] = map[string]*recordsets.RecordSet{"A": {ID: "rs-x", Name: "sub2.z1.test.", Type: "A", TTL: 300, Records: []string{"5.6.7.8"}}}
+	zoneState, err = h.GetZoneState(hostedZone)
+	Ω(err).Should(BeNil())
+	Ω(len(zoneState.GetDNSSets())).Should(Equal(1))
+
+	tz.zone.Serial++
+	zoneState, err = h.GetZoneState(hostedZone)
+	Ω(err).Should(BeNil())
+	Ω(len(zoneState.GetDNSSets())).Should(Equal(2))
+}
@@ -17,6 +17,8 @@
 package remote
 
 import (
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 )
@@ -24,9 +26,10 @@ import (
 const TYPE_CODE = "remote"
 
 var rateLimiterDefaults = provider.RateLimiterOptions{
-	Enabled: true,
-	QPS:     9,
-	Burst:   10,
+	Enabled:  true,
+	QPS:      9,
+	Burst:    10,
+	Adaptive: true,
 }
 
 var advancedDefaults = provider.AdvancedOptions{
@@ -36,7 +39,9 @@ var advancedDefaults = provider.AdvancedOptions{
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
 	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.
-		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults))
+		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults)).
+	SetSecretValidator(provider.RequiredSecretKeys([]string{"REMOTE_ENDPOINT", "remoteEndpoint"},
+		[]string{"CLIENT_CERT", corev1.TLSCertKey}, []string{"CLIENT_KEY", corev1.TLSPrivateKeyKey}, []string{"NAMESPACE", "namespace"}))
 
 func init() {
 	compound.MustRegister(Factory)
@@ -135,6 +135,7 @@ func (h *Handler) loadTLSCredentials(serverCA_PEM, clientCert_PEM, clientKey_PEM
 	config := &tls.Config{
 		Certificates: []tls.Certificate{clientCert},
 	}
+	h.config.Transport.ApplyTLSPolicy(config)
 
 	if len(serverCA_PEM) > 0 {
 		certPool := x509.NewCertPool()
@@ -185,7 +185,7 @@ func (r *reconciler) reconcile(logger logger.LogContext, obj resources.Object) e
 	}
 
 	if hasSecret && !cert.Status.Recreating {
-		return nil
+		return r.updateObservedGeneration(cert)
 	}
 
 	switch cert.Spec.Type {
@@ -249,6 +249,7 @@ func (r *reconciler) writeSecretAndStatus(cert *api.RemoteAccessCertificate, cda
 		_, _, err = r.certResources.ModifyStatus(cert, func(data resources.ObjectData) (bool, error) {
 			o := data.(*api.RemoteAccessCertificate)
 			mod := utils.ModificationState{}
+			mod.AssureInt64Value(&o.Status.ObservedGeneration, o.Generation)
 			mod.AssureStringValue(&o.Status.Message, err.Error())
 			return mod.IsModified(), nil
 		})
@@ -258,6 +259,7 @@ func (r *reconciler) writeSecretAndStatus(cert *api.RemoteAccessCertificate, cda
 	_, _, err = r.certResources.ModifyStatus(cert, func(data resources.ObjectData) (bool, error) {
 		o := data.(*api.RemoteAccessCertificate)
 		mod := utils.ModificationState{}
+		mod.AssureInt64Value(&o.Status.ObservedGeneration, o.Generation)
 		o.Status.NotAfter = &metav1.Time{Time: cdata.Certificate.NotAfter}
 		o.Status.NotBefore = &metav1.Time{Time: cdata.Certificate.NotBefore}
 		sn := cdata.Certificate.SerialNumber.String()
@@ -271,10 +273,24 @@ func (r *reconciler) writeSecretAndStatus(cert *api.RemoteAccessCertificate, cda
 	return err
 }
 
+func (r *reconciler) updateObservedGeneration(cert *api.RemoteAccessCertificate) error {
+	if cert.Status.ObservedGeneration == cert.Generation {
+		return nil
+	}
+	_, _, err := r.certResources.ModifyStatus(cert, func(data resources.ObjectData) (bool, error) {
+		o := data.(*api.RemoteAccessCertificate)
+		mod := utils.ModificationState{}
+		mod.AssureInt64Value(&o.Status.ObservedGeneration, o.Generation)
+		return mod.IsModified(), nil
+	})
+	return err
+}
+
 func (r *reconciler) resetForCertificatRecreation(certobj *api.RemoteAccessCertificate) error {
 	_, _, err := r.certResources.ModifyStatus(certobj, func(data resources.ObjectData) (bool, error) {
 		o := data.(*api.RemoteAccessCertificate)
 		mod := utils.ModificationState{}
+		mod.AssureInt64Value(&o.Status.ObservedGeneration, o.Generation)
 		mod.AssureStringValue(&o.Status.Message, "")
 		mod.AssureBoolValue(&o.Status.Recreating, true)
 		return mod.IsModified(), nil
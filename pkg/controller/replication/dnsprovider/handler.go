@@ -220,6 +220,7 @@ func (this *sourceReconciler) createEntryFor(logger logger.LogContext, obj resou
 
 	provider.Spec = *spec
 	provider.Spec.SecretRef = nil
+	provider.Spec.SecondarySecretRef = nil
 
 	if this.namespace == "" {
 		provider.Namespace = obj.GetNamespace()
@@ -382,6 +383,7 @@ func (this *sourceReconciler) updateSourceStatus(source *api.DNSProvider, source
 		mod := utils.ModificationState{}
 		mod.AssureStringPtrPtr(&s.Status.Message, &sourceMsg)
 		mod.AssureStringValue(&s.Status.State, api.STATE_ERROR)
+		mod.AssureInt64Value(&s.Status.ObservedGeneration, s.Generation)
 		return mod.IsModified(), nil
 	})
 	if err != nil {
@@ -99,6 +99,10 @@ func (this *slaveReconciler) Reconcile(logger logger.LogContext, obj resources.O
 				}
 				mod.AssureStringPtrPtr(&ownerStatus.Message, msg)
 				assureTimeValuePtrPtr(mod, &ownerStatus.LastUptimeTime, status.LastUptimeTime)
+				if status.ObservedGeneration == provider.GetGeneration() {
+					// slave status reflects the actual slave spec, which is replicated from the owner
+					mod.AssureInt64Value(&ownerStatus.ObservedGeneration, o.GetGeneration())
+				}
 				if mod.IsModified() {
 					err = o.UpdateStatus()
 					if err != nil {
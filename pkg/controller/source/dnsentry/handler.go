@@ -66,7 +66,10 @@ func (this *DNSEntrySource) GetDNSInfo(logger logger.LogContext, obj resources.O
 		OrigRef:       data.Spec.Reference,
 		TTL:           data.Spec.TTL,
 		Interval:      data.Spec.CNameLookupInterval,
+		Resolve:       data.Spec.ResolveTargetsToAddresses,
 		RoutingPolicy: data.Spec.RoutingPolicy,
+		Provider:      data.Spec.Provider,
+		Zone:          data.Spec.Zone,
 	}
 	return info, nil
 }
@@ -18,6 +18,7 @@ package ingress
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
 	"github.com/gardener/controller-manager-library/pkg/logger"
@@ -29,6 +30,15 @@ import (
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 )
 
+// EXCLUDE_HOSTS_ANNOTATION lists hosts of ingress rules to be excluded from DNS management
+const EXCLUDE_HOSTS_ANNOTATION = dns.ANNOTATION_GROUP + "/exclude-hosts"
+
+// EXCLUDE_RULES_ANNOTATION lists indices of ingress rules (starting with 0) to be excluded from DNS management
+const EXCLUDE_RULES_ANNOTATION = dns.ANNOTATION_GROUP + "/exclude-rules"
+
+// INCLUDE_RULES_ANNOTATION lists indices of ingress rules (starting with 0) to be used exclusively for DNS management
+const INCLUDE_RULES_ANNOTATION = dns.ANNOTATION_GROUP + "/include-rules"
+
 type IngressSource struct {
 	source.DefaultDNSSource
 }
@@ -38,11 +48,15 @@ func NewIngressSource(controller.Interface) (source.DNSSource, error) {
 }
 
 func (this *IngressSource) GetDNSInfo(logger logger.LogContext, obj resources.Object, current *source.DNSCurrentState) (*source.DNSInfo, error) {
-	info := &source.DNSInfo{Targets: this.GetTargets(obj)}
+	info := &source.DNSInfo{Targets: this.GetTargets(obj, current.PreferInternalAddresses)}
 	hosts, err := this.extractRuleHosts(obj)
 	if err != nil {
 		return nil, err
 	}
+	hosts, err = filterRuleHosts(obj.GetAnnotations(), hosts)
+	if err != nil {
+		return nil, err
+	}
 	names := utils.StringSet{}
 	all := current.AnnotatedNames.Contains("all") || current.AnnotatedNames.Contains("*")
 	for _, host := range hosts {
@@ -78,30 +92,53 @@ func (this *IngressSource) extractRuleHosts(obj resources.Object) ([]string, err
 	}
 }
 
-func (this *IngressSource) GetTargets(obj resources.Object) utils.StringSet {
-	set := utils.StringSet{}
+func (this *IngressSource) GetTargets(obj resources.Object, preferInternal bool) utils.StringSet {
 	switch data := obj.Data().(type) {
 	case *networkingv1beta1.Ingress:
-		for _, i := range data.Status.LoadBalancer.Ingress {
-			if i.Hostname != "" && i.IP == "" {
-				set.Add(i.Hostname)
-			} else {
-				if i.IP != "" {
-					set.Add(i.IP)
-				}
-			}
-		}
+		return source.LoadBalancerTargets(data.Status.LoadBalancer.Ingress, preferInternal)
 	case *networkingv1.Ingress:
-		for _, i := range data.Status.LoadBalancer.Ingress {
-			if i.Hostname != "" && i.IP == "" {
-				set.Add(i.Hostname)
-			} else {
-				if i.IP != "" {
-					set.Add(i.IP)
-				}
-			}
-		}
+		return source.LoadBalancerTargets(data.Status.LoadBalancer.Ingress, preferInternal)
 	default:
+		return utils.StringSet{}
+	}
+}
+
+// filterRuleHosts removes the hosts of rules excluded by annotations.
+// Excluded hosts are replaced by an empty string to keep the rule indices.
+func filterRuleHosts(annos map[string]string, hosts []string) ([]string, error) {
+	excludedHosts := utils.StringSet{}
+	excludedHosts.AddAllSplittedSelected(annos[EXCLUDE_HOSTS_ANNOTATION], utils.StandardNonEmptyStringElement)
+	excludedRules, err := parseRuleIndices(annos, EXCLUDE_RULES_ANNOTATION, len(hosts))
+	if err != nil {
+		return nil, err
+	}
+	includedRules, err := parseRuleIndices(annos, INCLUDE_RULES_ANNOTATION, len(hosts))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(hosts))
+	for i, host := range hosts {
+		if excludedHosts.Contains(host) || excludedRules[i] || (includedRules != nil && !includedRules[i]) {
+			continue
+		}
+		result[i] = host
+	}
+	return result, nil
+}
+
+func parseRuleIndices(annos map[string]string, annotation string, count int) (map[int]bool, error) {
+	value, ok := annos[annotation]
+	if !ok {
+		return nil, nil
+	}
+	indices := map[int]bool{}
+	for s := range utils.NewStringSet().AddAllSplittedSelected(value, utils.StandardNonEmptyStringElement) {
+		i, err := strconv.Atoi(s)
+		if err != nil || i < 0 || i >= count {
+			return nil, fmt.Errorf("invalid rule index %q in annotation %s (ingress has %d rules)", s, annotation, count)
+		}
+		indices[i] = true
 	}
-	return set
+	return indices, nil
 }
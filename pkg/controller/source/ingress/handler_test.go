@@ -0,0 +1,98 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package ingress
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRuleIndices(t *testing.T) {
+	table := []struct {
+		value    *string
+		expected map[int]bool
+		err      bool
+	}{
+		{nil, nil, false},
+		{ptr(""), map[int]bool{}, false},
+		{ptr("0"), map[int]bool{0: true}, false},
+		{ptr("0,2"), map[int]bool{0: true, 2: true}, false},
+		{ptr(" 2 , 0 "), map[int]bool{0: true, 2: true}, false},
+		{ptr("1,1"), map[int]bool{1: true}, false},
+		{ptr("0,,1"), map[int]bool{0: true, 1: true}, false},
+		{ptr("3"), nil, true},
+		{ptr("-1"), nil, true},
+		{ptr("a"), nil, true},
+		{ptr("1.5"), nil, true},
+		{ptr("0;1"), nil, true},
+	}
+	for _, entry := range table {
+		annos := map[string]string{}
+		if entry.value != nil {
+			annos[INCLUDE_RULES_ANNOTATION] = *entry.value
+		}
+		indices, err := parseRuleIndices(annos, INCLUDE_RULES_ANNOTATION, 3)
+		if entry.err {
+			if err == nil {
+				t.Errorf("%q: expected error, got %v", *entry.value, indices)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %s", entry.value, err)
+		} else if !reflect.DeepEqual(indices, entry.expected) {
+			t.Errorf("%v: expected %v, got %v", entry.value, entry.expected, indices)
+		}
+	}
+}
+
+func TestFilterRuleHosts(t *testing.T) {
+	hosts := []string{"a.example.com", "b.example.com", "c.example.com"}
+	table := []struct {
+		annos    map[string]string
+		expected []string
+		err      bool
+	}{
+		{nil, []string{"a.example.com", "b.example.com", "c.example.com"}, false},
+		{map[string]string{EXCLUDE_HOSTS_ANNOTATION: "b.example.com, x.example.com"}, []string{"a.example.com", "", "c.example.com"}, false},
+		{map[string]string{EXCLUDE_RULES_ANNOTATION: "0,2"}, []string{"", "b.example.com", ""}, false},
+		{map[string]string{INCLUDE_RULES_ANNOTATION: "1,1"}, []string{"", "b.example.com", ""}, false},
+		{map[string]string{INCLUDE_RULES_ANNOTATION: "0,1", EXCLUDE_RULES_ANNOTATION: "1"}, []string{"a.example.com", "", ""}, false},
+		{map[string]string{INCLUDE_RULES_ANNOTATION: "0,1", EXCLUDE_HOSTS_ANNOTATION: "a.example.com"}, []string{"", "b.example.com", ""}, false},
+		{map[string]string{INCLUDE_RULES_ANNOTATION: ""}, []string{"", "", ""}, false},
+		{map[string]string{EXCLUDE_RULES_ANNOTATION: "3"}, nil, true},
+		{map[string]string{INCLUDE_RULES_ANNOTATION: "first"}, nil, true},
+	}
+	for _, entry := range table {
+		result, err := filterRuleHosts(entry.annos, hosts)
+		if entry.err {
+			if err == nil {
+				t.Errorf("%v: expected error, got %v", entry.annos, result)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %s", entry.annos, err)
+		} else if !reflect.DeepEqual(result, entry.expected) {
+			t.Errorf("%v: expected %q, got %q", entry.annos, entry.expected, result)
+		}
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
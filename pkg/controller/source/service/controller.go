@@ -17,14 +17,32 @@
 package service
 
 import (
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/watches"
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/external-dns-management/pkg/dns/source"
 )
 
 var _MAIN_RESOURCE = resources.NewGroupKind("core", "Service")
 
+var _NODE_RESOURCE = resources.NewGroupKind("core", "Node")
+
 func init() {
-	source.DNSSourceController(source.NewDNSSouceTypeForExtractor("service-dns", _MAIN_RESOURCE, GetTargets), nil).
+	cfg := source.DNSSourceController(source.NewDNSSouceTypeForCreator("service-dns", _MAIN_RESOURCE, NewServiceSource), nil).
 		FinalizerDomain("dns.gardener.cloud").
-		MustRegister(source.CONTROLLER_GROUP_DNS_SOURCES)
+		Cluster(cluster.DEFAULT).
+		WorkerPool("nodes", 1, 0).
+		Reconciler(NodeReconciler, "nodes").
+		ReconcilerWatch("nodes", _NODE_RESOURCE.Group, _NODE_RESOURCE.Kind)
+	// services of additional source clusters use the nodes of their own cluster
+	for _, name := range source.SourceClusters {
+		cfg = cfg.Cluster(name).
+			FlavoredReconcilerWatch("nodes",
+				watches.Conditional(
+					source.IsAdditionalSourceCluster(),
+					watches.ResourceFlavorByGK(_NODE_RESOURCE),
+				),
+			)
+	}
+	cfg.MustRegister(source.CONTROLLER_GROUP_DNS_SOURCES)
 }
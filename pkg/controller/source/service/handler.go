@@ -19,13 +19,76 @@ package service
 import (
 	"fmt"
 
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/source"
 	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
+type ServiceSource struct {
+	source.DefaultDNSSource
+	// nodeIPs provides the internal IPs of the nodes of the cluster of a service
+	nodeIPs func(logger logger.LogContext, resources resources.Resources) (utils.StringSet, error)
+}
+
+func NewServiceSource(controller.Interface) (source.DNSSource, error) {
+	return &ServiceSource{
+		DefaultDNSSource: source.NewDefaultDNSSource(nil),
+		nodeIPs:          getNodeInternalIPs,
+	}, nil
+}
+
+func (this *ServiceSource) GetDNSInfo(logger logger.LogContext, obj resources.Object, current *source.DNSCurrentState) (*source.DNSInfo, error) {
+	info := &source.DNSInfo{}
+	info.Names = dns.NewDNSNameSetFromStringSet(current.AnnotatedNames, current.GetSetIdentifier())
+	tgts, err := this.getTargets(logger, obj.GetCluster().Resources(), obj.Data().(*api.Service), info.Names, current.PreferInternalAddresses)
+	info.Targets = tgts
+	return info, err
+}
+
+func (this *ServiceSource) getTargets(logger logger.LogContext, resources resources.Resources, svc *api.Service, names dns.DNSNameSet, preferInternal bool) (utils.StringSet, error) {
+	if preferInternal {
+		if set := source.InternalLoadBalancerTargets(svc.Status.LoadBalancer.Ingress); len(set) > 0 {
+			return set, nil
+		}
+		// services of type NodePort are only reachable with the addresses of the nodes,
+		// load balancers without internal addresses keep their ingress addresses
+		if svc.Spec.Type == api.ServiceTypeNodePort {
+			return this.nodeIPs(logger, resources)
+		}
+	}
+	if svc.Spec.Type != api.ServiceTypeLoadBalancer {
+		if len(names) == 0 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("service is not of type LoadBalancer")
+	}
+	return source.LoadBalancerTargets(svc.Status.LoadBalancer.Ingress, false), nil
+}
+
+func getNodeInternalIPs(logger logger.LogContext, resources resources.Resources) (utils.StringSet, error) {
+	res, err := resources.GetByExample(&api.Node{})
+	if err != nil {
+		return nil, err
+	}
+	list, err := res.ListCached(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("cannot list nodes: %w", err)
+	}
+	nodes := make([]*api.Node, 0, len(list))
+	for _, o := range list {
+		nodes = append(nodes, o.Data().(*api.Node))
+	}
+	set := source.NodeInternalIPs(nodes...)
+	logger.Debugf("using internal IPs of %d nodes: %s", len(nodes), set)
+	return set, nil
+}
+
+// GetTargets extracts the targets from the load balancer status of a service.
 func GetTargets(logger logger.LogContext, obj resources.Object, names dns.DNSNameSet) (utils.StringSet, utils.StringSet, error) {
 	svc := obj.Data().(*api.Service)
 	if svc.Spec.Type != api.ServiceTypeLoadBalancer {
@@ -34,15 +97,5 @@ func GetTargets(logger logger.LogContext, obj resources.Object, names dns.DNSNam
 		}
 		return nil, nil, fmt.Errorf("service is not of type LoadBalancer")
 	}
-	set := utils.StringSet{}
-	for _, i := range svc.Status.LoadBalancer.Ingress {
-		if i.Hostname != "" && i.IP == "" {
-			set.Add(i.Hostname)
-		} else {
-			if i.IP != "" {
-				set.Add(i.IP)
-			}
-		}
-	}
-	return set, nil, nil
+	return source.LoadBalancerTargets(svc.Status.LoadBalancer.Ingress, false), nil, nil
 }
@@ -0,0 +1,160 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package service
+
+import (
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	. "github.com/onsi/gomega"
+	api "k8s.io/api/core/v1"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+)
+
+func testServiceSource(nodeIPs ...string) (*ServiceSource, *int) {
+	calls := 0
+	return &ServiceSource{nodeIPs: func(logger logger.LogContext, _ resources.Resources) (utils.StringSet, error) {
+		calls++
+		return utils.NewStringSet(nodeIPs...), nil
+	}}, &calls
+}
+
+func testService(typ api.ServiceType, ingresses ...api.LoadBalancerIngress) *api.Service {
+	svc := &api.Service{}
+	svc.Spec.Type = typ
+	svc.Status.LoadBalancer.Ingress = ingresses
+	return svc
+}
+
+func TestGetTargets(t *testing.T) {
+	RegisterTestingT(t)
+
+	log := logger.New()
+	names := dns.NewDNSNameSetFromStringSet(utils.NewStringSet("svc.example.com"), "")
+	this, calls := testServiceSource("10.250.0.1", "10.250.0.2")
+
+	external := api.LoadBalancerIngress{IP: "1.2.3.4"}
+	internal := api.LoadBalancerIngress{IP: "10.0.0.5"}
+
+	targets, err := this.getTargets(log, nil, testService(api.ServiceTypeLoadBalancer, external, internal), names, false)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(targets).Should(Equal(utils.NewStringSet("1.2.3.4", "10.0.0.5")))
+
+	targets, err = this.getTargets(log, nil, testService(api.ServiceTypeLoadBalancer, external, internal), names, true)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(targets).Should(Equal(utils.NewStringSet("10.0.0.5")))
+
+	// load balancers without internal addresses keep their external ones
+	targets, err = this.getTargets(log, nil, testService(api.ServiceTypeLoadBalancer, external), names, true)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(targets).Should(Equal(utils.NewStringSet("1.2.3.4")))
+	Ω(*calls).Should(Equal(0))
+
+	targets, err = this.getTargets(log, nil, testService(api.ServiceTypeNodePort), names, true)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(targets).Should(Equal(utils.NewStringSet("10.250.0.1", "10.250.0.2")))
+	Ω(*calls).Should(Equal(1))
+
+	_, err = this.getTargets(log, nil, testService(api.ServiceTypeNodePort), names, false)
+	Ω(err).Should(MatchError("service is not of type LoadBalancer"))
+	_, err = this.getTargets(log, nil, testService(api.ServiceTypeClusterIP), names, true)
+	Ω(err).Should(MatchError("service is not of type LoadBalancer"))
+	Ω(*calls).Should(Equal(1))
+
+	targets, err = this.getTargets(log, nil, testService(api.ServiceTypeClusterIP), nil, true)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(targets).Should(BeEmpty())
+}
+
+// resourcesInterface avoids a conflict of the embedded field with the method Resources()
+type resourcesInterface = resources.Resources
+
+type testResources struct {
+	resourcesInterface
+	name string
+}
+
+type testCluster struct {
+	resources.Cluster
+	resources *testResources
+}
+
+func (c *testCluster) Resources() resources.Resources {
+	return c.resources
+}
+
+type testServiceObject struct {
+	resources.Object
+	cluster *testCluster
+	svc     *api.Service
+}
+
+func (o *testServiceObject) GetCluster() resources.Cluster {
+	return o.cluster
+}
+
+func (o *testServiceObject) Data() resources.ObjectData {
+	return o.svc
+}
+
+func TestGetDNSInfoUsesNodesOfServiceCluster(t *testing.T) {
+	RegisterTestingT(t)
+
+	clusterIPs := map[string][]string{
+		"default": {"10.250.0.1"},
+		"source1": {"10.251.0.1", "10.251.0.2"},
+	}
+	this := &ServiceSource{nodeIPs: func(logger logger.LogContext, res resources.Resources) (utils.StringSet, error) {
+		return utils.NewStringSet(clusterIPs[res.(*testResources).name]...), nil
+	}}
+	current := &source.DNSCurrentState{AnnotatedNames: utils.NewStringSet("svc.example.com"), PreferInternalAddresses: true}
+	for name, ips := range clusterIPs {
+		obj := &testServiceObject{
+			cluster: &testCluster{resources: &testResources{name: name}},
+			svc:     testService(api.ServiceTypeNodePort),
+		}
+		info, err := this.GetDNSInfo(logger.New(), obj, current)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(info.Targets).Should(Equal(utils.NewStringSet(ips...)))
+	}
+}
+
+func TestNodeReconcilerUpdate(t *testing.T) {
+	RegisterTestingT(t)
+
+	gk := resources.NewGroupKind("core", "Node")
+	node1 := resources.NewClusterKey("default", gk, "", "node1")
+	node2 := resources.NewClusterKey("default", gk, "", "node2")
+	other1 := resources.NewClusterKey("source1", gk, "", "node1")
+
+	r := &nodeReconciler{addresses: map[resources.ClusterObjectKey]utils.StringSet{}}
+	Ω(r.update(node1, utils.NewStringSet("10.250.0.1"))).Should(BeTrue())
+	Ω(r.update(node1, utils.NewStringSet("10.250.0.1"))).Should(BeFalse())
+	Ω(r.update(node2, utils.NewStringSet())).Should(BeFalse())
+	Ω(r.update(node1, utils.NewStringSet("10.250.0.1", "fd00::1"))).Should(BeTrue())
+	// nodes with the same name in different clusters are tracked separately
+	Ω(r.update(other1, utils.NewStringSet("10.250.0.1", "fd00::1"))).Should(BeTrue())
+	Ω(r.update(node2, nil)).Should(BeFalse())
+	Ω(r.update(node1, nil)).Should(BeTrue())
+	Ω(r.addresses).Should(HaveLen(1))
+	Ω(r.update(other1, nil)).Should(BeTrue())
+	Ω(r.addresses).Should(BeEmpty())
+}
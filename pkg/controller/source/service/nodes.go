@@ -0,0 +1,104 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package service
+
+import (
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+)
+
+// NodeReconciler tracks the internal IPs of the nodes of the source clusters and triggers
+// the services of type NodePort of the same cluster, whose targets may fall back to these addresses.
+func NodeReconciler(c controller.Interface) (reconcile.Interface, error) {
+	return &nodeReconciler{controller: c, addresses: map[resources.ClusterObjectKey]utils.StringSet{}}, nil
+}
+
+var _ reconcile.Interface = &nodeReconciler{}
+
+type nodeReconciler struct {
+	reconcile.DefaultReconciler
+
+	lock       sync.Mutex
+	controller controller.Interface
+	addresses  map[resources.ClusterObjectKey]utils.StringSet
+}
+
+func (r *nodeReconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	if r.update(obj.ClusterKey(), source.NodeInternalIPs(obj.Data().(*api.Node))) {
+		r.triggerServices(logger, obj.GetCluster().GetId())
+	}
+	return reconcile.Succeeded(logger)
+}
+
+func (r *nodeReconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	if r.update(key, nil) {
+		r.triggerServices(logger, key.Cluster())
+	}
+	return reconcile.Succeeded(logger)
+}
+
+// update records the internal IPs of a node (nil for deleted nodes) and
+// reports whether the set of addresses has changed.
+func (r *nodeReconciler) update(key resources.ClusterObjectKey, ips utils.StringSet) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	old := r.addresses[key]
+	if len(ips) == 0 {
+		delete(r.addresses, key)
+	} else {
+		r.addresses[key] = ips
+	}
+	return !old.Equals(ips)
+}
+
+// triggerServices triggers the services of type NodePort of the cluster with the given id.
+func (r *nodeReconciler) triggerServices(logger logger.LogContext, clusterId string) {
+	for _, cluster := range source.GetSourceClusters(r.controller) {
+		if cluster.GetId() != clusterId {
+			continue
+		}
+		res, err := cluster.Resources().GetByExample(&api.Service{})
+		if err != nil {
+			logger.Warnf("cannot get resource services: %s", err)
+			continue
+		}
+		list, err := res.ListCached(labels.Everything())
+		if err != nil {
+			logger.Warnf("cannot list services: %s", err)
+			continue
+		}
+		count := 0
+		for _, o := range list {
+			if o.Data().(*api.Service).Spec.Type == api.ServiceTypeNodePort {
+				r.controller.EnqueueKey(o.ClusterKey())
+				count++
+			}
+		}
+		if count > 0 {
+			logger.Infof("internal IPs of nodes changed: trigger %d service(s) of type NodePort in cluster %s", count, cluster.GetName())
+		}
+	}
+}
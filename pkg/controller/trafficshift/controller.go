@@ -0,0 +1,453 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package trafficshift
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources/apiextensions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/crds"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+const CONTROLLER = "dnstrafficshift"
+
+// MAX_WEIGHT is the sum of the weights of both entries of a traffic shift
+const MAX_WEIGHT = 100
+
+// DEFAULT_STEP_WEIGHT is the default percentage of the traffic shifted with every step
+const DEFAULT_STEP_WEIGHT = 10
+
+// DEFAULT_INTERVAL is the default interval between two steps
+const DEFAULT_INTERVAL = 10 * time.Minute
+
+// DEFAULT_HEALTH_CHECK_TIMEOUT is the default timeout of a single health check
+const DEFAULT_HEALTH_CHECK_TIMEOUT = 10 * time.Second
+
+// DEFAULT_HEALTH_CHECK_PERIOD is the default period of the health checks during a shift
+const DEFAULT_HEALTH_CHECK_PERIOD = time.Minute
+
+// DEFAULT_HEALTH_CHECK_FAILURE_THRESHOLD is the default number of consecutive failed
+// health checks aborting a shift
+const DEFAULT_HEALTH_CHECK_FAILURE_THRESHOLD = 3
+
+const OPT_HEALTH_CHECK_HOSTS = "health-check-allowed-hosts"
+const OPT_HEALTH_CHECK_DENIED_CIDRS = "health-check-denied-cidrs"
+
+var shiftGroupKind = resources.NewGroupKind(api.GroupName, api.DNSTrafficShiftKind)
+var entryGroupKind = resources.NewGroupKind(api.GroupName, api.DNSEntryKind)
+
+func init() {
+	crds.AddToRegistry(apiextensions.DefaultRegistry())
+
+	controller.Configure(CONTROLLER).
+		Reconciler(Create).
+		DefaultWorkerPool(2, 10*time.Minute).
+		CustomResourceDefinitions(shiftGroupKind, entryGroupKind).
+		MainResource(api.GroupName, api.DNSTrafficShiftKind).
+		Watches(
+			controller.NewResourceKey(api.GroupName, api.DNSEntryKind),
+		).
+		StringArrayOption(OPT_HEALTH_CHECK_HOSTS, "hosts allowed as health check targets of traffic shifts (`*.<domain>` allows all subdomains, health checks are rejected if not set)").
+		StringArrayOption(OPT_HEALTH_CHECK_DENIED_CIDRS, "CIDRs denied as health check targets in addition to the loopback, link-local and private networks (e.g. the pod and service networks of the cluster)").
+		ActivateExplicitly().
+		MustRegister()
+}
+
+type reconciler struct {
+	reconcile.DefaultReconciler
+	controller controller.Interface
+	shifts     resources.Interface
+	entries    resources.Interface
+	checker    HealthChecker
+}
+
+var _ reconcile.Interface = &reconciler{}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func Create(controller controller.Interface) (reconcile.Interface, error) {
+	shifts, err := controller.GetMainCluster().Resources().GetByExample(&api.DNSTrafficShift{})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := controller.GetMainCluster().Resources().GetByExample(&api.DNSEntry{})
+	if err != nil {
+		return nil, err
+	}
+	hosts, _ := controller.GetStringArrayOption(OPT_HEALTH_CHECK_HOSTS)
+	cidrs, _ := controller.GetStringArrayOption(OPT_HEALTH_CHECK_DENIED_CIDRS)
+	checker, err := NewHTTPHealthChecker(hosts, cidrs)
+	if err != nil {
+		return nil, err
+	}
+	return &reconciler{
+		controller: controller,
+		shifts:     shifts,
+		entries:    entries,
+		checker:    checker,
+	}, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	switch data := obj.Data().(type) {
+	case *api.DNSTrafficShift:
+		if obj.IsDeleting() {
+			return reconcile.Succeeded(logger)
+		}
+		delay, err := this.reconcileShift(logger, obj, data)
+		if err != nil {
+			return reconcile.Delay(logger, err)
+		}
+		if delay > 0 {
+			return reconcile.Succeeded(logger).RescheduleAfter(delay)
+		}
+	case *api.DNSEntry:
+		return this.enqueueReferencingShifts(logger, obj.ClusterKey())
+	}
+	return reconcile.Succeeded(logger)
+}
+
+func (this *reconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	if key.GroupKind() != entryGroupKind {
+		return reconcile.Succeeded(logger)
+	}
+	return this.enqueueReferencingShifts(logger, key)
+}
+
+// enqueueReferencingShifts enqueues all traffic shifts in the namespace of the
+// given DNS entry shifting traffic from or to it.
+func (this *reconciler) enqueueReferencingShifts(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	shifts, err := this.shifts.Namespace(key.Namespace()).ListCached(labels.Everything())
+	if err != nil {
+		return reconcile.Delay(logger, err)
+	}
+	for _, obj := range shifts {
+		shift := obj.Data().(*api.DNSTrafficShift)
+		if shift.Spec.From == key.Name() || shift.Spec.To == key.Name() {
+			this.controller.EnqueueKey(obj.ClusterKey())
+		}
+	}
+	return reconcile.Succeeded(logger)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// reconcileShift performs the next step of a traffic shift if it is due and
+// returns the delay until the shift must be reconciled again.
+func (this *reconciler) reconcileShift(logger logger.LogContext, obj resources.Object, shift *api.DNSTrafficShift) (time.Duration, error) {
+	status := shift.Status.DeepCopy()
+	if status.ObservedGeneration == shift.Generation && (status.State == api.STATE_COMPLETED || status.State == api.STATE_ABORTED) {
+		return 0, nil
+	}
+	if status.ObservedGeneration != shift.Generation {
+		// a changed spec restarts the shift
+		status.Weight = 0
+		status.LastStep = nil
+		status.HealthCheckFailures = 0
+	}
+
+	settings, err := GetSettings(shift)
+	if err == nil && settings.HealthCheck != nil {
+		err = this.checker.Allowed(settings.HealthCheck)
+	}
+	if err != nil {
+		return 0, this.updateStatus(logger, obj, withState(status, api.STATE_INVALID, err.Error()))
+	}
+	from, err := this.getEntry(shift.Namespace, shift.Spec.From)
+	if err != nil {
+		return 0, this.entryError(logger, obj, status, shift.Spec.From, err)
+	}
+	to, err := this.getEntry(shift.Namespace, shift.Spec.To)
+	if err != nil {
+		return 0, this.entryError(logger, obj, status, shift.Spec.To, err)
+	}
+	if err := ValidateEntries(from.Data().(*api.DNSEntry), to.Data().(*api.DNSEntry)); err != nil {
+		return 0, this.updateStatus(logger, obj, withState(status, api.STATE_INVALID, err.Error()))
+	}
+
+	target := to.Data().(*api.DNSEntry)
+	if target.Status.State == api.STATE_ERROR || target.Status.State == api.STATE_INVALID {
+		return 0, this.abort(logger, obj, status, from, to, fmt.Sprintf("entry %s is %s", target.Name, target.Status.State))
+	}
+	if settings.HealthCheck != nil {
+		if err := this.checker.Check(settings); err != nil {
+			if HealthCheckFailed(status, settings) {
+				return 0, this.abort(logger, obj, status, from, to, fmt.Sprintf("health check failed %d times: %s", status.HealthCheckFailures, err))
+			}
+			// no step is performed until the health check succeeds again
+			msg := fmt.Sprintf("health check failed (%d/%d): %s", status.HealthCheckFailures, settings.FailureThreshold, err)
+			return settings.Period, this.updateStatus(logger, obj, withState(status, api.STATE_SHIFTING, msg))
+		}
+		status.HealthCheckFailures = 0
+	}
+
+	weight, due, delay := NextStep(status, settings, time.Now())
+	if due {
+		if target.Status.State != api.STATE_READY {
+			// the shift is enqueued again once the entry has been changed
+			msg := fmt.Sprintf("waiting for entry %s to become ready", target.Name)
+			return settings.recheck(0), this.updateStatus(logger, obj, withState(status, api.STATE_SHIFTING, msg))
+		}
+		if err := this.applyWeights(logger, from, to, weight); err != nil {
+			return 0, err
+		}
+		now := metav1.Now()
+		status.Weight = weight
+		status.LastStep = &now
+		obj.Eventf(corev1.EventTypeNormal, "shift", "shifted %d%% of the traffic to entry %s", weight, target.Name)
+		if weight >= MAX_WEIGHT {
+			msg := fmt.Sprintf("all traffic shifted to entry %s", target.Name)
+			return 0, this.updateStatus(logger, obj, withState(status, api.STATE_COMPLETED, msg))
+		}
+		delay = settings.Interval
+	}
+	msg := fmt.Sprintf("%d%% of the traffic shifted to entry %s", status.Weight, target.Name)
+	return settings.recheck(delay), this.updateStatus(logger, obj, withState(status, api.STATE_SHIFTING, msg))
+}
+
+func (this *reconciler) getEntry(namespace, name string) (resources.Object, error) {
+	return this.entries.Namespace(namespace).GetCached(name)
+}
+
+func (this *reconciler) entryError(logger logger.LogContext, obj resources.Object, status *api.DNSTrafficShiftStatus, name string, err error) error {
+	msg := fmt.Sprintf("cannot get entry %s: %s", name, err)
+	if err2 := this.updateStatus(logger, obj, withState(status, api.STATE_ERROR, msg)); err2 != nil {
+		return err2
+	}
+	if errors.IsNotFound(err) {
+		// the shift is enqueued again once the entry is created
+		return nil
+	}
+	return err
+}
+
+// abort shifts all traffic back to the original entry.
+func (this *reconciler) abort(logger logger.LogContext, obj resources.Object, status *api.DNSTrafficShiftStatus, from, to resources.Object, reason string) error {
+	logger.Warnf("aborting traffic shift: %s", reason)
+	if err := this.applyWeights(logger, from, to, 0); err != nil {
+		return err
+	}
+	obj.Eventf(corev1.EventTypeWarning, "abort", "traffic shifted back to entry %s: %s", from.GetName(), reason)
+	status.Weight = 0
+	return this.updateStatus(logger, obj, withState(status, api.STATE_ABORTED, reason))
+}
+
+// applyWeights sets the weight of the target entry and the complementary
+// weight of the original entry.
+func (this *reconciler) applyWeights(logger logger.LogContext, from, to resources.Object, weight int64) error {
+	for _, e := range []struct {
+		obj    resources.Object
+		weight int64
+	}{{to, weight}, {from, MAX_WEIGHT - weight}} {
+		mod, err := e.obj.Modify(func(data resources.ObjectData) (bool, error) {
+			return SetWeight(data.(*api.DNSEntry), e.weight), nil
+		})
+		if err != nil {
+			return fmt.Errorf("cannot update weight of entry %s: %w", e.obj.GetName(), err)
+		}
+		if mod {
+			logger.Infof("set weight of entry %s to %d", e.obj.GetName(), e.weight)
+		}
+	}
+	return nil
+}
+
+func (this *reconciler) updateStatus(logger logger.LogContext, obj resources.Object, desired *api.DNSTrafficShiftStatus) error {
+	_, err := obj.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSTrafficShift).Status
+		desired.ObservedGeneration = data.GetGeneration()
+		desired.Conditions = append([]metav1.Condition(nil), status.Conditions...)
+		dnsutils.SetStateConditions(&desired.Conditions, desired.ObservedGeneration, desired.State, desired.Message)
+		if reflect.DeepEqual(status, desired) {
+			return false, nil
+		}
+		if status.State != desired.State || status.Message == nil || *status.Message != *desired.Message {
+			logger.Infof("update state to %s: %s", desired.State, *desired.Message)
+		}
+		*status = *desired
+		return true, nil
+	})
+	return err
+}
+
+func withState(status *api.DNSTrafficShiftStatus, state, msg string) *api.DNSTrafficShiftStatus {
+	status.State = state
+	status.Message = &msg
+	return status
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Settings are the effective settings of a traffic shift.
+type Settings struct {
+	StepWeight       int64
+	Interval         time.Duration
+	HealthCheck      *url.URL
+	Timeout          time.Duration
+	Period           time.Duration
+	FailureThreshold int64
+}
+
+// recheck returns the delay until the next reconciliation, which is limited
+// by the health check period.
+func (this *Settings) recheck(delay time.Duration) time.Duration {
+	if this.HealthCheck != nil && (delay <= 0 || this.Period < delay) {
+		return this.Period
+	}
+	return delay
+}
+
+// GetSettings validates the spec of a traffic shift and returns the settings
+// completed by the defaults.
+func GetSettings(shift *api.DNSTrafficShift) (*Settings, error) {
+	spec := &shift.Spec
+	if spec.From == "" || spec.To == "" {
+		return nil, fmt.Errorf("from and to entries must be set")
+	}
+	if spec.From == spec.To {
+		return nil, fmt.Errorf("from and to entries must be different")
+	}
+	settings := &Settings{
+		StepWeight:       DEFAULT_STEP_WEIGHT,
+		Interval:         DEFAULT_INTERVAL,
+		Timeout:          DEFAULT_HEALTH_CHECK_TIMEOUT,
+		Period:           DEFAULT_HEALTH_CHECK_PERIOD,
+		FailureThreshold: DEFAULT_HEALTH_CHECK_FAILURE_THRESHOLD,
+	}
+	if spec.StepWeight != nil {
+		if *spec.StepWeight < 1 || *spec.StepWeight > MAX_WEIGHT {
+			return nil, fmt.Errorf("stepWeight must be between 1 and %d", MAX_WEIGHT)
+		}
+		settings.StepWeight = *spec.StepWeight
+	}
+	if spec.Interval != nil {
+		if spec.Interval.Duration <= 0 {
+			return nil, fmt.Errorf("interval must be positive")
+		}
+		settings.Interval = spec.Interval.Duration
+	}
+	if hc := spec.HealthCheck; hc != nil {
+		u, err := url.Parse(hc.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid health check URL %q", hc.URL)
+		}
+		settings.HealthCheck = u
+		if hc.Timeout != nil {
+			if hc.Timeout.Duration <= 0 {
+				return nil, fmt.Errorf("health check timeout must be positive")
+			}
+			settings.Timeout = hc.Timeout.Duration
+		}
+		if hc.Period != nil {
+			if hc.Period.Duration <= 0 {
+				return nil, fmt.Errorf("health check period must be positive")
+			}
+			settings.Period = hc.Period.Duration
+		}
+		if hc.FailureThreshold != nil {
+			if *hc.FailureThreshold < 1 {
+				return nil, fmt.Errorf("health check failure threshold must be positive")
+			}
+			settings.FailureThreshold = *hc.FailureThreshold
+		}
+	}
+	return settings, nil
+}
+
+// ValidateEntries checks that both entries use a weighted routing policy for
+// the same DNS name.
+func ValidateEntries(from, to *api.DNSEntry) error {
+	for _, e := range []*api.DNSEntry{from, to} {
+		if _, err := Weight(e); err != nil {
+			return err
+		}
+	}
+	if from.Spec.DNSName != to.Spec.DNSName {
+		return fmt.Errorf("entries %s and %s have different DNS names", from.Name, to.Name)
+	}
+	return nil
+}
+
+// Weight returns the weight of an entry with weighted routing policy.
+func Weight(entry *api.DNSEntry) (int64, error) {
+	policy := entry.Spec.RoutingPolicy
+	if policy == nil || policy.Type != dns.RoutingPolicyWeighted {
+		return 0, fmt.Errorf("entry %s has no weighted routing policy", entry.Name)
+	}
+	weight, err := strconv.ParseInt(policy.Parameters["weight"], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("entry %s has invalid weight %q", entry.Name, policy.Parameters["weight"])
+	}
+	return weight, nil
+}
+
+// SetWeight sets the weight of an entry with weighted routing policy.
+func SetWeight(entry *api.DNSEntry, weight int64) bool {
+	value := strconv.FormatInt(weight, 10)
+	policy := entry.Spec.RoutingPolicy
+	if policy == nil || policy.Parameters["weight"] == value {
+		return false
+	}
+	if policy.Parameters == nil {
+		policy.Parameters = map[string]string{}
+	}
+	policy.Parameters["weight"] = value
+	return true
+}
+
+// NextStep returns the weight of the target entry after the next step,
+// whether the step is due, and otherwise the delay until it is due.
+func NextStep(status *api.DNSTrafficShiftStatus, settings *Settings, now time.Time) (int64, bool, time.Duration) {
+	weight := status.Weight + settings.StepWeight
+	if weight > MAX_WEIGHT {
+		weight = MAX_WEIGHT
+	}
+	if status.LastStep == nil {
+		return weight, true, 0
+	}
+	next := status.LastStep.Add(settings.Interval)
+	if !now.Before(next) {
+		return weight, true, 0
+	}
+	return status.Weight, false, next.Sub(now)
+}
+
+// HealthCheckFailed counts a failed health check and returns whether the
+// threshold of consecutive failures aborting the shift is reached.
+func HealthCheckFailed(status *api.DNSTrafficShiftStatus, settings *Settings) bool {
+	status.HealthCheckFailures++
+	return status.HealthCheckFailures >= settings.FailureThreshold
+}
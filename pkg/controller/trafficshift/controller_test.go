@@ -0,0 +1,237 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package trafficshift
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+func weightedEntry(name, weight string) *api.DNSEntry {
+	entry := &api.DNSEntry{}
+	entry.Name = name
+	entry.Spec.DNSName = "my.service.example.com"
+	entry.Spec.RoutingPolicy = &api.RoutingPolicy{
+		Type:          "weighted",
+		SetIdentifier: name,
+		Parameters:    map[string]string{"weight": weight},
+	}
+	return entry
+}
+
+func TestGetSettings(t *testing.T) {
+	RegisterTestingT(t)
+
+	shift := &api.DNSTrafficShift{}
+	shift.Spec.From = "old"
+	shift.Spec.To = "new"
+	settings, err := GetSettings(shift)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(settings.StepWeight).Should(Equal(int64(DEFAULT_STEP_WEIGHT)))
+	Ω(settings.Interval).Should(Equal(DEFAULT_INTERVAL))
+	Ω(settings.HealthCheck).Should(BeNil())
+
+	step := int64(25)
+	shift.Spec.StepWeight = &step
+	shift.Spec.Interval = &metav1.Duration{Duration: 5 * time.Minute}
+	shift.Spec.HealthCheck = &api.DNSTrafficShiftHealthCheck{URL: "https://new.example.com/healthz"}
+	settings, err = GetSettings(shift)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(settings.StepWeight).Should(Equal(step))
+	Ω(settings.Interval).Should(Equal(5 * time.Minute))
+	Ω(settings.HealthCheck.Host).Should(Equal("new.example.com"))
+	Ω(settings.Timeout).Should(Equal(DEFAULT_HEALTH_CHECK_TIMEOUT))
+	Ω(settings.Period).Should(Equal(DEFAULT_HEALTH_CHECK_PERIOD))
+	Ω(settings.FailureThreshold).Should(Equal(int64(DEFAULT_HEALTH_CHECK_FAILURE_THRESHOLD)))
+
+	threshold := int64(0)
+	shift.Spec.HealthCheck.FailureThreshold = &threshold
+	_, err = GetSettings(shift)
+	Ω(err).Should(HaveOccurred())
+	threshold = 5
+	settings, err = GetSettings(shift)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(settings.FailureThreshold).Should(Equal(threshold))
+
+	shift.Spec.HealthCheck.URL = "tcp://new.example.com"
+	_, err = GetSettings(shift)
+	Ω(err).Should(HaveOccurred())
+
+	shift.Spec.HealthCheck = nil
+	step = 0
+	_, err = GetSettings(shift)
+	Ω(err).Should(HaveOccurred())
+
+	step = 10
+	shift.Spec.To = "old"
+	_, err = GetSettings(shift)
+	Ω(err).Should(HaveOccurred())
+}
+
+func TestWeights(t *testing.T) {
+	RegisterTestingT(t)
+
+	from := weightedEntry("old", "100")
+	to := weightedEntry("new", "0")
+	Ω(ValidateEntries(from, to)).Should(Succeed())
+
+	Ω(SetWeight(to, 10)).Should(BeTrue())
+	Ω(SetWeight(to, 10)).Should(BeFalse())
+	Ω(Weight(to)).Should(Equal(int64(10)))
+
+	to.Spec.DNSName = "other.example.com"
+	Ω(ValidateEntries(from, to)).ShouldNot(Succeed())
+
+	to.Spec.DNSName = from.Spec.DNSName
+	to.Spec.RoutingPolicy = nil
+	Ω(ValidateEntries(from, to)).ShouldNot(Succeed())
+	Ω(SetWeight(to, 10)).Should(BeFalse())
+}
+
+func TestNextStep(t *testing.T) {
+	RegisterTestingT(t)
+
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	settings := &Settings{StepWeight: 30, Interval: 10 * time.Minute}
+	status := &api.DNSTrafficShiftStatus{}
+
+	weight, due, _ := NextStep(status, settings, now)
+	Ω(due).Should(BeTrue())
+	Ω(weight).Should(Equal(int64(30)))
+
+	last := metav1.NewTime(now)
+	status.Weight = 30
+	status.LastStep = &last
+	weight, due, delay := NextStep(status, settings, now.Add(4*time.Minute))
+	Ω(due).Should(BeFalse())
+	Ω(weight).Should(Equal(int64(30)))
+	Ω(delay).Should(Equal(6 * time.Minute))
+
+	status.Weight = 90
+	weight, due, _ = NextStep(status, settings, now.Add(10*time.Minute))
+	Ω(due).Should(BeTrue())
+	Ω(weight).Should(Equal(int64(MAX_WEIGHT)))
+}
+
+func TestRecheck(t *testing.T) {
+	RegisterTestingT(t)
+
+	settings := &Settings{Period: time.Minute}
+	Ω(settings.recheck(0)).Should(Equal(time.Duration(0)))
+	Ω(settings.recheck(5 * time.Minute)).Should(Equal(5 * time.Minute))
+
+	settings.HealthCheck, _ = url.Parse("http://new.example.com")
+	Ω(settings.recheck(0)).Should(Equal(time.Minute))
+	Ω(settings.recheck(5 * time.Minute)).Should(Equal(time.Minute))
+	Ω(settings.recheck(30 * time.Second)).Should(Equal(30 * time.Second))
+}
+
+func TestHealthCheckFailed(t *testing.T) {
+	RegisterTestingT(t)
+
+	settings := &Settings{FailureThreshold: 3}
+	status := &api.DNSTrafficShiftStatus{}
+	Ω(HealthCheckFailed(status, settings)).Should(BeFalse())
+	Ω(HealthCheckFailed(status, settings)).Should(BeFalse())
+	Ω(HealthCheckFailed(status, settings)).Should(BeTrue())
+	Ω(status.HealthCheckFailures).Should(Equal(int64(3)))
+}
+
+func TestHTTPHealthCheckAllowed(t *testing.T) {
+	RegisterTestingT(t)
+
+	checker, err := NewHTTPHealthChecker([]string{"new.example.com", "*.service.example.com."}, nil)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	for _, allowed := range []string{"https://new.example.com/healthz", "http://NEW.example.com:8080", "https://instance-b.service.example.com/healthz"} {
+		u, _ := url.Parse(allowed)
+		Ω(checker.Allowed(u)).Should(Succeed(), allowed)
+	}
+	for _, denied := range []string{"https://other.example.com", "https://service.example.com", "http://169.254.169.254/latest/meta-data", "http://kubernetes.default.svc"} {
+		u, _ := url.Parse(denied)
+		Ω(checker.Allowed(u)).ShouldNot(Succeed(), denied)
+	}
+
+	checker, err = NewHTTPHealthChecker(nil, nil)
+	Ω(err).ShouldNot(HaveOccurred())
+	u, _ := url.Parse("https://new.example.com/healthz")
+	Ω(checker.Allowed(u)).ShouldNot(Succeed())
+
+	_, err = NewHTTPHealthChecker(nil, []string{"10.96.0.0"})
+	Ω(err).Should(HaveOccurred())
+}
+
+func TestHTTPHealthCheckDeniedNetworks(t *testing.T) {
+	RegisterTestingT(t)
+
+	checker, err := NewHTTPHealthChecker(nil, []string{"203.0.113.0/24"})
+	Ω(err).ShouldNot(HaveOccurred())
+	for _, denied := range []string{"127.0.0.1:80", "169.254.169.254:80", "10.96.0.1:443", "192.168.1.1:80", "[::1]:80", "[fe80::1]:80", "[fd00:ec2::254]:80", "[::ffff:169.254.169.254]:80", "203.0.113.10:443"} {
+		Ω(checker.checkAddress(denied)).ShouldNot(Succeed(), denied)
+	}
+	Ω(checker.checkAddress("198.51.100.10:443")).Should(Succeed())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	settings := &Settings{Timeout: time.Second}
+	settings.HealthCheck, _ = url.Parse(server.URL)
+	checker, err = NewHTTPHealthChecker([]string{"127.0.0.1"}, nil)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(checker.Check(settings)).ShouldNot(Succeed())
+}
+
+func TestHTTPHealthCheck(t *testing.T) {
+	RegisterTestingT(t)
+
+	healthy := true
+	redirect := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if redirect != "" {
+			http.Redirect(w, r, redirect, http.StatusFound)
+			return
+		}
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	shift := &api.DNSTrafficShift{}
+	shift.Spec.From = "old"
+	shift.Spec.To = "new"
+	shift.Spec.HealthCheck = &api.DNSTrafficShiftHealthCheck{URL: server.URL}
+	settings, err := GetSettings(shift)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	// the test server listens on the loopback network, which is denied by default
+	checker := newHTTPHealthChecker([]string{"127.0.0.1"}, nil)
+	Ω(checker.Check(settings)).Should(Succeed())
+	healthy = false
+	Ω(checker.Check(settings)).ShouldNot(Succeed())
+
+	healthy = true
+	redirect = "http://169.254.169.254/latest/meta-data"
+	Ω(checker.Check(settings)).ShouldNot(Succeed())
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package trafficshift
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// defaultDeniedNetworks are never requested by health checks, as they address
+// the cluster network, the nodes or cloud metadata endpoints.
+var defaultDeniedNetworks = []string{
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+}
+
+// HealthChecker checks the health of the new targets of a traffic shift.
+type HealthChecker interface {
+	// Allowed checks whether the health check URL may be requested at all.
+	Allowed(u *url.URL) error
+	// Check requests the health check URL.
+	Check(settings *Settings) error
+}
+
+// HTTPHealthChecker requests health check URLs of allowed hosts and refuses
+// to connect to addresses of denied networks.
+type HTTPHealthChecker struct {
+	hosts  []string
+	denied []*net.IPNet
+	client *http.Client
+}
+
+var _ HealthChecker = &HTTPHealthChecker{}
+
+// NewHTTPHealthChecker creates a health checker for the given allowed hosts
+// (`*.<domain>` allows all subdomains of a domain). The given CIDRs are denied
+// in addition to the loopback, link-local and private networks.
+func NewHTTPHealthChecker(hosts, cidrs []string) (*HTTPHealthChecker, error) {
+	var denied []*net.IPNet
+	for _, cidr := range append(append([]string{}, defaultDeniedNetworks...), cidrs...) {
+		_, n, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid denied health check CIDR %q: %w", cidr, err)
+		}
+		denied = append(denied, n)
+	}
+	return newHTTPHealthChecker(hosts, denied), nil
+}
+
+func newHTTPHealthChecker(hosts []string, denied []*net.IPNet) *HTTPHealthChecker {
+	this := &HTTPHealthChecker{denied: denied}
+	for _, h := range hosts {
+		if h = normalizeHost(h); h != "" {
+			this.hosts = append(this.hosts, h)
+		}
+	}
+	dialer := &net.Dialer{
+		Timeout: DEFAULT_HEALTH_CHECK_TIMEOUT,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return this.checkAddress(address)
+		},
+	}
+	this.client = &http.Client{
+		Transport: &http.Transport{
+			// no proxy, the resolved addresses of the target must be checked
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   DEFAULT_HEALTH_CHECK_TIMEOUT,
+			ResponseHeaderTimeout: DEFAULT_HEALTH_CHECK_TIMEOUT,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return fmt.Errorf("stopped after 10 redirects")
+			}
+			return this.Allowed(req.URL)
+		},
+	}
+	return this
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
+
+// Allowed checks whether the host of a health check URL is allowed.
+func (this *HTTPHealthChecker) Allowed(u *url.URL) error {
+	host := normalizeHost(u.Hostname())
+	for _, h := range this.hosts {
+		if host == h || (strings.HasPrefix(h, "*.") && strings.HasSuffix(host, h[1:])) {
+			return nil
+		}
+	}
+	return fmt.Errorf("host %q is not allowed as health check target", u.Hostname())
+}
+
+// checkAddress refuses connections to addresses of denied networks.
+func (this *HTTPHealthChecker) checkAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid address %q", address)
+	}
+	for _, n := range this.denied {
+		if n.Contains(ip) {
+			return fmt.Errorf("address %s is denied as health check target", ip)
+		}
+	}
+	return nil
+}
+
+// Check requests the health check URL and expects a 2xx status code.
+func (this *HTTPHealthChecker) Check(settings *Settings) error {
+	if err := this.Allowed(settings.HealthCheck); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), settings.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, settings.HealthCheck.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := this.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
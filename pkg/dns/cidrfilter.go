@@ -0,0 +1,103 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// CIDRFilter restricts IP addresses to allowed CIDR ranges.
+// Denied ranges take precedence over allowed ranges.
+type CIDRFilter struct {
+	allowed []*net.IPNet
+	denied  []*net.IPNet
+}
+
+// NewCIDRFilter parses the given CIDR ranges. It returns nil if no ranges are given.
+func NewCIDRFilter(allowed, denied []string) (*CIDRFilter, error) {
+	if len(allowed) == 0 && len(denied) == 0 {
+		return nil, nil
+	}
+	var err error
+	filter := &CIDRFilter{}
+	if filter.allowed, err = parseCIDRs(allowed); err != nil {
+		return nil, err
+	}
+	if filter.denied, err = parseCIDRs(denied); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, c := range cidrs {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		_, ipnet, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q", c)
+		}
+		nets = append(nets, ipnet)
+	}
+	return nets, nil
+}
+
+// CheckAddress returns an error if the given IP address is denied or not allowed.
+// Any other string (e.g. a hostname) is always accepted.
+func (this *CIDRFilter) CheckAddress(addr string) error {
+	if this == nil {
+		return nil
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return nil
+	}
+	for _, n := range this.denied {
+		if n.Contains(ip) {
+			return fmt.Errorf("address %s is in denied CIDR range %s", addr, n)
+		}
+	}
+	if len(this.allowed) == 0 {
+		return nil
+	}
+	for _, n := range this.allowed {
+		if n.Contains(ip) {
+			return nil
+		}
+	}
+	return fmt.Errorf("address %s is not in allowed CIDR ranges %s", addr, formatCIDRs(this.allowed))
+}
+
+func (this *CIDRFilter) String() string {
+	if this == nil {
+		return "none"
+	}
+	return fmt.Sprintf("allowed: %s, denied: %s", formatCIDRs(this.allowed), formatCIDRs(this.denied))
+}
+
+func formatCIDRs(nets []*net.IPNet) string {
+	s := make([]string, len(nets))
+	for i, n := range nets {
+		s[i] = n.String()
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
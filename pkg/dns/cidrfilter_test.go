@@ -0,0 +1,67 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"testing"
+)
+
+func TestCIDRFilter(t *testing.T) {
+	filter, err := NewCIDRFilter(nil, nil)
+	if err != nil || filter != nil {
+		t.Errorf("expected nil filter without ranges, got %v (%v)", filter, err)
+	}
+	if err := filter.CheckAddress("10.1.2.3"); err != nil {
+		t.Errorf("nil filter must accept all addresses: %s", err)
+	}
+	if _, err := NewCIDRFilter([]string{"10.0.0.0/33"}, nil); err == nil {
+		t.Errorf("expected error for invalid CIDR")
+	}
+
+	filter, err = NewCIDRFilter([]string{"10.0.0.0/8", "2001:db8::/32"}, []string{"10.1.0.0/16"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	table := []struct {
+		addr string
+		ok   bool
+	}{
+		{"10.2.3.4", true},
+		{"10.1.2.3", false},
+		{"192.168.0.1", false},
+		{"2001:db8::1", true},
+		{"2001:db9::1", false},
+		{"www.example.com", true},
+	}
+	for _, entry := range table {
+		err := filter.CheckAddress(entry.addr)
+		if entry.ok != (err == nil) {
+			t.Errorf("%s: expected ok=%t, got %v", entry.addr, entry.ok, err)
+		}
+	}
+
+	filter, err = NewCIDRFilter(nil, []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := filter.CheckAddress("1.2.3.4"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := filter.CheckAddress("172.20.0.1"); err == nil {
+		t.Errorf("expected 172.20.0.1 to be denied")
+	}
+}
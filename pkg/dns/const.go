@@ -26,5 +26,27 @@ const ANNOTATION_GROUP = "dns.gardener.cloud"
 const CLASS_ANNOTATION = ANNOTATION_GROUP + "/class"
 const REALM_ANNOTATION = ANNOTATION_GROUP + "/realms"
 const NOT_RATE_LIMITED_ANNOTATION = ANNOTATION_GROUP + "/not-rate-limited"
+const IP_STACK_ANNOTATION = ANNOTATION_GROUP + "/ip-stack"
+
+// SOURCE_CLUSTER_ANNOTATION contains the id of the additional source cluster of the source object of a generated entry
+const SOURCE_CLUSTER_ANNOTATION = ANNOTATION_GROUP + "/source-cluster"
+
+// DRY_RUN_ANNOTATION requests the dry run mode for a single entry
+const DRY_RUN_ANNOTATION = ANNOTATION_GROUP + "/dry-run"
+
+// FORCE_CLEANUP_ANNOTATION requests the removal of the finalizer of a deleted entry whose provider or hosted zone
+// is permanently gone (only if allowed by the controller)
+const FORCE_CLEANUP_ANNOTATION = ANNOTATION_GROUP + "/force-cleanup"
+
+// DUPLICATE_PRIORITY_ANNOTATION contains the priority of an entry for the duplicate policy priority-by-annotation
+const DUPLICATE_PRIORITY_ANNOTATION = ANNOTATION_GROUP + "/duplicate-priority"
+
+// PLANNED_CHANGES_ANNOTATION contains the changes planned for an entry in dry run mode
+const PLANNED_CHANGES_ANNOTATION = ANNOTATION_GROUP + "/planned-changes"
+
+// namespace labels used by the mutating admission webhook to default entry fields
+const DEFAULT_TTL_LABEL = ANNOTATION_GROUP + "/default-ttl"
+const DEFAULT_OWNER_ID_LABEL = ANNOTATION_GROUP + "/default-owner-id"
+const DEFAULT_CLASS_LABEL = ANNOTATION_GROUP + "/default-class"
 
 const OPT_SETUP = "setup"
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+// Package debugstate contains the types of the debug state endpoint of the DNS controller manager
+// and a client reading it, without depending on the controller packages.
+package debugstate
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Path is the path of the HTTP endpoint dumping the in-memory state of the DNS controllers.
+const Path = "/debug/state"
+
+// DiffPath is the path of the HTTP endpoint comparing the desired state of a hosted zone
+// with the record sets at the DNS provider.
+const DiffPath = "/debug/diff"
+
+// State is the in-memory state of a DNS controller served by the debug state endpoint.
+type State struct {
+	// Controller is the identifier of the DNS controller
+	Controller string `json:"controller"`
+	// Zones are the known hosted zones with their entries
+	Zones []Zone `json:"zones"`
+	// DNSNames maps the zoned DNS names to the owning entries
+	DNSNames map[string]string `json:"dnsNames"`
+	// BlockingEntries are the entries blocking zone reconciliations with the time since they block
+	BlockingEntries map[string]time.Time `json:"blockingEntries,omitempty"`
+	// UnassignedEntries are the entries without hosted zone
+	UnassignedEntries []Entry `json:"unassignedEntries,omitempty"`
+}
+
+// Zone is the state of a hosted zone.
+type Zone struct {
+	ProviderType string    `json:"providerType"`
+	ID           string    `json:"id"`
+	Domain       string    `json:"domain"`
+	Private      bool      `json:"private,omitempty"`
+	Providers    []string  `json:"providers"`
+	Busy         bool      `json:"busy,omitempty"`
+	Next         time.Time `json:"nextReconcile"`
+	Entries      []Entry   `json:"entries"`
+	// OutstandingRequests are the change requests of a running zone reconciliation not executed yet
+	OutstandingRequests []Change `json:"outstandingRequests,omitempty"`
+}
+
+// Entry is the state of an entry.
+type Entry struct {
+	Name    string `json:"name"`
+	DNSName string `json:"dnsName"`
+	State   string `json:"state"`
+	Message string `json:"message,omitempty"`
+	Valid   bool   `json:"valid"`
+	// ChangePending is true if the entry is modified and waits for a zone reconciliation
+	ChangePending bool `json:"changePending,omitempty"`
+	Deleting      bool `json:"deleting,omitempty"`
+}
+
+// ZoneDiff are the changes a zone reconciliation would execute to bring the record sets
+// at the DNS provider to the desired state given by the entries of the hosted zone.
+type ZoneDiff struct {
+	// Controller is the identifier of the DNS controller
+	Controller   string `json:"controller"`
+	ProviderType string `json:"providerType"`
+	ID           string `json:"id"`
+	Domain       string `json:"domain"`
+	// Error is set if the record sets of the zone cannot be read from the DNS provider
+	Error     string     `json:"error,omitempty"`
+	Changes   []Change   `json:"changes"`
+	Conflicts []Conflict `json:"conflicts,omitempty"`
+}
+
+// Change is a change request for a record set.
+type Change struct {
+	// Action is one of create, update or delete
+	Action        string `json:"action"`
+	Type          string `json:"type"`
+	DNSName       string `json:"dnsName"`
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	// Entry is the entry requesting the change, it is empty for the deletion of orphaned record sets
+	Entry       string `json:"entry,omitempty"`
+	Description string `json:"description"`
+}
+
+// Conflict is an entry whose record sets cannot be changed, e.g. because they are owned by another owner id.
+type Conflict struct {
+	Entry   string `json:"entry"`
+	Message string `json:"message"`
+}
+
+// Read reads the state of the DNS controllers from the debug state endpoint
+// of the HTTP server of a DNS controller manager, optionally restricted to a hosted zone.
+func Read(server, token, zone string) ([]byte, error) {
+	query := url.Values{}
+	if zone != "" {
+		query.Set("zone", zone)
+	}
+	return get(server, Path, token, query)
+}
+
+// ReadDiff reads the diffs of a hosted zone from the debug diff endpoint of the HTTP server
+// of a DNS controller manager. The provider type is only needed for ambiguous zone ids.
+func ReadDiff(server, token, zone, providerType string) ([]byte, error) {
+	query := url.Values{"zone": []string{zone}}
+	if providerType != "" {
+		query.Set("type", providerType)
+	}
+	return get(server, DiffPath, token, query)
+}
+
+func get(server, path, token string, query url.Values) ([]byte, error) {
+	u := strings.TrimSuffix(server, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	client := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
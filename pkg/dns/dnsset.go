@@ -124,6 +124,7 @@ const (
 
 	ATTR_TIMESTAMP = "ts"
 	ATTR_LOCKID    = "lockid"
+	ATTR_HOLDER    = "holder"
 )
 
 type DNSSet struct {
@@ -132,11 +133,17 @@ type DNSSet struct {
 	UpdateGroup   string
 	Sets          RecordSets
 	RoutingPolicy *RoutingPolicy
+
+	// ExternalDNSOwner is the owner found in the TXT registry of kubernetes-sigs/external-dns
+	ExternalDNSOwner string
+	// ExternalDNSRegistry are the registry record sets of kubernetes-sigs/external-dns for this set
+	ExternalDNSRegistry []*DNSSet
 }
 
 func (this *DNSSet) Clone() *DNSSet {
 	return &DNSSet{Name: this.Name, Sets: this.Sets.Clone(), UpdateGroup: this.UpdateGroup, Kind: this.Kind,
-		RoutingPolicy: this.RoutingPolicy.Clone()}
+		RoutingPolicy: this.RoutingPolicy.Clone(), ExternalDNSOwner: this.ExternalDNSOwner,
+		ExternalDNSRegistry: this.ExternalDNSRegistry}
 }
 
 func (this *DNSSet) getAttr(ty string, name string) string {
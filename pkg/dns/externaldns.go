@@ -0,0 +1,217 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TXT registry of kubernetes-sigs/external-dns
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	ExternalDNSHeritage      = "external-dns"
+	ExternalDNSLabelHeritage = "heritage"
+	ExternalDNSLabelOwner    = "owner"
+	ExternalDNSLabelResource = "resource"
+
+	externalDNSLabelPrefix     = "external-dns/"
+	externalDNSRecordTypeAffix = "%{record_type}"
+	externalDNSNonceSize       = 12
+)
+
+// ExternalDNSRegistry describes the TXT registry format of kubernetes-sigs/external-dns
+// used to mark the ownership of record sets.
+type ExternalDNSRegistry struct {
+	// Prefix is the prefix of the registry record names, it may contain the template %{record_type}.
+	Prefix string
+	// OwnerId is the external-dns owner id of record sets adopted from and maintained for external-dns.
+	OwnerId string
+	// AESKey is the optional key used for encrypted registry records.
+	AESKey []byte
+	// Write enables the maintenance of registry records for managed record sets.
+	Write bool
+}
+
+// RecordName returns the name of the registry record for a record set type.
+func (this *ExternalDNSRegistry) RecordName(dnsName, rtype string) string {
+	rtype = strings.ToLower(rtype)
+	prefix := this.Prefix
+	labels := strings.SplitN(dnsName, ".", 2)
+	if strings.Contains(prefix, externalDNSRecordTypeAffix) {
+		prefix = strings.ReplaceAll(prefix, externalDNSRecordTypeAffix, rtype)
+	} else {
+		labels[0] = rtype + "-" + labels[0]
+	}
+	if len(labels) < 2 {
+		return prefix + labels[0]
+	}
+	return prefix + labels[0] + "." + labels[1]
+}
+
+// LegacyRecordName returns the name of the registry record used by older versions of external-dns.
+func (this *ExternalDNSRegistry) LegacyRecordName(dnsName string) string {
+	return strings.ReplaceAll(this.Prefix, externalDNSRecordTypeAffix, "") + dnsName
+}
+
+// ParseLabels parses the value of a registry record. It returns false if the value
+// is no valid label set of external-dns.
+func (this *ExternalDNSRegistry) ParseLabels(value string) (map[string]string, bool) {
+	text := strings.Trim(value, "\"")
+	if len(this.AESKey) > 0 {
+		if decrypted, err := decryptExternalDNSText(text, this.AESKey); err == nil {
+			text = decrypted
+		}
+	}
+	labels := map[string]string{}
+	for _, token := range strings.Split(text, ",") {
+		kv := strings.SplitN(token, "=", 2)
+		if len(kv) != 2 {
+			return nil, false
+		}
+		labels[strings.TrimPrefix(kv[0], externalDNSLabelPrefix)] = kv[1]
+	}
+	if labels[ExternalDNSLabelHeritage] != ExternalDNSHeritage {
+		return nil, false
+	}
+	return labels, true
+}
+
+// FormatLabels returns the (optionally encrypted) value of a registry record for the given labels.
+func (this *ExternalDNSRegistry) FormatLabels(labels map[string]string) (string, error) {
+	keys := []string{}
+	for k := range labels {
+		if k != ExternalDNSLabelHeritage {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	tokens := []string{ExternalDNSLabelHeritage + "=" + ExternalDNSHeritage}
+	for _, k := range keys {
+		tokens = append(tokens, externalDNSLabelPrefix+k+"="+labels[k])
+	}
+	text := strings.Join(tokens, ",")
+	if len(this.AESKey) > 0 {
+		encrypted, err := encryptExternalDNSText(text, this.AESKey)
+		if err != nil {
+			return "", err
+		}
+		text = encrypted
+	}
+	return "\"" + text + "\"", nil
+}
+
+// NewRecordSet creates the registry record set for a record set type of the given set.
+func (this *ExternalDNSRegistry) NewRecordSet(set *DNSSet, rtype string, ttl int64) (*DNSSet, error) {
+	value, err := this.FormatLabels(map[string]string{ExternalDNSLabelOwner: this.OwnerId})
+	if err != nil {
+		return nil, err
+	}
+	reg := NewDNSSet(set.Name.WithDNSName(this.RecordName(set.Name.DNSName, rtype)), set.RoutingPolicy.Clone())
+	reg.SetRecordSet(RS_TXT, ttl, value)
+	return reg, nil
+}
+
+// Resolve assigns the registry records found in the given record sets to the record sets
+// they describe. The registry record sets are removed from the record sets and
+// remembered at the described set together with their external-dns owner.
+func (this *ExternalDNSRegistry) Resolve(sets DNSSets) {
+	owners := map[DNSSetName]string{}
+	for name, set := range sets {
+		if len(set.Sets) != 1 || set.Sets[RS_TXT] == nil {
+			continue
+		}
+		for _, r := range set.Sets[RS_TXT].Records {
+			if labels, ok := this.ParseLabels(r.Value); ok {
+				owners[name] = labels[ExternalDNSLabelOwner]
+				break
+			}
+		}
+	}
+	if len(owners) == 0 {
+		return
+	}
+	for name, set := range sets {
+		if _, ok := owners[name]; ok {
+			continue
+		}
+		candidates := []DNSSetName{name.WithDNSName(this.LegacyRecordName(name.DNSName))}
+		for rtype := range set.Sets {
+			if rtype != RS_META {
+				candidates = append(candidates, name.WithDNSName(this.RecordName(name.DNSName, rtype)))
+			}
+		}
+		for _, regName := range candidates {
+			if owner, ok := owners[regName]; ok {
+				set.ExternalDNSOwner = owner
+				set.ExternalDNSRegistry = append(set.ExternalDNSRegistry, sets[regName])
+			}
+		}
+	}
+	for _, set := range sets {
+		for _, reg := range set.ExternalDNSRegistry {
+			delete(sets, reg.Name)
+		}
+	}
+}
+
+func encryptExternalDNSText(text string, key []byte) (string, error) {
+	gcm, err := newExternalDNSCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, externalDNSNonceSize)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(text), nil)), nil
+}
+
+func decryptExternalDNSText(text string, key []byte) (string, error) {
+	gcm, err := newExternalDNSCipher(key)
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return "", err
+	}
+	if len(data) <= externalDNSNonceSize {
+		return "", fmt.Errorf("encrypted text too short")
+	}
+	plain, err := gcm.Open(nil, data[:externalDNSNonceSize], data[externalDNSNonceSize:], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+func newExternalDNSCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCMWithNonceSize(block, externalDNSNonceSize)
+}
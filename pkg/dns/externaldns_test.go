@@ -0,0 +1,85 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestExternalDNSRecordName(t *testing.T) {
+	RegisterTestingT(t)
+
+	table := []struct {
+		prefix string
+		wanted string
+		legacy string
+	}{
+		{"", "cname-www.example.com", "www.example.com"},
+		{"txt.", "txt.cname-www.example.com", "txt.www.example.com"},
+		{"%{record_type}-reg.", "cname-reg.www.example.com", "-reg.www.example.com"},
+	}
+	for _, entry := range table {
+		r := &ExternalDNSRegistry{Prefix: entry.prefix}
+		Ω(r.RecordName("www.example.com", RS_CNAME)).Should(Equal(entry.wanted))
+		Ω(r.LegacyRecordName("www.example.com")).Should(Equal(entry.legacy))
+	}
+}
+
+func TestExternalDNSLabels(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, key := range [][]byte{nil, []byte("0123456789abcdef0123456789abcdef")} {
+		r := &ExternalDNSRegistry{AESKey: key}
+		value, err := r.FormatLabels(map[string]string{ExternalDNSLabelOwner: "o1", ExternalDNSLabelResource: "ingress/default/foo"})
+		Ω(err).ShouldNot(HaveOccurred())
+		if key == nil {
+			Ω(value).Should(Equal("\"heritage=external-dns,external-dns/owner=o1,external-dns/resource=ingress/default/foo\""))
+		}
+		labels, ok := r.ParseLabels(value)
+		Ω(ok).Should(BeTrue())
+		Ω(labels).Should(HaveKeyWithValue(ExternalDNSLabelOwner, "o1"))
+		Ω(labels).Should(HaveKeyWithValue(ExternalDNSLabelResource, "ingress/default/foo"))
+	}
+
+	r := &ExternalDNSRegistry{}
+	_, ok := r.ParseLabels("\"owner=test\"")
+	Ω(ok).Should(BeFalse())
+	_, ok = r.ParseLabels("\"some text\"")
+	Ω(ok).Should(BeFalse())
+}
+
+func TestExternalDNSResolve(t *testing.T) {
+	RegisterTestingT(t)
+
+	r := &ExternalDNSRegistry{OwnerId: "o1"}
+	sets := DNSSets{}
+	www := DNSSetName{DNSName: "www.example.com"}
+	sets.AddRecordSet(www, nil, NewRecordSet(RS_A, 300, []*Record{{Value: "1.2.3.4"}}))
+	reg := DNSSetName{DNSName: "a-www.example.com"}
+	sets.AddRecordSet(reg, nil, NewRecordSet(RS_TXT, 300, []*Record{{Value: "\"heritage=external-dns,external-dns/owner=o2\""}}))
+	other := DNSSetName{DNSName: "other.example.com"}
+	sets.AddRecordSet(other, nil, NewRecordSet(RS_TXT, 300, []*Record{{Value: "\"some text\""}}))
+
+	r.Resolve(sets)
+	Ω(sets).Should(HaveLen(2))
+	Ω(sets[www].ExternalDNSOwner).Should(Equal("o2"))
+	Ω(sets[www].ExternalDNSRegistry).Should(HaveLen(1))
+	Ω(sets[www].ExternalDNSRegistry[0].Name).Should(Equal(reg))
+	Ω(sets[other].ExternalDNSOwner).Should(BeEmpty())
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+// IPStack specifies the IP address families used for address records.
+// The empty value accepts both address families.
+type IPStack string
+
+const (
+	IP_STACK_IPV4       IPStack = "ipv4"
+	IP_STACK_IPV6       IPStack = "ipv6"
+	IP_STACK_DUAL_STACK IPStack = "dual-stack"
+)
+
+// ParseIPStack validates the value of the ip-stack annotation.
+func ParseIPStack(value string) (IPStack, error) {
+	switch s := IPStack(strings.ToLower(strings.TrimSpace(value))); s {
+	case "", IP_STACK_IPV4, IP_STACK_IPV6, IP_STACK_DUAL_STACK:
+		return s, nil
+	default:
+		return "", fmt.Errorf("invalid ip stack %q (expected one of %s, %s, %s)", value, IP_STACK_IPV4, IP_STACK_IPV6, IP_STACK_DUAL_STACK)
+	}
+}
+
+func (this IPStack) AcceptsIPv4() bool {
+	return this != IP_STACK_IPV6
+}
+
+func (this IPStack) AcceptsIPv6() bool {
+	return this != IP_STACK_IPV4
+}
+
+// AcceptsAddress returns false for IP addresses of a filtered address family.
+// Any other string (e.g. a hostname) is always accepted.
+func (this IPStack) AcceptsAddress(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return true
+	}
+	if ip.To4() != nil {
+		return this.AcceptsIPv4()
+	}
+	return this.AcceptsIPv6()
+}
+
+// MissingAddressFamily returns the address family ("IPv4" or "IPv6") not contained in the given
+// IP addresses. It returns an empty string if both families are found or if the targets contain
+// hostnames or no addresses at all.
+func MissingAddressFamily(targets utils.StringSet) string {
+	var ipv4, ipv6 bool
+	for t := range targets {
+		ip := net.ParseIP(t)
+		switch {
+		case ip == nil:
+			return ""
+		case ip.To4() != nil:
+			ipv4 = true
+		default:
+			ipv6 = true
+		}
+	}
+	switch {
+	case ipv4 && !ipv6:
+		return "IPv6"
+	case ipv6 && !ipv4:
+		return "IPv4"
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+func TestIPStack(t *testing.T) {
+	table := []struct {
+		input string
+		stack IPStack
+		ok    bool
+		ipv4  bool
+		ipv6  bool
+	}{
+		{"", "", true, true, true},
+		{"ipv4", IP_STACK_IPV4, true, true, false},
+		{" IPv6 ", IP_STACK_IPV6, true, false, true},
+		{"dual-stack", IP_STACK_DUAL_STACK, true, true, true},
+		{"ipv5", "", false, false, false},
+	}
+	for _, entry := range table {
+		stack, err := ParseIPStack(entry.input)
+		if entry.ok != (err == nil) {
+			t.Errorf("%q: unexpected error result: %v", entry.input, err)
+			continue
+		}
+		if !entry.ok {
+			continue
+		}
+		if stack != entry.stack {
+			t.Errorf("%q: expected %q, but got %q", entry.input, entry.stack, stack)
+		}
+		if stack.AcceptsAddress("1.2.3.4") != entry.ipv4 {
+			t.Errorf("%q: unexpected result for IPv4 address", entry.input)
+		}
+		if stack.AcceptsAddress("2001:db8::1") != entry.ipv6 {
+			t.Errorf("%q: unexpected result for IPv6 address", entry.input)
+		}
+		if !stack.AcceptsAddress("a.example.com") {
+			t.Errorf("%q: hostname must always be accepted", entry.input)
+		}
+	}
+}
+
+func TestMissingAddressFamily(t *testing.T) {
+	table := []struct {
+		targets []string
+		missing string
+	}{
+		{nil, ""},
+		{[]string{"1.2.3.4", "1.2.3.5"}, "IPv6"},
+		{[]string{"2001:db8::1"}, "IPv4"},
+		{[]string{"1.2.3.4", "2001:db8::1"}, ""},
+		{[]string{"1.2.3.4", "a.example.com"}, ""},
+	}
+	for _, entry := range table {
+		if missing := MissingAddressFamily(utils.NewStringSet(entry.targets...)); missing != entry.missing {
+			t.Errorf("%v: expected %q, but got %q", entry.targets, entry.missing, missing)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// ADAPTIVE_BACKOFF_FACTOR is applied to the current qps of an adaptive rate limiter on a throttled provider response.
+	ADAPTIVE_BACKOFF_FACTOR = 0.5
+	// ADAPTIVE_MIN_QPS_FACTOR is the lowest fraction of the configured qps an adaptive rate limiter backs off to.
+	ADAPTIVE_MIN_QPS_FACTOR = 1.0 / 16
+	// ADAPTIVE_RAMP_UP_INTERVAL is the period without throttled responses after which the qps is increased again.
+	ADAPTIVE_RAMP_UP_INTERVAL = 30 * time.Second
+	// ADAPTIVE_RAMP_UP_STEP is the fraction of the configured qps added per ramp up interval.
+	ADAPTIVE_RAMP_UP_STEP = 0.1
+	// ADAPTIVE_PAUSE is the mean pause of all requests after a throttled response (jittered by +/- 50%).
+	ADAPTIVE_PAUSE = 1 * time.Second
+)
+
+// adaptiveRateLimiter is a token bucket rate limiter using the configured qps as upper bound.
+// On throttled provider responses it halves the qps and pauses all requests for a jittered
+// period. Without further throttling the qps is ramped up again step by step.
+type adaptiveRateLimiter struct {
+	lock        sync.Mutex
+	limiter     *rate.Limiter
+	maxQPS      float64
+	qps         float64
+	pausedUntil time.Time
+	lastChange  time.Time
+
+	now      func() time.Time
+	jitter   func() float64
+	onChange func(qps float32)
+}
+
+var _ flowcontrol.RateLimiter = &adaptiveRateLimiter{}
+
+func newAdaptiveRateLimiter(qps float32, burst int) *adaptiveRateLimiter {
+	return &adaptiveRateLimiter{
+		limiter: rate.NewLimiter(rate.Limit(qps), burst),
+		maxQPS:  float64(qps),
+		qps:     float64(qps),
+		now:     time.Now,
+		jitter:  rand.Float64,
+	}
+}
+
+// Throttled adapts the rate limiter to a throttled provider response.
+// Further throttled responses during the pause are caused by the same overload and ignored.
+func (this *adaptiveRateLimiter) Throttled() {
+	this.lock.Lock()
+	now := this.now()
+	if now.Before(this.pausedUntil) {
+		this.lock.Unlock()
+		return
+	}
+	qps := this.qps * ADAPTIVE_BACKOFF_FACTOR
+	if min := this.maxQPS * ADAPTIVE_MIN_QPS_FACTOR; qps < min {
+		qps = min
+	}
+	this.pausedUntil = now.Add(time.Duration((0.5 + this.jitter()) * float64(ADAPTIVE_PAUSE)))
+	changed := this.setQPS(now, qps)
+	this.lock.Unlock()
+
+	if changed && this.onChange != nil {
+		this.onChange(float32(qps))
+	}
+}
+
+// setQPS must be called with lock.
+func (this *adaptiveRateLimiter) setQPS(now time.Time, qps float64) bool {
+	this.lastChange = now
+	if qps == this.qps {
+		return false
+	}
+	this.qps = qps
+	this.limiter.SetLimitAt(now, rate.Limit(qps))
+	return true
+}
+
+// prepare ramps up the qps if there was no throttling for a while and returns the remaining pause.
+func (this *adaptiveRateLimiter) prepare() time.Duration {
+	this.lock.Lock()
+	now := this.now()
+	changed := false
+	qps := this.qps
+	if qps < this.maxQPS && now.Sub(this.lastChange) >= ADAPTIVE_RAMP_UP_INTERVAL {
+		qps += this.maxQPS * ADAPTIVE_RAMP_UP_STEP
+		if qps > this.maxQPS {
+			qps = this.maxQPS
+		}
+		changed = this.setQPS(now, qps)
+	}
+	pause := this.pausedUntil.Sub(now)
+	this.lock.Unlock()
+
+	if changed && this.onChange != nil {
+		this.onChange(float32(qps))
+	}
+	return pause
+}
+
+func (this *adaptiveRateLimiter) TryAccept() bool {
+	if this.prepare() > 0 {
+		return false
+	}
+	return this.limiter.Allow()
+}
+
+func (this *adaptiveRateLimiter) Accept() {
+	_ = this.Wait(context.Background())
+}
+
+func (this *adaptiveRateLimiter) Wait(ctx context.Context) error {
+	if pause := this.prepare(); pause > 0 {
+		timer := time.NewTimer(pause)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+	return this.limiter.Wait(ctx)
+}
+
+func (this *adaptiveRateLimiter) QPS() float32 {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return float32(this.qps)
+}
+
+func (this *adaptiveRateLimiter) Stop() {
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+var _ = ginkgov2.Describe("Adaptive rate limiter", func() {
+	var (
+		now     time.Time
+		limiter *adaptiveRateLimiter
+		changes []float32
+	)
+
+	ginkgov2.BeforeEach(func() {
+		now = time.Now()
+		changes = nil
+		limiter = newAdaptiveRateLimiter(16, 100)
+		limiter.now = func() time.Time { return now }
+		limiter.jitter = func() float64 { return 0.5 }
+		limiter.onChange = func(qps float32) { changes = append(changes, qps) }
+	})
+
+	ginkgov2.It("backs off on throttled responses and pauses the requests", func() {
+		Ω(limiter.TryAccept()).Should(BeTrue())
+		limiter.Throttled()
+		Ω(limiter.QPS()).Should(Equal(float32(8)))
+		Ω(limiter.TryAccept()).Should(BeFalse())
+
+		// ignored during the pause
+		limiter.Throttled()
+		Ω(limiter.QPS()).Should(Equal(float32(8)))
+
+		now = now.Add(ADAPTIVE_PAUSE)
+		Ω(limiter.TryAccept()).Should(BeTrue())
+		for i := 0; i < 10; i++ {
+			now = now.Add(ADAPTIVE_PAUSE)
+			limiter.Throttled()
+		}
+		Ω(limiter.QPS()).Should(Equal(float32(1)))
+		Ω(changes).Should(Equal([]float32{8, 4, 2, 1}))
+	})
+
+	ginkgov2.It("ramps up slowly without throttled responses", func() {
+		limiter.Throttled()
+		limiter.Throttled()
+		now = now.Add(ADAPTIVE_PAUSE)
+		limiter.Throttled()
+		Ω(limiter.QPS()).Should(Equal(float32(4)))
+
+		now = now.Add(ADAPTIVE_RAMP_UP_INTERVAL / 2)
+		limiter.TryAccept()
+		Ω(limiter.QPS()).Should(Equal(float32(4)))
+		now = now.Add(ADAPTIVE_RAMP_UP_INTERVAL / 2)
+		limiter.TryAccept()
+		Ω(limiter.QPS()).Should(BeNumerically("~", 5.6, 0.001))
+		for i := 0; i < 10; i++ {
+			now = now.Add(ADAPTIVE_RAMP_UP_INTERVAL)
+			limiter.TryAccept()
+		}
+		Ω(limiter.QPS()).Should(Equal(float32(16)))
+	})
+
+	ginkgov2.DescribeTable("detects throttled provider responses",
+		func(err error, expected bool) {
+			Ω(perrs.IsThrottlingResponse(err)).Should(Equal(expected))
+		},
+		ginkgov2.Entry("no error", nil, false),
+		ginkgov2.Entry("throttling error", perrs.NewThrottlingError(fmt.Errorf("failed")), true),
+		ginkgov2.Entry("google", fmt.Errorf("googleapi: Error 429: Rate Limit Exceeded, rateLimitExceeded"), true),
+		ginkgov2.Entry("azure", fmt.Errorf("dns.RecordSetsClient#CreateOrUpdate: Failure responding to request: StatusCode=429"), true),
+		ginkgov2.Entry("other error", fmt.Errorf("zone not found"), false),
+	)
+})
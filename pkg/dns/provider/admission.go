@@ -0,0 +1,329 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1beta1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/server/webhook"
+)
+
+func createAdmissionWebhookConfig(c controller.Interface) (*webhook.Config, error) {
+	port, err := c.GetIntOption(OPT_ADMISSION_WEBHOOK_PORT)
+	if err != nil {
+		return nil, err
+	}
+	if port == 0 {
+		return nil, nil
+	}
+	certDir, _ := c.GetStringOption(OPT_ADMISSION_WEBHOOK_CERT_DIR)
+	if certDir == "" {
+		return nil, fmt.Errorf("missing %s for activated admission webhook server", OPT_ADMISSION_WEBHOOK_CERT_DIR)
+	}
+	return &webhook.Config{Port: port, CertDir: certDir}, nil
+}
+
+func (this *state) startAdmissionWebhookServer() error {
+	this.context.Infof("starting admission webhook server")
+	return webhook.StartServer(this.context.GetContext(), this.context, this.config.AdmissionWebhookConfig, &webhook.Handlers{
+		Validators: map[string]webhook.ValidateFunc{
+			webhook.PathValidateDNSEntry:    this.validateDNSEntryAdmission,
+			webhook.PathValidateDNSProvider: this.validateDNSProviderAdmission,
+		},
+		Mutators: map[string]webhook.MutateFunc{
+			webhook.PathMutateDNSEntry: this.mutateDNSEntryAdmission,
+		},
+		Converters: map[string]webhook.ConvertFunc{
+			webhook.PathConvert: v1beta1.ConvertObject,
+		},
+	})
+}
+
+// mutateDNSEntryAdmission sets the defaults for unset fields of created and updated entries.
+// Updates are mutated, too, as source controllers reset the fields not specified by the source object.
+func (this *state) mutateDNSEntryAdmission(req *admissionv1.AdmissionRequest) ([]webhook.PatchOperation, error) {
+	entry := &api.DNSEntry{}
+	if err := json.Unmarshal(req.Object.Raw, entry); err != nil {
+		return nil, fmt.Errorf("cannot decode DNSEntry: %w", err)
+	}
+	labels, err := this.namespaceLabels(req.Namespace)
+	if err != nil {
+		this.context.Warnf("cannot get namespace %s for entry defaults: %s", req.Namespace, err)
+	}
+	defaults, err := this.entryDefaultsFor(req.Namespace, labels)
+	if err != nil {
+		return nil, err
+	}
+	return EntryDefaultPatches(entry, defaults), nil
+}
+
+// namespaceLabelsLookup returns a function reading the labels of a namespace of the target cluster.
+func namespaceLabelsLookup(ctx Context) func(name string) (map[string]string, error) {
+	return func(name string) (map[string]string, error) {
+		ns := &corev1.Namespace{}
+		if _, err := ctx.GetCluster(TARGET_CLUSTER).Resources().GetObjectInto(resources.NewObjectName(name), ns); err != nil {
+			return nil, err
+		}
+		return ns.Labels, nil
+	}
+}
+
+// entryDefaultsFor determines the defaults for entries of a namespace.
+// The namespace labels take precedence over the defaults of the DNS policies.
+func (this *state) entryDefaultsFor(namespace string, labels map[string]string) (*api.EntryDefaults, error) {
+	defaults := &api.EntryDefaults{}
+	for _, pol := range this.getDNSPolicies() {
+		if d := pol.EntryDefaults(namespace); d != nil {
+			defaults = d.DeepCopy()
+			break
+		}
+	}
+	if value := labels[dns.DEFAULT_TTL_LABEL]; value != "" {
+		ttl, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid label %s=%s of namespace %s", dns.DEFAULT_TTL_LABEL, value, namespace)
+		}
+		defaults.TTL = &ttl
+	}
+	if value := labels[dns.DEFAULT_OWNER_ID_LABEL]; value != "" {
+		defaults.OwnerId = &value
+	}
+	if value := labels[dns.DEFAULT_CLASS_LABEL]; value != "" {
+		defaults.Class = &value
+	}
+	return defaults, nil
+}
+
+// EntryDefaultPatches returns the JSON patch operations setting the defaults for all unset fields of an entry.
+func EntryDefaultPatches(entry *api.DNSEntry, defaults *api.EntryDefaults) []webhook.PatchOperation {
+	var ops []webhook.PatchOperation
+	if defaults.TTL != nil && entry.Spec.TTL == nil {
+		ops = append(ops, webhook.PatchOperation{Op: "add", Path: "/spec/ttl", Value: *defaults.TTL})
+	}
+	if defaults.OwnerId != nil && entry.Spec.OwnerId == nil {
+		ops = append(ops, webhook.PatchOperation{Op: "add", Path: "/spec/ownerId", Value: *defaults.OwnerId})
+	}
+	if defaults.Class != nil && entry.Annotations[dns.CLASS_ANNOTATION] == "" {
+		if entry.Annotations == nil {
+			ops = append(ops, webhook.PatchOperation{Op: "add", Path: "/metadata/annotations",
+				Value: map[string]string{dns.CLASS_ANNOTATION: *defaults.Class}})
+		} else {
+			path := "/metadata/annotations/" + strings.ReplaceAll(dns.CLASS_ANNOTATION, "/", "~1")
+			ops = append(ops, webhook.PatchOperation{Op: "add", Path: path, Value: *defaults.Class})
+		}
+	}
+	return ops
+}
+
+func (this *state) validateDNSEntryAdmission(req *admissionv1.AdmissionRequest) error {
+	entry := &api.DNSEntry{}
+	if err := json.Unmarshal(req.Object.Raw, entry); err != nil {
+		return fmt.Errorf("cannot decode DNSEntry: %w", err)
+	}
+	return ValidateDNSEntrySpec(&entry.Spec, !this.config.DisableDNSNameValidation)
+}
+
+func (this *state) validateDNSProviderAdmission(req *admissionv1.AdmissionRequest) error {
+	provider := &api.DNSProvider{}
+	if err := json.Unmarshal(req.Object.Raw, provider); err != nil {
+		return fmt.Errorf("cannot decode DNSProvider: %w", err)
+	}
+	if err := ValidateDNSProviderSpec(&provider.Spec, this.config.Factory.TypeCodes().Contains); err != nil {
+		return err
+	}
+
+	if ref := provider.Spec.SecretRef; ref != nil {
+		if err := this.validateProviderSecret(ref, req.Namespace); err != nil {
+			return err
+		}
+	}
+	if ref := provider.Spec.SecondarySecretRef; ref != nil {
+		return this.validateProviderSecret(ref, req.Namespace)
+	}
+	return nil
+}
+
+func (this *state) validateProviderSecret(ref *corev1.SecretReference, namespace string) error {
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	// a missing secret is accepted, as it may be created after the provider
+	obj, err := this.secretresc.GetCached(resources.NewObjectName(namespace, ref.Name))
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("cannot get secret %s/%s: %w", namespace, ref.Name, err)
+	}
+	if len(obj.Data().(*corev1.Secret).Data) == 0 {
+		return fmt.Errorf("secret %s/%s contains no credentials", namespace, ref.Name)
+	}
+	return nil
+}
+
+// ValidateDNSEntrySpec checks the provider independent constraints of an entry spec.
+func ValidateDNSEntrySpec(spec *api.DNSEntrySpec, validateName bool) error {
+	if spec.DNSName == "" {
+		return fmt.Errorf("dnsName must be set")
+	}
+	if validateName {
+		if err := dns.ValidateDomainName(spec.DNSName); err != nil {
+			return err
+		}
+	}
+	if len(spec.Targets) > 0 && len(spec.Text) > 0 {
+		return fmt.Errorf("only text or targets possible")
+	}
+	if spec.TargetRef != nil && (len(spec.Targets) > 0 || len(spec.Text) > 0) {
+		return fmt.Errorf("targetRef cannot be combined with targets or text")
+	}
+	if spec.Reference == nil && spec.TargetRef == nil && len(spec.Targets) == 0 && len(spec.Text) == 0 {
+		return fmt.Errorf("no target or text specified")
+	}
+	for i, t := range spec.Targets {
+		if strings.TrimSpace(t) == "" {
+			return fmt.Errorf("target %d must not be empty", i+1)
+		}
+	}
+	if spec.TTL != nil && *spec.TTL <= 0 {
+		return fmt.Errorf("TTL must be greater than zero")
+	}
+	if spec.CNameLookupInterval != nil && *spec.CNameLookupInterval < 0 {
+		return fmt.Errorf("cnameLookupInterval must not be negative")
+	}
+	if spec.RoutingPolicy != nil {
+		if err := validateRoutingPolicy(spec.RoutingPolicy); err != nil {
+			return fmt.Errorf("invalid routingPolicy: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateRoutingPolicy(policy *api.RoutingPolicy) error {
+	if policy.SetIdentifier == "" {
+		return fmt.Errorf("setIdentifier must be set")
+	}
+	switch policy.Type {
+	case dns.RoutingPolicyWeighted:
+		p := dns.NewRoutingPolicy(policy.Type)
+		p.Parameters = policy.Parameters
+		if err := p.CheckParameterKeys([]string{"weight"}, dns.RoutingPolicyParamHealthCheck, dns.RoutingPolicyParamHealthCheckID); err != nil {
+			return err
+		}
+		if weight, err := strconv.ParseInt(policy.Parameters["weight"], 10, 64); err != nil || weight < 0 {
+			return fmt.Errorf("invalid value for parameter weight: %s", policy.Parameters["weight"])
+		}
+		_, hc := policy.Parameters[dns.RoutingPolicyParamHealthCheck]
+		_, hcid := policy.Parameters[dns.RoutingPolicyParamHealthCheckID]
+		if hc && hcid {
+			return fmt.Errorf("parameters %s and %s are exclusive", dns.RoutingPolicyParamHealthCheck, dns.RoutingPolicyParamHealthCheckID)
+		}
+	default:
+		return fmt.Errorf("unsupported type %q", policy.Type)
+	}
+	return nil
+}
+
+// ValidateDNSProviderSpec checks the provider independent constraints of a provider spec.
+func ValidateDNSProviderSpec(spec *api.DNSProviderSpec, knownType func(string) bool) error {
+	if spec.Type == "" {
+		return fmt.Errorf("type must be set")
+	}
+	if !knownType(spec.Type) {
+		return fmt.Errorf("unknown provider type %q", spec.Type)
+	}
+	if spec.Vault != nil {
+		if spec.SecretRef != nil {
+			return fmt.Errorf("secretRef and vault are exclusive")
+		}
+		if spec.Vault.Path == "" || spec.Vault.Role == "" {
+			return fmt.Errorf("vault requires path and role")
+		}
+	} else if spec.SecretRef == nil || spec.SecretRef.Name == "" {
+		return fmt.Errorf("secretRef must be set")
+	}
+	if spec.SecondarySecretRef != nil && spec.SecondarySecretRef.Name == "" {
+		return fmt.Errorf("secondarySecretRef must specify a name")
+	}
+	if _, err := NewTransportConfig(spec.ProxyURL, spec.CABundle, nil); err != nil {
+		return err
+	}
+	if err := validateDomainSelection(spec.Domains); err != nil {
+		return fmt.Errorf("invalid domains: %w", err)
+	}
+	if err := validateZoneSelection(spec.Zones); err != nil {
+		return fmt.Errorf("invalid zones: %w", err)
+	}
+	if spec.DefaultTTL != nil && *spec.DefaultTTL <= 0 {
+		return fmt.Errorf("defaultTTL must be greater than zero")
+	}
+	if spec.RateLimit != nil && (spec.RateLimit.RequestsPerDay <= 0 || spec.RateLimit.Burst < 0) {
+		return fmt.Errorf("rateLimit requires requestsPerDay greater than zero and non-negative burst")
+	}
+	if _, err := newProviderTargetCIDRFilter(spec.TargetCIDRs); err != nil {
+		return fmt.Errorf("invalid targetCIDRs: %w", err)
+	}
+	return nil
+}
+
+func validateDomainSelection(sel *api.DNSSelection) error {
+	if sel == nil {
+		return nil
+	}
+	for _, d := range append(append([]string{}, sel.Include...), sel.Exclude...) {
+		if normalizePolicyDomain(d) == "" {
+			return fmt.Errorf("empty domain")
+		}
+	}
+	for _, inc := range sel.Include {
+		in := normalizePolicyDomain(inc)
+		for _, exc := range sel.Exclude {
+			ex := normalizePolicyDomain(exc)
+			if in == ex || strings.HasSuffix(in, "."+ex) {
+				return fmt.Errorf("included domain %q is excluded by %q", inc, exc)
+			}
+		}
+	}
+	return nil
+}
+
+func validateZoneSelection(sel *api.DNSSelection) error {
+	if sel == nil {
+		return nil
+	}
+	for _, inc := range sel.Include {
+		if inc == "" {
+			return fmt.Errorf("empty zone id")
+		}
+		if containsString(sel.Exclude, inc) {
+			return fmt.Errorf("zone %q is both included and excluded", inc)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/json"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/server/webhook"
+)
+
+var _ = ginkgov2.Describe("Admission validation", func() {
+	int64ptr := func(v int64) *int64 { return &v }
+
+	ginkgov2.It("validates entry specs", func() {
+		valid := func(spec api.DNSEntrySpec) error { return ValidateDNSEntrySpec(&spec, true) }
+
+		Ω(valid(api.DNSEntrySpec{DNSName: "a.example.com", Targets: []string{"1.2.3.4"}})).Should(Succeed())
+		Ω(valid(api.DNSEntrySpec{DNSName: "a.example.com", Text: []string{"foo"}, TTL: int64ptr(60)})).Should(Succeed())
+		Ω(valid(api.DNSEntrySpec{DNSName: "a.example.com", TargetRef: &api.TargetReference{Kind: "Service", Name: "svc"}})).Should(Succeed())
+		Ω(valid(api.DNSEntrySpec{DNSName: "a.example.com", Reference: &api.EntryReference{Name: "base"}})).Should(Succeed())
+
+		Ω(valid(api.DNSEntrySpec{Targets: []string{"1.2.3.4"}})).ShouldNot(Succeed())
+		Ω(valid(api.DNSEntrySpec{DNSName: "a_b.example.com", Targets: []string{"1.2.3.4"}})).ShouldNot(Succeed())
+		Ω(valid(api.DNSEntrySpec{DNSName: "a.example.com"})).ShouldNot(Succeed())
+		Ω(valid(api.DNSEntrySpec{DNSName: "a.example.com", Targets: []string{"1.2.3.4"}, Text: []string{"foo"}})).ShouldNot(Succeed())
+		Ω(valid(api.DNSEntrySpec{DNSName: "a.example.com", Targets: []string{" "}})).ShouldNot(Succeed())
+		Ω(valid(api.DNSEntrySpec{DNSName: "a.example.com", Targets: []string{"1.2.3.4"}, TTL: int64ptr(0)})).ShouldNot(Succeed())
+	})
+
+	ginkgov2.It("validates routing policies", func() {
+		spec := api.DNSEntrySpec{DNSName: "a.example.com", Targets: []string{"1.2.3.4"}}
+		spec.RoutingPolicy = &api.RoutingPolicy{Type: "weighted", SetIdentifier: "id", Parameters: map[string]string{"weight": "10"}}
+		Ω(ValidateDNSEntrySpec(&spec, true)).Should(Succeed())
+
+		spec.RoutingPolicy = &api.RoutingPolicy{Type: "weighted", Parameters: map[string]string{"weight": "10"}}
+		Ω(ValidateDNSEntrySpec(&spec, true)).ShouldNot(Succeed())
+		spec.RoutingPolicy = &api.RoutingPolicy{Type: "weighted", SetIdentifier: "id", Parameters: map[string]string{"weight": "x"}}
+		Ω(ValidateDNSEntrySpec(&spec, true)).ShouldNot(Succeed())
+		spec.RoutingPolicy = &api.RoutingPolicy{Type: "weighted", SetIdentifier: "id", Parameters: map[string]string{"weight": "1", "foo": "bar"}}
+		Ω(ValidateDNSEntrySpec(&spec, true)).ShouldNot(Succeed())
+		spec.RoutingPolicy = &api.RoutingPolicy{Type: "latency", SetIdentifier: "id"}
+		Ω(ValidateDNSEntrySpec(&spec, true)).ShouldNot(Succeed())
+
+		spec.RoutingPolicy = &api.RoutingPolicy{Type: "weighted", SetIdentifier: "id", Parameters: map[string]string{"weight": "1", "healthCheck": "hc"}}
+		Ω(ValidateDNSEntrySpec(&spec, true)).Should(Succeed())
+		spec.RoutingPolicy = &api.RoutingPolicy{Type: "weighted", SetIdentifier: "id", Parameters: map[string]string{"weight": "1", "healthCheckID": "abc"}}
+		Ω(ValidateDNSEntrySpec(&spec, true)).Should(Succeed())
+		spec.RoutingPolicy = &api.RoutingPolicy{Type: "weighted", SetIdentifier: "id", Parameters: map[string]string{"weight": "1", "healthCheck": "hc", "healthCheckID": "abc"}}
+		Ω(ValidateDNSEntrySpec(&spec, true)).ShouldNot(Succeed())
+	})
+
+	ginkgov2.It("validates provider specs", func() {
+		known := func(t string) bool { return t == "aws-route53" }
+		secretRef := &corev1.SecretReference{Name: "secret"}
+
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "aws-route53", SecretRef: secretRef}, known)).Should(Succeed())
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{
+			Type:      "aws-route53",
+			SecretRef: secretRef,
+			Domains:   &api.DNSSelection{Include: []string{"example.com"}, Exclude: []string{"sub.example.com"}},
+			Zones:     &api.DNSSelection{Include: []string{"Z1"}, Exclude: []string{"Z2"}},
+		}, known)).Should(Succeed())
+
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "foo", SecretRef: secretRef}, known)).ShouldNot(Succeed())
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "aws-route53"}, known)).ShouldNot(Succeed())
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{
+			Type:      "aws-route53",
+			SecretRef: secretRef,
+			Domains:   &api.DNSSelection{Include: []string{"sub.example.com"}, Exclude: []string{"example.com"}},
+		}, known)).ShouldNot(Succeed())
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{
+			Type:      "aws-route53",
+			SecretRef: secretRef,
+			Zones:     &api.DNSSelection{Include: []string{"Z1"}, Exclude: []string{"Z1"}},
+		}, known)).ShouldNot(Succeed())
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "aws-route53", SecretRef: secretRef, DefaultTTL: int64ptr(-1)}, known)).ShouldNot(Succeed())
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "aws-route53", SecretRef: secretRef, SecondarySecretRef: &corev1.SecretReference{Name: "secret2"}}, known)).Should(Succeed())
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "aws-route53", SecretRef: secretRef, SecondarySecretRef: &corev1.SecretReference{}}, known)).ShouldNot(Succeed())
+		vault := &api.VaultReference{Path: "aws/creds/dns", Role: "dns"}
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "aws-route53", Vault: vault}, known)).Should(Succeed())
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "aws-route53", SecretRef: secretRef, Vault: vault}, known)).ShouldNot(Succeed())
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "aws-route53", Vault: &api.VaultReference{Path: "aws/creds/dns"}}, known)).ShouldNot(Succeed())
+		proxyURL := "http://proxy.example.com:3128"
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "aws-route53", SecretRef: secretRef, ProxyURL: &proxyURL}, known)).Should(Succeed())
+		proxyURL = "proxy.example.com:3128"
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "aws-route53", SecretRef: secretRef, ProxyURL: &proxyURL}, known)).ShouldNot(Succeed())
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "aws-route53", SecretRef: secretRef, CABundle: []byte("invalid")}, known)).ShouldNot(Succeed())
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "aws-route53", SecretRef: secretRef, TargetCIDRs: &api.TargetCIDRs{Denied: []string{"10.0.0.0/8"}}}, known)).Should(Succeed())
+		Ω(ValidateDNSProviderSpec(&api.DNSProviderSpec{Type: "aws-route53", SecretRef: secretRef, TargetCIDRs: &api.TargetCIDRs{Allowed: []string{"10.0.0.300/8"}}}, known)).ShouldNot(Succeed())
+	})
+
+	ginkgov2.It("determines entry defaults from policies and namespace labels", func() {
+		pol, err := newDNSPolicy("p", &api.DNSPolicySpec{
+			Defaults: []api.EntryDefaults{
+				{Namespaces: []string{"team-a"}, TTL: int64ptr(60)},
+				{TTL: int64ptr(600), Class: &[]string{"garden"}[0]},
+			},
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		s := &state{dnsPolicies: map[string]*dnsPolicy{"p": pol}}
+
+		defaults, err := s.entryDefaultsFor("team-a", nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(*defaults.TTL).Should(Equal(int64(60)))
+		Ω(defaults.Class).Should(BeNil())
+
+		defaults, err = s.entryDefaultsFor("team-b", map[string]string{
+			dns.DEFAULT_TTL_LABEL:      "120",
+			dns.DEFAULT_OWNER_ID_LABEL: "owner",
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(*defaults.TTL).Should(Equal(int64(120)))
+		Ω(*defaults.OwnerId).Should(Equal("owner"))
+		Ω(*defaults.Class).Should(Equal("garden"))
+
+		_, err = s.entryDefaultsFor("team-b", map[string]string{dns.DEFAULT_TTL_LABEL: "x"})
+		Ω(err).Should(HaveOccurred())
+	})
+
+	ginkgov2.It("patches unset entry fields only", func() {
+		owner := "owner"
+		class := "garden"
+		defaults := &api.EntryDefaults{TTL: int64ptr(60), OwnerId: &owner, Class: &class}
+
+		entry := &api.DNSEntry{}
+		Ω(EntryDefaultPatches(entry, defaults)).Should(Equal([]webhook.PatchOperation{
+			{Op: "add", Path: "/spec/ttl", Value: int64(60)},
+			{Op: "add", Path: "/spec/ownerId", Value: "owner"},
+			{Op: "add", Path: "/metadata/annotations", Value: map[string]string{dns.CLASS_ANNOTATION: "garden"}},
+		}))
+
+		entry.Spec.TTL = int64ptr(300)
+		entry.Spec.OwnerId = &owner
+		entry.Annotations = map[string]string{"foo": "bar"}
+		Ω(EntryDefaultPatches(entry, defaults)).Should(Equal([]webhook.PatchOperation{
+			{Op: "add", Path: "/metadata/annotations/dns.gardener.cloud~1class", Value: "garden"},
+		}))
+
+		entry.Annotations[dns.CLASS_ANNOTATION] = "other"
+		Ω(EntryDefaultPatches(entry, defaults)).Should(BeEmpty())
+	})
+
+	ginkgov2.It("defaults unset fields of source generated entries on create and update", func() {
+		s := &state{namespaceLabels: func(name string) (map[string]string, error) {
+			return map[string]string{dns.DEFAULT_TTL_LABEL: "120"}, nil
+		}}
+		request := func(op admissionv1.Operation, entry *api.DNSEntry) *admissionv1.AdmissionRequest {
+			raw, err := json.Marshal(entry)
+			Ω(err).ShouldNot(HaveOccurred())
+			return &admissionv1.AdmissionRequest{Operation: op, Namespace: entry.Namespace, Object: runtime.RawExtension{Raw: raw}}
+		}
+
+		// entry as generated by a source controller for an object without TTL annotation
+		entry := &api.DNSEntry{}
+		entry.Namespace = "team-a"
+		entry.GenerateName = "mysvc-service-"
+		entry.Spec.DNSName = "mysvc.example.com"
+		entry.Spec.Targets = []string{"1.2.3.4"}
+		Ω(s.mutateDNSEntryAdmission(request(admissionv1.Create, entry))).Should(Equal([]webhook.PatchOperation{
+			{Op: "add", Path: "/spec/ttl", Value: int64(120)},
+		}))
+
+		// the source controller resets the defaulted TTL on updates
+		entry.Name = "mysvc-service-x7k2p"
+		entry.Spec.Targets = []string{"1.2.3.5"}
+		Ω(s.mutateDNSEntryAdmission(request(admissionv1.Update, entry))).Should(Equal([]webhook.PatchOperation{
+			{Op: "add", Path: "/spec/ttl", Value: int64(120)},
+		}))
+
+		response := webhook.ReviewMutation(request(admissionv1.Update, entry), s.mutateDNSEntryAdmission)
+		Ω(response.Allowed).Should(BeTrue())
+		Ω(*response.PatchType).Should(Equal(admissionv1.PatchTypeJSONPatch))
+		Ω(string(response.Patch)).Should(Equal(`[{"op":"add","path":"/spec/ttl","value":120}]`))
+
+		entry.Spec.TTL = int64ptr(300)
+		Ω(s.mutateDNSEntryAdmission(request(admissionv1.Update, entry))).Should(BeEmpty())
+		Ω(webhook.ReviewMutation(request(admissionv1.Update, entry), s.mutateDNSEntryAdmission).Patch).Should(BeNil())
+	})
+})
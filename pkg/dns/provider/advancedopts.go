@@ -43,8 +43,8 @@ var AdvancedOptionsDefaults = AdvancedOptions{
 }
 
 func (this *AdvancedOptions) AddOptionsToSet(set config.OptionSet) {
-	set.AddIntOption(&this.BatchSize, OPT_ADVANCED_BATCH_SIZE, "", 50, "batch size for change requests (currently only used for aws-route53)")
-	set.AddIntOption(&this.MaxRetries, OPT_ADVANCED_MAX_RETRIES, "", 7, "maximum number of retries to avoid paging stops on throttling (currently only used for aws-route53)")
+	set.AddIntOption(&this.BatchSize, OPT_ADVANCED_BATCH_SIZE, "", this.BatchSize, "maximum number of record set changes submitted in one batch request (ignored by provider types without batch API, i.e. all except aws-route53 and google-clouddns)")
+	set.AddIntOption(&this.MaxRetries, OPT_ADVANCED_MAX_RETRIES, "", this.MaxRetries, "maximum number of retries to avoid paging stops on throttling (currently only used for aws-route53)")
 	set.AddStringArrayOption(&this.BlockedZones, OPT_ADVANCED_BLOCKED_ZONE, "", []string{}, "Blocks a zone given in the format `zone-id` from a provider as if the zone is not existing.")
 }
 
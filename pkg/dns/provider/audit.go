@@ -0,0 +1,107 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/audit"
+)
+
+func createAuditLog(c controller.Interface) (*audit.Log, error) {
+	sink, err := c.GetStringOption(OPT_AUDIT_SINK)
+	if err != nil || sink == "" {
+		return nil, nil
+	}
+	target, _ := c.GetStringOption(OPT_AUDIT_TARGET)
+	topic, _ := c.GetStringOption(OPT_AUDIT_KAFKA_TOPIC)
+	s, err := audit.NewSink(&audit.Config{Sink: sink, Target: target, KafkaTopic: topic})
+	if err != nil {
+		return nil, err
+	}
+	return audit.NewLog(s), nil
+}
+
+// auditRequests writes an audit record for every applied change request.
+func (this *ChangeGroup) auditRequests(logger logger.LogContext, reqs []*ChangeRequest) {
+	log := this.model.config.AuditLog
+	if log == nil {
+		return
+	}
+	for _, req := range reqs {
+		if !req.Applied {
+			continue
+		}
+		record := newAuditRecord(req)
+		record.Controller = this.model.config.Ident
+		record.Zone = this.model.ZoneId().String()
+		if this.provider != nil {
+			record.ProviderType = this.provider.TypeCode()
+			record.Provider = this.provider.ObjectName().String()
+		}
+		if err := log.Write(record); err != nil {
+			logger.Warnf("cannot write audit record for %s %s record set %s: %s", req.Action, req.Type, record.DNSName, err)
+		}
+	}
+}
+
+func newAuditRecord(req *ChangeRequest) *audit.Record {
+	record := &audit.Record{
+		Time:       time.Now(),
+		Action:     req.Action,
+		RecordType: req.Type,
+		Old:        auditValues(req.Deletion, req.Type),
+		New:        auditValues(req.Addition, req.Type),
+		Object:     requestingObject(req.Done),
+	}
+	for _, set := range []*dns.DNSSet{req.Addition, req.Deletion} {
+		if set != nil {
+			record.DNSName = set.Name.DNSName
+			record.SetIdentifier = set.Name.SetIdentifier
+			record.OwnerId = set.GetOwner()
+			break
+		}
+	}
+	return record
+}
+
+func auditValues(set *dns.DNSSet, rtype string) *audit.Values {
+	if set == nil || set.Sets[rtype] == nil {
+		return nil
+	}
+	rs := set.Sets[rtype]
+	values := &audit.Values{TTL: rs.TTL, Records: []string{}}
+	for _, r := range rs.Records {
+		values.Records = append(values.Records, r.Value)
+	}
+	if set.RoutingPolicy != nil {
+		values.RoutingPolicy = &audit.RoutingPolicy{Type: set.RoutingPolicy.Type, Parameters: set.RoutingPolicy.Parameters}
+	}
+	return values
+}
+
+// requestingObject determines the entry object a change request has been created for.
+func requestingObject(done DoneHandler) string {
+	if u := statusUpdateOf(done); u != nil {
+		return u.Entry.Object().GroupKind().Kind + " " + u.Entry.ObjectName().String()
+	}
+	return ""
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Record is the audit record of an executed change request of a record set.
+type Record struct {
+	// Time of the execution
+	Time time.Time `json:"time"`
+	// Controller is the identifier of the DNS controller executing the change
+	Controller string `json:"controller,omitempty"`
+	// ProviderType is the type of the DNS provider
+	ProviderType string `json:"providerType,omitempty"`
+	// Provider is the DNSProvider object (namespace/name) used for the change
+	Provider string `json:"provider,omitempty"`
+	// Zone is the id of the hosted zone
+	Zone string `json:"zone"`
+	// Action is one of create, update or delete
+	Action string `json:"action"`
+	// DNSName is the domain name of the record set
+	DNSName string `json:"dnsName"`
+	// SetIdentifier is the set identifier of a record set with routing policy
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	// RecordType is the type of the record set
+	RecordType string `json:"recordType"`
+	// Old contains the values of the record set before the change
+	Old *Values `json:"old,omitempty"`
+	// New contains the values of the record set after the change
+	New *Values `json:"new,omitempty"`
+	// Object is the requesting object (kind namespace/name), if known
+	Object string `json:"object,omitempty"`
+	// OwnerId is the owner id of the record set
+	OwnerId string `json:"ownerId,omitempty"`
+	// PreviousHash is the hash of the preceding record
+	PreviousHash string `json:"previousHash"`
+	// Hash is the SHA-256 hash of this record (without hash) chained with the previous hash
+	Hash string `json:"hash"`
+}
+
+// Values are the values of a record set.
+type Values struct {
+	TTL           int64          `json:"ttl"`
+	Records       []string       `json:"records"`
+	RoutingPolicy *RoutingPolicy `json:"routingPolicy,omitempty"`
+}
+
+// RoutingPolicy is the routing policy of a record set.
+type RoutingPolicy struct {
+	Type       string            `json:"type"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// Sink is the destination of the audit records.
+type Sink interface {
+	Write(record *Record) error
+}
+
+// ChainedSink is an optional interface of a Sink providing the hash of the
+// last record already written to continue the hash chain.
+type ChainedSink interface {
+	Sink
+	LastHash() string
+}
+
+// Log writes audit records with a hash chain to a sink.
+// Each record contains the hash of its predecessor, so that any modification or deletion
+// of records can be detected with Verify.
+type Log struct {
+	lock     sync.Mutex
+	sink     Sink
+	lastHash string
+}
+
+// NewLog creates an audit log for the given sink.
+func NewLog(sink Sink) *Log {
+	log := &Log{sink: sink}
+	if c, ok := sink.(ChainedSink); ok {
+		log.lastHash = c.LastHash()
+	}
+	return log
+}
+
+// Write completes the hash chain of the record and writes it to the sink.
+func (this *Log) Write(record *Record) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	record.PreviousHash = this.lastHash
+	hash, err := Hash(record)
+	if err != nil {
+		return err
+	}
+	record.Hash = hash
+	if err := this.sink.Write(record); err != nil {
+		return err
+	}
+	this.lastHash = hash
+	return nil
+}
+
+// Hash calculates the hash of a record ignoring its field Hash.
+func Hash(record *Record) (string, error) {
+	r := *record
+	r.Hash = ""
+	data, err := json.Marshal(&r)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Verify checks the hash chain of the audit records read line by line from the given reader.
+// It returns the number of verified records.
+func Verify(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	count := 0
+	last := ""
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		count++
+		record := &Record{}
+		if err := json.Unmarshal(line, record); err != nil {
+			return count - 1, fmt.Errorf("record %d: %w", count, err)
+		}
+		if count > 1 && record.PreviousHash != last {
+			return count - 1, fmt.Errorf("record %d: chain broken (previous hash mismatch)", count)
+		}
+		hash, err := Hash(record)
+		if err != nil {
+			return count - 1, fmt.Errorf("record %d: %w", count, err)
+		}
+		if hash != record.Hash {
+			return count - 1, fmt.Errorf("record %d: hash mismatch", count)
+		}
+		last = record.Hash
+	}
+	return count, scanner.Err()
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package audit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/external-dns-management/pkg/dns/provider/audit"
+)
+
+type memorySink struct {
+	buf bytes.Buffer
+}
+
+func (this *memorySink) Write(record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	this.buf.Write(append(data, '\n'))
+	return nil
+}
+
+func newRecord(name string) *Record {
+	return &Record{
+		Zone:       "zone1",
+		Action:     "create",
+		DNSName:    name,
+		RecordType: "A",
+		New:        &Values{TTL: 300, Records: []string{"1.2.3.4"}},
+		Object:     "DNSEntry default/" + name,
+	}
+}
+
+var _ = ginkgov2.Describe("Audit log", func() {
+	ginkgov2.It("chains the records", func() {
+		sink := &memorySink{}
+		log := NewLog(sink)
+		Ω(log.Write(newRecord("a.example.com"))).Should(Succeed())
+		Ω(log.Write(newRecord("b.example.com"))).Should(Succeed())
+		Ω(log.Write(newRecord("c.example.com"))).Should(Succeed())
+
+		count, err := Verify(bytes.NewReader(sink.buf.Bytes()))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(count).Should(Equal(3))
+	})
+
+	ginkgov2.It("detects modified and deleted records", func() {
+		sink := &memorySink{}
+		log := NewLog(sink)
+		for _, name := range []string{"a.example.com", "b.example.com", "c.example.com"} {
+			Ω(log.Write(newRecord(name))).Should(Succeed())
+		}
+		lines := strings.Split(strings.TrimSpace(sink.buf.String()), "\n")
+
+		modified := strings.Replace(sink.buf.String(), "1.2.3.4", "5.6.7.8", 1)
+		count, err := Verify(strings.NewReader(modified))
+		Ω(err).Should(MatchError("record 1: hash mismatch"))
+		Ω(count).Should(Equal(0))
+
+		deleted := lines[0] + "\n" + lines[2] + "\n"
+		count, err = Verify(strings.NewReader(deleted))
+		Ω(err).Should(MatchError("record 2: chain broken (previous hash mismatch)"))
+		Ω(count).Should(Equal(1))
+	})
+
+	ginkgov2.It("continues the chain of an existing file", func() {
+		path := filepath.Join(ginkgov2.GinkgoT().TempDir(), "audit.log")
+		sink, err := NewFileSink(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(NewLog(sink).Write(newRecord("a.example.com"))).Should(Succeed())
+
+		sink, err = NewFileSink(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(NewLog(sink).Write(newRecord("b.example.com"))).Should(Succeed())
+
+		data, err := os.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		count, err := Verify(bytes.NewReader(data))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(count).Should(Equal(2))
+	})
+
+	ginkgov2.It("posts records to webhook and Kafka REST proxy", func() {
+		var paths, contentTypes []string
+		var bodies [][]byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			paths = append(paths, r.URL.Path)
+			contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+			bodies = append(bodies, body)
+		}))
+		defer server.Close()
+
+		sink, err := NewSink(&Config{Sink: SinkWebhook, Target: server.URL + "/audit"})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(sink.Write(newRecord("a.example.com"))).Should(Succeed())
+		sink, err = NewSink(&Config{Sink: SinkKafka, Target: server.URL, KafkaTopic: "dns-audit"})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(sink.Write(newRecord("b.example.com"))).Should(Succeed())
+
+		Ω(paths).Should(Equal([]string{"/audit", "/topics/dns-audit"}))
+		Ω(contentTypes).Should(Equal([]string{"application/json", "application/vnd.kafka.json.v2+json"}))
+		record := &Record{}
+		Ω(json.Unmarshal(bodies[0], record)).Should(Succeed())
+		Ω(record.DNSName).Should(Equal("a.example.com"))
+		Ω(string(bodies[1])).Should(HavePrefix(`{"records":[{"key":"zone1","value":{`))
+	})
+
+	ginkgov2.It("rejects invalid configurations", func() {
+		_, err := NewSink(&Config{Sink: "syslog", Target: "x"})
+		Ω(err).Should(HaveOccurred())
+		_, err = NewSink(&Config{Sink: SinkKafka, Target: "http://proxy"})
+		Ω(err).Should(HaveOccurred())
+		_, err = NewSink(&Config{Sink: SinkFile})
+		Ω(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SinkFile writes the records as JSON lines to a file
+	SinkFile = "file"
+	// SinkWebhook posts every record as JSON to a HTTP endpoint
+	SinkWebhook = "webhook"
+	// SinkKafka produces every record to a Kafka topic using a Kafka REST proxy
+	SinkKafka = "kafka"
+)
+
+// Config is the configuration of the audit sink.
+type Config struct {
+	// Sink is the sink type (file, webhook or kafka)
+	Sink string
+	// Target is the file path, the webhook URL or the URL of the Kafka REST proxy
+	Target string
+	// KafkaTopic is the Kafka topic
+	KafkaTopic string
+}
+
+// NewSink creates the sink for the given configuration.
+func NewSink(config *Config) (Sink, error) {
+	if config.Target == "" {
+		return nil, fmt.Errorf("missing target for audit sink %q", config.Sink)
+	}
+	switch config.Sink {
+	case SinkFile:
+		return NewFileSink(config.Target)
+	case SinkWebhook:
+		return NewWebhookSink(config.Target), nil
+	case SinkKafka:
+		if config.KafkaTopic == "" {
+			return nil, fmt.Errorf("missing Kafka topic for audit sink %q", config.Sink)
+		}
+		return NewKafkaSink(config.Target, config.KafkaTopic), nil
+	default:
+		return nil, fmt.Errorf("invalid audit sink %q (supported: %s, %s, %s)", config.Sink, SinkFile, SinkWebhook, SinkKafka)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type fileSink struct {
+	lock     sync.Mutex
+	file     *os.File
+	lastHash string
+}
+
+var _ ChainedSink = &fileSink{}
+
+// NewFileSink creates a sink appending the records as JSON lines to the given file.
+// The hash chain is continued with the last record found in an existing file.
+func NewFileSink(path string) (Sink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open audit file: %w", err)
+	}
+	lastHash, err := readLastHash(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("cannot read audit file %s: %w", path, err)
+	}
+	return &fileSink{file: file, lastHash: lastHash}, nil
+}
+
+func readLastHash(r io.Reader) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var last []byte
+	for scanner.Scan() {
+		if line := scanner.Bytes(); len(line) > 0 {
+			last = append(last[:0], line...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if last == nil {
+		return "", nil
+	}
+	record := &Record{}
+	if err := json.Unmarshal(last, record); err != nil {
+		return "", err
+	}
+	return record.Hash, nil
+}
+
+func (this *fileSink) LastHash() string {
+	return this.lastHash
+}
+
+func (this *fileSink) Write(record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if _, err := this.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return this.file.Sync()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type httpSink struct {
+	client      *http.Client
+	url         string
+	contentType string
+	body        func(record *Record) interface{}
+}
+
+// NewWebhookSink creates a sink posting every record as JSON to the given URL.
+func NewWebhookSink(url string) Sink {
+	return &httpSink{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		url:         url,
+		contentType: "application/json",
+		body:        func(record *Record) interface{} { return record },
+	}
+}
+
+// NewKafkaSink creates a sink producing every record to a Kafka topic
+// using the REST proxy API v2 at the given URL. The zone id is used as message key.
+func NewKafkaSink(url, topic string) Sink {
+	return &httpSink{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		url:         strings.TrimSuffix(url, "/") + "/topics/" + topic,
+		contentType: "application/vnd.kafka.json.v2+json",
+		body: func(record *Record) interface{} {
+			return &kafkaRecords{Records: []kafkaRecord{{Key: record.Zone, Value: record}}}
+		},
+	}
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string  `json:"key,omitempty"`
+	Value *Record `json:"value"`
+}
+
+func (this *httpSink) Write(record *Record) error {
+	data, err := json.Marshal(this.body(record))
+	if err != nil {
+		return err
+	}
+	resp, err := this.client.Post(this.url, this.contentType, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit sink %s responded with status %d", this.url, resp.StatusCode)
+	}
+	return nil
+}
@@ -27,9 +27,13 @@ import (
 	"github.com/gardener/external-dns-management/pkg/dns"
 	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+	"github.com/gardener/external-dns-management/pkg/server/tracing"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -59,6 +63,62 @@ func NewChangeRequest(action string, rtype string, del, add *dns.DNSSet, done Do
 	return r
 }
 
+// Description returns a human readable description of the applied change request.
+func (this *ChangeRequest) Description() string {
+	return this.description("created", "updated", "deleted")
+}
+
+// PlannedDescription returns a human readable description of the change request to be applied.
+func (this *ChangeRequest) PlannedDescription() string {
+	return this.description(R_CREATE, R_UPDATE, R_DELETE)
+}
+
+func (this *ChangeRequest) description(created, updated, deleted string) string {
+	switch this.Action {
+	case R_CREATE:
+		msg := fmt.Sprintf("%s %s record set %s: %s", created, this.Type, this.Addition.Name, recordString(this.Addition, this.Type))
+		if this.Addition.RoutingPolicy != nil {
+			msg += " with routing policy " + routingPolicyString(this.Addition.RoutingPolicy)
+		}
+		return msg
+	case R_DELETE:
+		return fmt.Sprintf("%s %s record set %s: %s", deleted, this.Type, this.Deletion.Name, recordString(this.Deletion, this.Type))
+	default:
+		msg := fmt.Sprintf("%s %s record set %s: %s -> %s", updated, this.Type, this.Addition.Name,
+			recordString(this.Deletion, this.Type), recordString(this.Addition, this.Type))
+		if !reflect.DeepEqual(this.Deletion.RoutingPolicy, this.Addition.RoutingPolicy) {
+			msg += fmt.Sprintf(", routing policy %s -> %s",
+				routingPolicyString(this.Deletion.RoutingPolicy), routingPolicyString(this.Addition.RoutingPolicy))
+		}
+		return msg
+	}
+}
+
+func recordString(set *dns.DNSSet, rtype string) string {
+	if set == nil || set.Sets[rtype] == nil {
+		return "no records"
+	}
+	return set.Sets[rtype].RecordString()
+}
+
+func routingPolicyString(policy *dns.RoutingPolicy) string {
+	if policy == nil {
+		return "none"
+	}
+	var params []string
+	for k, v := range policy.Parameters {
+		params = append(params, k+"="+v)
+	}
+	sort.Strings(params)
+	return fmt.Sprintf("%s(%s)", policy.Type, strings.Join(params, ","))
+}
+
+// ChangeRecorder is an optional interface of a DoneHandler.
+// It is informed about every successfully applied change request.
+type ChangeRecorder interface {
+	ChangeApplied(req *ChangeRequest)
+}
+
 type applyingDoneHandler struct {
 	changeRequest *ChangeRequest
 	inner         DoneHandler
@@ -82,13 +142,36 @@ func (h *applyingDoneHandler) Throttled() {
 	}
 }
 
+// Skipped finishes a change request intentionally not applied at the provider.
+func (h *applyingDoneHandler) Skipped() {
+	if h.inner != nil {
+		h.inner.Succeeded()
+	}
+}
+
 func (h *applyingDoneHandler) Succeeded() {
 	h.changeRequest.Applied = true
+	if r, ok := h.inner.(ChangeRecorder); ok {
+		r.ChangeApplied(h.changeRequest)
+	}
 	if h.inner != nil {
 		h.inner.Succeeded()
 	}
 }
 
+// RetiredOwnership is implemented by ownerships knowing recently deactivated
+// owner ids whose records should be cleaned up.
+type RetiredOwnership interface {
+	RetiredPolicy(id string) *api.DeletionPolicy
+	HasRetiredIds() bool
+}
+
+// OwnerChangeRecorder is implemented by ownerships recording the time of the
+// last change applied for an owner id.
+type OwnerChangeRecorder interface {
+	ChangeApplied(id string, t time.Time)
+}
+
 type ChangeGroup struct {
 	name          string
 	provider      DNSProvider
@@ -130,6 +213,9 @@ func (this *ChangeGroup) cleanup(logger logger.LogContext, model *ChangeModel) b
 						e.Trigger(logger)
 					}
 				} else {
+					if !model.deleteOrphan(s.Name) {
+						continue
+					}
 					model.Infof("found unapplied managed set '%s'", s.Name)
 					var done DoneHandler
 					for _, e := range model.context.entries {
@@ -142,30 +228,151 @@ func (this *ChangeGroup) cleanup(logger logger.LogContext, model *ChangeModel) b
 						mod = true
 						this.addDeleteRequest(s, ty, model.wrappedDoneHandler(s.Name, done))
 					}
+					for _, reg := range s.ExternalDNSRegistry {
+						this.addDeleteRequest(reg, dns.RS_TXT, model.wrappedDoneHandler(s.Name, done))
+					}
 				}
+			} else if policy := model.retiredPolicy(s); policy != nil {
+				model.Infof("found set '%s' of deactivated owner %s -> cleanup (%s)", s.Name, s.GetOwner(), *policy)
+				mod = this.cleanupRetired(s, *policy, model.wrappedDoneHandler(s.Name, nil)) || mod
 			}
 		}
 	}
 	return mod
 }
 
+// cleanupRetired deletes the record set of a deactivated owner id or only its
+// ownership record according to the deactivation policy of the owner.
+func (this *ChangeGroup) cleanupRetired(s *dns.DNSSet, policy api.DeletionPolicy, done DoneHandler) bool {
+	if policy == api.DeletionPolicyRetain {
+		if _, ok := s.Sets[dns.RS_META]; !ok {
+			return false
+		}
+		this.addDeleteRequest(s, dns.RS_META, done)
+		return true
+	}
+	for ty := range s.Sets {
+		this.addDeleteRequest(s, ty, done)
+	}
+	for _, reg := range s.ExternalDNSRegistry {
+		this.addDeleteRequest(reg, dns.RS_TXT, done)
+	}
+	return len(s.Sets) > 0
+}
+
 func (this *ChangeGroup) update(logger logger.LogContext, model *ChangeModel) bool {
 	ok := true
 	model.Infof("reconcile entries for %s (with %d requests)", this.name, len(this.requests))
 
 	reqs := this.requests
+	if len(reqs) > 0 && this.model.config.Dryrun {
+		this.planRequests(logger, reqs)
+		return true
+	}
+	reqs = this.skipForbiddenDeletions(logger, reqs)
+	var dryrun []*ChangeRequest
+	reqs, dryrun = splitDryRunRequests(reqs)
+	if len(dryrun) > 0 {
+		this.planRequests(logger, dryrun)
+	}
 	if len(reqs) > 0 {
 		this.model.context.dnsTicker.TickWhile(logger, func() {
+			_, span := tracing.Start(model.context.ctx, "execute requests", trace.WithAttributes(zoneAttributes(model.ZoneId())...),
+				trace.WithAttributes(attribute.String("dns.provider", this.provider.ObjectName().String()), attribute.Int("dns.requests", len(reqs))))
 			err := this.provider.ExecuteRequests(logger, model.context.zone.getZone(), this.model.zonestate, reqs)
+			tracing.End(span, err)
 			if err != nil {
 				model.Errorf("entry reconciliation failed for %s: %s", this.name, err)
 				ok = false
 			}
+			this.auditRequests(logger, reqs)
+			this.notifyRequests(reqs)
+			this.recordOwnerChanges(reqs)
+			model.context.conflicts.recordWritten(model.ZoneId(), reqs)
 		})
 	}
 	return ok
 }
 
+// recordOwnerChanges records the time of applied changes for the owners of the changed record sets.
+func (this *ChangeGroup) recordOwnerChanges(reqs []*ChangeRequest) {
+	recorder, ok := this.model.ownership.(OwnerChangeRecorder)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	for _, req := range reqs {
+		if !req.Applied {
+			continue
+		}
+		set := req.Addition
+		if set == nil {
+			set = req.Deletion
+		}
+		if set != nil && set.GetOwner() != "" {
+			recorder.ChangeApplied(set.GetOwner(), now)
+		}
+	}
+}
+
+// planRequests reports the change requests in dry run mode without executing them.
+func (this *ChangeGroup) planRequests(logger logger.LogContext, reqs []*ChangeRequest) {
+	var updates []*StatusUpdate
+	planned := map[*StatusUpdate][]string{}
+	for _, req := range reqs {
+		desc := req.PlannedDescription()
+		logger.Infof("dry run: planned change %s", desc)
+		if u := statusUpdateOf(req.Done); u != nil {
+			if _, ok := planned[u]; !ok {
+				updates = append(updates, u)
+			}
+			planned[u] = append(planned[u], desc)
+		}
+	}
+	for _, u := range updates {
+		u.Planned(planned[u])
+	}
+}
+
+// skipForbiddenDeletions drops the delete requests for providers with upsert-only policy.
+func (this *ChangeGroup) skipForbiddenDeletions(logger logger.LogContext, reqs []*ChangeRequest) []*ChangeRequest {
+	var apply []*ChangeRequest
+	for _, req := range reqs {
+		if req.Action != R_DELETE {
+			apply = append(apply, req)
+			continue
+		}
+		p := this.model.context.providers.LookupFor(req.Deletion.Name.DNSName)
+		if p == nil || !p.UpsertOnly() {
+			apply = append(apply, req)
+			continue
+		}
+		desc := req.PlannedDescription()
+		logger.Infof("upsert only: skipped change %s", desc)
+		if u := statusUpdateOf(req.Done); u != nil {
+			u.ChangeSkipped(req, "upsert only")
+		}
+		if h, ok := req.Done.(*applyingDoneHandler); ok {
+			h.Skipped()
+		}
+	}
+	return apply
+}
+
+// splitDryRunRequests separates the requests of entries in dry run mode (annotation dns.gardener.cloud/dry-run).
+// The requests of deleted entries are always applied, as the records provisioned before switching
+// to dry run mode must be removed to release the entry.
+func splitDryRunRequests(reqs []*ChangeRequest) (apply, dryrun []*ChangeRequest) {
+	for _, req := range reqs {
+		if u := statusUpdateOf(req.Done); u != nil && !u.delete && u.Entry.IsDryRun() {
+			dryrun = append(dryrun, req)
+		} else {
+			apply = append(apply, req)
+		}
+	}
+	return
+}
+
 func (this *ChangeGroup) addCreateRequest(dnsset *dns.DNSSet, rtype string, done DoneHandler) {
 	this.addChangeRequest(R_CREATE, nil, dnsset, rtype, done)
 }
@@ -196,6 +403,7 @@ type ChangeModel struct {
 	providergroups map[string]*ChangeGroup
 	zonestate      DNSZoneState
 	failedDNSNames dns.DNSNameSet
+	orphans        dns.DNSNameSet
 }
 
 type ChangeResult struct {
@@ -213,6 +421,7 @@ func NewChangeModel(logger logger.LogContext, ownership dns.Ownership, req *zone
 		applied:        map[dns.DNSSetName]*dns.DNSSet{},
 		providergroups: map[string]*ChangeGroup{},
 		failedDNSNames: dns.DNSNameSet{},
+		orphans:        dns.DNSNameSet{},
 	}
 }
 
@@ -266,12 +475,17 @@ func (this *ChangeModel) Setup() error {
 		return fmt.Errorf("no provider found for zone %q", this.ZoneId())
 	}
 	this.context.dnsTicker.TickWhile(this, func() {
+		_, span := tracing.Start(this.context.ctx, "get zone state", trace.WithAttributes(zoneAttributes(this.ZoneId())...))
 		this.zonestate, err = provider.GetZoneState(this.context.zone.getZone())
+		tracing.End(span, err)
 	})
 	if err != nil {
 		return err
 	}
 	sets := this.zonestate.GetDNSSets()
+	if this.config.ExternalDNSRegistry != nil {
+		this.config.ExternalDNSRegistry.Resolve(sets)
+	}
 	this.context.zone.SetOwners(sets.GetOwners())
 	this.dangling = newChangeGroup("dangling entries", provider, this)
 	for setName, set := range sets {
@@ -337,11 +551,21 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name dns.DNSSetName, upda
 	if !delete {
 		this.ApplySpec(newset, oldset, p, spec)
 	}
+	if apply && !delete {
+		if err := this.checkOwnerConflict(name, oldset); err != nil {
+			if done != nil {
+				done.Failed(err)
+			} else {
+				this.Warnf("no done handler and %s", err)
+			}
+			return ChangeResult{Error: err}
+		}
+	}
 	mod := false
 	if oldset != nil {
 		this.Debugf("found old for %s %q", oldset.GetKind(), oldset.Name)
 		if this.IsForeign(oldset) {
-			err := &perrs.AlreadyBusyForOwner{Name: name, EntryCreatedAt: createdAt, Owner: oldset.GetOwner()}
+			err := &perrs.AlreadyBusyForOwner{Name: name, EntryCreatedAt: createdAt, Owner: foreignOwner(oldset)}
 			retry := p.ReportZoneStateConflict(this.context.zone.getZone(), err)
 			if done != nil {
 				if apply && !retry {
@@ -361,6 +585,23 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name dns.DNSSetName, upda
 				}
 				this.Infof("catch entry %q by reassigning owner", name)
 			}
+			if delete && retainRecords(p, spec) {
+				if _, ok := oldset.Sets[dns.RS_META]; ok {
+					if apply {
+						this.Infof("retain records of %q, only removing ownership", name)
+						view.addDeleteRequest(oldset, dns.RS_META, done)
+					}
+					mod = true
+				}
+				mod = this.updateExternalDNSRegistry(apply, view, oldset, newset, done) || mod
+				if apply {
+					this.applied[name] = newset
+					if !mod && done != nil {
+						done.Succeeded()
+					}
+				}
+				return ChangeResult{Modified: mod}
+			}
 			for ty, rset := range newset.Sets {
 				curset := oldset.Sets[ty]
 				if curset == nil {
@@ -399,6 +640,7 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name dns.DNSSetName, upda
 					mod = true
 				}
 			}
+			mod = this.updateExternalDNSRegistry(apply, view, oldset, newset, done) || mod
 		}
 	} else {
 		if !delete {
@@ -408,12 +650,16 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name dns.DNSSetName, upda
 				for ty := range newset.Sets {
 					view.addCreateRequest(newset, ty, done)
 				}
+				this.updateExternalDNSRegistry(apply, view, nil, newset, done)
 			}
 			mod = true
 		}
 	}
 	if apply {
 		this.applied[name] = newset
+		if u := statusUpdateOf(done); u != nil && !delete {
+			u.records = newset
+		}
 		if !mod && done != nil {
 			done.Succeeded()
 		}
@@ -421,24 +667,71 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name dns.DNSSetName, upda
 	return ChangeResult{Modified: mod}
 }
 
+// retainRecords checks whether the records of a deleted entry should be kept,
+// either by the deletion policy of the entry or by the default of the provider.
+func retainRecords(p DNSProvider, spec TargetSpec) bool {
+	policy := spec.DeletionPolicy()
+	if policy == nil {
+		policy = p.DefaultDeletionPolicy()
+	}
+	return policy != nil && *policy == api.DeletionPolicyRetain
+}
+
 func (this *ChangeModel) Cleanup(logger logger.LogContext) bool {
 	mod := false
 	for _, view := range this.providergroups {
 		mod = view.cleanup(logger, this) || mod
 	}
 	mod = this.dangling.cleanup(logger, this) || mod
+	metrics.ReportOrphanedRecords(this.ZoneId(), this.context.zone.RetainOrphans(this.orphans))
 	if mod {
 		logger.Infof("found entries to be deleted")
 	}
 	return mod
 }
 
+// deleteOrphan checks whether an orphaned record set, i.e. a managed record set
+// without a corresponding entry, should be deleted now. If a grace period is
+// configured, the deletion is delayed until the record set has been orphaned
+// for the complete grace period.
+func (this *ChangeModel) deleteOrphan(name dns.DNSSetName) bool {
+	if this.config.OrphanGracePeriod <= 0 && !this.config.OrphanDryrun {
+		return true
+	}
+	this.orphans.Add(name)
+	since := this.context.zone.OrphanedSince(name, time.Now())
+	if this.config.OrphanDryrun {
+		this.Infof("found orphaned managed set '%s' (since %s) -> preserve unchanged (orphan dry run)", name, since.Format(time.RFC3339))
+		return false
+	}
+	remaining := this.config.OrphanGracePeriod - time.Since(since)
+	if remaining > 0 {
+		this.Infof("found orphaned managed set '%s' -> preserve for %s", name, remaining.Round(time.Second))
+		if zone := this.context.zone; zone.nextTrigger == 0 || remaining < zone.nextTrigger {
+			zone.nextTrigger = remaining
+		}
+		return false
+	}
+	metrics.AddDeletedOrphanedRecords(this.ZoneId(), 1)
+	return true
+}
+
 func (this *ChangeModel) Update(logger logger.LogContext) error {
 	failed := false
-	for _, view := range this.providergroups {
+	planned := 0
+	// the requests not executed yet are shown by the debug state endpoint
+	outstanding := this.Requests()
+	this.context.zone.setOutstandingRequests(outstanding)
+	defer this.context.zone.setOutstandingRequests(nil)
+	for _, view := range this.groups() {
+		planned += len(view.requests)
 		failed = !view.update(logger, this) || failed
+		outstanding = outstanding[len(view.requests):]
+		this.context.zone.setOutstandingRequests(outstanding)
+	}
+	if this.config.Dryrun {
+		metrics.ReportPlannedChanges(this.ZoneId(), planned)
 	}
-	failed = !this.dangling.update(logger, this) || failed
 	if failed {
 		return fmt.Errorf("entry reconciliation failed for some provider(s)")
 	}
@@ -485,6 +778,12 @@ func (this *changeModelDoneHandler) Succeeded() {
 	}
 }
 
+func (this *changeModelDoneHandler) ChangeApplied(req *ChangeRequest) {
+	if r, ok := this.inner.(ChangeRecorder); ok {
+		r.ChangeApplied(req)
+	}
+}
+
 func (this *changeModelDoneHandler) Throttled() {
 	if this.inner != nil {
 		this.inner.Throttled()
@@ -498,8 +797,18 @@ func (this *ChangeModel) Owns(set *dns.DNSSet) bool {
 	return set.GetKind() != api.DNSLockKind && set.IsOwnedBy(this.ownership)
 }
 
+// retiredPolicy returns the deactivation policy for a record set of a
+// recently deactivated owner id, or nil if it must not be touched.
+func (this *ChangeModel) retiredPolicy(set *dns.DNSSet) *api.DeletionPolicy {
+	retired, ok := this.ownership.(RetiredOwnership)
+	if !ok || set.GetKind() == api.DNSLockKind || set.GetOwner() == "" {
+		return nil
+	}
+	return retired.RetiredPolicy(set.GetOwner())
+}
+
 func (this *ChangeModel) IsForeign(set *dns.DNSSet) bool {
-	return set.IsForeign(this.ownership)
+	return set.IsForeign(this.ownership) || this.isExternalDNSForeign(set)
 }
 
 func (this *ChangeModel) setOwner(set *dns.DNSSet, id string) bool {
@@ -528,7 +837,7 @@ func (this *ChangeModel) ApplySpec(set *dns.DNSSet, base *dns.DNSSet, provider D
 		ttl := t.GetTTL()
 		if t.GetRecordType() == dns.RS_CNAME && len(spec.Targets()) > 1 {
 			cnames = append(cnames, t.GetHostName())
-			ipv4addrs, ipv6addrs, err := lookupHosts(t.GetHostName())
+			ipv4addrs, ipv6addrs, err := this.config.HostResolver.LookupHosts(t.GetHostName())
 			if err == nil {
 				for _, addr := range ipv4addrs {
 					AddRecord(targetsets, dns.RS_A, addr, ttl)
@@ -0,0 +1,277 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgov2.Describe("Change request", func() {
+	name := dns.DNSSetName{DNSName: "a.example.com"}
+
+	ginkgov2.It("describes created and deleted record sets", func() {
+		set := dns.NewDNSSet(name, nil)
+		set.SetRecordSet(dns.RS_A, 300, "1.2.3.4", "5.6.7.8")
+
+		req := NewChangeRequest(R_CREATE, dns.RS_A, nil, set, nil)
+		Ω(req.Description()).Should(Equal("created A record set a.example.com: [1.2.3.4, 5.6.7.8]"))
+		req = NewChangeRequest(R_DELETE, dns.RS_A, set, nil, nil)
+		Ω(req.Description()).Should(Equal("deleted A record set a.example.com: [1.2.3.4, 5.6.7.8]"))
+		Ω(req.PlannedDescription()).Should(Equal("delete A record set a.example.com: [1.2.3.4, 5.6.7.8]"))
+	})
+
+	ginkgov2.It("describes updated record sets and routing policies", func() {
+		weighted := dns.DNSSetName{DNSName: "a.example.com", SetIdentifier: "id1"}
+		old := dns.NewDNSSet(weighted, dns.NewRoutingPolicy("weighted", "weight", "10"))
+		old.SetRecordSet(dns.RS_CNAME, 300, "b.example.com")
+		new := dns.NewDNSSet(weighted, dns.NewRoutingPolicy("weighted", "weight", "0"))
+		new.SetRecordSet(dns.RS_CNAME, 300, "c.example.com")
+
+		req := NewChangeRequest(R_UPDATE, dns.RS_CNAME, old, new, nil)
+		Ω(req.Description()).Should(Equal("updated CNAME record set a.example.com#id1: [b.example.com] -> [c.example.com]" +
+			", routing policy weighted(weight=10) -> weighted(weight=0)"))
+		req = NewChangeRequest(R_CREATE, dns.RS_CNAME, nil, new, nil)
+		Ω(req.Description()).Should(Equal("created CNAME record set a.example.com#id1: [c.example.com] with routing policy weighted(weight=0)"))
+	})
+})
+
+var _ = ginkgov2.Describe("Orphaned record sets", func() {
+	name := dns.DNSSetName{DNSName: "a.example.com"}
+
+	newModel := func(zone *dnsHostedZone, config Config) *ChangeModel {
+		req := &zoneReconciliation{zone: zone}
+		return NewChangeModel(logger.New(), nil, req, config)
+	}
+	newZone := func() *dnsHostedZone {
+		return newDNSHostedZone(time.Second, NewDNSHostedZone("mock", "z1", "example.com", "", nil, false))
+	}
+
+	ginkgov2.It("are deleted immediately without grace period", func() {
+		model := newModel(newZone(), Config{})
+		Ω(model.deleteOrphan(name)).Should(BeTrue())
+		Ω(model.orphans.IsEmpty()).Should(BeTrue())
+	})
+
+	ginkgov2.It("are preserved during the grace period", func() {
+		zone := newZone()
+		model := newModel(zone, Config{OrphanGracePeriod: time.Hour})
+		Ω(model.deleteOrphan(name)).Should(BeFalse())
+		Ω(zone.nextTrigger).Should(BeNumerically(">", 59*time.Minute))
+		Ω(zone.RetainOrphans(model.orphans)).Should(Equal(1))
+
+		zone.orphans[name] = time.Now().Add(-2 * time.Hour)
+		model = newModel(zone, Config{OrphanGracePeriod: time.Hour})
+		Ω(model.deleteOrphan(name)).Should(BeTrue())
+	})
+
+	ginkgov2.It("are forgotten if no longer orphaned", func() {
+		zone := newZone()
+		model := newModel(zone, Config{OrphanGracePeriod: time.Hour})
+		Ω(model.deleteOrphan(name)).Should(BeFalse())
+		Ω(zone.RetainOrphans(dns.DNSNameSet{})).Should(Equal(0))
+	})
+
+	ginkgov2.It("are never deleted in orphan dry run mode", func() {
+		zone := newZone()
+		zone.orphans[name] = time.Now().Add(-2 * time.Hour)
+		model := newModel(zone, Config{OrphanGracePeriod: time.Hour, OrphanDryrun: true})
+		Ω(model.deleteOrphan(name)).Should(BeFalse())
+	})
+})
+
+type testOwnership string
+
+func (o testOwnership) IsResponsibleFor(id string) bool { return id == string(o) }
+func (o testOwnership) GetIds() utils.StringSet         { return utils.NewStringSet(string(o)) }
+
+var _ = ginkgov2.Describe("Planned cleanup", func() {
+	ginkgov2.It("deletes the owned record sets without entries", func() {
+		zone := newDNSHostedZone(time.Second, NewDNSHostedZone("mock", "z1", "example.com", "", nil, false))
+		model := NewChangeModel(logger.New(), testOwnership("owner"), &zoneReconciliation{zone: zone}, Config{})
+		model.dangling = newChangeGroup("dangling entries", nil, model)
+		add := func(name, owner string) dns.DNSSetName {
+			set := dns.NewDNSSet(dns.DNSSetName{DNSName: name}, nil)
+			set.SetRecordSet(dns.RS_A, 300, "1.2.3.4")
+			if owner != "" {
+				set.SetOwner(owner)
+			}
+			model.dangling.dnssets[set.Name] = set
+			return set.Name
+		}
+		add("orphan.example.com", "owner")
+		add("foreign.example.com", "other")
+		add("unmanaged.example.com", "")
+		model.applied[add("applied.example.com", "owner")] = nil
+
+		model.PlanCleanup()
+		reqs := model.Requests()
+		Ω(reqs).Should(HaveLen(2))
+		types := []string{reqs[0].Type, reqs[1].Type}
+		Ω(types).Should(ConsistOf(dns.RS_A, dns.RS_META))
+		for _, req := range reqs {
+			Ω(req.Action).Should(Equal(R_DELETE))
+			Ω(req.Deletion.Name.DNSName).Should(Equal("orphan.example.com"))
+		}
+	})
+})
+
+type retiredOwnership struct {
+	testOwnership
+	retired map[string]api.DeletionPolicy
+}
+
+func (o retiredOwnership) RetiredPolicy(id string) *api.DeletionPolicy {
+	if p, ok := o.retired[id]; ok {
+		return &p
+	}
+	return nil
+}
+
+func (o retiredOwnership) HasRetiredIds() bool {
+	return len(o.retired) > 0
+}
+
+var _ = ginkgov2.Describe("Cleanup of deactivated owners", func() {
+	plan := func(policy api.DeletionPolicy) ChangeRequests {
+		zone := newDNSHostedZone(time.Second, NewDNSHostedZone("mock", "z1", "example.com", "", nil, false))
+		ownership := retiredOwnership{testOwnership("owner"), map[string]api.DeletionPolicy{"retired": policy}}
+		model := NewChangeModel(logger.New(), ownership, &zoneReconciliation{zone: zone}, Config{})
+		model.dangling = newChangeGroup("dangling entries", nil, model)
+		for name, owner := range map[string]string{"retired.example.com": "retired", "foreign.example.com": "other"} {
+			set := dns.NewDNSSet(dns.DNSSetName{DNSName: name}, nil)
+			set.SetRecordSet(dns.RS_A, 300, "1.2.3.4")
+			set.SetOwner(owner)
+			model.dangling.dnssets[set.Name] = set
+		}
+		model.PlanCleanup()
+		return model.Requests()
+	}
+
+	ginkgov2.It("deletes the record sets with policy Delete", func() {
+		reqs := plan(api.DeletionPolicyDelete)
+		Ω(reqs).Should(HaveLen(2))
+		types := []string{reqs[0].Type, reqs[1].Type}
+		Ω(types).Should(ConsistOf(dns.RS_A, dns.RS_META))
+		for _, req := range reqs {
+			Ω(req.Action).Should(Equal(R_DELETE))
+			Ω(req.Deletion.Name.DNSName).Should(Equal("retired.example.com"))
+		}
+	})
+
+	ginkgov2.It("removes only the ownership with policy Retain", func() {
+		reqs := plan(api.DeletionPolicyRetain)
+		Ω(reqs).Should(HaveLen(1))
+		Ω(reqs[0].Action).Should(Equal(R_DELETE))
+		Ω(reqs[0].Type).Should(Equal(dns.RS_META))
+		Ω(reqs[0].Deletion.Name.DNSName).Should(Equal("retired.example.com"))
+	})
+})
+
+type recordingDoneHandler struct {
+	succeeded int
+	applied   []*ChangeRequest
+}
+
+func (h *recordingDoneHandler) SetInvalid(err error)             {}
+func (h *recordingDoneHandler) Failed(err error)                 {}
+func (h *recordingDoneHandler) Throttled()                       {}
+func (h *recordingDoneHandler) Succeeded()                       { h.succeeded++ }
+func (h *recordingDoneHandler) ChangeApplied(req *ChangeRequest) { h.applied = append(h.applied, req) }
+
+var _ = ginkgov2.Describe("Skipped change requests", func() {
+	ginkgov2.It("succeed without being reported as applied", func() {
+		set := dns.NewDNSSet(dns.DNSSetName{DNSName: "a.example.com"}, nil)
+		set.SetRecordSet(dns.RS_A, 300, "1.2.3.4")
+		done := &recordingDoneHandler{}
+
+		req := NewChangeRequest(R_DELETE, dns.RS_A, set, nil, done)
+		req.Done.(*applyingDoneHandler).Skipped()
+		Ω(done.succeeded).Should(Equal(1))
+		Ω(done.applied).Should(BeEmpty())
+		Ω(req.Applied).Should(BeFalse())
+
+		req.Done.Succeeded()
+		Ω(done.applied).Should(Equal([]*ChangeRequest{req}))
+	})
+})
+
+var _ = ginkgov2.Describe("Drift check", func() {
+	ginkgov2.It("is due once per period", func() {
+		zone := newDNSHostedZone(time.Second, NewDNSHostedZone("mock", "z1", "example.com", "", nil, false))
+		now := time.Now()
+		Ω(zone.StartDriftCheck(now, 0)).Should(BeFalse())
+		Ω(zone.StartDriftCheck(now, time.Hour)).Should(BeTrue())
+		Ω(zone.StartDriftCheck(now.Add(time.Minute), time.Hour)).Should(BeFalse())
+		Ω(zone.StartDriftCheck(now.Add(time.Hour), time.Hour)).Should(BeTrue())
+	})
+})
+
+var _ = ginkgov2.Describe("Dry run requests", func() {
+	name := dns.DNSSetName{DNSName: "a.example.com"}
+
+	newStatusUpdate := func(dryrun, deleting bool) *StatusUpdate {
+		entry := &api.DNSEntry{}
+		if dryrun {
+			entry.Annotations = map[string]string{dns.DRY_RUN_ANNOTATION: "true"}
+		}
+		v := &EntryVersion{object: &dnsutils.DNSEntryObject{Object: &entryData{entry: entry}}}
+		return &StatusUpdate{Entry: &Entry{EntryVersion: v}, delete: deleting}
+	}
+	newRequests := func(done DoneHandler) []*ChangeRequest {
+		old := dns.NewDNSSet(name, nil)
+		old.SetRecordSet(dns.RS_A, 300, "1.2.3.4")
+		new := dns.NewDNSSet(name, nil)
+		new.SetRecordSet(dns.RS_A, 300, "1.2.3.5")
+		return []*ChangeRequest{
+			NewChangeRequest(R_CREATE, dns.RS_A, nil, new, done),
+			NewChangeRequest(R_UPDATE, dns.RS_A, old, new, done),
+			NewChangeRequest(R_DELETE, dns.RS_A, old, nil, done),
+		}
+	}
+
+	ginkgov2.It("plans create, update and delete requests of entries in dry run mode", func() {
+		reqs := newRequests(newStatusUpdate(true, false))
+		apply, dryrun := splitDryRunRequests(reqs)
+		Ω(apply).Should(BeEmpty())
+		Ω(dryrun).Should(Equal(reqs))
+	})
+
+	ginkgov2.It("applies requests of entries without dry run mode", func() {
+		reqs := newRequests(newStatusUpdate(false, false))
+		reqs = append(reqs, newRequests(nil)...)
+		apply, dryrun := splitDryRunRequests(reqs)
+		Ω(apply).Should(Equal(reqs))
+		Ω(dryrun).Should(BeEmpty())
+	})
+
+	ginkgov2.It("applies requests of deleted entries in dry run mode", func() {
+		deleted := newRequests(newStatusUpdate(true, true))
+		planned := newRequests(newStatusUpdate(true, false))
+		apply, dryrun := splitDryRunRequests(append(append([]*ChangeRequest{}, deleted...), planned...))
+		Ω(apply).Should(Equal(deleted))
+		Ω(dryrun).Should(Equal(planned))
+	})
+})
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const (
+	conditionalCacheMaxEntries  = 1000
+	conditionalCacheMaxBodySize = 16 * 1024 * 1024
+)
+
+// conditionalTransport sends conditional GET requests (If-None-Match/If-Modified-Since) for resources
+// with a cached response providing an ETag or Last-Modified header. A response with status
+// 304 (Not Modified) is replaced by the cached response, so that unchanged resources are not downloaded again.
+type conditionalTransport struct {
+	base    http.RoundTripper
+	metrics Metrics
+
+	lock    sync.Mutex
+	entries map[string]*conditionalEntry
+}
+
+type conditionalEntry struct {
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+var _ http.RoundTripper = &conditionalTransport{}
+
+func newConditionalTransport(base http.RoundTripper, metrics Metrics) *conditionalTransport {
+	return &conditionalTransport{base: base, metrics: metrics, entries: map[string]*conditionalEntry{}}
+}
+
+func (this *conditionalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" ||
+		req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return this.base.RoundTrip(req)
+	}
+	key := req.URL.String()
+	entry := this.get(key)
+	if entry != nil {
+		req = req.Clone(req.Context())
+		if entry.etag != "" {
+			req.Header.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+	resp, err := this.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if this.metrics != nil {
+			this.metrics.AddGenericRequests(M_NOTMODIFIED, 1)
+		}
+		return entry.response(req), nil
+	case resp.StatusCode == http.StatusOK:
+		etag := resp.Header.Get("ETag")
+		lastModified := resp.Header.Get("Last-Modified")
+		if etag == "" && lastModified == "" || resp.ContentLength > conditionalCacheMaxBodySize {
+			this.delete(key)
+			return resp, nil
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, conditionalCacheMaxBodySize+1))
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		if len(body) > conditionalCacheMaxBodySize {
+			// too large for caching, pass the already read part and the rest of the body
+			this.delete(key)
+			resp.Body = &readCloser{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), Closer: resp.Body}
+			return resp, nil
+		}
+		resp.Body.Close()
+		this.set(key, &conditionalEntry{etag: etag, lastModified: lastModified, header: resp.Header.Clone(), body: body})
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	default:
+		return resp, nil
+	}
+}
+
+func (this *conditionalTransport) get(key string) *conditionalEntry {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.entries[key]
+}
+
+func (this *conditionalTransport) set(key string, entry *conditionalEntry) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if _, ok := this.entries[key]; !ok && len(this.entries) >= conditionalCacheMaxEntries {
+		for k := range this.entries {
+			delete(this.entries, k)
+			break
+		}
+	}
+	this.entries[key] = entry
+}
+
+func (this *conditionalTransport) delete(key string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.entries, key)
+}
+
+func (this *conditionalEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        this.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(this.body)),
+		ContentLength: int64(len(this.body)),
+		Request:       req,
+	}
+}
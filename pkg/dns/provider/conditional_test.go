@@ -0,0 +1,73 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Conditional transport", func() {
+	var (
+		server   *httptest.Server
+		body     string
+		received []string
+	)
+
+	ginkgov2.BeforeEach(func() {
+		body = "v1"
+		received = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = append(received, r.Header.Get("If-None-Match"))
+			etag := `"` + body + `"`
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			w.Write([]byte(body))
+		}))
+	})
+
+	ginkgov2.AfterEach(func() {
+		server.Close()
+	})
+
+	get := func(client *http.Client) string {
+		resp, err := client.Get(server.URL + "/records")
+		Ω(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+		Ω(resp.StatusCode).Should(Equal(http.StatusOK))
+		data, err := io.ReadAll(resp.Body)
+		Ω(err).ShouldNot(HaveOccurred())
+		return string(data)
+	}
+
+	ginkgov2.It("replaces not modified responses by the cached response", func() {
+		client := (*TransportConfig)(nil).WithConditionalRequests(nil).HTTPClient()
+
+		Ω(get(client)).Should(Equal("v1"))
+		Ω(get(client)).Should(Equal("v1"))
+		body = "v2"
+		Ω(get(client)).Should(Equal("v2"))
+		Ω(received).Should(Equal([]string{"", `"v1"`, `"v1"`}))
+	})
+})
@@ -66,16 +66,19 @@ func (this *FactoryOptions) Evaluate() error {
 type GenericFactoryOptions struct {
 	RateLimiterOptions
 	AdvancedOptions
+	SyncOptions
 }
 
 var GenericFactoryOptionDefaults = GenericFactoryOptions{
 	RateLimiterOptions: RateLimiterOptionDefaults,
 	AdvancedOptions:    AdvancedOptionsDefaults,
+	SyncOptions:        SyncOptionsDefaults,
 }
 
 func (this *GenericFactoryOptions) AddOptionsToSet(set config.OptionSet) {
 	this.RateLimiterOptions.AddOptionsToSet(set)
 	this.AdvancedOptions.AddOptionsToSet(set)
+	this.SyncOptions.AddOptionsToSet(set)
 }
 
 func (this GenericFactoryOptions) SetRateLimiterOptions(o RateLimiterOptions) GenericFactoryOptions {
@@ -88,6 +91,11 @@ func (this GenericFactoryOptions) SetAdvancedOptions(o AdvancedOptions) GenericF
 	return this
 }
 
+func (this GenericFactoryOptions) SetSyncOptions(o SyncOptions) GenericFactoryOptions {
+	this.SyncOptions = o
+	return this
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func (c *DNSHandlerConfig) GetRequiredProperty(key string, altKeys ...string) (string, error) {
@@ -271,7 +279,22 @@ func (c *DNSHandlerConfig) Complete() error {
 			return fmt.Errorf("invalid rate limiter: %w", err)
 		}
 		c.Logger.Infof("rate limiter: %v", rateLimiterConfig)
+		if adaptive, ok := rateLimiter.(*adaptiveRateLimiter); ok {
+			if user, ok := c.Metrics.(adaptiveRateLimiterUser); ok {
+				user.setAdaptiveRateLimiter(adaptive)
+			}
+		}
+		if c.Metrics != nil {
+			rateLimiter = newMeteredRateLimiter(rateLimiter, rateLimiterConfig, c.Metrics)
+		}
+		c.ZoneCacheFactory.sync = c.ZoneCacheFactory.sync.Merge(c.Options.GetSyncConfig())
+		if c.Options.ConditionalRequests {
+			c.Transport = c.Transport.WithConditionalRequests(c.Metrics)
+		}
 	}
 	c.RateLimiter = rateLimiter
+	if user, ok := c.Metrics.(syncConfigUser); ok {
+		user.setSyncConfig(c.ZoneCacheFactory.sync)
+	}
 	return nil
 }
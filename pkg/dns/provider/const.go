@@ -33,21 +33,117 @@ const (
 	OPT_CACHE_TTL                  = "cache-ttl"
 	OPT_SETUP                      = dns.OPT_SETUP
 	OPT_DNSDELAY                   = "dns-delay"
+	OPT_ZONE_TRIGGER_DEBOUNCE      = "zone-trigger-debounce"
+	OPT_MAX_ZONES_PER_ACCOUNT      = "max-concurrent-zones-per-account"
 	OPT_RESCHEDULEDELAY            = "reschedule-delay"
 	OPT_LOCKSTATUSCHECKPERIOD      = "lock-status-check-period"
 	OPT_DISABLE_ZONE_STATE_CACHING = "disable-zone-state-caching"
 	OPT_DISABLE_DNSNAME_VALIDATION = "disable-dnsname-validation"
+	OPT_ZONE_STATE_CACHE_DIR       = "zone-state-cache-dir"
+	OPT_LAZY_ZONE_LOADING          = "lazy-zone-loading"
 
 	OPT_REMOTE_ACCESS_PORT               = "remote-access-port"
 	OPT_REMOTE_ACCESS_CACERT             = "remote-access-cacert"
 	OPT_REMOTE_ACCESS_SERVER_SECRET_NAME = "remote-access-server-secret-name"
 	OPT_REMOTE_ACCESS_CLIENT_ID          = "remote-access-client-id"
 
+	OPT_ADMISSION_WEBHOOK_PORT     = "admission-webhook-port"
+	OPT_ADMISSION_WEBHOOK_CERT_DIR = "admission-webhook-cert-dir"
+
+	OPT_AUDIT_SINK        = "audit-sink"
+	OPT_AUDIT_TARGET      = "audit-target"
+	OPT_AUDIT_KAFKA_TOPIC = "audit-kafka-topic"
+
+	OPT_NOTIFICATION_WEBHOOKS       = "notification-webhooks"
+	OPT_NOTIFICATION_SLACK_WEBHOOKS = "notification-slack-webhooks"
+	OPT_NOTIFICATION_EVENTS         = "notification-events"
+
+	OPT_ORPHAN_GRACE_PERIOD = "orphan-grace-period"
+	OPT_ORPHAN_DRYRUN       = "orphan-dry-run"
+
+	OPT_UPSERT_ONLY = "upsert-only"
+
+	OPT_PROPAGATION_CHECK_TIMEOUT   = "propagation-check-timeout"
+	OPT_PROPAGATION_CHECK_RESOLVERS = "propagation-check-resolvers"
+
+	OPT_STUCK_ENTRY_THRESHOLD = "stuck-entry-threshold"
+	OPT_STUCK_ENTRY_RETRIGGER = "stuck-entry-retrigger"
+
+	OPT_OWNER_CONFLICT_THRESHOLD = "owner-conflict-threshold"
+	OPT_OWNER_CONFLICT_WINDOW    = "owner-conflict-window"
+
+	OPT_ALLOW_FORCE_CLEANUP = "allow-force-cleanup"
+
+	OPT_DUPLICATE_POLICY = "duplicate-policy"
+
+	OPT_MIN_TTL = "min-ttl"
+	OPT_MAX_TTL = "max-ttl"
+
+	OPT_LOCK_LOOKUP_MODE        = "lock-lookup-mode"
+	OPT_LOCK_LOOKUP_RESOLVERS   = "lock-lookup-resolvers"
+	OPT_LOCK_LOOKUP_TIMEOUT     = "lock-lookup-timeout"
+	OPT_LOCK_LOOKUP_RETRIES     = "lock-lookup-retries"
+	OPT_LOCK_LOOKUP_RETRY_DELAY = "lock-lookup-retry-delay"
+
+	OPT_QUOTA_BACKPRESSURE_THRESHOLD = "quota-backpressure-threshold"
+
+	OPT_DRIFT_CHECK_PERIOD       = "drift-check-period"
+	OPT_DISABLE_DRIFT_CORRECTION = "disable-drift-correction"
+
+	OPT_EXTERNAL_DNS_REGISTRY    = "external-dns-registry"
+	OPT_EXTERNAL_DNS_OWNER_ID    = "external-dns-owner-id"
+	OPT_EXTERNAL_DNS_TXT_PREFIX  = "external-dns-txt-prefix"
+	OPT_EXTERNAL_DNS_TXT_AES_KEY = "external-dns-txt-encrypt-aes-key"
+
+	OPT_ENABLE_ZONE_EXPORT = "enable-zone-export"
+
+	OPT_MAINTAIN_PTR_RECORDS = "maintain-ptr-records"
+
+	OPT_DNS_LOOKUP_RESOLVERS = "dns-lookup-resolvers"
+	OPT_DNS_LOOKUP_PROTOCOL  = "dns-lookup-protocol"
+	OPT_DNS_LOOKUP_TIMEOUT   = "dns-lookup-timeout"
+	OPT_DNS_LOOKUP_RETRIES   = "dns-lookup-retries"
+
+	OPT_CNAME_CHAIN_MAX_DEPTH  = "cname-chain-max-depth"
+	OPT_CNAME_LOOKUP_RESOLVERS = "cname-lookup-resolvers"
+
+	OPT_ALLOWED_TARGET_CIDRS = "allowed-target-cidrs"
+	OPT_DENIED_TARGET_CIDRS  = "denied-target-cidrs"
+
+	OPT_DEBUG_STATE_TOKEN_FILE = "debug-state-token-file"
+
+	OPT_READINESS_ZONES_MAX_AGE = "readiness-zones-max-age"
+
+	OPT_ZONE_SHARDING_GROUP          = "zone-sharding-group"
+	OPT_ZONE_SHARDING_LEASE_DURATION = "zone-sharding-lease-duration"
+
+	OPT_VAULT_ADDRESS     = "vault-address"
+	OPT_VAULT_AUTH_MOUNT  = "vault-auth-mount"
+	OPT_VAULT_TOKEN_FILE  = "vault-token-file"
+	OPT_SOPS_VAULT_ROLE   = "sops-vault-role"
+	OPT_SOPS_AGE_KEY_FILE = "sops-age-key-file"
+
+	OPT_CHECK_PERMISSIONS = "check-permissions"
+
+	OPT_TLS_MIN_VERSION   = "tls-min-version"
+	OPT_TLS_CIPHER_SUITES = "tls-cipher-suites"
+
+	OPT_TRACING_OTLP_ENDPOINT = "tracing-otlp-endpoint"
+	OPT_TRACING_OTLP_INSECURE = "tracing-otlp-insecure"
+
+	OPT_LOG_FORMAT = "log-format"
+
 	OPT_PROVIDERTYPES = "provider-types"
 
-	OPT_RATELIMITER_ENABLED = "ratelimiter.enabled"
-	OPT_RATELIMITER_QPS     = "ratelimiter.qps"
-	OPT_RATELIMITER_BURST   = "ratelimiter.burst"
+	OPT_RATELIMITER_ENABLED  = "ratelimiter.enabled"
+	OPT_RATELIMITER_QPS      = "ratelimiter.qps"
+	OPT_RATELIMITER_BURST    = "ratelimiter.burst"
+	OPT_RATELIMITER_ADAPTIVE = "ratelimiter.adaptive"
+
+	OPT_SYNC_ZONES_CACHE_TTL      = "sync.zones-cache-ttl"
+	OPT_SYNC_ZONE_STATE_CACHE_TTL = "sync.zone-state-cache-ttl"
+	OPT_SYNC_RESYNC_PERIOD        = "sync.resync-period"
+	OPT_SYNC_CONDITIONAL_REQUESTS = "sync.conditional-requests"
 
 	OPT_ADVANCED_BATCH_SIZE   = "advanced.batch-size"
 	OPT_ADVANCED_MAX_RETRIES  = "advanced.max-retries"
@@ -62,4 +158,6 @@ const (
 
 const (
 	AnnotationRemoteAccess = dns.ANNOTATION_GROUP + "/remote-access"
+	// AnnotationImportedFrom marks DNSEntries generated by the record import of a provider
+	AnnotationImportedFrom = dns.ANNOTATION_GROUP + "/imported-from"
 )
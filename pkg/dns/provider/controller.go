@@ -25,6 +25,7 @@ import (
 	"github.com/gardener/external-dns-management/pkg/apis/dns/crds"
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/debugstate"
 	"github.com/gardener/external-dns-management/pkg/dns/source"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 
@@ -55,9 +56,13 @@ const DNS_POOL = "dns"
 var ownerGroupKind = resources.NewGroupKind(api.GroupName, api.DNSOwnerKind)
 var secretGroupKind = resources.NewGroupKind("", "Secret")
 var providerGroupKind = resources.NewGroupKind(api.GroupName, api.DNSProviderKind)
+var dnsZoneGroupKind = resources.NewGroupKind(api.GroupName, api.DNSZoneKind)
+var healthCheckGroupKind = resources.NewGroupKind(api.GroupName, api.DNSHealthCheckKind)
 var entryGroupKind = resources.NewGroupKind(api.GroupName, api.DNSEntryKind)
 var zonePolicyGroupKind = resources.NewGroupKind(api.GroupName, api.DNSHostedZonePolicyKind)
 var lockGroupKind = resources.NewGroupKind(api.GroupName, api.DNSLockKind)
+var dnsPolicyGroupKind = resources.NewGroupKind(api.GroupName, api.DNSPolicyKind)
+var dnsClassProfileGroupKind = resources.NewGroupKind(api.GroupName, api.DNSClassProfileKind)
 
 // RemoteAccessClientID stores the optional client ID for remote access
 var RemoteAccessClientID string
@@ -120,24 +125,86 @@ func DNSController(name string, factory DNSHandlerFactory) controller.Configurat
 		RequireLease().
 		DefaultedStringOption(OPT_CLASS, dns.DEFAULT_CLASS, "Class identifier used to differentiate responsible controllers for entry resources").
 		DefaultedStringOption(OPT_IDENTIFIER, "dnscontroller", "Identifier used to mark DNS entries in DNS system").
-		DefaultedBoolOption(OPT_DRYRUN, false, "just check, don't modify").
+		DefaultedBoolOption(OPT_DRYRUN, false, "just check, don't modify (planned changes are reported at the entries)").
 		DefaultedBoolOption(OPT_DISABLE_ZONE_STATE_CACHING, false, "disable use of cached dns zone state on changes").
 		DefaultedBoolOption(OPT_DISABLE_DNSNAME_VALIDATION, false, "disable validation of domain names according to RFC 1123.").
+		DefaultedBoolOption(OPT_LAZY_ZONE_LOADING, false, "load the dns zone state of hosted zones only if they are targeted by DNS entries (orphaned records in other zones are not detected)").
+		DefaultedStringOption(OPT_ZONE_STATE_CACHE_DIR, "", "directory for persisting the cached dns zone states to reuse them after a restart (disabled if empty)").
 		DefaultedIntOption(OPT_TTL, 300, "Default time-to-live for DNS entries. Defines how long the record is kept in cache by DNS servers or resolvers.").
 		DefaultedIntOption(OPT_CACHE_TTL, 120, "Time-to-live for provider hosted zone cache").
 		DefaultedIntOption(OPT_SETUP, 10, "number of processors for controller setup").
 		DefaultedDurationOption(OPT_DNSDELAY, 10*time.Second, "delay between two dns reconciliations").
+		DefaultedIntOption(OPT_MAX_ZONES_PER_ACCOUNT, 0, "maximum number of hosted zones of a provider account reconciled concurrently by the workers of pool dns (unlimited if 0)").
+		DefaultedDurationOption(OPT_ZONE_TRIGGER_DEBOUNCE, 0, "window for coalescing the triggers of a hosted zone by entry changes into a single zone reconciliation (triggered immediately if 0)").
 		DefaultedDurationOption(OPT_RESCHEDULEDELAY, 120*time.Second, "reschedule delay after losing provider").
 		DefaultedDurationOption(OPT_LOCKSTATUSCHECKPERIOD, 120*time.Second, "interval for dns lock status checks").
+		DefaultedStringOption(OPT_LOCK_LOOKUP_MODE, LOCK_LOOKUP_RESOLVER, "lookup of the dns lock records for status checks (resolver: resolvers of option --lock-lookup-resolvers or --dns-lookup-resolvers with protocol --dns-lookup-protocol, provider: API of the provider, e.g. for private zones)").
+		DefaultedStringOption(OPT_LOCK_LOOKUP_RESOLVERS, "", "comma separated list of resolvers used for the dns lock status checks instead of the resolvers of option --dns-lookup-resolvers").
+		DefaultedDurationOption(OPT_LOCK_LOOKUP_TIMEOUT, 0, "timeout of a single lookup of dns lock records (value of option --dns-lookup-timeout if not set)").
+		DefaultedIntOption(OPT_LOCK_LOOKUP_RETRIES, -1, "number of retries of failed lookups of dns lock records, records not found are not retried (value of option --dns-lookup-retries if negative)").
+		DefaultedDurationOption(OPT_LOCK_LOOKUP_RETRY_DELAY, DEFAULT_LOCK_LOOKUP_RETRY_DELAY, "delay before retrying a failed lookup of dns lock records").
 		DefaultedIntOption(OPT_REMOTE_ACCESS_PORT, 0, "port of remote access server for remote-enabled providers").
 		DefaultedStringOption(OPT_REMOTE_ACCESS_CACERT, "", "CA who signed client certs file").
 		DefaultedStringOption(OPT_REMOTE_ACCESS_SERVER_SECRET_NAME, "", "name of secret containing remote access server's certificate").
 		DefaultedStringOption(OPT_REMOTE_ACCESS_CLIENT_ID, "", "identifier used for remote access").
+		DefaultedIntOption(OPT_ADMISSION_WEBHOOK_PORT, 0, "port of admission webhook server validating entries and providers (disabled if 0)").
+		DefaultedStringOption(OPT_ADMISSION_WEBHOOK_CERT_DIR, "", "directory containing the certificate (tls.crt) and key (tls.key) of the admission webhook server").
+		DefaultedStringOption(OPT_AUDIT_SINK, "", "sink for audit records of executed DNS changes (file, webhook or kafka, disabled if empty)").
+		DefaultedStringOption(OPT_AUDIT_TARGET, "", "audit target: file path, webhook URL or URL of Kafka REST proxy").
+		DefaultedStringOption(OPT_AUDIT_KAFKA_TOPIC, "", "Kafka topic for audit records").
+		DefaultedStringOption(OPT_NOTIFICATION_WEBHOOKS, "", "comma separated list of webhook URLs receiving notifications about the lifecycle of DNS entries as JSON").
+		DefaultedStringOption(OPT_NOTIFICATION_SLACK_WEBHOOKS, "", "comma separated list of Slack incoming webhook URLs receiving notifications about the lifecycle of DNS entries").
+		DefaultedStringOption(OPT_NOTIFICATION_EVENTS, "", "comma separated list of notification events (entry_ready, entry_failed, record_deleted, zone_unreachable, all if empty)").
+		DefaultedDurationOption(OPT_ORPHAN_GRACE_PERIOD, 0, "grace period before orphaned records carrying the owner identifier are deleted (deleted immediately if 0)").
+		DefaultedDurationOption(OPT_DRIFT_CHECK_PERIOD, 0, "period for verifying the records of all hosted zones against the DNS providers (disabled if 0)").
+		DefaultedBoolOption(OPT_DISABLE_DRIFT_CORRECTION, false, "only report records modified outside of the DNS controller found by the drift check, don't correct them").
+		DefaultedDurationOption(OPT_PROPAGATION_CHECK_TIMEOUT, 0, "maximum duration for checking the resolution of applied DNS entries against the authoritative name servers (disabled if 0)").
+		DefaultedStringOption(OPT_PROPAGATION_CHECK_RESOLVERS, "", "comma separated list of recursive resolvers (host[:port]) additionally queried by the propagation check (e.g. public resolvers like 8.8.8.8)").
+		DefaultedDurationOption(OPT_STUCK_ENTRY_THRESHOLD, 0, "duration after which entries staying in state Pending or Error are flagged with condition Stuck reporting the blocking reason (disabled if 0)").
+		DefaultedBoolOption(OPT_STUCK_ENTRY_RETRIGGER, false, "retrigger the reconciliation of hosted zones blocking stuck entries").
+		DefaultedIntOption(OPT_OWNER_CONFLICT_THRESHOLD, 3, "number of changes by another owner of a record set written by this controller within the owner conflict window to report a split-brain and suspend its updates (disabled if 0)").
+		DefaultedDurationOption(OPT_OWNER_CONFLICT_WINDOW, 30*time.Minute, "time window for counting changes by another owner for the split-brain detection").
+		DefaultedStringOption(OPT_DUPLICATE_POLICY, string(api.DuplicatePolicyRejectNewer), "default handling of entries claiming the same DNS name (reject-newer, reject-older, merge-targets or priority-by-annotation)").
+		DefaultedIntOption(OPT_MIN_TTL, 0, "minimum TTL for DNS entries, lower TTLs are clamped (no minimum if 0)").
+		DefaultedIntOption(OPT_MAX_TTL, 0, "maximum TTL for DNS entries, higher TTLs are clamped (no maximum if 0)").
+		DefaultedBoolOption(OPT_ALLOW_FORCE_CLEANUP, false, "allow removing the finalizer of deleted entries with annotation dns.gardener.cloud/force-cleanup=true if their provider or hosted zone is gone and no records are reachable").
+		DefaultedIntOption(OPT_QUOTA_BACKPRESSURE_THRESHOLD, 80, "usage of the request quota of a provider account in percent above which the delay between zone reconciliations is stretched (disabled if 0)").
+		DefaultedStringOption(OPT_EXTERNAL_DNS_REGISTRY, "", "compatibility mode for the TXT registry of kubernetes-sigs/external-dns (read: respect and adopt ownership, sync: additionally maintain registry records, disabled if empty)").
+		DefaultedStringOption(OPT_EXTERNAL_DNS_OWNER_ID, "", "external-dns owner id of adopted and maintained records").
+		DefaultedStringOption(OPT_EXTERNAL_DNS_TXT_PREFIX, "", "prefix of the external-dns registry records (may contain %{record_type})").
+		DefaultedStringOption(OPT_EXTERNAL_DNS_TXT_AES_KEY, "", "AES key for encrypted external-dns registry records").
+		DefaultedBoolOption(OPT_MAINTAIN_PTR_RECORDS, false, "maintain PTR records for the A and AAAA records of DNS entries in hosted reverse zones (in-addr.arpa, ip6.arpa)").
+		DefaultedStringOption(OPT_DNS_LOOKUP_RESOLVERS, "", "comma separated list of resolvers used for all DNS lookups of the controller instead of the host resolver (host[:port], or https URLs for protocol doh), overwritten by the resolver options of single lookups").
+		DefaultedStringOption(OPT_DNS_LOOKUP_PROTOCOL, DNS_LOOKUP_PROTOCOL_UDP, "protocol for queries sent to configured resolvers (udp, tcp, dot: DNS over TLS or doh: DNS over HTTPS, dot and doh need option --dns-lookup-resolvers)").
+		DefaultedDurationOption(OPT_DNS_LOOKUP_TIMEOUT, DEFAULT_DNS_LOOKUP_TIMEOUT, "timeout of a single DNS query for target resolution, lock status checks, propagation checks and force cleanups").
+		DefaultedIntOption(OPT_DNS_LOOKUP_RETRIES, 0, "number of retries of failed DNS lookups for target resolution, lock status checks, propagation checks and force cleanups (names not found are not retried)").
+		DefaultedIntOption(OPT_CNAME_CHAIN_MAX_DEPTH, 10, "maximum length of CNAME chains followed when resolving targets to addresses (single lookup with the host resolver if 0)").
+		DefaultedStringOption(OPT_CNAME_LOOKUP_RESOLVERS, "", "comma separated list of resolvers used for following CNAME chains instead of the resolvers of option --dns-lookup-resolvers or /etc/resolv.conf").
+		DefaultedStringOption(OPT_ALLOWED_TARGET_CIDRS, "", "comma separated list of CIDR ranges of IP addresses allowed as targets of DNS entries (all addresses if empty)").
+		DefaultedStringOption(OPT_DENIED_TARGET_CIDRS, "", "comma separated list of CIDR ranges of IP addresses never published as targets of DNS entries (e.g. 10.0.0.0/8,172.16.0.0/12,192.168.0.0/16)").
+		DefaultedBoolOption(OPT_ENABLE_ZONE_EXPORT, false, "enables export of the desired state of hosted zones as zone files or Terraform resources at path /zones/export (needs option --server-port-http)").
+		DefaultedStringOption(OPT_DEBUG_STATE_TOKEN_FILE, "", "file containing the bearer token for the debug endpoints at paths "+debugstate.Path+" and "+debugstate.DiffPath+" (disabled if not set, needs option --server-port-http)").
+		DefaultedDurationOption(OPT_READINESS_ZONES_MAX_AGE, 30*time.Minute, "maximum age of the hosted zone lists of valid providers for the readiness endpoint at path "+ReadinessPath+" (not checked if 0)").
+		DefaultedStringOption(OPT_ZONE_SHARDING_GROUP, "", "name of the group of controller replicas splitting the reconciliation of hosted zones in active-active mode (disabled if empty, needs option --omit-lease and no other controllers requiring leader election, e.g. -c dnscontrollers)").
+		DefaultedDurationOption(OPT_ZONE_SHARDING_LEASE_DURATION, 15*time.Second, "duration of the leases announcing the controller replicas of the zone sharding group").
+		DefaultedStringOption(OPT_VAULT_ADDRESS, "", "address of the HashiCorp Vault server for provider credentials (e.g. https://vault.example.com:8200)").
+		DefaultedStringOption(OPT_VAULT_AUTH_MOUNT, "kubernetes", "mount path of the Kubernetes auth method in Vault").
+		DefaultedStringOption(OPT_VAULT_TOKEN_FILE, "/var/run/secrets/kubernetes.io/serviceaccount/token", "service account token file used for the Vault login").
+		DefaultedStringOption(OPT_SOPS_VAULT_ROLE, "", "role of the Vault login used for decrypting data keys of SOPS encrypted provider secrets with the transit secrets engine").
+		DefaultedStringOption(OPT_SOPS_AGE_KEY_FILE, "", "file containing the age identities (AGE-SECRET-KEY-1...) used for decrypting data keys of SOPS encrypted provider secrets").
+		DefaultedStringOption(OPT_TLS_MIN_VERSION, "", "minimum TLS version (1.0, 1.1, 1.2 or 1.3) for connections of provider clients and the remote access server").
+		DefaultedStringOption(OPT_TLS_CIPHER_SUITES, "", "comma separated list of TLS cipher suites allowed for connections of provider clients and the remote access server (TLS 1.2 and lower)").
+		DefaultedStringOption(OPT_TRACING_OTLP_ENDPOINT, "", "host and port of an OTLP/HTTP receiver for exporting OpenTelemetry traces (e.g. otel-collector:4318, tracing disabled if not set)").
+		DefaultedBoolOption(OPT_TRACING_OTLP_INSECURE, false, "use plain HTTP for exporting OpenTelemetry traces").
+		DefaultedStringOption(OPT_LOG_FORMAT, dnsutils.LOG_FORMAT_TEXT, "format of the log lines of reconciliations (text or json with entry, zone, provider and correlation id as fields)").
+		DefaultedBoolOption(OPT_CHECK_PERMISSIONS, false, "probe the provider API permissions needed for the included hosted zones and report missing ones in the provider status").
+		DefaultedBoolOption(OPT_UPSERT_ONLY, false, "only create and update DNS records, never delete them at the providers").
+		DefaultedBoolOption(OPT_ORPHAN_DRYRUN, false, "only report orphaned records carrying the owner identifier, don't delete them").
 		FinalizerDomain("dns.gardener.cloud").
 		Reconciler(DNSReconcilerType(factory)).
 		Cluster(TARGET_CLUSTER).
 		Syncer(SYNC_ENTRIES, controller.NewResourceKey(api.GroupName, api.DNSEntryKind)).
-		CustomResourceDefinitions(ownerGroupKind, entryGroupKind).
+		CustomResourceDefinitions(ownerGroupKind, entryGroupKind, dnsPolicyGroupKind, dnsClassProfileGroupKind).
 		MainResource(api.GroupName, api.DNSEntryKind).
 		DefaultWorkerPool(2, 0).
 		WorkerPool("ownerids", 1, 0).
@@ -145,8 +212,21 @@ func DNSController(name string, factory DNSHandlerFactory) controller.Configurat
 			controller.NewResourceKey(api.GroupName, api.DNSOwnerKind),
 			controller.NewResourceKey(api.GroupName, api.DNSLockKind),
 		).
+		WorkerPool("targetrefs", 1, 0).
+		Watches(
+			controller.NewResourceKey("core", "Service"),
+			controller.NewResourceKey("core", "Node"),
+		).
+		WorkerPool("dnspolicies", 1, 0).
+		Watches(
+			controller.NewResourceKey(api.GroupName, api.DNSPolicyKind),
+		).
+		WorkerPool("dnsclassprofiles", 1, 0).
+		Watches(
+			controller.NewResourceKey(api.GroupName, api.DNSClassProfileKind),
+		).
 		Cluster(PROVIDER_CLUSTER).
-		CustomResourceDefinitions(providerGroupKind).
+		CustomResourceDefinitions(providerGroupKind, dnsZoneGroupKind, healthCheckGroupKind).
 		WorkerPool("providers", 2, 10*time.Minute).
 		Watches(
 			controller.NewResourceKey(api.GroupName, api.DNSProviderKind),
@@ -159,6 +239,14 @@ func DNSController(name string, factory DNSHandlerFactory) controller.Configurat
 		Watches(
 			controller.NewResourceKey(api.GroupName, api.DNSHostedZonePolicyKind),
 		).
+		WorkerPool("dnszones", 1, 10*time.Minute).
+		Watches(
+			controller.NewResourceKey(api.GroupName, api.DNSZoneKind),
+		).
+		WorkerPool("dnshealthchecks", 1, 10*time.Minute).
+		Watches(
+			controller.NewResourceKey(api.GroupName, api.DNSHealthCheckKind),
+		).
 		WorkerPool(DNS_POOL, 1, 15*time.Minute).CommandMatchers(utils.NewStringGlobMatcher(CMD_HOSTEDZONE_PREFIX+"*")).
 		Commands(CMD_DNSLOOKUP).
 		WorkerPool("statistic", 2, 0).Commands(CMD_STATISTIC).
@@ -272,6 +360,14 @@ func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object
 		} else {
 			return this.state.RemoveZonePolicy(logger, dnsutils.DNSHostedZonePolicy(obj))
 		}
+	case obj.IsA(&api.DNSPolicy{}):
+		if this.state.IsResponsibleFor(logger, obj) {
+			return this.state.UpdateDNSPolicy(logger, dnsutils.DNSPolicy(obj))
+		} else {
+			return this.state.RemoveDNSPolicy(logger, dnsutils.DNSPolicy(obj))
+		}
+	case obj.IsA(&api.DNSClassProfile{}):
+		return this.state.UpdateDNSClassProfile(logger, dnsutils.DNSClassProfile(obj))
 	case obj.IsA(&api.DNSLock{}):
 		if this.state.IsResponsibleFor(logger, obj) {
 			return this.state.UpdateEntry(logger, dnsutils.DNSLock(obj))
@@ -280,6 +376,16 @@ func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object
 		}
 	case obj.IsA(&corev1.Secret{}):
 		return this.state.UpdateSecret(logger, obj)
+	case obj.IsA(&api.DNSZone{}):
+		if this.state.IsResponsibleFor(logger, obj) {
+			return this.state.UpdateDNSZone(logger, dnsutils.DNSZone(obj))
+		}
+	case obj.IsA(&api.DNSHealthCheck{}):
+		if this.state.IsResponsibleFor(logger, obj) {
+			return this.state.UpdateDNSHealthCheck(logger, dnsutils.DNSHealthCheck(obj))
+		}
+	case obj.IsA(&corev1.Service{}), obj.IsA(&corev1.Node{}):
+		this.state.references.NotifyHolder(this.state.context, obj.ClusterKey())
 	}
 	return reconcile.Succeeded(logger)
 }
@@ -298,6 +404,10 @@ func (this *reconciler) Delete(logger logger.LogContext, obj resources.Object) r
 			return this.state.DeleteEntry(logger, dnsutils.DNSLock(obj))
 		case obj.IsA(&corev1.Secret{}):
 			return this.state.UpdateSecret(logger, obj)
+		case obj.IsA(&api.DNSZone{}):
+			return this.state.DeleteDNSZone(logger, dnsutils.DNSZone(obj))
+		case obj.IsA(&api.DNSHealthCheck{}):
+			return this.state.DeleteDNSHealthCheck(logger, dnsutils.DNSHealthCheck(obj))
 		}
 	}
 	return reconcile.Succeeded(logger)
@@ -316,6 +426,12 @@ func (this *reconciler) Deleted(logger logger.LogContext, key resources.ClusterO
 		return this.state.ZonePolicyDeleted(logger, key)
 	case lockGroupKind:
 		return this.state.EntryDeleted(logger, key)
+	case dnsPolicyGroupKind:
+		return this.state.DNSPolicyDeleted(logger, key)
+	case dnsClassProfileGroupKind:
+		return this.state.DNSClassProfileDeleted(logger, key)
+	case serviceGroupKind, nodeGroupKind:
+		this.state.references.NotifyHolder(this.state.context, key)
 	}
 	return reconcile.Succeeded(logger)
 }
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/server"
+
+	"github.com/gardener/external-dns-management/pkg/dns/debugstate"
+)
+
+var debugState = &debugStateHandler{}
+
+// debugStateHandler serves the in-memory state of all DNS controllers with configured debug state token.
+type debugStateHandler struct {
+	lock   sync.Mutex
+	states []*state
+}
+
+func registerDebugState(state *state) {
+	debugState.lock.Lock()
+	defer debugState.lock.Unlock()
+	if len(debugState.states) == 0 {
+		server.RegisterHandler(debugstate.Path, debugState)
+		server.RegisterHandler(debugstate.DiffPath, http.HandlerFunc(debugState.ServeDiff))
+	}
+	debugState.states = append(debugState.states, state)
+}
+
+func (this *debugStateHandler) getStates() []*state {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return append([]*state{}, this.states...)
+}
+
+// ServeHTTP dumps the state of the DNS controllers whose token matches the bearer token of the request.
+// The optional query parameter zone restricts the dump to the hosted zones with the given id.
+func (this *debugStateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	zone := r.URL.Query().Get("zone")
+	result := []*debugstate.State{}
+	for _, s := range this.getStates() {
+		if s.checkDebugStateToken(token) {
+			result = append(result, s.debugState(zone))
+		}
+	}
+	if len(result) == 0 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(result)
+}
+
+// checkDebugStateToken compares the token with the content of the token file.
+// The file is read for every request to support token rotation.
+func (this *state) checkDebugStateToken(token string) bool {
+	data, err := os.ReadFile(this.config.DebugStateTokenFile)
+	if err != nil {
+		this.context.Warnf("cannot read debug state token file: %s", err)
+		return false
+	}
+	expected := strings.TrimSpace(string(data))
+	return expected != "" && token != "" && subtle.ConstantTimeCompare([]byte(expected), []byte(token)) == 1
+}
+
+func (this *state) debugState(zoneFilter string) *debugstate.State {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	result := &debugstate.State{
+		Controller:      this.config.Ident,
+		Zones:           []debugstate.Zone{},
+		DNSNames:        map[string]string{},
+		BlockingEntries: map[string]time.Time{},
+	}
+	zones := map[string]*debugstate.Zone{}
+	for zoneid, zone := range this.zones {
+		if zoneFilter != "" && zoneid.ID != zoneFilter {
+			continue
+		}
+		z := &debugstate.Zone{
+			ProviderType: zoneid.ProviderType,
+			ID:           zoneid.ID,
+			Domain:       zone.Domain(),
+			Private:      zone.IsPrivate(),
+			Providers:    []string{},
+			Busy:         zone.IsBusy(),
+			Next:         zone.GetNext(),
+			Entries:      []debugstate.Entry{},
+		}
+		for _, req := range zone.getOutstandingRequests() {
+			z.OutstandingRequests = append(z.OutstandingRequests, debugChange(req))
+		}
+		sortDebugChanges(z.OutstandingRequests)
+		for name := range this.zoneproviders[zoneid] {
+			z.Providers = append(z.Providers, name.String())
+		}
+		sort.Strings(z.Providers)
+		zones[zoneid.String()] = z
+	}
+	for _, e := range this.entries {
+		zoneid := e.ZoneId()
+		de := debugstate.Entry{
+			Name:          e.ObjectName().String(),
+			DNSName:       e.DNSName(),
+			State:         e.State(),
+			Message:       e.Message(),
+			Valid:         e.IsValid(),
+			ChangePending: e.IsModified(),
+			Deleting:      e.IsDeleting(),
+		}
+		if z := zones[zoneid.String()]; z != nil {
+			z.Entries = append(z.Entries, de)
+		} else if zoneid.IsEmpty() && zoneFilter == "" {
+			result.UnassignedEntries = append(result.UnassignedEntries, de)
+		}
+	}
+	for _, z := range zones {
+		sort.Slice(z.Entries, func(i, j int) bool { return z.Entries[i].Name < z.Entries[j].Name })
+		result.Zones = append(result.Zones, *z)
+	}
+	sort.Slice(result.Zones, func(i, j int) bool {
+		return result.Zones[i].ProviderType+"/"+result.Zones[i].ID < result.Zones[j].ProviderType+"/"+result.Zones[j].ID
+	})
+	sort.Slice(result.UnassignedEntries, func(i, j int) bool {
+		return result.UnassignedEntries[i].Name < result.UnassignedEntries[j].Name
+	})
+	for name, e := range this.dnsnames {
+		if zoneFilter == "" || name.ZoneID.ID == zoneFilter {
+			result.DNSNames[fmt.Sprintf("%s:%s", name.ZoneID, name.DNSSetName)] = e.ObjectName().String()
+		}
+	}
+	for name, since := range this.blockingEntries {
+		result.BlockingEntries[name.String()] = since
+	}
+	return result
+}
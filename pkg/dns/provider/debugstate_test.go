@@ -0,0 +1,115 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/debugstate"
+)
+
+var _ = ginkgov2.Describe("Debug state", func() {
+	var handler *debugStateHandler
+	var zone *dnsHostedZone
+
+	ginkgov2.BeforeEach(func() {
+		tokenFile := filepath.Join(ginkgov2.GinkgoT().TempDir(), "token")
+		Ω(os.WriteFile(tokenFile, []byte("secret\n"), 0600)).Should(Succeed())
+		zoneid := dns.NewZoneID("mock", "z1")
+		zone = newDNSHostedZone(time.Second, NewDNSHostedZone("mock", "z1", "example.com", "", nil, false))
+		s := &state{
+			config:          Config{Ident: "test", DebugStateTokenFile: tokenFile},
+			zones:           map[dns.ZoneID]*dnsHostedZone{zoneid: zone},
+			zoneproviders:   map[dns.ZoneID]resources.ObjectNameSet{zoneid: resources.NewObjectNameSet(resources.NewObjectName("default", "p1"))},
+			entries:         Entries{},
+			dnsnames:        map[ZonedDNSSetName]*Entry{},
+			blockingEntries: map[resources.ObjectName]time.Time{},
+		}
+		handler = &debugStateHandler{states: []*state{s}}
+	})
+
+	serve := func(token, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, debugstate.Path+query, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	ginkgov2.It("rejects requests without valid token", func() {
+		Ω(serve("", "").Code).Should(Equal(http.StatusUnauthorized))
+		Ω(serve("other", "").Code).Should(Equal(http.StatusUnauthorized))
+	})
+
+	ginkgov2.It("dumps the zones", func() {
+		rec := serve("secret", "")
+		Ω(rec.Code).Should(Equal(http.StatusOK))
+		states := []*debugstate.State{}
+		Ω(json.Unmarshal(rec.Body.Bytes(), &states)).Should(Succeed())
+		Ω(states).Should(HaveLen(1))
+		Ω(states[0].Controller).Should(Equal("test"))
+		Ω(states[0].Zones).Should(HaveLen(1))
+		Ω(states[0].Zones[0].Domain).Should(Equal("example.com"))
+		Ω(states[0].Zones[0].Providers).Should(Equal([]string{"default/p1"}))
+		Ω(states[0].Zones[0].OutstandingRequests).Should(BeEmpty())
+
+		Ω(json.Unmarshal(serve("secret", "?zone=z2").Body.Bytes(), &states)).Should(Succeed())
+		Ω(states[0].Zones).Should(BeEmpty())
+	})
+
+	ginkgov2.It("dumps the outstanding change requests of the zones", func() {
+		www := dns.NewDNSSet(dns.DNSSetName{DNSName: "www.example.com"}, nil)
+		old := dns.NewDNSSet(dns.DNSSetName{DNSName: "old.example.com"}, nil)
+		zone.setOutstandingRequests(ChangeRequests{
+			NewChangeRequest(R_CREATE, dns.RS_A, nil, www, nil),
+			NewChangeRequest(R_DELETE, dns.RS_CNAME, old, nil, nil),
+		})
+		states := []*debugstate.State{}
+		Ω(json.Unmarshal(serve("secret", "").Body.Bytes(), &states)).Should(Succeed())
+		Ω(states[0].Zones[0].OutstandingRequests).Should(Equal([]debugstate.Change{
+			{Action: R_DELETE, Type: dns.RS_CNAME, DNSName: "old.example.com", Description: "delete CNAME record set old.example.com: no records"},
+			{Action: R_CREATE, Type: dns.RS_A, DNSName: "www.example.com", Description: "create A record set www.example.com: no records"},
+		}))
+	})
+
+	ginkgov2.It("rejects invalid diff requests", func() {
+		serveDiff := func(token, query string) int {
+			req := httptest.NewRequest(http.MethodGet, debugstate.DiffPath+query, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+			handler.ServeDiff(rec, req)
+			return rec.Code
+		}
+		Ω(serveDiff("secret", "")).Should(Equal(http.StatusBadRequest))
+		Ω(serveDiff("other", "?zone=z1")).Should(Equal(http.StatusUnauthorized))
+		Ω(serveDiff("secret", "?zone=z2")).Should(Equal(http.StatusNotFound))
+		Ω(serveDiff("secret", "?zone=z1&type=other")).Should(Equal(http.StatusNotFound))
+	})
+})
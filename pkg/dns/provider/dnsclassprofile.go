@@ -0,0 +1,91 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+// dnsClassProfile is the validated form of a DNSClassProfile used for all entries of a DNS class
+type dnsClassProfile struct {
+	class     string
+	spec      api.DNSClassProfileSpec
+	providers resources.ObjectNameSet
+}
+
+func newDNSClassProfile(class string, spec *api.DNSClassProfileSpec) (*dnsClassProfile, error) {
+	if spec.TTL != nil && *spec.TTL <= 0 {
+		return nil, fmt.Errorf("ttl must be greater than zero")
+	}
+	if spec.OwnerId != nil && *spec.OwnerId == "" {
+		return nil, fmt.Errorf("ownerId must not be empty")
+	}
+	if spec.DuplicatePolicy != nil {
+		if err := validateDuplicatePolicy(*spec.DuplicatePolicy); err != nil {
+			return nil, err
+		}
+	}
+	providers := resources.ObjectNameSet{}
+	for _, p := range spec.AllowedProviders {
+		name, err := ParsePinnedProviderName(p, "")
+		if err != nil || name.Namespace() == "" {
+			return nil, fmt.Errorf("invalid allowed provider %q (expected namespace/name)", p)
+		}
+		providers.Add(name)
+	}
+	return &dnsClassProfile{class: class, spec: *spec.DeepCopy(), providers: providers}, nil
+}
+
+// TTL returns the default TTL of the class or nil if not set.
+func (this *dnsClassProfile) TTL() *int64 {
+	if this == nil {
+		return nil
+	}
+	return this.spec.TTL
+}
+
+// OwnerId returns the default owner id of the class or nil if not set.
+func (this *dnsClassProfile) OwnerId() *string {
+	if this == nil {
+		return nil
+	}
+	return this.spec.OwnerId
+}
+
+// IsDryRun checks whether DNS record changes are suppressed for the class.
+func (this *dnsClassProfile) IsDryRun() bool {
+	return this != nil && this.spec.DryRun
+}
+
+// DuplicatePolicy returns the duplicate policy of the class or nil if not set.
+func (this *dnsClassProfile) DuplicatePolicy() *api.DuplicatePolicy {
+	if this == nil {
+		return nil
+	}
+	return this.spec.DuplicatePolicy
+}
+
+// CheckProvider checks whether the given provider may be used by entries of the class.
+func (this *dnsClassProfile) CheckProvider(name resources.ObjectName) error {
+	if this == nil || len(this.providers) == 0 || this.providers.Contains(name) {
+		return nil
+	}
+	return fmt.Errorf("provider %s not allowed for dns class %q", name, this.class)
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = ginkgov2.Describe("DNS class profile", func() {
+	int64ptr := func(v int64) *int64 { return &v }
+	strptr := func(v string) *string { return &v }
+
+	ginkgov2.It("rejects invalid profiles", func() {
+		_, err := newDNSClassProfile("c", &api.DNSClassProfileSpec{TTL: int64ptr(0)})
+		Ω(err).Should(HaveOccurred())
+		_, err = newDNSClassProfile("c", &api.DNSClassProfileSpec{OwnerId: strptr("")})
+		Ω(err).Should(HaveOccurred())
+		_, err = newDNSClassProfile("c", &api.DNSClassProfileSpec{AllowedProviders: []string{"provider"}})
+		Ω(err).Should(HaveOccurred())
+		_, err = newDNSClassProfile("c", &api.DNSClassProfileSpec{AllowedProviders: []string{"a/b/c"}})
+		Ω(err).Should(HaveOccurred())
+	})
+
+	ginkgov2.It("provides defaults", func() {
+		prof, err := newDNSClassProfile("c", &api.DNSClassProfileSpec{TTL: int64ptr(120), OwnerId: strptr("tenant-a"), DryRun: true})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(*prof.TTL()).Should(Equal(int64(120)))
+		Ω(*prof.OwnerId()).Should(Equal("tenant-a"))
+		Ω(prof.IsDryRun()).Should(BeTrue())
+
+		var none *dnsClassProfile
+		Ω(none.TTL()).Should(BeNil())
+		Ω(none.OwnerId()).Should(BeNil())
+		Ω(none.IsDryRun()).Should(BeFalse())
+	})
+
+	ginkgov2.It("checks allowed providers", func() {
+		prof, err := newDNSClassProfile("c", &api.DNSClassProfileSpec{AllowedProviders: []string{"tenant-a/aws"}})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(prof.CheckProvider(resources.NewObjectName("tenant-a", "aws"))).Should(Succeed())
+		Ω(prof.CheckProvider(resources.NewObjectName("tenant-b", "aws"))).ShouldNot(Succeed())
+
+		prof, err = newDNSClassProfile("c", &api.DNSClassProfileSpec{})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(prof.CheckProvider(resources.NewObjectName("tenant-b", "aws"))).Should(Succeed())
+
+		var none *dnsClassProfile
+		Ω(none.CheckProvider(resources.NewObjectName("tenant-b", "aws"))).Should(Succeed())
+	})
+})
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("DNSHealthCheck", func() {
+	intptr := func(i int) *int { return &i }
+
+	ginkgov2.It("completes defaults of health check spec", func() {
+		spec, err := healthCheckSpec(&api.DNSHealthCheckSpec{Type: api.HealthCheckTypeHTTPS, FQDN: "www.example.com"}, "uid")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(*spec).Should(Equal(HealthCheckSpec{
+			Type:             api.HealthCheckTypeHTTPS,
+			FQDN:             "www.example.com",
+			Port:             443,
+			RequestInterval:  30,
+			FailureThreshold: 3,
+			Reference:        "uid",
+		}))
+
+		spec, err = healthCheckSpec(&api.DNSHealthCheckSpec{Type: api.HealthCheckTypeHTTP, IPAddress: "1.2.3.4",
+			Port: intptr(8080), ResourcePath: "/healthz", RequestInterval: intptr(10), FailureThreshold: intptr(5)}, "uid")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(spec.Port).Should(Equal(8080))
+		Ω(spec.RequestInterval).Should(Equal(10))
+		Ω(spec.FailureThreshold).Should(Equal(5))
+	})
+
+	ginkgov2.It("rejects invalid health check specs", func() {
+		invalid := []api.DNSHealthCheckSpec{
+			{Type: "ICMP", FQDN: "www.example.com"},
+			{Type: api.HealthCheckTypeHTTP},
+			{Type: api.HealthCheckTypeHTTP, IPAddress: "1.2.3"},
+			{Type: api.HealthCheckTypeHTTP, FQDN: "www.example.com", ResourcePath: "healthz"},
+			{Type: api.HealthCheckTypeTCP, FQDN: "www.example.com", SearchString: "ok"},
+			{Type: api.HealthCheckTypeTCP, FQDN: "www.example.com", Port: intptr(70000)},
+			{Type: api.HealthCheckTypeTCP, FQDN: "www.example.com", FailureThreshold: intptr(11)},
+			{Type: api.HealthCheckTypeTCP, FQDN: "www.example.com", RequestInterval: intptr(0)},
+		}
+		for _, spec := range invalid {
+			_, err := healthCheckSpec(&spec, "uid")
+			Ω(err).Should(HaveOccurred(), "spec %#v", spec)
+		}
+	})
+
+	ginkgov2.It("resolves health check reference in routing policy", func() {
+		name := resources.NewObjectName("ns", "hc")
+		policy := dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, "weight", "10", dns.RoutingPolicyParamHealthCheck, "hc")
+
+		resolved, err := resolveHealthCheck(policy, name, "id-1")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(resolved).Should(Equal(dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, "weight", "10", dns.RoutingPolicyParamHealthCheckID, "id-1")))
+		Ω(policy.Parameters).Should(HaveKey(dns.RoutingPolicyParamHealthCheck))
+
+		_, err = resolveHealthCheck(policy, name, "")
+		Ω(err).Should(MatchError("health check ns/hc not found or not ready"))
+
+		policy.Parameters[dns.RoutingPolicyParamHealthCheckID] = "id-2"
+		_, err = resolveHealthCheck(policy, name, "id-1")
+		Ω(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,380 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// transport protocols of DNS lookups
+const (
+	// DNS_LOOKUP_PROTOCOL_UDP uses UDP and repeats truncated answers with TCP
+	DNS_LOOKUP_PROTOCOL_UDP = "udp"
+	DNS_LOOKUP_PROTOCOL_TCP = "tcp"
+	// DNS_LOOKUP_PROTOCOL_DOT uses DNS over TLS (RFC 7858)
+	DNS_LOOKUP_PROTOCOL_DOT = "dot"
+	// DNS_LOOKUP_PROTOCOL_DOH uses DNS over HTTPS (RFC 8484), the resolvers are given as https URLs
+	DNS_LOOKUP_PROTOCOL_DOH = "doh"
+)
+
+const DEFAULT_DNS_LOOKUP_TIMEOUT = 5 * time.Second
+
+// DNSLookupConfig configures the DNS lookups of the controller: status checks of DNS locks, resolution of
+// target hostnames, propagation checks and the verification of forced cleanups.
+// A nil config uses the host resolver with plain DNS.
+type DNSLookupConfig struct {
+	// Resolvers are used instead of the host resolver if not overwritten by the options of a single lookup kind
+	// (host:port, or https URLs for protocol doh)
+	Resolvers []string
+	// Protocol is the transport protocol for queries sent to configured resolvers
+	Protocol string
+	// Timeout is the timeout of a single query
+	Timeout time.Duration
+	// Retries is the number of retries of failed lookups, names not found are not retried
+	Retries int
+	// TLSPolicy restricts the TLS connections for protocols dot and doh
+	TLSPolicy *dnsutils.TLSPolicy
+
+	// client is shared by all DNS over HTTPS queries to reuse its connections
+	client     *http.Client
+	clientOnce sync.Once
+}
+
+func createDNSLookupConfig(c controller.Interface, tlsPolicy *dnsutils.TLSPolicy) (*DNSLookupConfig, error) {
+	cfg := &DNSLookupConfig{TLSPolicy: tlsPolicy}
+	cfg.Protocol, _ = c.GetStringOption(OPT_DNS_LOOKUP_PROTOCOL)
+	switch cfg.Protocol {
+	case "":
+		cfg.Protocol = DNS_LOOKUP_PROTOCOL_UDP
+	case DNS_LOOKUP_PROTOCOL_UDP, DNS_LOOKUP_PROTOCOL_TCP, DNS_LOOKUP_PROTOCOL_DOT, DNS_LOOKUP_PROTOCOL_DOH:
+	default:
+		return nil, fmt.Errorf("invalid option %s: unknown protocol %q (expected %s, %s, %s or %s)", OPT_DNS_LOOKUP_PROTOCOL, cfg.Protocol,
+			DNS_LOOKUP_PROTOCOL_UDP, DNS_LOOKUP_PROTOCOL_TCP, DNS_LOOKUP_PROTOCOL_DOT, DNS_LOOKUP_PROTOCOL_DOH)
+	}
+	value, _ := c.GetStringOption(OPT_DNS_LOOKUP_RESOLVERS)
+	resolvers, err := cfg.parseResolvers(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid option %s: %w", OPT_DNS_LOOKUP_RESOLVERS, err)
+	}
+	cfg.Resolvers = resolvers
+	if (cfg.Protocol == DNS_LOOKUP_PROTOCOL_DOT || cfg.Protocol == DNS_LOOKUP_PROTOCOL_DOH) && len(cfg.Resolvers) == 0 {
+		return nil, fmt.Errorf("option %s=%s requires option %s", OPT_DNS_LOOKUP_PROTOCOL, cfg.Protocol, OPT_DNS_LOOKUP_RESOLVERS)
+	}
+	cfg.Timeout, _ = c.GetDurationOption(OPT_DNS_LOOKUP_TIMEOUT)
+	cfg.Retries, _ = c.GetIntOption(OPT_DNS_LOOKUP_RETRIES)
+	if cfg.Retries < 0 {
+		return nil, fmt.Errorf("option %s must not be negative", OPT_DNS_LOOKUP_RETRIES)
+	}
+	if cfg.Protocol == DNS_LOOKUP_PROTOCOL_DOH {
+		cfg.httpClient()
+	}
+	return cfg, nil
+}
+
+func (this *DNSLookupConfig) protocol() string {
+	if this == nil || this.Protocol == "" {
+		return DNS_LOOKUP_PROTOCOL_UDP
+	}
+	return this.Protocol
+}
+
+func (this *DNSLookupConfig) timeout() time.Duration {
+	if this == nil || this.Timeout <= 0 {
+		return DEFAULT_DNS_LOOKUP_TIMEOUT
+	}
+	return this.Timeout
+}
+
+func (this *DNSLookupConfig) retries() int {
+	if this == nil {
+		return 0
+	}
+	return this.Retries
+}
+
+func (this *DNSLookupConfig) resolvers() []string {
+	if this == nil {
+		return nil
+	}
+	return this.Resolvers
+}
+
+func (this *DNSLookupConfig) String() string {
+	if this == nil || len(this.Resolvers) == 0 {
+		return fmt.Sprintf("host resolver (timeout %v, retries %d)", this.timeout(), this.retries())
+	}
+	return fmt.Sprintf("%s via %s (timeout %v, retries %d)", strings.Join(this.Resolvers, ","), this.protocol(), this.timeout(), this.retries())
+}
+
+// parseResolvers parses a comma separated list of resolvers for the configured protocol.
+func (this *DNSLookupConfig) parseResolvers(value string) ([]string, error) {
+	var resolvers []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		address, err := this.normalizeResolver(item)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, address)
+	}
+	return resolvers, nil
+}
+
+// normalizeResolver validates the address of a resolver. Addresses are returned as host:port
+// (default port 53, or 853 for DNS over TLS), URLs for DNS over HTTPS are kept.
+func (this *DNSLookupConfig) normalizeResolver(address string) (string, error) {
+	switch this.protocol() {
+	case DNS_LOOKUP_PROTOCOL_DOH:
+		u, err := url.Parse(address)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return "", fmt.Errorf("invalid DNS over HTTPS resolver %q (expected https URL)", address)
+		}
+		return address, nil
+	case DNS_LOOKUP_PROTOCOL_DOT:
+		if _, _, err := net.SplitHostPort(address); err == nil {
+			return address, nil
+		}
+		normalized, err := dnsutils.NormalizeResolverAddress(address)
+		if err != nil {
+			return "", err
+		}
+		host, _, _ := net.SplitHostPort(normalized)
+		return net.JoinHostPort(host, "853"), nil
+	default:
+		return dnsutils.NormalizeResolverAddress(address)
+	}
+}
+
+// newResolver returns a resolver sending all queries to the given resolver with the configured protocol.
+func (this *DNSLookupConfig) newResolver(server string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return this.dial(ctx, network, server)
+		},
+	}
+}
+
+// newPlainResolver returns a resolver sending all queries to the given server with plain DNS,
+// e.g. for querying authoritative name servers.
+func (this *DNSLookupConfig) newPlainResolver(server string) *net.Resolver {
+	plain := &DNSLookupConfig{Protocol: DNS_LOOKUP_PROTOCOL_UDP, Timeout: this.timeout()}
+	return plain.newResolver(server)
+}
+
+// dial opens a connection to a resolver. Connections for TCP, DNS over TLS and DNS over HTTPS
+// are streams transferring DNS messages prefixed by their length.
+func (this *DNSLookupConfig) dial(ctx context.Context, network, server string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: this.timeout()}
+	switch this.protocol() {
+	case DNS_LOOKUP_PROTOCOL_TCP:
+		return dialer.DialContext(ctx, "tcp", server)
+	case DNS_LOOKUP_PROTOCOL_DOT:
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: this.tlsConfig()}
+		return tlsDialer.DialContext(ctx, "tcp", server)
+	case DNS_LOOKUP_PROTOCOL_DOH:
+		return newDoHConn(ctx, this.httpClient(), server), nil
+	default:
+		return dialer.DialContext(ctx, network, server)
+	}
+}
+
+func (this *DNSLookupConfig) tlsConfig() *tls.Config {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if this != nil {
+		this.TLSPolicy.Apply(cfg)
+	}
+	return cfg
+}
+
+// httpClient returns the HTTP client for DNS over HTTPS, which is created once
+// per config, so its transport and idle connections are reused by all queries.
+func (this *DNSLookupConfig) httpClient() *http.Client {
+	this.clientOnce.Do(func() {
+		this.client = &http.Client{
+			Timeout: this.timeout(),
+			Transport: &http.Transport{
+				Proxy:             http.ProxyFromEnvironment,
+				TLSClientConfig:   this.tlsConfig(),
+				ForceAttemptHTTP2: true,
+				MaxIdleConns:      10,
+				IdleConnTimeout:   90 * time.Second,
+			},
+		}
+	})
+	return this.client
+}
+
+// lookup calls the lookup function with the host resolver or with the given resolvers in the given order
+// until one of them answers. Each call is limited by the configured timeout. If all resolvers fail, the lookup
+// is retried, but not for names not found.
+func (this *DNSLookupConfig) lookup(ctx context.Context, resolvers []string, lookup func(ctx context.Context, resolver *net.Resolver) error) error {
+	candidates := []*net.Resolver{net.DefaultResolver}
+	if len(resolvers) > 0 {
+		candidates = nil
+		for _, server := range resolvers {
+			candidates = append(candidates, this.newResolver(server))
+		}
+	}
+	var err error
+	for attempt := 0; attempt <= this.retries(); attempt++ {
+		for _, resolver := range candidates {
+			qctx, cancel := context.WithTimeout(ctx, this.timeout())
+			err = lookup(qctx, resolver)
+			cancel()
+			if err == nil || isNotFound(err) {
+				return err
+			}
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// lookupIP resolves the IPv4 and IPv6 addresses of a hostname with the configured resolvers.
+func (this *DNSLookupConfig) lookupIP(hostname string) ([]net.IP, error) {
+	var ips []net.IP
+	err := this.lookup(context.Background(), this.resolvers(), func(ctx context.Context, resolver *net.Resolver) error {
+		var err error
+		ips, err = resolver.LookupIP(ctx, "ip", hostname)
+		return err
+	})
+	return ips, err
+}
+
+// dohConn is a stream connection transferring each DNS message written by a resolver
+// as DNS over HTTPS request (RFC 8484). The answer is provided for reading.
+type dohConn struct {
+	ctx      context.Context
+	client   *http.Client
+	url      string
+	deadline time.Time
+	request  bytes.Buffer
+	response bytes.Reader
+}
+
+var _ net.Conn = &dohConn{}
+
+func newDoHConn(ctx context.Context, client *http.Client, url string) *dohConn {
+	return &dohConn{ctx: ctx, client: client, url: url}
+}
+
+func (this *dohConn) Write(b []byte) (int, error) {
+	return this.request.Write(b)
+}
+
+func (this *dohConn) Read(b []byte) (int, error) {
+	if this.response.Len() == 0 {
+		if err := this.roundTrip(); err != nil {
+			return 0, err
+		}
+	}
+	return this.response.Read(b)
+}
+
+// roundTrip posts the next complete message of the request buffer.
+func (this *dohConn) roundTrip() error {
+	data := this.request.Bytes()
+	if len(data) < 2 || len(data) < 2+int(binary.BigEndian.Uint16(data)) {
+		return io.ErrUnexpectedEOF
+	}
+	msg := this.request.Next(2 + int(binary.BigEndian.Uint16(data)))[2:]
+
+	ctx := this.ctx
+	if !this.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, this.deadline)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, this.url, bytes.NewReader(msg))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+	resp, err := this.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("DNS over HTTPS request to %s failed with status %d", this.url, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 65535))
+	if err != nil {
+		return err
+	}
+	answer := make([]byte, 2, 2+len(body))
+	binary.BigEndian.PutUint16(answer, uint16(len(body)))
+	this.response.Reset(append(answer, body...))
+	return nil
+}
+
+func (this *dohConn) Close() error {
+	return nil
+}
+
+func (this *dohConn) LocalAddr() net.Addr {
+	return dohAddr("")
+}
+
+func (this *dohConn) RemoteAddr() net.Addr {
+	return dohAddr(this.url)
+}
+
+func (this *dohConn) SetDeadline(t time.Time) error {
+	this.deadline = t
+	return nil
+}
+
+func (this *dohConn) SetReadDeadline(t time.Time) error {
+	return this.SetDeadline(t)
+}
+
+func (this *dohConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+type dohAddr string
+
+func (this dohAddr) Network() string {
+	return DNS_LOOKUP_PROTOCOL_DOH
+}
+
+func (this dohAddr) String() string {
+	return string(this)
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("DNS lookup configuration", func() {
+	ginkgov2.It("normalizes the resolvers for the protocol", func() {
+		var none *DNSLookupConfig
+		Ω(none.parseResolvers("8.8.8.8, dns.example.com:5353")).Should(Equal([]string{"8.8.8.8:53", "dns.example.com:5353"}))
+
+		dot := &DNSLookupConfig{Protocol: DNS_LOOKUP_PROTOCOL_DOT}
+		Ω(dot.parseResolvers("1.1.1.1,dns.example.com:8853,2001:db8::1")).Should(Equal([]string{"1.1.1.1:853", "dns.example.com:8853", "[2001:db8::1]:853"}))
+
+		doh := &DNSLookupConfig{Protocol: DNS_LOOKUP_PROTOCOL_DOH}
+		Ω(doh.parseResolvers("https://dns.example.com/dns-query")).Should(Equal([]string{"https://dns.example.com/dns-query"}))
+		_, err := doh.parseResolvers("dns.example.com")
+		Ω(err).Should(MatchError("invalid DNS over HTTPS resolver \"dns.example.com\" (expected https URL)"))
+	})
+
+	ginkgov2.It("retries failed lookups with all resolvers, but not names not found", func() {
+		cfg := &DNSLookupConfig{Resolvers: []string{"10.0.0.1:53", "10.0.0.2:53"}, Timeout: time.Second, Retries: 2}
+		calls := 0
+		err := cfg.lookup(context.Background(), cfg.Resolvers, func(ctx context.Context, resolver *net.Resolver) error {
+			calls++
+			return fmt.Errorf("failed")
+		})
+		Ω(err).Should(MatchError("failed"))
+		Ω(calls).Should(Equal(6))
+
+		calls = 0
+		err = cfg.lookup(context.Background(), cfg.Resolvers, func(ctx context.Context, resolver *net.Resolver) error {
+			calls++
+			return &net.DNSError{Err: "no such host", Name: "www.example.com", IsNotFound: true}
+		})
+		Ω(isNotFound(err)).Should(BeTrue())
+		Ω(calls).Should(Equal(1))
+	})
+
+	ginkgov2.It("reuses the HTTP client for DNS over HTTPS", func() {
+		cfg := &DNSLookupConfig{Protocol: DNS_LOOKUP_PROTOCOL_DOH, Resolvers: []string{"https://dns.example.com/dns-query"}}
+		client := cfg.httpClient()
+		Ω(client).ShouldNot(BeNil())
+		Ω(cfg.httpClient()).Should(BeIdenticalTo(client))
+		Ω(client.Transport).Should(BeIdenticalTo(cfg.httpClient().Transport))
+		Ω((&DNSLookupConfig{Protocol: DNS_LOOKUP_PROTOCOL_DOH}).httpClient()).ShouldNot(BeIdenticalTo(client))
+	})
+
+	ginkgov2.It("resolves names with DNS over HTTPS", func() {
+		requests := 0
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			query, _ := io.ReadAll(r.Body)
+			if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/dns-message" || len(query) < 12 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			name, off, _ := readDNSName(query, 12)
+			var answer []dnsRecord
+			switch binary.BigEndian.Uint16(query[off:]) {
+			case dnsTypeA:
+				answer = []dnsRecord{{name: name, rtype: dnsTypeA, ttl: 60, value: "1.2.3.4"}}
+			case dnsTypeAAAA:
+				answer = []dnsRecord{{name: name, rtype: dnsTypeAAAA, ttl: 60, value: "2001:db8::1"}}
+			}
+			w.Header().Set("Content-Type", "application/dns-message")
+			_, _ = w.Write(packTestDNSResponse(query, dnsRcodeSuccess, answer))
+		}))
+		defer server.Close()
+
+		resolver := &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return newDoHConn(ctx, server.Client(), server.URL), nil
+			},
+		}
+		addrs, err := resolver.LookupHost(context.Background(), "www.example.com.")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(addrs).Should(ConsistOf("1.2.3.4", "2001:db8::1"))
+		Ω(requests).Should(BeNumerically(">=", 2))
+
+		conn := newDoHConn(context.Background(), server.Client(), server.URL+"/missing")
+		query, err := packDNSQuery(1, "www.example.com", dnsTypeA)
+		Ω(err).ShouldNot(HaveOccurred())
+		_, _ = conn.Write(append([]byte{0, byte(len(query))}, query...))
+		_, err = conn.Read(make([]byte, 2))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(conn.response.Len()).Should(BeNumerically(">", 0))
+	})
+})
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var policyRecordTypes = map[string]bool{
+	dns.RS_A:     true,
+	dns.RS_AAAA:  true,
+	dns.RS_CNAME: true,
+	dns.RS_TXT:   true,
+}
+
+// dnsPolicy is the validated form of a DNSPolicy used to check entries
+type dnsPolicy struct {
+	name string
+	spec api.DNSPolicySpec
+}
+
+func newDNSPolicy(name string, spec *api.DNSPolicySpec) (*dnsPolicy, error) {
+	if spec.MinTTL != nil && *spec.MinTTL <= 0 {
+		return nil, fmt.Errorf("minTTL must be greater than zero")
+	}
+	if spec.MaxTTL != nil && *spec.MaxTTL <= 0 {
+		return nil, fmt.Errorf("maxTTL must be greater than zero")
+	}
+	if spec.MinTTL != nil && spec.MaxTTL != nil && *spec.MinTTL > *spec.MaxTTL {
+		return nil, fmt.Errorf("minTTL %d is greater than maxTTL %d", *spec.MinTTL, *spec.MaxTTL)
+	}
+	for _, t := range spec.ForbiddenRecordTypes {
+		if !policyRecordTypes[strings.ToUpper(t)] {
+			return nil, fmt.Errorf("unsupported record type %q", t)
+		}
+	}
+	for i, rule := range spec.AllowedDomains {
+		if len(rule.DomainSuffixes) == 0 {
+			return nil, fmt.Errorf("allowed domain rule %d has no domain suffixes", i+1)
+		}
+		for _, suffix := range rule.DomainSuffixes {
+			if normalizePolicyDomain(suffix) == "" {
+				return nil, fmt.Errorf("allowed domain rule %d has empty domain suffix", i+1)
+			}
+		}
+	}
+	for i, quota := range spec.Quotas {
+		if quota.MaxEntries < 0 {
+			return nil, fmt.Errorf("maxEntries of quota %d must not be negative", i+1)
+		}
+	}
+	for i, defaults := range spec.Defaults {
+		if defaults.TTL != nil && *defaults.TTL <= 0 {
+			return nil, fmt.Errorf("default TTL of rule %d must be greater than zero", i+1)
+		}
+	}
+	return &dnsPolicy{name: name, spec: *spec.DeepCopy()}, nil
+}
+
+// EntryDefaults returns the first defaults rule selecting the given namespace.
+func (this *dnsPolicy) EntryDefaults(namespace string) *api.EntryDefaults {
+	for i, defaults := range this.spec.Defaults {
+		if len(defaults.Namespaces) == 0 || containsString(defaults.Namespaces, namespace) {
+			return &this.spec.Defaults[i]
+		}
+	}
+	return nil
+}
+
+// Quota returns the first quota selecting the given namespace.
+func (this *dnsPolicy) Quota(namespace string) *api.NamespaceQuota {
+	for i, quota := range this.spec.Quotas {
+		if len(quota.Namespaces) == 0 || containsString(quota.Namespaces, namespace) {
+			return &this.spec.Quotas[i]
+		}
+	}
+	return nil
+}
+
+// Check validates the given entry attributes against the policy.
+// The ttl may be nil if it is not known yet.
+func (this *dnsPolicy) Check(namespace, dnsname string, ttl *int64, targets Targets) error {
+	name := normalizePolicyDomain(dnsname)
+	if len(this.spec.AllowedDomains) > 0 && !this.isDomainAllowed(namespace, name) {
+		return this.errorf("domain name %q not allowed in namespace %q", dnsname, namespace)
+	}
+	if w := this.spec.Wildcards; w != nil && w.Forbidden && strings.HasPrefix(name, "*.") && !containsString(w.AllowedNamespaces, namespace) {
+		return this.errorf("wildcard domain name %q not allowed in namespace %q", dnsname, namespace)
+	}
+	if ttl != nil {
+		if this.spec.MinTTL != nil && *ttl < *this.spec.MinTTL {
+			return this.errorf("TTL %d is lower than minimum %d", *ttl, *this.spec.MinTTL)
+		}
+		if this.spec.MaxTTL != nil && *ttl > *this.spec.MaxTTL {
+			return this.errorf("TTL %d is greater than maximum %d", *ttl, *this.spec.MaxTTL)
+		}
+	}
+	for _, t := range this.spec.ForbiddenRecordTypes {
+		rtype := strings.ToUpper(t)
+		for _, target := range targets {
+			if target.GetRecordType() == rtype {
+				return this.errorf("record type %s is forbidden", rtype)
+			}
+		}
+	}
+	return nil
+}
+
+func (this *dnsPolicy) isDomainAllowed(namespace, name string) bool {
+	for _, rule := range this.spec.AllowedDomains {
+		if len(rule.Namespaces) > 0 && !containsString(rule.Namespaces, namespace) {
+			continue
+		}
+		for _, suffix := range rule.DomainSuffixes {
+			suffix = normalizePolicyDomain(suffix)
+			if name == suffix || strings.HasSuffix(name, "."+suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (this *dnsPolicy) errorf(msg string, args ...interface{}) error {
+	return fmt.Errorf("violates dns policy %q: %s", this.name, fmt.Sprintf(msg, args...))
+}
+
+func normalizePolicyDomain(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
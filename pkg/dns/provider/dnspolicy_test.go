@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgov2.Describe("DNS policy", func() {
+	int64ptr := func(v int64) *int64 { return &v }
+	aTargets := Targets{dnsutils.NewTarget(dns.RS_A, "1.2.3.4", 300)}
+
+	ginkgov2.It("rejects invalid policies", func() {
+		_, err := newDNSPolicy("p", &api.DNSPolicySpec{MinTTL: int64ptr(600), MaxTTL: int64ptr(60)})
+		Ω(err).Should(HaveOccurred())
+		_, err = newDNSPolicy("p", &api.DNSPolicySpec{ForbiddenRecordTypes: []string{"MX"}})
+		Ω(err).Should(HaveOccurred())
+		_, err = newDNSPolicy("p", &api.DNSPolicySpec{AllowedDomains: []api.DomainRule{{Namespaces: []string{"a"}}}})
+		Ω(err).Should(HaveOccurred())
+	})
+
+	ginkgov2.It("checks allowed domains per namespace", func() {
+		pol, err := newDNSPolicy("p", &api.DNSPolicySpec{
+			AllowedDomains: []api.DomainRule{
+				{Namespaces: []string{"team-a"}, DomainSuffixes: []string{"a.example.com"}},
+				{DomainSuffixes: []string{"shared.example.com."}},
+			},
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(pol.Check("team-a", "www.a.example.com", nil, aTargets)).Should(Succeed())
+		Ω(pol.Check("team-a", "A.Example.com", nil, aTargets)).Should(Succeed())
+		Ω(pol.Check("team-b", "www.shared.example.com", nil, aTargets)).Should(Succeed())
+		Ω(pol.Check("team-b", "www.a.example.com", nil, aTargets)).ShouldNot(Succeed())
+		Ω(pol.Check("team-a", "www.xa.example.com", nil, aTargets)).ShouldNot(Succeed())
+	})
+
+	ginkgov2.It("checks ttl limits", func() {
+		pol, err := newDNSPolicy("p", &api.DNSPolicySpec{MinTTL: int64ptr(60), MaxTTL: int64ptr(600)})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(pol.Check("ns", "www.example.com", int64ptr(300), aTargets)).Should(Succeed())
+		Ω(pol.Check("ns", "www.example.com", nil, aTargets)).Should(Succeed())
+		Ω(pol.Check("ns", "www.example.com", int64ptr(30), aTargets)).ShouldNot(Succeed())
+		Ω(pol.Check("ns", "www.example.com", int64ptr(3600), aTargets)).ShouldNot(Succeed())
+	})
+
+	ginkgov2.It("checks forbidden record types", func() {
+		pol, err := newDNSPolicy("p", &api.DNSPolicySpec{ForbiddenRecordTypes: []string{"cname"}})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(pol.Check("ns", "www.example.com", nil, aTargets)).Should(Succeed())
+		cname := Targets{dnsutils.NewTarget(dns.RS_CNAME, "other.example.com", 300)}
+		Ω(pol.Check("ns", "www.example.com", nil, cname)).ShouldNot(Succeed())
+	})
+
+	ginkgov2.It("selects quotas per namespace", func() {
+		_, err := newDNSPolicy("p", &api.DNSPolicySpec{Quotas: []api.NamespaceQuota{{MaxEntries: -1}}})
+		Ω(err).Should(HaveOccurred())
+
+		pol, err := newDNSPolicy("p", &api.DNSPolicySpec{
+			Quotas: []api.NamespaceQuota{
+				{Namespaces: []string{"team-a"}, MaxEntries: 100},
+				{MaxEntries: 10},
+			},
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(pol.Quota("team-a").MaxEntries).Should(Equal(100))
+		Ω(pol.Quota("team-b").MaxEntries).Should(Equal(10))
+
+		pol, err = newDNSPolicy("p", &api.DNSPolicySpec{Quotas: []api.NamespaceQuota{{Namespaces: []string{"team-a"}, MaxEntries: 1}}})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(pol.Quota("team-b")).Should(BeNil())
+	})
+
+	ginkgov2.It("checks wildcard restrictions", func() {
+		pol, err := newDNSPolicy("p", &api.DNSPolicySpec{
+			Wildcards: &api.WildcardRestriction{Forbidden: true, AllowedNamespaces: []string{"ingress"}},
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(pol.Check("ns", "www.example.com", nil, aTargets)).Should(Succeed())
+		Ω(pol.Check("ns", "*.example.com", nil, aTargets)).ShouldNot(Succeed())
+		Ω(pol.Check("ingress", "*.example.com", nil, aTargets)).Should(Succeed())
+	})
+})
@@ -0,0 +1,272 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// Minimal DNS client for lookups which need the records of a response including their TTLs
+// (the host resolver only provides the final addresses).
+
+const (
+	dnsTypeA     uint16 = 1
+	dnsTypeCNAME uint16 = 5
+	dnsTypeSOA   uint16 = 6
+	dnsTypeAAAA  uint16 = 28
+	dnsTypeOPT   uint16 = 41
+
+	dnsClassINET uint16 = 1
+
+	dnsRcodeSuccess   = 0
+	dnsRcodeNameError = 3
+
+	dnsUDPSize = 1232
+)
+
+var dnsRcodeNames = map[int]string{1: "FORMERR", 2: "SERVFAIL", 3: "NXDOMAIN", 4: "NOTIMP", 5: "REFUSED"}
+
+// dnsRecord is a record of the answer section of a DNS response.
+// The value is the IP address for A and AAAA records and the target for CNAME records.
+type dnsRecord struct {
+	name  string
+	rtype uint16
+	ttl   uint32
+	value string
+}
+
+type dnsResponse struct {
+	rcode  int
+	answer []dnsRecord
+	// negativeTTL is the TTL for caching a missing answer given by the SOA record
+	// of the authority section (0 if not available)
+	negativeTTL uint32
+}
+
+// exchangeDNSQuery sends a recursive query to the given resolver with the configured protocol.
+// Truncated UDP responses are repeated with TCP.
+func exchangeDNSQuery(lookup *DNSLookupConfig, server, name string, qtype uint16) (*dnsResponse, error) {
+	id := newDNSQueryID()
+	query, err := packDNSQuery(id, name, qtype)
+	if err != nil {
+		return nil, err
+	}
+	resp, truncated, err := sendDNSQuery(lookup, "udp", server, query, id)
+	if err == nil && truncated {
+		resp, _, err = sendDNSQuery(lookup, "tcp", server, query, id)
+	}
+	return resp, err
+}
+
+func sendDNSQuery(lookup *DNSLookupConfig, network, server string, query []byte, id uint16) (*dnsResponse, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), lookup.timeout())
+	defer cancel()
+	conn, err := lookup.dial(ctx, network, server)
+	if err != nil {
+		return nil, false, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, false, err
+		}
+	}
+	var msg []byte
+	if _, ok := conn.(net.PacketConn); ok {
+		if _, err := conn.Write(query); err != nil {
+			return nil, false, err
+		}
+		msg = make([]byte, dnsUDPSize)
+		n, err := conn.Read(msg)
+		if err != nil {
+			return nil, false, err
+		}
+		msg = msg[:n]
+	} else {
+		buf := make([]byte, 2, 2+len(query))
+		binary.BigEndian.PutUint16(buf, uint16(len(query)))
+		if _, err := conn.Write(append(buf, query...)); err != nil {
+			return nil, false, err
+		}
+		if _, err := io.ReadFull(conn, buf[:2]); err != nil {
+			return nil, false, err
+		}
+		msg = make([]byte, binary.BigEndian.Uint16(buf))
+		if _, err := io.ReadFull(conn, msg); err != nil {
+			return nil, false, err
+		}
+	}
+	return unpackDNSResponse(msg, id)
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func newDNSQueryID() uint16 {
+	b := make([]byte, 2)
+	_, _ = rand.Read(b)
+	return binary.BigEndian.Uint16(b)
+}
+
+// packDNSQuery creates a recursive query with an EDNS0 record announcing the UDP payload size.
+func packDNSQuery(id uint16, name string, qtype uint16) ([]byte, error) {
+	msg := make([]byte, 12, 512)
+	binary.BigEndian.PutUint16(msg[0:], id)
+	binary.BigEndian.PutUint16(msg[2:], 0x0100) // recursion desired
+	binary.BigEndian.PutUint16(msg[4:], 1)
+	binary.BigEndian.PutUint16(msg[10:], 1)
+	name = strings.TrimSuffix(name, ".")
+	if name != "" {
+		for _, label := range strings.Split(name, ".") {
+			if len(label) == 0 || len(label) > 63 {
+				return nil, fmt.Errorf("invalid DNS name %q", name)
+			}
+			msg = append(msg, byte(len(label)))
+			msg = append(msg, label...)
+		}
+	}
+	msg = append(msg, 0)
+	msg = appendUint16(msg, qtype)
+	msg = appendUint16(msg, dnsClassINET)
+	// OPT record: root name, type, UDP payload size as class, extended rcode and flags, no data
+	msg = append(msg, 0)
+	msg = appendUint16(msg, dnsTypeOPT)
+	msg = appendUint16(msg, dnsUDPSize)
+	msg = append(msg, 0, 0, 0, 0, 0, 0)
+	return msg, nil
+}
+
+// unpackDNSResponse parses the answer and authority sections of a response.
+// It returns true if the response is truncated.
+func unpackDNSResponse(msg []byte, id uint16) (*dnsResponse, bool, error) {
+	if len(msg) < 12 {
+		return nil, false, fmt.Errorf("DNS response too short")
+	}
+	if binary.BigEndian.Uint16(msg[0:]) != id {
+		return nil, false, fmt.Errorf("DNS response id mismatch")
+	}
+	flags := binary.BigEndian.Uint16(msg[2:])
+	if flags&0x8000 == 0 {
+		return nil, false, fmt.Errorf("DNS message is no response")
+	}
+	if flags&0x0200 != 0 {
+		return nil, true, nil
+	}
+	resp := &dnsResponse{rcode: int(flags & 0x000f)}
+	qdcount := int(binary.BigEndian.Uint16(msg[4:]))
+	ancount := int(binary.BigEndian.Uint16(msg[6:]))
+	nscount := int(binary.BigEndian.Uint16(msg[8:]))
+
+	off := 12
+	var err error
+	for i := 0; i < qdcount; i++ {
+		if _, off, err = readDNSName(msg, off); err != nil {
+			return nil, false, err
+		}
+		off += 4
+	}
+	for i := 0; i < ancount+nscount; i++ {
+		var name string
+		if name, off, err = readDNSName(msg, off); err != nil {
+			return nil, false, err
+		}
+		if off+10 > len(msg) {
+			return nil, false, fmt.Errorf("DNS response truncated")
+		}
+		rtype := binary.BigEndian.Uint16(msg[off:])
+		ttl := binary.BigEndian.Uint32(msg[off+4:])
+		rdlen := int(binary.BigEndian.Uint16(msg[off+8:]))
+		off += 10
+		if off+rdlen > len(msg) {
+			return nil, false, fmt.Errorf("DNS response truncated")
+		}
+		rdata := msg[off : off+rdlen]
+		if i < ancount {
+			record := dnsRecord{name: name, rtype: rtype, ttl: ttl}
+			switch {
+			case rtype == dnsTypeA && rdlen == net.IPv4len, rtype == dnsTypeAAAA && rdlen == net.IPv6len:
+				record.value = net.IP(rdata).String()
+			case rtype == dnsTypeCNAME:
+				if record.value, _, err = readDNSName(msg, off); err != nil {
+					return nil, false, err
+				}
+			default:
+				off += rdlen
+				continue
+			}
+			resp.answer = append(resp.answer, record)
+		} else if rtype == dnsTypeSOA {
+			// skip primary name server and mailbox, the minimum TTL is the last of five 32-bit fields
+			if rdlen < 22 {
+				return nil, false, fmt.Errorf("invalid SOA record")
+			}
+			min := binary.BigEndian.Uint32(rdata[rdlen-4:])
+			if ttl < min {
+				min = ttl
+			}
+			resp.negativeTTL = min
+		}
+		off += rdlen
+	}
+	return resp, false, nil
+}
+
+// readDNSName reads a possibly compressed name and returns it in lower case with trailing dot
+// together with the offset following the name.
+func readDNSName(msg []byte, off int) (string, int, error) {
+	var labels []string
+	end := -1
+	for jumps := 0; ; {
+		if off >= len(msg) {
+			return "", 0, fmt.Errorf("DNS response truncated")
+		}
+		l := int(msg[off])
+		switch {
+		case l == 0:
+			if end < 0 {
+				end = off + 1
+			}
+			return strings.ToLower(strings.Join(labels, ".")) + ".", end, nil
+		case l&0xc0 == 0xc0:
+			if off+1 >= len(msg) {
+				return "", 0, fmt.Errorf("DNS response truncated")
+			}
+			if jumps++; jumps > 32 {
+				return "", 0, fmt.Errorf("too many compression pointers in DNS name")
+			}
+			if end < 0 {
+				end = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(msg[off:]) & 0x3fff)
+		case l&0xc0 != 0:
+			return "", 0, fmt.Errorf("invalid DNS label")
+		default:
+			if off+1+l > len(msg) {
+				return "", 0, fmt.Errorf("DNS response truncated")
+			}
+			labels = append(labels, string(msg[off+1:off+1+l]))
+			off += 1 + l
+		}
+	}
+}
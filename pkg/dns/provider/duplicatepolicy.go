@@ -0,0 +1,196 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+func createDuplicatePolicy(c controller.Interface) (api.DuplicatePolicy, error) {
+	value, err := c.GetStringOption(OPT_DUPLICATE_POLICY)
+	if err != nil || value == "" {
+		return api.DuplicatePolicyRejectNewer, nil
+	}
+	policy := api.DuplicatePolicy(value)
+	if err := validateDuplicatePolicy(policy); err != nil {
+		return "", err
+	}
+	return policy, nil
+}
+
+func validateDuplicatePolicy(policy api.DuplicatePolicy) error {
+	switch policy {
+	case api.DuplicatePolicyRejectNewer, api.DuplicatePolicyRejectOlder, api.DuplicatePolicyMergeTargets, api.DuplicatePolicyPriorityByAnnotation:
+		return nil
+	}
+	return fmt.Errorf("invalid duplicate policy %q (expected %s, %s, %s or %s)", policy, api.DuplicatePolicyRejectNewer,
+		api.DuplicatePolicyRejectOlder, api.DuplicatePolicyMergeTargets, api.DuplicatePolicyPriorityByAnnotation)
+}
+
+// duplicatePolicy determines the handling of an entry claiming the DNS name of another entry.
+// The policy of the hosted zone takes precedence over the policy of the DNS class and the controller default.
+// It must be called with the state lock held.
+func (this *state) duplicatePolicy(e *EntryVersion) api.DuplicatePolicy {
+	if zone := this.zones[e.ZoneId()]; zone != nil {
+		if pol := zone.Policy(); pol != nil && pol.spec.Policy.DuplicatePolicy != nil {
+			return *pol.spec.Policy.DuplicatePolicy
+		}
+	}
+	if policy := e.profile.DuplicatePolicy(); policy != nil {
+		return *policy
+	}
+	if this.config.DuplicatePolicy == "" {
+		return api.DuplicatePolicyRejectNewer
+	}
+	return this.config.DuplicatePolicy
+}
+
+// duplicatePriority returns the priority of an entry given by the annotation dns.gardener.cloud/duplicate-priority.
+func duplicatePriority(e *Entry) int {
+	value, ok := e.object.GetAnnotations()[dns.DUPLICATE_PRIORITY_ANNOTATION]
+	if !ok {
+		return 0
+	}
+	prio, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return prio
+}
+
+// keepsDNSName checks whether the current owner cur of a DNS name keeps it against the entry e.
+func keepsDNSName(policy api.DuplicatePolicy, cur, e *Entry) bool {
+	switch policy {
+	case api.DuplicatePolicyRejectOlder:
+		return e.Before(cur)
+	case api.DuplicatePolicyPriorityByAnnotation:
+		if pc, pe := duplicatePriority(cur), duplicatePriority(e); pc != pe {
+			return pc > pe
+		}
+	}
+	return cur.Before(e)
+}
+
+// canMergeTargets checks whether the targets of the duplicate entry e can be merged into the record sets of cur.
+func canMergeTargets(cur, e *Entry) bool {
+	return cur.Kind() == api.DNSEntryKind && e.Kind() == api.DNSEntryKind &&
+		cur.routingPolicy == nil && e.routingPolicy == nil && cur.OwnerId() == e.OwnerId()
+}
+
+// getMergedEntries returns the valid entries whose targets are merged into the record sets of the given entries
+// per DNS name. It must be called with the state lock held.
+func (this *state) getMergedEntries(entries Entries) map[ZonedDNSSetName]EntryList {
+	var merged map[ZonedDNSSetName]EntryList
+	for _, e := range this.entries {
+		if !e.merged || !e.duplicate || !e.IsValid() || e.IsDeleting() || e.IsOutOfSchedule() {
+			continue
+		}
+		cur := this.dnsnames[e.ZonedDNSName()]
+		if cur == nil || entries[cur.ObjectName()] != cur || !canMergeTargets(cur, e) {
+			continue
+		}
+		if merged == nil {
+			merged = map[ZonedDNSSetName]EntryList{}
+		}
+		merged[e.ZonedDNSName()] = append(merged[e.ZonedDNSName()], e)
+	}
+	return merged
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// mergedTargetSpec is the target spec of an entry extended by the targets of merged duplicate entries.
+type mergedTargetSpec struct {
+	dnsutils.TargetSpec
+	targets []dnsutils.Target
+}
+
+func (this *mergedTargetSpec) Targets() []dnsutils.Target {
+	return this.targets
+}
+
+func newMergedTargetSpec(spec dnsutils.TargetSpec, merged EntryList) dnsutils.TargetSpec {
+	found := map[string]struct{}{}
+	var targets []dnsutils.Target
+	add := func(list []dnsutils.Target) {
+		for _, t := range list {
+			key := t.GetRecordType() + ":" + t.GetHostName()
+			if _, ok := found[key]; !ok {
+				found[key] = struct{}{}
+				targets = append(targets, t)
+			}
+		}
+	}
+	add(spec.Targets())
+	for _, e := range merged {
+		add(e.object.GetTargetSpec(e).Targets())
+	}
+	return &mergedTargetSpec{TargetSpec: spec, targets: targets}
+}
+
+// mergedStatusUpdate propagates the result of applying merged record sets to the status of all merged entries.
+type mergedStatusUpdate struct {
+	*StatusUpdate
+	merged []*StatusUpdate
+}
+
+var _ DoneHandler = &mergedStatusUpdate{}
+
+func newMergedStatusUpdate(logger logger.LogContext, e *Entry, merged EntryList, f FinalizerHandler) *mergedStatusUpdate {
+	u := &mergedStatusUpdate{StatusUpdate: NewStatusUpdate(logger, e, f).(*StatusUpdate)}
+	for _, m := range merged {
+		u.merged = append(u.merged, NewStatusUpdate(logger, m, f).(*StatusUpdate))
+	}
+	return u
+}
+
+func (this *mergedStatusUpdate) SetInvalid(err error) {
+	this.StatusUpdate.SetInvalid(err)
+	for _, m := range this.merged {
+		m.SetInvalid(err)
+	}
+}
+
+func (this *mergedStatusUpdate) Failed(err error) {
+	this.StatusUpdate.Failed(err)
+	for _, m := range this.merged {
+		m.Failed(err)
+	}
+}
+
+func (this *mergedStatusUpdate) Throttled() {
+	this.StatusUpdate.Throttled()
+	for _, m := range this.merged {
+		m.Throttled()
+	}
+}
+
+func (this *mergedStatusUpdate) Succeeded() {
+	this.StatusUpdate.Succeeded()
+	for _, m := range this.merged {
+		m.records = this.records
+		m.Succeeded()
+	}
+}
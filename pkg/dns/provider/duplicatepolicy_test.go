@@ -0,0 +1,130 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// duplicateTestSpec provides the attributes of an entry object relevant for the duplicate policies.
+type duplicateTestSpec struct {
+	dnsutils.DNSSpecification
+	name        resources.ObjectName
+	created     time.Time
+	annotations map[string]string
+	ownerId     *string
+}
+
+func (this *duplicateTestSpec) ObjectName() resources.ObjectName {
+	return this.name
+}
+
+func (this *duplicateTestSpec) GetCreationTimestamp() metav1.Time {
+	return metav1.NewTime(this.created)
+}
+
+func (this *duplicateTestSpec) GetAnnotations() map[string]string {
+	return this.annotations
+}
+
+func (this *duplicateTestSpec) GetOwnerId() *string {
+	return this.ownerId
+}
+
+func (this *duplicateTestSpec) GroupKind() schema.GroupKind {
+	return schema.GroupKind{Group: api.GroupName, Kind: api.DNSEntryKind}
+}
+
+func (this *duplicateTestSpec) GetDeletionPolicy() *api.DeletionPolicy {
+	return nil
+}
+
+func (this *duplicateTestSpec) GetTargetSpec(p dnsutils.TargetProvider) dnsutils.TargetSpec {
+	return dnsutils.BaseTargetSpec(this, p)
+}
+
+var _ = ginkgov2.Describe("Duplicate policy", func() {
+	now := time.Now()
+
+	newEntry := func(name string, age time.Duration, priority string, targets ...string) *Entry {
+		spec := &duplicateTestSpec{
+			name:        resources.NewObjectName("default", name),
+			created:     now.Add(-age),
+			annotations: map[string]string{},
+		}
+		if priority != "" {
+			spec.annotations[dns.DUPLICATE_PRIORITY_ANNOTATION] = priority
+		}
+		v := &EntryVersion{object: spec}
+		for _, t := range targets {
+			v.targets = append(v.targets, dnsutils.NewTarget(dns.RS_A, t, 300))
+		}
+		return &Entry{EntryVersion: v}
+	}
+
+	ginkgov2.It("validates policies", func() {
+		Ω(validateDuplicatePolicy(api.DuplicatePolicyMergeTargets)).Should(Succeed())
+		Ω(validateDuplicatePolicy("first-wins")).ShouldNot(Succeed())
+	})
+
+	ginkgov2.It("decides which entry keeps the DNS name", func() {
+		older := newEntry("older", time.Hour, "")
+		newer := newEntry("newer", time.Minute, "10")
+
+		Ω(keepsDNSName(api.DuplicatePolicyRejectNewer, older, newer)).Should(BeTrue())
+		Ω(keepsDNSName(api.DuplicatePolicyMergeTargets, older, newer)).Should(BeTrue())
+		Ω(keepsDNSName(api.DuplicatePolicyRejectOlder, older, newer)).Should(BeFalse())
+		Ω(keepsDNSName(api.DuplicatePolicyRejectOlder, newer, older)).Should(BeTrue())
+		Ω(keepsDNSName(api.DuplicatePolicyPriorityByAnnotation, older, newer)).Should(BeFalse())
+		Ω(keepsDNSName(api.DuplicatePolicyPriorityByAnnotation, newer, older)).Should(BeTrue())
+
+		same := newEntry("same", time.Second, "invalid")
+		Ω(keepsDNSName(api.DuplicatePolicyPriorityByAnnotation, older, same)).Should(BeTrue())
+	})
+
+	ginkgov2.It("merges targets of duplicate entries", func() {
+		cur := newEntry("cur", time.Hour, "", "1.1.1.1", "2.2.2.2")
+		dup1 := newEntry("dup1", time.Minute, "", "2.2.2.2", "3.3.3.3")
+		dup2 := newEntry("dup2", time.Second, "", "4.4.4.4")
+		Ω(canMergeTargets(cur, dup1)).Should(BeTrue())
+
+		spec := newMergedTargetSpec(cur.object.GetTargetSpec(cur), EntryList{dup1, dup2})
+		var hosts []string
+		for _, t := range spec.Targets() {
+			hosts = append(hosts, t.GetHostName())
+		}
+		Ω(hosts).Should(Equal([]string{"1.1.1.1", "2.2.2.2", "3.3.3.3", "4.4.4.4"}))
+	})
+
+	ginkgov2.It("does not merge entries of different owners", func() {
+		cur := newEntry("cur", time.Hour, "")
+		dup := newEntry("dup", time.Minute, "")
+		owner := "other"
+		dup.object.(*duplicateTestSpec).ownerId = &owner
+		Ω(canMergeTargets(cur, dup)).Should(BeFalse())
+	})
+})
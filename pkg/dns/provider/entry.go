@@ -18,7 +18,6 @@ package provider
 
 import (
 	"fmt"
-	"net"
 	"reflect"
 	"sort"
 	"strconv"
@@ -36,7 +35,9 @@ import (
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/statistic"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
 
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 )
@@ -86,14 +87,24 @@ type EntryVersion struct {
 	routingPolicy *dns.RoutingPolicy
 	mappings      map[string][]string
 	warnings      []string
+	profile       *dnsClassProfile
+	records       *dns.DNSSet
 
 	status api.DNSBaseStatus
+	// requestedTTL is the TTL of the entry before clamping it to the TTL bounds (nil if not clamped)
+	requestedTTL *int64
 
 	interval    int64
 	responsible bool
 	valid       bool
 	duplicate   bool
-	obsolete    bool
+	// merged is set for duplicate entries whose targets are merged into the record sets of the DNS name
+	merged bool
+	// outOfSchedule is set for valid entries outside of the period given by activateAt and deactivateAt
+	outOfSchedule bool
+	// scheduleDelay is the delay until the next scheduled activation or deactivation (0 if there is none)
+	scheduleDelay time.Duration
+	obsolete      bool
 }
 
 func NewEntryVersion(object dnsutils.DNSSpecification, old *Entry) *EntryVersion {
@@ -145,6 +156,12 @@ func (this *EntryVersion) RequiresUpdateFor(e *EntryVersion) (reasons []string,
 	if this.obsolete != e.obsolete {
 		reasons = append(reasons, "provider responsibility changed")
 	}
+	if this.outOfSchedule != e.outOfSchedule {
+		reasons = append(reasons, "schedule changed")
+	}
+	if this.IsDryRun() != e.IsDryRun() {
+		reasons = append(reasons, "dry run changed")
+	}
 
 	if this.object.RefreshTime().Before(e.object.RefreshTime()) {
 		reasons = append(reasons, "refresh time changed")
@@ -165,6 +182,11 @@ func (this *EntryVersion) IsDeleting() bool {
 	return this.object.IsDeleting()
 }
 
+// IsOutOfSchedule checks whether the records of the entry are not scheduled at the moment (spec fields activateAt and deactivateAt).
+func (this *EntryVersion) IsOutOfSchedule() bool {
+	return this.outOfSchedule
+}
+
 func (this *EntryVersion) Object() dnsutils.DNSSpecification {
 	return this.object
 }
@@ -245,7 +267,16 @@ func (this *EntryVersion) OwnerId() string {
 	if this.object.GetOwnerId() != nil {
 		return *this.object.GetOwnerId()
 	}
-	return ""
+	return utils.StringValue(this.profile.OwnerId())
+}
+
+// defaultTTL returns the TTL used if the entry does not specify one.
+// The TTL of the class profile takes precedence over the provider default.
+func (this *EntryVersion) defaultTTL(provider DNSProvider) int64 {
+	if ttl := this.profile.TTL(); ttl != nil {
+		return *ttl
+	}
+	return provider.DefaultTTL()
 }
 
 type dnsSpecModification struct {
@@ -255,6 +286,7 @@ type dnsSpecModification struct {
 	ttl     *int64
 	ownerid *string
 	lookup  *int64
+	resolve *bool
 	policy  *dns.RoutingPolicy
 }
 
@@ -276,28 +308,45 @@ func (this *dnsSpecModification) GetOwnerId() *string {
 	if this.ownerid != nil {
 		return this.ownerid
 	}
-	return this.GetOwnerId()
+	return this.DNSSpecification.GetOwnerId()
 }
 
 func (this *dnsSpecModification) GetCNameLookupInterval() *int64 {
 	if this.lookup != nil {
 		return this.lookup
 	}
-	return this.GetCNameLookupInterval()
+	return this.DNSSpecification.GetCNameLookupInterval()
+}
+
+func (this *dnsSpecModification) GetResolveTargetsToAddresses() *bool {
+	if this.resolve != nil {
+		return this.resolve
+	}
+	return this.DNSSpecification.GetResolveTargetsToAddresses()
 }
 
 func (this *dnsSpecModification) GetTTL() *int64 {
 	if this.ttl != nil {
 		return this.ttl
 	}
-	return this.GetTTL()
+	return this.DNSSpecification.GetTTL()
+}
+
+func (this *dnsSpecModification) GetRoutingPolicy() *dns.RoutingPolicy {
+	if this.policy != nil {
+		return this.policy
+	}
+	return this.DNSSpecification.GetRoutingPolicy()
 }
 
 func (this *dnsSpecModification) IsModified() bool {
-	return this.targets != nil || this.text != nil || this.ownerid != nil || this.lookup != nil || this.ttl != nil || this.policy != nil
+	return this.targets != nil || this.text != nil || this.ownerid != nil || this.lookup != nil || this.resolve != nil || this.ttl != nil || this.policy != nil
 }
 
 func complete(logger logger.LogContext, state *state, spec dnsutils.DNSSpecification, object resources.Object, prefix string) (dnsutils.DNSSpecification, error) {
+	if spec.GetTargetRef() != nil {
+		return completeByTargetRef(logger, state, spec, object, prefix)
+	}
 	if ref := spec.GetReference(); ref != nil && ref.Name != "" {
 		mod := &dnsSpecModification{DNSSpecification: spec}
 		ns := ref.Namespace
@@ -347,6 +396,9 @@ func complete(logger logger.LogContext, state *state, spec dnsutils.DNSSpecifica
 		if spec.GetCNameLookupInterval() == nil {
 			mod.lookup = rspec.GetCNameLookupInterval()
 		}
+		if spec.GetResolveTargetsToAddresses() == nil {
+			mod.resolve = rspec.GetResolveTargetsToAddresses()
+		}
 		if mod.IsModified() {
 			return mod, nil
 		}
@@ -376,6 +428,10 @@ func validate(logger logger.LogContext, state *state, entry *EntryVersion, p *En
 	if err != nil {
 		return
 	}
+	effspec, err = state.completeHealthCheck(effspec, entry.object)
+	if err != nil {
+		return
+	}
 
 	if p.zonedomain == entry.dnsSetName.DNSName {
 		err = fmt.Errorf("usage of dns name (%s) identical to domain of hosted zone (%s) is not supported",
@@ -390,12 +446,26 @@ func validate(logger logger.LogContext, state *state, entry *EntryVersion, p *En
 		err = fmt.Errorf("TTL must be greater than zero")
 		return
 	}
+	if err = newEntrySchedule(effspec).Validate(); err != nil {
+		return
+	}
+	if err = validateLifetime(effspec); err != nil {
+		return
+	}
 
+	var stack dns.IPStack
+	if stack, err = entry.IPStack(); err != nil {
+		return
+	}
 	for i, t := range effspec.GetTargets() {
 		if strings.TrimSpace(t) == "" {
 			err = fmt.Errorf("target %d must not be empty", i+1)
 			return
 		}
+		if !stack.AcceptsAddress(t) {
+			warnings = append(warnings, fmt.Sprintf("dns entry %q ignores target %q for ip stack %s", entry.ObjectName(), t, stack))
+			continue
+		}
 		var new Target
 		new, err = NewHostTargetFromEntryVersion(t, entry)
 		if err != nil {
@@ -428,14 +498,36 @@ func validate(logger logger.LogContext, state *state, entry *EntryVersion, p *En
 
 	if len(targets) == 0 {
 		err = fmt.Errorf("no target or text specified")
+		return
+	}
+
+	ttl := effspec.GetTTL()
+	if ttl == nil && p.provider != nil {
+		defaultTTL := entry.defaultTTL(p.provider)
+		ttl = &defaultTTL
+	}
+	if ttl != nil {
+		min, max := state.ttlBounds(dns.NewZoneID(p.ptype, p.zoneid))
+		clamped := clampTTL(*ttl, min, max)
+		ttl = &clamped
 	}
+	if err = state.checkTargetCIDRs(p.provider, targets); err != nil {
+		return
+	}
+	err = state.checkDNSPolicies(entry.object.GetNamespace(), entry.object.GetDNSName(), ttl, targets)
+	if err != nil {
+		return
+	}
+	err = state.checkZonePolicy(dns.NewZoneID(p.ptype, p.zoneid), entry.object.GetNamespace(), entry.OwnerId(), entry.object.GetDNSName(), ttl, targets)
+	if err != nil {
+		return
+	}
+	err = state.checkQuotas(entry)
 	return
 }
 
 func validateOwner(logger logger.LogContext, state *state, entry *EntryVersion) error {
-	effspec := entry.object
-
-	if ownerid := utils.StringValue(effspec.GetOwnerId()); ownerid != "" {
+	if ownerid := entry.OwnerId(); ownerid != "" {
 		if entry.Kind() != api.DNSLockKind && !state.ownerCache.IsResponsibleFor(ownerid) && !state.ownerCache.IsResponsiblePendingFor(ownerid) {
 			return fmt.Errorf("unknown owner id '%s'", ownerid)
 		}
@@ -515,11 +607,12 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 		this.providername = p.provider.ObjectName()
 		provider = p.provider.ObjectName().String()
 		this.status.Provider = &provider
-		defaultTTL := p.provider.DefaultTTL()
+		defaultTTL := this.defaultTTL(p.provider)
 		this.status.TTL = &defaultTTL
 		if spec.GetTTL() != nil {
 			this.status.TTL = spec.GetTTL()
 		}
+		this.clampTTL(state, dns.NewZoneID(p.ptype, p.zoneid))
 	} else {
 		this.providername = nil
 		this.status.Provider = nil
@@ -538,6 +631,7 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 	spec, targets, warnings, verr := validate(logger, state, this, p)
 	if p.provider != nil && spec.GetTTL() != nil {
 		this.status.TTL = spec.GetTTL()
+		this.clampTTL(state, dns.NewZoneID(p.ptype, p.zoneid))
 	}
 
 	if verr != nil {
@@ -550,6 +644,7 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 	///////////// handle
 
 	hello.Infof(logger, "validation ok")
+	this.reportTTLClamping(state)
 
 	if this.IsDeleting() {
 		logger.Infof("update state to %s", api.STATE_DELETING)
@@ -558,7 +653,7 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 		this.valid = true
 	} else {
 		this.warnings = warnings
-		targets, multiCName, multiOk := normalizeTargets(logger, this.object, targets...)
+		targets, multiCName, multiOk := normalizeTargets(logger, state.config.HostResolver, spec, targets...)
 		if multiCName {
 			this.interval = int64(600)
 			if iv := spec.GetCNameLookupInterval(); iv != nil && *iv > 0 {
@@ -582,6 +677,12 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 				this.UpdateStatus(logger, state, verr.Error())
 				return reconcile.Recheck(logger, verr, time.Duration(this.interval)*time.Second)
 			}
+		} else if isPolledTargetRef(spec.GetTargetRef()) {
+			// changes of the referenced object are not watched
+			this.interval = int64(600)
+			if iv := spec.GetCNameLookupInterval(); iv != nil && *iv > 0 {
+				this.interval = *iv
+			}
 		} else {
 			this.interval = 0
 		}
@@ -618,6 +719,16 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 			}
 		}
 
+		if this.valid {
+			scheduled, msg, delay := newEntrySchedule(this.object).Check(time.Now())
+			this.scheduleDelay = delay
+			if !scheduled {
+				this.outOfSchedule = true
+				this.status.State = api.STATE_INACTIVE
+				this.status.Message = StatusMessage(msg)
+			}
+		}
+
 		if this.status.State == api.STATE_READY && this.object.BaseStatus() != nil && this.object.GetGeneration() != this.object.BaseStatus().ObservedGeneration {
 			this.status.State = api.STATE_PENDING
 		}
@@ -627,8 +738,12 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 		logger.Infof("%s: valid: %t, message: %s%s", this.status.State, this.valid, utils.StringValue(this.status.Message), errorValue(", err: %s", err))
 		logmsg := dnsutils.NewLogMessage("update entry status")
 		f := func(data resources.ObjectData) (bool, error) {
-			status := dnsutils.DNSObject(this.object.GetResource().Wrap(data)).BaseStatus()
+			o := dnsutils.DNSObject(this.object.GetResource().Wrap(data))
+			status := o.BaseStatus()
 			mod := &utils.ModificationState{}
+			if this.outOfSchedule {
+				mod.Modify(o.AcknowledgeProviderRecords("", nil))
+			}
 			if p.zoneid != "" {
 				mod.AssureStringPtrValue(&status.ProviderType, p.ptype)
 			}
@@ -647,6 +762,31 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 	return reconcile.DelayOnError(logger, err)
 }
 
+// IPStack returns the address families to use according to annotation dns.gardener.cloud/ip-stack
+func (this *EntryVersion) IPStack() (dns.IPStack, error) {
+	return objectIPStack(this.object)
+}
+
+func objectIPStack(object resources.Object) (dns.IPStack, error) {
+	value, _ := resources.GetAnnotation(object.Data(), dns.IP_STACK_ANNOTATION)
+	return dns.ParseIPStack(value)
+}
+
+// IsSettled checks whether the actual generation of the entry has already been provisioned successfully.
+func (this *EntryVersion) IsSettled() bool {
+	status := this.object.BaseStatus()
+	return this.status.State == api.STATE_READY && status != nil && status.ObservedGeneration == this.object.GetGeneration()
+}
+
+// IsDryRun checks for annotation dns.gardener.cloud/dry-run or a dry-run class profile
+func (this *EntryVersion) IsDryRun() bool {
+	value, ok := resources.GetAnnotation(this.object.Data(), dns.DRY_RUN_ANNOTATION)
+	if ok {
+		ok, _ = strconv.ParseBool(value)
+	}
+	return ok || this.profile.IsDryRun()
+}
+
 // NotRateLimited checks for annotation dns.gardener.cloud/not-rate-limited
 func (this *EntryVersion) NotRateLimited() bool {
 	value, ok := resources.GetAnnotation(this.object.Data(), dns.NOT_RATE_LIMITED_ANNOTATION)
@@ -674,7 +814,9 @@ func (this *EntryVersion) updateStatus(logger logger.LogContext, state, msg stri
 		if utils.StringValue(this.status.Provider) == "" {
 			mod.Modify(o.AcknowledgeTargets(nil))
 			mod.Modify(o.AcknowledgeRoutingPolicy(nil))
+			mod.Modify(o.AcknowledgeProviderRecords("", nil))
 		}
+		assureSourceCluster(mod, data)
 		if mod.IsModified() {
 			logmsg.Infof(logger)
 		}
@@ -685,6 +827,14 @@ func (this *EntryVersion) updateStatus(logger logger.LogContext, state, msg stri
 	return err
 }
 
+// assureSourceCluster reflects the source cluster annotation of a generated entry in its status.
+func assureSourceCluster(mod *utils.ModificationState, data resources.ObjectData) {
+	if e, ok := data.(*api.DNSEntry); ok {
+		cluster, _ := resources.GetAnnotation(e, dns.SOURCE_CLUSTER_ANNOTATION)
+		mod.AssureStringValue(&e.Status.SourceCluster, cluster)
+	}
+}
+
 func (this *EntryVersion) UpdateStatus(logger logger.LogContext, state string, msg string) (bool, error) {
 	f := func(data resources.ObjectData) (bool, error) {
 		obj, err := this.object.GetResource().Wrap(data)
@@ -708,14 +858,19 @@ func (this *EntryVersion) UpdateStatus(logger logger.LogContext, state string, m
 			if o.AcknowledgeRoutingPolicy(this.routingPolicy) {
 				mod.Modify(true)
 			}
+			if this.records != nil {
+				mod.Modify(o.AcknowledgeProviderRecords(utils.StringValue(this.status.Zone), this.records))
+			}
 			if this.status.Provider != nil {
 				mod.AssureStringPtrPtr(&b.Provider, this.status.Provider)
 			}
 		} else if state != api.STATE_STALE {
 			mod.Modify(o.AcknowledgeTargets(nil))
 			mod.Modify(o.AcknowledgeRoutingPolicy(nil))
+			mod.Modify(o.AcknowledgeProviderRecords("", nil))
 		}
 		mod.AssureInt64Value(&b.ObservedGeneration, o.GetGeneration())
+		assureSourceCluster(mod, data)
 		if !(this.status.State == api.STATE_STALE && this.status.State == state) {
 			mod.AssureStringPtrValue(&b.Message, msg)
 			this.status.Message = &msg
@@ -767,9 +922,9 @@ func targetList(targets Targets) ([]string, string) {
 	return list, msg
 }
 
-func normalizeTargets(logger logger.LogContext, object dnsutils.DNSSpecification, targets ...Target) (Targets, bool, bool) {
+func normalizeTargets(logger logger.LogContext, resolver *hostResolver, object dnsutils.DNSSpecification, targets ...Target) (Targets, bool, bool) {
 	multiCNAME := len(targets) > 1 && targets[0].GetRecordType() == dns.RS_CNAME
-	if !multiCNAME {
+	if !multiCNAME && !(resolveTargetsToAddresses(object) && hasCNAMETarget(targets)) {
 		return targets, false, false
 	}
 
@@ -780,9 +935,16 @@ func normalizeTargets(logger logger.LogContext, object dnsutils.DNSSpecification
 		object.Event(corev1.EventTypeWarning, "dnslookup restriction", w)
 		return result, true, false
 	}
+	stack, _ := objectIPStack(object)
 	for _, t := range targets {
-		ipv4addrs, ipv6addrs, err := lookupHosts(t.GetHostName())
+		ipv4addrs, ipv6addrs, err := resolver.LookupHosts(t.GetHostName())
 		if err == nil {
+			if !stack.AcceptsIPv4() {
+				ipv4addrs = nil
+			}
+			if !stack.AcceptsIPv6() {
+				ipv6addrs = nil
+			}
 		outerV4:
 			for _, addr := range ipv4addrs {
 				for _, old := range result {
@@ -810,8 +972,22 @@ func normalizeTargets(logger logger.LogContext, object dnsutils.DNSSpecification
 	return result, true, true
 }
 
-func lookupHosts(hostname string) ([]string, []string, error) {
-	ips, err := net.LookupIP(hostname)
+func resolveTargetsToAddresses(object dnsutils.DNSSpecification) bool {
+	resolve := object.GetResolveTargetsToAddresses()
+	return resolve != nil && *resolve
+}
+
+func hasCNAMETarget(targets Targets) bool {
+	for _, t := range targets {
+		if t.GetRecordType() == dns.RS_CNAME {
+			return true
+		}
+	}
+	return false
+}
+
+func lookupHosts(lookup *DNSLookupConfig, hostname string) ([]string, []string, error) {
+	ips, err := lookup.lookupIP(hostname)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -836,6 +1012,8 @@ type Entry struct {
 	lock           *dnsutils.TryLock
 	key            string
 	createdAt      time.Time
+	specChangedAt  time.Time
+	spanContext    trace.SpanContext
 	modified       bool
 	updateRequired bool
 	activezone     dns.ZoneID
@@ -856,6 +1034,13 @@ func NewEntry(v *EntryVersion, state *state) *Entry {
 	if v.status.ProviderType != nil && v.status.Zone != nil {
 		e.activezone = dns.NewZoneID(*v.status.ProviderType, *v.status.Zone)
 	}
+	if status := v.object.BaseStatus(); status != nil && status.ObservedGeneration != v.object.GetGeneration() {
+		if status.ObservedGeneration == 0 {
+			e.specChangedAt = v.object.GetCreationTimestamp().Time
+		} else {
+			e.specChangedAt = e.createdAt
+		}
+	}
 	return e
 }
 
@@ -907,6 +1092,9 @@ func (this *Entry) Update(logger logger.LogContext, new *EntryVersion) *Entry {
 		}
 		this.modified = true
 	}
+	if this.specChangedAt.IsZero() && new.object.GetGeneration() != this.object.GetGeneration() {
+		this.specChangedAt = time.Now()
+	}
 	this.EntryVersion = new
 
 	if new.valid && this.status.State == api.STATE_STALE {
@@ -916,6 +1104,19 @@ func (this *Entry) Update(logger logger.LogContext, new *EntryVersion) *Entry {
 	return this
 }
 
+// reportSpecApplied reports the duration from the first observed unapplied spec change until
+// the successful apply at the provider and starts the optional propagation check.
+func (this *Entry) reportSpecApplied() {
+	if this.specChangedAt.IsZero() {
+		return
+	}
+	changedAt := this.specChangedAt
+	this.specChangedAt = time.Time{}
+	zoneid := this.ZoneId()
+	metrics.ReportEntryApplySeconds(zoneid, time.Since(changedAt))
+	this.state.propagation.Check(zoneid, this.DNSSetName(), this.Targets(), this.RoutingPolicy(), changedAt)
+}
+
 func (this *Entry) Before(e *Entry) bool {
 	if e == nil {
 		return true
@@ -934,6 +1135,9 @@ func (this *Entry) updateStatistic(statistic *statistic.EntryStatistic) {
 	defer this.lock.Unlock()
 	statistic.Owners.Inc(this.OwnerId(), this.ProviderType(), this.ProviderName())
 	statistic.Providers.Inc(this.ProviderType(), this.ProviderName())
+	if zoneid := this.ZoneId(); zoneid.ID != "" {
+		statistic.Zones.Inc(this.OwnerId(), zoneid.ID)
+	}
 }
 
 ////////////////////////////////////////////////////////////////////////////////
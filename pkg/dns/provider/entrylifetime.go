@@ -0,0 +1,68 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// entryExpiration returns the time the lifetime of the entry given by the optional spec field lifetimeSeconds expires.
+func entryExpiration(object dnsutils.DNSSpecification) *time.Time {
+	lifetime := object.GetLifetimeSeconds()
+	if lifetime == nil {
+		return nil
+	}
+	t := object.GetCreationTimestamp().Add(time.Duration(*lifetime) * time.Second)
+	return &t
+}
+
+func validateLifetime(object dnsutils.DNSSpecification) error {
+	if lifetime := object.GetLifetimeSeconds(); lifetime != nil && *lifetime <= 0 {
+		return fmt.Errorf("lifetimeSeconds must be greater than zero")
+	}
+	return nil
+}
+
+// checkEntryLifetime deletes an entry with expired lifetime. The deletion of its DNS records is done by the
+// regular deletion of the entry. Otherwise the entry is rescheduled for the expiration of its lifetime.
+func (this *state) checkEntryLifetime(logger logger.LogContext, object dnsutils.DNSSpecification, status reconcile.Status) reconcile.Status {
+	expiration := entryExpiration(object)
+	if expiration == nil || object.IsDeleting() || validateLifetime(object) != nil {
+		return status
+	}
+	if remaining := time.Until(*expiration); remaining > 0 {
+		if status.IsSucceeded() {
+			status = status.RescheduleAfter(remaining)
+		}
+		return status
+	}
+	msg := fmt.Sprintf("lifetime of %ds expired -> deleting entry", *object.GetLifetimeSeconds())
+	logger.Info(msg)
+	object.Event(corev1.EventTypeNormal, "expired", msg)
+	if err := object.Delete(); err != nil && !errors.IsNotFound(err) {
+		return reconcile.Delay(logger, err)
+	}
+	return reconcile.Succeeded(logger)
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// lifetimeTestSpec provides the attributes of an entry object relevant for its lifetime.
+type lifetimeTestSpec struct {
+	dnsutils.DNSSpecification
+	created  time.Time
+	lifetime *int64
+	deleted  bool
+}
+
+func (this *lifetimeTestSpec) GetCreationTimestamp() metav1.Time {
+	return metav1.NewTime(this.created)
+}
+
+func (this *lifetimeTestSpec) GetLifetimeSeconds() *int64 {
+	return this.lifetime
+}
+
+func (this *lifetimeTestSpec) IsDeleting() bool {
+	return false
+}
+
+func (this *lifetimeTestSpec) Event(eventtype, reason, message string) {
+}
+
+func (this *lifetimeTestSpec) Delete() error {
+	this.deleted = true
+	return nil
+}
+
+var _ = ginkgov2.Describe("Entry lifetime", func() {
+	int64ptr := func(v int64) *int64 { return &v }
+	s := &state{}
+
+	ginkgov2.It("ignores entries without lifetime", func() {
+		spec := &lifetimeTestSpec{created: time.Now().Add(-time.Hour)}
+		Ω(entryExpiration(spec)).Should(BeNil())
+		status := s.checkEntryLifetime(logger.New(), spec, reconcile.Succeeded(logger.New()))
+		Ω(status.Interval).Should(BeNumerically("<", 0))
+		Ω(spec.deleted).Should(BeFalse())
+	})
+
+	ginkgov2.It("reschedules entries until expiration", func() {
+		created := time.Now().Add(-time.Minute)
+		spec := &lifetimeTestSpec{created: created, lifetime: int64ptr(3600)}
+		Ω(*entryExpiration(spec)).Should(Equal(created.Add(time.Hour)))
+		status := s.checkEntryLifetime(logger.New(), spec, reconcile.Succeeded(logger.New()))
+		Ω(status.Interval).Should(BeNumerically("~", 59*time.Minute, time.Second))
+		Ω(spec.deleted).Should(BeFalse())
+	})
+
+	ginkgov2.It("deletes expired entries", func() {
+		spec := &lifetimeTestSpec{created: time.Now().Add(-2 * time.Hour), lifetime: int64ptr(3600)}
+		status := s.checkEntryLifetime(logger.New(), spec, reconcile.Succeeded(logger.New()))
+		Ω(status.IsSucceeded()).Should(BeTrue())
+		Ω(spec.deleted).Should(BeTrue())
+	})
+
+	ginkgov2.It("validates the lifetime", func() {
+		Ω(validateLifetime(&lifetimeTestSpec{lifetime: int64ptr(1)})).Should(Succeed())
+		Ω(validateLifetime(&lifetimeTestSpec{lifetime: int64ptr(0)})).ShouldNot(Succeed())
+	})
+})
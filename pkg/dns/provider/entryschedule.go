@@ -0,0 +1,74 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// entrySchedule is the activation period of an entry given by the optional spec fields activateAt and deactivateAt.
+type entrySchedule struct {
+	activateAt   *metav1.Time
+	deactivateAt *metav1.Time
+}
+
+func newEntrySchedule(object dnsutils.DNSSpecification) entrySchedule {
+	return entrySchedule{activateAt: object.GetActivateAt(), deactivateAt: object.GetDeactivateAt()}
+}
+
+func (this entrySchedule) Validate() error {
+	if this.activateAt != nil && this.deactivateAt != nil && !this.deactivateAt.After(this.activateAt.Time) {
+		return fmt.Errorf("deactivateAt (%s) must be after activateAt (%s)", formatScheduleTime(this.deactivateAt), formatScheduleTime(this.activateAt))
+	}
+	return nil
+}
+
+// Check returns whether the records of the entry are scheduled at the given time, a status message
+// if they are not, and the delay until the next scheduled change (0 if there is none).
+func (this entrySchedule) Check(now time.Time) (bool, string, time.Duration) {
+	if this.deactivateAt != nil && !now.Before(this.deactivateAt.Time) {
+		return false, fmt.Sprintf("deactivated at %s", formatScheduleTime(this.deactivateAt)), 0
+	}
+	if this.activateAt != nil && now.Before(this.activateAt.Time) {
+		return false, fmt.Sprintf("scheduled for activation at %s", formatScheduleTime(this.activateAt)), this.activateAt.Sub(now)
+	}
+	if this.deactivateAt != nil {
+		return true, "", this.deactivateAt.Sub(now)
+	}
+	return true, "", 0
+}
+
+func formatScheduleTime(t *metav1.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// unscheduledTargetSpec is the target spec of an entry out of schedule.
+// Its records are deleted regardless of the deletion policy.
+type unscheduledTargetSpec struct {
+	dnsutils.TargetSpec
+}
+
+func (this *unscheduledTargetSpec) DeletionPolicy() *api.DeletionPolicy {
+	policy := api.DeletionPolicyDelete
+	return &policy
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = ginkgov2.Describe("Entry schedule", func() {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(d))
+		return &t
+	}
+
+	ginkgov2.It("validates the period", func() {
+		Ω(entrySchedule{}.Validate()).Should(Succeed())
+		Ω(entrySchedule{activateAt: at(0), deactivateAt: at(time.Hour)}.Validate()).Should(Succeed())
+		Ω(entrySchedule{activateAt: at(time.Hour), deactivateAt: at(time.Hour)}.Validate()).ShouldNot(Succeed())
+	})
+
+	ginkgov2.It("is always scheduled without period", func() {
+		scheduled, _, delay := entrySchedule{}.Check(now)
+		Ω(scheduled).Should(BeTrue())
+		Ω(delay).Should(BeZero())
+	})
+
+	ginkgov2.It("waits for activation", func() {
+		scheduled, msg, delay := entrySchedule{activateAt: at(time.Hour)}.Check(now)
+		Ω(scheduled).Should(BeFalse())
+		Ω(msg).Should(Equal("scheduled for activation at 2022-06-01T13:00:00Z"))
+		Ω(delay).Should(Equal(time.Hour))
+
+		scheduled, _, delay = entrySchedule{activateAt: at(-time.Hour)}.Check(now)
+		Ω(scheduled).Should(BeTrue())
+		Ω(delay).Should(BeZero())
+	})
+
+	ginkgov2.It("waits for deactivation", func() {
+		schedule := entrySchedule{activateAt: at(-time.Hour), deactivateAt: at(30 * time.Minute)}
+		scheduled, _, delay := schedule.Check(now)
+		Ω(scheduled).Should(BeTrue())
+		Ω(delay).Should(Equal(30 * time.Minute))
+
+		scheduled, msg, delay := schedule.Check(now.Add(time.Hour))
+		Ω(scheduled).Should(BeFalse())
+		Ω(msg).Should(Equal("deactivated at 2022-06-01T12:30:00Z"))
+		Ω(delay).Should(BeZero())
+	})
+
+	ginkgov2.It("deletes records of unscheduled entries regardless of deletion policy", func() {
+		Ω(*(&unscheduledTargetSpec{}).DeletionPolicy()).Should(Equal(api.DeletionPolicyDelete))
+	})
+})
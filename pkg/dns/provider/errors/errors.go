@@ -18,6 +18,7 @@ package errors
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/resources"
@@ -43,6 +44,16 @@ func (e *AlreadyBusyForOwner) Error() string {
 	return fmt.Sprintf("DNS name %q already busy for owner %q", e.Name, e.Owner)
 }
 
+type OwnerConflict struct {
+	Name    dns.DNSSetName
+	Owner   string
+	Changes int
+}
+
+func (e *OwnerConflict) Error() string {
+	return fmt.Sprintf("DNS name %q repeatedly changed by owner %q (%d times), stopped updating to avoid fighting with another controller", e.Name, e.Owner, e.Changes)
+}
+
 type NoSuchHostedZone struct {
 	ZoneId string
 	Err    error
@@ -68,3 +79,34 @@ func IsThrottlingError(err error) bool {
 	_, ok := err.(*ThrottlingError)
 	return ok
 }
+
+// throttlingMessages are (lower case) parts of error messages of the provider APIs and SDKs
+// indicating that a request has been throttled.
+var throttlingMessages = []string{
+	"throttl",
+	"too many requests",
+	"rate exceeded",
+	"rate limit exceeded",
+	"ratelimitexceeded",
+	"requestlimitexceeded",
+	"statuscode=429",
+	"status 429",
+	"error 429",
+}
+
+// IsThrottlingResponse checks if the error of a provider request indicates that the provider throttled the request.
+func IsThrottlingResponse(err error) bool {
+	if err == nil {
+		return false
+	}
+	if IsThrottlingError(err) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, part := range throttlingMessages {
+		if strings.Contains(msg, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"crypto/aes"
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+const (
+	EXTERNAL_DNS_REGISTRY_READ = "read"
+	EXTERNAL_DNS_REGISTRY_SYNC = "sync"
+)
+
+func createExternalDNSRegistry(c controller.Interface) (*dns.ExternalDNSRegistry, error) {
+	mode, err := c.GetStringOption(OPT_EXTERNAL_DNS_REGISTRY)
+	if err != nil || mode == "" {
+		return nil, nil
+	}
+	if mode != EXTERNAL_DNS_REGISTRY_READ && mode != EXTERNAL_DNS_REGISTRY_SYNC {
+		return nil, fmt.Errorf("invalid external-dns registry mode %q (expected %s or %s)", mode,
+			EXTERNAL_DNS_REGISTRY_READ, EXTERNAL_DNS_REGISTRY_SYNC)
+	}
+	registry := &dns.ExternalDNSRegistry{Write: mode == EXTERNAL_DNS_REGISTRY_SYNC}
+	registry.OwnerId, _ = c.GetStringOption(OPT_EXTERNAL_DNS_OWNER_ID)
+	registry.Prefix, _ = c.GetStringOption(OPT_EXTERNAL_DNS_TXT_PREFIX)
+	if key, _ := c.GetStringOption(OPT_EXTERNAL_DNS_TXT_AES_KEY); key != "" {
+		if _, err := aes.NewCipher([]byte(key)); err != nil {
+			return nil, fmt.Errorf("invalid external-dns registry AES key: %w", err)
+		}
+		registry.AESKey = []byte(key)
+	}
+	if registry.OwnerId == "" {
+		return nil, fmt.Errorf("external-dns registry mode %q requires option %s", mode, OPT_EXTERNAL_DNS_OWNER_ID)
+	}
+	return registry, nil
+}
+
+// isExternalDNSForeign checks whether a record set without own ownership record
+// is owned by another instance of kubernetes-sigs/external-dns.
+func (this *ChangeModel) isExternalDNSForeign(set *dns.DNSSet) bool {
+	r := this.config.ExternalDNSRegistry
+	return r != nil && set.GetOwner() == "" && set.ExternalDNSOwner != "" && set.ExternalDNSOwner != r.OwnerId
+}
+
+// foreignOwner returns the owner of a foreign record set.
+func foreignOwner(set *dns.DNSSet) string {
+	if owner := set.GetOwner(); owner != "" {
+		return owner
+	}
+	return dns.ExternalDNSHeritage + "/" + set.ExternalDNSOwner
+}
+
+// updateExternalDNSRegistry maintains the TXT registry records of kubernetes-sigs/external-dns
+// for a record set. In sync mode, registry records are created for all record types of the
+// new set. All other registry records of the old set are deleted, this drops the ownership
+// of external-dns for adopted record sets.
+func (this *ChangeModel) updateExternalDNSRegistry(apply bool, view *ChangeGroup, oldset, newset *dns.DNSSet, done DoneHandler) bool {
+	r := this.config.ExternalDNSRegistry
+	if r == nil {
+		return false
+	}
+	mod := false
+	obsolete := map[dns.DNSSetName]*dns.DNSSet{}
+	if oldset != nil {
+		for _, reg := range oldset.ExternalDNSRegistry {
+			obsolete[reg.Name] = reg
+		}
+	}
+	if r.Write {
+		for ty, rs := range newset.Sets {
+			if ty == dns.RS_META {
+				continue
+			}
+			name := newset.Name.WithDNSName(r.RecordName(newset.Name.DNSName, ty))
+			if _, ok := obsolete[name]; ok {
+				delete(obsolete, name)
+				continue
+			}
+			mod = true
+			if apply {
+				reg, err := r.NewRecordSet(newset, ty, rs.TTL)
+				if err != nil {
+					this.Warnf("cannot create external-dns registry record for %s: %s", newset.Name, err)
+					continue
+				}
+				view.addCreateRequest(reg, dns.RS_TXT, done)
+			}
+		}
+	}
+	for _, reg := range obsolete {
+		mod = true
+		if apply {
+			view.addDeleteRequest(reg, dns.RS_TXT, done)
+		}
+	}
+	return mod
+}
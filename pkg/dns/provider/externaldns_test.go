@@ -0,0 +1,73 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("External-dns registry", func() {
+	name := dns.DNSSetName{DNSName: "www.example.com"}
+
+	newModel := func(write bool) *ChangeModel {
+		registry := &dns.ExternalDNSRegistry{OwnerId: "o1", Write: write}
+		return NewChangeModel(logger.New(), nil, &zoneReconciliation{}, Config{ExternalDNSRegistry: registry})
+	}
+	newSet := func(owner string) *dns.DNSSet {
+		set := dns.NewDNSSet(name, nil)
+		set.SetRecordSet(dns.RS_A, 300, "1.2.3.4")
+		if owner != "" {
+			set.ExternalDNSOwner = owner
+			reg := dns.NewDNSSet(name.WithDNSName("a-www.example.com"), nil)
+			reg.SetRecordSet(dns.RS_TXT, 300, "\"heritage=external-dns,external-dns/owner="+owner+"\"")
+			set.ExternalDNSRegistry = []*dns.DNSSet{reg}
+		}
+		return set
+	}
+
+	ginkgov2.It("treats record sets of other external-dns owners as foreign", func() {
+		model := newModel(false)
+		Ω(model.IsForeign(newSet("o2"))).Should(BeTrue())
+		Ω(model.IsForeign(newSet("o1"))).Should(BeFalse())
+		Ω(foreignOwner(newSet("o2"))).Should(Equal("external-dns/o2"))
+	})
+
+	ginkgov2.It("drops the registry records of adopted record sets", func() {
+		model := newModel(false)
+		view := newChangeGroup("test", nil, model)
+		Ω(model.updateExternalDNSRegistry(true, view, newSet("o1"), newSet(""), nil)).Should(BeTrue())
+		Ω(view.requests).Should(HaveLen(1))
+		Ω(view.requests[0].Action).Should(Equal(R_DELETE))
+		Ω(view.requests[0].Deletion.Name.DNSName).Should(Equal("a-www.example.com"))
+	})
+
+	ginkgov2.It("maintains registry records in sync mode", func() {
+		model := newModel(true)
+		view := newChangeGroup("test", nil, model)
+		Ω(model.updateExternalDNSRegistry(true, view, newSet("o1"), newSet(""), nil)).Should(BeFalse())
+		Ω(view.requests).Should(BeEmpty())
+
+		Ω(model.updateExternalDNSRegistry(true, view, nil, newSet(""), nil)).Should(BeTrue())
+		Ω(view.requests).Should(HaveLen(1))
+		Ω(view.requests[0].Action).Should(Equal(R_CREATE))
+		Ω(view.requests[0].Addition.Name.DNSName).Should(Equal("a-www.example.com"))
+	})
+})
@@ -34,6 +34,7 @@ type Factory struct {
 	optionCreator         extension.OptionSourceCreator
 	genericDefaults       *GenericFactoryOptions
 	supportZoneStateCache bool
+	secretValidator       SecretValidator
 }
 
 var _ DNSHandlerFactory = &Factory{}
@@ -65,6 +66,12 @@ func (this *Factory) SetGenericFactoryOptionDefaults(defaults ...GenericFactoryO
 	return this
 }
 
+// SetSecretValidator sets the validator checking the shape of provider secrets without accessing the DNS system.
+func (this *Factory) SetSecretValidator(validator SecretValidator) *Factory {
+	this.secretValidator = validator
+	return this
+}
+
 func (this *Factory) SetOptionSourceByExample(proto config.OptionSource, defaults ...GenericFactoryOptions) *Factory {
 	this.optionCreator = controller.OptionSourceCreator(proto)
 	return this.SetGenericFactoryOptionDefaults(defaults...)
@@ -109,6 +116,16 @@ func (this *Factory) SupportZoneStateCache(typecode string) (bool, error) {
 	return false, fmt.Errorf("not responsible for %q", typecode)
 }
 
+func (this *Factory) ValidateSecret(typecode string, props utils.Properties) error {
+	if typecode != this.typecode {
+		return fmt.Errorf("not responsible for %q", typecode)
+	}
+	if this.secretValidator == nil {
+		return nil
+	}
+	return this.secretValidator(props)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 type CompoundFactory struct {
@@ -207,3 +224,14 @@ func (this *CompoundFactory) SupportZoneStateCache(typecode string) (bool, error
 	}
 	return false, fmt.Errorf("not responsible for %q", typecode)
 }
+
+func (this *CompoundFactory) ValidateSecret(typecode string, props utils.Properties) error {
+	f := this.factories[typecode]
+	if f == nil {
+		return fmt.Errorf("not responsible for %q", typecode)
+	}
+	if v, ok := f.(secretValidatingFactory); ok {
+		return v.ValidateSecret(typecode, props)
+	}
+	return nil
+}
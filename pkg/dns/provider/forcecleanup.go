@@ -0,0 +1,133 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// FORCE_CLEANUP_TIMEOUT is the maximum duration for verifying that no records of an entry are reachable anymore.
+const FORCE_CLEANUP_TIMEOUT = 30 * time.Second
+
+// recordQuery returns the values of the records of the given type resolved for a DNS name.
+type recordQuery func(ctx context.Context, dnsname, rtype string) ([]string, error)
+
+// forceCleanupRequested returns true if the removal of the finalizer is forced for a deleted entry.
+func forceCleanupRequested(object resources.Object) bool {
+	return object.IsDeleting() && object.GroupKind().Kind == api.DNSEntryKind &&
+		object.GetAnnotations()[dns.FORCE_CLEANUP_ANNOTATION] == "true"
+}
+
+// expectedRecords returns the values per record type of an entry possibly still served by the DNS system.
+// The record sets applied at the provider are preferred over the spec.
+func expectedRecords(object dnsutils.DNSSpecification) map[string][]string {
+	records := map[string][]string{}
+	if entry, ok := object.Data().(*api.DNSEntry); ok && entry.Status.ProviderRecords != nil {
+		for _, rs := range entry.Status.ProviderRecords.RecordSets {
+			records[rs.Type] = append(records[rs.Type], rs.Values...)
+		}
+		return records
+	}
+	for _, t := range object.GetTargets() {
+		if ip := net.ParseIP(t); ip != nil {
+			if ip.To4() != nil {
+				records[dns.RS_A] = append(records[dns.RS_A], ip.String())
+			} else {
+				records[dns.RS_AAAA] = append(records[dns.RS_AAAA], ip.String())
+			}
+		} else {
+			records[dns.RS_CNAME] = append(records[dns.RS_CNAME], t)
+		}
+	}
+	for _, t := range object.GetText() {
+		records[dns.RS_TXT] = append(records[dns.RS_TXT], fmt.Sprintf("%q", t))
+	}
+	return records
+}
+
+// findReachableRecords returns the expected records still resolvable for a DNS name.
+func findReachableRecords(ctx context.Context, dnsname string, expected map[string][]string, query recordQuery) ([]string, error) {
+	var reachable []string
+	for rtype, values := range expected {
+		found, err := query(ctx, dnsname, rtype)
+		if err != nil {
+			return nil, fmt.Errorf("cannot query %s records of %s: %w", rtype, dnsname, err)
+		}
+		for _, v := range values {
+			for _, f := range found {
+				if strings.TrimSuffix(f, ".") == strings.TrimSuffix(v, ".") {
+					reachable = append(reachable, fmt.Sprintf("%s %s", rtype, v))
+					break
+				}
+			}
+		}
+	}
+	sort.Strings(reachable)
+	return reachable, nil
+}
+
+// recordQuery returns the query used to verify a forced cleanup. The resolvers of the propagation check are
+// preferred over the resolvers of the DNS lookup configuration.
+func (this *state) recordQuery() recordQuery {
+	resolvers := this.config.PropagationResolvers
+	if len(resolvers) == 0 {
+		resolvers = this.config.DNSLookup.resolvers()
+	}
+	return func(ctx context.Context, dnsname, rtype string) ([]string, error) {
+		var values []string
+		err := this.config.DNSLookup.lookup(ctx, resolvers, func(ctx context.Context, resolver *net.Resolver) error {
+			var err error
+			values, err = queryRecords(ctx, resolver, dnsname, rtype)
+			return err
+		})
+		return values, err
+	}
+}
+
+// forceCleanup removes the finalizer of a deleted entry whose provider or hosted zone is permanently gone,
+// after verifying that none of its records are reachable anymore.
+func (this *state) forceCleanup(logger logger.LogContext, object dnsutils.DNSSpecification) reconcile.Status {
+	ctx, cancel := context.WithTimeout(this.context.GetContext(), FORCE_CLEANUP_TIMEOUT)
+	defer cancel()
+	reachable, err := findReachableRecords(ctx, object.GetDNSName(), expectedRecords(object), this.recordQuery())
+	if err != nil {
+		return reconcile.Delay(logger, fmt.Errorf("cannot verify records for force cleanup: %w", err))
+	}
+	if len(reachable) > 0 {
+		msg := fmt.Sprintf("force cleanup refused, records still reachable: %s", strings.Join(reachable, ", "))
+		logger.Warn(msg)
+		object.Event(corev1.EventTypeWarning, "forcecleanup", msg)
+		return reconcile.RescheduleAfter(logger, time.Minute)
+	}
+	logger.Infof("force cleanup: removing finalizer (no records of %s reachable)", object.GetDNSName())
+	object.Eventf(corev1.EventTypeNormal, "forcecleanup", "finalizer removed, no records of %s reachable", object.GetDNSName())
+	return reconcile.DelayOnError(logger, this.RemoveFinalizer(object))
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Force cleanup", func() {
+	resolved := map[string][]string{
+		dns.RS_A:     {"1.2.3.4"},
+		dns.RS_CNAME: {"other.example.com."},
+	}
+	query := func(_ context.Context, dnsname, rtype string) ([]string, error) {
+		if dnsname != "a.example.com" {
+			return nil, fmt.Errorf("unexpected name %s", dnsname)
+		}
+		return resolved[rtype], nil
+	}
+
+	ginkgov2.It("finds reachable records of the entry", func() {
+		reachable, err := findReachableRecords(context.TODO(), "a.example.com", map[string][]string{
+			dns.RS_A:     {"1.2.3.4", "5.6.7.8"},
+			dns.RS_CNAME: {"other.example.com"},
+			dns.RS_TXT:   {"\"foo\""},
+		}, query)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(reachable).Should(Equal([]string{"A 1.2.3.4", "CNAME other.example.com"}))
+	})
+
+	ginkgov2.It("ignores foreign records", func() {
+		reachable, err := findReachableRecords(context.TODO(), "a.example.com", map[string][]string{
+			dns.RS_A: {"5.6.7.8"},
+		}, query)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(reachable).Should(BeEmpty())
+	})
+
+	ginkgov2.It("fails if records cannot be verified", func() {
+		_, err := findReachableRecords(context.TODO(), "b.example.com", map[string][]string{
+			dns.RS_A: {"1.2.3.4"},
+		}, query)
+		Ω(err).Should(HaveOccurred())
+	})
+})
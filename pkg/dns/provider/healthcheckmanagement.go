@@ -0,0 +1,48 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+// HealthCheckSpec describes a health check to be created or updated by a provider.
+type HealthCheckSpec struct {
+	// Type is the protocol of the health check (HTTP, HTTPS or TCP)
+	Type      string
+	FQDN      string
+	IPAddress string
+	Port      int
+	// ResourcePath is the path requested by HTTP and HTTPS health checks
+	ResourcePath string
+	// SearchString is the string expected in the response body of HTTP and HTTPS health checks
+	SearchString     string
+	RequestInterval  int
+	FailureThreshold int
+	// Reference is a unique identifier of the requesting object, it is used
+	// to make the creation idempotent if supported by the provider
+	Reference string
+}
+
+// DNSHealthCheckManagement is an optional interface of a DNSHandler supporting
+// the creation and deletion of health checks, which can be referenced by
+// routing policies with the parameter healthCheckID.
+type DNSHealthCheckManagement interface {
+	CreateHealthCheck(logger logger.LogContext, spec *HealthCheckSpec) (string, error)
+	UpdateHealthCheck(logger logger.LogContext, id string, spec *HealthCheckSpec) error
+	DeleteHealthCheck(logger logger.LogContext, id string) error
+}
@@ -0,0 +1,259 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+)
+
+const (
+	// minHostLookupTTL is the minimum caching time of host lookups.
+	minHostLookupTTL = 5 * time.Second
+	// negativeHostLookupTTL is the caching time of lookups without answer if the SOA record is missing.
+	negativeHostLookupTTL = 30 * time.Second
+)
+
+func createHostResolver(c controller.Interface, lookup *DNSLookupConfig) (*hostResolver, error) {
+	maxDepth, _ := c.GetIntOption(OPT_CNAME_CHAIN_MAX_DEPTH)
+	if maxDepth <= 0 {
+		return &hostResolver{config: lookup}, nil
+	}
+	value, _ := c.GetStringOption(OPT_CNAME_LOOKUP_RESOLVERS)
+	servers, err := lookup.parseResolvers(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid option %s: %w", OPT_CNAME_LOOKUP_RESOLVERS, err)
+	}
+	if len(servers) == 0 {
+		servers = lookup.resolvers()
+	}
+	if len(servers) == 0 {
+		servers, err = readResolvConf("/etc/resolv.conf")
+		if err != nil {
+			return nil, fmt.Errorf("cannot read resolvers for CNAME lookups: %w", err)
+		}
+	}
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("no resolvers for CNAME lookups")
+	}
+	return newHostResolver(lookup, servers, maxDepth), nil
+}
+
+// readResolvConf returns the name servers of a resolv.conf file as host:port.
+func readResolvConf(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var servers []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" && net.ParseIP(fields[1]) != nil {
+			servers = append(servers, net.JoinHostPort(fields[1], "53"))
+		}
+	}
+	return servers, scanner.Err()
+}
+
+// hostResolver resolves target hostnames to IP addresses by following CNAME chains hop by hop
+// with a depth limit and loop detection. The results of all lookups, including the intermediate
+// hops of a chain, are cached for the TTL of their records.
+// Without maximum depth, the hostnames are resolved with a single lookup.
+// A nil resolver falls back to a single lookup with the host resolver.
+type hostResolver struct {
+	lock     sync.Mutex
+	config   *DNSLookupConfig
+	servers  []string
+	maxDepth int
+	cache    map[hostLookupKey]*hostLookup
+	now      func() time.Time
+	query    func(server, name string, qtype uint16) (*dnsResponse, error)
+}
+
+type hostLookupKey struct {
+	name  string
+	qtype uint16
+}
+
+// hostLookup is the cached answer for a name: either a CNAME or the addresses of the queried type.
+type hostLookup struct {
+	cname   string
+	addrs   []string
+	expires time.Time
+}
+
+func newHostResolver(lookup *DNSLookupConfig, servers []string, maxDepth int) *hostResolver {
+	return &hostResolver{
+		config:   lookup,
+		servers:  servers,
+		maxDepth: maxDepth,
+		cache:    map[hostLookupKey]*hostLookup{},
+		now:      time.Now,
+		query: func(server, name string, qtype uint16) (*dnsResponse, error) {
+			return exchangeDNSQuery(lookup, server, name, qtype)
+		},
+	}
+}
+
+// LookupHosts returns the IPv4 and IPv6 addresses of the given hostname.
+func (this *hostResolver) LookupHosts(hostname string) ([]string, []string, error) {
+	if this == nil {
+		return lookupHosts(nil, hostname)
+	}
+	if this.maxDepth <= 0 {
+		return lookupHosts(this.config, hostname)
+	}
+	ipv4addrs, err := this.resolve(hostname, dnsTypeA)
+	if err != nil {
+		return nil, nil, err
+	}
+	ipv6addrs, err := this.resolve(hostname, dnsTypeAAAA)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ipv4addrs) == 0 && len(ipv6addrs) == 0 {
+		return nil, nil, fmt.Errorf("%s has no IPv4/IPv6 address", hostname)
+	}
+	return ipv4addrs, ipv6addrs, nil
+}
+
+// resolve follows the CNAME chain of the hostname and returns the addresses of the given type.
+func (this *hostResolver) resolve(hostname string, qtype uint16) ([]string, error) {
+	name := strings.ToLower(strings.TrimSuffix(hostname, ".")) + "."
+	chain := []string{}
+	visited := map[string]bool{}
+	for {
+		if visited[name] {
+			return nil, fmt.Errorf("CNAME loop detected for %s: %s -> %s", hostname, strings.Join(chain, " -> "), name)
+		}
+		if len(chain) > this.maxDepth {
+			return nil, fmt.Errorf("CNAME chain of %s exceeds maximum depth %d", hostname, this.maxDepth)
+		}
+		visited[name] = true
+		chain = append(chain, name)
+		lookup, err := this.lookup(name, qtype)
+		if err != nil {
+			return nil, err
+		}
+		if lookup.cname == "" {
+			return lookup.addrs, nil
+		}
+		name = lookup.cname
+	}
+}
+
+func (this *hostResolver) lookup(name string, qtype uint16) (*hostLookup, error) {
+	key := hostLookupKey{name: name, qtype: qtype}
+	this.lock.Lock()
+	cached := this.cache[key]
+	this.lock.Unlock()
+	if cached != nil && this.now().Before(cached.expires) {
+		return cached, nil
+	}
+	resp, err := this.ask(name, qtype)
+	if err != nil {
+		return nil, err
+	}
+	return this.store(name, qtype, resp), nil
+}
+
+// ask queries the resolvers in the given order until one of them answers.
+// If all resolvers fail, the query is retried according to the lookup configuration.
+func (this *hostResolver) ask(name string, qtype uint16) (*dnsResponse, error) {
+	var err error
+	for attempt := 0; attempt <= this.config.retries(); attempt++ {
+		for _, server := range this.servers {
+			var resp *dnsResponse
+			resp, err = this.query(server, name, qtype)
+			if err == nil {
+				if resp.rcode == dnsRcodeSuccess || resp.rcode == dnsRcodeNameError {
+					return resp, nil
+				}
+				err = fmt.Errorf("lookup of %s at %s failed with rcode %s", name, server, rcodeName(resp.rcode))
+			}
+		}
+	}
+	return nil, err
+}
+
+// store caches the answer of a response for every owner name of the answer section
+// and returns the lookup of the queried name.
+func (this *hostResolver) store(name string, qtype uint16, resp *dnsResponse) *hostLookup {
+	now := this.now()
+	lookups := map[string]*hostLookup{}
+	ttls := map[string]uint32{}
+	for _, r := range resp.answer {
+		l := lookups[r.name]
+		if l == nil {
+			l = &hostLookup{}
+			lookups[r.name] = l
+			ttls[r.name] = r.ttl
+		} else if r.ttl < ttls[r.name] {
+			ttls[r.name] = r.ttl
+		}
+		switch r.rtype {
+		case dnsTypeCNAME:
+			l.cname = r.value
+		case qtype:
+			l.addrs = append(l.addrs, r.value)
+		}
+	}
+	if lookups[name] == nil {
+		ttl := negativeHostLookupTTL
+		if resp.negativeTTL > 0 {
+			ttl = time.Duration(resp.negativeTTL) * time.Second
+		}
+		lookups[name] = &hostLookup{}
+		ttls[name] = uint32(ttl / time.Second)
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for key, l := range this.cache {
+		if !now.Before(l.expires) {
+			delete(this.cache, key)
+		}
+	}
+	for owner, l := range lookups {
+		ttl := time.Duration(ttls[owner]) * time.Second
+		if ttl < minHostLookupTTL {
+			ttl = minHostLookupTTL
+		}
+		if l.cname != "" {
+			l.addrs = nil
+		}
+		l.expires = now.Add(ttl)
+		this.cache[hostLookupKey{name: owner, qtype: qtype}] = l
+	}
+	return lookups[name]
+}
+
+func rcodeName(rcode int) string {
+	if name, ok := dnsRcodeNames[rcode]; ok {
+		return name
+	}
+	return fmt.Sprintf("%d", rcode)
+}
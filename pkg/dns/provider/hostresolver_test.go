@@ -0,0 +1,244 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func appendTestDNSName(msg []byte, name string) []byte {
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	return append(msg, 0)
+}
+
+// packTestDNSResponse creates a response without name compression.
+func packTestDNSResponse(query []byte, rcode int, answer []dnsRecord) []byte {
+	msg := append([]byte{}, query[:12]...)
+	binary.BigEndian.PutUint16(msg[2:], 0x8180|uint16(rcode))
+	binary.BigEndian.PutUint16(msg[6:], uint16(len(answer)))
+	binary.BigEndian.PutUint16(msg[10:], 0)
+	name, off, _ := readDNSName(query, 12)
+	msg = appendTestDNSName(msg, name)
+	msg = append(msg, query[off:off+4]...)
+	for _, r := range answer {
+		msg = appendTestDNSName(msg, r.name)
+		msg = appendUint16(msg, r.rtype)
+		msg = appendUint16(msg, dnsClassINET)
+		msg = append(msg, byte(r.ttl>>24), byte(r.ttl>>16), byte(r.ttl>>8), byte(r.ttl))
+		var rdata []byte
+		if r.rtype == dnsTypeCNAME {
+			rdata = appendTestDNSName(nil, r.value)
+		} else if ip := net.ParseIP(r.value); ip.To4() != nil {
+			rdata = ip.To4()
+		} else {
+			rdata = ip.To16()
+		}
+		msg = appendUint16(msg, uint16(len(rdata)))
+		msg = append(msg, rdata...)
+	}
+	return msg
+}
+
+var _ = ginkgov2.Describe("Host resolver", func() {
+	var (
+		now      time.Time
+		queries  []string
+		zone     map[string][]dnsRecord
+		resolver *hostResolver
+	)
+
+	// answer simulates a recursive resolver returning the complete CNAME chain
+	answer := func(server, name string, qtype uint16) (*dnsResponse, error) {
+		queries = append(queries, fmt.Sprintf("%s/%d", name, qtype))
+		resp := &dnsResponse{rcode: dnsRcodeSuccess}
+		for i := 0; i < 20; i++ {
+			records := zone[name]
+			if len(records) == 0 {
+				if i == 0 {
+					resp.rcode = dnsRcodeNameError
+				}
+				break
+			}
+			next := ""
+			for _, r := range records {
+				if r.rtype == dnsTypeCNAME {
+					resp.answer = append(resp.answer, r)
+					next = r.value
+				} else if r.rtype == qtype {
+					resp.answer = append(resp.answer, r)
+				}
+			}
+			if next == "" {
+				break
+			}
+			name = next
+		}
+		return resp, nil
+	}
+
+	ginkgov2.BeforeEach(func() {
+		now = time.Now()
+		queries = nil
+		zone = map[string][]dnsRecord{
+			"www.example.com.":   {{name: "www.example.com.", rtype: dnsTypeCNAME, ttl: 300, value: "lb.example.com."}},
+			"lb.example.com.":    {{name: "lb.example.com.", rtype: dnsTypeCNAME, ttl: 60, value: "lb.example.net."}},
+			"lb.example.net.":    {{name: "lb.example.net.", rtype: dnsTypeA, ttl: 30, value: "1.2.3.4"}, {name: "lb.example.net.", rtype: dnsTypeAAAA, ttl: 30, value: "2001:db8::1"}},
+			"loop1.example.com.": {{name: "loop1.example.com.", rtype: dnsTypeCNAME, ttl: 300, value: "loop2.example.com."}},
+			"loop2.example.com.": {{name: "loop2.example.com.", rtype: dnsTypeCNAME, ttl: 300, value: "loop1.example.com."}},
+		}
+		resolver = newHostResolver(nil, []string{"10.0.0.1:53"}, 10)
+		resolver.now = func() time.Time { return now }
+		resolver.query = answer
+	})
+
+	ginkgov2.It("follows CNAME chains and caches intermediate lookups", func() {
+		ipv4addrs, ipv6addrs, err := resolver.LookupHosts("WWW.example.com")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(ipv4addrs).Should(ConsistOf("1.2.3.4"))
+		Ω(ipv6addrs).Should(ConsistOf("2001:db8::1"))
+		Ω(queries).Should(ConsistOf("www.example.com./1", "www.example.com./28"))
+
+		_, _, err = resolver.LookupHosts("lb.example.com")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(queries).Should(HaveLen(2))
+	})
+
+	ginkgov2.It("refreshes lookups after their TTL", func() {
+		_, _, err := resolver.LookupHosts("www.example.com")
+		Ω(err).ShouldNot(HaveOccurred())
+		zone["lb.example.net."] = []dnsRecord{{name: "lb.example.net.", rtype: dnsTypeA, ttl: 30, value: "1.2.3.5"}}
+
+		now = now.Add(20 * time.Second)
+		ipv4addrs, _, err := resolver.LookupHosts("www.example.com")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(ipv4addrs).Should(ConsistOf("1.2.3.4"))
+		Ω(queries).Should(HaveLen(2))
+
+		now = now.Add(20 * time.Second)
+		ipv4addrs, _, err = resolver.LookupHosts("www.example.com")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(ipv4addrs).Should(ConsistOf("1.2.3.5"))
+		Ω(queries).Should(ConsistOf("www.example.com./1", "www.example.com./28", "lb.example.net./1", "lb.example.net./28"))
+	})
+
+	ginkgov2.It("detects CNAME loops", func() {
+		_, _, err := resolver.LookupHosts("loop1.example.com")
+		Ω(err).Should(MatchError("CNAME loop detected for loop1.example.com: loop1.example.com. -> loop2.example.com. -> loop1.example.com."))
+	})
+
+	ginkgov2.It("limits the depth of CNAME chains", func() {
+		resolver.maxDepth = 1
+		_, _, err := resolver.LookupHosts("www.example.com")
+		Ω(err).Should(MatchError("CNAME chain of www.example.com exceeds maximum depth 1"))
+	})
+
+	ginkgov2.It("caches missing names", func() {
+		_, _, err := resolver.LookupHosts("missing.example.com")
+		Ω(err).Should(MatchError("missing.example.com has no IPv4/IPv6 address"))
+		_, _, err = resolver.LookupHosts("missing.example.com")
+		Ω(err).Should(HaveOccurred())
+		Ω(queries).Should(HaveLen(2))
+	})
+
+	ginkgov2.It("tries the next resolver on failures", func() {
+		resolver.servers = []string{"10.0.0.1:53", "10.0.0.2:53"}
+		resolver.query = func(server, name string, qtype uint16) (*dnsResponse, error) {
+			if server == "10.0.0.1:53" {
+				return &dnsResponse{rcode: 2}, nil
+			}
+			return answer(server, name, qtype)
+		}
+		ipv4addrs, _, err := resolver.LookupHosts("lb.example.net")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(ipv4addrs).Should(ConsistOf("1.2.3.4"))
+
+		resolver.servers = []string{"10.0.0.1:53"}
+		_, _, err = resolver.LookupHosts("www.example.com")
+		Ω(err).Should(MatchError("lookup of www.example.com. at 10.0.0.1:53 failed with rcode SERVFAIL"))
+	})
+})
+
+var _ = ginkgov2.Describe("DNS query", func() {
+	ginkgov2.It("packs queries and unpacks responses", func() {
+		query, err := packDNSQuery(42, "www.example.com", dnsTypeA)
+		Ω(err).ShouldNot(HaveOccurred())
+		name, _, err := readDNSName(query, 12)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(name).Should(Equal("www.example.com."))
+
+		msg := packTestDNSResponse(query, dnsRcodeSuccess, []dnsRecord{
+			{name: "www.example.com.", rtype: dnsTypeCNAME, ttl: 300, value: "lb.example.net."},
+			{name: "lb.example.net.", rtype: dnsTypeA, ttl: 30, value: "1.2.3.4"},
+		})
+		resp, truncated, err := unpackDNSResponse(msg, 42)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(truncated).Should(BeFalse())
+		Ω(resp.answer).Should(Equal([]dnsRecord{
+			{name: "www.example.com.", rtype: dnsTypeCNAME, ttl: 300, value: "lb.example.net."},
+			{name: "lb.example.net.", rtype: dnsTypeA, ttl: 30, value: "1.2.3.4"},
+		}))
+
+		_, _, err = unpackDNSResponse(msg, 43)
+		Ω(err).Should(MatchError("DNS response id mismatch"))
+		_, _, err = unpackDNSResponse(msg[:len(msg)-2], 42)
+		Ω(err).Should(MatchError("DNS response truncated"))
+	})
+
+	ginkgov2.It("reads compressed names", func() {
+		msg := []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+		msg = appendTestDNSName(msg, "example.com")
+		msg = append(msg, 3, 'w', 'w', 'w', 0xc0, 12)
+		name, end, err := readDNSName(msg, 25)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(name).Should(Equal("www.example.com."))
+		Ω(end).Should(Equal(len(msg)))
+
+		loop := append(msg, 0xc0, byte(len(msg)))
+		_, _, err = readDNSName(loop, len(msg))
+		Ω(err).Should(MatchError("too many compression pointers in DNS name"))
+	})
+
+	ginkgov2.It("exchanges queries with a server", func() {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		Ω(err).ShouldNot(HaveOccurred())
+		defer conn.Close()
+		go func() {
+			buf := make([]byte, 512)
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = conn.WriteTo(packTestDNSResponse(buf[:n], dnsRcodeSuccess, []dnsRecord{
+				{name: "www.example.com.", rtype: dnsTypeAAAA, ttl: 60, value: "2001:db8::1"},
+			}), addr)
+		}()
+
+		resp, err := exchangeDNSQuery(nil, conn.LocalAddr().String(), "www.example.com.", dnsTypeAAAA)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(resp.answer).Should(Equal([]dnsRecord{{name: "www.example.com.", rtype: dnsTypeAAAA, ttl: 60, value: "2001:db8::1"}}))
+	})
+})
@@ -0,0 +1,207 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+const importedEntryPrefix = "imported-"
+
+var invalidNameChars = regexp.MustCompile("[^a-z0-9.-]+")
+
+// ImportCandidates returns the record sets of the zone which are neither
+// managed by any DNS controller nor handled by any known DNSEntry, grouped
+// by the provider responsible for the dns name. Record sets owned by the
+// configured owner of kubernetes-sigs/external-dns are included.
+func (this *ChangeModel) ImportCandidates() map[DNSProvider][]*dns.DNSSet {
+	candidates := map[DNSProvider][]*dns.DNSSet{}
+	for _, view := range this.providergroups {
+		for name, set := range view.dnssets {
+			if _, ok := this.applied[name]; ok {
+				continue
+			}
+			if set.GetOwner() != "" || this.isExternalDNSForeign(set) || set.GetKind() != api.DNSEntryKind {
+				continue
+			}
+			if this.ExistsInEquivalentZone(name) || this.IsStale(ZonedDNSSetName{ZoneID: this.ZoneId(), DNSSetName: name}) != nil {
+				continue
+			}
+			p := this.context.providers.LookupFor(name.DNSName)
+			if p == nil {
+				continue
+			}
+			candidates[p] = append(candidates[p], set)
+		}
+	}
+	return candidates
+}
+
+// importRecords creates DNSEntry objects for unmanaged records of providers requesting a record import.
+func (this *state) importRecords(logger logger.LogContext, zoneid dns.ZoneID, changes *ChangeModel) {
+	for p, sets := range changes.ImportCandidates() {
+		spec := recordImportSpec(p, zoneid)
+		if spec == nil {
+			continue
+		}
+		resc, err := this.context.GetByExample(&api.DNSEntry{})
+		if err != nil {
+			logger.Warnf("record import for provider %s failed: %s", p.ObjectName(), err)
+			return
+		}
+		for _, set := range sets {
+			if !importable(spec, set) {
+				continue
+			}
+			entry, err := importedEntry(p.ObjectName().Namespace(), p.ObjectName().Name(), spec, set)
+			if err != nil {
+				logger.Warnf("cannot import record set %s: %s", set.Name, err)
+				continue
+			}
+			if class := p.Object().GetAnnotations()[dns.CLASS_ANNOTATION]; class != "" {
+				entry.Annotations[dns.CLASS_ANNOTATION] = class
+			}
+			_, err = resc.Create(entry)
+			if err != nil {
+				if !errors.IsAlreadyExists(err) {
+					logger.Warnf("cannot create entry %s/%s for record set %s: %s", entry.Namespace, entry.Name, set.Name, err)
+				}
+				continue
+			}
+			if set.ExternalDNSOwner != "" {
+				logger.Infof("migrated external-dns record set %s as entry %s/%s", set.Name, entry.Namespace, entry.Name)
+				p.Object().Eventf(corev1.EventTypeNormal, "migrate", "migrated external-dns record set %s as entry %s/%s", set.Name, entry.Namespace, entry.Name)
+				continue
+			}
+			logger.Infof("imported record set %s as entry %s/%s", set.Name, entry.Namespace, entry.Name)
+			p.Object().Eventf(corev1.EventTypeNormal, "import", "imported record set %s as entry %s/%s", set.Name, entry.Namespace, entry.Name)
+		}
+	}
+}
+
+// requestsRecordImport returns true if one of the providers requests the import of records of the given zone.
+func requestsRecordImport(providers DNSProviders, zoneid dns.ZoneID) bool {
+	for _, p := range providers {
+		if recordImportSpec(p, zoneid) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// recordImportSpec returns the record import settings of the provider, if
+// the import is requested for the given zone.
+func recordImportSpec(p DNSProvider, zoneid dns.ZoneID) *api.RecordImport {
+	data, ok := p.Object().Data().(*api.DNSProvider)
+	if !ok || data.Spec.RecordImport == nil {
+		return nil
+	}
+	spec := data.Spec.RecordImport
+	if len(spec.Zones) > 0 && !utils.NewStringSet(spec.Zones...).Contains(zoneid.ID) {
+		return nil
+	}
+	return spec
+}
+
+// importable checks whether a record set is imported with the given import settings.
+// Record sets owned by kubernetes-sigs/external-dns are only migrated on request.
+func importable(spec *api.RecordImport, set *dns.DNSSet) bool {
+	return set.ExternalDNSOwner == "" || spec.ExternalDNS != nil && *spec.ExternalDNS
+}
+
+// importedEntry generates the DNSEntry object for an unmanaged record set.
+func importedEntry(namespace, provider string, spec *api.RecordImport, set *dns.DNSSet) (*api.DNSEntry, error) {
+	entry := &api.DNSEntry{}
+	entry.Name = importedEntryName(set.Name)
+	entry.Namespace = namespace
+	if spec.Namespace != nil && *spec.Namespace != "" {
+		entry.Namespace = *spec.Namespace
+	}
+	entry.Annotations = map[string]string{AnnotationImportedFrom: namespace + "/" + provider}
+	entry.Spec.DNSName = set.Name.DNSName
+	entry.Spec.OwnerId = spec.OwnerId
+
+	types := []string{}
+	for ty := range set.Sets {
+		if ty != dns.RS_META {
+			types = append(types, ty)
+		}
+	}
+	sort.Strings(types)
+	var ttl int64
+	for _, ty := range types {
+		rs := set.Sets[ty]
+		switch ty {
+		case dns.RS_A, dns.RS_AAAA, dns.RS_CNAME, dns.RS_ALIAS:
+			for _, r := range rs.Records {
+				entry.Spec.Targets = append(entry.Spec.Targets, r.Value)
+			}
+		case dns.RS_TXT:
+			for _, r := range rs.Records {
+				text, err := strconv.Unquote(r.Value)
+				if err != nil {
+					text = r.Value
+				}
+				entry.Spec.Text = append(entry.Spec.Text, text)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported record type %s", ty)
+		}
+		if ttl == 0 || rs.TTL < ttl {
+			ttl = rs.TTL
+		}
+	}
+	if len(entry.Spec.Targets) == 0 && len(entry.Spec.Text) == 0 {
+		return nil, fmt.Errorf("no records")
+	}
+	if len(entry.Spec.Targets) > 0 && len(entry.Spec.Text) > 0 {
+		return nil, fmt.Errorf("mixed TXT and address records")
+	}
+	if ttl > 0 {
+		entry.Spec.TTL = &ttl
+	}
+	if set.RoutingPolicy != nil {
+		entry.Spec.RoutingPolicy = &api.RoutingPolicy{
+			Type:          set.RoutingPolicy.Type,
+			SetIdentifier: set.Name.SetIdentifier,
+			Parameters:    set.RoutingPolicy.Parameters,
+		}
+	}
+	return entry, nil
+}
+
+// importedEntryName derives a valid object name from the record set name.
+func importedEntryName(name dns.DNSSetName) string {
+	s := strings.ToLower(strings.ReplaceAll(name.DNSName, "*", "star"))
+	if name.SetIdentifier != "" {
+		s += "-" + strings.ToLower(name.SetIdentifier)
+	}
+	s = strings.Trim(invalidNameChars.ReplaceAllString(s, "-"), ".-")
+	return atMost(importedEntryPrefix+s, 253)
+}
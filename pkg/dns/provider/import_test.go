@@ -0,0 +1,85 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Record import", func() {
+	ownerId := "owner"
+	spec := &api.RecordImport{OwnerId: &ownerId}
+
+	ginkgov2.It("derives valid entry names", func() {
+		Ω(importedEntryName(dns.DNSSetName{DNSName: "*.Example.com"})).Should(Equal("imported-star.example.com"))
+		Ω(importedEntryName(dns.DNSSetName{DNSName: "a.example.com", SetIdentifier: "eu_1"})).Should(Equal("imported-a.example.com-eu-1"))
+	})
+
+	ginkgov2.It("imports address records", func() {
+		set := dns.NewDNSSet(dns.DNSSetName{DNSName: "a.example.com"}, nil)
+		set.SetRecordSet(dns.RS_A, 300, "1.2.3.4")
+		set.SetRecordSet(dns.RS_AAAA, 120, "::1")
+
+		entry, err := importedEntry("default", "aws", spec, set)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(entry.Namespace).Should(Equal("default"))
+		Ω(entry.Annotations).Should(HaveKeyWithValue(AnnotationImportedFrom, "default/aws"))
+		Ω(entry.Spec.DNSName).Should(Equal("a.example.com"))
+		Ω(entry.Spec.OwnerId).Should(Equal(&ownerId))
+		Ω(entry.Spec.Targets).Should(Equal([]string{"1.2.3.4", "::1"}))
+		Ω(*entry.Spec.TTL).Should(Equal(int64(120)))
+	})
+
+	ginkgov2.It("imports text records with routing policy", func() {
+		name := dns.DNSSetName{DNSName: "a.example.com", SetIdentifier: "id1"}
+		set := dns.NewDNSSet(name, dns.NewRoutingPolicy("weighted", "weight", "10"))
+		set.SetRecordSet(dns.RS_TXT, 300, "\"foo bar\"")
+
+		entry, err := importedEntry("default", "aws", spec, set)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(entry.Spec.Text).Should(Equal([]string{"foo bar"}))
+		Ω(entry.Spec.RoutingPolicy).Should(Equal(&api.RoutingPolicy{Type: "weighted", SetIdentifier: "id1", Parameters: map[string]string{"weight": "10"}}))
+	})
+
+	ginkgov2.It("migrates external-dns record sets only on request", func() {
+		set := dns.NewDNSSet(dns.DNSSetName{DNSName: "a.example.com"}, nil)
+		set.SetRecordSet(dns.RS_A, 300, "1.2.3.4")
+		Ω(importable(spec, set)).Should(BeTrue())
+
+		set.ExternalDNSOwner = "o1"
+		Ω(importable(spec, set)).Should(BeFalse())
+		migrate := true
+		Ω(importable(&api.RecordImport{ExternalDNS: &migrate}, set)).Should(BeTrue())
+	})
+
+	ginkgov2.It("rejects unsupported record sets", func() {
+		set := dns.NewDNSSet(dns.DNSSetName{DNSName: "a.example.com"}, nil)
+		set.SetRecordSet(dns.RS_NS, 300, "ns1.example.com")
+		_, err := importedEntry("default", "aws", spec, set)
+		Ω(err).Should(HaveOccurred())
+
+		set = dns.NewDNSSet(dns.DNSSetName{DNSName: "a.example.com"}, nil)
+		set.SetRecordSet(dns.RS_A, 300, "1.2.3.4")
+		set.SetRecordSet(dns.RS_TXT, 300, "\"foo\"")
+		_, err = importedEntry("default", "aws", spec, set)
+		Ω(err).Should(HaveOccurred())
+	})
+})
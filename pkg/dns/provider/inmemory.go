@@ -25,7 +25,7 @@ import (
 
 type zonedata struct {
 	zone    DNSHostedZone
-	dnssets dns.DNSSets
+	records *recordStore
 }
 
 type InMemory struct {
@@ -68,16 +68,27 @@ func (m *InMemory) CloneZoneState(zone DNSHostedZone) (DNSZoneState, error) {
 		return nil, fmt.Errorf("DNSZone %s not hosted", zone.Id())
 	}
 
-	dnssets := data.dnssets.Clone()
-	return NewDNSZoneState(dnssets), nil
+	return NewDNSZoneState(data.records.DNSSets()), nil
+}
+
+// GetDNSSets returns a copy of the DNS sets of a zone or nil if the zone is not hosted.
+func (m *InMemory) GetDNSSets(zoneID dns.ZoneID) dns.DNSSets {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	data, ok := m.zones[zoneID]
+	if !ok {
+		return nil
+	}
+	return data.records.DNSSets()
 }
 
 func (m *InMemory) SetZone(zone DNSHostedZone, zoneState DNSZoneState) {
-	clone := zoneState.GetDNSSets().Clone()
+	records := newRecordStore(zoneState.GetDNSSets())
 
 	m.lock.Lock()
 	defer m.lock.Unlock()
-	m.zones[zone.Id()] = zonedata{zone: zone, dnssets: clone}
+	m.zones[zone.Id()] = zonedata{zone: zone, records: records}
 }
 
 func (m *InMemory) DeleteZone(zoneID dns.ZoneID) {
@@ -95,7 +106,7 @@ func (m *InMemory) AddZone(zone DNSHostedZone) bool {
 		return false
 	}
 
-	m.zones[zone.Id()] = zonedata{zone: zone, dnssets: dns.DNSSets{}}
+	m.zones[zone.Id()] = zonedata{zone: zone, records: newRecordStore(nil)}
 	return true
 }
 
@@ -111,10 +122,10 @@ func (m *InMemory) Apply(zoneID dns.ZoneID, request *ChangeRequest, metrics Metr
 	name, rset := buildRecordSet(request)
 	switch request.Action {
 	case R_CREATE, R_UPDATE:
-		data.dnssets.AddRecordSet(name, request.Addition.RoutingPolicy, rset)
+		data.records.AddRecordSet(name, request.Addition.RoutingPolicy, rset)
 		metrics.AddZoneRequests(zoneID.ID, M_UPDATERECORDS, 1)
 	case R_DELETE:
-		data.dnssets.RemoveRecordSet(name, rset.Type)
+		data.records.RemoveRecordSet(name, rset.Type)
 		metrics.AddZoneRequests(zoneID.ID, M_DELETERECORDS, 1)
 	}
 	return nil
@@ -168,7 +179,7 @@ func (m *InMemory) buildZoneDump(zoneId dns.ZoneID) *ZoneDump {
 	hostedZone := DumpDNSHostedZone{ProviderType: data.zone.Id().ProviderType, Id: data.zone.Id().ID, Domain: data.zone.Domain(),
 		Key: data.zone.Key(), ForwardedDomains: data.zone.ForwardedDomains()}
 
-	return &ZoneDump{HostedZone: hostedZone, DNSSets: data.dnssets}
+	return &ZoneDump{HostedZone: hostedZone, DNSSets: data.records.DNSSets()}
 }
 
 /*
@@ -26,9 +26,14 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/controller-manager-library/pkg/utils"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/audit"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/notification"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
+	"github.com/gardener/external-dns-management/pkg/server/tracing"
+	"github.com/gardener/external-dns-management/pkg/server/webhook"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/util/flowcontrol"
@@ -42,12 +47,52 @@ type Config struct {
 	Ident                    string
 	Dryrun                   bool
 	ZoneStateCaching         bool
+	ZoneStateCacheDir        string
+	LazyZoneLoading          bool
 	DisableDNSNameValidation bool
 	Delay                    time.Duration
+	ZoneTriggerDebounce      time.Duration
+	MaxZonesPerAccount       int
 	Enabled                  utils.StringSet
 	Options                  *FactoryOptions
 	Factory                  DNSHandlerFactory
 	RemoteAccessConfig       *embed.RemoteAccessServerConfig
+	AdmissionWebhookConfig   *webhook.Config
+	AuditLog                 *audit.Log
+	Notifier                 *notification.Notifier
+	OrphanGracePeriod        time.Duration
+	OrphanDryrun             bool
+	UpsertOnly               bool
+	DriftCheckPeriod         time.Duration
+	DriftCorrection          bool
+	PropagationCheckTimeout  time.Duration
+	PropagationResolvers     []string
+	DNSLookup                *DNSLookupConfig
+	StuckEntryThreshold      time.Duration
+	StuckEntryRetrigger      bool
+	OwnerConflictThreshold   int
+	OwnerConflictWindow      time.Duration
+	AllowForceCleanup        bool
+	DuplicatePolicy          api.DuplicatePolicy
+	MinTTL                   int64
+	MaxTTL                   int64
+	LockLookup               *LockLookupConfig
+	QuotaBackpressure        int
+	ExternalDNSRegistry      *dns.ExternalDNSRegistry
+	ZoneExport               bool
+	PTRRecords               bool
+	TargetCIDRs              *dns.CIDRFilter
+	HostResolver             *hostResolver
+	DebugStateTokenFile      string
+	ReadinessZonesMaxAge     time.Duration
+	ZoneSharding             *ZoneShardingConfig
+	Vault                    *VaultConfig
+	SopsVaultRole            string
+	SopsAgeKeyFile           string
+	CheckPermissions         bool
+	TLSPolicy                *dnsutils.TLSPolicy
+	Tracing                  *tracing.Config
+	LogFormat                string
 }
 
 func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (*Config, error) {
@@ -70,6 +115,9 @@ func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (
 		delay = 10 * time.Second
 	}
 
+	zoneTriggerDebounce, _ := c.GetDurationOption(OPT_ZONE_TRIGGER_DEBOUNCE)
+	maxZonesPerAccount, _ := c.GetIntOption(OPT_MAX_ZONES_PER_ACCOUNT)
+
 	rescheduleDelay, err := c.GetDurationOption(OPT_RESCHEDULEDELAY)
 	if err != nil {
 		rescheduleDelay = 120 * time.Second
@@ -84,12 +132,100 @@ func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (
 		return nil, err
 	}
 
+	tlsPolicy, err := createTLSPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+
 	remoteAccessConfig, err := createRemoteAccessConfig(c)
 	if err != nil {
 		return nil, err
 	}
+	if remoteAccessConfig != nil {
+		remoteAccessConfig.TLSPolicy = tlsPolicy
+	}
+
+	admissionWebhookConfig, err := createAdmissionWebhookConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	notifier, err := createNotifier(c)
+	if err != nil {
+		return nil, err
+	}
+	auditLog, err := createAuditLog(c)
+	if err != nil {
+		return nil, err
+	}
+
+	externalDNSRegistry, err := createExternalDNSRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+
+	vault, err := createVaultConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneSharding, err := createZoneShardingConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	orphanGracePeriod, _ := c.GetDurationOption(OPT_ORPHAN_GRACE_PERIOD)
+	orphanDryrun, _ := c.GetBoolOption(OPT_ORPHAN_DRYRUN)
+	upsertOnly, _ := c.GetBoolOption(OPT_UPSERT_ONLY)
+	driftCheckPeriod, _ := c.GetDurationOption(OPT_DRIFT_CHECK_PERIOD)
+	disableDriftCorrection, _ := c.GetBoolOption(OPT_DISABLE_DRIFT_CORRECTION)
+	propagationCheckTimeout, _ := c.GetDurationOption(OPT_PROPAGATION_CHECK_TIMEOUT)
+	dnsLookup, err := createDNSLookupConfig(c, tlsPolicy)
+	if err != nil {
+		return nil, err
+	}
+	propagationResolvers, err := createPropagationResolvers(c, dnsLookup)
+	if err != nil {
+		return nil, err
+	}
+	stuckEntryThreshold, _ := c.GetDurationOption(OPT_STUCK_ENTRY_THRESHOLD)
+	stuckEntryRetrigger, _ := c.GetBoolOption(OPT_STUCK_ENTRY_RETRIGGER)
+	ownerConflictThreshold, _ := c.GetIntOption(OPT_OWNER_CONFLICT_THRESHOLD)
+	ownerConflictWindow, _ := c.GetDurationOption(OPT_OWNER_CONFLICT_WINDOW)
+	allowForceCleanup, _ := c.GetBoolOption(OPT_ALLOW_FORCE_CLEANUP)
+	duplicatePolicy, err := createDuplicatePolicy(c)
+	if err != nil {
+		return nil, err
+	}
+	minTTL, maxTTL, err := createTTLBounds(c)
+	if err != nil {
+		return nil, err
+	}
+	lockLookup, err := createLockLookupConfig(c, dnsLookup)
+	if err != nil {
+		return nil, err
+	}
+	quotaBackpressure, _ := c.GetIntOption(OPT_QUOTA_BACKPRESSURE_THRESHOLD)
+	zoneExport, _ := c.GetBoolOption(OPT_ENABLE_ZONE_EXPORT)
+	ptrRecords, _ := c.GetBoolOption(OPT_MAINTAIN_PTR_RECORDS)
+	targetCIDRs, err := createTargetCIDRFilter(c)
+	if err != nil {
+		return nil, err
+	}
+	hostResolver, err := createHostResolver(c, dnsLookup)
+	if err != nil {
+		return nil, err
+	}
+	debugStateTokenFile, _ := c.GetStringOption(OPT_DEBUG_STATE_TOKEN_FILE)
+	readinessZonesMaxAge, _ := c.GetDurationOption(OPT_READINESS_ZONES_MAX_AGE)
+	checkPermissions, _ := c.GetBoolOption(OPT_CHECK_PERMISSIONS)
+	sopsVaultRole, _ := c.GetStringOption(OPT_SOPS_VAULT_ROLE)
+	sopsAgeKeyFile, _ := c.GetStringOption(OPT_SOPS_AGE_KEY_FILE)
+	logFormat, _ := c.GetStringOption(OPT_LOG_FORMAT)
 
 	disableZoneStateCaching, _ := c.GetBoolOption(OPT_DISABLE_ZONE_STATE_CACHING)
+	zoneStateCacheDir, _ := c.GetStringOption(OPT_ZONE_STATE_CACHE_DIR)
+	lazyZoneLoading, _ := c.GetBoolOption(OPT_LAZY_ZONE_LOADING)
 	disableDNSNameValidation, _ := c.GetBoolOption(OPT_DISABLE_DNSNAME_VALIDATION)
 
 	enabled := utils.StringSet{}
@@ -121,12 +257,52 @@ func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (
 		StatusCheckPeriod:        statuscheckperiod,
 		Dryrun:                   dryrun,
 		ZoneStateCaching:         !disableZoneStateCaching,
+		ZoneStateCacheDir:        zoneStateCacheDir,
+		LazyZoneLoading:          lazyZoneLoading,
 		DisableDNSNameValidation: disableDNSNameValidation,
 		Delay:                    delay,
+		ZoneTriggerDebounce:      zoneTriggerDebounce,
+		MaxZonesPerAccount:       maxZonesPerAccount,
 		Enabled:                  enabled,
 		Options:                  fopts,
 		Factory:                  factory,
 		RemoteAccessConfig:       remoteAccessConfig,
+		AdmissionWebhookConfig:   admissionWebhookConfig,
+		AuditLog:                 auditLog,
+		Notifier:                 notifier,
+		OrphanGracePeriod:        orphanGracePeriod,
+		OrphanDryrun:             orphanDryrun,
+		UpsertOnly:               upsertOnly,
+		DriftCheckPeriod:         driftCheckPeriod,
+		DriftCorrection:          !disableDriftCorrection,
+		PropagationCheckTimeout:  propagationCheckTimeout,
+		PropagationResolvers:     propagationResolvers,
+		DNSLookup:                dnsLookup,
+		StuckEntryThreshold:      stuckEntryThreshold,
+		StuckEntryRetrigger:      stuckEntryRetrigger,
+		OwnerConflictThreshold:   ownerConflictThreshold,
+		OwnerConflictWindow:      ownerConflictWindow,
+		AllowForceCleanup:        allowForceCleanup,
+		DuplicatePolicy:          duplicatePolicy,
+		MinTTL:                   minTTL,
+		MaxTTL:                   maxTTL,
+		LockLookup:               lockLookup,
+		QuotaBackpressure:        quotaBackpressure,
+		ExternalDNSRegistry:      externalDNSRegistry,
+		ZoneExport:               zoneExport,
+		PTRRecords:               ptrRecords,
+		TargetCIDRs:              targetCIDRs,
+		HostResolver:             hostResolver,
+		DebugStateTokenFile:      debugStateTokenFile,
+		ReadinessZonesMaxAge:     readinessZonesMaxAge,
+		ZoneSharding:             zoneSharding,
+		Vault:                    vault,
+		SopsVaultRole:            sopsVaultRole,
+		SopsAgeKeyFile:           sopsAgeKeyFile,
+		CheckPermissions:         checkPermissions,
+		TLSPolicy:                tlsPolicy,
+		Tracing:                  createTracingConfig(c, tlsPolicy),
+		LogFormat:                logFormat,
 	}, nil
 }
 
@@ -167,6 +343,7 @@ type DNSHandlerConfig struct {
 	Options          *FactoryOptions
 	Metrics          Metrics
 	RateLimiter      flowcontrol.RateLimiter
+	Transport        *TransportConfig
 }
 
 type DNSZoneState interface {
@@ -176,6 +353,7 @@ type DNSZoneState interface {
 const (
 	M_LISTZONES  = "list_zones"
 	M_PLISTZONES = "list_zones_pages"
+	M_GETZONE    = "get_zone"
 
 	M_LISTRECORDS  = "list_records"
 	M_PLISTRECORDS = "list_records_pages"
@@ -186,13 +364,18 @@ const (
 	M_CREATERECORDS = "create_records"
 	M_DELETERECORDS = "delete_records"
 
-	M_CACHED_GETZONES     = "cached_getzones"
-	M_CACHED_GETZONESTATE = "cached_getzonestate"
+	M_CACHED_GETZONES        = "cached_getzones"
+	M_CACHED_GETZONESTATE    = "cached_getzonestate"
+	M_UNCHANGED_GETZONESTATE = "unchanged_getzonestate"
+
+	M_NOTMODIFIED = "not_modified"
 )
 
 type Metrics interface {
 	AddGenericRequests(requestType string, n int)
 	AddZoneRequests(zoneID, requestType string, n int)
+	// AddThrottledRequests counts requests delayed by the provider rate limiter
+	AddThrottledRequests(n int)
 }
 
 type Finalizers interface {
@@ -247,6 +430,11 @@ type DNSProvider interface {
 	TypeCode() string
 
 	DefaultTTL() int64
+	DefaultDeletionPolicy() *api.DeletionPolicy
+	// UpsertOnly returns true if DNS records must not be deleted at the provider.
+	UpsertOnly() bool
+	// TargetCIDRs returns the filter for IP address targets of the provider (nil if not restricted).
+	TargetCIDRs() *dns.CIDRFilter
 
 	GetZones() DNSHostedZones
 	IncludesZone(zoneID dns.ZoneID) bool
@@ -256,12 +444,18 @@ type DNSProvider interface {
 	ExecuteRequests(logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, requests []*ChangeRequest) error
 
 	GetDedicatedDNSAccess() DedicatedDNSAccess
+	GetZoneManagement() DNSZoneManagement
+	GetHealthCheckManagement() DNSHealthCheckManagement
 
 	Match(dns string) int
 	MatchZone(dns string) int
 	IsValid() bool
+	// Priority returns the priority of the provider used to select between providers responsible for the same DNS name.
+	Priority() int
 
 	AccountHash() string
+	// QuotaUsage returns the ratio of the requests in the last minute to the request quota of the account.
+	QuotaUsage() float64
 	MapTarget(t Target) Target
 
 	// ReportZoneStateConflict is used to report a conflict because of stale data.
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgov2.Describe("Lazy zone loading", func() {
+	var (
+		s   *state
+		req *zoneReconciliation
+	)
+
+	newProvider := func(recordImport *api.RecordImport) *dnsProviderVersion {
+		provider := &api.DNSProvider{}
+		provider.Namespace = "default"
+		provider.Name = "p1"
+		provider.Spec.RecordImport = recordImport
+		return &dnsProviderVersion{object: &dnsutils.DNSProviderObject{Object: &accessObject{data: provider, kind: api.DNSProviderKind}}}
+	}
+
+	ginkgov2.BeforeEach(func() {
+		s = &state{config: Config{LazyZoneLoading: true}}
+		req = &zoneReconciliation{
+			zone:      newDNSHostedZone(time.Second, NewDNSHostedZone("mock", "z1", "example.com", "", nil, false)),
+			providers: DNSProviders{},
+			entries:   Entries{},
+			stale:     ZonedDNSSetNames{},
+			ownership: testOwnership("owner"),
+		}
+		p := newProvider(nil)
+		req.providers[p.ObjectName()] = p
+	})
+
+	ginkgov2.It("skips zones without entries", func() {
+		Ω(s.skipZoneLoading(req)).Should(BeTrue())
+	})
+
+	ginkgov2.It("loads all zones if disabled", func() {
+		s.config.LazyZoneLoading = false
+		Ω(s.skipZoneLoading(req)).Should(BeFalse())
+	})
+
+	ginkgov2.It("loads zones with entries", func() {
+		name := resources.NewObjectName("default", "e1")
+		req.entries[name] = &Entry{}
+		Ω(s.skipZoneLoading(req)).Should(BeFalse())
+
+		delete(req.entries, name)
+		req.stale[ZonedDNSSetName{ZoneID: req.zone.Id(), DNSSetName: dns.DNSSetName{DNSName: "a.example.com"}}] = &Entry{}
+		Ω(s.skipZoneLoading(req)).Should(BeFalse())
+	})
+
+	ginkgov2.It("loads zones with imported records", func() {
+		p := newProvider(&api.RecordImport{Zones: []string{"z2"}})
+		req.providers[p.ObjectName()] = p
+		Ω(s.skipZoneLoading(req)).Should(BeTrue())
+
+		p = newProvider(&api.RecordImport{Zones: []string{"z1"}})
+		req.providers[p.ObjectName()] = p
+		Ω(s.skipZoneLoading(req)).Should(BeFalse())
+	})
+
+	ginkgov2.It("loads zones while records of deactivated owner ids are cleaned up", func() {
+		req.ownership = retiredOwnership{testOwnership: "owner", retired: map[string]api.DeletionPolicy{}}
+		Ω(s.skipZoneLoading(req)).Should(BeTrue())
+
+		req.ownership = retiredOwnership{testOwnership: "owner", retired: map[string]api.DeletionPolicy{"old": api.DeletionPolicyDelete}}
+		Ω(s.skipZoneLoading(req)).Should(BeFalse())
+	})
+})
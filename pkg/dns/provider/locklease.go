@@ -0,0 +1,182 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// LOCK_LEASE_DURATION_FACTOR is the factor applied to the TTL of a DNS lock to get the duration
+// of its lease after the last renewal. It leaves room for renewals cached by resolvers.
+const LOCK_LEASE_DURATION_FACTOR = 3
+
+// lockLease is the state of the lease of a DNS lock found in DNS.
+type lockLease struct {
+	holder     string
+	renewed    time.Time
+	expiration time.Time
+}
+
+// newLockLease returns the lease described by the attributes of the lock record
+// or nil if the record has no valid lease.
+func newLockLease(holder, timestamp string, ttl int64) *lockLease {
+	if holder == "" {
+		return nil
+	}
+	i, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil
+	}
+	renewed := time.Unix(i, 0)
+	return &lockLease{holder: holder, renewed: renewed, expiration: renewed.Add(lockLeaseDuration(ttl))}
+}
+
+// claimableBy checks whether the lease can be acquired or renewed by the given holder.
+func (this *lockLease) claimableBy(holder string, now time.Time) bool {
+	return this == nil || this.holder == holder || !now.Before(this.expiration)
+}
+
+func (this *lockLease) String() string {
+	if this == nil {
+		return "no lease"
+	}
+	return fmt.Sprintf("lease held by %s until %s", this.holder, this.expiration.UTC().Format(time.RFC3339))
+}
+
+func lockLeaseDuration(ttl int64) time.Duration {
+	return time.Duration(LOCK_LEASE_DURATION_FACTOR*ttl) * time.Second
+}
+
+// lockLeaseRenewInterval returns the interval for renewing a lease. Renewing after half of the TTL
+// ensures that the renewal is visible to others before cached records expire.
+func lockLeaseRenewInterval(ttl int64) time.Duration {
+	interval := time.Duration(ttl) * time.Second / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return interval
+}
+
+// evaluateLockLease checks the lock id and the lease found in DNS for the given holder.
+// It returns whether the lock is valid and whether the lease can be acquired or renewed by the holder.
+func evaluateLockLease(lockObj, holder, lockDNS string, lease *lockLease, now time.Time) (ok, claimable bool, msg string) {
+	if lockObj != lockDNS {
+		return false, false, fmt.Sprintf("mismatching lock ids %s != %s", lockObj, lockDNS)
+	}
+	if !lease.claimableBy(holder, now) {
+		return true, false, lease.String()
+	}
+	return true, true, ""
+}
+
+// lockLeaseRecordSet returns the lock record set for a lease renewed by the holder at the given time.
+func lockLeaseRecordSet(entry *Entry, holder string, renewed time.Time) DedicatedRecordSet {
+	obj := entry.object.(*dnsutils.DNSLockObject)
+	return lockRecordSet(entry, obj.GetLeaseText(holder, renewed))
+}
+
+func lockRecordSet(entry *Entry, text []string) DedicatedRecordSet {
+	ttl := entry.TTL()
+	records := dns.Records{}
+	for _, s := range text {
+		records = append(records, dnsutils.NewText(s, ttl).AsRecord())
+	}
+	return FromDedicatedRecordSet(entry.DNSSetName(), dns.NewRecordSet(dns.RS_TXT, ttl, records))
+}
+
+// checkAndUpdateLockLease acquires or renews the lease of a DNS lock if it is free, held by the own holder or expired.
+// The entry is rescheduled for the next renewal or for claiming the lease after its expiration.
+func (this *state) checkAndUpdateLockLease(logger logger.LogContext, entry *Entry, premise *EntryPremise,
+	handler DedicatedDNSAccess, zone DNSHostedZone) reconcile.Status {
+	obj := entry.object.(*dnsutils.DNSLockObject)
+	holder := obj.GetHolder(this.config.Ident)
+	ttl := entry.TTL()
+	now := time.Now()
+
+	rs, err := handler.GetRecordSet(zone, entry.DNSSetName(), dns.RS_TXT)
+	if err != nil {
+		return reconcile.Delay(logger, err)
+	}
+	this.locks.reportLookup(entry.ObjectName(), now)
+	var lease *lockLease
+	ok, claimable, msg := true, true, ""
+	if len(rs) != 0 {
+		lease = newLockLease(rs.GetAttr(dns.ATTR_HOLDER), rs.GetAttr(dns.ATTR_TIMESTAMP), ttl)
+		ok, claimable, msg = evaluateLockLease(utils.StringValue(obj.Spec().LockId), holder, rs.GetAttr(dns.ATTR_LOCKID), lease, now)
+	}
+
+	reschedule := lockLeaseRenewInterval(ttl)
+	if claimable {
+		err = handler.CreateOrUpdateRecordSet(logger, zone, rs, lockLeaseRecordSet(entry, holder, now))
+		if err != nil {
+			return reconcile.Delay(logger, err)
+		}
+		if lease != nil && lease.holder != holder {
+			logger.Infof("claimed expired lease of %s", lease.holder)
+			this.locks.reportAcquired(entry.object, lease.holder)
+		}
+		lease = &lockLease{holder: holder, renewed: now, expiration: now.Add(lockLeaseDuration(ttl))}
+		msg = "DNS record is set, " + lease.String()
+	} else if ok {
+		reschedule = lease.expiration.Sub(now) + time.Second
+	}
+	entry.updateRequired = false
+	this.locks.reportState(entry.object, lockState(ok, claimable, false), msg)
+
+	_, err = entry.object.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSLock).Status
+		mod := utils.ModificationState{}
+		mod.Modify(AssureTimestamp(&status.FirstFailedDNSLookup, time.Time{}))
+
+		state := api.STATE_READY
+		if !ok {
+			state = api.STATE_INVALID
+		}
+		mod.AssureStringValue(&status.State, state)
+		mod.AssureStringPtrPtr(&status.Message, &msg)
+		if lease != nil {
+			mod.AssureStringPtrPtr(&status.Holder, &lease.holder)
+			mod.Modify(AssureTimestamp(&status.Timestamp, lease.renewed))
+			mod.Modify(AssureTimestamp(&status.LeaseExpiration, lease.expiration))
+		}
+
+		mod.AssureStringPtrPtr(&status.Zone, &premise.zoneid)
+		provider := premise.provider.ObjectName().String()
+		mod.AssureStringPtrPtr(&status.Provider, &provider)
+		mod.AssureStringPtrPtr(&status.ProviderType, &premise.ptype)
+		mod.AssureInt64Value(&status.ObservedGeneration, entry.object.GetGeneration())
+		return mod.IsModified(), nil
+	})
+	if err != nil {
+		return reconcile.Delay(logger, err)
+	}
+	if !ok {
+		return reconcile.Succeeded(logger)
+	}
+	return reconcile.RescheduleAfter(logger, reschedule)
+}
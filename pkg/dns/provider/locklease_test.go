@@ -0,0 +1,74 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Lock lease", func() {
+	renewed := time.Unix(1600000000, 0)
+	timestamp := fmt.Sprintf("%d", renewed.Unix())
+
+	ginkgov2.It("parses the lease from the lock record", func() {
+		lease := newLockLease("c1", timestamp, 60)
+		Ω(lease).ShouldNot(BeNil())
+		Ω(lease.holder).Should(Equal("c1"))
+		Ω(lease.renewed).Should(Equal(renewed))
+		Ω(lease.expiration).Should(Equal(renewed.Add(180 * time.Second)))
+
+		Ω(newLockLease("", timestamp, 60)).Should(BeNil())
+		Ω(newLockLease("c1", "invalid", 60)).Should(BeNil())
+	})
+
+	ginkgov2.It("renews the own lease and claims free or expired leases", func() {
+		lease := newLockLease("c1", timestamp, 60)
+		Ω(lease.claimableBy("c1", renewed.Add(time.Hour))).Should(BeTrue())
+		Ω(lease.claimableBy("c2", renewed.Add(179*time.Second))).Should(BeFalse())
+		Ω(lease.claimableBy("c2", renewed.Add(180*time.Second))).Should(BeTrue())
+
+		var none *lockLease
+		Ω(none.claimableBy("c2", renewed)).Should(BeTrue())
+	})
+
+	ginkgov2.It("evaluates lock id and lease", func() {
+		lease := newLockLease("c1", timestamp, 60)
+
+		ok, claimable, msg := evaluateLockLease("lock", "c2", "other", lease, renewed)
+		Ω(ok).Should(BeFalse())
+		Ω(claimable).Should(BeFalse())
+		Ω(msg).Should(Equal("mismatching lock ids lock != other"))
+
+		ok, claimable, msg = evaluateLockLease("lock", "c2", "lock", lease, renewed.Add(time.Minute))
+		Ω(ok).Should(BeTrue())
+		Ω(claimable).Should(BeFalse())
+		Ω(msg).Should(Equal("lease held by c1 until 2020-09-13T12:29:40Z"))
+
+		ok, claimable, _ = evaluateLockLease("lock", "c2", "lock", lease, renewed.Add(time.Hour))
+		Ω(ok).Should(BeTrue())
+		Ω(claimable).Should(BeTrue())
+	})
+
+	ginkgov2.It("derives the renew interval from the ttl", func() {
+		Ω(lockLeaseRenewInterval(120)).Should(Equal(time.Minute))
+		Ω(lockLeaseRenewInterval(1)).Should(Equal(time.Second))
+	})
+})
@@ -0,0 +1,221 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// modes for looking up the TXT records of DNS locks
+const (
+	// LOCK_LOOKUP_RESOLVER resolves the lock records with the configured resolvers or the host resolver
+	LOCK_LOOKUP_RESOLVER = "resolver"
+	// LOCK_LOOKUP_PROVIDER reads the lock records with the API of the responsible provider
+	LOCK_LOOKUP_PROVIDER = "provider"
+)
+
+// DEFAULT_LOCK_LOOKUP_RETRY_DELAY is the default delay before retrying a failed lookup of DNS lock records
+const DEFAULT_LOCK_LOOKUP_RETRY_DELAY = time.Second
+
+// errLockRecordsNotFound is returned if the provider has no lock records.
+var errLockRecordsNotFound = fmt.Errorf("no TXT records found")
+
+// LockLookupConfig configures the lookup of the TXT records for the status checks of DNS locks.
+type LockLookupConfig struct {
+	Mode string
+	// Resolvers are the addresses of the resolvers, by default the resolvers of the general DNS lookup configuration
+	Resolvers []string
+	// Timeout is the timeout of a single lookup, by default the timeout of the general DNS lookup configuration
+	Timeout time.Duration
+	// Retries is the number of retries of a failed lookup, by default the retries of the general DNS lookup configuration
+	Retries int
+	// RetryDelay is the delay before retrying a failed lookup
+	RetryDelay time.Duration
+	// Lookup is the general DNS lookup configuration providing the transport protocol
+	Lookup *DNSLookupConfig
+}
+
+func createLockLookupConfig(c controller.Interface, lookup *DNSLookupConfig) (*LockLookupConfig, error) {
+	mode, _ := c.GetStringOption(OPT_LOCK_LOOKUP_MODE)
+	if mode == "" {
+		mode = LOCK_LOOKUP_RESOLVER
+	}
+	if mode != LOCK_LOOKUP_RESOLVER && mode != LOCK_LOOKUP_PROVIDER {
+		return nil, fmt.Errorf("invalid lock lookup mode %q (expected %s or %s)", mode,
+			LOCK_LOOKUP_RESOLVER, LOCK_LOOKUP_PROVIDER)
+	}
+	cfg := &LockLookupConfig{Mode: mode, Lookup: lookup}
+	if value, _ := c.GetStringOption(OPT_LOCK_LOOKUP_RESOLVERS); value != "" {
+		if mode == LOCK_LOOKUP_PROVIDER {
+			return nil, fmt.Errorf("option %s cannot be used with lock lookup mode %s", OPT_LOCK_LOOKUP_RESOLVERS, mode)
+		}
+		resolvers, err := lookup.parseResolvers(value)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Resolvers = resolvers
+	} else {
+		cfg.Resolvers = lookup.resolvers()
+	}
+	cfg.Timeout, _ = c.GetDurationOption(OPT_LOCK_LOOKUP_TIMEOUT)
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = lookup.timeout()
+	}
+	cfg.Retries, _ = c.GetIntOption(OPT_LOCK_LOOKUP_RETRIES)
+	if cfg.Retries < 0 {
+		cfg.Retries = lookup.retries()
+	}
+	cfg.RetryDelay, _ = c.GetDurationOption(OPT_LOCK_LOOKUP_RETRY_DELAY)
+	return cfg, nil
+}
+
+// forLock returns the effective lookup configuration for a DNS lock, overwritten by its lookup spec.
+// Resolvers given in the spec enforce the lookup with these resolvers.
+func (this *LockLookupConfig) forLock(spec *api.DNSLockLookup) LockLookupConfig {
+	cfg := LockLookupConfig{Mode: LOCK_LOOKUP_RESOLVER}
+	if this != nil {
+		cfg = *this
+	}
+	if spec != nil {
+		if len(spec.Resolvers) > 0 {
+			cfg.Mode = LOCK_LOOKUP_RESOLVER
+			cfg.Resolvers = nil
+			for _, r := range spec.Resolvers {
+				if address, err := cfg.Lookup.normalizeResolver(r); err == nil {
+					cfg.Resolvers = append(cfg.Resolvers, address)
+				}
+			}
+		}
+		if spec.Timeout != nil {
+			cfg.Timeout = spec.Timeout.Duration
+		}
+		if spec.Retries != nil {
+			cfg.Retries = *spec.Retries
+		}
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = cfg.Lookup.timeout()
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = DEFAULT_LOCK_LOOKUP_RETRY_DELAY
+	}
+	return cfg
+}
+
+// lookupLockRecords returns the values of the TXT records of a DNS lock.
+// Failed lookups are retried, but not lookups of records not found.
+func (this *state) lookupLockRecords(e *Entry) ([]string, error) {
+	var spec *api.DNSLockLookup
+	if obj, ok := e.object.(*dnsutils.DNSLockObject); ok {
+		spec = obj.Spec().Lookup
+	}
+	cfg := this.config.LockLookup.forLock(spec)
+	for attempt := 0; ; attempt++ {
+		records, err := this.lookupLockRecordsOnce(e, &cfg)
+		if err == nil || isNotFound(err) || attempt >= cfg.Retries {
+			return records, err
+		}
+		select {
+		case <-this.context.GetContext().Done():
+			return nil, err
+		case <-time.After(cfg.RetryDelay):
+		}
+	}
+}
+
+func (this *state) lookupLockRecordsOnce(e *Entry, cfg *LockLookupConfig) ([]string, error) {
+	if cfg.Mode == LOCK_LOOKUP_PROVIDER {
+		return this.readLockRecords(e)
+	}
+	ctx, cancel := context.WithTimeout(this.context.GetContext(), cfg.Timeout)
+	defer cancel()
+	return lookupTXT(ctx, cfg.Lookup, cfg.Resolvers, e.DNSName())
+}
+
+func isNotFound(err error) bool {
+	if errors.Is(err, errLockRecordsNotFound) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// readLockRecords reads the TXT records of a DNS lock with the dedicated DNS access of its provider.
+func (this *state) readLockRecords(e *Entry) ([]string, error) {
+	this.lock.RLock()
+	zone := this.zones[e.ZoneId()]
+	var handler DedicatedDNSAccess
+	if p := this.providers[e.ProviderName()]; p != nil {
+		handler = p.GetDedicatedDNSAccess()
+	}
+	if handler == nil {
+		for _, p := range this.getProvidersForZone(e.ZoneId()) {
+			if handler = p.GetDedicatedDNSAccess(); handler != nil {
+				break
+			}
+		}
+	}
+	this.lock.RUnlock()
+
+	if zone == nil {
+		return nil, fmt.Errorf("hosted zone %s not found", e.ZoneId())
+	}
+	if handler == nil {
+		return nil, fmt.Errorf("no provider with dedicated DNS access for hosted zone %s", e.ZoneId())
+	}
+	rs, err := handler.GetRecordSet(zone, e.DNSSetName(), dns.RS_TXT)
+	if err != nil {
+		return nil, err
+	}
+	if len(rs) == 0 {
+		return nil, fmt.Errorf("%w for %s", errLockRecordsNotFound, e.DNSName())
+	}
+	records := make([]string, len(rs))
+	for i, r := range rs {
+		records[i] = r.GetValue()
+	}
+	return records, nil
+}
+
+// lookupTXT resolves TXT records with the host resolver or with the first of the given resolvers answering.
+func lookupTXT(ctx context.Context, lookup *DNSLookupConfig, resolvers []string, dnsName string) ([]string, error) {
+	if len(resolvers) == 0 {
+		return net.DefaultResolver.LookupTXT(ctx, dnsName)
+	}
+	var err error
+	for _, server := range resolvers {
+		var records []string
+		records, err = lookup.newResolver(server).LookupTXT(ctx, dnsName)
+		if err == nil {
+			return records, nil
+		}
+		if isNotFound(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+type lockRecordsHandler struct {
+	DNSHandler
+	records map[string]*dns.RecordSet
+	calls   int
+}
+
+var _ DedicatedDNSAccess = &lockRecordsHandler{}
+
+func (this *lockRecordsHandler) GetRecordSet(_ DNSHostedZone, name dns.DNSSetName, recordType string) (DedicatedRecordSet, error) {
+	this.calls++
+	rs := this.records[name.DNSName]
+	if rs == nil || rs.Type != recordType {
+		return nil, nil
+	}
+	return FromDedicatedRecordSet(name, rs), nil
+}
+
+func (this *lockRecordsHandler) CreateOrUpdateRecordSet(_ logger.LogContext, _ DNSHostedZone, _, _ DedicatedRecordSet) error {
+	return nil
+}
+
+func (this *lockRecordsHandler) DeleteRecordSet(_ logger.LogContext, _ DNSHostedZone, _ DedicatedRecordSet) error {
+	return nil
+}
+
+var _ = ginkgov2.Describe("Lock lookup", func() {
+	var (
+		s       *state
+		handler *lockRecordsHandler
+	)
+
+	providerName := resources.NewObjectName("default", "p1")
+	zoneid := dns.NewZoneID("mock", "z1")
+
+	newLockEntry := func(dnsName string) *Entry {
+		ptype, zone := "mock", "z1"
+		return &Entry{EntryVersion: &EntryVersion{
+			providername: providerName,
+			dnsSetName:   dns.DNSSetName{DNSName: dnsName},
+			status:       api.DNSBaseStatus{ProviderType: &ptype, Zone: &zone},
+		}}
+	}
+
+	ginkgov2.BeforeEach(func() {
+		handler = &lockRecordsHandler{records: map[string]*dns.RecordSet{
+			"lock.example.com": dns.NewRecordSet(dns.RS_TXT, 60, dns.Records{
+				{Value: "\"lockid=abc\""},
+				{Value: "\"timestamp=1600000000\""},
+			}),
+		}}
+		s = &state{
+			config:        Config{LockLookup: &LockLookupConfig{Mode: LOCK_LOOKUP_PROVIDER}},
+			zones:         map[dns.ZoneID]*dnsHostedZone{zoneid: newDNSHostedZone(time.Second, NewDNSHostedZone("mock", "z1", "example.com", "", nil, true))},
+			zoneproviders: map[dns.ZoneID]resources.ObjectNameSet{zoneid: resources.NewObjectNameSet(providerName)},
+			providers: map[resources.ObjectName]*dnsProviderVersion{
+				providerName: {account: &DNSAccount{handler: handler}},
+			},
+		}
+	})
+
+	ginkgov2.It("reads the lock records with the provider API", func() {
+		records, err := s.lookupLockRecords(newLockEntry("lock.example.com"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(records).Should(ConsistOf("\"lockid=abc\"", "\"timestamp=1600000000\""))
+	})
+
+	ginkgov2.It("fails for missing lock records", func() {
+		_, err := s.lookupLockRecords(newLockEntry("other.example.com"))
+		Ω(err).Should(MatchError(ContainSubstring("no TXT records found")))
+	})
+
+	ginkgov2.It("does not retry lookups of missing lock records", func() {
+		s.config.LockLookup.Retries = 2
+		_, err := s.lookupLockRecords(newLockEntry("other.example.com"))
+		Ω(isNotFound(err)).Should(BeTrue())
+		Ω(handler.calls).Should(Equal(1))
+	})
+
+	ginkgov2.It("overwrites the lookup configuration by the lock spec", func() {
+		cfg := &LockLookupConfig{Mode: LOCK_LOOKUP_PROVIDER, Timeout: 5 * time.Second, Retries: 1, RetryDelay: time.Second}
+		Ω(cfg.forLock(nil)).Should(Equal(*cfg))
+
+		retries := 3
+		effective := cfg.forLock(&api.DNSLockLookup{
+			Resolvers: []string{"10.0.0.1", "dns.example.com:5353"},
+			Timeout:   &metav1.Duration{Duration: 2 * time.Second},
+			Retries:   &retries,
+		})
+		Ω(effective.Mode).Should(Equal(LOCK_LOOKUP_RESOLVER))
+		Ω(effective.Resolvers).Should(Equal([]string{"10.0.0.1:53", "dns.example.com:5353"}))
+		Ω(effective.Timeout).Should(Equal(2 * time.Second))
+		Ω(effective.Retries).Should(Equal(3))
+
+		var none *LockLookupConfig
+		Ω(none.forLock(nil)).Should(Equal(LockLookupConfig{Mode: LOCK_LOOKUP_RESOLVER,
+			Timeout: DEFAULT_DNS_LOOKUP_TIMEOUT, RetryDelay: DEFAULT_LOCK_LOOKUP_RETRY_DELAY}))
+		lookup := &DNSLookupConfig{Timeout: 3 * time.Second}
+		Ω((&LockLookupConfig{Lookup: lookup}).forLock(nil).Timeout).Should(Equal(3 * time.Second))
+	})
+
+	ginkgov2.It("fails without provider with dedicated DNS access", func() {
+		s.providers[providerName].account.handler = nil
+		_, err := s.lookupLockRecords(newLockEntry("lock.example.com"))
+		Ω(err).Should(MatchError(ContainSubstring("no provider with dedicated DNS access")))
+	})
+})
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+// lockObserver tracks the states and lookups of the DNS locks and reports them as metrics
+// and as events on takeovers.
+type lockObserver struct {
+	lock  sync.Mutex
+	locks map[resources.ObjectName]*lockObservation
+}
+
+type lockObservation struct {
+	state      string
+	lastLookup time.Time
+}
+
+func newLockObserver() *lockObserver {
+	return &lockObserver{locks: map[resources.ObjectName]*lockObservation{}}
+}
+
+// lockState returns the state of a lock reported by the metrics.
+func lockState(ok, owned, stale bool) string {
+	switch {
+	case stale:
+		return metrics.LOCK_STATE_STALE
+	case !ok:
+		return metrics.LOCK_STATE_INVALID
+	case owned:
+		return metrics.LOCK_STATE_HELD
+	default:
+		return metrics.LOCK_STATE_CONTENDED
+	}
+}
+
+func (this *lockObserver) get(name resources.ObjectName) *lockObservation {
+	obs := this.locks[name]
+	if obs == nil {
+		obs = &lockObservation{}
+		this.locks[name] = obs
+	}
+	return obs
+}
+
+// reportLookup records the time of a successful lookup of the lock records.
+func (this *lockObserver) reportLookup(name resources.ObjectName, now time.Time) {
+	if this == nil {
+		return
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.get(name).lastLookup = now
+}
+
+// reportState updates the state of a lock. A held lock becoming contended has been taken over by another holder.
+func (this *lockObserver) reportState(obj resources.Object, state, msg string) {
+	if this == nil {
+		return
+	}
+	name := obj.ObjectName()
+	this.lock.Lock()
+	obs := this.get(name)
+	old := obs.state
+	obs.state = state
+	this.lock.Unlock()
+
+	metrics.ReportDNSLockState(name, state)
+	if old == metrics.LOCK_STATE_HELD && state == metrics.LOCK_STATE_CONTENDED {
+		metrics.AddDNSLockTakeover(name, false)
+		obj.Eventf(corev1.EventTypeWarning, "takeover", "lock taken over: %s", msg)
+	}
+}
+
+// reportAcquired records the takeover of the expired lease of another holder.
+func (this *lockObserver) reportAcquired(obj resources.Object, previousHolder string) {
+	if this == nil {
+		return
+	}
+	metrics.AddDNSLockTakeover(obj.ObjectName(), true)
+	obj.Eventf(corev1.EventTypeNormal, "takeover", "claimed expired lease of %s", previousHolder)
+}
+
+// reportResurrect records an attempt to resurrect a lock after failed lookups.
+func (this *lockObserver) reportResurrect(obj resources.Object) {
+	if this == nil {
+		return
+	}
+	metrics.AddDNSLockResurrect(obj.ObjectName())
+	obj.Event(corev1.EventTypeWarning, "resurrect", "lookups of the lock records failed, trying to resurrect the lock")
+}
+
+// updateLookupAges reports the ages of the last successful lookups. Locks without successful lookup
+// are reported relative to the given start time.
+func (this *lockObserver) updateLookupAges(start, now time.Time) {
+	if this == nil {
+		return
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for name, obs := range this.locks {
+		last := obs.lastLookup
+		if last.IsZero() {
+			last = start
+		}
+		metrics.ReportDNSLockLookupAge(name, now.Sub(last))
+	}
+}
+
+// remove stops observing a deleted lock.
+func (this *lockObserver) remove(name resources.ObjectName) {
+	if this == nil {
+		return
+	}
+	this.lock.Lock()
+	delete(this.locks, name)
+	this.lock.Unlock()
+	metrics.DeleteDNSLock(name)
+}
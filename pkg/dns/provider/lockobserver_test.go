@@ -0,0 +1,61 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+var _ = ginkgov2.Describe("Lock observer", func() {
+	ginkgov2.It("derives the lock state", func() {
+		Ω(lockState(true, true, false)).Should(Equal(metrics.LOCK_STATE_HELD))
+		Ω(lockState(true, false, false)).Should(Equal(metrics.LOCK_STATE_CONTENDED))
+		Ω(lockState(false, false, false)).Should(Equal(metrics.LOCK_STATE_INVALID))
+		Ω(lockState(true, true, true)).Should(Equal(metrics.LOCK_STATE_STALE))
+	})
+
+	ginkgov2.It("reports the age of the last successful lookup", func() {
+		observer := newLockObserver()
+		name := resources.NewObjectName("default", "lock1")
+		start := time.Now().Add(-time.Hour)
+		now := start.Add(50 * time.Minute)
+
+		lookupAge := func() float64 {
+			m := &dto.Metric{}
+			Ω(metrics.DNSLockLookupAge.WithLabelValues("default", "lock1").Write(m)).Should(Succeed())
+			return m.GetGauge().GetValue()
+		}
+
+		observer.get(name)
+		observer.updateLookupAges(start, now)
+		Ω(lookupAge()).Should(Equal(3000.0))
+
+		observer.reportLookup(name, now.Add(-time.Minute))
+		observer.updateLookupAges(start, now)
+		Ω(lookupAge()).Should(Equal(60.0))
+
+		observer.remove(name)
+		Ω(observer.locks).Should(BeEmpty())
+	})
+})
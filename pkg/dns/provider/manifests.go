@@ -0,0 +1,318 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+// SecretValidator checks the shape of the credentials of a provider secret
+// without accessing the API of the DNS system.
+type SecretValidator func(props utils.Properties) error
+
+// RequiredSecretKeys returns a secret validator requiring a non-empty value
+// for every given group of alternative keys.
+func RequiredSecretKeys(keys ...[]string) SecretValidator {
+	return func(props utils.Properties) error {
+		for _, alternatives := range keys {
+			found := false
+			for _, key := range alternatives {
+				if props[key] != "" {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("'%s' required in secret", strings.Join(alternatives, "' or '"))
+			}
+		}
+		return nil
+	}
+}
+
+// secretValidatingFactory is implemented by handler factories checking the shape of provider secrets.
+type secretValidatingFactory interface {
+	ValidateSecret(typecode string, props utils.Properties) error
+}
+
+// ValidateProviderSecret checks the shape of the credentials of a provider secret for the given provider type.
+// Secrets encrypted with SOPS cannot be checked offline and are accepted.
+func ValidateProviderSecret(factory DNSHandlerFactory, typecode string, props utils.Properties) error {
+	if _, ok := props[SOPS_SECRET_KEY]; ok {
+		return nil
+	}
+	if f, ok := factory.(secretValidatingFactory); ok {
+		return f.ValidateSecret(typecode, props)
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// ManifestIssue is a problem found in a manifest.
+type ManifestIssue struct {
+	Source  string
+	Kind    string
+	Name    string
+	Warning bool
+	Err     error
+}
+
+func (this ManifestIssue) Error() string {
+	level := "error"
+	if this.Warning {
+		level = "warning"
+	}
+	return fmt.Sprintf("%s: %s %s %s: %s", this.Source, level, this.Kind, this.Name, this.Err)
+}
+
+type manifest struct {
+	source string
+	name   string
+	data   []byte
+}
+
+// ManifestValidator validates DNSEntry and DNSProvider manifests (and the shape of the
+// provider secrets given as manifests) without a cluster.
+type ManifestValidator struct {
+	factory       DNSHandlerFactory
+	validateNames bool
+	entries       []manifest
+	providers     []manifest
+	secrets       map[string]*corev1.Secret
+	issues        []ManifestIssue
+	objects       int
+}
+
+func NewManifestValidator(factory DNSHandlerFactory, validateNames bool) *ManifestValidator {
+	return &ManifestValidator{factory: factory, validateNames: validateNames, secrets: map[string]*corev1.Secret{}}
+}
+
+// Add reads the (multi document) YAML or JSON manifests. Documents of other kinds are ignored.
+func (this *ManifestValidator) Add(source string, r io.Reader) error {
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(r))
+	for i := 1; ; i++ {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		src := fmt.Sprintf("%s[%d]", source, i)
+		meta := struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+			Metadata   struct {
+				Namespace string `json:"namespace"`
+				Name      string `json:"name"`
+			} `json:"metadata"`
+		}{}
+		if err := yaml.Unmarshal(doc, &meta); err != nil {
+			this.issue(src, "document", "", false, err)
+			continue
+		}
+		name := manifestName(meta.Metadata.Namespace, meta.Metadata.Name)
+		switch {
+		case meta.APIVersion == api.SchemeGroupVersion.String() && meta.Kind == api.DNSEntryKind:
+			this.entries = append(this.entries, manifest{src, name, doc})
+		case meta.APIVersion == api.SchemeGroupVersion.String() && meta.Kind == api.DNSProviderKind:
+			this.providers = append(this.providers, manifest{src, name, doc})
+		case meta.APIVersion == "v1" && meta.Kind == "Secret":
+			secret := &corev1.Secret{}
+			if err := yaml.UnmarshalStrict(doc, secret); err != nil {
+				this.issue(src, "Secret", name, false, err)
+				continue
+			}
+			this.secrets[secret.Namespace+"/"+secret.Name] = secret
+		default:
+			continue
+		}
+		this.objects++
+	}
+}
+
+// Validate validates the added manifests and returns the found issues.
+func (this *ManifestValidator) Validate() []ManifestIssue {
+	for _, m := range this.entries {
+		entry := &api.DNSEntry{}
+		if err := yaml.UnmarshalStrict(m.data, entry); err != nil {
+			this.issue(m.source, api.DNSEntryKind, m.name, false, err)
+			continue
+		}
+		name := manifestName(entry.Namespace, entry.Name)
+		if err := validateObjectName(entry.Name); err != nil {
+			this.issue(m.source, api.DNSEntryKind, name, false, err)
+		}
+		if err := ValidateDNSEntrySpec(&entry.Spec, this.validateNames); err != nil {
+			this.issue(m.source, api.DNSEntryKind, name, false, err)
+		}
+	}
+	for _, m := range this.providers {
+		provider := &api.DNSProvider{}
+		if err := yaml.UnmarshalStrict(m.data, provider); err != nil {
+			this.issue(m.source, api.DNSProviderKind, m.name, false, err)
+			continue
+		}
+		name := manifestName(provider.Namespace, provider.Name)
+		if err := validateObjectName(provider.Name); err != nil {
+			this.issue(m.source, api.DNSProviderKind, name, false, err)
+		}
+		if err := ValidateDNSProviderSpec(&provider.Spec, this.factory.TypeCodes().Contains); err != nil {
+			this.issue(m.source, api.DNSProviderKind, name, false, err)
+			continue
+		}
+		for _, ref := range []*corev1.SecretReference{provider.Spec.SecretRef, provider.Spec.SecondarySecretRef} {
+			if ref != nil {
+				this.validateSecret(m.source, provider, ref)
+			}
+		}
+	}
+	sort.SliceStable(this.issues, func(i, j int) bool { return this.issues[i].Source < this.issues[j].Source })
+	return this.issues
+}
+
+// Objects returns the number of validated objects.
+func (this *ManifestValidator) Objects() int {
+	return this.objects
+}
+
+func (this *ManifestValidator) validateSecret(source string, provider *api.DNSProvider, ref *corev1.SecretReference) {
+	namespace := provider.Namespace
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	name := manifestName(provider.Namespace, provider.Name)
+	secret := this.secrets[namespace+"/"+ref.Name]
+	if secret == nil {
+		this.issue(source, api.DNSProviderKind, name, true, fmt.Errorf("secret %s not found in manifests", manifestName(namespace, ref.Name)))
+		return
+	}
+	props := utils.Properties{}
+	for k, v := range secret.Data {
+		props[k] = string(v)
+	}
+	for k, v := range secret.StringData {
+		props[k] = v
+	}
+	if err := ValidateProviderSecret(this.factory, provider.Spec.Type, props); err != nil {
+		this.issue(source, api.DNSProviderKind, name, false, fmt.Errorf("invalid secret %s: %w", manifestName(namespace, ref.Name), err))
+	}
+}
+
+func (this *ManifestValidator) issue(source, kind, name string, warning bool, err error) {
+	this.issues = append(this.issues, ManifestIssue{Source: source, Kind: kind, Name: name, Warning: warning, Err: err})
+}
+
+func validateObjectName(name string) error {
+	if name == "" {
+		return fmt.Errorf("metadata.name must be set")
+	}
+	if msgs := validation.IsDNS1123Subdomain(name); len(msgs) > 0 {
+		return fmt.Errorf("invalid metadata.name: %s", strings.Join(msgs, ", "))
+	}
+	return nil
+}
+
+func manifestName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+// ReadProviderManifests reads a DNSProvider and the properties of its secret from (multi document) manifest files,
+// e.g. for command line tools. The secret is selected by the secret reference of the provider if there are several.
+func ReadProviderManifests(files ...string) (*api.DNSProvider, utils.Properties, error) {
+	var p *api.DNSProvider
+	secrets := []*corev1.Secret{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		reader := k8syaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+		for {
+			doc, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", file, err)
+			}
+			meta := struct {
+				Kind string `json:"kind"`
+			}{}
+			if err := yaml.Unmarshal(doc, &meta); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", file, err)
+			}
+			switch meta.Kind {
+			case api.DNSProviderKind:
+				if p != nil {
+					return nil, nil, fmt.Errorf("%s: multiple DNSProvider manifests", file)
+				}
+				p = &api.DNSProvider{}
+				if err := yaml.Unmarshal(doc, p); err != nil {
+					return nil, nil, fmt.Errorf("%s: invalid DNSProvider manifest: %w", file, err)
+				}
+			case "Secret":
+				secret := &corev1.Secret{}
+				if err := yaml.Unmarshal(doc, secret); err != nil {
+					return nil, nil, fmt.Errorf("%s: invalid secret manifest: %w", file, err)
+				}
+				secrets = append(secrets, secret)
+			}
+		}
+	}
+	if p == nil {
+		return nil, nil, fmt.Errorf("no DNSProvider manifest found")
+	}
+	var secret *corev1.Secret
+	for _, s := range secrets {
+		if len(secrets) == 1 || p.Spec.SecretRef != nil && s.Name == p.Spec.SecretRef.Name {
+			secret = s
+		}
+	}
+	if secret == nil {
+		return nil, nil, fmt.Errorf("no secret manifest found for provider %s", p.Name)
+	}
+	props := utils.Properties{}
+	for k, v := range secret.Data {
+		props[k] = string(v)
+	}
+	for k, v := range secret.StringData {
+		props[k] = v
+	}
+	return p, props, nil
+}
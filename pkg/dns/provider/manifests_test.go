@@ -0,0 +1,153 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"strings"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Manifest validation", func() {
+	factory := NewDNSHandlerFactory("test-dns", nil).
+		SetSecretValidator(RequiredSecretKeys([]string{"TOKEN", "token"}))
+
+	validate := func(manifests string) []ManifestIssue {
+		validator := NewManifestValidator(factory, true)
+		Ω(validator.Add("test.yaml", strings.NewReader(manifests))).Should(Succeed())
+		return validator.Validate()
+	}
+
+	ginkgov2.It("accepts valid manifests", func() {
+		issues := validate(`
+apiVersion: dns.gardener.cloud/v1alpha1
+kind: DNSEntry
+metadata:
+  name: www
+  namespace: default
+spec:
+  dnsName: www.example.com
+  ttl: 300
+  targets:
+  - 1.2.3.4
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: other
+---
+apiVersion: dns.gardener.cloud/v1alpha1
+kind: DNSProvider
+metadata:
+  name: test
+  namespace: default
+spec:
+  type: test-dns
+  secretRef:
+    name: test-secret
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: test-secret
+  namespace: default
+stringData:
+  token: foo
+`)
+		Ω(issues).Should(BeEmpty())
+	})
+
+	ginkgov2.It("reports invalid entries", func() {
+		issues := validate(`
+apiVersion: dns.gardener.cloud/v1alpha1
+kind: DNSEntry
+metadata:
+  name: Invalid_Name
+spec:
+  dnsName: www.example.com
+  ttl: -1
+  targets:
+  - 1.2.3.4
+---
+apiVersion: dns.gardener.cloud/v1alpha1
+kind: DNSEntry
+metadata:
+  name: unknown
+spec:
+  dnsName: www.example.com
+  unknown: true
+`)
+		Ω(issues).Should(HaveLen(3))
+		Ω(issues[0].Source).Should(Equal("test.yaml[1]"))
+		Ω(issues[0].Err.Error()).Should(ContainSubstring("invalid metadata.name"))
+		Ω(issues[1].Err.Error()).Should(ContainSubstring("TTL"))
+		Ω(issues[2].Source).Should(Equal("test.yaml[2]"))
+		Ω(issues[2].Name).Should(Equal("unknown"))
+		Ω(issues[2].Err.Error()).Should(ContainSubstring("unknown field"))
+	})
+
+	ginkgov2.It("reports invalid provider types and secrets", func() {
+		issues := validate(`
+apiVersion: dns.gardener.cloud/v1alpha1
+kind: DNSProvider
+metadata:
+  name: unknown-type
+  namespace: default
+spec:
+  type: unknown-dns
+  secretRef:
+    name: test-secret
+---
+apiVersion: dns.gardener.cloud/v1alpha1
+kind: DNSProvider
+metadata:
+  name: invalid-secret
+  namespace: default
+spec:
+  type: test-dns
+  secretRef:
+    name: test-secret
+---
+apiVersion: dns.gardener.cloud/v1alpha1
+kind: DNSProvider
+metadata:
+  name: missing-secret
+  namespace: default
+spec:
+  type: test-dns
+  secretRef:
+    name: missing
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: test-secret
+  namespace: default
+stringData:
+  other: foo
+`)
+		Ω(issues).Should(HaveLen(3))
+		Ω(issues[0].Name).Should(Equal("default/unknown-type"))
+		Ω(issues[0].Warning).Should(BeFalse())
+		Ω(issues[1].Name).Should(Equal("default/invalid-secret"))
+		Ω(issues[1].Warning).Should(BeFalse())
+		Ω(issues[1].Err.Error()).Should(Equal("invalid secret default/test-secret: 'TOKEN' or 'token' required in secret"))
+		Ω(issues[2].Name).Should(Equal("default/missing-secret"))
+		Ω(issues[2].Warning).Should(BeTrue())
+	})
+})
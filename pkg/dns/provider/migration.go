@@ -0,0 +1,218 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// RoutingPolicyChecker is an optional interface of a DNSHandler to check whether the routing policy
+// and the set identifier of a record set are supported by the DNS provider.
+// Handlers not implementing this interface are assumed not to support routing policies.
+type RoutingPolicyChecker interface {
+	CheckRoutingPolicy(set *dns.DNSSet) error
+}
+
+// MigrationOptions are the options for planning the migration of the managed record sets
+// of a hosted zone to the hosted zone of another provider.
+type MigrationOptions struct {
+	// OwnerIds restricts the migration to record sets of these owner ids. All managed record sets are migrated if empty.
+	OwnerIds utils.StringSet
+	// NewOwnerId replaces the owner id of the migrated record sets if not empty.
+	NewOwnerId string
+	// Flatten merges the record sets of a weighted routing policy into a single record set without
+	// routing policy if the target does not support the routing policy.
+	Flatten bool
+	// Target is the handler of the target provider. It is used to check the support of routing policies.
+	Target DNSHandler
+}
+
+// Migration is a record set to be created at the target provider.
+type Migration struct {
+	// Sources are the migrated record sets of the source zone (more than one for flattened routing policies).
+	Sources []*dns.DNSSet
+	// Target is the record set to be created in the target zone.
+	Target *dns.DNSSet
+	// Note describes the translation of the routing policy, if any.
+	Note string
+}
+
+// MigrationPlan is the result of planning a migration.
+type MigrationPlan struct {
+	Migrations []*Migration
+	// Unchanged are the record sets already existing with the same records in the target zone.
+	Unchanged []dns.DNSSetName
+	// Skipped are the record sets which cannot be migrated with the reason.
+	Skipped map[dns.DNSSetName]string
+}
+
+// PlanMigration calculates the record sets to be created in the target zone for the managed record
+// sets of the source zone. The ownership records are migrated together with the record sets.
+// Set identifiers of routing policies are renumbered if the target requires it.
+func PlanMigration(source, target dns.DNSSets, opts MigrationOptions) *MigrationPlan {
+	plan := &MigrationPlan{Skipped: map[dns.DNSSetName]string{}}
+	groups := map[string][]*dns.DNSSet{}
+	for _, set := range source {
+		owner := set.GetOwner()
+		if owner == "" || len(opts.OwnerIds) > 0 && !opts.OwnerIds.Contains(owner) {
+			continue
+		}
+		groups[set.Name.DNSName] = append(groups[set.Name.DNSName], set)
+	}
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		sets := groups[name]
+		sort.Slice(sets, func(i, j int) bool { return sets[i].Name.SetIdentifier < sets[j].Name.SetIdentifier })
+		migrations, err := translateRoutingPolicies(sets, opts)
+		if err != nil {
+			for _, set := range sets {
+				plan.Skipped[set.Name] = err.Error()
+			}
+			continue
+		}
+		for _, m := range migrations {
+			if opts.NewOwnerId != "" {
+				m.Target.SetOwner(opts.NewOwnerId)
+			}
+			existing := target[m.Target.Name]
+			switch {
+			case existing == nil:
+				plan.Migrations = append(plan.Migrations, m)
+			case equalRecordSets(existing, m.Target):
+				plan.Unchanged = append(plan.Unchanged, m.Target.Name)
+			default:
+				for _, set := range m.Sources {
+					plan.Skipped[set.Name] = fmt.Sprintf("record set %s already exists in target zone with different records", m.Target.Name)
+				}
+			}
+		}
+	}
+	return plan
+}
+
+// translateRoutingPolicies maps the record sets of a DNS name to record sets supported by the target.
+func translateRoutingPolicies(sets []*dns.DNSSet, opts MigrationOptions) ([]*Migration, error) {
+	var result []*Migration
+	if len(sets) == 1 && sets[0].Name.SetIdentifier == "" && sets[0].RoutingPolicy == nil {
+		return append(result, &Migration{Sources: sets, Target: sets[0].Clone()}), nil
+	}
+
+	checker, _ := opts.Target.(RoutingPolicyChecker)
+	var err error
+	if checker != nil {
+		result, err = checkRoutingPolicies(checker, sets, false)
+		if err == nil {
+			return result, nil
+		}
+		if renumbered, err2 := checkRoutingPolicies(checker, sets, true); err2 == nil {
+			return renumbered, nil
+		}
+	} else {
+		err = fmt.Errorf("routing policies not supported by target")
+	}
+	if !opts.Flatten {
+		return nil, err
+	}
+	return flattenRoutingPolicies(sets)
+}
+
+func checkRoutingPolicies(checker RoutingPolicyChecker, sets []*dns.DNSSet, renumber bool) ([]*Migration, error) {
+	var result []*Migration
+	for i, set := range sets {
+		m := &Migration{Sources: []*dns.DNSSet{set}, Target: set.Clone()}
+		if renumber {
+			m.Target.Name.SetIdentifier = strconv.Itoa(i)
+			m.Note = fmt.Sprintf("set identifier %s renumbered to %d", set.Name.SetIdentifier, i)
+		}
+		if err := checker.CheckRoutingPolicy(m.Target); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// flattenRoutingPolicies merges the records of the weighted record sets with a weight greater than zero.
+func flattenRoutingPolicies(sets []*dns.DNSSet) ([]*Migration, error) {
+	target := dns.NewDNSSet(dns.DNSSetName{DNSName: sets[0].Name.DNSName}, nil)
+	target.Kind = sets[0].Kind
+	for _, set := range sets {
+		if set.RoutingPolicy == nil || set.RoutingPolicy.Type != dns.RoutingPolicyWeighted {
+			return nil, fmt.Errorf("only weighted routing policies can be flattened")
+		}
+		if weight, err := strconv.ParseInt(set.RoutingPolicy.Parameters["weight"], 10, 64); err != nil || weight == 0 {
+			continue
+		}
+		for ty, rs := range set.Sets {
+			if ty == dns.RS_META {
+				if target.Sets[ty] == nil {
+					target.Sets[ty] = rs.Clone()
+				}
+				continue
+			}
+			if cur := target.Sets[ty]; cur != nil {
+				if rs.TTL < cur.TTL {
+					cur.TTL = rs.TTL
+				}
+				for _, r := range rs.Records {
+					if !containsRecord(cur, r.Value) {
+						cur.Add(r.Clone())
+					}
+				}
+			} else {
+				target.Sets[ty] = rs.Clone()
+			}
+		}
+	}
+	if len(target.Sets) == 0 || len(target.Sets) == 1 && target.Sets[dns.RS_META] != nil {
+		return nil, fmt.Errorf("no record set with weight greater than zero")
+	}
+	return []*Migration{{Sources: sets, Target: target, Note: fmt.Sprintf("%d weighted record sets flattened", len(sets))}}, nil
+}
+
+func containsRecord(rs *dns.RecordSet, value string) bool {
+	for _, r := range rs.Records {
+		if r.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func equalRecordSets(a, b *dns.DNSSet) bool {
+	if len(a.Sets) != len(b.Sets) || !reflect.DeepEqual(a.RoutingPolicy, b.RoutingPolicy) {
+		return false
+	}
+	for ty, rs := range a.Sets {
+		if other := b.Sets[ty]; other == nil || !rs.Match(other) {
+			return false
+		}
+	}
+	return true
+}
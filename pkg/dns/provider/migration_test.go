@@ -0,0 +1,108 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+type indexRoutingPolicyHandler struct {
+	DNSHandler
+}
+
+func (h *indexRoutingPolicyHandler) CheckRoutingPolicy(set *dns.DNSSet) error {
+	if _, err := strconv.Atoi(set.Name.SetIdentifier); err != nil {
+		return fmt.Errorf("set identifier must be a number")
+	}
+	return nil
+}
+
+var _ = ginkgov2.Describe("Record migration", func() {
+	newSet := func(name, id, owner string, policy *dns.RoutingPolicy, values ...string) *dns.DNSSet {
+		set := dns.NewDNSSet(dns.DNSSetName{DNSName: name, SetIdentifier: id}, policy)
+		set.SetRecordSet(dns.RS_A, 300, values...)
+		if owner != "" {
+			set.SetOwner(owner)
+		}
+		return set
+	}
+	weighted := func(weight string) *dns.RoutingPolicy {
+		return dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, "weight", weight)
+	}
+	sets := func(list ...*dns.DNSSet) dns.DNSSets {
+		result := dns.DNSSets{}
+		for _, s := range list {
+			result[s.Name] = s
+		}
+		return result
+	}
+
+	ginkgov2.It("migrates the managed record sets of the selected owners", func() {
+		source := sets(
+			newSet("a.example.com", "", "owner1", nil, "1.1.1.1"),
+			newSet("b.example.com", "", "owner2", nil, "2.2.2.2"),
+			newSet("c.example.com", "", "", nil, "3.3.3.3"),
+			newSet("d.example.com", "", "owner1", nil, "4.4.4.4"),
+			newSet("e.example.com", "", "owner1", nil, "5.5.5.5"),
+		)
+		target := sets(
+			newSet("d.example.com", "", "new", nil, "4.4.4.4"),
+			newSet("e.example.com", "", "", nil, "9.9.9.9"),
+		)
+		plan := PlanMigration(source, target, MigrationOptions{OwnerIds: utils.NewStringSet("owner1"), NewOwnerId: "new"})
+		Ω(plan.Migrations).Should(HaveLen(1))
+		Ω(plan.Migrations[0].Target.Name.DNSName).Should(Equal("a.example.com"))
+		Ω(plan.Migrations[0].Target.GetOwner()).Should(Equal("new"))
+		Ω(source[plan.Migrations[0].Target.Name].GetOwner()).Should(Equal("owner1"))
+		Ω(plan.Unchanged).Should(Equal([]dns.DNSSetName{{DNSName: "d.example.com"}}))
+		Ω(plan.Skipped).Should(HaveKey(dns.DNSSetName{DNSName: "e.example.com"}))
+	})
+
+	ginkgov2.It("translates routing policies", func() {
+		source := sets(
+			newSet("w.example.com", "blue", "owner", weighted("1"), "1.1.1.1"),
+			newSet("w.example.com", "green", "owner", weighted("0"), "2.2.2.2"),
+		)
+
+		plan := PlanMigration(source, dns.DNSSets{}, MigrationOptions{})
+		Ω(plan.Migrations).Should(BeEmpty())
+		Ω(plan.Skipped).Should(HaveLen(2))
+
+		plan = PlanMigration(source, dns.DNSSets{}, MigrationOptions{Target: &indexRoutingPolicyHandler{}})
+		Ω(plan.Migrations).Should(HaveLen(2))
+		Ω(plan.Migrations[0].Target.Name.SetIdentifier).Should(Equal("0"))
+		Ω(plan.Migrations[0].Sources[0].Name.SetIdentifier).Should(Equal("blue"))
+		Ω(plan.Migrations[1].Target.Name.SetIdentifier).Should(Equal("1"))
+		Ω(plan.Migrations[1].Target.RoutingPolicy).Should(Equal(weighted("0")))
+
+		plan = PlanMigration(source, dns.DNSSets{}, MigrationOptions{Flatten: true})
+		Ω(plan.Migrations).Should(HaveLen(1))
+		m := plan.Migrations[0]
+		Ω(m.Sources).Should(HaveLen(2))
+		Ω(m.Target.Name).Should(Equal(dns.DNSSetName{DNSName: "w.example.com"}))
+		Ω(m.Target.RoutingPolicy).Should(BeNil())
+		Ω(m.Target.Sets[dns.RS_A].RecordString()).Should(Equal("[1.1.1.1]"))
+		Ω(m.Target.GetOwner()).Should(Equal("owner"))
+	})
+})
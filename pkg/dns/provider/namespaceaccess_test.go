@@ -0,0 +1,158 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+type accessObject struct {
+	resources.Object
+	data resources.ObjectData
+	kind string
+}
+
+func (this *accessObject) Data() resources.ObjectData {
+	return this.data
+}
+
+func (this *accessObject) GetNamespace() string {
+	return this.data.GetNamespace()
+}
+
+func (this *accessObject) GetAnnotations() map[string]string {
+	return this.data.GetAnnotations()
+}
+
+func (this *accessObject) GetOwners(kinds ...schema.GroupKind) resources.ClusterObjectKeySet {
+	return nil
+}
+
+func (this *accessObject) ObjectName() resources.ObjectName {
+	return resources.NewObjectName(this.data.GetNamespace(), this.data.GetName())
+}
+
+func (this *accessObject) ClusterKey() resources.ClusterObjectKey {
+	return resources.NewClusterKey("default", resources.NewGroupKind(api.GroupName, this.kind), this.data.GetNamespace(), this.data.GetName())
+}
+
+var _ = ginkgov2.Describe("Namespace access of providers", func() {
+	var s *state
+
+	newProvider := func(allowed []string, selector *metav1.LabelSelector) *dnsProviderVersion {
+		provider := &api.DNSProvider{}
+		provider.Namespace = "dns"
+		provider.Name = "p1"
+		provider.Spec.AllowedNamespaces = allowed
+		provider.Spec.AllowedNamespaceSelector = selector
+		p := &dnsProviderVersion{
+			object:   &dnsutils.DNSProviderObject{Object: &accessObject{data: provider, kind: api.DNSProviderKind}},
+			valid:    true,
+			included: utils.NewStringSet("example.com"),
+			excluded: utils.StringSet{},
+		}
+		if selector != nil {
+			var err error
+			p.namespaceSelector, err = metav1.LabelSelectorAsSelector(selector)
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+		s.providers = map[resources.ObjectName]*dnsProviderVersion{p.ObjectName(): p}
+		return p
+	}
+	newEntry := func(namespace string, pinned *string) dnsutils.DNSSpecification {
+		entry := &api.DNSEntry{}
+		entry.Namespace = namespace
+		entry.Name = "e1"
+		entry.Spec.DNSName = "www.example.com"
+		entry.Spec.Provider = pinned
+		return &dnsutils.DNSEntryObject{Object: &accessObject{data: entry, kind: api.DNSEntryKind}}
+	}
+	pinned := "dns/p1"
+
+	ginkgov2.BeforeEach(func() {
+		s = &state{
+			classes: controller.NewClasses(nil, "", dns.CLASS_ANNOTATION, dns.DEFAULT_CLASS),
+			namespaceLabels: func(name string) (map[string]string, error) {
+				switch name {
+				case "team-a":
+					return map[string]string{"dns": "allowed"}, nil
+				case "team-b":
+					return map[string]string{"dns": "denied"}, nil
+				}
+				return nil, fmt.Errorf("namespace %s not found", name)
+			},
+		}
+	})
+
+	ginkgov2.It("allows all namespaces without restriction", func() {
+		p := newProvider(nil, nil)
+		for _, ref := range []*string{nil, &pinned} {
+			found, _, err := s.lookupProvider(newEntry("team-b", ref))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found).Should(BeIdenticalTo(p))
+		}
+	})
+
+	ginkgov2.It("allows the own and the listed namespaces", func() {
+		p := newProvider([]string{"team-a"}, nil)
+		for _, ns := range []string{"dns", "team-a"} {
+			for _, ref := range []*string{nil, &pinned} {
+				found, _, err := s.lookupProvider(newEntry(ns, ref))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(found).Should(BeIdenticalTo(p))
+			}
+		}
+	})
+
+	ginkgov2.It("denies other namespaces", func() {
+		newProvider([]string{}, nil)
+		for _, ref := range []*string{nil, &pinned} {
+			found, _, err := s.lookupProvider(newEntry("team-a", ref))
+			Ω(err).Should(MatchError("provider dns/p1 is not allowed for namespace team-a"))
+			Ω(found).Should(BeNil())
+		}
+	})
+
+	ginkgov2.It("allows namespaces matching the namespace selector", func() {
+		p := newProvider([]string{"team-c"}, &metav1.LabelSelector{MatchLabels: map[string]string{"dns": "allowed"}})
+		for _, ns := range []string{"dns", "team-a", "team-c"} {
+			for _, ref := range []*string{nil, &pinned} {
+				found, _, err := s.lookupProvider(newEntry(ns, ref))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(found).Should(BeIdenticalTo(p))
+			}
+		}
+		for _, ref := range []*string{nil, &pinned} {
+			_, _, err := s.lookupProvider(newEntry("team-b", ref))
+			Ω(err).Should(MatchError("provider dns/p1 is not allowed for namespace team-b"))
+			_, _, err = s.lookupProvider(newEntry("team-d", ref))
+			Ω(err).Should(MatchError("cannot check namespace team-d for provider dns/p1: namespace team-d not found"))
+		}
+	})
+})
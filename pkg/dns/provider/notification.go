@@ -0,0 +1,119 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/notification"
+)
+
+func createNotifier(c controller.Interface) (*notification.Notifier, error) {
+	webhooks, _ := c.GetStringOption(OPT_NOTIFICATION_WEBHOOKS)
+	slackWebhooks, _ := c.GetStringOption(OPT_NOTIFICATION_SLACK_WEBHOOKS)
+	events, _ := c.GetStringOption(OPT_NOTIFICATION_EVENTS)
+	return notification.NewNotifier(&notification.Config{
+		Webhooks:      splitList(webhooks),
+		SlackWebhooks: splitList(slackWebhooks),
+		Events:        splitList(events),
+	})
+}
+
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// notifyEntry sends a notification about the lifecycle of an entry.
+func (this *Entry) notifyEntry(eventType, message string) {
+	if this.state == nil {
+		return
+	}
+	this.state.config.Notifier.Notify(&notification.Event{
+		Type:         eventType,
+		Controller:   this.state.config.Ident,
+		ProviderType: this.ProviderType(),
+		Provider:     utils.StringValue(this.status.Provider),
+		Zone:         utils.StringValue(this.status.Zone),
+		DNSName:      this.DNSName(),
+		Object:       this.Kind() + " " + this.ObjectName().String(),
+		Message:      message,
+	})
+}
+
+// notifyRequests sends a notification for every DNS name whose record sets have been deleted.
+func (this *ChangeGroup) notifyRequests(reqs []*ChangeRequest) {
+	notifier := this.model.config.Notifier
+	if notifier == nil {
+		return
+	}
+	deleted := map[dns.DNSSetName][]string{}
+	var names []dns.DNSSetName
+	objects := map[dns.DNSSetName]string{}
+	for _, req := range reqs {
+		if !req.Applied || req.Action != R_DELETE || req.Deletion == nil {
+			continue
+		}
+		name := req.Deletion.Name
+		if deleted[name] == nil {
+			names = append(names, name)
+			objects[name] = requestingObject(req.Done)
+		}
+		deleted[name] = append(deleted[name], req.Type)
+	}
+	for _, name := range names {
+		event := &notification.Event{
+			Type:       notification.EventRecordDeleted,
+			Controller: this.model.config.Ident,
+			Zone:       this.model.ZoneId().ID,
+			DNSName:    name.DNSName,
+			Object:     objects[name],
+			Message:    "deleted record sets " + strings.Join(deleted[name], ", "),
+		}
+		if this.provider != nil {
+			event.ProviderType = this.provider.TypeCode()
+			event.Provider = this.provider.ObjectName().String()
+		}
+		notifier.Notify(event)
+	}
+}
+
+// notifyZoneUnreachable sends a notification if the state of a hosted zone cannot be read.
+func (this *state) notifyZoneUnreachable(req *zoneReconciliation, err error) {
+	event := &notification.Event{
+		Type:         notification.EventZoneUnreachable,
+		Controller:   this.config.Ident,
+		ProviderType: req.zone.Id().ProviderType,
+		Zone:         req.zone.Id().ID,
+		DNSName:      req.zone.Domain(),
+		Message:      err.Error(),
+	}
+	for _, p := range req.providers {
+		event.Provider = p.ObjectName().String()
+		break
+	}
+	this.config.Notifier.Notify(event)
+}
@@ -0,0 +1,249 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+const (
+	// EventEntryReady is sent if an entry becomes ready
+	EventEntryReady = "entry_ready"
+	// EventEntryFailed is sent if the records of an entry cannot be provisioned
+	EventEntryFailed = "entry_failed"
+	// EventRecordDeleted is sent if the record sets of a DNS name are deleted at the provider
+	EventRecordDeleted = "record_deleted"
+	// EventZoneUnreachable is sent if the state of a hosted zone cannot be read from the provider
+	EventZoneUnreachable = "zone_unreachable"
+)
+
+// Events are all supported event types.
+var Events = []string{EventEntryReady, EventEntryFailed, EventRecordDeleted, EventZoneUnreachable}
+
+// DefaultQueueSize is the default number of queued events, further events are dropped.
+const DefaultQueueSize = 1000
+
+// Event is a notification about the lifecycle of DNS entries, record sets, and hosted zones.
+type Event struct {
+	// Time of the event
+	Time time.Time `json:"time"`
+	// Type is the event type
+	Type string `json:"type"`
+	// Controller is the identifier of the DNS controller
+	Controller string `json:"controller,omitempty"`
+	// ProviderType is the type of the DNS provider
+	ProviderType string `json:"providerType,omitempty"`
+	// Provider is the DNSProvider object (namespace/name)
+	Provider string `json:"provider,omitempty"`
+	// Zone is the id of the hosted zone
+	Zone string `json:"zone,omitempty"`
+	// DNSName is the domain name of the entry or record set
+	DNSName string `json:"dnsName,omitempty"`
+	// Object is the entry object (kind namespace/name), if known
+	Object string `json:"object,omitempty"`
+	// Message describes the event
+	Message string `json:"message,omitempty"`
+}
+
+// Sink is the destination of the notifications.
+type Sink interface {
+	Send(event *Event) error
+}
+
+// Config is the configuration of the notifications.
+type Config struct {
+	// Webhooks are the URLs of generic webhooks receiving the events as JSON
+	Webhooks []string
+	// SlackWebhooks are the URLs of Slack incoming webhooks
+	SlackWebhooks []string
+	// Events are the event types to send (all if empty)
+	Events []string
+	// QueueSize is the maximum number of queued events (DefaultQueueSize if 0)
+	QueueSize int
+}
+
+// Notifier sends events asynchronously to all sinks.
+// Events are dropped if the queue is full, so that the reconciliations are never blocked.
+type Notifier struct {
+	sinks  []Sink
+	events map[string]bool
+	queue  chan *Event
+}
+
+// NewNotifier creates a notifier for the given configuration.
+// It returns nil if no webhook is configured.
+func NewNotifier(config *Config) (*Notifier, error) {
+	var sinks []Sink
+	for _, url := range config.Webhooks {
+		sinks = append(sinks, NewWebhookSink(url))
+	}
+	for _, url := range config.SlackWebhooks {
+		sinks = append(sinks, NewSlackSink(url))
+	}
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+	events := map[string]bool{}
+	for _, e := range config.Events {
+		if !isEvent(e) {
+			return nil, fmt.Errorf("invalid notification event %q (supported: %v)", e, Events)
+		}
+		events[e] = true
+	}
+	if len(events) == 0 {
+		for _, e := range Events {
+			events[e] = true
+		}
+	}
+	size := config.QueueSize
+	if size <= 0 {
+		size = DefaultQueueSize
+	}
+	return NewNotifierForSinks(sinks, events, size), nil
+}
+
+// NewNotifierForSinks creates a notifier for the given sinks and event types.
+func NewNotifierForSinks(sinks []Sink, events map[string]bool, size int) *Notifier {
+	return &Notifier{sinks: sinks, events: events, queue: make(chan *Event, size)}
+}
+
+func isEvent(e string) bool {
+	for _, t := range Events {
+		if t == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Start sends the queued events until the context is done.
+func (this *Notifier) Start(ctx context.Context, logger logger.LogContext) {
+	if this == nil {
+		return
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-this.queue:
+				this.send(logger, event)
+			}
+		}
+	}()
+}
+
+func (this *Notifier) send(logger logger.LogContext, event *Event) {
+	for _, sink := range this.sinks {
+		if err := sink.Send(event); err != nil {
+			metrics.AddNotifications(event.Type, metrics.NOTIFICATION_FAILED)
+			logger.Warnf("cannot send notification %s for %s: %s", event.Type, event.DNSName, err)
+			continue
+		}
+		metrics.AddNotifications(event.Type, metrics.NOTIFICATION_SENT)
+	}
+}
+
+// Notify queues an event if its type is selected.
+func (this *Notifier) Notify(event *Event) {
+	if this == nil || !this.events[event.Type] {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	select {
+	case this.queue <- event:
+	default:
+		metrics.AddNotifications(event.Type, metrics.NOTIFICATION_DROPPED)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type webhookSink struct {
+	client *http.Client
+	url    string
+	body   func(event *Event) interface{}
+}
+
+// NewWebhookSink creates a sink posting every event as JSON to the given URL.
+func NewWebhookSink(url string) Sink {
+	return &webhookSink{
+		client: &http.Client{Timeout: 10 * time.Second},
+		url:    url,
+		body:   func(event *Event) interface{} { return event },
+	}
+}
+
+// NewSlackSink creates a sink posting every event as message to a Slack incoming webhook.
+func NewSlackSink(url string) Sink {
+	return &webhookSink{
+		client: &http.Client{Timeout: 10 * time.Second},
+		url:    url,
+		body:   func(event *Event) interface{} { return &slackMessage{Text: SlackText(event)} },
+	}
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// SlackText formats an event as text of a Slack message.
+func SlackText(event *Event) string {
+	text := fmt.Sprintf("*%s*", event.Type)
+	if event.Object != "" {
+		text += " " + event.Object
+	}
+	if event.DNSName != "" {
+		text += fmt.Sprintf(" `%s`", event.DNSName)
+	}
+	if event.Zone != "" {
+		text += fmt.Sprintf(" (zone %s)", event.Zone)
+	}
+	if event.Message != "" {
+		text += ": " + event.Message
+	}
+	return text
+}
+
+func (this *webhookSink) Send(event *Event) error {
+	data, err := json.Marshal(this.body(event))
+	if err != nil {
+		return err
+	}
+	resp, err := this.client.Post(this.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
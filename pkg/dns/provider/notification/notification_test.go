@@ -0,0 +1,111 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/external-dns-management/pkg/dns/provider/notification"
+)
+
+type channelSink struct {
+	events chan *Event
+}
+
+func (this *channelSink) Send(event *Event) error {
+	this.events <- event
+	return nil
+}
+
+var _ = ginkgov2.Describe("Notifications", func() {
+	ginkgov2.It("is disabled without webhooks", func() {
+		notifier, err := NewNotifier(&Config{Events: []string{EventEntryReady}})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(notifier).Should(BeNil())
+		notifier.Notify(&Event{Type: EventEntryReady})
+	})
+
+	ginkgov2.It("rejects unknown events", func() {
+		_, err := NewNotifier(&Config{Webhooks: []string{"http://localhost"}, Events: []string{"foo"}})
+		Ω(err).Should(MatchError(ContainSubstring(`invalid notification event "foo"`)))
+	})
+
+	ginkgov2.It("sends the selected events", func() {
+		sink := &channelSink{events: make(chan *Event, 10)}
+		notifier := NewNotifierForSinks([]Sink{sink}, map[string]bool{EventEntryFailed: true}, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		notifier.Start(ctx, logger.New())
+
+		notifier.Notify(&Event{Type: EventEntryReady, DNSName: "a.example.com"})
+		notifier.Notify(&Event{Type: EventEntryFailed, DNSName: "b.example.com"})
+		var event *Event
+		Eventually(sink.events).Should(Receive(&event))
+		Ω(event.DNSName).Should(Equal("b.example.com"))
+		Ω(event.Time.IsZero()).Should(BeFalse())
+		Consistently(sink.events).ShouldNot(Receive())
+	})
+
+	ginkgov2.It("drops events if the queue is full", func() {
+		sink := &channelSink{events: make(chan *Event, 10)}
+		notifier := NewNotifierForSinks([]Sink{sink}, map[string]bool{EventEntryReady: true}, 1)
+		notifier.Notify(&Event{Type: EventEntryReady, DNSName: "a.example.com"})
+		notifier.Notify(&Event{Type: EventEntryReady, DNSName: "b.example.com"})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		notifier.Start(ctx, logger.New())
+		var event *Event
+		Eventually(sink.events).Should(Receive(&event))
+		Ω(event.DNSName).Should(Equal("a.example.com"))
+		Consistently(sink.events).ShouldNot(Receive())
+	})
+
+	ginkgov2.It("posts events to webhooks", func() {
+		bodies := make(chan map[string]interface{}, 2)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			body := map[string]interface{}{}
+			Ω(json.Unmarshal(data, &body)).Should(Succeed())
+			bodies <- body
+		}))
+		defer server.Close()
+
+		event := &Event{Type: EventZoneUnreachable, Zone: "z1", DNSName: "example.com", Message: "access denied"}
+		Ω(NewWebhookSink(server.URL).Send(event)).Should(Succeed())
+		Ω(<-bodies).Should(HaveKeyWithValue("type", EventZoneUnreachable))
+
+		Ω(NewSlackSink(server.URL).Send(event)).Should(Succeed())
+		Ω(<-bodies).Should(Equal(map[string]interface{}{"text": "*zone_unreachable* `example.com` (zone z1): access denied"}))
+	})
+
+	ginkgov2.It("fails for unsuccessful responses", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+		Ω(NewWebhookSink(server.URL).Send(&Event{Type: EventEntryReady})).Should(MatchError("webhook responded with status 400"))
+	})
+})
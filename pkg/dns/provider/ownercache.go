@@ -18,7 +18,9 @@ package provider
 
 import (
 	"context"
+	"reflect"
 	"sync"
+	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
 	"github.com/gardener/controller-manager-library/pkg/logger"
@@ -39,12 +41,37 @@ type ProviderCacheContext interface {
 	Infof(msg string, args ...interface{})
 }
 
+// OWNER_CLEANUP_PERIOD is the period after the deactivation of an owner id, in which
+// its records are cleaned up according to the deactivation policy of the owner.
+// The hosted zones are triggered immediately on deactivation, the limited period
+// avoids touching records of other clusters activating the owner id later on.
+// Owner ids still used by another owner or a DNS lock, or handed over with a
+// validity window, are never cleaned up.
+const OWNER_CLEANUP_PERIOD = 30 * time.Minute
+
 type ProviderTypeCounts map[string]int
-type OwnerCounts map[OwnerName]ProviderTypeCounts
+type OwnerCounts map[OwnerName]*OwnerEntryCounts
+
+// OwnerEntryCounts is the entry statistic of an owner id reported in the status of its owner objects.
+type OwnerEntryCounts struct {
+	ByType     ProviderTypeCounts
+	ByProvider map[string]int
+	ByZone     map[string]int
+	LastChange time.Time
+}
 
 type OwnerObjectInfo struct {
 	active bool
 	id     string
+	policy *api.DeletionPolicy
+	// scheduled is set for owners with a validity window or a DNS activation used for a handover
+	scheduled bool
+}
+
+// retiredOwnerID describes a deactivated owner id whose records are cleaned up.
+type retiredOwnerID struct {
+	policy api.DeletionPolicy
+	until  time.Time
 }
 
 type OwnerName string
@@ -64,8 +91,13 @@ func (this *OwnerDNSActivation) IsActive() bool {
 type OwnerDNSActivations map[resources.ClusterObjectKey]*OwnerDNSActivation
 
 type OwnerIDInfo struct {
-	refcount    int
-	entrycounts map[string]int
+	refcount       int
+	entrycounts    map[string]int
+	providercounts map[string]int
+	zonecounts     map[string]int
+	lastchange     time.Time
+	reportedchange time.Time
+	reported       bool
 }
 type OwnerIDInfos map[string]OwnerIDInfo
 
@@ -86,6 +118,8 @@ type OwnerCache struct {
 
 	ownerids   OwnerIDInfos
 	pendingids utils.StringSet
+	retiredids map[string]retiredOwnerID
+	lockids    map[resources.ObjectName]string
 
 	schedule *dnsutils.Schedule
 }
@@ -99,6 +133,8 @@ func NewOwnerCache(ctx ProviderCacheContext, config *Config) *OwnerCache {
 		ownerids:       OwnerIDInfos{config.Ident: {refcount: 1, entrycounts: ProviderTypeCounts{}}},
 		dnsactivations: OwnerDNSActivations{},
 		pendingids:     utils.StringSet{},
+		retiredids:     map[string]retiredOwnerID{},
+		lockids:        map[resources.ObjectName]string{},
 	}
 	this.schedule = dnsutils.NewSchedule(ctx.GetContext(), dnsutils.ScheduleExecutorFunction(this.expire))
 	return this
@@ -125,7 +161,7 @@ func (this *OwnerCache) TriggerDNSActivation(logger logger.LogContext, cntr cont
 
 func (this *OwnerCache) expire(key dnsutils.ScheduleKey) {
 	id := key.(resources.ClusterObjectKey)
-	this.ctx.Infof("owner %s expired", id.Name())
+	this.ctx.Infof("validity of owner %s changed", id.Name())
 	this.ctx.EnqueueKey(id)
 }
 
@@ -141,31 +177,111 @@ func (this *OwnerCache) IsResponsiblePendingFor(id string) bool {
 	return this.pendingids.Contains(id)
 }
 
+// RetiredPolicy returns the deactivation policy for the records of a
+// recently deactivated owner id, or nil if the records should not be touched.
+func (this *OwnerCache) RetiredPolicy(id string) *api.DeletionPolicy {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	if r, ok := this.retiredids[id]; ok && time.Now().Before(r.until) && !this.inUse(id) {
+		return &r.policy
+	}
+	return nil
+}
+
+// HasRetiredIds returns true if the records of recently deactivated owner ids are still to be cleaned up.
+func (this *OwnerCache) HasRetiredIds() bool {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	now := time.Now()
+	for id, r := range this.retiredids {
+		if now.Before(r.until) && !this.inUse(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateLock records the owner id used by a DNS lock. Owner ids of active locks
+// are never cleaned up.
+func (this *OwnerCache) UpdateLock(name resources.ObjectName, id string, active bool) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if active && id != "" {
+		this.lockids[name] = id
+	} else {
+		delete(this.lockids, name)
+	}
+}
+
+// DeleteLock forgets the owner id of a deleted DNS lock.
+func (this *OwnerCache) DeleteLock(name resources.ObjectName) {
+	this.UpdateLock(name, "", false)
+}
+
+// inUse checks whether an owner id is still used by an owner or a DNS lock,
+// or is handed over to or from another cluster with a validity window or a DNS activation.
+func (this *OwnerCache) inUse(id string) bool {
+	if this.ownerids.Contains(id) || this.pendingids.Contains(id) {
+		return true
+	}
+	for _, o := range this.owners {
+		if o.id == id && (o.active || o.scheduled) {
+			return true
+		}
+	}
+	for _, l := range this.lockids {
+		if l == id {
+			return true
+		}
+	}
+	return false
+}
+
 func (this *OwnerCache) GetIds() utils.StringSet {
 	this.lock.RLock()
 	defer this.lock.RUnlock()
 	return this.ownerids.KeySet()
 }
 
-func (this *OwnerCache) UpdateCountsWith(statistic statistic.OwnerStatistic, types utils.StringSet) OwnerCounts {
+func (this *OwnerCache) UpdateCountsWith(statistic *statistic.EntryStatistic, types utils.StringSet) OwnerCounts {
 	changed := OwnerCounts{}
 	this.lock.Lock()
 	defer this.lock.Unlock()
 	for id, e := range this.ownerids {
-		mod := false
-		pts := statistic.Get(id)
+		mod := !e.reported || e.lastchange != e.reportedchange
+		pts := statistic.Owners.Get(id)
+		providers := map[string]int{}
 		for t := range types {
 			c := 0
 			if v, ok := pts[t]; ok {
 				c = v.Count()
+				for pname, n := range v {
+					providers[pname.String()] += n
+				}
 			}
-			mod = mod || this.checkCount(&e, t, c)
+			mod = this.checkCount(&e, t, c) || mod
+		}
+		zones := map[string]int{}
+		for zoneid, n := range statistic.Zones.Get(id) {
+			zones[zoneid] = n
+		}
+		if !reflect.DeepEqual(e.providercounts, providers) || !reflect.DeepEqual(e.zonecounts, zones) {
+			e.providercounts = providers
+			e.zonecounts = zones
+			mod = true
 		}
 		if mod {
+			e.reported = true
+			e.reportedchange = e.lastchange
 			this.ownerids[id] = e
 			for n, o := range this.owners {
 				if o.id == id {
-					changed[n] = e.entrycounts
+					changed[n] = &OwnerEntryCounts{
+						ByType:     e.entrycounts,
+						ByProvider: e.providercounts,
+						ByZone:     e.zonecounts,
+						LastChange: e.lastchange,
+					}
 				}
 			}
 		}
@@ -173,6 +289,16 @@ func (this *OwnerCache) UpdateCountsWith(statistic statistic.OwnerStatistic, typ
 	return changed
 }
 
+// ChangeApplied records the time of a change applied to a record set of an owner id.
+func (this *OwnerCache) ChangeApplied(id string, t time.Time) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if e, ok := this.ownerids[id]; ok {
+		e.lastchange = t
+		this.ownerids[id] = e
+	}
+}
+
 func (this *OwnerCache) checkCount(e *OwnerIDInfo, ptype string, count int) bool {
 	if e.entrycounts[ptype] != count {
 		e.entrycounts[ptype] = count
@@ -190,18 +316,33 @@ func (this *OwnerCache) UpdateOwner(owner *dnsutils.DNSOwnerObject) (changeset u
 	} else {
 		delete(this.dnsactivations, owner.ClusterKey())
 	}
-	return this._updateOwnerData(OwnerName(owner.GetName()), owner.ClusterKey(), owner.GetOwnerId(), active, owner.GetCounts(), owner.ValidUntil())
+	cachekey := OwnerName(owner.GetName())
+	changeset, activeset = this._updateOwnerData(cachekey, owner.ClusterKey(), owner.GetOwnerId(), active, owner.GetCounts(), owner.NextTransition(time.Now()))
+	info := this.owners[cachekey]
+	info.policy = owner.GetDeactivationPolicy()
+	info.scheduled = owner.IsHandover()
+	this.owners[cachekey] = info
+	this.retire(changeset, info)
+	return changeset, activeset
 }
 
-func (this *OwnerCache) updateOwnerData(cachekey OwnerName, key dnsutils.ScheduleKey, id string, active bool, counts ProviderTypeCounts, valid *metav1.Time) (changeset utils.StringSet, activeset utils.StringSet) {
+func (this *OwnerCache) updateOwnerData(cachekey OwnerName, key dnsutils.ScheduleKey, id string, active bool, counts ProviderTypeCounts, next *metav1.Time) (changeset utils.StringSet, activeset utils.StringSet) {
 	this.lock.Lock()
 	defer this.lock.Unlock()
-	return this._updateOwnerData(cachekey, key, id, active, counts, valid)
+	return this._updateOwnerData(cachekey, key, id, active, counts, next)
 }
 
-func (this *OwnerCache) _updateOwnerData(cachekey OwnerName, key dnsutils.ScheduleKey, id string, active bool, counts ProviderTypeCounts, valid *metav1.Time) (changeset utils.StringSet, activeset utils.StringSet) {
+func (this *OwnerCache) _updateOwnerData(cachekey OwnerName, key dnsutils.ScheduleKey, id string, active bool, counts ProviderTypeCounts, next *metav1.Time) (changeset utils.StringSet, activeset utils.StringSet) {
 	changeset = utils.StringSet{}
 
+	if key != nil {
+		// re-evaluate the owner on the next activation or expiration
+		if next != nil {
+			this.schedule.Schedule(key, next.Time)
+		} else {
+			this.schedule.Delete(key)
+		}
+	}
 	old, ok := this.owners[cachekey]
 	if ok {
 		if old.id == id && old.active == active {
@@ -209,13 +350,6 @@ func (this *OwnerCache) _updateOwnerData(cachekey OwnerName, key dnsutils.Schedu
 		}
 		this.deactivate(cachekey, old, changeset)
 	}
-	if key != nil {
-		if active && valid != nil {
-			this.schedule.Schedule(key, (*valid).Time)
-		} else {
-			this.schedule.Delete(key)
-		}
-	}
 	this.activate(cachekey, id, active, changeset, counts)
 	return changeset, this.ownerids.KeySet()
 }
@@ -230,10 +364,32 @@ func (this *OwnerCache) DeleteOwner(key resources.ClusterObjectKey) (changeset u
 	if ok {
 		this.schedule.Delete(key)
 		this.deactivate(cachekey, old, changeset)
+		this.retire(changeset, old)
 	}
 	return changeset, this.ownerids.KeySet()
 }
 
+// retire remembers the deactivated owner ids of a changeset for the cleanup of
+// their records according to the deactivation policy of the given owner.
+// Owners with a validity window hand over their records, so they are kept.
+func (this *OwnerCache) retire(changeset utils.StringSet, owner OwnerObjectInfo) {
+	now := time.Now()
+	for id, r := range this.retiredids {
+		if !now.Before(r.until) {
+			delete(this.retiredids, id)
+		}
+	}
+	if owner.policy == nil || owner.scheduled {
+		return
+	}
+	for id := range changeset {
+		if !this.inUse(id) {
+			this.ctx.Infof("cleaning up records of deactivated owner id %s (%s)", id, *owner.policy)
+			this.retiredids[id] = retiredOwnerID{policy: *owner.policy, until: now.Add(OWNER_CLEANUP_PERIOD)}
+		}
+	}
+}
+
 func (this *OwnerCache) deactivate(cachekey OwnerName, old OwnerObjectInfo, changeset utils.StringSet) {
 	this.pendingids.Remove(old.id)
 	if old.active {
@@ -262,6 +418,7 @@ func (this *OwnerCache) activate(cachekey OwnerName, id string, active bool, cha
 		this.ownerids[id] = e
 		if e.refcount == 1 {
 			changeset.Add(id)
+			delete(this.retiredids, id)
 		}
 	}
 	this.owners[cachekey] = OwnerObjectInfo{id: id, active: active}
@@ -27,6 +27,10 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/statistic"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 )
 
 const ident = "TEST"
@@ -39,6 +43,15 @@ type owner struct {
 	valid  *metav1.Time
 	active bool
 }
+type ownerData struct {
+	resources.Object
+	owner *api.DNSOwner
+}
+
+func (this *ownerData) Data() resources.ObjectData {
+	return this.owner
+}
+
 type TestOwnerCacheContext struct {
 	ids map[OwnerName]owner
 	*OwnerCache
@@ -54,7 +67,11 @@ func (this *TestOwnerCacheContext) EnqueueKey(key resources.ClusterObjectKey) er
 	old, ok := this.ids[cachekey]
 	if ok {
 		active := old.active && old.valid.After(time.Now())
-		this.OwnerCache.updateOwnerData(cachekey, key, old.id, active, nil, old.valid)
+		var next *metav1.Time
+		if active {
+			next = old.valid
+		}
+		this.OwnerCache.updateOwnerData(cachekey, key, old.id, active, nil, next)
 	}
 	return nil
 }
@@ -242,4 +259,151 @@ var _ = ginkgov2.Describe("Owner cache", func() {
 		Expect(cache.GetIds()).To(Equal(utils.NewStringSet(ident, "id1", "id2")))
 	})
 
+	ginkgov2.It("retires deactivated owner ids with deactivation policy", func() {
+		policy := api.DeletionPolicyDelete
+		setPolicy := func(name OwnerName) {
+			info := cache.owners[name]
+			info.policy = &policy
+			cache.owners[name] = info
+		}
+		cache.updateOwnerData(name1, "id1", true)
+		cache.updateOwnerData(name2, "id1", true)
+		setPolicy(name1)
+		setPolicy(name2)
+
+		cache.DeleteOwner(key1)
+		Expect(cache.RetiredPolicy("id1")).To(BeNil())
+		Expect(cache.HasRetiredIds()).To(BeFalse())
+		cache.DeleteOwner(key2)
+		Expect(cache.RetiredPolicy("id1")).To(Equal(&policy))
+		Expect(cache.HasRetiredIds()).To(BeTrue())
+
+		cache.updateOwnerData(name1, "id1", true)
+		Expect(cache.RetiredPolicy("id1")).To(BeNil())
+		Expect(cache.HasRetiredIds()).To(BeFalse())
+	})
+
+	ginkgov2.It("keeps records of owner ids handed over to another owner", func() {
+		policy := api.DeletionPolicyDelete
+		cache.updateOwnerData(name1, "id1", true)
+		cache.updateOwnerData(name2, "id1", false)
+		info := cache.owners[name1]
+		info.policy = &policy
+		cache.owners[name1] = info
+		info = cache.owners[name2]
+		info.scheduled = true
+		cache.owners[name2] = info
+
+		changed, _ := cache.DeleteOwner(key1)
+		Expect(changed).To(Equal(utils.NewStringSet("id1")))
+		Expect(cache.RetiredPolicy("id1")).To(BeNil())
+
+		cache.DeleteOwner(key2)
+		Expect(cache.RetiredPolicy("id1")).To(BeNil())
+	})
+
+	ginkgov2.It("keeps records of owner ids with a scheduled deactivation", func() {
+		policy := api.DeletionPolicyDelete
+		cache.updateOwnerData(name1, "id1", true)
+		info := cache.owners[name1]
+		info.policy = &policy
+		info.scheduled = true
+		cache.owners[name1] = info
+
+		cache.DeleteOwner(key1)
+		Expect(cache.RetiredPolicy("id1")).To(BeNil())
+	})
+
+	ginkgov2.It("keeps records of owner ids switched to another cluster by DNS activation", func() {
+		policy := api.DeletionPolicyDelete
+		owner := &dnsutils.DNSOwnerObject{Object: &ownerData{owner: &api.DNSOwner{Spec: api.DNSOwnerSpec{
+			OwnerId:       "id1",
+			DNSActivation: &api.DNSActivation{DNSName: "owner.example.com"},
+		}}}}
+		cache.updateOwnerData(name1, "id1", true)
+		info := cache.owners[name1]
+		info.policy = &policy
+		info.scheduled = owner.IsHandover()
+		cache.owners[name1] = info
+
+		// the DNS activation record now selects the other cluster
+		changed, _ := cache.updateOwnerData(name1, "id1", false)
+		Expect(changed).To(Equal(utils.NewStringSet("id1")))
+		Expect(cache.RetiredPolicy("id1")).To(BeNil())
+
+		cache.DeleteOwner(key1)
+		Expect(cache.RetiredPolicy("id1")).To(BeNil())
+	})
+
+	ginkgov2.It("keeps records of owner ids used by DNS locks", func() {
+		policy := api.DeletionPolicyDelete
+		lock := resources.NewObjectName("test", "lock1")
+		cache.updateOwnerData(name1, "id1", true)
+		info := cache.owners[name1]
+		info.policy = &policy
+		cache.owners[name1] = info
+		cache.UpdateLock(lock, "id1", true)
+
+		cache.DeleteOwner(key1)
+		Expect(cache.RetiredPolicy("id1")).To(BeNil())
+
+		cache.updateOwnerData(name1, "id1", true)
+		info = cache.owners[name1]
+		info.policy = &policy
+		cache.owners[name1] = info
+		cache.DeleteLock(lock)
+		cache.DeleteOwner(key1)
+		Expect(cache.RetiredPolicy("id1")).To(Equal(&policy))
+		cache.UpdateLock(lock, "id1", true)
+		Expect(cache.RetiredPolicy("id1")).To(BeNil())
+	})
+
+	ginkgov2.It("keeps records of deactivated owner ids without deactivation policy", func() {
+		cache.updateOwnerData(name1, "id1", true)
+		cache.DeleteOwner(key1)
+		Expect(cache.RetiredPolicy("id1")).To(BeNil())
+	})
+
+	ginkgov2.It("reports entry counts by type, provider and zone", func() {
+		cache.updateOwnerData(name1, "id1", true)
+		stat := statistic.NewEntryStatistic()
+		stat.Owners.Inc("id1", "aws-route53", resources.NewObjectName("default", "p1"))
+		stat.Owners.Inc("id1", "aws-route53", resources.NewObjectName("default", "p1"))
+		stat.Owners.Inc("id1", "aws-route53", resources.NewObjectName("default", "p2"))
+		stat.Zones.Inc("id1", "Z1")
+		stat.Zones.Inc("id1", "Z1")
+		stat.Zones.Inc("id1", "Z2")
+		types := utils.NewStringSet("aws-route53")
+
+		changes := cache.UpdateCountsWith(stat, types)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[name1].ByType).To(Equal(ProviderTypeCounts{"aws-route53": 3}))
+		Expect(changes[name1].ByProvider).To(Equal(map[string]int{"default/p1": 2, "default/p2": 1}))
+		Expect(changes[name1].ByZone).To(Equal(map[string]int{"Z1": 2, "Z2": 1}))
+		Expect(changes[name1].LastChange.IsZero()).To(BeTrue())
+
+		Expect(cache.UpdateCountsWith(stat, types)).To(BeEmpty())
+
+		stat.Zones.Inc("id1", "Z2")
+		changes = cache.UpdateCountsWith(stat, types)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[name1].ByZone).To(Equal(map[string]int{"Z1": 2, "Z2": 2}))
+	})
+
+	ginkgov2.It("reports last applied change", func() {
+		cache.updateOwnerData(name1, "id1", true)
+		stat := statistic.NewEntryStatistic()
+		types := utils.NewStringSet("aws-route53")
+		Expect(cache.UpdateCountsWith(stat, types)).To(HaveLen(1))
+		Expect(cache.UpdateCountsWith(stat, types)).To(BeEmpty())
+
+		now := time.Now()
+		cache.ChangeApplied("id1", now)
+		cache.ChangeApplied("unknown", now)
+		changes := cache.UpdateCountsWith(stat, types)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[name1].LastChange).To(Equal(now))
+		Expect(cache.UpdateCountsWith(stat, types)).To(BeEmpty())
+	})
+
 })
@@ -0,0 +1,287 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+// reasons of the condition OwnerConflict of an entry
+const (
+	OWNER_CONFLICT_SPLIT_BRAIN = "SplitBrain"
+	OWNER_CONFLICT_RESOLVED    = "Resolved"
+)
+
+// ownerConflict describes the foreign changes observed for a record set written by this controller.
+type ownerConflict struct {
+	name ZonedDNSSetName
+	// owner is the owner found for the last foreign change
+	owner string
+	// changes are the times of the foreign changes within the window
+	changes []time.Time
+	flagged bool
+	// reported is true if the conflict has already been reported for the entry
+	reported bool
+}
+
+func (this *ownerConflict) message() string {
+	return fmt.Sprintf("record set is repeatedly changed by owner %q (%d times), another controller installation seems to manage the same DNS name; updates are suspended",
+		this.owner, len(this.changes))
+}
+
+// ownerConflictDetector detects split-brain situations, i.e. record sets written by this controller
+// which are repeatedly changed by another owner (different owner id or external-dns heritage).
+// Instead of fighting at the provider, the updates of flagged record sets are suspended
+// until no foreign change has been observed for the window.
+type ownerConflictDetector struct {
+	lock      sync.Mutex
+	threshold int
+	window    time.Duration
+	// written contains the record sets last written by this controller
+	written map[ZonedDNSSetName]*dns.DNSSet
+	// conflicts contains the record sets with foreign changes within the window
+	conflicts map[ZonedDNSSetName]*ownerConflict
+}
+
+// newOwnerConflictDetector creates a detector. It returns nil if the detection is disabled (threshold 0).
+func newOwnerConflictDetector(threshold int, window time.Duration) *ownerConflictDetector {
+	if threshold <= 0 || window <= 0 {
+		return nil
+	}
+	return &ownerConflictDetector{
+		threshold: threshold,
+		window:    window,
+		written:   map[ZonedDNSSetName]*dns.DNSSet{},
+		conflicts: map[ZonedDNSSetName]*ownerConflict{},
+	}
+}
+
+// recordWritten remembers the record sets of successfully applied change requests.
+func (this *ownerConflictDetector) recordWritten(zoneid dns.ZoneID, reqs []*ChangeRequest) {
+	if this == nil {
+		return
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	added := map[ZonedDNSSetName]struct{}{}
+	var deleted []ZonedDNSSetName
+	for _, req := range reqs {
+		if !req.Applied {
+			continue
+		}
+		if req.Addition != nil {
+			name := ZonedDNSSetName{DNSSetName: req.Addition.Name, ZoneID: zoneid}
+			this.written[name] = req.Addition
+			added[name] = struct{}{}
+		} else if req.Deletion != nil {
+			deleted = append(deleted, ZonedDNSSetName{DNSSetName: req.Deletion.Name, ZoneID: zoneid})
+		}
+	}
+	for _, name := range deleted {
+		if _, ok := added[name]; !ok {
+			delete(this.written, name)
+		}
+	}
+}
+
+// observe compares the current record set found in the zone with the record set last written
+// by this controller. A difference is counted as foreign change of the given owner.
+// It returns true if a foreign change has been detected.
+func (this *ownerConflictDetector) observe(name ZonedDNSSetName, current *dns.DNSSet, owner string, now time.Time) bool {
+	if this == nil || current == nil {
+		return false
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	written := this.written[name]
+	if written == nil || !overwritten(written, current) {
+		return false
+	}
+	// count each change only once until the record set is written again
+	delete(this.written, name)
+	conflict := this.conflicts[name]
+	if conflict == nil {
+		conflict = &ownerConflict{name: name}
+		this.conflicts[name] = conflict
+	}
+	conflict.owner = owner
+	conflict.changes = append(pruneChanges(conflict.changes, now.Add(-this.window)), now)
+	if len(conflict.changes) >= this.threshold {
+		conflict.flagged = true
+	}
+	return true
+}
+
+// suspended returns the error for a flagged record set whose updates are suspended.
+func (this *ownerConflictDetector) suspended(name ZonedDNSSetName) error {
+	if this == nil {
+		return nil
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	conflict := this.conflicts[name]
+	if conflict == nil || !conflict.flagged {
+		return nil
+	}
+	return &perrs.OwnerConflict{Name: name.DNSSetName, Owner: conflict.owner, Changes: len(conflict.changes)}
+}
+
+// check returns the newly flagged conflicts and the resolved conflicts of a hosted zone and
+// the number of currently flagged record sets. A conflict is resolved if no foreign change
+// has been observed within the window.
+func (this *ownerConflictDetector) check(zoneid dns.ZoneID, now time.Time) ([]*ownerConflict, []*ownerConflict, int) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	var flagged, resolved []*ownerConflict
+	count := 0
+	for name, conflict := range this.conflicts {
+		if name.ZoneID != zoneid {
+			continue
+		}
+		conflict.changes = pruneChanges(conflict.changes, now.Add(-this.window))
+		if len(conflict.changes) == 0 {
+			delete(this.conflicts, name)
+			if conflict.reported {
+				resolved = append(resolved, conflict)
+			}
+			continue
+		}
+		if conflict.flagged {
+			count++
+			if !conflict.reported {
+				conflict.reported = true
+				flagged = append(flagged, conflict)
+			}
+		}
+	}
+	sort.Slice(flagged, func(i, j int) bool { return flagged[i].name.String() < flagged[j].name.String() })
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i].name.String() < resolved[j].name.String() })
+	return flagged, resolved, count
+}
+
+// pruneChanges removes the change times before the given time.
+func pruneChanges(changes []time.Time, since time.Time) []time.Time {
+	result := changes[:0]
+	for _, t := range changes {
+		if !t.Before(since) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// overwritten checks whether the owner or the records of a record set written by this controller
+// have been changed.
+func overwritten(written, current *dns.DNSSet) bool {
+	if written.GetOwner() != current.GetOwner() {
+		return true
+	}
+	for ty, rset := range written.Sets {
+		if ty == dns.RS_META {
+			continue
+		}
+		if cur := current.Sets[ty]; cur == nil || !cur.Match(rset) {
+			return true
+		}
+	}
+	for ty := range current.Sets {
+		if _, ok := written.Sets[ty]; !ok && ty != dns.RS_META {
+			return true
+		}
+	}
+	return false
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// conflictingOwner returns the owner of a record set changed by someone else.
+func (this *ChangeModel) conflictingOwner(set *dns.DNSSet) string {
+	if this.IsForeign(set) {
+		return foreignOwner(set)
+	}
+	if owner := set.GetOwner(); owner != "" {
+		return owner
+	}
+	if set.ExternalDNSOwner != "" {
+		return dns.ExternalDNSHeritage + "/" + set.ExternalDNSOwner
+	}
+	return "unknown"
+}
+
+// checkOwnerConflict records foreign changes of a record set and returns an error if the
+// updates of the record set are suspended because of a split-brain situation.
+func (this *ChangeModel) checkOwnerConflict(name dns.DNSSetName, oldset *dns.DNSSet) error {
+	conflicts := this.context.conflicts
+	if conflicts == nil {
+		return nil
+	}
+	zname := ZonedDNSSetName{DNSSetName: name, ZoneID: this.ZoneId()}
+	if oldset != nil {
+		owner := this.conflictingOwner(oldset)
+		if conflicts.observe(zname, oldset, owner, time.Now()) {
+			this.Warnf("record set %s has been changed by owner %q", name, owner)
+			metrics.AddForeignRecordChanges(this.ZoneId(), 1)
+		}
+	}
+	return conflicts.suspended(zname)
+}
+
+func (this *state) reportOwnerConflicts(logger logger.LogContext, zoneid dns.ZoneID) {
+	if this.conflicts == nil {
+		return
+	}
+	flagged, resolved, count := this.conflicts.check(zoneid, time.Now())
+	for _, c := range flagged {
+		logger.Warnf("split-brain detected for %s: %s", c.name, c.message())
+		this.reportOwnerConflict(c.name, metav1.ConditionTrue, OWNER_CONFLICT_SPLIT_BRAIN, c.message(), true)
+	}
+	for _, c := range resolved {
+		logger.Infof("owner conflict of %s resolved", c.name)
+		this.reportOwnerConflict(c.name, metav1.ConditionFalse, OWNER_CONFLICT_RESOLVED,
+			fmt.Sprintf("no change by another owner observed for %s", this.conflicts.window), false)
+	}
+	metrics.ReportOwnerConflicts(zoneid, count)
+}
+
+func (this *state) reportOwnerConflict(name ZonedDNSSetName, status metav1.ConditionStatus, reason, message string, event bool) {
+	this.lock.RLock()
+	entry := this.dnsnames[name]
+	this.lock.RUnlock()
+	if entry == nil {
+		return
+	}
+	if event {
+		entry.object.Eventf(corev1.EventTypeWarning, "split-brain", "%s: %s", reason, message)
+	}
+	this.acknowledgeEntryCondition(entry, api.CONDITION_OWNER_CONFLICT, status, reason, message)
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+var _ = ginkgov2.Describe("Owner conflict detector", func() {
+	zoneid := dns.NewZoneID("mock", "z1")
+	setName := dns.DNSSetName{DNSName: "a.example.com"}
+	name := ZonedDNSSetName{DNSSetName: setName, ZoneID: zoneid}
+
+	newSet := func(owner string, ip string) *dns.DNSSet {
+		set := dns.NewDNSSet(setName, nil)
+		set.SetRecordSet(dns.RS_A, 300, ip)
+		set.SetOwner(owner)
+		return set
+	}
+	written := func(d *ownerConflictDetector, set *dns.DNSSet) {
+		d.recordWritten(zoneid, []*ChangeRequest{{Action: R_UPDATE, Type: dns.RS_A, Addition: set, Applied: true}})
+	}
+
+	ginkgov2.It("is disabled without threshold", func() {
+		Ω(newOwnerConflictDetector(0, time.Minute)).Should(BeNil())
+		var d *ownerConflictDetector
+		Ω(d.observe(name, newSet("other", "1.2.3.4"), "other", time.Now())).Should(BeFalse())
+		Ω(d.suspended(name)).Should(BeNil())
+	})
+
+	ginkgov2.It("detects changed owners and records", func() {
+		Ω(overwritten(newSet("owner", "1.2.3.4"), newSet("owner", "1.2.3.4"))).Should(BeFalse())
+		Ω(overwritten(newSet("owner", "1.2.3.4"), newSet("other", "1.2.3.4"))).Should(BeTrue())
+		Ω(overwritten(newSet("owner", "1.2.3.4"), newSet("owner", "5.6.7.8"))).Should(BeTrue())
+		cname := newSet("owner", "1.2.3.4")
+		cname.SetRecordSet(dns.RS_CNAME, 300, "b.example.com")
+		Ω(overwritten(newSet("owner", "1.2.3.4"), cname)).Should(BeTrue())
+	})
+
+	ginkgov2.It("ignores record sets not written by this controller", func() {
+		d := newOwnerConflictDetector(1, time.Minute)
+		Ω(d.observe(name, newSet("other", "1.2.3.4"), "other", time.Now())).Should(BeFalse())
+		written(d, newSet("owner", "1.2.3.4"))
+		d.recordWritten(zoneid, []*ChangeRequest{{Action: R_DELETE, Type: dns.RS_A, Deletion: newSet("owner", "1.2.3.4"), Applied: true}})
+		Ω(d.observe(name, newSet("other", "1.2.3.4"), "other", time.Now())).Should(BeFalse())
+	})
+
+	ginkgov2.It("flags repeated foreign changes and reports the resolution", func() {
+		d := newOwnerConflictDetector(2, time.Minute)
+		now := time.Now()
+
+		written(d, newSet("owner", "1.2.3.4"))
+		Ω(d.observe(name, newSet("owner", "1.2.3.4"), "owner", now)).Should(BeFalse())
+		Ω(d.observe(name, newSet("other", "5.6.7.8"), "other", now)).Should(BeTrue())
+		// counted only once until written again
+		Ω(d.observe(name, newSet("other", "5.6.7.8"), "other", now)).Should(BeFalse())
+		Ω(d.suspended(name)).Should(BeNil())
+		flagged, resolved, count := d.check(zoneid, now)
+		Ω(flagged).Should(BeEmpty())
+		Ω(resolved).Should(BeEmpty())
+		Ω(count).Should(Equal(0))
+
+		written(d, newSet("owner", "1.2.3.4"))
+		Ω(d.observe(name, newSet("other", "5.6.7.8"), "other", now.Add(10*time.Second))).Should(BeTrue())
+		err := d.suspended(name)
+		Ω(err).Should(BeAssignableToTypeOf(&perrs.OwnerConflict{}))
+		Ω(err.Error()).Should(ContainSubstring(`owner "other" (2 times)`))
+
+		flagged, _, count = d.check(zoneid, now.Add(20*time.Second))
+		Ω(flagged).Should(HaveLen(1))
+		Ω(flagged[0].name).Should(Equal(name))
+		Ω(count).Should(Equal(1))
+		flagged, _, count = d.check(zoneid, now.Add(30*time.Second))
+		Ω(flagged).Should(BeEmpty())
+		Ω(count).Should(Equal(1))
+		_, _, count = d.check(dns.NewZoneID("mock", "z2"), now.Add(30*time.Second))
+		Ω(count).Should(Equal(0))
+
+		_, resolved, count = d.check(zoneid, now.Add(2*time.Minute))
+		Ω(resolved).Should(HaveLen(1))
+		Ω(count).Should(Equal(0))
+		Ω(d.suspended(name)).Should(BeNil())
+	})
+
+	ginkgov2.It("suspends the updates of the change model", func() {
+		zone := newDNSHostedZone(time.Second, NewDNSHostedZone("mock", "z1", "example.com", "", nil, false))
+		d := newOwnerConflictDetector(1, time.Minute)
+		model := NewChangeModel(logger.New(), testOwnership("owner"), &zoneReconciliation{zone: zone, conflicts: d}, Config{})
+
+		Ω(model.checkOwnerConflict(setName, nil)).Should(Succeed())
+		written(d, newSet("owner", "1.2.3.4"))
+		Ω(model.checkOwnerConflict(setName, newSet("owner", "1.2.3.4"))).Should(Succeed())
+
+		foreign := newSet("", "5.6.7.8")
+		foreign.ExternalDNSOwner = "cluster-b"
+		Ω(model.conflictingOwner(foreign)).Should(Equal("external-dns/cluster-b"))
+		err := model.checkOwnerConflict(setName, foreign)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(`owner "external-dns/cluster-b"`))
+	})
+})
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// PERMISSION_CHECK_INTERVAL is the minimum interval between two permission checks of a hosted zone.
+const PERMISSION_CHECK_INTERVAL = 30 * time.Minute
+
+// PermissionCheckName returns the DNS name of the non-existing record set used for probing
+// the permission for changes of a hosted zone.
+func PermissionCheckName(zone DNSHostedZone) string {
+	return "_dns-permission-check." + zone.Domain()
+}
+
+// PermissionChecker is an optional interface of a DNSHandler to probe the permission
+// for changing the record sets of a hosted zone without modifying the zone.
+type PermissionChecker interface {
+	// CheckChangePermission returns an error if the record sets of the zone cannot be changed
+	// because of missing permissions.
+	CheckChangePermission(zone DNSHostedZone) error
+}
+
+// CheckPermissions probes the provider API permissions needed by the DNS controller for the given
+// hosted zones and returns descriptions of the missing ones. Listing the hosted zones is not checked,
+// as it is a precondition for getting the zones.
+// Permissions for changes are only checked for handlers implementing the PermissionChecker interface.
+func CheckPermissions(handler DNSHandler, zones DNSHostedZones) []string {
+	var missing []string
+	checker, _ := handler.(PermissionChecker)
+	for _, zone := range zones {
+		if _, err := handler.GetZoneState(zone); err != nil {
+			missing = append(missing, fmt.Sprintf("read record sets of zone %s: %s", zone.Id().ID, err))
+			continue
+		}
+		if checker != nil {
+			if err := checker.CheckChangePermission(zone); err != nil {
+				missing = append(missing, fmt.Sprintf("change record sets of zone %s: %s", zone.Id().ID, err))
+			}
+		}
+	}
+	return missing
+}
+
+type permissionCheck struct {
+	time    time.Time
+	missing []string
+}
+
+// CheckPermissions probes the permissions of the account for the given hosted zones.
+// The results are cached per zone for PERMISSION_CHECK_INTERVAL.
+func (this *DNSAccount) CheckPermissions(zones DNSHostedZones) []string {
+	this.permissionsLock.Lock()
+	defer this.permissionsLock.Unlock()
+
+	if this.permissions == nil {
+		this.permissions = map[dns.ZoneID]*permissionCheck{}
+	}
+	var missing []string
+	now := time.Now()
+	for _, zone := range zones {
+		check := this.permissions[zone.Id()]
+		if check == nil || now.Sub(check.time) >= PERMISSION_CHECK_INTERVAL {
+			check = &permissionCheck{time: now, missing: CheckPermissions(this.handler, DNSHostedZones{zone})}
+			this.permissions[zone.Id()] = check
+		}
+		missing = append(missing, check.missing...)
+	}
+	return missing
+}
+
+// NewStandaloneHandler creates a handler for the given provider type outside of a controller,
+// e.g. for command line tools. Default factory options are used and the zone state is not cached.
+func NewStandaloneHandler(ctx context.Context, logger logger.LogContext, factory DNSHandlerFactory,
+	typecode string, props utils.Properties, providerConfig *runtime.RawExtension) (DNSHandler, error) {
+	if c, ok := factory.(*CompoundFactory); ok {
+		factory = c.factories[typecode]
+		if factory == nil {
+			return nil, fmt.Errorf("unknown provider type %q", typecode)
+		}
+	}
+	cfg := &DNSHandlerConfig{
+		Context:    ctx,
+		Logger:     logger,
+		Properties: props,
+		Config:     providerConfig,
+		ZoneCacheFactory: ZoneCacheFactory{
+			context:               ctx,
+			logger:                logger,
+			disableZoneStateCache: true,
+		},
+		Options: &FactoryOptions{GenericFactoryOptions: GenericFactoryOptionDefaults},
+		Metrics: &NullMetrics{},
+	}
+	return factory.Create(typecode, cfg)
+}
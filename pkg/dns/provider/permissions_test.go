@@ -0,0 +1,92 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type permissionTestHandler struct {
+	DefaultDNSHandler
+	unreadable   string
+	unchangeable string
+	checks       int
+}
+
+var _ PermissionChecker = &permissionTestHandler{}
+
+func (h *permissionTestHandler) GetZones() (DNSHostedZones, error) {
+	return nil, nil
+}
+
+func (h *permissionTestHandler) GetZoneState(zone DNSHostedZone) (DNSZoneState, error) {
+	if zone.Id().ID == h.unreadable {
+		return nil, fmt.Errorf("access denied")
+	}
+	return nil, nil
+}
+
+func (h *permissionTestHandler) CheckChangePermission(zone DNSHostedZone) error {
+	h.checks++
+	if zone.Id().ID == h.unchangeable {
+		return fmt.Errorf("access denied")
+	}
+	return nil
+}
+
+func (h *permissionTestHandler) ReportZoneStateConflict(zone DNSHostedZone, err error) bool {
+	return false
+}
+
+func (h *permissionTestHandler) ExecuteRequests(logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error {
+	return nil
+}
+
+func (h *permissionTestHandler) Release() {}
+
+var _ = ginkgov2.Describe("Permission check", func() {
+	zones := DNSHostedZones{
+		NewDNSHostedZone("test", "z1", "a.example.com", "", nil, false),
+		NewDNSHostedZone("test", "z2", "b.example.com", "", nil, false),
+		NewDNSHostedZone("test", "z3", "c.example.com", "", nil, false),
+	}
+
+	ginkgov2.It("reports missing read and change permissions", func() {
+		h := &permissionTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test"), unreadable: "z1", unchangeable: "z2"}
+		Ω(CheckPermissions(h, zones)).Should(Equal([]string{
+			"read record sets of zone z1: access denied",
+			"change record sets of zone z2: access denied",
+		}))
+		Ω(h.checks).Should(Equal(2))
+	})
+
+	ginkgov2.It("caches the results per account and zone", func() {
+		h := &permissionTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test"), unchangeable: "z3"}
+		account := NewDNSAccount(nil, h, "hash")
+		Ω(account.CheckPermissions(zones)).Should(Equal([]string{"change record sets of zone z3: access denied"}))
+		Ω(account.CheckPermissions(zones[2:])).Should(HaveLen(1))
+		Ω(h.checks).Should(Equal(3))
+	})
+
+	ginkgov2.It("uses a name below the zone domain for probing", func() {
+		Ω(PermissionCheckName(zones[0])).Should(Equal("_dns-permission-check.a.example.com"))
+	})
+})
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgov2.Describe("Pinned providers", func() {
+	ginkgov2.DescribeTable("parses provider references",
+		func(ref string, expected string) {
+			name, err := ParsePinnedProviderName(ref, "default")
+			if expected == "" {
+				Ω(err).Should(MatchError(ContainSubstring("invalid provider reference")))
+				return
+			}
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(name.String()).Should(Equal(expected))
+		},
+		ginkgov2.Entry("name", "p1", "default/p1"),
+		ginkgov2.Entry("namespace and name", "dns/p1", "dns/p1"),
+		ginkgov2.Entry("empty", "", ""),
+		ginkgov2.Entry("missing name", "dns/", ""),
+		ginkgov2.Entry("missing namespace", "/p1", ""),
+		ginkgov2.Entry("too many segments", "dns/p1/x", ""),
+	)
+
+	var s *state
+	ginkgov2.BeforeEach(func() {
+		provider := &api.DNSProvider{}
+		provider.Namespace = "dns"
+		provider.Name = "p1"
+		provider.Spec.AllowedNamespaces = []string{"team-a"}
+		p := &dnsProviderVersion{
+			object:   &dnsutils.DNSProviderObject{Object: &accessObject{data: provider, kind: api.DNSProviderKind}},
+			valid:    true,
+			included: utils.NewStringSet("example.com"),
+			excluded: utils.StringSet{},
+		}
+		s = &state{
+			classes:   controller.NewClasses(nil, "", dns.CLASS_ANNOTATION, dns.DEFAULT_CLASS),
+			providers: map[resources.ObjectName]*dnsProviderVersion{p.ObjectName(): p},
+		}
+	})
+
+	ginkgov2.DescribeTable("looks up the pinned provider",
+		func(namespace, dnsName, ref string, expectedErr string) {
+			entry := &api.DNSEntry{}
+			entry.Namespace = namespace
+			entry.Name = "e1"
+			entry.Spec.DNSName = dnsName
+			entry.Spec.Provider = &ref
+			found, _, err := s.lookupPinnedProvider(&dnsutils.DNSEntryObject{Object: &accessObject{data: entry, kind: api.DNSEntryKind}}, ref)
+			if expectedErr != "" {
+				Ω(err).Should(MatchError(expectedErr))
+				Ω(found).Should(BeNil())
+				return
+			}
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found.ObjectName().String()).Should(Equal("dns/p1"))
+		},
+		ginkgov2.Entry("name in own namespace", "dns", "www.example.com", "p1", ""),
+		ginkgov2.Entry("namespace and name from allowed namespace", "team-a", "www.example.com", "dns/p1", ""),
+		ginkgov2.Entry("malformed reference", "team-a", "www.example.com", "dns/",
+			`invalid provider reference "dns/" (expected namespace/name)`),
+		ginkgov2.Entry("name in other namespace", "team-a", "www.example.com", "p1",
+			"requested provider team-a/p1 not found"),
+		ginkgov2.Entry("domain not served", "team-a", "www.example.org", "dns/p1",
+			"requested provider dns/p1 is not responsible for www.example.org"),
+		ginkgov2.Entry("denied namespace", "team-b", "www.example.com", "dns/p1",
+			"provider dns/p1 is not allowed for namespace team-b"),
+	)
+})
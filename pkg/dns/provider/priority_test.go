@@ -0,0 +1,90 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgov2.Describe("Provider priority", func() {
+	newProvider := func(name string, priority int) *dnsProviderVersion {
+		provider := &api.DNSProvider{}
+		provider.Namespace = "dns"
+		provider.Name = name
+		provider.Spec.Priority = &priority
+		return &dnsProviderVersion{
+			object: &dnsutils.DNSProviderObject{Object: &accessObject{data: provider, kind: api.DNSProviderKind}},
+			valid:  true,
+		}
+	}
+	assigned := func(p *dnsProviderVersion) *string {
+		name := p.ObjectName().String()
+		return &name
+	}
+
+	ginkgov2.It("prefers any provider to none", func() {
+		match := &providerMatch{}
+		Ω(match.compare(newProvider("p1", 0), 1)).Should(BeNumerically(">", 0))
+		Ω(match.prefers(newProvider("p1", 0), 1, nil)).Should(BeTrue())
+	})
+
+	ginkgov2.It("prefers a higher priority to a longer match", func() {
+		low, high := newProvider("p1", 0), newProvider("p2", 10)
+		match := &providerMatch{found: low, match: 3}
+		Ω(match.compare(high, 2)).Should(BeNumerically(">", 0))
+		Ω(match.prefers(high, 2, assigned(low))).Should(BeTrue())
+
+		match = &providerMatch{found: high, match: 2}
+		Ω(match.compare(low, 3)).Should(BeNumerically("<", 0))
+		Ω(match.prefers(low, 3, assigned(low))).Should(BeFalse())
+	})
+
+	ginkgov2.It("prefers the longest match for equal priorities", func() {
+		short, long := newProvider("p1", 5), newProvider("p2", 5)
+		match := &providerMatch{found: short, match: 2}
+		Ω(match.compare(long, 3)).Should(BeNumerically(">", 0))
+		Ω(match.prefers(long, 3, assigned(short))).Should(BeTrue())
+
+		match = &providerMatch{found: long, match: 3}
+		Ω(match.compare(short, 2)).Should(BeNumerically("<", 0))
+		Ω(match.prefers(short, 2, assigned(short))).Should(BeFalse())
+	})
+
+	ginkgov2.It("keeps the assigned provider on a tie", func() {
+		p1, p2 := newProvider("p1", 0), newProvider("p2", 0)
+		match := &providerMatch{found: p2, match: 2}
+		Ω(match.compare(p1, 2)).Should(Equal(0))
+		Ω(match.prefers(p1, 2, assigned(p2))).Should(BeFalse())
+
+		match = &providerMatch{found: p1, match: 2}
+		Ω(match.prefers(p2, 2, assigned(p2))).Should(BeTrue())
+	})
+
+	ginkgov2.It("prefers the lowest object name on a tie without assigned provider", func() {
+		p1, p2, p3 := newProvider("p1", 0), newProvider("p2", 0), newProvider("p3", 0)
+		for _, assignment := range []*string{nil, assigned(p3)} {
+			match := &providerMatch{found: p2, match: 2}
+			Ω(match.prefers(p1, 2, assignment)).Should(BeTrue())
+			match = &providerMatch{found: p1, match: 2}
+			Ω(match.prefers(p2, 2, assignment)).Should(BeFalse())
+		}
+	})
+})
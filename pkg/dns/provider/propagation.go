@@ -0,0 +1,306 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+// PROPAGATION_CHECK_INTERVAL is the interval between two queries of the authoritative name servers
+// while waiting for the propagation of an applied DNS entry.
+const PROPAGATION_CHECK_INTERVAL = 5 * time.Second
+
+// propagationReporter reports the state of the propagation check of an entry as condition Propagated.
+type propagationReporter func(zoneid dns.ZoneID, name dns.DNSSetName, status metav1.ConditionStatus, reason, message string)
+
+// propagationChecker measures the time from a spec change of a DNS entry until its records are
+// resolved by all authoritative name servers of the hosted zone and the optional resolvers.
+type propagationChecker struct {
+	lock    sync.Mutex
+	ctx     context.Context
+	logger  logger.LogContext
+	timeout time.Duration
+	// lookup is the configuration for the lookup of the name servers and the queries of the resolvers
+	lookup *DNSLookupConfig
+	// resolvers are the addresses (host:port) of additionally queried recursive resolvers
+	resolvers []string
+	// getZone returns the hosted zone for a zone id or nil if unknown
+	getZone func(zoneid dns.ZoneID) DNSHostedZone
+	// report is called with the state of a check, if set
+	report propagationReporter
+	// lookupNS returns the addresses (host:port) of the authoritative name servers of a domain
+	lookupNS func(ctx context.Context, domain string) ([]string, error)
+	// query returns the values of the records of the given type resolved by a name server
+	query   func(ctx context.Context, server, dnsname, rtype string) ([]string, error)
+	pending map[dns.DNSSetName]*pendingPropagationCheck
+}
+
+type pendingPropagationCheck struct {
+	cancel context.CancelFunc
+}
+
+func createPropagationResolvers(c controller.Interface, lookup *DNSLookupConfig) ([]string, error) {
+	value, err := c.GetStringOption(OPT_PROPAGATION_CHECK_RESOLVERS)
+	if err != nil || value == "" {
+		return nil, nil
+	}
+	return lookup.parseResolvers(value)
+}
+
+// parseResolverAddresses parses a comma separated list of resolvers given as host or host:port.
+func parseResolverAddresses(value string) ([]string, error) {
+	var resolvers []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		address, err := dnsutils.NormalizeResolverAddress(item)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, address)
+	}
+	return resolvers, nil
+}
+
+// newPropagationChecker creates a propagation checker. It returns nil if the check is disabled
+// (timeout 0).
+func newPropagationChecker(ctx context.Context, logger logger.LogContext, timeout time.Duration, lookup *DNSLookupConfig, resolvers []string,
+	getZone func(zoneid dns.ZoneID) DNSHostedZone, report propagationReporter) *propagationChecker {
+	if timeout <= 0 {
+		return nil
+	}
+	checker := &propagationChecker{
+		ctx:       ctx,
+		logger:    logger,
+		timeout:   timeout,
+		lookup:    lookup,
+		resolvers: resolvers,
+		getZone:   getZone,
+		report:    report,
+		pending:   map[dns.DNSSetName]*pendingPropagationCheck{},
+	}
+	checker.lookupNS = checker.lookupAuthoritativeServers
+	checker.query = checker.queryServer
+	return checker
+}
+
+// Check starts the asynchronous propagation check of the records of an entry applied in the given zone.
+// A pending check for the same DNS name is cancelled.
+// Entries with routing policy are not checked, as the answers depend on the querying client.
+func (this *propagationChecker) Check(zoneid dns.ZoneID, name dns.DNSSetName, targets Targets, routingPolicy *dns.RoutingPolicy, changedAt time.Time) {
+	if this == nil || len(targets) == 0 {
+		return
+	}
+	if routingPolicy != nil || name.SetIdentifier != "" {
+		this.reportState(zoneid, name, metav1.ConditionUnknown, api.PROPAGATION_NOTCHECKED, "records with routing policy are not checked")
+		return
+	}
+	expected := map[string][]string{}
+	for _, t := range targets {
+		expected[t.GetRecordType()] = append(expected[t.GetRecordType()], t.GetHostName())
+	}
+
+	ctx, cancel := context.WithTimeout(this.ctx, this.timeout-time.Since(changedAt))
+	check := &pendingPropagationCheck{cancel: cancel}
+	this.lock.Lock()
+	if old := this.pending[name]; old != nil {
+		old.cancel()
+	}
+	this.pending[name] = check
+	this.lock.Unlock()
+	metrics.AddEntryPropagationPending(zoneid, 1)
+
+	go func() {
+		defer this.done(zoneid, name, check)
+		this.reportState(zoneid, name, metav1.ConditionFalse, api.PROPAGATION_PENDING, "waiting for resolution of records by name servers")
+		if err := this.waitForPropagation(ctx, zoneid, name.DNSName, expected); err != nil {
+			switch ctx.Err() {
+			case context.Canceled:
+				// superseded by a newer check
+			case context.DeadlineExceeded:
+				metrics.AddEntryPropagationTimeouts(zoneid)
+				this.logger.Infof("propagation check for %s in zone %s failed: %s", name, zoneid, err)
+				this.reportState(zoneid, name, metav1.ConditionFalse, api.PROPAGATION_TIMEOUT, err.Error())
+			default:
+				this.reportState(zoneid, name, metav1.ConditionUnknown, api.PROPAGATION_CHECKFAILED, err.Error())
+			}
+			return
+		}
+		metrics.ReportEntryPropagationSeconds(zoneid, time.Since(changedAt))
+		this.reportState(zoneid, name, metav1.ConditionTrue, api.PROPAGATION_RESOLVED, "records resolved by all name servers")
+	}()
+}
+
+func (this *propagationChecker) reportState(zoneid dns.ZoneID, name dns.DNSSetName, status metav1.ConditionStatus, reason, message string) {
+	if this.report != nil {
+		this.report(zoneid, name, status, reason, message)
+	}
+}
+
+func (this *propagationChecker) done(zoneid dns.ZoneID, name dns.DNSSetName, check *pendingPropagationCheck) {
+	metrics.AddEntryPropagationPending(zoneid, -1)
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	check.cancel()
+	if this.pending[name] == check {
+		delete(this.pending, name)
+	}
+}
+
+func (this *propagationChecker) waitForPropagation(ctx context.Context, zoneid dns.ZoneID, dnsname string, expected map[string][]string) error {
+	zone := this.getZone(zoneid)
+	if zone == nil {
+		return fmt.Errorf("unknown zone")
+	}
+	if zone.IsPrivate() {
+		return fmt.Errorf("private zone")
+	}
+	servers, err := this.lookupNS(ctx, zone.Domain())
+	if err != nil {
+		return fmt.Errorf("cannot lookup name servers: %w", err)
+	}
+	if len(servers) == 0 {
+		return fmt.Errorf("no name servers found")
+	}
+	servers = append(servers, this.resolvers...)
+	for {
+		err = this.checkServers(ctx, servers, dnsname, expected)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(PROPAGATION_CHECK_INTERVAL):
+		}
+	}
+}
+
+func (this *propagationChecker) checkServers(ctx context.Context, servers []string, dnsname string, expected map[string][]string) error {
+	for _, server := range servers {
+		for rtype, values := range expected {
+			found, err := this.query(ctx, server, dnsname, rtype)
+			if err != nil {
+				return fmt.Errorf("query %s for %s %s: %w", server, rtype, dnsname, err)
+			}
+			for _, value := range values {
+				if !containsValue(found, value) {
+					return fmt.Errorf("%s %s not resolved by %s", rtype, value, server)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (this *propagationChecker) lookupAuthoritativeServers(ctx context.Context, domain string) ([]string, error) {
+	var nss []*net.NS
+	err := this.lookup.lookup(ctx, this.lookup.resolvers(), func(ctx context.Context, resolver *net.Resolver) error {
+		var err error
+		nss, err = resolver.LookupNS(ctx, domain)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	servers := make([]string, len(nss))
+	for i, ns := range nss {
+		servers[i] = net.JoinHostPort(strings.TrimSuffix(ns.Host, "."), "53")
+	}
+	return servers, nil
+}
+
+// queryServer queries an authoritative name server with plain DNS or an additional resolver
+// with the configured protocol.
+func (this *propagationChecker) queryServer(ctx context.Context, server, dnsname, rtype string) ([]string, error) {
+	resolver := this.lookup.newPlainResolver(server)
+	if containsValue(this.resolvers, server) {
+		resolver = this.lookup.newResolver(server)
+	}
+	return queryRecords(ctx, resolver, dnsname, rtype)
+}
+
+// queryRecords returns the values of the records of the given type resolved by a resolver.
+func queryRecords(ctx context.Context, resolver *net.Resolver, dnsname, rtype string) ([]string, error) {
+	fqdn := strings.TrimSuffix(dnsname, ".") + "."
+	switch rtype {
+	case dns.RS_A, dns.RS_AAAA:
+		network := "ip4"
+		if rtype == dns.RS_AAAA {
+			network = "ip6"
+		}
+		ips, err := resolver.LookupIP(ctx, network, fqdn)
+		if err != nil {
+			return nil, ignoreNotFound(err)
+		}
+		values := make([]string, len(ips))
+		for i, ip := range ips {
+			values[i] = ip.String()
+		}
+		return values, nil
+	case dns.RS_CNAME:
+		cname, err := resolver.LookupCNAME(ctx, fqdn)
+		if err != nil {
+			return nil, ignoreNotFound(err)
+		}
+		return []string{strings.TrimSuffix(cname, ".")}, nil
+	case dns.RS_TXT:
+		txts, err := resolver.LookupTXT(ctx, fqdn)
+		if err != nil {
+			return nil, ignoreNotFound(err)
+		}
+		values := make([]string, len(txts))
+		for i, txt := range txts {
+			values[i] = fmt.Sprintf("%q", txt)
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("unsupported record type %s", rtype)
+}
+
+// ignoreNotFound maps errors of not (yet) existing records to an empty result.
+func ignoreNotFound(err error) error {
+	if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+		return nil
+	}
+	return err
+}
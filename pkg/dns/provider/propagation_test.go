@@ -0,0 +1,120 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgov2.Describe("Propagation checker", func() {
+	zoneid := dns.NewZoneID("mock", "z1")
+	var records map[string][]string
+	var checker *propagationChecker
+	var reported chan string
+
+	ginkgov2.BeforeEach(func() {
+		records = map[string][]string{}
+		reported = make(chan string, 10)
+		checker = newPropagationChecker(context.Background(), nil, time.Minute, nil, nil, func(id dns.ZoneID) DNSHostedZone {
+			if id == zoneid {
+				return NewDNSHostedZone("mock", "z1", "example.com", "", nil, false)
+			}
+			return nil
+		}, func(id dns.ZoneID, name dns.DNSSetName, status metav1.ConditionStatus, reason, message string) {
+			reported <- name.DNSName + ":" + string(status) + ":" + reason
+		})
+		checker.lookupNS = func(ctx context.Context, domain string) ([]string, error) {
+			return []string{"ns1:53", "ns2:53"}, nil
+		}
+		checker.query = func(ctx context.Context, server, dnsname, rtype string) ([]string, error) {
+			return records[server+"/"+dnsname+"/"+rtype], nil
+		}
+	})
+
+	ginkgov2.It("is disabled without timeout", func() {
+		Ω(newPropagationChecker(context.Background(), nil, 0, nil, nil, nil, nil)).Should(BeNil())
+	})
+
+	ginkgov2.It("reports the state of the check", func() {
+		records["ns1:53/www.example.com/A"] = []string{"1.1.1.1"}
+		records["ns2:53/www.example.com/A"] = []string{"1.1.1.1"}
+		targets := Targets{dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 300)}
+		checker.Check(zoneid, dns.DNSSetName{DNSName: "www.example.com"}, targets, nil, time.Now())
+		Eventually(reported).Should(Receive(Equal("www.example.com:False:" + api.PROPAGATION_PENDING)))
+		Eventually(reported).Should(Receive(Equal("www.example.com:True:" + api.PROPAGATION_RESOLVED)))
+
+		checker.Check(zoneid, dns.DNSSetName{DNSName: "www.example.com", SetIdentifier: "id"}, targets, &dns.RoutingPolicy{Type: "weighted"}, time.Now())
+		Ω(reported).Should(Receive(Equal("www.example.com:Unknown:" + api.PROPAGATION_NOTCHECKED)))
+	})
+
+	ginkgov2.It("queries the additional resolvers", func() {
+		checker.resolvers = []string{"8.8.8.8:53"}
+		records["ns1:53/www.example.com/A"] = []string{"1.1.1.1"}
+		records["ns2:53/www.example.com/A"] = []string{"1.1.1.1"}
+		expected := map[string][]string{dns.RS_A: {"1.1.1.1"}}
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		err := checker.waitForPropagation(ctx, zoneid, "www.example.com", expected)
+		Ω(err).Should(MatchError(ContainSubstring("not resolved by 8.8.8.8:53")))
+
+		records["8.8.8.8:53/www.example.com/A"] = []string{"1.1.1.1"}
+		Ω(checker.waitForPropagation(context.Background(), zoneid, "www.example.com", expected)).Should(Succeed())
+	})
+
+	ginkgov2.It("parses resolver addresses", func() {
+		resolvers, err := parseResolverAddresses("8.8.8.8, dns.example.com:5353,2001:db8::1,[2001:db8::2]")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(resolvers).Should(Equal([]string{"8.8.8.8:53", "dns.example.com:5353", "[2001:db8::1]:53", "[2001:db8::2]:53"}))
+		_, err = parseResolverAddresses("a:b:c")
+		Ω(err).Should(HaveOccurred())
+	})
+
+	ginkgov2.It("succeeds if all authoritative name servers resolve the records", func() {
+		records["ns1:53/www.example.com/A"] = []string{"1.1.1.1", "1.1.1.2"}
+		records["ns2:53/www.example.com/A"] = []string{"1.1.1.2", "1.1.1.1"}
+		expected := map[string][]string{dns.RS_A: {"1.1.1.1", "1.1.1.2"}}
+		Ω(checker.waitForPropagation(context.Background(), zoneid, "www.example.com", expected)).Should(Succeed())
+	})
+
+	ginkgov2.It("fails on timeout if a name server is not up to date", func() {
+		records["ns1:53/www.example.com/A"] = []string{"1.1.1.1"}
+		records["ns2:53/www.example.com/A"] = []string{"1.1.1.3"}
+		expected := map[string][]string{dns.RS_A: {"1.1.1.1"}}
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		err := checker.waitForPropagation(ctx, zoneid, "www.example.com", expected)
+		Ω(err).Should(MatchError(ContainSubstring("not resolved by ns2:53")))
+		Ω(ctx.Err()).Should(Equal(context.DeadlineExceeded))
+	})
+
+	ginkgov2.It("fails for unknown and private zones", func() {
+		Ω(checker.waitForPropagation(context.Background(), dns.NewZoneID("mock", "z2"), "www.example.com", nil)).ShouldNot(Succeed())
+		checker.getZone = func(id dns.ZoneID) DNSHostedZone {
+			return NewDNSHostedZone("mock", "z1", "example.com", "", nil, true)
+		}
+		Ω(checker.waitForPropagation(context.Background(), zoneid, "www.example.com", nil)).ShouldNot(Succeed())
+	})
+})
@@ -28,10 +28,13 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/selection"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
@@ -50,7 +53,13 @@ func (this DNSProviders) LookupFor(dns string) DNSProvider {
 	for _, p := range this {
 		n := p.Match(dns)
 		if n > 0 {
-			if match < n || match == n && found != nil && strings.Compare(p.AccountHash(), found.AccountHash()) < 0 {
+			if found == nil {
+				found = p
+				match = n
+				continue
+			}
+			c := comparePreference(p.Priority(), n, found.Priority(), match)
+			if c > 0 || c == 0 && strings.Compare(p.AccountHash(), found.AccountHash()) < 0 {
 				found = p
 				match = n
 			}
@@ -59,6 +68,15 @@ func (this DNSProviders) LookupFor(dns string) DNSProvider {
 	return found
 }
 
+// comparePreference compares two provider matches given by provider priority and match length.
+// The priority dominates the match length.
+func comparePreference(priority1, match1, priority2, match2 int) int {
+	if priority1 != priority2 {
+		return priority1 - priority2
+	}
+	return match1 - match2
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 type DNSAccount struct {
@@ -68,10 +86,19 @@ type DNSAccount struct {
 
 	hash    string
 	clients resources.ObjectNameSet
+
+	permissionsLock sync.Mutex
+	permissions     map[dns.ZoneID]*permissionCheck
+
+	quota    quotaUsage
+	adaptive *adaptiveRateLimiter
+	sync     SyncConfig
 }
 
 var _ DNSHandler = &DNSAccount{}
 var _ Metrics = &DNSAccount{}
+var _ quotaTracker = &DNSAccount{}
+var _ syncConfigUser = &DNSAccount{}
 
 func NewDNSAccount(config utils.Properties, handler DNSHandler, hash string) *DNSAccount {
 	return &DNSAccount{
@@ -91,6 +118,52 @@ func (this *DNSAccount) AddZoneRequests(zoneID, requestType string, n int) {
 	metrics.AddRequests(this.handler.ProviderType(), this.hash, requestType, n, &zoneID)
 }
 
+func (this *DNSAccount) AddThrottledRequests(n int) {
+	metrics.AddProviderThrottles(this.handler.ProviderType(), "", metrics.THROTTLE_API_RATELIMIT, n)
+}
+
+// SetQuota is called while creating the handler, the quota limit is reported once the handler is set.
+func (this *DNSAccount) SetQuota(qps float32) {
+	this.quota.setQuota(qps)
+}
+
+func (this *DNSAccount) AddQuotaRequests(n int) {
+	usage := this.quota.add(time.Now(), n)
+	metrics.ReportAccountQuotaUsage(this.handler.ProviderType(), this.hash, usage)
+}
+
+// QuotaUsage returns the ratio of the requests in the last minute to the request quota of the account.
+func (this *DNSAccount) QuotaUsage() float64 {
+	return this.quota.Usage(time.Now())
+}
+
+func (this *DNSAccount) setAdaptiveRateLimiter(limiter *adaptiveRateLimiter) {
+	this.adaptive = limiter
+	limiter.onChange = func(qps float32) {
+		this.quota.setQuota(qps)
+		metrics.ReportAccountQuotaLimit(this.ProviderType(), this.hash, float64(qps))
+	}
+}
+
+func (this *DNSAccount) setSyncConfig(sync SyncConfig) {
+	this.sync = sync
+}
+
+// SyncConfig returns the synchronization settings of the provider type, overridden by the DNSProvider.
+func (this *DNSAccount) SyncConfig() SyncConfig {
+	return this.sync
+}
+
+// reportProviderError adapts the rate limiter of the account if the provider throttled a request.
+func (this *DNSAccount) reportProviderError(zone string, err error) {
+	if perrs.IsThrottlingResponse(err) {
+		metrics.AddProviderThrottles(this.handler.ProviderType(), zone, metrics.THROTTLE_PROVIDER, 1)
+		if this.adaptive != nil {
+			this.adaptive.Throttled()
+		}
+	}
+}
+
 func (this *DNSAccount) ProviderType() string {
 	return this.handler.ProviderType()
 }
@@ -100,12 +173,15 @@ func (this *DNSAccount) Hash() string {
 }
 
 func (this *DNSAccount) GetZones() (DNSHostedZones, error) {
+	start := time.Now()
 	zones, err := this.handler.GetZones()
+	metrics.ReportProviderRequestSeconds(this.handler.ProviderType(), "", metrics.OP_GETZONES, err != nil, time.Since(start))
 	if err == nil {
 		zones = addObviousForwardedDomains(zones)
 		this.Succeeded()
 	} else {
 		this.Failed()
+		this.reportProviderError("", err)
 	}
 	return zones, err
 }
@@ -142,11 +218,14 @@ func addObviousForwardedDomains(zones DNSHostedZones) DNSHostedZones {
 }
 
 func (this *DNSAccount) GetZoneState(zone DNSHostedZone) (DNSZoneState, error) {
+	start := time.Now()
 	state, err := this.handler.GetZoneState(zone)
+	metrics.ReportProviderRequestSeconds(zone.Id().ProviderType, zone.Id().ID, metrics.OP_GETZONESTATE, err != nil, time.Since(start))
 	if err == nil {
 		this.Succeeded()
 	} else {
 		this.Failed()
+		this.reportProviderError(zone.Id().ID, err)
 	}
 	return state, err
 }
@@ -156,7 +235,14 @@ func (this *DNSAccount) ReportZoneStateConflict(zone DNSHostedZone, err error) b
 }
 
 func (this *DNSAccount) ExecuteRequests(logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error {
-	return this.handler.ExecuteRequests(logger, zone, state, reqs)
+	metrics.ReportChangeBatchSize(zone.Id(), len(reqs))
+	start := time.Now()
+	err := this.handler.ExecuteRequests(logger, zone, state, reqs)
+	metrics.ReportProviderRequestSeconds(zone.Id().ProviderType, zone.Id().ID, metrics.OP_EXECUTEREQUESTS, err != nil, time.Since(start))
+	if err != nil {
+		this.reportProviderError(zone.Id().ID, err)
+	}
+	return err
 }
 
 func (this *DNSAccount) MapTarget(t Target) Target {
@@ -185,7 +271,12 @@ func NewAccountCache(ttl time.Duration, opts *FactoryOptions) *AccountCache {
 
 func (this *AccountCache) Get(logger logger.LogContext, provider *dnsutils.DNSProviderObject, props utils.Properties, state *state) (*DNSAccount, error) {
 	name := provider.ObjectName()
-	hash := this.Hash(props, provider.Spec().Type, provider.Spec().ProviderConfig)
+	transport, err := NewTransportConfig(provider.Spec().ProxyURL, provider.Spec().CABundle, state.config.TLSPolicy)
+	if err != nil {
+		return nil, err
+	}
+	sync := syncConfigFromSpec(provider.Spec().Sync)
+	hash := this.Hash(props, provider.Spec().Type, provider.Spec().ProviderConfig, transport, sync)
 	this.lock.Lock()
 	defer this.lock.Unlock()
 	a := this.cache[hash]
@@ -201,6 +292,7 @@ func (this *AccountCache) Get(logger logger.LogContext, provider *dnsutils.DNSPr
 			zonesTTL:              this.ttl,
 			zoneStates:            state.zoneStates,
 			disableZoneStateCache: !state.config.ZoneStateCaching,
+			sync:                  sync,
 		}
 
 		cfg := DNSHandlerConfig{
@@ -212,12 +304,15 @@ func (this *AccountCache) Get(logger logger.LogContext, provider *dnsutils.DNSPr
 			ZoneCacheFactory: cacheFactory,
 			Options:          this.options,
 			Metrics:          a,
+			Transport:        transport,
 		}
-		var err error
 		a.handler, err = state.GetHandlerFactory().Create(provider.TypeCode(), &cfg)
 		if err != nil {
 			return nil, err
 		}
+		if qps := a.quota.Quota(); qps > 0 {
+			metrics.ReportAccountQuotaLimit(a.ProviderType(), a.Hash(), qps)
+		}
 		logger.Infof("creating account for %s (%s)", name, a.Hash())
 		this.cache[hash] = a
 	}
@@ -249,7 +344,7 @@ func (this *AccountCache) Release(logger logger.LogContext, a *DNSAccount, name
 	}
 }
 
-func (this *AccountCache) Hash(props utils.Properties, ptype string, extension *runtime.RawExtension) string {
+func (this *AccountCache) Hash(props utils.Properties, ptype string, extension *runtime.RawExtension, transport *TransportConfig, sync SyncConfig) string {
 	keys := make([]string, len(props))
 	i := 0
 	h := sha256.New224()
@@ -271,6 +366,11 @@ func (this *AccountCache) Hash(props utils.Properties, ptype string, extension *
 	}
 	h.Write(null)
 	h.Write([]byte(ptype))
+	transport.hash(h)
+	if sync != (SyncConfig{}) {
+		h.Write(null)
+		h.Write([]byte(sync.String()))
+	}
 	return hex.EncodeToString(h.Sum(nil))
 }
 
@@ -283,11 +383,16 @@ type dnsProviderVersion struct {
 	account *DNSAccount
 	valid   bool
 
-	defaultTTL int64
+	defaultTTL        int64
+	targetCIDRs       *dns.CIDRFilter
+	namespaceSelector labels.Selector
 
-	secret      resources.ObjectName
-	def_include utils.StringSet
-	def_exclude utils.StringSet
+	secret          resources.ObjectName
+	secondarySecret resources.ObjectName
+	failover        bool
+	refresh         time.Duration
+	def_include     utils.StringSet
+	def_exclude     utils.StringSet
 
 	zones          DNSHostedZones
 	included_zones utils.StringSet
@@ -296,6 +401,9 @@ type dnsProviderVersion struct {
 	included  utils.StringSet
 	excluded  utils.StringSet
 	rateLimit *api.RateLimit
+
+	missingPermissions []string
+	zonesFetched       time.Time
 }
 
 var _ DNSProvider = &dnsProviderVersion{}
@@ -312,8 +420,55 @@ func (this *dnsProviderVersion) DefaultTTL() int64 {
 	return this.defaultTTL
 }
 
+func (this *dnsProviderVersion) DefaultDeletionPolicy() *api.DeletionPolicy {
+	return this.object.Spec().DefaultDeletionPolicy
+}
+
+func (this *dnsProviderVersion) UpsertOnly() bool {
+	upsertOnly := this.object.Spec().UpsertOnly
+	return this.state.config.UpsertOnly || (upsertOnly != nil && *upsertOnly)
+}
+
+func (this *dnsProviderVersion) TargetCIDRs() *dns.CIDRFilter {
+	return this.targetCIDRs
+}
+
+func (this *dnsProviderVersion) Priority() int {
+	if p := this.object.Spec().Priority; p != nil {
+		return *p
+	}
+	return 0
+}
+
+// checkNamespaceAccess checks whether the provider may be used by DNS entries of the given namespace.
+// The labels of the namespace are only read if the provider specifies a namespace selector
+// and the namespace is not listed explicitly.
+func (this *dnsProviderVersion) checkNamespaceAccess(namespace string, namespaceLabels func(name string) (map[string]string, error)) error {
+	allowed := this.object.Spec().AllowedNamespaces
+	if (allowed == nil && this.namespaceSelector == nil) || namespace == this.object.GetNamespace() {
+		return nil
+	}
+	for _, ns := range allowed {
+		if ns == namespace {
+			return nil
+		}
+	}
+	if this.namespaceSelector != nil {
+		nslabels, err := namespaceLabels(namespace)
+		if err != nil {
+			return fmt.Errorf("cannot check namespace %s for provider %s: %w", namespace, this.ObjectName(), err)
+		}
+		if this.namespaceSelector.Matches(labels.Set(nslabels)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("provider %s is not allowed for namespace %s", this.ObjectName(), namespace)
+}
+
+// equivalentTo checks whether two versions of a provider serve the same zones and domains.
+// A changed account (e.g. caused by rotated credentials) is not relevant, as it is swapped in place.
 func (this *dnsProviderVersion) equivalentTo(v *dnsProviderVersion) bool {
-	if this.account != v.account {
+	if this.valid != v.valid {
 		return false
 	}
 	if !this.zones.EquivalentTo(v.zones) {
@@ -328,6 +483,9 @@ func (this *dnsProviderVersion) equivalentTo(v *dnsProviderVersion) bool {
 	if !reflect.DeepEqual(this.defaultTTL, v.defaultTTL) {
 		return false
 	}
+	if !reflect.DeepEqual(this.targetCIDRs, v.targetCIDRs) {
+		return false
+	}
 	if this.secret != nil && v.secret != nil && this.secret != v.secret {
 		return false
 	} else {
@@ -335,9 +493,20 @@ func (this *dnsProviderVersion) equivalentTo(v *dnsProviderVersion) bool {
 			return false
 		}
 	}
+	if this.secondarySecret != v.secondarySecret {
+		return false
+	}
+	if !this.included.Equals(v.included) || !this.excluded.Equals(v.excluded) {
+		return false
+	}
 	return true
 }
 
+// accountSwapped returns true if only the account has changed compared to the last valid version.
+func (this *dnsProviderVersion) accountSwapped(last *dnsProviderVersion) bool {
+	return last != nil && last.account != nil && this.account != nil && this.account != last.account && this.equivalentTo(last)
+}
+
 func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutils.DNSProviderObject, last *dnsProviderVersion) (*dnsProviderVersion, reconcile.Status) {
 	domsel := selection.PrepareSelection(provider.DNSProvider().Spec.Domains)
 	this := &dnsProviderVersion{
@@ -373,39 +542,58 @@ func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutil
 		panic(fmt.Errorf("provider name mismatch %q<=>%q", last.ObjectName(), this.ObjectName()))
 	}
 
-	var props utils.Properties
-	var err error
-
-	ref := this.object.DNSProvider().Spec.SecretRef
-	if ref != nil {
-		localref := *ref
-		ref = &localref
-
-		if ref.Namespace == "" {
-			ref.Namespace = provider.GetNamespace()
-		}
-		this.secret = resources.NewObjectName(ref.Namespace, ref.Name)
-		props, _, err = state.GetContext().GetSecretPropertiesByRef(provider, ref)
+	spec := &this.object.DNSProvider().Spec
+	targetCIDRs, err := newProviderTargetCIDRFilter(spec.TargetCIDRs)
+	if err != nil {
+		return this, this.failed(logger, false, fmt.Errorf("invalid targetCIDRs: %w", err), false)
+	}
+	this.targetCIDRs = targetCIDRs
+	if spec.AllowedNamespaceSelector != nil {
+		this.namespaceSelector, err = metav1.LabelSelectorAsSelector(spec.AllowedNamespaceSelector)
 		if err != nil {
-			if errors.IsNotFound(err) {
-				return this, this.failed(logger, false, fmt.Errorf("cannot get secret %s/%s for provider %s: %s",
-					ref.Namespace, ref.Name, provider.Description(), err), false)
-			}
-			return this, this.failed(logger, false, fmt.Errorf("error reading secret for provider %q", provider.Description()), true)
+			return this, this.failed(logger, false, fmt.Errorf("invalid allowedNamespaceSelector: %w", err), false)
 		}
-	} else {
+	}
+	if spec.SecretRef == nil && spec.Vault == nil {
 		return this, this.failed(logger, false, fmt.Errorf("no secret specified"), false)
 	}
-
-	this.account, err = state.GetDNSAccount(logger, provider, props)
-	if err != nil {
-		return this, this.failed(logger, false, err, true)
+	if spec.SecretRef != nil {
+		this.secret = secretName(provider, spec.SecretRef)
+	}
+	if spec.SecondarySecretRef != nil {
+		this.secondarySecret = secretName(provider, spec.SecondarySecretRef)
 	}
 
-	zones, err := this.account.GetZones()
+	var account *DNSAccount
+	var zones DNSHostedZones
+	props, temp, err := this.primaryCredentials()
+	if err == nil {
+		account, zones, temp, err = this.setupAccount(logger, props)
+	}
+	this.account = account
+	if err != nil && this.secondarySecret != nil {
+		logger.Warnf("primary credentials failed: %s -> failing over to secondary secret %s", err, this.secondarySecret)
+		var szones DNSHostedZones
+		account = nil
+		props, _, serr := this.secretProperties(this.secondarySecret)
+		if serr == nil {
+			account, szones, _, serr = this.setupAccount(logger, props)
+		}
+		if serr == nil {
+			this.object.Eventf(corev1.EventTypeWarning, "failover", "primary credentials failed, using secondary secret %s: %s", this.secondarySecret, err)
+			primary := this.account
+			this.account = account
+			this.failover = true
+			this.releaseUnusedAccount(logger, primary, last)
+			zones, err = szones, nil
+		} else {
+			this.releaseUnusedAccount(logger, account, last)
+			err = fmt.Errorf("%s (secondary secret: %s)", err, serr)
+		}
+	}
 	if err != nil {
 		this.zones = nil
-		return this, this.failed(logger, false, fmt.Errorf("cannot get hosted zones: %w", err), true)
+		return this, this.failed(logger, false, err, temp)
 	}
 	if len(zones) == 0 {
 		empty := utils.StringSet{}
@@ -414,6 +602,7 @@ func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutil
 		return this, this.failedButRecheck(logger, fmt.Errorf("no hosted zones available in account"), mod)
 	}
 
+	lastIncludedZones := this.included_zones
 	results := selection.CalcZoneAndDomainSelection(provider.DNSProvider().Spec, toLightZones(zones))
 	this.zones = fromLightZones(results.Zones)
 	this.included = results.DomainSel.Include
@@ -423,6 +612,9 @@ func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutil
 	for _, warning := range results.Warnings {
 		this.object.Eventf(corev1.EventTypeWarning, "reconcile", "%s", warning)
 	}
+	if added, deleted := lastIncludedZones.DiffFrom(this.included_zones); len(added) > 0 || len(deleted) > 0 {
+		this.object.Eventf(corev1.EventTypeNormal, "zones", "%s", zoneChangeMessage(added, deleted))
+	}
 	mod := this.object.SetSelection(this.included, this.excluded, &this.object.Status().Domains)
 	mod = this.object.SetSelection(this.included_zones, this.excluded_zones, &this.object.Status().Zones) || mod
 	if results.Error != "" {
@@ -469,12 +661,127 @@ func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutil
 		}
 	}
 
+	if state.config.CheckPermissions {
+		this.checkPermissions(logger, last)
+	}
+
 	this.valid = true
 	this.rateLimit = state.updateProviderRateLimiter(logger, provider)
 
 	return this, this.succeeded(logger, mod)
 }
 
+// checkPermissions probes the provider API permissions needed for the included zones.
+// Newly missing permissions are reported by an event.
+func (this *dnsProviderVersion) checkPermissions(logger logger.LogContext, last *dnsProviderVersion) {
+	var zones DNSHostedZones
+	for _, z := range this.zones {
+		if this.IncludesZone(z.Id()) {
+			zones = append(zones, z)
+		}
+	}
+	this.missingPermissions = this.account.CheckPermissions(zones)
+	if len(this.missingPermissions) == 0 {
+		return
+	}
+	if last == nil || !utils.NewStringSet(last.missingPermissions...).Equals(utils.NewStringSet(this.missingPermissions...)) {
+		msg := strings.Join(this.missingPermissions, "; ")
+		logger.Warnf("missing permissions: %s", msg)
+		this.object.Eventf(corev1.EventTypeWarning, "permissions", "missing permissions: %s", msg)
+	}
+}
+
+func secretName(provider *dnsutils.DNSProviderObject, ref *corev1.SecretReference) resources.ObjectName {
+	if ref.Namespace == "" {
+		return resources.NewObjectName(provider.GetNamespace(), ref.Name)
+	}
+	return resources.NewObjectName(ref.Namespace, ref.Name)
+}
+
+// primaryCredentials returns the credential properties read from Vault or the primary secret.
+// The flag indicates a temporary error.
+func (this *dnsProviderVersion) primaryCredentials() (utils.Properties, bool, error) {
+	if ref := this.object.DNSProvider().Spec.Vault; ref != nil {
+		props, refresh, err := this.state.vault.GetCredentials(this.ObjectName(), ref)
+		this.refresh = refresh
+		return props, true, err
+	}
+	return this.secretProperties(this.secret)
+}
+
+// secretProperties returns the credential properties of a secret. The flag indicates a temporary error.
+func (this *dnsProviderVersion) secretProperties(secret resources.ObjectName) (utils.Properties, bool, error) {
+	provider := this.object
+	ref := &corev1.SecretReference{Namespace: secret.Namespace(), Name: secret.Name()}
+	props, _, err := this.state.GetContext().GetSecretPropertiesByRef(provider, ref)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, false, fmt.Errorf("cannot get secret %s/%s for provider %s: %s",
+				ref.Namespace, ref.Name, provider.Description(), err)
+		}
+		return nil, true, fmt.Errorf("error reading secret %s/%s for provider %q", ref.Namespace, ref.Name, provider.Description())
+	}
+	props, err = this.state.sops.Decrypt(props)
+	if err != nil {
+		return nil, true, fmt.Errorf("secret %s/%s for provider %s: %s", ref.Namespace, ref.Name, provider.Description(), err)
+	}
+	return props, false, nil
+}
+
+// setupAccount gets the account for the given credentials and reads its hosted zones.
+// The account is returned even if the zones cannot be read. The flag indicates a temporary error.
+func (this *dnsProviderVersion) setupAccount(logger logger.LogContext, props utils.Properties) (*DNSAccount, DNSHostedZones, bool, error) {
+	provider := this.object
+	account, err := this.state.GetDNSAccount(logger, provider, props)
+	if err != nil {
+		return nil, nil, true, err
+	}
+
+	zones, err := account.GetZones()
+	if err != nil {
+		return account, nil, true, fmt.Errorf("cannot get hosted zones: %w", err)
+	}
+	this.zonesFetched = time.Now()
+	return account, zones, false, nil
+}
+
+// releaseUnusedAccount releases an account acquired for failed credentials.
+// The account of the last version is released by the caller if it is not used anymore.
+func (this *dnsProviderVersion) releaseUnusedAccount(logger logger.LogContext, account *DNSAccount, last *dnsProviderVersion) {
+	if account != nil && account != this.account && (last == nil || last.account != account) {
+		this.state.accountCache.Release(logger, account, this.ObjectName())
+	}
+}
+
+// secrets returns the names of all secrets referenced by the provider.
+func (this *dnsProviderVersion) secrets() resources.ObjectNameSet {
+	set := resources.ObjectNameSet{}
+	if this.secret != nil {
+		set.Add(this.secret)
+	}
+	if this.secondarySecret != nil {
+		set.Add(this.secondarySecret)
+	}
+	return set
+}
+
+func zoneChangeMessage(added, deleted utils.StringSet) string {
+	var parts []string
+	if len(added) > 0 {
+		parts = append(parts, "added "+sortedList(added))
+	}
+	if len(deleted) > 0 {
+		parts = append(parts, "removed "+sortedList(deleted))
+	}
+	return "included zones changed: " + strings.Join(parts, ", ")
+}
+
+func sortedList(set utils.StringSet) string {
+	list := set.AsArray()
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
+
 func toLightZones(zones DNSHostedZones) []selection.LightDNSHostedZone {
 	lzones := make([]selection.LightDNSHostedZone, len(zones), len(zones))
 	for i, z := range zones {
@@ -495,6 +802,13 @@ func (this *dnsProviderVersion) AccountHash() string {
 	return this.account.Hash()
 }
 
+func (this *dnsProviderVersion) QuotaUsage() float64 {
+	if this.account == nil {
+		return 0
+	}
+	return this.account.QuotaUsage()
+}
+
 func (this *dnsProviderVersion) ObjectName() resources.ObjectName {
 	return this.object.ObjectName()
 }
@@ -598,13 +912,30 @@ func (this *dnsProviderVersion) succeeded(logger logger.LogContext, modified boo
 	status := &this.object.DNSProvider().Status
 	mod := resources.NewModificationState(this.object, modified)
 	mod.AssureStringValue(&status.State, api.STATE_READY)
-	mod.AssureStringPtrValue(&status.Message, "provider operational")
+	msg := "provider operational"
+	if this.failover {
+		msg = fmt.Sprintf("%s (using secondary secret %s)", msg, this.secondarySecret)
+	}
+	if len(this.missingPermissions) > 0 {
+		msg = fmt.Sprintf("%s, but missing permissions: %s", msg, strings.Join(this.missingPermissions, "; "))
+	}
+	mod.AssureStringPtrValue(&status.Message, msg)
 	mod.AssureInt64Value(&status.ObservedGeneration, this.object.DNSProvider().Generation)
 	mod.AssureInt64PtrValue(&status.DefaultTTL, this.defaultTTL)
 	assureRateLimit(mod, &status.RateLimit, this.rateLimit)
+	mod.Modify(this.object.AcknowledgeReadyConditions())
 	if mod.IsModified() {
 		dnsutils.SetLastUpdateTime(&this.object.Status().LastUptimeTime)
 	}
+	interval := this.refresh // credentials read from Vault must be renewed
+	if this.account != nil {
+		if resync := this.account.SyncConfig().ResyncPeriod; resync > 0 && (interval == 0 || resync < interval) {
+			interval = resync
+		}
+	}
+	if interval > 0 {
+		return reconcile.UpdateStatus(logger, mod, interval)
+	}
 	return reconcile.UpdateStatus(logger, mod)
 }
 
@@ -636,3 +967,13 @@ func (this *dnsProviderVersion) GetDedicatedDNSAccess() DedicatedDNSAccess {
 	h, _ := this.account.handler.(DedicatedDNSAccess)
 	return h
 }
+
+func (this *dnsProviderVersion) GetZoneManagement() DNSZoneManagement {
+	h, _ := this.account.handler.(DNSZoneManagement)
+	return h
+}
+
+func (this *dnsProviderVersion) GetHealthCheckManagement() DNSHealthCheckManagement {
+	h, _ := this.account.handler.(DNSHealthCheckManagement)
+	return h
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Provider version", func() {
+	newVersion := func(account *DNSAccount, domain string) *dnsProviderVersion {
+		return &dnsProviderVersion{
+			account:     account,
+			valid:       true,
+			secret:      resources.NewObjectName("ns", "secret"),
+			zones:       DNSHostedZones{NewDNSHostedZone("mock-inmemory", "Z1", "example.com", "", nil, false)},
+			def_include: utils.NewStringSet(domain),
+			def_exclude: utils.StringSet{},
+			included:    utils.NewStringSet(domain),
+			excluded:    utils.StringSet{},
+		}
+	}
+
+	ginkgov2.It("swaps a changed account in place", func() {
+		a1 := NewDNSAccount(nil, nil, "hash1")
+		a2 := NewDNSAccount(nil, nil, "hash2")
+		last := newVersion(a1, "example.com")
+
+		Ω(newVersion(a2, "example.com").equivalentTo(last)).Should(BeTrue())
+		Ω(newVersion(a2, "example.com").accountSwapped(last)).Should(BeTrue())
+		Ω(newVersion(a1, "example.com").accountSwapped(last)).Should(BeFalse())
+		Ω(newVersion(a2, "sub.example.com").accountSwapped(last)).Should(BeFalse())
+
+		invalid := newVersion(a2, "example.com")
+		invalid.valid = false
+		Ω(invalid.equivalentTo(last)).Should(BeFalse())
+	})
+})
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+const (
+	reverseZoneIPv4 = "in-addr.arpa"
+	reverseZoneIPv6 = "ip6.arpa"
+)
+
+// isReverseZone checks whether a hosted zone serves reverse lookups.
+func isReverseZone(domain string) bool {
+	domain = strings.ToLower(dns.NormalizeHostname(domain))
+	return dnsutils.Match(domain, reverseZoneIPv4) || dnsutils.Match(domain, reverseZoneIPv6)
+}
+
+// reverseName returns the name of the PTR record for an IP address.
+func reverseName(address string) (string, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return "", fmt.Errorf("invalid IP address %q", address)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return fmt.Sprintf("%d.%d.%d.%d.%s", ip4[3], ip4[2], ip4[1], ip4[0], reverseZoneIPv4), nil
+	}
+	const hexDigits = "0123456789abcdef"
+	labels := make([]string, 0, 2*net.IPv6len+1)
+	for i := net.IPv6len - 1; i >= 0; i-- {
+		labels = append(labels, string(hexDigits[ip[i]&0x0f]), string(hexDigits[ip[i]>>4]))
+	}
+	return strings.Join(append(labels, reverseZoneIPv6), "."), nil
+}
+
+// ptrRecordSet is a PTR record set maintained for the address records of DNS entries.
+type ptrRecordSet struct {
+	name      dns.DNSSetName
+	createdAt time.Time
+	spec      *ptrTargetSpec
+}
+
+// ptrTargetSpec is the target spec of a PTR record set.
+type ptrTargetSpec struct {
+	ownerId string
+	targets []Target
+}
+
+var _ TargetSpec = &ptrTargetSpec{}
+
+func (this *ptrTargetSpec) Kind() string                        { return api.DNSEntryKind }
+func (this *ptrTargetSpec) OwnerId() string                     { return this.ownerId }
+func (this *ptrTargetSpec) Targets() []Target                   { return this.targets }
+func (this *ptrTargetSpec) RoutingPolicy() *dns.RoutingPolicy   { return nil }
+func (this *ptrTargetSpec) DeletionPolicy() *api.DeletionPolicy { return nil }
+func (this *ptrTargetSpec) Responsible(set *dns.DNSSet, ownership dns.Ownership) bool {
+	return !set.IsForeign(ownership)
+}
+
+// ptrRecordSetsForZone calculates the PTR record sets of a reverse zone for the A and AAAA records
+// of all valid entries. A PTR record set is placed in the most specific reverse zone.
+// The state lock must be held.
+func (this *state) ptrRecordSetsForZone(zone *dnsHostedZone) []*ptrRecordSet {
+	type ptrSource struct {
+		entry *Entry
+		ttl   int64
+	}
+	sources := map[string][]ptrSource{}
+	for _, e := range this.entries {
+		if !e.IsValid() || e.IsDeleting() || e.IsOutOfSchedule() || e.Kind() == api.DNSLockKind {
+			continue
+		}
+		if e.ZoneId().ID == "" || strings.HasPrefix(e.DNSName(), "*.") {
+			continue
+		}
+		if z := this.zones[e.ZoneId()]; z == nil || isReverseZone(z.Domain()) {
+			continue
+		}
+		for _, t := range e.Targets() {
+			if t.GetRecordType() != dns.RS_A && t.GetRecordType() != dns.RS_AAAA {
+				continue
+			}
+			name, err := reverseName(t.GetHostName())
+			if err != nil {
+				continue
+			}
+			if !dnsutils.Match(name, zone.Domain()) || !containsZone(this.getZonesForName(name), zone.Id()) {
+				continue
+			}
+			sources[name] = append(sources[name], ptrSource{entry: e, ttl: t.GetTTL()})
+		}
+	}
+
+	var sets []*ptrRecordSet
+	for name, list := range sources {
+		sort.Slice(list, func(i, j int) bool {
+			if !list[i].entry.CreatedAt().Equal(list[j].entry.CreatedAt()) {
+				return list[i].entry.CreatedAt().Before(list[j].entry.CreatedAt())
+			}
+			return list[i].entry.ObjectName().String() < list[j].entry.ObjectName().String()
+		})
+		spec := &ptrTargetSpec{ownerId: list[0].entry.OwnerId()}
+		hosts := map[string]bool{}
+		for _, s := range list {
+			host := dns.AlignHostname(s.entry.DNSName())
+			if !hosts[host] {
+				hosts[host] = true
+				spec.targets = append(spec.targets, dnsutils.NewTarget(dns.RS_PTR, host, list[0].ttl))
+			}
+		}
+		sort.Slice(spec.targets, func(i, j int) bool { return spec.targets[i].GetHostName() < spec.targets[j].GetHostName() })
+		sets = append(sets, &ptrRecordSet{name: dns.DNSSetName{DNSName: name}, createdAt: list[0].entry.CreatedAt(), spec: spec})
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i].name.DNSName < sets[j].name.DNSName })
+	return sets
+}
+
+func containsZone(zones []*dnsHostedZone, zoneid dns.ZoneID) bool {
+	for _, z := range zones {
+		if z.Id() == zoneid {
+			return true
+		}
+	}
+	return false
+}
+
+// triggerReverseZones triggers the reconciliation of all reverse zones to update the PTR records.
+func (this *state) triggerReverseZones(logger logger.LogContext) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for zoneid, zone := range this.zones {
+		if isReverseZone(zone.Domain()) {
+			logger.Infof("trigger reverse zone %s", zoneid)
+			this.triggerHostedZone(zoneid)
+		}
+	}
+}
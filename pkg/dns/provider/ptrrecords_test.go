@@ -0,0 +1,111 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+type ptrTestSpec struct {
+	duplicateTestSpec
+}
+
+func (this *ptrTestSpec) IsDeleting() bool {
+	return false
+}
+
+var _ = ginkgov2.Describe("PTR records", func() {
+	now := time.Now()
+	forward := dns.NewZoneID("mock", "forward")
+	reverse := dns.NewZoneID("mock", "reverse")
+	reverseSub := dns.NewZoneID("mock", "reverse-sub")
+
+	newEntry := func(name, dnsname string, age time.Duration, targets ...string) *Entry {
+		spec := &ptrTestSpec{duplicateTestSpec{name: resources.NewObjectName("default", name)}}
+		v := &EntryVersion{object: spec, valid: true, dnsSetName: dns.DNSSetName{DNSName: dnsname}}
+		v.status.ProviderType = &forward.ProviderType
+		v.status.Zone = &forward.ID
+		for _, t := range targets {
+			v.targets = append(v.targets, dnsutils.NewTarget(dns.RS_A, t, 300))
+		}
+		return &Entry{EntryVersion: v, createdAt: now.Add(-age)}
+	}
+	newZone := func(id dns.ZoneID, domain string) *dnsHostedZone {
+		return newDNSHostedZone(time.Second, NewDNSHostedZone(id.ProviderType, id.ID, domain, "", nil, false))
+	}
+
+	ginkgov2.It("calculates reverse names", func() {
+		name, err := reverseName("10.1.2.3")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(name).Should(Equal("3.2.1.10.in-addr.arpa"))
+
+		name, err = reverseName("2001:db8::567:89ab")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(name).Should(Equal("b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"))
+
+		_, err = reverseName("www.example.com")
+		Ω(err).Should(HaveOccurred())
+	})
+
+	ginkgov2.It("detects reverse zones", func() {
+		Ω(isReverseZone("10.in-addr.arpa")).Should(BeTrue())
+		Ω(isReverseZone("8.b.d.0.1.0.0.2.ip6.arpa.")).Should(BeTrue())
+		Ω(isReverseZone("in-addr.arpa.example.com")).Should(BeFalse())
+	})
+
+	ginkgov2.It("maps address records of entries to the most specific reverse zone", func() {
+		s := &state{
+			zones: map[dns.ZoneID]*dnsHostedZone{
+				forward:    newZone(forward, "example.com"),
+				reverse:    newZone(reverse, "10.in-addr.arpa"),
+				reverseSub: newZone(reverseSub, "1.10.in-addr.arpa"),
+			},
+			entries: Entries{},
+		}
+		for _, e := range []*Entry{
+			newEntry("a", "a.example.com", time.Hour, "10.1.0.1", "10.2.0.1"),
+			newEntry("b", "b.example.com", time.Minute, "10.2.0.1"),
+			newEntry("c", "c.example.com", time.Minute, "192.168.0.1"),
+		} {
+			s.entries[e.ObjectName()] = e
+		}
+		invalid := newEntry("d", "d.example.com", time.Minute, "10.3.0.1")
+		invalid.valid = false
+		s.entries[invalid.ObjectName()] = invalid
+
+		sets := s.ptrRecordSetsForZone(s.zones[reverse])
+		Ω(sets).Should(HaveLen(1))
+		Ω(sets[0].name.DNSName).Should(Equal("1.0.2.10.in-addr.arpa"))
+		Ω(sets[0].createdAt).Should(Equal(now.Add(-time.Hour)))
+		Ω(sets[0].spec.Targets()).Should(Equal([]Target{
+			dnsutils.NewTarget(dns.RS_PTR, "a.example.com.", 300),
+			dnsutils.NewTarget(dns.RS_PTR, "b.example.com.", 300),
+		}))
+
+		sets = s.ptrRecordSetsForZone(s.zones[reverseSub])
+		Ω(sets).Should(HaveLen(1))
+		Ω(sets[0].name.DNSName).Should(Equal("1.0.1.10.in-addr.arpa"))
+		Ω(sets[0].spec.Targets()).Should(Equal([]Target{dnsutils.NewTarget(dns.RS_PTR, "a.example.com.", 300)}))
+	})
+})
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// QUOTA_WINDOW is the sliding window for measuring the consumption of the request quota of an account.
+	QUOTA_WINDOW = 60 * time.Second
+	// QUOTA_MAX_BACKPRESSURE is the maximum factor for stretching the delay between two zone reconciliations.
+	QUOTA_MAX_BACKPRESSURE = 4.0
+)
+
+// quotaTracker is optionally implemented by the Metrics of a DNSHandlerConfig
+// to track the consumption of the request quota given by the rate limiter.
+type quotaTracker interface {
+	SetQuota(qps float32)
+	AddQuotaRequests(n int)
+}
+
+type quotaSlot struct {
+	second int64
+	count  int
+}
+
+// quotaUsage counts the requests of an account per second for the quota window.
+type quotaUsage struct {
+	lock  sync.Mutex
+	qps   float64
+	slots [int(QUOTA_WINDOW / time.Second)]quotaSlot
+}
+
+func (this *quotaUsage) setQuota(qps float32) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.qps = float64(qps)
+}
+
+func (this *quotaUsage) Quota() float64 {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.qps
+}
+
+// add counts n requests at the given time and returns the resulting usage.
+func (this *quotaUsage) add(now time.Time, n int) float64 {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	second := now.Unix()
+	slot := &this.slots[second%int64(len(this.slots))]
+	if slot.second != second {
+		slot.second = second
+		slot.count = 0
+	}
+	slot.count += n
+	return this.usage(second)
+}
+
+// Usage returns the ratio of the requests in the quota window to the request quota.
+// It is 0 if no quota is known.
+func (this *quotaUsage) Usage(now time.Time) float64 {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.usage(now.Unix())
+}
+
+func (this *quotaUsage) usage(second int64) float64 {
+	if this.qps <= 0 {
+		return 0
+	}
+	count := 0
+	for _, slot := range this.slots {
+		if slot.second > second-int64(len(this.slots)) && slot.second <= second {
+			count += slot.count
+		}
+	}
+	return float64(count) / (this.qps * QUOTA_WINDOW.Seconds())
+}
+
+// quotaBackpressure returns the factor for stretching the delay between two zone reconciliations.
+// It is 1 below the threshold (given in percent, disabled if 0) and grows linearly
+// up to QUOTA_MAX_BACKPRESSURE for a fully consumed quota.
+func quotaBackpressure(usage float64, threshold int) float64 {
+	limit := float64(threshold) / 100
+	if threshold <= 0 || usage <= limit {
+		return 1
+	}
+	if limit >= 1 {
+		return QUOTA_MAX_BACKPRESSURE
+	}
+	factor := 1 + (usage-limit)/(1-limit)*(QUOTA_MAX_BACKPRESSURE-1)
+	return math.Min(factor, QUOTA_MAX_BACKPRESSURE)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+type quotaCountingMetrics struct {
+	NullMetrics
+	qps      float32
+	requests int
+}
+
+func (m *quotaCountingMetrics) SetQuota(qps float32) {
+	m.qps = qps
+}
+
+func (m *quotaCountingMetrics) AddQuotaRequests(n int) {
+	m.requests += n
+}
+
+var _ = ginkgov2.Describe("Quota usage", func() {
+	ginkgov2.It("measures the requests in the quota window", func() {
+		usage := &quotaUsage{}
+		now := time.Unix(1000, 0)
+		Ω(usage.add(now, 30)).Should(Equal(0.0))
+
+		usage.setQuota(1)
+		Ω(usage.Usage(now)).Should(Equal(0.5))
+		Ω(usage.add(now.Add(30*time.Second), 15)).Should(Equal(0.75))
+		Ω(usage.Usage(now.Add(QUOTA_WINDOW))).Should(Equal(0.25))
+		Ω(usage.Usage(now.Add(2 * QUOTA_WINDOW))).Should(Equal(0.0))
+	})
+
+	ginkgov2.It("reuses outdated slots", func() {
+		usage := &quotaUsage{}
+		usage.setQuota(1)
+		now := time.Unix(1000, 0)
+		usage.add(now, 60)
+		Ω(usage.add(now.Add(QUOTA_WINDOW), 6)).Should(Equal(0.1))
+	})
+
+	ginkgov2.It("stretches the delay above the threshold", func() {
+		Ω(quotaBackpressure(0.9, 0)).Should(Equal(1.0))
+		Ω(quotaBackpressure(0.5, 80)).Should(Equal(1.0))
+		Ω(quotaBackpressure(0.9, 80)).Should(BeNumerically("~", 2.5, 1e-9))
+		Ω(quotaBackpressure(1.0, 80)).Should(BeNumerically("~", QUOTA_MAX_BACKPRESSURE, 1e-9))
+		Ω(quotaBackpressure(1.5, 80)).Should(Equal(QUOTA_MAX_BACKPRESSURE))
+		Ω(quotaBackpressure(1.5, 100)).Should(Equal(QUOTA_MAX_BACKPRESSURE))
+	})
+
+	ginkgov2.It("is tracked by the metered rate limiter", func() {
+		metrics := &quotaCountingMetrics{}
+		limiter := newMeteredRateLimiter(flowcontrol.NewTokenBucketRateLimiter(100, 2), &RateLimiterConfig{QPS: 100, Burst: 2}, metrics)
+		limiter.Accept()
+		limiter.Accept()
+		limiter.Accept()
+		Ω(metrics.qps).Should(Equal(float32(100)))
+		Ω(metrics.requests).Should(Equal(3))
+	})
+})
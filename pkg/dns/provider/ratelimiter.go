@@ -24,35 +24,39 @@ import (
 )
 
 type RateLimiterConfig struct {
-	QPS   float32
-	Burst int
+	QPS      float32
+	Burst    int
+	Adaptive bool
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 
 type RateLimiterOptions struct {
-	Enabled bool
-	QPS     int
-	Burst   int
+	Enabled  bool
+	QPS      int
+	Burst    int
+	Adaptive bool
 }
 
 var RateLimiterOptionDefaults = RateLimiterOptions{
-	Enabled: true,
-	QPS:     10,
-	Burst:   20,
+	Enabled:  true,
+	QPS:      10,
+	Burst:    20,
+	Adaptive: true,
 }
 
 func (this *RateLimiterOptions) AddOptionsToSet(set config.OptionSet) {
 	set.AddBoolOption(&this.Enabled, OPT_RATELIMITER_ENABLED, "", this.Enabled, "enables rate limiter for DNS provider requests")
 	set.AddIntOption(&this.QPS, OPT_RATELIMITER_QPS, "", this.QPS, "maximum requests/queries per second")
 	set.AddIntOption(&this.Burst, OPT_RATELIMITER_BURST, "", this.Burst, "number of burst requests for rate limiter")
+	set.AddBoolOption(&this.Adaptive, OPT_RATELIMITER_ADAPTIVE, "", this.Adaptive, "adapts the rate limiter to throttled provider responses (backing off from the configured qps and slowly ramping up again)")
 }
 
 func (c *RateLimiterOptions) GetRateLimiterConfig() *RateLimiterConfig {
 	if !c.Enabled {
 		return nil
 	}
-	return &RateLimiterConfig{QPS: float32(c.QPS), Burst: c.Burst}
+	return &RateLimiterConfig{QPS: float32(c.QPS), Burst: c.Burst, Adaptive: c.Adaptive}
 }
 
 // configuration helpers
@@ -72,10 +76,15 @@ func (c RateLimiterOptions) SetEnabled(enabled bool) RateLimiterOptions {
 	return c
 }
 
+func (c RateLimiterOptions) SetAdaptive(adaptive bool) RateLimiterOptions {
+	c.Adaptive = adaptive
+	return c
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func (c *RateLimiterConfig) String() string {
-	return fmt.Sprintf("QPS: %f, Burst: %d", c.QPS, c.Burst)
+	return fmt.Sprintf("QPS: %f, Burst: %d, Adaptive: %t", c.QPS, c.Burst, c.Adaptive)
 }
 
 func (c *RateLimiterConfig) NewRateLimiter() (flowcontrol.RateLimiter, error) {
@@ -90,9 +99,45 @@ func (c *RateLimiterConfig) NewRateLimiter() (flowcontrol.RateLimiter, error) {
 		return nil, fmt.Errorf("invalid burst value %d", c.Burst)
 	}
 
+	if c.Adaptive {
+		return newAdaptiveRateLimiter(c.QPS, c.Burst), nil
+	}
 	return flowcontrol.NewTokenBucketRateLimiter(c.QPS, c.Burst), nil
 }
 
 func AlwaysRateLimiter() flowcontrol.RateLimiter {
 	return flowcontrol.NewFakeAlwaysRateLimiter()
 }
+
+// meteredRateLimiter counts the requests which have to wait for a token of the rate limiter.
+// If the metrics track the quota consumption, all accepted requests are reported, too.
+type meteredRateLimiter struct {
+	flowcontrol.RateLimiter
+	metrics Metrics
+	quota   quotaTracker
+}
+
+// adaptiveRateLimiterUser is optionally implemented by the Metrics of a DNSHandlerConfig
+// to report throttled provider responses to an adaptive rate limiter.
+type adaptiveRateLimiterUser interface {
+	setAdaptiveRateLimiter(limiter *adaptiveRateLimiter)
+}
+
+func newMeteredRateLimiter(limiter flowcontrol.RateLimiter, cfg *RateLimiterConfig, metrics Metrics) *meteredRateLimiter {
+	metered := &meteredRateLimiter{RateLimiter: limiter, metrics: metrics}
+	if tracker, ok := metrics.(quotaTracker); ok && cfg != nil {
+		tracker.SetQuota(cfg.QPS)
+		metered.quota = tracker
+	}
+	return metered
+}
+
+func (this *meteredRateLimiter) Accept() {
+	if !this.RateLimiter.TryAccept() {
+		this.metrics.AddThrottledRequests(1)
+		this.RateLimiter.Accept()
+	}
+	if this.quota != nil {
+		this.quota.AddQuotaRequests(1)
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+type throttleCountingMetrics struct {
+	NullMetrics
+	throttled int
+}
+
+func (m *throttleCountingMetrics) AddThrottledRequests(n int) {
+	m.throttled += n
+}
+
+var _ = ginkgov2.Describe("Metered rate limiter", func() {
+	ginkgov2.It("counts requests waiting for a token", func() {
+		metrics := &throttleCountingMetrics{}
+		limiter := &meteredRateLimiter{RateLimiter: flowcontrol.NewTokenBucketRateLimiter(100, 2), metrics: metrics}
+		limiter.Accept()
+		limiter.Accept()
+		Ω(metrics.throttled).Should(Equal(0))
+		limiter.Accept()
+		Ω(metrics.throttled).Should(Equal(1))
+	})
+})
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/server"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+// ReadinessPath is the path of the HTTP endpoint reporting the readiness of the DNS controllers.
+const ReadinessPath = "/readyz"
+
+// readiness states of a provider
+const (
+	PROVIDER_READY   = "ready"
+	PROVIDER_PENDING = "pending"
+	PROVIDER_INVALID = "invalid"
+	PROVIDER_STALE   = "stale"
+)
+
+// Readiness is the readiness of all DNS controllers served by the readiness endpoint.
+type Readiness struct {
+	Ready       bool                  `json:"ready"`
+	Controllers []ControllerReadiness `json:"controllers"`
+}
+
+// ControllerReadiness is the readiness of a DNS controller.
+// It is ready if the setup is done, all providers are validated and their zone lists are fresh.
+// Invalid providers are reported, but don't affect the readiness.
+type ControllerReadiness struct {
+	Controller  string              `json:"controller"`
+	Ready       bool                `json:"ready"`
+	Initialized bool                `json:"initialized"`
+	Providers   []ProviderReadiness `json:"providers"`
+}
+
+// ProviderReadiness is the readiness of a provider.
+type ProviderReadiness struct {
+	Name         string     `json:"name"`
+	Type         string     `json:"type"`
+	Status       string     `json:"status"`
+	Message      string     `json:"message,omitempty"`
+	ZonesFetched *time.Time `json:"zonesFetched,omitempty"`
+}
+
+var readiness = &readinessHandler{}
+
+// readinessHandler serves the readiness of all DNS controllers.
+type readinessHandler struct {
+	lock   sync.Mutex
+	states []*state
+}
+
+func registerReadiness(state *state) {
+	readiness.lock.Lock()
+	defer readiness.lock.Unlock()
+	if len(readiness.states) == 0 {
+		server.RegisterHandler(ReadinessPath, readiness)
+	}
+	readiness.states = append(readiness.states, state)
+}
+
+func (this *readinessHandler) getStates() []*state {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return append([]*state{}, this.states...)
+}
+
+// ServeHTTP responds with status 200 if all DNS controllers are ready, and with 503 otherwise.
+// The body contains the readiness details per controller and provider.
+func (this *readinessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	result := &Readiness{Ready: true, Controllers: []ControllerReadiness{}}
+	for _, s := range this.getStates() {
+		c := s.readiness(time.Now())
+		result.Ready = result.Ready && c.Ready
+		result.Controllers = append(result.Controllers, c)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if !result.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(result)
+}
+
+func (this *state) readiness(now time.Time) ControllerReadiness {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	result := ControllerReadiness{
+		Controller:  this.config.Ident,
+		Initialized: this.initialized,
+		Ready:       this.initialized,
+		Providers:   []ProviderReadiness{},
+	}
+	for _, p := range this.providers {
+		pr := p.readiness(now, this.config.ReadinessZonesMaxAge)
+		if pr.Status == PROVIDER_PENDING || pr.Status == PROVIDER_STALE {
+			result.Ready = false
+		}
+		result.Providers = append(result.Providers, pr)
+	}
+	sort.Slice(result.Providers, func(i, j int) bool { return result.Providers[i].Name < result.Providers[j].Name })
+	return result
+}
+
+func (this *dnsProviderVersion) readiness(now time.Time, maxAge time.Duration) ProviderReadiness {
+	status := this.object.Status()
+	result := ProviderReadiness{
+		Name:    this.ObjectName().String(),
+		Type:    this.TypeCode(),
+		Message: utils.StringValue(status.Message),
+	}
+	if !this.zonesFetched.IsZero() {
+		fetched := this.zonesFetched
+		result.ZonesFetched = &fetched
+	}
+	validated := status.State != "" && status.ObservedGeneration >= this.object.GetGeneration()
+	result.Status = providerReadinessStatus(validated, this.valid, this.zonesFetched, now, maxAge)
+	if result.Status == PROVIDER_STALE {
+		result.Message = "hosted zones not fetched within " + maxAge.String()
+	}
+	return result
+}
+
+// providerReadinessStatus determines the readiness status of a provider.
+// The zone list of a valid provider is stale if it is older than the given maximum age (not checked if 0).
+func providerReadinessStatus(validated, valid bool, zonesFetched, now time.Time, maxAge time.Duration) string {
+	switch {
+	case !validated:
+		return PROVIDER_PENDING
+	case !valid:
+		return PROVIDER_INVALID
+	case maxAge > 0 && now.Sub(zonesFetched) > maxAge:
+		return PROVIDER_STALE
+	}
+	return PROVIDER_READY
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+)
+
+var _ = ginkgov2.Describe("Readiness", func() {
+	now := time.Now()
+
+	ginkgov2.It("determines the provider status", func() {
+		Ω(providerReadinessStatus(false, true, now, now, time.Minute)).Should(Equal(PROVIDER_PENDING))
+		Ω(providerReadinessStatus(true, false, time.Time{}, now, time.Minute)).Should(Equal(PROVIDER_INVALID))
+		Ω(providerReadinessStatus(true, true, now.Add(-time.Second), now, time.Minute)).Should(Equal(PROVIDER_READY))
+		Ω(providerReadinessStatus(true, true, now.Add(-2*time.Minute), now, time.Minute)).Should(Equal(PROVIDER_STALE))
+		Ω(providerReadinessStatus(true, true, now.Add(-2*time.Minute), now, 0)).Should(Equal(PROVIDER_READY))
+	})
+
+	ginkgov2.It("is ready after the setup of all controllers", func() {
+		s1 := &state{config: Config{Ident: "c1"}, providers: map[resources.ObjectName]*dnsProviderVersion{}, initialized: true}
+		s2 := &state{config: Config{Ident: "c2"}, providers: map[resources.ObjectName]*dnsProviderVersion{}}
+		handler := &readinessHandler{states: []*state{s1, s2}}
+
+		serve := func() (int, *Readiness) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadinessPath, nil))
+			result := &Readiness{}
+			Ω(json.Unmarshal(rec.Body.Bytes(), result)).Should(Succeed())
+			return rec.Code, result
+		}
+
+		code, result := serve()
+		Ω(code).Should(Equal(http.StatusServiceUnavailable))
+		Ω(result.Ready).Should(BeFalse())
+		Ω(result.Controllers).Should(HaveLen(2))
+		Ω(result.Controllers[0].Ready).Should(BeTrue())
+		Ω(result.Controllers[1].Initialized).Should(BeFalse())
+
+		s2.initialized = true
+		code, result = serve()
+		Ω(code).Should(Equal(http.StatusOK))
+		Ω(result.Ready).Should(BeTrue())
+	})
+})
@@ -0,0 +1,236 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// recordStore is a compact representation of the DNS sets of a hosted zone used by the
+// zone state cache. The record sets are kept in maps per record type and all DNS names
+// and record values are interned, so that the many identical values of large zones
+// (e.g. owner and prefix attributes or common targets) are stored only once. This avoids
+// the DNSSet, RecordSet and Record objects per DNS name, which dominate the memory
+// footprint of zones with millions of records.
+type recordStore struct {
+	strings stringInterner
+	types   map[string]map[dns.DNSSetName]*storedRecordSet
+	// attributes of the DNS sets beside the record sets, only stored if set
+	meta map[dns.DNSSetName]*storedSetMeta
+}
+
+type storedRecordSet struct {
+	ttl       int64
+	ignoreTTL bool
+	values    []string
+}
+
+type storedSetMeta struct {
+	kind                string
+	updateGroup         string
+	routingPolicy       *dns.RoutingPolicy
+	externalDNSOwner    string
+	externalDNSRegistry []*dns.DNSSet
+}
+
+// stringInterner keeps a single instance of every string with its number of references,
+// so that strings no longer referenced by the store are dropped.
+type stringInterner map[string]internedString
+
+type internedString struct {
+	value string
+	refs  int
+}
+
+func (this stringInterner) intern(s string) string {
+	if s == "" {
+		return s
+	}
+	i, ok := this[s]
+	if !ok {
+		i.value = s
+	}
+	i.refs++
+	this[s] = i
+	return i.value
+}
+
+func (this stringInterner) release(s string) {
+	if s == "" {
+		return
+	}
+	if i, ok := this[s]; ok {
+		if i.refs <= 1 {
+			delete(this, s)
+		} else {
+			i.refs--
+			this[s] = i
+		}
+	}
+}
+
+func newRecordStore(dnssets dns.DNSSets) *recordStore {
+	store := &recordStore{
+		strings: stringInterner{},
+		types:   map[string]map[dns.DNSSetName]*storedRecordSet{},
+		meta:    map[dns.DNSSetName]*storedSetMeta{},
+	}
+	for _, dnsset := range dnssets {
+		for _, rs := range dnsset.Sets {
+			store.setRecordSet(dnsset.Name, rs)
+		}
+		store.setMeta(dnsset.Name, &storedSetMeta{
+			kind:                dnsset.Kind,
+			updateGroup:         dnsset.UpdateGroup,
+			routingPolicy:       dnsset.RoutingPolicy.Clone(),
+			externalDNSOwner:    dnsset.ExternalDNSOwner,
+			externalDNSRegistry: dnsset.ExternalDNSRegistry,
+		})
+	}
+	return store
+}
+
+// internName interns the strings of a DNS set name. Every map entry keyed by the name holds
+// its own references, which are released by releaseName when the entry is removed.
+func (this *recordStore) internName(name dns.DNSSetName) dns.DNSSetName {
+	return dns.DNSSetName{DNSName: this.strings.intern(name.DNSName), SetIdentifier: this.strings.intern(name.SetIdentifier)}
+}
+
+func (this *recordStore) releaseName(name dns.DNSSetName) {
+	this.strings.release(name.DNSName)
+	this.strings.release(name.SetIdentifier)
+}
+
+func (this *recordStore) releaseRecordSet(name dns.DNSSetName, stored *storedRecordSet) {
+	this.releaseName(name)
+	for _, value := range stored.values {
+		this.strings.release(value)
+	}
+}
+
+func (this *recordStore) releaseMeta(name dns.DNSSetName, meta *storedSetMeta) {
+	this.releaseName(name)
+	this.strings.release(meta.kind)
+	this.strings.release(meta.updateGroup)
+}
+
+func (this *recordStore) setRecordSet(name dns.DNSSetName, rs *dns.RecordSet) {
+	sets := this.types[rs.Type]
+	if sets == nil {
+		sets = map[dns.DNSSetName]*storedRecordSet{}
+		this.types[this.strings.intern(rs.Type)] = sets
+	}
+	stored := &storedRecordSet{ttl: rs.TTL, ignoreTTL: rs.IgnoreTTL}
+	if len(rs.Records) > 0 {
+		stored.values = make([]string, len(rs.Records))
+		for i, r := range rs.Records {
+			stored.values[i] = this.strings.intern(r.Value)
+		}
+	}
+	if old := sets[name]; old != nil {
+		this.releaseRecordSet(name, old)
+	}
+	sets[this.internName(name)] = stored
+}
+
+func (this *recordStore) setMeta(name dns.DNSSetName, meta *storedSetMeta) {
+	// intern the new strings before releasing the old ones, which may be the same
+	meta.kind = this.strings.intern(meta.kind)
+	meta.updateGroup = this.strings.intern(meta.updateGroup)
+	if old := this.meta[name]; old != nil {
+		this.releaseMeta(name, old)
+		delete(this.meta, name)
+	}
+	if meta.kind == "" && meta.updateGroup == "" && meta.routingPolicy == nil &&
+		meta.externalDNSOwner == "" && meta.externalDNSRegistry == nil {
+		return
+	}
+	this.meta[this.internName(name)] = meta
+}
+
+func (this *recordStore) hasRecordSets(name dns.DNSSetName) bool {
+	for _, sets := range this.types {
+		if _, ok := sets[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AddRecordSet adds or replaces a record set like DNSSets.AddRecordSet.
+func (this *recordStore) AddRecordSet(name dns.DNSSetName, policy *dns.RoutingPolicy, rs *dns.RecordSet) {
+	if rs.Type == dns.RS_CNAME {
+		rs = rs.Clone()
+		for _, r := range rs.Records {
+			r.Value = dns.NormalizeHostname(r.Value)
+		}
+	}
+	meta := &storedSetMeta{}
+	if this.hasRecordSets(name) {
+		if old := this.meta[name]; old != nil {
+			*meta = *old
+		}
+	}
+	this.setRecordSet(name, rs)
+	meta.routingPolicy = policy.Clone()
+	this.setMeta(name, meta)
+}
+
+// RemoveRecordSet removes a record set like DNSSets.RemoveRecordSet.
+func (this *recordStore) RemoveRecordSet(name dns.DNSSetName, recordSetType string) {
+	if sets := this.types[recordSetType]; sets != nil {
+		if old := sets[name]; old != nil {
+			this.releaseRecordSet(name, old)
+			delete(sets, name)
+		}
+		if len(sets) == 0 {
+			delete(this.types, recordSetType)
+			this.strings.release(recordSetType)
+		}
+	}
+	if meta := this.meta[name]; meta != nil && !this.hasRecordSets(name) {
+		this.releaseMeta(name, meta)
+		delete(this.meta, name)
+	}
+}
+
+// DNSSets returns the stored DNS sets.
+func (this *recordStore) DNSSets() dns.DNSSets {
+	dnssets := dns.DNSSets{}
+	for rtype, sets := range this.types {
+		for name, stored := range sets {
+			dnsset := dnssets[name]
+			if dnsset == nil {
+				dnsset = dns.NewDNSSet(name, nil)
+				if meta := this.meta[name]; meta != nil {
+					dnsset.Kind = meta.kind
+					dnsset.UpdateGroup = meta.updateGroup
+					dnsset.RoutingPolicy = meta.routingPolicy.Clone()
+					dnsset.ExternalDNSOwner = meta.externalDNSOwner
+					dnsset.ExternalDNSRegistry = meta.externalDNSRegistry
+				}
+				dnssets[name] = dnsset
+			}
+			rs := &dns.RecordSet{Type: rtype, TTL: stored.ttl, IgnoreTTL: stored.ignoreTTL}
+			for _, value := range stored.values {
+				rs.Records = append(rs.Records, &dns.Record{Value: value})
+			}
+			dnsset.Sets[rtype] = rs
+		}
+	}
+	return dnssets
+}
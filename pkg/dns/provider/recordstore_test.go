@@ -0,0 +1,205 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Record store", func() {
+	var dnssets dns.DNSSets
+
+	ginkgov2.BeforeEach(func() {
+		dnssets = dns.DNSSets{}
+		a := dns.NewDNSSet(dns.DNSSetName{DNSName: "a.example.com"}, nil)
+		a.SetRecordSet(dns.RS_A, 300, "1.2.3.4", "1.2.3.5")
+		a.SetOwner("owner1")
+		dnssets[a.Name] = a
+		b := dns.NewDNSSet(dns.DNSSetName{DNSName: "b.example.com", SetIdentifier: "eu"},
+			dns.NewRoutingPolicy(dns.RoutingPolicyWeighted, "weight", "10"))
+		b.SetRecordSet(dns.RS_CNAME, 120, "a.example.com")
+		b.UpdateGroup = "group1"
+		dnssets[b.Name] = b
+	})
+
+	ginkgov2.It("returns the stored DNS sets", func() {
+		store := newRecordStore(dnssets)
+		Ω(store.DNSSets()).Should(Equal(dnssets))
+	})
+
+	ginkgov2.It("applies changes like DNS sets", func() {
+		store := newRecordStore(dnssets)
+		added := dns.NewRecordSet(dns.RS_AAAA, 60, []*dns.Record{{Value: "::1"}})
+		for _, sets := range []interface {
+			AddRecordSet(dns.DNSSetName, *dns.RoutingPolicy, *dns.RecordSet)
+			RemoveRecordSet(dns.DNSSetName, string)
+		}{store, dnssets} {
+			sets.AddRecordSet(dns.DNSSetName{DNSName: "a.example.com"}, nil, added.Clone())
+			sets.AddRecordSet(dns.DNSSetName{DNSName: "c.example.com"}, nil, dns.NewRecordSet(dns.RS_CNAME, 60, []*dns.Record{{Value: "b.example.com."}}))
+			sets.RemoveRecordSet(dns.DNSSetName{DNSName: "b.example.com", SetIdentifier: "eu"}, dns.RS_CNAME)
+		}
+		Ω(store.DNSSets()).Should(Equal(dnssets))
+		Ω(dnssets).Should(HaveLen(2))
+		Ω(dnssets[dns.DNSSetName{DNSName: "c.example.com"}].Sets[dns.RS_CNAME].Records[0].Value).Should(Equal("b.example.com"))
+	})
+
+	ginkgov2.It("interns identical values", func() {
+		store := newRecordStore(dnssets)
+		store.AddRecordSet(dns.DNSSetName{DNSName: "d.example.com"}, nil, dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.2.3.4"}}))
+		Ω(store.strings).Should(HaveKeyWithValue("1.2.3.4", internedString{value: "1.2.3.4", refs: 2}))
+		Ω(refsOf(store.strings)).Should(Equal(referencesOf(store)))
+	})
+
+	ginkgov2.It("releases strings no longer referenced", func() {
+		store := newRecordStore(dnssets)
+		store.AddRecordSet(dns.DNSSetName{DNSName: "a.example.com"}, nil, dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.2.3.6"}}))
+		Ω(store.strings).ShouldNot(HaveKey("1.2.3.4"))
+		Ω(refsOf(store.strings)).Should(Equal(referencesOf(store)))
+
+		store.RemoveRecordSet(dns.DNSSetName{DNSName: "b.example.com", SetIdentifier: "eu"}, dns.RS_CNAME)
+		Ω(store.strings).ShouldNot(HaveKey("group1"))
+		Ω(store.strings).ShouldNot(HaveKey(dns.RS_CNAME))
+		Ω(refsOf(store.strings)).Should(Equal(referencesOf(store)))
+
+		for name, dnsset := range store.DNSSets() {
+			for rtype := range dnsset.Sets {
+				store.RemoveRecordSet(name, rtype)
+			}
+		}
+		Ω(store.strings).Should(BeEmpty())
+		Ω(store.types).Should(BeEmpty())
+		Ω(store.meta).Should(BeEmpty())
+	})
+})
+
+func refsOf(strings stringInterner) map[string]int {
+	refs := map[string]int{}
+	for s, i := range strings {
+		refs[s] = i.refs
+	}
+	return refs
+}
+
+// referencesOf counts the references of the non-empty strings held by the store.
+func referencesOf(store *recordStore) map[string]int {
+	refs := map[string]int{}
+	add := func(values ...string) {
+		for _, v := range values {
+			if v != "" {
+				refs[v]++
+			}
+		}
+	}
+	for rtype, sets := range store.types {
+		add(rtype)
+		for name, stored := range sets {
+			add(name.DNSName, name.SetIdentifier)
+			add(stored.values...)
+		}
+	}
+	for name, meta := range store.meta {
+		add(name.DNSName, name.SetIdentifier, meta.kind, meta.updateGroup)
+	}
+	return refs
+}
+
+// largeZone creates the DNS sets of a zone with an A and an owner TXT record set per name,
+// like the zone states read from a provider.
+func largeZone(names int) dns.DNSSets {
+	dnssets := dns.DNSSets{}
+	for i := 0; i < names; i++ {
+		name := fmt.Sprintf("host-%d.example.com", i)
+		dnssets.AddRecordSetFromProvider(name, dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: fmt.Sprintf("10.0.%d.%d", i/256%256, i%256)}}))
+		dnssets.AddRecordSetFromProvider("comment-"+name, dns.NewRecordSet(dns.RS_TXT, 300, []*dns.Record{
+			{Value: "\"owner=dnscontroller\""}, {Value: "\"prefix=comment-\""},
+		}))
+	}
+	return dnssets
+}
+
+func heapInUse() uint64 {
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+const benchmarkZoneNames = 100000
+
+func BenchmarkZoneStateDNSSets(b *testing.B) {
+	zone := largeZone(benchmarkZoneNames)
+	b.ReportAllocs()
+	b.ResetTimer()
+	var kept dns.DNSSets
+	for i := 0; i < b.N; i++ {
+		before := heapInUse()
+		kept = zone.Clone()
+		b.ReportMetric(float64(heapInUse()-before)/benchmarkZoneNames, "bytes/name")
+	}
+	runtime.KeepAlive(kept)
+}
+
+func BenchmarkZoneStateRecordStore(b *testing.B) {
+	zone := largeZone(benchmarkZoneNames)
+	b.ReportAllocs()
+	b.ResetTimer()
+	var kept *recordStore
+	for i := 0; i < b.N; i++ {
+		before := heapInUse()
+		kept = newRecordStore(zone)
+		b.ReportMetric(float64(heapInUse()-before)/benchmarkZoneNames, "bytes/name")
+	}
+	runtime.KeepAlive(kept)
+}
+
+// BenchmarkZoneStateReconcilePeak measures the memory of a cached zone state and the peak while the
+// zone is reconciled, as the change model works on the DNS sets materialized from the record store.
+func BenchmarkZoneStateReconcilePeak(b *testing.B) {
+	zone := largeZone(benchmarkZoneNames)
+	b.ReportAllocs()
+	b.ResetTimer()
+	var kept *recordStore
+	var materialized dns.DNSSets
+	for i := 0; i < b.N; i++ {
+		kept, materialized = nil, nil
+		before := heapInUse()
+		kept = newRecordStore(zone)
+		cached := heapInUse()
+		materialized = kept.DNSSets()
+		peak := heapInUse()
+		b.ReportMetric(float64(cached-before)/benchmarkZoneNames, "cached-bytes/name")
+		b.ReportMetric(float64(peak-before)/benchmarkZoneNames, "peak-bytes/name")
+	}
+	runtime.KeepAlive(kept)
+	runtime.KeepAlive(materialized)
+}
+
+func BenchmarkRecordStoreDNSSets(b *testing.B) {
+	store := newRecordStore(largeZone(benchmarkZoneNames))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		store.DNSSets()
+	}
+}
@@ -45,13 +45,14 @@ func (this *References) AddRef(holder resources.ClusterObjectKey, ref resources.
 	if ok && old == ref {
 		return
 	}
+	this.del(holder)
 	set := this.usages[ref]
 	if set == nil {
 		set = resources.ClusterObjectKeySet{}
 		this.usages[ref] = set
 	}
 	set.Add(holder)
-	this.del(holder)
+	this.refs[holder] = ref
 }
 
 func (this *References) DelRef(holder resources.ClusterObjectKey) {
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+type entryData struct {
+	resources.Object
+	entry *api.DNSEntry
+}
+
+func (this *entryData) Data() resources.ObjectData {
+	return this.entry
+}
+
+var _ = ginkgov2.Describe("Resolving targets to addresses", func() {
+	var (
+		now      time.Time
+		queries  int
+		zone     map[string][]dnsRecord
+		resolver *hostResolver
+	)
+
+	ginkgov2.BeforeEach(func() {
+		now = time.Now()
+		queries = 0
+		zone = map[string][]dnsRecord{
+			"lb.example.net.": {{name: "lb.example.net.", rtype: dnsTypeA, ttl: 30, value: "1.2.3.4"}, {name: "lb.example.net.", rtype: dnsTypeAAAA, ttl: 30, value: "2001:db8::1"}},
+		}
+		resolver = newHostResolver(nil, []string{"10.0.0.1:53"}, 10)
+		resolver.now = func() time.Time { return now }
+		resolver.query = func(server, name string, qtype uint16) (*dnsResponse, error) {
+			queries++
+			resp := &dnsResponse{rcode: dnsRcodeSuccess}
+			for _, r := range zone[name] {
+				if r.rtype == qtype {
+					resp.answer = append(resp.answer, r)
+				}
+			}
+			return resp, nil
+		}
+	})
+
+	newEntry := func(resolve *bool) dnsutils.DNSSpecification {
+		entry := &api.DNSEntry{}
+		entry.Spec.ResolveTargetsToAddresses = resolve
+		return &dnsutils.DNSEntryObject{Object: &entryData{entry: entry}}
+	}
+	newTargets := func(names ...string) Targets {
+		targets := Targets{}
+		for _, n := range names {
+			t, err := NewHostTargetFromEntryVersion(n, &EntryVersion{})
+			Ω(err).ShouldNot(HaveOccurred())
+			targets = append(targets, t)
+		}
+		return targets
+	}
+	records := func(targets Targets) []string {
+		result := []string{}
+		for _, t := range targets {
+			result = append(result, t.GetRecordType()+" "+t.GetHostName())
+		}
+		return result
+	}
+
+	ginkgov2.It("keeps a single hostname target as CNAME without option", func() {
+		for _, resolve := range []*bool{nil, boolPtr(false)} {
+			targets, multi, ok := normalizeTargets(logger.New(), resolver, newEntry(resolve), newTargets("lb.example.net")...)
+			Ω(multi).Should(BeFalse())
+			Ω(ok).Should(BeFalse())
+			Ω(records(targets)).Should(Equal([]string{dns.RS_CNAME + " lb.example.net"}))
+		}
+		Ω(queries).Should(Equal(0))
+	})
+
+	ginkgov2.It("resolves a single hostname target to addresses", func() {
+		targets, multi, ok := normalizeTargets(logger.New(), resolver, newEntry(boolPtr(true)), newTargets("lb.example.net")...)
+		Ω(multi).Should(BeTrue())
+		Ω(ok).Should(BeTrue())
+		Ω(records(targets)).Should(Equal([]string{dns.RS_A + " 1.2.3.4", dns.RS_AAAA + " 2001:db8::1"}))
+	})
+
+	ginkgov2.It("keeps address targets", func() {
+		targets, multi, _ := normalizeTargets(logger.New(), resolver, newEntry(boolPtr(true)), newTargets("1.2.3.5")...)
+		Ω(multi).Should(BeFalse())
+		Ω(records(targets)).Should(Equal([]string{dns.RS_A + " 1.2.3.5"}))
+		Ω(queries).Should(Equal(0))
+	})
+
+	ginkgov2.It("refreshes the addresses after their TTL", func() {
+		entry := newEntry(boolPtr(true))
+		_, _, _ = normalizeTargets(logger.New(), resolver, entry, newTargets("lb.example.net")...)
+		zone["lb.example.net."] = []dnsRecord{{name: "lb.example.net.", rtype: dnsTypeA, ttl: 30, value: "1.2.3.6"}}
+
+		now = now.Add(20 * time.Second)
+		targets, _, _ := normalizeTargets(logger.New(), resolver, entry, newTargets("lb.example.net")...)
+		Ω(records(targets)).Should(Equal([]string{dns.RS_A + " 1.2.3.4", dns.RS_AAAA + " 2001:db8::1"}))
+
+		now = now.Add(20 * time.Second)
+		targets, multi, ok := normalizeTargets(logger.New(), resolver, entry, newTargets("lb.example.net")...)
+		Ω(multi).Should(BeTrue())
+		Ω(ok).Should(BeTrue())
+		Ω(records(targets)).Should(Equal([]string{dns.RS_A + " 1.2.3.6"}))
+	})
+
+	ginkgov2.It("takes the option from the source spec", func() {
+		spec := &dnsSpecModification{DNSSpecification: newEntry(nil), resolve: boolPtr(true)}
+		Ω(resolveTargetsToAddresses(spec)).Should(BeTrue())
+		Ω(resolveTargetsToAddresses(newEntry(nil))).Should(BeFalse())
+	})
+})
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,451 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// LabelZoneShardingGroup marks the leases of the controller replicas sharing the hosted zones
+const LabelZoneShardingGroup = dns.ANNOTATION_GROUP + "/zone-sharding-group"
+
+// ZONE_SHARDING_VIRTUAL_NODES is the number of points of a replica on the consistent hash ring
+const ZONE_SHARDING_VIRTUAL_NODES = 100
+
+// ZoneShardingConfig contains the settings of the active-active mode splitting the
+// reconciliation of the hosted zones between the controller replicas.
+type ZoneShardingConfig struct {
+	Group         string
+	Namespace     string
+	Identity      string
+	LeaseDuration time.Duration
+}
+
+func (this *ZoneShardingConfig) LeaseName() string {
+	return this.Group + "-" + this.Identity
+}
+
+// RenewInterval is the period of the lease renewals and member updates.
+// It is also used as grace period for handing over hosted zones between the replicas.
+func (this *ZoneShardingConfig) RenewInterval() time.Duration {
+	return this.LeaseDuration / 3
+}
+
+func createZoneShardingConfig(c controller.Interface) (*ZoneShardingConfig, error) {
+	group, _ := c.GetStringOption(OPT_ZONE_SHARDING_GROUP)
+	if group == "" {
+		return nil, nil
+	}
+	if errs := validation.IsDNS1123Label(group); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid %s %q: %s", OPT_ZONE_SHARDING_GROUP, group, strings.Join(errs, ", "))
+	}
+	if err := checkZoneShardingControllers(c.GetEnvironment()); err != nil {
+		return nil, err
+	}
+	duration, err := c.GetDurationOption(OPT_ZONE_SHARDING_LEASE_DURATION)
+	if err != nil || duration <= 0 {
+		duration = 15 * time.Second
+	}
+	identity := os.Getenv("POD_NAME")
+	if identity == "" {
+		identity, err = os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("cannot determine identity for zone sharding: %w", err)
+		}
+	}
+	identity = strings.ToLower(identity)
+	if errs := validation.IsDNS1123Subdomain(group + "-" + identity); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid zone sharding identity %q: %s", identity, strings.Join(errs, ", "))
+	}
+	return &ZoneShardingConfig{
+		Group:         group,
+		Namespace:     c.GetEnvironment().Namespace(),
+		Identity:      identity,
+		LeaseDuration: duration,
+	}, nil
+}
+
+// checkZoneShardingControllers checks the controllers activated together with zone sharding.
+// Zone sharding needs the option --omit-lease, which disables the leader election for all controllers
+// of the controller manager. Therefore, no other controllers requiring leader election may be active,
+// as they would run on all replicas.
+func checkZoneShardingControllers(env controller.Environment) error {
+	cfg := env.GetConfig()
+	defs := controller.DefaultDefinitions()
+	active, err := defs.Groups().Members(env, strings.Split(cfg.Controllers, ","))
+	if err != nil {
+		return err
+	}
+	for name := range active.Copy() {
+		required, err := defs.GetRequiredControllers(name)
+		if err != nil {
+			return err
+		}
+		active.AddSet(required)
+	}
+	var dnscontrollers utils.StringSet
+	if group := defs.Groups().Get(CONTROLLER_GROUP_DNS_CONTROLLERS); group != nil {
+		dnscontrollers = group.Members()
+	}
+	return validateZoneShardingControllers(cfg.Lease.OmitLease, active, dnscontrollers, func(name string) bool {
+		def := defs.Get(name)
+		return def != nil && def.RequireLease()
+	})
+}
+
+func validateZoneShardingControllers(omitLease bool, active, dnscontrollers utils.StringSet, requireLease func(name string) bool) error {
+	if !omitLease {
+		return fmt.Errorf("option --%s requires option --omit-lease", OPT_ZONE_SHARDING_GROUP)
+	}
+	others := utils.StringSet{}
+	for name := range active {
+		if !dnscontrollers.Contains(name) && requireLease(name) {
+			others.Add(name)
+		}
+	}
+	if len(others) > 0 {
+		names := others.AsArray()
+		sort.Strings(names)
+		return fmt.Errorf("option --%s cannot be used with controllers requiring leader election: %s (use -c %s)",
+			OPT_ZONE_SHARDING_GROUP, strings.Join(names, ","), CONTROLLER_GROUP_DNS_CONTROLLERS)
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// shardRing assigns keys to the members with consistent hashing, so that
+// only the keys of the joining or leaving member move on membership changes.
+type shardRing struct {
+	members []string
+	points  []uint64
+	owners  []string
+}
+
+func newShardRing(members []string) *shardRing {
+	type point struct {
+		hash   uint64
+		member string
+	}
+	sorted := append([]string{}, members...)
+	sort.Strings(sorted)
+	points := make([]point, 0, len(sorted)*ZONE_SHARDING_VIRTUAL_NODES)
+	for _, m := range sorted {
+		for i := 0; i < ZONE_SHARDING_VIRTUAL_NODES; i++ {
+			points = append(points, point{hash: shardHash(fmt.Sprintf("%s#%d", m, i)), member: m})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].hash == points[j].hash {
+			return points[i].member < points[j].member
+		}
+		return points[i].hash < points[j].hash
+	})
+	ring := &shardRing{
+		members: sorted,
+		points:  make([]uint64, len(points)),
+		owners:  make([]string, len(points)),
+	}
+	for i, p := range points {
+		ring.points[i] = p.hash
+		ring.owners[i] = p.member
+	}
+	return ring
+}
+
+// Owner returns the member responsible for the key or an empty string if there is no member.
+func (this *shardRing) Owner(key string) string {
+	if this == nil || len(this.points) == 0 {
+		return ""
+	}
+	h := shardHash(key)
+	i := sort.Search(len(this.points), func(i int) bool { return this.points[i] >= h })
+	if i == len(this.points) {
+		i = 0
+	}
+	return this.owners[i]
+}
+
+func (this *shardRing) hasMember(member string) bool {
+	if this == nil {
+		return false
+	}
+	i := sort.SearchStrings(this.members, member)
+	return i < len(this.members) && this.members[i] == member
+}
+
+func (this *shardRing) hasMembers(members []string) bool {
+	if this == nil || len(this.members) != len(members) {
+		return false
+	}
+	for i, m := range members {
+		if this.members[i] != m {
+			return false
+		}
+	}
+	return true
+}
+
+func shardHash(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+// activeShardMembers returns the sorted holder identities of the leases renewed within their lease duration.
+// As the clocks of the replicas may differ, the leases of other replicas are only regarded as expired
+// after the additional grace period, whereas the own lease (identity self) expires without grace period.
+// So a replica always gives up its zones before another one takes them over.
+func activeShardMembers(leases []*coordinationv1.Lease, now time.Time, self string, grace time.Duration) []string {
+	members := []string{}
+	for _, l := range leases {
+		if l.Spec.HolderIdentity == nil || l.Spec.RenewTime == nil || l.Spec.LeaseDurationSeconds == nil {
+			continue
+		}
+		expiry := l.Spec.RenewTime.Add(time.Duration(*l.Spec.LeaseDurationSeconds) * time.Second)
+		if *l.Spec.HolderIdentity != self {
+			expiry = expiry.Add(grace)
+		}
+		if now.Before(expiry) {
+			members = append(members, *l.Spec.HolderIdentity)
+		}
+	}
+	sort.Strings(members)
+	return members
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// zoneSharding maintains the lease of the controller replica and the consistent hash ring
+// of all replicas with a valid lease. A replica only reconciles the hosted zones assigned to it.
+// Zones moving from a still active replica (e.g. on joining the group) are handed over after the
+// renew interval, when the previous owner has seen the membership change, too.
+type zoneSharding struct {
+	logger   logger.LogContext
+	config   ZoneShardingConfig
+	resc     resources.Interface
+	takeover func(gained func(zoneid dns.ZoneID) bool)
+	now      func() time.Time
+	schedule func(d time.Duration, f func())
+
+	lock         sync.RWMutex
+	ring         *shardRing
+	previous     *shardRing
+	handoffUntil time.Time
+	lastSync     time.Time
+}
+
+func newZoneSharding(logger logger.LogContext, resc resources.Interface, config ZoneShardingConfig, takeover func(gained func(zoneid dns.ZoneID) bool)) *zoneSharding {
+	return &zoneSharding{
+		logger:   logger,
+		config:   config,
+		resc:     resc,
+		takeover: takeover,
+		now:      time.Now,
+		schedule: func(d time.Duration, f func()) { time.AfterFunc(d, f) },
+	}
+}
+
+// Owns returns true if the hosted zone is assigned to this controller replica.
+func (this *zoneSharding) Owns(zoneid dns.ZoneID) bool {
+	return this.Owner(zoneid) == this.config.Identity
+}
+
+// Owner returns the identity of the controller replica the hosted zone is assigned to.
+// During a handoff it is still the previous owner.
+func (this *zoneSharding) Owner(zoneid dns.ZoneID) string {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	key := zoneid.String()
+	owner := this.ring.Owner(key)
+	if this.handingOver(key, owner, this.now()) {
+		return this.previous.Owner(key)
+	}
+	return owner
+}
+
+// handingOver checks whether a zone assigned to this replica is still reconciled by its previous owner.
+func (this *zoneSharding) handingOver(key, owner string, now time.Time) bool {
+	if owner != this.config.Identity || !now.Before(this.handoffUntil) {
+		return false
+	}
+	prev := this.previous.Owner(key)
+	return prev != "" && prev != owner && this.ring.hasMember(prev)
+}
+
+// Members returns the identities of the controller replicas sharing the hosted zones.
+func (this *zoneSharding) Members() []string {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	if this.ring == nil {
+		return nil
+	}
+	return append([]string{}, this.ring.members...)
+}
+
+// Start renews the lease of the replica and updates the members until the context is done.
+func (this *zoneSharding) Start(ctx context.Context) {
+	this.sync(time.Now())
+	go func() {
+		ticker := time.NewTicker(this.config.RenewInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				this.release()
+				return
+			case <-ticker.C:
+				this.sync(time.Now())
+			}
+		}
+	}()
+}
+
+func (this *zoneSharding) sync(now time.Time) {
+	if err := this.renew(now); err != nil {
+		this.logger.Warnf("cannot renew zone sharding lease %s: %s", this.config.LeaseName(), err)
+	}
+	leases, err := this.list()
+	if err != nil {
+		this.logger.Warnf("cannot list zone sharding leases: %s", err)
+		if !this.lastSync.IsZero() && now.Sub(this.lastSync) > this.config.LeaseDuration {
+			// other replicas may already have taken over the zones
+			this.setMembers(nil, now)
+		}
+		return
+	}
+	this.lastSync = now
+	this.setMembers(activeShardMembers(leases, now, this.config.Identity, this.config.RenewInterval()), now)
+}
+
+func (this *zoneSharding) setMembers(members []string, now time.Time) {
+	this.lock.Lock()
+	old := this.ring
+	if old.hasMembers(members) {
+		this.lock.Unlock()
+		return
+	}
+	previous := old
+	if now.Before(this.handoffUntil) {
+		// the previous owners of a running handoff may still reconcile their zones
+		previous = this.previous
+	} else if previous == nil {
+		// on startup the other replicas still distribute the zones among themselves
+		var others []string
+		for _, m := range members {
+			if m != this.config.Identity {
+				others = append(others, m)
+			}
+		}
+		previous = newShardRing(others)
+	}
+	ring := newShardRing(members)
+	this.ring = ring
+	this.previous = previous
+	grace := this.config.RenewInterval()
+	this.handoffUntil = now.Add(grace)
+	this.lock.Unlock()
+
+	this.logger.Infof("zone sharding members changed: %v", members)
+	gained := func(zoneid dns.ZoneID) bool {
+		key := zoneid.String()
+		return ring.Owner(key) == this.config.Identity && previous.Owner(key) != this.config.Identity && this.Owns(zoneid)
+	}
+	this.takeover(gained)
+	if grace > 0 {
+		this.schedule(grace, func() {
+			this.completeHandoff(ring, gained)
+		})
+	}
+}
+
+// completeHandoff takes over the zones handed over by other replicas after a membership change,
+// if the members have not changed again in the meantime.
+func (this *zoneSharding) completeHandoff(ring *shardRing, gained func(zoneid dns.ZoneID) bool) {
+	this.lock.RLock()
+	current := this.ring == ring
+	this.lock.RUnlock()
+	if current {
+		this.takeover(gained)
+	}
+}
+
+func (this *zoneSharding) renew(now time.Time) error {
+	name := &coordinationv1.Lease{}
+	name.Namespace = this.config.Namespace
+	name.Name = this.config.LeaseName()
+	_, _, err := this.resc.CreateOrModifyByName(name, func(data resources.ObjectData) (bool, error) {
+		lease := data.(*coordinationv1.Lease)
+		resources.SetLabel(lease, LabelZoneShardingGroup, this.config.Group)
+		seconds := int32(this.config.LeaseDuration / time.Second)
+		if seconds < 1 {
+			seconds = 1
+		}
+		renew := metav1.NewMicroTime(now)
+		lease.Spec.HolderIdentity = &this.config.Identity
+		lease.Spec.LeaseDurationSeconds = &seconds
+		if lease.Spec.AcquireTime == nil {
+			lease.Spec.AcquireTime = &renew
+		}
+		lease.Spec.RenewTime = &renew
+		return true, nil
+	})
+	return err
+}
+
+func (this *zoneSharding) list() ([]*coordinationv1.Lease, error) {
+	objs, err := this.resc.Namespace(this.config.Namespace).List(metav1.ListOptions{
+		LabelSelector: LabelZoneShardingGroup + "=" + this.config.Group,
+	})
+	if err != nil {
+		return nil, err
+	}
+	leases := make([]*coordinationv1.Lease, 0, len(objs))
+	for _, o := range objs {
+		leases = append(leases, o.Data().(*coordinationv1.Lease))
+	}
+	return leases, nil
+}
+
+// release deletes the lease, so that the other replicas take over the hosted zones without waiting for its expiry.
+func (this *zoneSharding) release() {
+	name := &coordinationv1.Lease{}
+	name.Namespace = this.config.Namespace
+	name.Name = this.config.LeaseName()
+	if err := this.resc.DeleteByName(name); err != nil {
+		this.logger.Warnf("cannot delete zone sharding lease %s: %s", name.Name, err)
+		return
+	}
+	this.logger.Infof("released zone sharding lease %s", name.Name)
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Zone sharding", func() {
+	zoneIDs := func(n int) []dns.ZoneID {
+		ids := make([]dns.ZoneID, n)
+		for i := range ids {
+			ids[i] = dns.NewZoneID("aws-route53", fmt.Sprintf("Z%04d", i))
+		}
+		return ids
+	}
+
+	ginkgov2.It("distributes zones between all members", func() {
+		ring := newShardRing([]string{"replica-a", "replica-b", "replica-c"})
+		counts := map[string]int{}
+		for _, id := range zoneIDs(3000) {
+			counts[ring.Owner(id.String())]++
+		}
+		Ω(counts).Should(HaveLen(3))
+		for _, c := range counts {
+			Ω(c).Should(BeNumerically(">", 700))
+		}
+		Ω((*shardRing)(nil).Owner("x")).Should(Equal(""))
+		Ω(newShardRing(nil).Owner("x")).Should(Equal(""))
+	})
+
+	ginkgov2.It("moves only the zones of a joining member", func() {
+		old := newShardRing([]string{"replica-a", "replica-b"})
+		ring := newShardRing([]string{"replica-c", "replica-a", "replica-b"})
+		for _, id := range zoneIDs(1000) {
+			owner := ring.Owner(id.String())
+			if owner != "replica-c" {
+				Ω(owner).Should(Equal(old.Owner(id.String())))
+			}
+		}
+		Ω(ring.hasMembers([]string{"replica-a", "replica-b", "replica-c"})).Should(BeTrue())
+		Ω(ring.hasMembers([]string{"replica-a", "replica-b"})).Should(BeFalse())
+	})
+
+	ginkgov2.It("selects members with valid leases", func() {
+		now := time.Now()
+		lease := func(identity string, renew time.Time) *coordinationv1.Lease {
+			seconds := int32(15)
+			t := metav1.NewMicroTime(renew)
+			return &coordinationv1.Lease{Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &identity,
+				LeaseDurationSeconds: &seconds,
+				RenewTime:            &t,
+			}}
+		}
+		leases := []*coordinationv1.Lease{
+			lease("replica-b", now.Add(-5*time.Second)),
+			lease("replica-a", now),
+			lease("replica-c", now.Add(-20*time.Second)),
+			{},
+		}
+		Ω(activeShardMembers(leases, now, "", 0)).Should(Equal([]string{"replica-a", "replica-b"}))
+
+		// the leases of other replicas expire after the grace period, the own one without
+		leases = append(leases, lease("replica-d", now.Add(-17*time.Second)))
+		Ω(activeShardMembers(leases, now, "replica-b", 5*time.Second)).Should(Equal([]string{"replica-a", "replica-b", "replica-d"}))
+		Ω(activeShardMembers(leases, now, "replica-d", 5*time.Second)).Should(Equal([]string{"replica-a", "replica-b"}))
+	})
+
+	ginkgov2.It("takes over zones of leaving members", func() {
+		var gained []dns.ZoneID
+		ids := zoneIDs(100)
+		sharding := newZoneSharding(logger.NewContext("", "test"), nil, ZoneShardingConfig{Identity: "replica-a"}, func(f func(zoneid dns.ZoneID) bool) {
+			gained = nil
+			for _, id := range ids {
+				if f(id) {
+					gained = append(gained, id)
+				}
+			}
+		})
+
+		sharding.setMembers([]string{"replica-a", "replica-b"}, time.Now())
+		owned := 0
+		for _, id := range ids {
+			if sharding.Owns(id) {
+				owned++
+			}
+		}
+		Ω(gained).Should(HaveLen(owned))
+		Ω(owned).Should(BeNumerically(">", 0))
+		Ω(owned).Should(BeNumerically("<", len(ids)))
+
+		gained = nil
+		sharding.setMembers([]string{"replica-a", "replica-b"}, time.Now())
+		Ω(gained).Should(BeNil())
+
+		sharding.setMembers([]string{"replica-a"}, time.Now())
+		Ω(gained).Should(HaveLen(len(ids) - owned))
+		for _, id := range ids {
+			Ω(sharding.Owns(id)).Should(BeTrue())
+		}
+		Ω(sharding.Members()).Should(Equal([]string{"replica-a"}))
+	})
+
+	ginkgov2.It("never lets two replicas with overlapping views own a zone", func() {
+		ids := zoneIDs(200)
+		now := time.Now()
+		var scheduled []func()
+		gained := map[string][]dns.ZoneID{}
+		replica := func(identity string) *zoneSharding {
+			var sharding *zoneSharding
+			sharding = newZoneSharding(logger.NewContext("", "test"), nil, ZoneShardingConfig{Identity: identity, LeaseDuration: 15 * time.Second},
+				func(f func(zoneid dns.ZoneID) bool) {
+					for _, id := range ids {
+						if f(id) {
+							gained[identity] = append(gained[identity], id)
+						}
+					}
+				})
+			sharding.now = func() time.Time { return now }
+			sharding.schedule = func(d time.Duration, f func()) {
+				Ω(d).Should(Equal(5 * time.Second))
+				scheduled = append(scheduled, f)
+			}
+			return sharding
+		}
+		exclusive := func(a, b *zoneSharding) int {
+			owned := 0
+			for _, id := range ids {
+				Ω(a.Owns(id) && b.Owns(id)).Should(BeFalse(), "zone %s owned twice", id)
+				if a.Owns(id) || b.Owns(id) {
+					owned++
+				}
+			}
+			return owned
+		}
+
+		a := replica("replica-a")
+		a.setMembers([]string{"replica-a"}, now)
+		Ω(gained["replica-a"]).Should(HaveLen(len(ids)))
+		scheduled = nil
+
+		// replica-b joins, replica-a sees the new lease only with its next sync
+		b := replica("replica-b")
+		b.setMembers([]string{"replica-a", "replica-b"}, now)
+		Ω(gained["replica-b"]).Should(BeEmpty())
+		Ω(exclusive(a, b)).Should(Equal(len(ids)))
+		Ω(b.Owner(ids[0])).Should(Equal("replica-a"))
+
+		now = now.Add(3 * time.Second)
+		a.setMembers([]string{"replica-a", "replica-b"}, now)
+		Ω(exclusive(a, b)).Should(BeNumerically("<", len(ids)))
+
+		// replica-b takes over after the handoff period
+		now = now.Add(2 * time.Second)
+		for _, f := range scheduled {
+			f()
+		}
+		Ω(exclusive(a, b)).Should(Equal(len(ids)))
+		Ω(gained["replica-b"]).ShouldNot(BeEmpty())
+		for _, id := range gained["replica-b"] {
+			Ω(b.Owns(id)).Should(BeTrue())
+			Ω(b.Owner(id)).Should(Equal("replica-b"))
+		}
+
+		// replica-b stops renewing its lease, replica-a's clock is 3 seconds ahead
+		seconds := int32(15)
+		identity := "replica-b"
+		renew := metav1.NewMicroTime(now)
+		leases := []*coordinationv1.Lease{{Spec: coordinationv1.LeaseSpec{HolderIdentity: &identity, LeaseDurationSeconds: &seconds, RenewTime: &renew}}}
+		for t := 10 * time.Second; t < 25*time.Second; t += time.Second {
+			bActive := len(activeShardMembers(leases, now.Add(t), "replica-b", 5*time.Second)) > 0
+			aSeesB := len(activeShardMembers(leases, now.Add(t+3*time.Second), "replica-a", 5*time.Second)) > 0
+			if bActive {
+				Ω(aSeesB).Should(BeTrue(), "replica-a takes over %s after renewal while replica-b is still active", t)
+			}
+		}
+	})
+
+	ginkgov2.It("requires disabled leader election without other controllers requiring it", func() {
+		dnscontrollers := utils.NewStringSet("aws-route53", "compound")
+		requireLease := func(name string) bool { return name != "webhook" }
+
+		err := validateZoneShardingControllers(false, utils.NewStringSet("aws-route53"), dnscontrollers, requireLease)
+		Ω(err).Should(MatchError("option --zone-sharding-group requires option --omit-lease"))
+
+		err = validateZoneShardingControllers(true, utils.NewStringSet("aws-route53", "webhook"), dnscontrollers, requireLease)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = validateZoneShardingControllers(true, utils.NewStringSet("aws-route53", "service", "ingress", "webhook"), dnscontrollers, requireLease)
+		Ω(err).Should(MatchError("option --zone-sharding-group cannot be used with controllers requiring leader election: ingress,service (use -c dnscontrollers)"))
+	})
+})
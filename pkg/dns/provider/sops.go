@@ -0,0 +1,368 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"gopkg.in/yaml.v2"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// SOPS_SECRET_KEY is the key of a provider secret containing a SOPS encrypted document
+// with the credential properties.
+const SOPS_SECRET_KEY = "sops.yaml"
+
+// SOPS_DATA_KEY_TTL is the time the decrypted data keys of SOPS documents are cached.
+const SOPS_DATA_KEY_TTL = time.Hour
+
+// SOPS_KMS_TIMEOUT is the timeout of a data key decryption with AWS KMS.
+const SOPS_KMS_TIMEOUT = 30 * time.Second
+
+type sopsMetadata struct {
+	KMS              []sopsKMSKey   `yaml:"kms"`
+	HCVault          []sopsVaultKey `yaml:"hc_vault"`
+	Age              []sopsAgeKey   `yaml:"age"`
+	PGP              []interface{}  `yaml:"pgp"`
+	LastModified     string         `yaml:"lastmodified"`
+	MAC              string         `yaml:"mac"`
+	MACOnlyEncrypted bool           `yaml:"mac_only_encrypted"`
+}
+
+type sopsKMSKey struct {
+	ARN     string            `yaml:"arn"`
+	Enc     string            `yaml:"enc"`
+	Context map[string]string `yaml:"context"`
+}
+
+type sopsAgeKey struct {
+	Recipient string `yaml:"recipient"`
+	Enc       string `yaml:"enc"`
+}
+
+type sopsVaultKey struct {
+	EnginePath string `yaml:"engine_path"`
+	KeyName    string `yaml:"key_name"`
+	Enc        string `yaml:"enc"`
+}
+
+// sopsDecryptor decrypts provider secrets encrypted with SOPS in memory.
+// The data keys may be encrypted with AWS KMS (using the ambient AWS credentials of the controller),
+// with the transit secrets engine of HashiCorp Vault or with age for the identities of the age key file.
+type sopsDecryptor struct {
+	vault      *vaultClient
+	vaultRole  string
+	ageKeyFile string
+	client     *http.Client
+	now        func() time.Time
+
+	lock sync.Mutex
+	// decrypted data keys by hash of the key metadata
+	keys map[string]*sopsDataKey
+	// decryptKey decrypts the data key of a document, replaceable for tests
+	decryptKey func(meta *sopsMetadata) ([]byte, error)
+}
+
+type sopsDataKey struct {
+	key     []byte
+	expires time.Time
+}
+
+func newSopsDecryptor(vault *vaultClient, vaultRole, ageKeyFile string, tlsPolicy *dnsutils.TLSPolicy) *sopsDecryptor {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{}
+	tlsPolicy.Apply(transport.TLSClientConfig)
+	this := &sopsDecryptor{
+		vault:      vault,
+		vaultRole:  vaultRole,
+		ageKeyFile: ageKeyFile,
+		client:     &http.Client{Timeout: SOPS_KMS_TIMEOUT, Transport: transport},
+		now:        time.Now,
+		keys:       map[string]*sopsDataKey{},
+	}
+	this.decryptKey = this.decryptDataKey
+	return this
+}
+
+// Decrypt replaces the SOPS encrypted document of the secret properties (if any) by its decrypted properties.
+// Plain properties of the secret are kept, but overwritten by the decrypted ones.
+func (this *sopsDecryptor) Decrypt(props utils.Properties) (utils.Properties, error) {
+	doc, ok := props[SOPS_SECRET_KEY]
+	if !ok {
+		return props, nil
+	}
+	decrypted, err := this.decryptDocument([]byte(doc))
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt %s: %w", SOPS_SECRET_KEY, err)
+	}
+	result := utils.Properties{}
+	for k, v := range props {
+		if k != SOPS_SECRET_KEY {
+			result[k] = v
+		}
+	}
+	for k, v := range decrypted {
+		result[k] = v
+	}
+	return result, nil
+}
+
+func (this *sopsDecryptor) decryptDocument(doc []byte) (utils.Properties, error) {
+	tree := yaml.MapSlice{}
+	if err := yaml.Unmarshal(doc, &tree); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+	var meta *sopsMetadata
+	for _, item := range tree {
+		if item.Key == "sops" {
+			data, err := yaml.Marshal(item.Value)
+			if err != nil {
+				return nil, err
+			}
+			meta = &sopsMetadata{}
+			if err := yaml.Unmarshal(data, meta); err != nil {
+				return nil, fmt.Errorf("invalid sops metadata: %w", err)
+			}
+		}
+	}
+	if meta == nil {
+		return nil, fmt.Errorf("sops metadata missing")
+	}
+	key, err := this.dataKey(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	props := utils.Properties{}
+	hash := sha512.New()
+	for _, item := range tree {
+		name, ok := item.Key.(string)
+		if !ok || name == "sops" {
+			continue
+		}
+		var plain string
+		encrypted := false
+		switch v := item.Value.(type) {
+		case string:
+			if strings.HasPrefix(v, "ENC[") {
+				plain, err = sopsDecryptValue(key, v, name+":")
+				if err != nil {
+					return nil, fmt.Errorf("cannot decrypt %s: %w", name, err)
+				}
+				encrypted = true
+			} else {
+				plain = v
+			}
+		case bool:
+			// booleans are hashed like by SOPS
+			plain = "False"
+			if v {
+				plain = "True"
+			}
+		case int:
+			plain = strconv.Itoa(v)
+		case float64:
+			plain = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return nil, fmt.Errorf("unsupported value for %s: only flat documents are supported", name)
+		}
+		if encrypted || !meta.MACOnlyEncrypted {
+			hash.Write([]byte(plain))
+		}
+		props[name] = plain
+	}
+
+	mac, err := sopsDecryptValue(key, meta.MAC, meta.LastModified)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt mac: %w", err)
+	}
+	if !strings.EqualFold(mac, fmt.Sprintf("%X", hash.Sum(nil))) {
+		return nil, fmt.Errorf("mac mismatch: document has been modified")
+	}
+	return props, nil
+}
+
+// dataKey returns the cached or newly decrypted data key of a document.
+// The data keys are cached for SOPS_DATA_KEY_TTL, the lock is not held during the decryption.
+func (this *sopsDecryptor) dataKey(meta *sopsMetadata) ([]byte, error) {
+	h := sha256.New()
+	for _, k := range meta.KMS {
+		h.Write([]byte(k.ARN + "\x00" + k.Enc + "\x00"))
+	}
+	for _, k := range meta.HCVault {
+		h.Write([]byte(k.EnginePath + "\x00" + k.KeyName + "\x00" + k.Enc + "\x00"))
+	}
+	for _, k := range meta.Age {
+		h.Write([]byte(k.Recipient + "\x00" + k.Enc + "\x00"))
+	}
+	id := string(h.Sum(nil))
+
+	now := this.now()
+	this.lock.Lock()
+	for k, e := range this.keys {
+		if !now.Before(e.expires) {
+			delete(this.keys, k)
+		}
+	}
+	e := this.keys[id]
+	this.lock.Unlock()
+	if e != nil {
+		return e.key, nil
+	}
+
+	key, err := this.decryptKey(meta)
+	if err != nil {
+		return nil, err
+	}
+	this.lock.Lock()
+	this.keys[id] = &sopsDataKey{key: key, expires: now.Add(SOPS_DATA_KEY_TTL)}
+	this.lock.Unlock()
+	return key, nil
+}
+
+// decryptDataKey tries all supported key groups of the metadata.
+func (this *sopsDecryptor) decryptDataKey(meta *sopsMetadata) ([]byte, error) {
+	var errs []string
+	for _, k := range meta.KMS {
+		key, err := sopsKMSDecrypt(this.client, k)
+		if err == nil {
+			return key, nil
+		}
+		errs = append(errs, fmt.Sprintf("kms %s: %s", k.ARN, err))
+	}
+	for _, k := range meta.HCVault {
+		if this.vaultRole == "" {
+			errs = append(errs, fmt.Sprintf("hc_vault %s: option --%s not set", k.KeyName, OPT_SOPS_VAULT_ROLE))
+			continue
+		}
+		key, err := this.vault.TransitDecrypt(this.vaultRole, k.EnginePath, k.KeyName, k.Enc)
+		if err == nil {
+			return key, nil
+		}
+		errs = append(errs, fmt.Sprintf("hc_vault %s: %s", k.KeyName, err))
+	}
+	if len(meta.Age) > 0 {
+		identities, err := this.ageIdentities()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("age: %s", err))
+		} else {
+			for _, k := range meta.Age {
+				key, err := ageDecrypt(identities, k.Enc)
+				if err == nil {
+					return key, nil
+				}
+				errs = append(errs, fmt.Sprintf("age %s: %s", k.Recipient, err))
+			}
+		}
+	}
+	if len(errs) == 0 {
+		if len(meta.PGP) > 0 {
+			return nil, fmt.Errorf("pgp encrypted data keys are not supported, use kms, hc_vault or age")
+		}
+		return nil, fmt.Errorf("no data key found")
+	}
+	return nil, fmt.Errorf("cannot decrypt data key: %s", strings.Join(errs, ", "))
+}
+
+// ageIdentities reads the identities of the age key file.
+// The file is read for every data key decryption to pick up rotated keys of mounted secrets.
+func (this *sopsDecryptor) ageIdentities() ([][]byte, error) {
+	if this.ageKeyFile == "" {
+		return nil, fmt.Errorf("option --%s not set", OPT_SOPS_AGE_KEY_FILE)
+	}
+	return readAgeIdentities(this.ageKeyFile)
+}
+
+// sopsDecryptValue decrypts a value of the form ENC[AES256_GCM,data:...,iv:...,tag:...,type:...].
+func sopsDecryptValue(key []byte, value, additionalData string) (string, error) {
+	if !strings.HasPrefix(value, "ENC[AES256_GCM,") || !strings.HasSuffix(value, "]") {
+		return "", fmt.Errorf("invalid encrypted value")
+	}
+	parts := map[string][]byte{}
+	for _, field := range strings.Split(value[len("ENC[AES256_GCM,"):len(value)-1], ",") {
+		kv := strings.SplitN(field, ":", 2)
+		if len(kv) != 2 || kv[0] == "type" {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(kv[1])
+		if err != nil {
+			return "", fmt.Errorf("invalid %s of encrypted value", kv[0])
+		}
+		parts[kv[0]] = data
+	}
+	if len(parts["iv"]) == 0 || len(parts["tag"]) == 0 {
+		return "", fmt.Errorf("invalid encrypted value")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(parts["iv"]))
+	if err != nil {
+		return "", err
+	}
+	plain, err := gcm.Open(nil, parts["iv"], append(parts["data"], parts["tag"]...), []byte(additionalData))
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// sopsKMSDecrypt decrypts a data key with AWS KMS using the ambient AWS credentials of the controller.
+func sopsKMSDecrypt(client *http.Client, k sopsKMSKey) ([]byte, error) {
+	// arn:<partition>:kms:<region>:<account>:key/<id>
+	parts := strings.Split(k.ARN, ":")
+	if len(parts) < 6 || parts[0] != "arn" || parts[2] != "kms" {
+		return nil, fmt.Errorf("invalid key arn")
+	}
+	blob, err := base64.StdEncoding.DecodeString(k.Enc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encrypted data key: %w", err)
+	}
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(parts[3]), HTTPClient: client})
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), SOPS_KMS_TIMEOUT)
+	defer cancel()
+	input := &kms.DecryptInput{CiphertextBlob: blob, KeyId: aws.String(k.ARN)}
+	if len(k.Context) > 0 {
+		input.EncryptionContext = aws.StringMap(k.Context)
+	}
+	out, err := kms.New(sess).DecryptWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return out.Plaintext, nil
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func sopsEncryptValue(key []byte, plain, additionalData string) string {
+	block, err := aes.NewCipher(key)
+	Ω(err).ShouldNot(HaveOccurred())
+	iv := make([]byte, 32)
+	_, _ = rand.Read(iv)
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	Ω(err).ShouldNot(HaveOccurred())
+	out := gcm.Seal(nil, iv, []byte(plain), []byte(additionalData))
+	data, tag := out[:len(out)-gcm.Overhead()], out[len(out)-gcm.Overhead():]
+	enc := base64.StdEncoding.EncodeToString
+	return fmt.Sprintf("ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:str]", enc(data), enc(iv), enc(tag))
+}
+
+var _ = ginkgov2.Describe("SOPS encrypted secrets", func() {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	lastModified := "2022-11-02T10:00:00Z"
+	var decryptor *sopsDecryptor
+	var keyRequests int
+
+	document := func(values map[string]string, macValues ...string) string {
+		hash := sha512.New()
+		for _, v := range macValues {
+			hash.Write([]byte(v))
+		}
+		lines := []string{}
+		for _, k := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"} {
+			lines = append(lines, fmt.Sprintf("%s: %s", k, sopsEncryptValue(key, values[k], k+":")))
+		}
+		lines = append(lines, "AWS_REGION: eu-west-1")
+		lines = append(lines,
+			"sops:",
+			"  hc_vault:",
+			"  - engine_path: transit",
+			"    key_name: sops",
+			"    enc: vault:v1:abc",
+			"  lastmodified: \""+lastModified+"\"",
+			"  mac: "+sopsEncryptValue(key, fmt.Sprintf("%X", hash.Sum(nil)), lastModified),
+		)
+		return strings.Join(lines, "\n")
+	}
+
+	ginkgov2.BeforeEach(func() {
+		keyRequests = 0
+		decryptor = newSopsDecryptor(nil, "", "", nil)
+		decryptor.decryptKey = func(meta *sopsMetadata) ([]byte, error) {
+			keyRequests++
+			Ω(meta.HCVault).Should(HaveLen(1))
+			return key, nil
+		}
+	})
+
+	ginkgov2.It("keeps secrets without SOPS document", func() {
+		props := utils.Properties{"AWS_ACCESS_KEY_ID": "ak"}
+		Ω(decryptor.Decrypt(props)).Should(Equal(props))
+	})
+
+	ginkgov2.It("decrypts the document and caches the data key", func() {
+		values := map[string]string{"AWS_ACCESS_KEY_ID": "ak", "AWS_SECRET_ACCESS_KEY": "sk"}
+		props := utils.Properties{
+			SOPS_SECRET_KEY: document(values, "ak", "sk", "eu-west-1"),
+			"plain":         "value",
+		}
+		expected := utils.Properties{"AWS_ACCESS_KEY_ID": "ak", "AWS_SECRET_ACCESS_KEY": "sk", "AWS_REGION": "eu-west-1", "plain": "value"}
+		Ω(decryptor.Decrypt(props)).Should(Equal(expected))
+		Ω(decryptor.Decrypt(props)).Should(Equal(expected))
+		Ω(keyRequests).Should(Equal(1))
+	})
+
+	ginkgov2.It("decrypts the data key again after the cache TTL", func() {
+		now := time.Now()
+		decryptor.now = func() time.Time { return now }
+		values := map[string]string{"AWS_ACCESS_KEY_ID": "ak", "AWS_SECRET_ACCESS_KEY": "sk"}
+		props := utils.Properties{SOPS_SECRET_KEY: document(values, "ak", "sk", "eu-west-1")}
+		_, err := decryptor.Decrypt(props)
+		Ω(err).ShouldNot(HaveOccurred())
+		now = now.Add(SOPS_DATA_KEY_TTL - time.Second)
+		_, err = decryptor.Decrypt(props)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(keyRequests).Should(Equal(1))
+
+		now = now.Add(time.Second)
+		_, err = decryptor.Decrypt(props)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(keyRequests).Should(Equal(2))
+		Ω(decryptor.keys).Should(HaveLen(1))
+	})
+
+	ginkgov2.It("detects modified documents", func() {
+		values := map[string]string{"AWS_ACCESS_KEY_ID": "ak", "AWS_SECRET_ACCESS_KEY": "sk"}
+		props := utils.Properties{SOPS_SECRET_KEY: document(values, "ak", "other", "eu-west-1")}
+		_, err := decryptor.Decrypt(props)
+		Ω(err).Should(MatchError(ContainSubstring("mac mismatch")))
+	})
+
+	ginkgov2.It("rejects values decrypted with the wrong data key", func() {
+		_, err := sopsDecryptValue([]byte("fedcba9876543210fedcba9876543210"), sopsEncryptValue(key, "ak", "a:"), "a:")
+		Ω(err).Should(HaveOccurred())
+		_, err = sopsDecryptValue(key, sopsEncryptValue(key, "ak", "a:"), "b:")
+		Ω(err).Should(HaveOccurred())
+	})
+
+	ginkgov2.It("rejects invalid KMS keys", func() {
+		_, err := sopsKMSDecrypt(http.DefaultClient, sopsKMSKey{ARN: "key/1234", Enc: "YWJj"})
+		Ω(err).Should(MatchError("invalid key arn"))
+		_, err = sopsKMSDecrypt(http.DefaultClient, sopsKMSKey{ARN: "arn:aws:kms:eu-west-1:123456789012:key/1234", Enc: "not base64"})
+		Ω(err).Should(MatchError(ContainSubstring("invalid encrypted data key")))
+	})
+
+	ginkgov2.It("reports unsupported key groups", func() {
+		_, err := newSopsDecryptor(nil, "", "", nil).decryptDataKey(&sopsMetadata{PGP: []interface{}{"fingerprint"}})
+		Ω(err).Should(MatchError(ContainSubstring("not supported")))
+		_, err = newSopsDecryptor(nil, "", "", nil).decryptDataKey(&sopsMetadata{Age: []sopsAgeKey{{Recipient: "age1..."}}})
+		Ω(err).Should(MatchError(ContainSubstring("option --" + OPT_SOPS_AGE_KEY_FILE + " not set")))
+	})
+})
@@ -0,0 +1,365 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Decryption of SOPS data keys encrypted with age (https://age-encryption.org/v1) for X25519 identities.
+
+const (
+	AGE_INTRO          = "age-encryption.org/v1"
+	AGE_ARMOR_BEGIN    = "-----BEGIN AGE ENCRYPTED FILE-----"
+	AGE_ARMOR_END      = "-----END AGE ENCRYPTED FILE-----"
+	AGE_IDENTITY_HRP   = "age-secret-key-"
+	AGE_X25519_LABEL   = "age-encryption.org/v1/X25519"
+	AGE_STANZA_COLUMNS = 64
+	AGE_CHUNK_SIZE     = 64 * 1024
+)
+
+var ageBase64 = base64.RawStdEncoding.Strict()
+
+type ageStanza struct {
+	args []string
+	body []byte
+}
+
+type ageHeader struct {
+	stanzas []*ageStanza
+	// header up to and including the footer prefix "---", covered by the mac
+	macData []byte
+	mac     []byte
+}
+
+// readAgeIdentities reads the X25519 identities (AGE-SECRET-KEY-1...) of an age key file.
+// Empty lines and comments starting with '#' are ignored.
+func readAgeIdentities(file string) ([][]byte, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var identities [][]byte
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		identity, err := parseAgeIdentity(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid age identity in line %d of %s: %w", i+1, file, err)
+		}
+		identities = append(identities, identity)
+	}
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("no age identity found in %s", file)
+	}
+	return identities, nil
+}
+
+func parseAgeIdentity(s string) ([]byte, error) {
+	if strings.ToUpper(s) != s {
+		return nil, fmt.Errorf("identity must be upper case")
+	}
+	hrp, data, err := bech32Decode(strings.ToLower(s))
+	if err != nil {
+		return nil, err
+	}
+	if hrp != AGE_IDENTITY_HRP {
+		return nil, fmt.Errorf("unsupported identity type %q", strings.ToUpper(hrp))
+	}
+	if len(data) != curve25519.ScalarSize {
+		return nil, fmt.Errorf("invalid identity length")
+	}
+	return data, nil
+}
+
+// ageDecrypt decrypts an armored age file with one of the given X25519 identities.
+func ageDecrypt(identities [][]byte, armored string) ([]byte, error) {
+	data, err := ageUnarmor(armored)
+	if err != nil {
+		return nil, err
+	}
+	header, payload, err := parseAgeHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	var fileKey []byte
+	for _, identity := range identities {
+		for _, s := range header.stanzas {
+			if fileKey, err = ageUnwrapX25519(identity, s); err != nil {
+				return nil, err
+			}
+			if fileKey != nil {
+				break
+			}
+		}
+		if fileKey != nil {
+			break
+		}
+	}
+	if fileKey == nil {
+		return nil, fmt.Errorf("no matching age identity")
+	}
+
+	mac := hmac.New(sha256.New, ageHKDF(fileKey, nil, "header"))
+	mac.Write(header.macData)
+	if !hmac.Equal(mac.Sum(nil), header.mac) {
+		return nil, fmt.Errorf("age header mac mismatch")
+	}
+	return ageDecryptPayload(fileKey, payload)
+}
+
+func ageUnarmor(armored string) ([]byte, error) {
+	s := strings.TrimSpace(armored)
+	if !strings.HasPrefix(s, AGE_ARMOR_BEGIN) || !strings.HasSuffix(s, AGE_ARMOR_END) {
+		return nil, fmt.Errorf("invalid armored age file")
+	}
+	var b64 strings.Builder
+	for _, line := range strings.Split(s[len(AGE_ARMOR_BEGIN):len(s)-len(AGE_ARMOR_END)], "\n") {
+		b64.WriteString(strings.TrimSpace(line))
+	}
+	data, err := base64.StdEncoding.DecodeString(b64.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid armored age file: %w", err)
+	}
+	return data, nil
+}
+
+func parseAgeHeader(data []byte) (*ageHeader, []byte, error) {
+	header := &ageHeader{}
+	rest := data
+	nextLine := func() (string, bool) {
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			return "", false
+		}
+		line := string(rest[:i])
+		rest = rest[i+1:]
+		return line, true
+	}
+
+	if line, ok := nextLine(); !ok || line != AGE_INTRO {
+		return nil, nil, fmt.Errorf("unsupported age file version")
+	}
+	for {
+		offset := len(data) - len(rest)
+		line, ok := nextLine()
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid age header")
+		}
+		if strings.HasPrefix(line, "--- ") {
+			mac, err := ageBase64.DecodeString(line[len("--- "):])
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid age header mac: %w", err)
+			}
+			header.macData = data[:offset+len("---")]
+			header.mac = mac
+			return header, rest, nil
+		}
+		if !strings.HasPrefix(line, "-> ") {
+			return nil, nil, fmt.Errorf("invalid age header line")
+		}
+		stanza := &ageStanza{args: strings.Split(line[len("-> "):], " ")}
+		for {
+			line, ok := nextLine()
+			if !ok {
+				return nil, nil, fmt.Errorf("invalid age stanza body")
+			}
+			body, err := ageBase64.DecodeString(line)
+			if err != nil || len(line) > AGE_STANZA_COLUMNS {
+				return nil, nil, fmt.Errorf("invalid age stanza body")
+			}
+			stanza.body = append(stanza.body, body...)
+			if len(line) < AGE_STANZA_COLUMNS {
+				break
+			}
+		}
+		header.stanzas = append(header.stanzas, stanza)
+	}
+}
+
+// ageUnwrapX25519 returns the file key of an X25519 stanza or nil if the stanza is
+// not addressed to the identity.
+func ageUnwrapX25519(identity []byte, s *ageStanza) ([]byte, error) {
+	if len(s.args) == 0 || s.args[0] != "X25519" {
+		return nil, nil
+	}
+	if len(s.args) != 2 {
+		return nil, fmt.Errorf("invalid X25519 stanza")
+	}
+	share, err := ageBase64.DecodeString(s.args[1])
+	if err != nil || len(share) != curve25519.PointSize {
+		return nil, fmt.Errorf("invalid X25519 stanza")
+	}
+	if len(s.body) != 16+chacha20poly1305.Overhead {
+		return nil, fmt.Errorf("invalid X25519 stanza")
+	}
+	recipient, err := curve25519.X25519(identity, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := curve25519.X25519(identity, share)
+	if err != nil {
+		return nil, fmt.Errorf("invalid X25519 stanza: %w", err)
+	}
+	salt := append(append([]byte{}, share...), recipient...)
+	aead, err := chacha20poly1305.New(ageHKDF(shared, salt, AGE_X25519_LABEL))
+	if err != nil {
+		return nil, err
+	}
+	fileKey, err := aead.Open(nil, make([]byte, chacha20poly1305.NonceSize), s.body, nil)
+	if err != nil {
+		// encrypted for another recipient
+		return nil, nil
+	}
+	return fileKey, nil
+}
+
+// ageDecryptPayload decrypts the STREAM encrypted payload following the header.
+func ageDecryptPayload(fileKey, payload []byte) ([]byte, error) {
+	if len(payload) < 16 {
+		return nil, fmt.Errorf("invalid age payload")
+	}
+	aead, err := chacha20poly1305.New(ageHKDF(fileKey, payload[:16], "payload"))
+	if err != nil {
+		return nil, err
+	}
+	payload = payload[16:]
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	var plain []byte
+	for counter := uint64(0); ; counter++ {
+		size := AGE_CHUNK_SIZE + aead.Overhead()
+		last := len(payload) <= size
+		if last {
+			size = len(payload)
+			nonce[len(nonce)-1] = 1
+		}
+		for i := 0; i < 8; i++ {
+			nonce[len(nonce)-2-i] = byte(counter >> (8 * i))
+		}
+		chunk, err := aead.Open(nil, nonce, payload[:size], nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decrypt age payload: %w", err)
+		}
+		if len(chunk) == 0 && counter > 0 {
+			return nil, fmt.Errorf("invalid age payload")
+		}
+		plain = append(plain, chunk...)
+		payload = payload[size:]
+		if last {
+			return plain, nil
+		}
+	}
+}
+
+func ageHKDF(secret, salt []byte, info string) []byte {
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte(info)), key); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// bech32 (BIP 173) without length limit as used by age
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HrpExpand(hrp string) []byte {
+	result := make([]byte, 0, 2*len(hrp)+1)
+	for _, c := range []byte(hrp) {
+		result = append(result, c>>5)
+	}
+	result = append(result, 0)
+	for _, c := range []byte(hrp) {
+		result = append(result, c&31)
+	}
+	return result
+}
+
+func bech32Decode(s string) (string, []byte, error) {
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, fmt.Errorf("invalid bech32 string")
+	}
+	hrp := s[:pos]
+	var values []byte
+	for _, c := range []byte(s[pos+1:]) {
+		v := strings.IndexByte(bech32Charset, c)
+		if v < 0 {
+			return "", nil, fmt.Errorf("invalid bech32 character %q", c)
+		}
+		values = append(values, byte(v))
+	}
+	if bech32Polymod(append(bech32HrpExpand(hrp), values...)) != 1 {
+		return "", nil, fmt.Errorf("invalid bech32 checksum")
+	}
+	data, err := bech32ConvertBits(values[:len(values)-6], 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return hrp, data, nil
+}
+
+func bech32ConvertBits(data []byte, from, to uint, pad bool) ([]byte, error) {
+	var result []byte
+	acc, bits := uint32(0), uint(0)
+	maxv := uint32(1)<<to - 1
+	for _, v := range data {
+		acc = acc<<from | uint32(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			result = append(result, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			result = append(result, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil, fmt.Errorf("invalid bech32 padding")
+	}
+	return result, nil
+}
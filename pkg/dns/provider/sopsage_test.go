@@ -0,0 +1,135 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+)
+
+func newAgeIdentity() (string, []byte) {
+	scalar := make([]byte, curve25519.ScalarSize)
+	_, _ = rand.Read(scalar)
+	values, err := bech32ConvertBits(scalar, 8, 5, true)
+	Ω(err).ShouldNot(HaveOccurred())
+	chk := bech32Polymod(append(append(bech32HrpExpand(AGE_IDENTITY_HRP), values...), 0, 0, 0, 0, 0, 0)) ^ 1
+	for i := 0; i < 6; i++ {
+		values = append(values, byte(chk>>uint(5*(5-i)))&31)
+	}
+	s := AGE_IDENTITY_HRP + "1"
+	for _, v := range values {
+		s += string(bech32Charset[v])
+	}
+	return strings.ToUpper(s), scalar
+}
+
+func ageEncrypt(identity []byte, plain []byte) string {
+	recipient, err := curve25519.X25519(identity, curve25519.Basepoint)
+	Ω(err).ShouldNot(HaveOccurred())
+	fileKey := make([]byte, 16)
+	_, _ = rand.Read(fileKey)
+	ephemeral := make([]byte, curve25519.ScalarSize)
+	_, _ = rand.Read(ephemeral)
+	share, err := curve25519.X25519(ephemeral, curve25519.Basepoint)
+	Ω(err).ShouldNot(HaveOccurred())
+	shared, err := curve25519.X25519(ephemeral, recipient)
+	Ω(err).ShouldNot(HaveOccurred())
+	aead, err := chacha20poly1305.New(ageHKDF(shared, append(append([]byte{}, share...), recipient...), AGE_X25519_LABEL))
+	Ω(err).ShouldNot(HaveOccurred())
+	body := aead.Seal(nil, make([]byte, chacha20poly1305.NonceSize), fileKey, nil)
+
+	header := AGE_INTRO + "\n-> X25519 " + ageBase64.EncodeToString(share) + "\n" + ageBase64.EncodeToString(body) + "\n---"
+	mac := hmac.New(sha256.New, ageHKDF(fileKey, nil, "header"))
+	mac.Write([]byte(header))
+	header += " " + ageBase64.EncodeToString(mac.Sum(nil)) + "\n"
+
+	nonce := make([]byte, 16)
+	_, _ = rand.Read(nonce)
+	aead, err = chacha20poly1305.New(ageHKDF(fileKey, nonce, "payload"))
+	Ω(err).ShouldNot(HaveOccurred())
+	chunkNonce := make([]byte, chacha20poly1305.NonceSize)
+	chunkNonce[len(chunkNonce)-1] = 1
+	data := append(append([]byte(header), nonce...), aead.Seal(nil, chunkNonce, plain, nil)...)
+
+	b64 := base64.StdEncoding.EncodeToString(data)
+	lines := []string{AGE_ARMOR_BEGIN}
+	for len(b64) > 64 {
+		lines = append(lines, b64[:64])
+		b64 = b64[64:]
+	}
+	return strings.Join(append(lines, b64, AGE_ARMOR_END), "\n") + "\n"
+}
+
+var _ = ginkgov2.Describe("SOPS data keys encrypted with age", func() {
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+
+	ginkgov2.It("decrypts the data key with the matching identity", func() {
+		_, other := newAgeIdentity()
+		_, identity := newAgeIdentity()
+		enc := ageEncrypt(identity, dataKey)
+		Ω(ageDecrypt([][]byte{other, identity}, enc)).Should(Equal(dataKey))
+
+		_, err := ageDecrypt([][]byte{other}, enc)
+		Ω(err).Should(MatchError("no matching age identity"))
+	})
+
+	ginkgov2.It("detects a modified header", func() {
+		_, identity := newAgeIdentity()
+		data, err := ageUnarmor(ageEncrypt(identity, dataKey))
+		Ω(err).ShouldNot(HaveOccurred())
+		modified := strings.Replace(string(data), AGE_INTRO+"\n", AGE_INTRO+"\n-> grease\n\n", 1)
+		armored := AGE_ARMOR_BEGIN + "\n" + base64.StdEncoding.EncodeToString([]byte(modified)) + "\n" + AGE_ARMOR_END
+		_, err = ageDecrypt([][]byte{identity}, armored)
+		Ω(err).Should(MatchError("age header mac mismatch"))
+	})
+
+	ginkgov2.It("reads the identities of the key file", func() {
+		s, identity := newAgeIdentity()
+		file := filepath.Join(ginkgov2.GinkgoT().TempDir(), "keys.txt")
+		Ω(os.WriteFile(file, []byte("# created: 2022-11-02T10:00:00Z\n# public key: age1...\n"+s+"\n"), 0600)).Should(Succeed())
+		Ω(readAgeIdentities(file)).Should(Equal([][]byte{identity}))
+
+		corrupted := s[:len(s)-1] + "Q"
+		if corrupted == s {
+			corrupted = s[:len(s)-1] + "P"
+		}
+		Ω(os.WriteFile(file, []byte(corrupted+"\n"), 0600)).Should(Succeed())
+		_, err := readAgeIdentities(file)
+		Ω(err).Should(MatchError(ContainSubstring("invalid bech32 checksum")))
+		Ω(os.WriteFile(file, []byte("# empty\n"), 0600)).Should(Succeed())
+		_, err = readAgeIdentities(file)
+		Ω(err).Should(MatchError(ContainSubstring("no age identity found")))
+	})
+
+	ginkgov2.It("decrypts the data key of SOPS documents", func() {
+		s, identity := newAgeIdentity()
+		file := filepath.Join(ginkgov2.GinkgoT().TempDir(), "keys.txt")
+		Ω(os.WriteFile(file, []byte(s+"\n"), 0600)).Should(Succeed())
+		meta := &sopsMetadata{Age: []sopsAgeKey{{Recipient: "age1...", Enc: ageEncrypt(identity, dataKey)}}}
+		Ω(newSopsDecryptor(nil, "", file, nil).decryptDataKey(meta)).Should(Equal(dataKey))
+	})
+})
@@ -17,12 +17,14 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -37,7 +39,9 @@ import (
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
 	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
+	"github.com/gardener/external-dns-management/pkg/server/tracing"
 )
 
 type ZonedDNSSetName struct {
@@ -58,10 +62,18 @@ type zoneReconciliation struct {
 	equivEntries dns.DNSNameSet
 	ownership    dns.Ownership
 	stale        ZonedDNSSetNames
-	dedicated    bool
-	deleting     bool
-	fhandler     FinalizerHandler
-	dnsTicker    *Ticker
+	// merged contains the duplicate entries whose targets are merged into the record sets of an entry
+	merged    map[ZonedDNSSetName]EntryList
+	dedicated bool
+	deleting  bool
+	fhandler  FinalizerHandler
+	dnsTicker *Ticker
+	// conflicts detects record sets changed by another owner, nil if not applying changes
+	conflicts *ownerConflictDetector
+	// ptrs are the PTR record sets maintained in a reverse zone
+	ptrs []*ptrRecordSet
+	// ctx carries the span of the zone reconciliation
+	ctx context.Context
 }
 
 type setup struct {
@@ -127,6 +139,8 @@ type state struct {
 	accountCache *AccountCache
 	ownerCache   *OwnerCache
 	zoneStates   *zoneStates
+	vault        *vaultClient
+	sops         *sopsDecryptor
 
 	foreign         map[resources.ObjectName]*foreignProvider
 	providers       map[resources.ObjectName]*dnsProviderVersion
@@ -135,9 +149,12 @@ type state struct {
 	zones           map[dns.ZoneID]*dnsHostedZone
 	zoneproviders   map[dns.ZoneID]resources.ObjectNameSet
 	providerzones   map[resources.ObjectName]map[dns.ZoneID]*dnsHostedZone
-	providersecrets map[resources.ObjectName]resources.ObjectName
+	providersecrets map[resources.ObjectName]resources.ObjectNameSet
 	zonePolicies    map[string]*dnsHostedZonePolicy
 	zoneStateTTL    atomic.Value
+	dnsPolicies     map[string]*dnsPolicy
+	classProfiles   map[string]*dnsClassProfile
+	cplock          sync.RWMutex
 
 	entries         Entries
 	outdated        *synchronizedEntries
@@ -148,12 +165,28 @@ type state struct {
 
 	dnsnames   ZonedDNSSetNames
 	references *References
+	// healthCheckRefs tracks the DNSHealthCheck objects referenced by the routing policies of entries
+	healthCheckRefs *References
+	// healthChecks contains the provider specific ids of the ready DNSHealthCheck objects
+	healthChecks map[resources.ObjectName]string
 
 	initialized bool
 
 	dnsTicker *Ticker
 
+	propagation *propagationChecker
+	stuck       *stuckEntryWatchdog
+	conflicts   *ownerConflictDetector
+	sharding    *zoneSharding
+	locks       *lockObserver
+
+	zoneTriggers *zoneTriggers
+	zoneSlots    *zoneReconciliationSlots
+
 	providerEventListeners []ProviderEventListener
+
+	// namespaceLabels returns the labels of a namespace of the target cluster
+	namespaceLabels func(name string) (map[string]string, error)
 }
 
 type rateLimiterData struct {
@@ -170,14 +203,84 @@ func NewDNSState(ctx Context, ownerresc, secretresc resources.Interface, classes
 	ctx.Infof("using identifier:            %s", config.Ident)
 	ctx.Infof("dry run mode:                %t", config.Dryrun)
 	ctx.Infof("reschedule delay:            %v", config.RescheduleDelay)
+	ctx.Infof("zone trigger debounce:       %v", config.ZoneTriggerDebounce)
+	ctx.Infof("max zones per account:       %d", config.MaxZonesPerAccount)
 	ctx.Infof("zone cache ttl for zones:    %v", config.CacheTTL)
 	ctx.Infof("disable zone state caching:  %t", !config.ZoneStateCaching)
+	if config.ZoneStateCacheDir != "" {
+		ctx.Infof("zone state cache directory:  %s", config.ZoneStateCacheDir)
+	}
+	ctx.Infof("lazy zone loading:           %t", config.LazyZoneLoading)
+	if config.LazyZoneLoading {
+		ctx.Warnf("lazy zone loading: orphaned records are only detected in hosted zones targeted by dns entries")
+	}
 	ctx.Infof("disable DNS name validation:  %t", config.DisableDNSNameValidation)
+	ctx.Infof("orphan grace period:         %v", config.OrphanGracePeriod)
+	ctx.Infof("orphan dry run mode:         %t", config.OrphanDryrun)
+	ctx.Infof("upsert only mode:            %t", config.UpsertOnly)
+	if config.TargetCIDRs != nil {
+		ctx.Infof("target CIDRs:                %s", config.TargetCIDRs)
+	}
+	ctx.Infof("drift check period:          %v", config.DriftCheckPeriod)
+	ctx.Infof("drift correction:            %t", config.DriftCorrection)
+	ctx.Infof("dns lookups:                 %s", config.DNSLookup)
+	ctx.Infof("propagation check timeout:   %v", config.PropagationCheckTimeout)
+	if len(config.PropagationResolvers) > 0 {
+		ctx.Infof("propagation check resolvers: %v", config.PropagationResolvers)
+	}
+	if config.StuckEntryThreshold > 0 {
+		ctx.Infof("stuck entry threshold:       %v (retrigger %t)", config.StuckEntryThreshold, config.StuckEntryRetrigger)
+	}
+	if config.OwnerConflictThreshold > 0 {
+		ctx.Infof("owner conflict threshold:    %d changes within %v", config.OwnerConflictThreshold, config.OwnerConflictWindow)
+	}
+	ctx.Infof("allow force cleanup:         %t", config.AllowForceCleanup)
+	ctx.Infof("duplicate policy:            %s", config.DuplicatePolicy)
+	if config.MinTTL > 0 || config.MaxTTL > 0 {
+		ctx.Infof("ttl bounds:                  %d - %d", config.MinTTL, config.MaxTTL)
+	}
+	if config.LockLookup != nil {
+		ctx.Infof("lock lookup mode:            %s (timeout %s, %d retries)", config.LockLookup.Mode, config.LockLookup.Timeout, config.LockLookup.Retries)
+		if len(config.LockLookup.Resolvers) > 0 {
+			ctx.Infof("lock lookup resolvers:       %v", config.LockLookup.Resolvers)
+		}
+	}
+	ctx.Infof("quota backpressure:          %d%%", config.QuotaBackpressure)
+	if config.ExternalDNSRegistry != nil {
+		ctx.Infof("external-dns registry:       owner %s (write: %t)", config.ExternalDNSRegistry.OwnerId, config.ExternalDNSRegistry.Write)
+	}
+	ctx.Infof("zone export:                 %t", config.ZoneExport)
+	ctx.Infof("maintain PTR records:        %t", config.PTRRecords)
+	ctx.Infof("notifications:               %t", config.Notifier != nil)
+	ctx.Infof("debug state endpoint:        %t", config.DebugStateTokenFile != "")
+	ctx.Infof("readiness zones max age:     %v", config.ReadinessZonesMaxAge)
+	if config.ZoneSharding != nil {
+		ctx.Infof("zone sharding group:         %s (identity %s, lease duration %v)", config.ZoneSharding.Group, config.ZoneSharding.Identity, config.ZoneSharding.LeaseDuration)
+	}
+	if config.Vault != nil {
+		ctx.Infof("vault address:               %s (auth mount %s)", config.Vault.Address, config.Vault.AuthMount)
+	}
+	if config.SopsVaultRole != "" {
+		ctx.Infof("sops vault role:             %s", config.SopsVaultRole)
+	}
+	if config.SopsAgeKeyFile != "" {
+		ctx.Infof("sops age key file:           %s", config.SopsAgeKeyFile)
+	}
+	ctx.Infof("check permissions:           %t", config.CheckPermissions)
+	ctx.Infof("TLS policy:                  %s", config.TLSPolicy)
+	ctx.Infof("log format:                  %s", config.LogFormat)
+	if config.Tracing != nil {
+		ctx.Infof("tracing OTLP endpoint:       %s (insecure: %t)", config.Tracing.Endpoint, config.Tracing.Insecure)
+	}
 	if config.RemoteAccessConfig != nil {
 		ctx.Infof("remote access server port: %d", config.RemoteAccessConfig.Port)
 	}
+	if config.AdmissionWebhookConfig != nil {
+		ctx.Infof("admission webhook port:    %d", config.AdmissionWebhookConfig.Port)
+	}
 
 	realms := access.RealmTypes{"use": access.NewRealmType(dns.REALM_ANNOTATION)}
+	vault := newVaultClient(config.Vault, config.TLSPolicy)
 
 	return &state{
 		setup:               newSetup(),
@@ -189,6 +292,8 @@ func NewDNSState(ctx Context, ownerresc, secretresc resources.Interface, classes
 		realms:              realms,
 		accountCache:        NewAccountCache(config.CacheTTL, config.Options),
 		ownerCache:          NewOwnerCache(ctx, &config),
+		vault:               vault,
+		sops:                newSopsDecryptor(vault, config.SopsVaultRole, config.SopsAgeKeyFile, config.TLSPolicy),
 		foreign:             map[resources.ObjectName]*foreignProvider{},
 		providers:           map[resources.ObjectName]*dnsProviderVersion{},
 		deleting:            map[resources.ObjectName]*dnsProviderVersion{},
@@ -196,14 +301,22 @@ func NewDNSState(ctx Context, ownerresc, secretresc resources.Interface, classes
 		secrets:             map[resources.ObjectName]resources.ObjectNameSet{},
 		zoneproviders:       map[dns.ZoneID]resources.ObjectNameSet{},
 		providerzones:       map[resources.ObjectName]map[dns.ZoneID]*dnsHostedZone{},
-		providersecrets:     map[resources.ObjectName]resources.ObjectName{},
+		providersecrets:     map[resources.ObjectName]resources.ObjectNameSet{},
 		zonePolicies:        map[string]*dnsHostedZonePolicy{},
+		dnsPolicies:         map[string]*dnsPolicy{},
+		classProfiles:       map[string]*dnsClassProfile{},
 		entries:             Entries{},
 		outdated:            newSynchronizedEntries(),
 		blockingEntries:     map[resources.ObjectName]time.Time{},
 		dnsnames:            map[ZonedDNSSetName]*Entry{},
 		references:          NewReferenceCache(),
+		healthCheckRefs:     NewReferenceCache(),
+		healthChecks:        map[resources.ObjectName]string{},
 		providerRateLimiter: map[resources.ObjectName]*rateLimiterData{},
+		zoneTriggers:        newZoneTriggers(),
+		zoneSlots:           newZoneReconciliationSlots(config.MaxZonesPerAccount),
+		locks:               newLockObserver(),
+		namespaceLabels:     namespaceLabelsLookup(ctx),
 	}
 }
 
@@ -217,7 +330,26 @@ func (this *state) Setup() error {
 		return fmt.Errorf("Pool %s not found", DNS_POOL)
 	}
 	this.zoneStates = newZoneStates(this.CreateStateTTLGetter(*syncPeriod))
+	if this.config.ZoneStateCaching && this.config.ZoneStateCacheDir != "" {
+		store, err := newZoneStateStore(this.context, this.config.ZoneStateCacheDir)
+		if err != nil {
+			return err
+		}
+		this.zoneStates.store = store
+	}
 	this.dnsTicker = NewTicker(this.context.GetPool(DNS_POOL).Tick)
+	if err := dnsutils.SetLogFormat(this.config.LogFormat); err != nil {
+		return err
+	}
+	if err := tracing.Setup(this.context.GetContext(), this.context, this.config.Tracing); err != nil {
+		return fmt.Errorf("cannot setup tracing: %w", err)
+	}
+	this.config.Notifier.Start(this.context.GetContext(), this.context)
+	this.propagation = newPropagationChecker(this.context.GetContext(), this.context, this.config.PropagationCheckTimeout,
+		this.config.DNSLookup, this.config.PropagationResolvers, this.GetHostedZone, this.reportPropagation)
+	this.stuck = newStuckEntryWatchdog(this.config.StuckEntryThreshold, this.config.StuckEntryRetrigger)
+	this.startStuckEntryWatchdog()
+	this.conflicts = newOwnerConflictDetector(this.config.OwnerConflictThreshold, this.config.OwnerConflictWindow)
 	this.ownerupd = startOwnerUpdater(this.context, this.ownerresc)
 	processors, err := this.context.GetIntOption(OPT_SETUP)
 	if err != nil || processors <= 0 {
@@ -240,6 +372,29 @@ func (this *state) Setup() error {
 		}
 	}
 
+	if this.config.AdmissionWebhookConfig != nil {
+		if err := this.startAdmissionWebhookServer(); err != nil {
+			return fmt.Errorf("startAdmissionWebhookServer failed with: %w", err)
+		}
+	}
+
+	if this.config.ZoneExport {
+		registerZoneExport(this)
+	}
+	if this.config.DebugStateTokenFile != "" {
+		registerDebugState(this)
+	}
+	registerReadiness(this)
+
+	if this.config.ZoneSharding != nil {
+		resc, err := this.context.GetByExample(&coordinationv1.Lease{})
+		if err != nil {
+			return fmt.Errorf("cannot setup zone sharding: %w", err)
+		}
+		this.sharding = newZoneSharding(this.context, resc, *this.config.ZoneSharding, this.takeoverZones)
+		this.sharding.Start(this.context.GetContext())
+	}
+
 	this.context.Infof("using %d parallel workers for initialization", processors)
 	this.setupFor(&api.DNSProvider{}, "providers", func(e resources.Object) {
 		p := dnsutils.DNSProvider(e)
@@ -399,12 +554,45 @@ type providerMatch struct {
 	match int
 }
 
+// compare compares a provider with the given match length with the currently found one.
+func (this *providerMatch) compare(p DNSProvider, n int) int {
+	if this.found == nil {
+		return 1
+	}
+	return comparePreference(p.Priority(), n, this.found.Priority(), this.match)
+}
+
+// prefers decides whether a provider with the given match length should replace the currently found one.
+// Preferred are providers with higher priority, then longer match, then the provider
+// already assigned to the entry, and finally the provider with the lowest object name.
+func (this *providerMatch) prefers(p DNSProvider, n int, assigned *string) bool {
+	c := this.compare(p, n)
+	if c != 0 {
+		return c > 0
+	}
+	if assigned != nil {
+		if *assigned == this.found.ObjectName().String() {
+			return false
+		}
+		if *assigned == p.ObjectName().String() {
+			return true
+		}
+	}
+	return p.ObjectName().String() < this.found.ObjectName().String()
+}
+
 func (this *state) lookupProvider(e dnsutils.DNSSpecification) (DNSProvider, DNSProvider, error) {
 	handleMatch := func(match *providerMatch, p *dnsProviderVersion, n int, err error) error {
-		if match.match <= n {
-			err2 := access.CheckAccessWithRealms(e, "use", p.Object(), this.realms)
+		if match.compare(p, n) >= 0 {
+			err2 := p.checkNamespaceAccess(e.GetNamespace(), this.namespaceLabels)
+			if err2 == nil {
+				err2 = this.getDNSClassProfile(e).CheckProvider(p.ObjectName())
+			}
+			if err2 == nil {
+				err2 = access.CheckAccessWithRealms(e, "use", p.Object(), this.realms)
+			}
 			if err2 == nil {
-				if match.match < n || (e.BaseStatus().Provider != nil && *e.BaseStatus().Provider == p.object.ObjectName().String()) {
+				if match.prefers(p, n, e.BaseStatus().Provider) {
 					match.found = p
 					match.match = n
 				}
@@ -416,11 +604,18 @@ func (this *state) lookupProvider(e dnsutils.DNSSpecification) (DNSProvider, DNS
 		}
 		return err
 	}
+	if pinned := e.GetProvider(); pinned != nil && *pinned != "" {
+		return this.lookupPinnedProvider(e, *pinned)
+	}
 	var err error
 	validMatch := &providerMatch{}
 	errorMatch := &providerMatch{}
 	validMatchFallback := &providerMatch{}
+	zoneid := utils.StringValue(e.GetZone())
 	for _, p := range this.providers {
+		if zoneid != "" && this.getPinnedZone(e, p, zoneid) == nil {
+			continue
+		}
 		n := p.Match(e.GetDNSName())
 		if n > 0 {
 			if p.IsValid() {
@@ -441,9 +636,80 @@ func (this *state) lookupProvider(e dnsutils.DNSSpecification) (DNSProvider, DNS
 	if errorMatch.found != nil {
 		return errorMatch.found, nil, nil
 	}
+	if zoneid != "" && validMatchFallback.found == nil && err == nil {
+		err = fmt.Errorf("requested zone %s not found for %s", zoneid, e.GetDNSName())
+	}
 	return nil, validMatchFallback.found, err
 }
 
+// getPinnedZone returns the hosted zone explicitly requested by an entry,
+// if it is served by the given provider and responsible for the DNS name of the entry.
+func (this *state) getPinnedZone(e dnsutils.DNSSpecification, p DNSProvider, zoneid string) *dnsHostedZone {
+	id := dns.NewZoneID(p.TypeCode(), zoneid)
+	zone := this.zones[id]
+	if zone == nil || !p.IncludesZone(id) || !dnsutils.Match(e.GetDNSName(), zone.Domain()) {
+		return nil
+	}
+	return zone
+}
+
+// getEntryZone returns the hosted zone used for an entry by the given provider.
+func (this *state) getEntryZone(e dnsutils.DNSSpecification, provider DNSProvider) *dnsHostedZone {
+	if zoneid := utils.StringValue(e.GetZone()); zoneid != "" {
+		if provider == nil {
+			return nil
+		}
+		return this.getPinnedZone(e, provider, zoneid)
+	}
+	return this.getProviderZoneForName(e.GetDNSName(), provider)
+}
+
+// lookupPinnedProvider restricts the provider lookup to the provider explicitly
+// requested by the entry (given as namespace/name or name in the entry's namespace).
+func (this *state) lookupPinnedProvider(e dnsutils.DNSSpecification, pinned string) (DNSProvider, DNSProvider, error) {
+	name, err := ParsePinnedProviderName(pinned, e.GetNamespace())
+	if err != nil {
+		return nil, nil, err
+	}
+	p := this.providers[name]
+	if p == nil {
+		return nil, nil, fmt.Errorf("requested provider %s not found", name)
+	}
+	if err := p.checkNamespaceAccess(e.GetNamespace(), this.namespaceLabels); err != nil {
+		return nil, nil, err
+	}
+	if err := this.getDNSClassProfile(e).CheckProvider(name); err != nil {
+		return nil, nil, err
+	}
+	if err := access.CheckAccessWithRealms(e, "use", p.Object(), this.realms); err != nil {
+		return nil, nil, err
+	}
+	if zoneid := utils.StringValue(e.GetZone()); zoneid != "" && this.getPinnedZone(e, p, zoneid) == nil {
+		return nil, nil, fmt.Errorf("requested zone %s of provider %s not found for %s", zoneid, name, e.GetDNSName())
+	}
+	if p.Match(e.GetDNSName()) > 0 {
+		return p, nil, nil
+	}
+	if p.IsValid() && p.MatchZone(e.GetDNSName()) > 0 {
+		return nil, p, nil
+	}
+	return nil, nil, fmt.Errorf("requested provider %s is not responsible for %s", name, e.GetDNSName())
+}
+
+// ParsePinnedProviderName parses a provider reference of the form namespace/name.
+// If no namespace is given, the namespace of the referencing object is used.
+func ParsePinnedProviderName(ref string, namespace string) (resources.ObjectName, error) {
+	parts := strings.Split(ref, "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return resources.NewObjectName(namespace, parts[0]), nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return resources.NewObjectName(parts[0], parts[1]), nil
+	default:
+		return nil, fmt.Errorf("invalid provider reference %q (expected namespace/name)", ref)
+	}
+}
+
 func (this *state) GetProvider(name resources.ObjectName) DNSProvider {
 	this.lock.RLock()
 	defer this.lock.RUnlock()
@@ -538,12 +804,31 @@ func (this *state) addEntriesForZone(logger logger.LogContext, entries Entries,
 	return entries, equivEntries, stale, deleting
 }
 
+// GetHostedZone returns the hosted zone for the zone id or nil if unknown.
+func (this *state) GetHostedZone(zoneid dns.ZoneID) DNSHostedZone {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	if zone := this.zones[zoneid]; zone != nil {
+		return zone
+	}
+	return nil
+}
+
 func (this *state) GetZoneForEntry(e *Entry) *dns.ZoneID {
 	if !e.IsValid() {
 		return nil
 	}
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
 	provider, _, _ := this.lookupProvider(e.object)
-	return this.GetProviderZoneForName(e.DNSName(), provider)
+	found := this.getEntryZone(e.object, provider)
+	if found != nil {
+		z := found.Id()
+		return &z
+	}
+	return nil
 }
 
 func (this *state) GetProviderZoneForName(name string, provider DNSProvider) *dns.ZoneID {
@@ -560,15 +845,30 @@ func (this *state) GetProviderZoneForName(name string, provider DNSProvider) *dn
 
 func (this *state) getProviderZoneForName(hostname string, provider DNSProvider) *dnsHostedZone {
 	zones := this.getZonesForName(hostname)
-	return filterZoneByProvider(zones, provider)
+	zone := filterZoneByProvider(zones, provider)
+	if zone == nil && provider != nil {
+		// a provider preferred by priority may only serve a less specific zone
+		zones = this.getFilteredZonesForName(hostname, provider.IncludesZone)
+		zone = filterZoneByProvider(zones, provider)
+	}
+	return zone
 }
 
 // getZonesForName can return multiple zones in the case of private zones
 func (this *state) getZonesForName(hostname string) []*dnsHostedZone {
+	return this.getFilteredZonesForName(hostname, nil)
+}
+
+// getFilteredZonesForName returns the most specific zones for a hostname
+// restricted to the zones accepted by the optional filter.
+func (this *state) getFilteredZonesForName(hostname string, filter func(zoneID dns.ZoneID) bool) []*dnsHostedZone {
 	var found []*dnsHostedZone
 	length := 0
 loop:
 	for _, zone := range this.zones {
+		if filter != nil && !filter(zone.Id()) {
+			continue
+		}
 		name := zone.Domain()
 		if dnsutils.Match(hostname, name) {
 			for _, f := range zone.ForwardedDomains() {
@@ -598,7 +898,13 @@ func (this *state) triggerStatistic() {
 func (this *state) triggerHostedZone(zoneid dns.ZoneID) {
 	cmd := CMD_HOSTEDZONE_PREFIX + zoneid.ProviderType + ":" + zoneid.ID
 	if this.context.IsReady() {
-		this.context.EnqueueCommand(cmd)
+		if this.config.ZoneTriggerDebounce <= 0 {
+			this.context.EnqueueCommand(cmd)
+		} else if this.zoneTriggers.Add(zoneid) {
+			this.context.GetPool(DNS_POOL).EnqueueCommandAfter(cmd, this.config.ZoneTriggerDebounce)
+		} else {
+			metrics.AddCoalescedZoneTrigger(zoneid)
+		}
 	} else {
 		this.setup.AddCommand(cmd)
 	}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"reflect"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// state handling for DNSClassProfiles
+////////////////////////////////////////////////////////////////////////////////
+
+func (this *state) UpdateDNSClassProfile(logger logger.LogContext, profile *dnsutils.DNSClassProfileObject) reconcile.Status {
+	class := profile.GetName()
+	if !this.classes.Contains(class) {
+		return this.RemoveDNSClassProfile(logger, profile)
+	}
+	prof, err := newDNSClassProfile(class, profile.Spec())
+	if err != nil {
+		logger.Warnf("invalid dns class profile: %s", err)
+		this.setDNSClassProfile(logger, class, nil)
+		if uerr := this.updateDNSClassProfileStatus(profile, api.STATE_INVALID, err.Error()); uerr != nil {
+			return reconcile.Delay(logger, uerr)
+		}
+		return reconcile.Succeeded(logger)
+	}
+	this.setDNSClassProfile(logger, class, prof)
+	if err := this.updateDNSClassProfileStatus(profile, api.STATE_READY, "profile is applied to entries of class"); err != nil {
+		return reconcile.Delay(logger, err)
+	}
+	return reconcile.Succeeded(logger)
+}
+
+func (this *state) RemoveDNSClassProfile(logger logger.LogContext, profile *dnsutils.DNSClassProfileObject) reconcile.Status {
+	return this.DNSClassProfileDeleted(logger, this.createDNSClassProfileClusterKey(profile.GetName()))
+}
+
+func (this *state) DNSClassProfileDeleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	this.setDNSClassProfile(logger, key.Name(), nil)
+	return reconcile.Succeeded(logger)
+}
+
+// setDNSClassProfile stores or removes the profile of a DNS class and triggers
+// all entries of the class if the profile has changed.
+func (this *state) setDNSClassProfile(logger logger.LogContext, class string, prof *dnsClassProfile) {
+	this.cplock.Lock()
+	old := this.classProfiles[class]
+	if prof == nil {
+		if old == nil {
+			this.cplock.Unlock()
+			return
+		}
+		delete(this.classProfiles, class)
+		logger.Infof("removed dns class profile %s", class)
+	} else {
+		if old != nil && reflect.DeepEqual(old.spec, prof.spec) {
+			this.cplock.Unlock()
+			return
+		}
+		this.classProfiles[class] = prof
+		logger.Infof("updated dns class profile %s", class)
+	}
+	this.cplock.Unlock()
+
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	for _, e := range this.entries {
+		if this.entryClass(e.Object()) == class {
+			this.TriggerEntry(nil, e)
+		}
+	}
+}
+
+// getDNSClassProfile returns the profile of the DNS class of the given object or nil.
+func (this *state) getDNSClassProfile(obj resources.Object) *dnsClassProfile {
+	this.cplock.RLock()
+	defer this.cplock.RUnlock()
+	return this.classProfiles[this.entryClass(obj)]
+}
+
+func (this *state) entryClass(obj resources.Object) string {
+	if class := obj.GetAnnotations()[dns.CLASS_ANNOTATION]; class != "" {
+		return class
+	}
+	return this.classes.Default()
+}
+
+func (this *state) updateDNSClassProfileStatus(profile *dnsutils.DNSClassProfileObject, state, msg string) error {
+	_, err := profile.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSClassProfile).Status
+		mod := status.State != state || status.Message == nil || *status.Message != msg ||
+			status.ObservedGeneration != data.GetGeneration()
+		status.State = state
+		status.Message = &msg
+		status.ObservedGeneration = data.GetGeneration()
+		mod = dnsutils.SetStateConditions(&status.Conditions, status.ObservedGeneration, state, &msg) || mod
+		return mod, nil
+	})
+	return err
+}
+
+func (this *state) createDNSClassProfileClusterKey(name string) resources.ClusterObjectKey {
+	targetClusterID := this.context.GetCluster(TARGET_CLUSTER).GetId()
+	return resources.NewClusterKey(targetClusterID, dnsClassProfileGroupKind, "", name)
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// state handling for DNSHealthChecks
+////////////////////////////////////////////////////////////////////////////////
+
+func (this *state) UpdateDNSHealthCheck(logger logger.LogContext, hc *dnsutils.DNSHealthCheckObject) reconcile.Status {
+	provider, mgmt, status := this.lookupHealthCheckManagement(logger, hc)
+	if provider == nil {
+		return status
+	}
+	if mgmt == nil {
+		return this.failDNSHealthCheck(logger, hc, api.STATE_INVALID, fmt.Errorf("provider type %s does not support management of health checks", provider.TypeCode()))
+	}
+	spec, err := healthCheckSpec(hc.Spec(), string(hc.GetUID()))
+	if err != nil {
+		return this.failDNSHealthCheck(logger, hc, api.STATE_INVALID, err)
+	}
+	if err := this.SetFinalizer(hc); err != nil {
+		return reconcile.Delay(logger, err)
+	}
+
+	id := hc.Status().HealthCheckID
+	if id == "" {
+		logger.Infof("creating %s health check with provider %s", spec.Type, provider.ObjectName())
+		id, err = mgmt.CreateHealthCheck(logger, spec)
+		if err == nil {
+			logger.Infof("created health check %s", id)
+		}
+	} else {
+		err = mgmt.UpdateHealthCheck(logger, id, spec)
+	}
+	if err != nil {
+		return this.failDNSHealthCheck(logger, hc, api.STATE_ERROR, err)
+	}
+	this.setHealthCheckID(logger, hc.ObjectName(), id)
+	err = this.updateDNSHealthCheckStatus(hc, api.STATE_READY, "health check provisioned", &id)
+	return reconcile.DelayOnError(logger, err)
+}
+
+func (this *state) DeleteDNSHealthCheck(logger logger.LogContext, hc *dnsutils.DNSHealthCheckObject) reconcile.Status {
+	if !this.HasFinalizer(hc) {
+		return reconcile.Succeeded(logger)
+	}
+	this.setHealthCheckID(logger, hc.ObjectName(), "")
+	if id := hc.Status().HealthCheckID; id != "" {
+		provider, mgmt, status := this.lookupHealthCheckManagement(logger, hc)
+		if provider == nil {
+			return status
+		}
+		if mgmt == nil {
+			return this.failDNSHealthCheck(logger, hc, api.STATE_INVALID, fmt.Errorf("provider type %s does not support management of health checks", provider.TypeCode()))
+		}
+		logger.Infof("deleting health check %s of provider %s", id, provider.ObjectName())
+		if err := mgmt.DeleteHealthCheck(logger, id); err != nil {
+			return this.failDNSHealthCheck(logger, hc, api.STATE_ERROR, fmt.Errorf("deletion of health check %s failed: %s", id, err))
+		}
+	}
+	return reconcile.DelayOnError(logger, this.RemoveFinalizer(hc))
+}
+
+// lookupHealthCheckManagement determines the provider of a DNSHealthCheck object.
+// If no provider is returned, the returned status should be used as
+// reconcile result.
+func (this *state) lookupHealthCheckManagement(logger logger.LogContext, hc *dnsutils.DNSHealthCheckObject) (DNSProvider, DNSHealthCheckManagement, reconcile.Status) {
+	provider, status := this.lookupManagingProvider(logger, hc, hc.Spec().Provider, func(state string, err error) reconcile.Status {
+		return this.failDNSHealthCheck(logger, hc, state, err)
+	})
+	if provider == nil {
+		return nil, nil, status
+	}
+	return provider, provider.GetHealthCheckManagement(), status
+}
+
+func (this *state) failDNSHealthCheck(logger logger.LogContext, hc *dnsutils.DNSHealthCheckObject, state string, err error) reconcile.Status {
+	if state != api.STATE_ERROR {
+		this.setHealthCheckID(logger, hc.ObjectName(), "")
+	}
+	if uerr := this.updateDNSHealthCheckStatus(hc, state, err.Error(), nil); uerr != nil {
+		return reconcile.Delay(logger, uerr)
+	}
+	if state == api.STATE_ERROR {
+		return reconcile.Delay(logger, err)
+	}
+	logger.Warn(err)
+	return reconcile.Succeeded(logger)
+}
+
+func (this *state) updateDNSHealthCheckStatus(hc *dnsutils.DNSHealthCheckObject, state, msg string, id *string) error {
+	_, err := hc.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSHealthCheck).Status
+		mod := status.State != state || status.Message == nil || *status.Message != msg ||
+			status.ObservedGeneration != data.GetGeneration()
+		status.State = state
+		status.Message = &msg
+		status.ObservedGeneration = data.GetGeneration()
+		if id != nil && status.HealthCheckID != *id {
+			status.HealthCheckID = *id
+			mod = true
+		}
+		mod = dnsutils.SetStateConditions(&status.Conditions, status.ObservedGeneration, state, &msg) || mod
+		return mod, nil
+	})
+	return err
+}
+
+// setHealthCheckID stores or removes (empty id) the provider specific id of a ready health check
+// and triggers the entries referencing the health check if the id has changed.
+func (this *state) setHealthCheckID(logger logger.LogContext, name resources.ObjectName, id string) {
+	this.lock.Lock()
+	old := this.healthChecks[name]
+	if id == "" {
+		delete(this.healthChecks, name)
+	} else {
+		this.healthChecks[name] = id
+	}
+	this.lock.Unlock()
+
+	if old != id {
+		logger.Infof("health check %s changed: %q -> %q", name, old, id)
+		this.healthCheckRefs.NotifyHolder(this.context, this.healthCheckKey(name))
+	}
+}
+
+func (this *state) getHealthCheckID(name resources.ObjectName) string {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.healthChecks[name]
+}
+
+func (this *state) healthCheckKey(name resources.ObjectName) resources.ClusterObjectKey {
+	return resources.NewClusterKey(this.context.GetCluster(PROVIDER_CLUSTER).GetId(), healthCheckGroupKind, name.Namespace(), name.Name())
+}
+
+// completeHealthCheck replaces the reference to a DNSHealthCheck object in the routing policy
+// of an entry by the provider specific id of the health check.
+func (this *state) completeHealthCheck(spec dnsutils.DNSSpecification, object resources.Object) (dnsutils.DNSSpecification, error) {
+	policy := spec.GetRoutingPolicy()
+	if policy == nil || policy.Parameters[dns.RoutingPolicyParamHealthCheck] == "" {
+		this.healthCheckRefs.DelRef(object.ClusterKey())
+		return spec, nil
+	}
+	name, err := ParsePinnedProviderName(policy.Parameters[dns.RoutingPolicyParamHealthCheck], object.GetNamespace())
+	if err != nil {
+		return nil, fmt.Errorf("invalid health check reference %q in routing policy", policy.Parameters[dns.RoutingPolicyParamHealthCheck])
+	}
+	this.healthCheckRefs.AddRef(object.ClusterKey(), this.healthCheckKey(name))
+	resolved, err := resolveHealthCheck(policy, name, this.getHealthCheckID(name))
+	if err != nil {
+		return nil, err
+	}
+	return &dnsSpecModification{DNSSpecification: spec, policy: resolved}, nil
+}
+
+// resolveHealthCheck returns a copy of the routing policy with the parameter healthCheckID
+// instead of the reference to the health check.
+func resolveHealthCheck(policy *dns.RoutingPolicy, name resources.ObjectName, id string) (*dns.RoutingPolicy, error) {
+	if _, ok := policy.Parameters[dns.RoutingPolicyParamHealthCheckID]; ok {
+		return nil, fmt.Errorf("routing policy parameters %s and %s are exclusive", dns.RoutingPolicyParamHealthCheck, dns.RoutingPolicyParamHealthCheckID)
+	}
+	if id == "" {
+		return nil, fmt.Errorf("health check %s not found or not ready", name)
+	}
+	resolved := policy.Clone()
+	delete(resolved.Parameters, dns.RoutingPolicyParamHealthCheck)
+	resolved.Parameters[dns.RoutingPolicyParamHealthCheckID] = id
+	return resolved, nil
+}
+
+// healthCheckSpec validates the spec of a DNSHealthCheck object and completes the defaults.
+func healthCheckSpec(spec *api.DNSHealthCheckSpec, reference string) (*HealthCheckSpec, error) {
+	hc := &HealthCheckSpec{
+		Type:             spec.Type,
+		FQDN:             spec.FQDN,
+		IPAddress:        spec.IPAddress,
+		ResourcePath:     spec.ResourcePath,
+		SearchString:     spec.SearchString,
+		RequestInterval:  30,
+		FailureThreshold: 3,
+		Reference:        reference,
+	}
+	switch spec.Type {
+	case api.HealthCheckTypeHTTP:
+		hc.Port = 80
+	case api.HealthCheckTypeHTTPS:
+		hc.Port = 443
+	case api.HealthCheckTypeTCP:
+		hc.Port = 80
+		if spec.ResourcePath != "" || spec.SearchString != "" {
+			return nil, fmt.Errorf("resource path and search string are only supported for HTTP and HTTPS health checks")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported health check type %q (expected HTTP, HTTPS or TCP)", spec.Type)
+	}
+	if spec.FQDN == "" && spec.IPAddress == "" {
+		return nil, fmt.Errorf("either fqdn or ipAddress must be set")
+	}
+	if spec.IPAddress != "" && net.ParseIP(spec.IPAddress) == nil {
+		return nil, fmt.Errorf("invalid IP address %q", spec.IPAddress)
+	}
+	if spec.ResourcePath != "" && !strings.HasPrefix(spec.ResourcePath, "/") {
+		return nil, fmt.Errorf("resource path %q must start with /", spec.ResourcePath)
+	}
+	if spec.Port != nil {
+		if *spec.Port < 1 || *spec.Port > 65535 {
+			return nil, fmt.Errorf("invalid port %d", *spec.Port)
+		}
+		hc.Port = *spec.Port
+	}
+	if spec.RequestInterval != nil {
+		if *spec.RequestInterval < 1 {
+			return nil, fmt.Errorf("invalid request interval %d", *spec.RequestInterval)
+		}
+		hc.RequestInterval = *spec.RequestInterval
+	}
+	if spec.FailureThreshold != nil {
+		if *spec.FailureThreshold < 1 || *spec.FailureThreshold > 10 {
+			return nil, fmt.Errorf("failure threshold %d must be between 1 and 10", *spec.FailureThreshold)
+		}
+		hc.FailureThreshold = *spec.FailureThreshold
+	}
+	return hc, nil
+}
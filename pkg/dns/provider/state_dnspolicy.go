@@ -0,0 +1,193 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// state handling for DNSPolicies
+////////////////////////////////////////////////////////////////////////////////
+
+func (this *state) UpdateDNSPolicy(logger logger.LogContext, policy *dnsutils.DNSPolicyObject) reconcile.Status {
+	name := policy.GetName()
+	pol, err := newDNSPolicy(name, policy.Spec())
+	if err != nil {
+		logger.Warnf("invalid dns policy: %s", err)
+		this.setDNSPolicy(logger, name, nil)
+		if uerr := this.updateDNSPolicyStatus(policy, api.STATE_INVALID, err.Error()); uerr != nil {
+			return reconcile.Delay(logger, uerr)
+		}
+		return reconcile.Succeeded(logger)
+	}
+	this.setDNSPolicy(logger, name, pol)
+	if err := this.updateDNSPolicyStatus(policy, api.STATE_READY, "policy is applied to entries"); err != nil {
+		return reconcile.Delay(logger, err)
+	}
+	return reconcile.Succeeded(logger)
+}
+
+func (this *state) RemoveDNSPolicy(logger logger.LogContext, policy *dnsutils.DNSPolicyObject) reconcile.Status {
+	return this.DNSPolicyDeleted(logger, this.createDNSPolicyClusterKey(policy.GetName()))
+}
+
+func (this *state) DNSPolicyDeleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	this.setDNSPolicy(logger, key.Name(), nil)
+	return reconcile.Succeeded(logger)
+}
+
+// setDNSPolicy stores or removes a policy and triggers all entries
+// for revalidation if the effective policy has changed.
+func (this *state) setDNSPolicy(logger logger.LogContext, name string, pol *dnsPolicy) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	old := this.dnsPolicies[name]
+	if pol == nil {
+		if old == nil {
+			return
+		}
+		delete(this.dnsPolicies, name)
+		logger.Infof("removed dns policy %s", name)
+	} else {
+		if old != nil && reflect.DeepEqual(old.spec, pol.spec) {
+			return
+		}
+		this.dnsPolicies[name] = pol
+		logger.Infof("updated dns policy %s", name)
+	}
+	for _, e := range this.entries {
+		this.TriggerEntry(nil, e)
+	}
+}
+
+// getDNSPolicies returns the active policies ordered by name.
+func (this *state) getDNSPolicies() []*dnsPolicy {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	policies := make([]*dnsPolicy, 0, len(this.dnsPolicies))
+	for _, pol := range this.dnsPolicies {
+		policies = append(policies, pol)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].name < policies[j].name })
+	return policies
+}
+
+func (this *state) checkDNSPolicies(namespace, dnsname string, ttl *int64, targets Targets) error {
+	for _, pol := range this.getDNSPolicies() {
+		if err := pol.Check(namespace, dnsname, ttl, targets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkQuotas validates that the entry is within the quotas of its namespace.
+// The entries of a namespace are admitted in the order of their creation.
+func (this *state) checkQuotas(entry *EntryVersion) error {
+	if entry.Kind() != api.DNSEntryKind || entry.IsDeleting() {
+		return nil
+	}
+	namespace := entry.ObjectName().Namespace()
+	var limit *api.NamespaceQuota
+	var policy *dnsPolicy
+	for _, pol := range this.getDNSPolicies() {
+		if q := pol.Quota(namespace); q != nil && (limit == nil || q.MaxEntries < limit.MaxEntries) {
+			limit = q
+			policy = pol
+		}
+	}
+	if limit == nil {
+		metrics.DeleteQuotaExceededEntries(namespace)
+		return nil
+	}
+
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	count := 1
+	rank := 0
+	created := entry.Object().GetCreationTimestamp().Time
+	for name, e := range this.entries {
+		if name.Namespace() != namespace || e.Kind() != api.DNSEntryKind || e.IsDeleting() || name == entry.ObjectName() {
+			continue
+		}
+		count++
+		t := e.Object().GetCreationTimestamp().Time
+		if t.Before(created) || t.Equal(created) && name.String() < entry.ObjectName().String() {
+			rank++
+		}
+	}
+	exceeded := count - limit.MaxEntries
+	if exceeded < 0 {
+		exceeded = 0
+	}
+	metrics.ReportQuotaExceededEntries(namespace, exceeded)
+	if rank >= limit.MaxEntries {
+		return policy.errorf("quota exceeded: namespace %q is limited to %d entries", namespace, limit.MaxEntries)
+	}
+	return nil
+}
+
+// triggerQuotaEntries triggers the entries of a namespace for revalidation
+// after an entry has been deleted if the namespace is limited by a quota.
+func (this *state) triggerQuotaEntries(namespace string) {
+	limited := false
+	for _, pol := range this.dnsPolicies {
+		if pol.Quota(namespace) != nil {
+			limited = true
+			break
+		}
+	}
+	if !limited {
+		return
+	}
+	for name, e := range this.entries {
+		if name.Namespace() == namespace {
+			this.TriggerEntry(nil, e)
+		}
+	}
+}
+
+func (this *state) updateDNSPolicyStatus(policy *dnsutils.DNSPolicyObject, state, msg string) error {
+	_, err := policy.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSPolicy).Status
+		mod := status.State != state || status.Message == nil || *status.Message != msg ||
+			status.ObservedGeneration != data.GetGeneration()
+		status.State = state
+		status.Message = &msg
+		status.ObservedGeneration = data.GetGeneration()
+		mod = dnsutils.SetStateConditions(&status.Conditions, status.ObservedGeneration, state, &msg) || mod
+		return mod, nil
+	})
+	return err
+}
+
+func (this *state) createDNSPolicyClusterKey(name string) resources.ClusterObjectKey {
+	targetClusterID := this.context.GetCluster(TARGET_CLUSTER).GetId()
+	return resources.NewClusterKey(targetClusterID, dnsPolicyGroupKind, "", name)
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// state handling for DNSZones
+////////////////////////////////////////////////////////////////////////////////
+
+func (this *state) UpdateDNSZone(logger logger.LogContext, zone *dnsutils.DNSZoneObject) reconcile.Status {
+	provider, mgmt, status := this.lookupZoneManagement(logger, zone)
+	if provider == nil {
+		return status
+	}
+	if mgmt == nil {
+		return this.failDNSZone(logger, zone, api.STATE_INVALID, fmt.Errorf("provider type %s does not support management of hosted zones", provider.TypeCode()))
+	}
+	if err := this.SetFinalizer(zone); err != nil {
+		return reconcile.Delay(logger, err)
+	}
+
+	spec := zone.Spec()
+	zoneSpec := &HostedZoneSpec{
+		Domain:          spec.DomainName,
+		Private:         spec.Private,
+		PrivateNetworks: spec.PrivateNetworks,
+		DNSSEC:          spec.DNSSEC,
+		Reference:       string(zone.GetUID()),
+	}
+	var info *HostedZoneInfo
+	var err error
+	if zoneid := zone.Status().ZoneID; zoneid == "" {
+		logger.Infof("creating hosted zone for %s with provider %s", spec.DomainName, provider.ObjectName())
+		info, err = mgmt.CreateHostedZone(logger, zoneSpec)
+		if err == nil {
+			logger.Infof("created hosted zone %s", info.ZoneID)
+		}
+	} else {
+		info, err = mgmt.UpdateHostedZone(logger, zoneid, zoneSpec)
+	}
+	if err != nil {
+		return this.failDNSZone(logger, zone, api.STATE_ERROR, err)
+	}
+	err = this.updateDNSZoneStatus(zone, api.STATE_READY, "hosted zone provisioned", info)
+	return reconcile.DelayOnError(logger, err)
+}
+
+func (this *state) DeleteDNSZone(logger logger.LogContext, zone *dnsutils.DNSZoneObject) reconcile.Status {
+	if !this.HasFinalizer(zone) {
+		return reconcile.Succeeded(logger)
+	}
+	if zoneid := zone.Status().ZoneID; zoneid != "" {
+		provider, mgmt, status := this.lookupZoneManagement(logger, zone)
+		if provider == nil {
+			return status
+		}
+		if mgmt == nil {
+			return this.failDNSZone(logger, zone, api.STATE_INVALID, fmt.Errorf("provider type %s does not support management of hosted zones", provider.TypeCode()))
+		}
+		logger.Infof("deleting hosted zone %s of provider %s", zoneid, provider.ObjectName())
+		if err := mgmt.DeleteHostedZone(logger, zoneid); err != nil {
+			return this.failDNSZone(logger, zone, api.STATE_ERROR, fmt.Errorf("deletion of hosted zone %s failed: %s", zoneid, err))
+		}
+	}
+	return reconcile.DelayOnError(logger, this.RemoveFinalizer(zone))
+}
+
+// lookupZoneManagement determines the provider of a DNSZone object.
+// If no provider is returned, the returned status should be used as
+// reconcile result.
+func (this *state) lookupZoneManagement(logger logger.LogContext, zone *dnsutils.DNSZoneObject) (DNSProvider, DNSZoneManagement, reconcile.Status) {
+	provider, status := this.lookupManagingProvider(logger, zone, zone.Spec().Provider, func(state string, err error) reconcile.Status {
+		return this.failDNSZone(logger, zone, state, err)
+	})
+	if provider == nil {
+		return nil, nil, status
+	}
+	return provider, provider.GetZoneManagement(), status
+}
+
+// lookupManagingProvider determines the provider pinned by an object managed with the provider
+// (namespace/name or name in the namespace of the object). If no provider is returned,
+// the returned status should be used as reconcile result.
+func (this *state) lookupManagingProvider(logger logger.LogContext, object resources.Object, pinned string,
+	fail func(state string, err error) reconcile.Status) (DNSProvider, reconcile.Status) {
+	name, err := ParsePinnedProviderName(pinned, object.GetNamespace())
+	if err != nil {
+		return nil, fail(api.STATE_INVALID, err)
+	}
+	resc, err := object.GetResource().Resources().GetByGK(providerGroupKind)
+	if err != nil {
+		return nil, reconcile.Delay(logger, err)
+	}
+	obj, err := resc.GetCached(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			status := fail(api.STATE_PENDING, fmt.Errorf("provider %s not found", name))
+			return nil, status.RescheduleAfter(this.config.RescheduleDelay)
+		}
+		return nil, reconcile.Delay(logger, err)
+	}
+	if !this.config.Factory.IsResponsibleFor(dnsutils.DNSProvider(obj)) || !this.config.Enabled.Contains(dnsutils.DNSProvider(obj).TypeCode()) {
+		// handled by another DNS controller
+		return nil, reconcile.Succeeded(logger)
+	}
+	provider := this.GetProvider(name)
+	if provider == nil || !provider.IsValid() {
+		status := fail(api.STATE_PENDING, fmt.Errorf("provider %s not ready", name))
+		return nil, status.RescheduleAfter(this.config.RescheduleDelay)
+	}
+	return provider, reconcile.Succeeded(logger)
+}
+
+func (this *state) failDNSZone(logger logger.LogContext, zone *dnsutils.DNSZoneObject, state string, err error) reconcile.Status {
+	if uerr := this.updateDNSZoneStatus(zone, state, err.Error(), nil); uerr != nil {
+		return reconcile.Delay(logger, uerr)
+	}
+	if state == api.STATE_ERROR {
+		return reconcile.Delay(logger, err)
+	}
+	logger.Warn(err)
+	return reconcile.Succeeded(logger)
+}
+
+func (this *state) updateDNSZoneStatus(zone *dnsutils.DNSZoneObject, state, msg string, info *HostedZoneInfo) error {
+	_, err := zone.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSZone).Status
+		mod := status.State != state || status.Message == nil || *status.Message != msg ||
+			status.ObservedGeneration != data.GetGeneration()
+		status.State = state
+		status.Message = &msg
+		status.ObservedGeneration = data.GetGeneration()
+		if info != nil {
+			if status.ZoneID != info.ZoneID || !reflect.DeepEqual(status.NameServers, info.NameServers) {
+				status.ZoneID = info.ZoneID
+				status.NameServers = info.NameServers
+				mod = true
+			}
+		}
+		mod = dnsutils.SetStateConditions(&status.Conditions, status.ObservedGeneration, state, &msg) || mod
+		return mod, nil
+	})
+	return err
+}
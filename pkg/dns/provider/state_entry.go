@@ -19,7 +19,6 @@ package provider
 
 import (
 	"fmt"
-	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -32,6 +31,9 @@ import (
 	"github.com/gardener/external-dns-management/pkg/dns"
 	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -207,9 +209,26 @@ func (this *state) AddEntryVersion(logger logger.LogContext, v *EntryVersion, st
 	if dnsname != "" {
 		if cur != nil {
 			if cur.ObjectName() != new.ObjectName() {
-				if cur.Before(new) {
+				policy := this.duplicatePolicy(v)
+				merge := policy == api.DuplicatePolicyMergeTargets && canMergeTargets(cur, new)
+				if keepsDNSName(policy, cur, new) {
 					new.duplicate = true
 					new.modified = false
+					if merge {
+						new.merged = true
+						msg := fmt.Sprintf("targets merged into record sets of entry %s", cur.ObjectName())
+						logger.Info(msg)
+						if status.IsSucceeded() && new.status.State != api.STATE_READY {
+							_, err := v.UpdateStatus(logger, api.STATE_PENDING, msg)
+							if err != nil {
+								return new, reconcile.DelayOnError(logger, err)
+							}
+						}
+						if !new.ZoneId().IsEmpty() {
+							this.triggerHostedZone(new.ZoneId())
+						}
+						return new, status
+					}
 					err := &perrs.AlreadyBusyForEntry{DNSName: dnsname, ObjectName: cur.ObjectName()}
 					logger.Warnf("%s", err)
 					if status.IsSucceeded() {
@@ -221,14 +240,15 @@ func (this *state) AddEntryVersion(logger logger.LogContext, v *EntryVersion, st
 					return new, status
 				} else {
 					cur.duplicate = true
+					cur.merged = merge
 					cur.modified = false
-					logger.Warnf("DNS name %q already busy for entry %q, but this one was earlier", dnsname, cur.ObjectName())
+					logger.Warnf("DNS name %q already busy for entry %q, but this one takes precedence (%s)", dnsname, cur.ObjectName(), policy)
 					logger.Infof("reschedule %q for error update", cur.ObjectName())
 					this.triggerKey(cur.ClusterKey())
 				}
 			}
 		}
-		if new.valid && new.status.State != api.STATE_READY && new.status.State != api.STATE_PENDING {
+		if new.valid && !new.outOfSchedule && new.status.State != api.STATE_READY && new.status.State != api.STATE_PENDING {
 			msg := fmt.Sprintf("activating for %s", new.ZonedDNSName())
 			logger.Info(msg)
 			_, err := new.UpdateStatus(logger, api.STATE_PENDING, msg)
@@ -253,7 +273,7 @@ func (this *state) EntryPremise(e dnsutils.DNSSpecification) (*EntryPremise, err
 		provider: provider,
 		fallback: fallback,
 	}
-	zone := this.getProviderZoneForName(e.GetDNSName(), provider)
+	zone := this.getEntryZone(e, provider)
 
 	if zone != nil {
 		p.ptype = zone.Id().ProviderType
@@ -263,7 +283,7 @@ func (this *state) EntryPremise(e dnsutils.DNSSpecification) (*EntryPremise, err
 		p.ptype = provider.TypeCode()
 		p.zoneid = *e.BaseStatus().Zone
 	} else if p.fallback != nil {
-		zone = this.getProviderZoneForName(e.GetDNSName(), p.fallback)
+		zone = this.getEntryZone(e, p.fallback)
 		if zone != nil {
 			p.ptype = zone.Id().ProviderType
 			p.zoneid = zone.Id().ID
@@ -274,6 +294,13 @@ func (this *state) EntryPremise(e dnsutils.DNSSpecification) (*EntryPremise, err
 }
 
 func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object dnsutils.DNSSpecification) reconcile.Status {
+	_, span := tracing.Start(this.context.GetContext(), "reconcile entry", trace.WithAttributes(
+		attribute.String("dns.entry", object.ObjectName().String()),
+		attribute.String("dns.name", object.GetDNSName()),
+		attribute.String("operation", op),
+	))
+	defer span.End()
+
 	old := this.GetEntry(object.ObjectName())
 	if old != nil {
 		if !old.lock.TryLockSpinning(200 * time.Millisecond) {
@@ -293,15 +320,37 @@ func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object
 	defer this.references.NotifyHolder(this.context, object.ClusterKey())
 
 	logger = this.RefineLogger(logger, p.ptype)
+	logger = dnsutils.WithLogFields(logger, map[string]string{
+		"entry":         object.ObjectName().String(),
+		"dnsName":       object.GetDNSName(),
+		"zone":          p.zoneid,
+		"provider":      providerName(p.provider),
+		"correlationId": correlationID(span),
+	})
 	v := NewEntryVersion(object, old)
+	v.profile = this.getDNSClassProfile(object)
 	if p.fallback != nil {
 		v.obsolete = true
 	}
 	status := v.Setup(logger, this, p, op, err, this.config, old)
 	new, status := this.AddEntryVersion(logger, v, status)
 
+	if this.config.AllowForceCleanup && forceCleanupRequested(object) && this.HasFinalizer(object) {
+		if zoneid := v.ZoneId(); zoneid.IsEmpty() || this.GetHostedZone(zoneid) == nil {
+			return this.forceCleanup(logger, object)
+		}
+		logger.Infof("force cleanup ignored: hosted zone %s is still handled", v.ZoneId())
+	}
+
 	if new != nil {
+		new.spanContext = span.SpanContext()
+		span.SetAttributes(zoneAttributes(new.ZoneId())...)
 		if new.Kind() == api.DNSLockKind {
+			this.ownerCache.UpdateLock(new.ObjectName(), new.OwnerId(), !object.IsDeleting())
+			if !new.ZoneId().IsEmpty() && !this.ownsZone(new.ZoneId()) {
+				logger.Infof("zone %s is reconciled by controller replica %s -> skip lock", new.ZoneId(), this.sharding.Owner(new.ZoneId()))
+				return status
+			}
 			if object.IsDeleting() {
 				return this.checkAndDeleteLock(logger, new, p)
 			} else {
@@ -313,7 +362,11 @@ func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object
 			if new.Interval() > 0 {
 				status = status.RescheduleAfter(time.Duration(new.Interval()) * time.Second)
 			}
+			if new.scheduleDelay > 0 {
+				status = status.RescheduleAfter(new.scheduleDelay)
+			}
 		}
+		status = this.checkEntryLifetime(logger, object, status)
 
 		if new.IsModified() && !new.ZoneId().IsEmpty() {
 			this.SmartInfof(logger, "trigger zone %q", new.ZoneId())
@@ -339,14 +392,16 @@ func (this *state) EntryDeleted(logger logger.LogContext, key resources.ClusterO
 		this.lock.Unlock()
 		this.references.DelRef(key)
 		this.references.NotifyHolder(this.context, key)
+		this.healthCheckRefs.DelRef(key)
 	}()
 
 	delete(this.blockingEntries, key.ObjectName())
+	this.ownerCache.DeleteLock(key.ObjectName())
 
 	old := this.entries[key.ObjectName()]
 	if old != nil {
 		provider, _, _ := this.lookupProvider(old.object)
-		zone := this.getProviderZoneForName(old.DNSName(), provider)
+		zone := this.getEntryZone(old.object, provider)
 		if zone != nil {
 			logger.Infof("removing entry %q (%s[%s])", key.ObjectName(), old.DNSName(), zone.Id())
 			this.triggerHostedZone(zone.Id())
@@ -354,6 +409,7 @@ func (this *state) EntryDeleted(logger logger.LogContext, key resources.ClusterO
 			this.smartInfof(logger, "removing foreign entry %q (%s)", key.ObjectName(), old.ZonedDNSName())
 		}
 		this.cleanupEntry(logger, old)
+		this.triggerQuotaEntries(key.ObjectName().Namespace())
 	} else {
 		logger.Debugf("removing unknown entry %q", key.ObjectName())
 	}
@@ -365,13 +421,14 @@ func (this *state) cleanupEntry(logger logger.LogContext, e *Entry) {
 	this.entries.Delete(e)
 	if this.dnsnames[e.ZonedDNSName()] == e {
 		var found *Entry
+		policy := this.duplicatePolicy(e.EntryVersion)
 		for _, a := range this.entries {
 			logger.Debugf("  checking %s(%s): dup:%t", a.ObjectName(), a.ZonedDNSName(), a.duplicate)
 			if a.duplicate && a.ZonedDNSName() == e.ZonedDNSName() {
 				if found == nil {
 					found = a
 				} else {
-					if a.Before(found) {
+					if !keepsDNSName(policy, found, a) {
 						found = a
 					}
 				}
@@ -395,7 +452,8 @@ func (this *state) cleanupEntry(logger logger.LogContext, e *Entry) {
 }
 
 func (this *state) checkAndUpdateLock(logger logger.LogContext, entry *Entry, premise *EntryPremise) reconcile.Status {
-	if !entry.updateRequired && entry.object.BaseStatus().ObservedGeneration == entry.object.GetGeneration() {
+	lease := entry.object.(*dnsutils.DNSLockObject).IsLease()
+	if !lease && !entry.updateRequired && entry.object.BaseStatus().ObservedGeneration == entry.object.GetGeneration() {
 		return reconcile.Succeeded(logger)
 	}
 
@@ -404,19 +462,17 @@ func (this *state) checkAndUpdateLock(logger logger.LogContext, entry *Entry, pr
 		return reconcile.Failed(logger, fmt.Errorf("provider type %s does not support DNS locks", premise.ptype))
 	}
 	zone := this.zones[entry.ZoneId()]
-
-	newTTL := entry.TTL()
-	records := dns.Records{}
-	for _, s := range entry.object.GetText() {
-		target := dnsutils.NewText(s, newTTL)
-		records = append(records, target.AsRecord())
+	if lease {
+		return this.checkAndUpdateLockLease(logger, entry, premise, handler, zone)
 	}
-	newRS := FromDedicatedRecordSet(entry.DNSSetName(), dns.NewRecordSet(dns.RS_TXT, newTTL, records))
+
+	newRS := lockRecordSet(entry, entry.object.GetText())
 
 	rs, err := handler.GetRecordSet(zone, entry.DNSSetName(), dns.RS_TXT)
 	if err != nil {
 		return reconcile.Delay(logger, err)
 	}
+	this.locks.reportLookup(entry.ObjectName(), time.Now())
 	owned := true
 	ok := true
 	ownedMsg := ""
@@ -425,6 +481,7 @@ func (this *state) checkAndUpdateLock(logger logger.LogContext, entry *Entry, pr
 		timestamp := rs.GetAttr(dns.ATTR_TIMESTAMP)
 		owned, ok, ownedMsg = isLockOwned(entry.object.(*dnsutils.DNSLockObject), lockID, timestamp)
 	}
+	this.locks.reportState(entry.object, lockState(ok, owned, false), ownedMsg)
 
 	if owned && hasLockRecordsetChanged(rs, newRS) {
 		err = handler.CreateOrUpdateRecordSet(logger, zone, rs, newRS)
@@ -522,7 +579,15 @@ func (this *state) checkAndDeleteLock(logger logger.LogContext, entry *Entry, pr
 	if rs != nil {
 		lockID := rs.GetAttr(dns.ATTR_LOCKID)
 		timestamp := rs.GetAttr(dns.ATTR_TIMESTAMP)
-		owned, _, _ := isLockOwned(entry.object.(*dnsutils.DNSLockObject), lockID, timestamp)
+		obj := entry.object.(*dnsutils.DNSLockObject)
+		owned := false
+		if obj.IsLease() {
+			// only the holder releases the lease, even if it is expired
+			lease := newLockLease(rs.GetAttr(dns.ATTR_HOLDER), timestamp, entry.TTL())
+			owned = lease != nil && lease.holder == obj.GetHolder(this.config.Ident) && lockID == utils.StringValue(obj.Spec().LockId)
+		} else {
+			owned, _, _ = isLockOwned(obj, lockID, timestamp)
+		}
 		if owned {
 			err = handler.DeleteRecordSet(logger, zone, rs)
 			if err != nil {
@@ -531,6 +596,7 @@ func (this *state) checkAndDeleteLock(logger logger.LogContext, entry *Entry, pr
 			logger.Infof("lock deleted")
 		}
 	}
+	this.locks.remove(entry.ObjectName())
 	return reconcile.DelayOnError(logger, this.RemoveFinalizer(entry.object))
 }
 
@@ -545,9 +611,13 @@ func (this *state) UpdateLockStates(log logger.LogContext) {
 	this.lock.RUnlock()
 
 	for dnsName, e := range entries {
-		records, err := net.LookupTXT(dnsName)
+		records, err := this.lookupLockRecords(e)
+		if err == nil {
+			this.locks.reportLookup(e.ObjectName(), time.Now())
+		}
 		this.updateLockState(log, dnsName, e, records, err)
 	}
+	this.locks.updateLookupAges(this.startupTime, time.Now())
 }
 
 func (this *state) updateLockState(log logger.LogContext, dnsName string, e *Entry, records []string, err error) {
@@ -559,6 +629,7 @@ func (this *state) updateLockState(log logger.LogContext, dnsName string, e *Ent
 	ts := time.Time{}
 	timestampDNS := ""
 	lockDNS := ""
+	holderDNS := ""
 	attrs := map[string]string{}
 	unnamed := 0
 
@@ -581,6 +652,8 @@ func (this *state) updateLockState(log logger.LogContext, dnsName string, e *Ent
 				ts = time.Unix(i, 0)
 			case dns.ATTR_LOCKID:
 				lockDNS = fields[1]
+			case dns.ATTR_HOLDER:
+				holderDNS = fields[1]
 			default:
 				attrs[fields[0]] = fields[1]
 			}
@@ -594,6 +667,7 @@ func (this *state) updateLockState(log logger.LogContext, dnsName string, e *Ent
 			firstfailed = status.FirstFailedDNSLookup.Time
 			if now.Sub(firstfailed) > ttl*2 {
 				log.Infof("try to resurrect dns lock %q", e.object.ObjectName())
+				this.locks.reportResurrect(e.object)
 				updateRequired = true
 			}
 		} else {
@@ -601,7 +675,24 @@ func (this *state) updateLockState(log logger.LogContext, dnsName string, e *Ent
 		}
 	}
 
-	owned, ok, ownedMsg := isLockOwned(e.object.(*dnsutils.DNSLockObject), lockDNS, timestampDNS)
+	obj := e.object.(*dnsutils.DNSLockObject)
+	var lease *lockLease
+	owned, ok, ownedMsg := false, false, ""
+	held := false
+	if obj.IsLease() {
+		holder := obj.GetHolder(this.config.Ident)
+		lease = newLockLease(holderDNS, timestampDNS, obj.Spec().TTL)
+		ok, owned, ownedMsg = evaluateLockLease(utils.StringValue(obj.Spec().LockId), holder, lockDNS, lease, time.Now())
+		held = owned && (lease == nil || lease.holder == holder)
+		if err == nil && ok && owned && !held {
+			log.Infof("lease of dns lock %q held by %s expired", e.object.ObjectName(), lease.holder)
+			updateRequired = true
+		}
+	} else {
+		owned, ok, ownedMsg = isLockOwned(obj, lockDNS, timestampDNS)
+		held = owned
+	}
+	this.locks.reportState(e.object, lockState(ok, held, !firstfailed.IsZero()), ownedMsg)
 	e.object.ModifyStatus(func(data resources.ObjectData) (bool, error) {
 		status := &data.(*api.DNSLock).Status
 		mod := utils.ModificationState{}
@@ -626,6 +717,16 @@ func (this *state) updateLockState(log logger.LogContext, dnsName string, e *Ent
 			pLockID = &lockDNS
 		}
 		mod.AssureStringPtrPtr(&status.LockId, pLockID)
+		var pHolder *string
+		if holderDNS != "" {
+			pHolder = &holderDNS
+		}
+		mod.AssureStringPtrPtr(&status.Holder, pHolder)
+		var expiration time.Time
+		if lease != nil {
+			expiration = lease.expiration
+		}
+		mod.Modify(AssureTimestamp(&status.LeaseExpiration, expiration))
 		mod.Modify(AssureTimestamp(&status.FirstFailedDNSLookup, firstfailed))
 		mod.Modify(!EqualAttrs(attrs, status.Attributes))
 		status.Attributes = attrs
@@ -664,3 +765,26 @@ func EqualAttrs(a, b map[string]string) bool {
 	}
 	return true
 }
+
+// reportPropagation sets the condition Propagated of the entry for the DNS name in the given zone.
+func (this *state) reportPropagation(zoneid dns.ZoneID, name dns.DNSSetName, status metav1.ConditionStatus, reason, message string) {
+	this.lock.RLock()
+	entry := this.dnsnames[ZonedDNSSetName{ZoneID: zoneid, DNSSetName: name}]
+	this.lock.RUnlock()
+	if entry == nil {
+		return
+	}
+	_, err := entry.object.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		o := dnsutils.DNSObject(entry.object.GetResource().Wrap(data))
+		return o.AcknowledgeCondition(metav1.Condition{
+			Type:               api.CONDITION_PROPAGATED,
+			Status:             status,
+			ObservedGeneration: o.GetGeneration(),
+			Reason:             reason,
+			Message:            message,
+		}), nil
+	})
+	if err != nil {
+		this.context.Warnf("cannot update condition %s of %s: %s", api.CONDITION_PROPAGATED, entry.ObjectName(), err)
+	}
+}
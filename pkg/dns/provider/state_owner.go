@@ -19,6 +19,7 @@ package provider
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
@@ -29,6 +30,7 @@ import (
 	"github.com/gardener/external-dns-management/pkg/dns/provider/statistic"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -37,8 +39,10 @@ import (
 
 func delta(owner *dnsutils.DNSOwnerObject, changed, active utils.StringSet) string {
 	msg := ""
-	if owner != nil && owner.ValidUntil() != nil {
-		if owner.IsEnabled() {
+	if owner != nil && owner.IsEnabled() {
+		if from := owner.ValidFrom(); from != nil && time.Now().Before(from.Time) {
+			msg = fmt.Sprintf(" (%s activates at %s)", owner.GetName(), from.Format(time.RFC3339))
+		} else if owner.ValidUntil() != nil {
 			if !owner.IsActive() {
 				msg = fmt.Sprintf(" (%s expired (%s))", owner.GetName(), owner.ValidUntil().Format(time.RFC3339))
 			} else {
@@ -89,9 +93,11 @@ func (this *state) UpdateOwner(logger logger.LogContext, owner *dnsutils.DNSOwne
 		this.TriggerEntriesByOwner(logger, changed)
 		this.TriggerHostedZonesByChangedOwners(logger, changed)
 	}
-	if statusActive := owner.Status().Active; statusActive == nil || *statusActive != owner.IsActive() {
+	status := owner.Status()
+	if statusActive := status.Active; statusActive == nil || *statusActive != owner.IsActive() || status.ObservedGeneration != owner.GetGeneration() {
 		isActive := owner.IsActive()
-		owner.Status().Active = &isActive
+		status.Active = &isActive
+		status.ObservedGeneration = owner.GetGeneration()
 		err := owner.UpdateStatus()
 		if err != nil {
 			return reconcile.DelayOnError(logger, fmt.Errorf("cannot update status of %s: %w", owner.ObjectName(), err))
@@ -122,7 +128,7 @@ func (this *state) UpdateOwnerCounts(log logger.LogContext) {
 	this.UpdateStatistic(statistic)
 	types := this.GetHandlerFactory().TypeCodes()
 	metrics.UpdateOwnerStatistic(statistic, types)
-	changes := this.ownerCache.UpdateCountsWith(statistic.Owners, types)
+	changes := this.ownerCache.UpdateCountsWith(statistic, types)
 	if len(changes) > 0 {
 		log.Infof("found %d changes for owner usages", len(changes))
 		this.ownerupd <- changes
@@ -145,7 +151,7 @@ func startOwnerUpdater(ctx Context, ownerresc resources.Interface) chan OwnerCou
 			case changes := <-requests:
 				log.Infof("starting owner update for %d changes", len(changes))
 				for n, counts := range changes {
-					log.Infof("  updating owner counts %v for %s", counts, n)
+					log.Infof("  updating owner counts %v for %s", *counts, n)
 					_, _, err := ownerresc.ModifyStatusByName(resources.NewObjectName(string(n)), func(data resources.ObjectData) (bool, error) {
 						owner, ok := data.(*v1alpha1.DNSOwner)
 						if !ok {
@@ -155,7 +161,7 @@ func startOwnerUpdater(ctx Context, ownerresc resources.Interface) chan OwnerCou
 						if owner.Status.Entries.ByType == nil {
 							owner.Status.Entries.ByType = ProviderTypeCounts{}
 						}
-						for t, v := range counts {
+						for t, v := range counts.ByType {
 							if owner.Status.Entries.ByType[t] != v {
 								mod = true
 								owner.Status.Entries.ByType[t] = v
@@ -172,6 +178,15 @@ func startOwnerUpdater(ctx Context, ownerresc resources.Interface) chan OwnerCou
 							owner.Status.Entries.Amount = sum
 							mod = true
 						}
+						mod = assureCounts(&owner.Status.Entries.ByProvider, counts.ByProvider) || mod
+						mod = assureCounts(&owner.Status.Entries.ByZone, counts.ByZone) || mod
+						if !counts.LastChange.IsZero() {
+							t := metav1.NewTime(counts.LastChange.Truncate(time.Second))
+							if owner.Status.LastChangeTime == nil || !owner.Status.LastChangeTime.Equal(&t) {
+								owner.Status.LastChangeTime = &t
+								mod = true
+							}
+						}
 						return mod, nil
 					})
 					if err != nil {
@@ -183,3 +198,21 @@ func startOwnerUpdater(ctx Context, ownerresc resources.Interface) chan OwnerCou
 	}()
 	return requests
 }
+
+// assureCounts sets the given counts, entries without count are omitted.
+func assureCounts(field *map[string]int, counts map[string]int) bool {
+	desired := map[string]int{}
+	for k, v := range counts {
+		if v > 0 {
+			desired[k] = v
+		}
+	}
+	if len(desired) == 0 {
+		desired = nil
+	}
+	if len(*field) == 0 && desired == nil || reflect.DeepEqual(*field, desired) {
+		return false
+	}
+	*field = desired
+	return true
+}
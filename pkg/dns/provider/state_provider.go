@@ -48,6 +48,11 @@ func (this *state) addEntriesForProvider(p *dnsProviderVersion, entries Entries)
 }
 func (this *state) UpdateProvider(logger logger.LogContext, obj *dnsutils.DNSProviderObject) reconcile.Status {
 	logger = this.RefineLogger(logger, obj.TypeCode())
+	logger = dnsutils.WithLogFields(logger, map[string]string{
+		"provider":      obj.ObjectName().String(),
+		"providerType":  obj.TypeCode(),
+		"correlationId": correlationID(nil),
+	})
 	logger.Infof("reconcile PROVIDER")
 	if !this.config.Enabled.Contains(obj.TypeCode()) || !this.config.Factory.IsResponsibleFor(obj) {
 		return this._UpdateForeignProvider(logger, obj)
@@ -70,15 +75,18 @@ func (this *state) _UpdateLocalProvider(logger logger.LogContext, obj *dnsutils.
 
 	new, status := updateDNSProvider(logger, this, obj, last)
 
-	if last != nil && last.account != nil && last.account != new.account {
-		this.accountCache.Release(logger, last.account, obj.ObjectName())
-	}
-
 	this.lock.Lock()
 	defer this.lock.Unlock()
-	regmod, regerr := this.registerSecret(logger, new.secret, new)
+	regmod, regerr := this.registerSecrets(logger, new.secrets(), new)
 
 	this.providers[new.ObjectName()] = new
+	if last != nil && last.account != nil && last.account != new.account {
+		if new.accountSwapped(last) {
+			logger.Infof("credentials changed: swapping account in place (keeping zone state)")
+		}
+		// the old account is released after the new version is active
+		this.accountCache.Release(logger, last.account, obj.ObjectName())
+	}
 	mod := this.updateZones(logger, last, new)
 	if !status.IsSucceeded() {
 		this.informProviderRemoved(logger, new.ObjectName())
@@ -301,12 +309,13 @@ func (this *state) removeLocalProvider(logger logger.LogContext, obj *dnsutils.D
 			}
 		}
 		logger.Infof("releasing provider secret")
-		_, err := this.registerSecret(logger, nil, cur)
+		_, err := this.registerSecrets(logger, nil, cur)
 		if err != nil {
 			return reconcile.Delay(logger, err)
 		}
 		logger.Infof("releasing account cache")
 		this.accountCache.Release(logger, cur.account, cur.ObjectName())
+		this.vault.Release(cur.ObjectName())
 		delete(this.deleting, obj.ObjectName())
 		delete(this.providerzones, obj.ObjectName())
 		logger.Infof("finally remove finalizer")
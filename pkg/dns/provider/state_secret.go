@@ -70,46 +70,29 @@ func (this *state) GetSecretUsage(name resources.ObjectName) []resources.Object
 	return result
 }
 
-func (this *state) registerSecret(logger logger.LogContext, secret resources.ObjectName, provider *dnsProviderVersion) (bool, error) {
+func (this *state) registerSecrets(logger logger.LogContext, secrets resources.ObjectNameSet, provider *dnsProviderVersion) (bool, error) {
 	pname := provider.ObjectName()
 	old := this.providersecrets[pname]
 
-	if old != nil && old != secret {
-		oldp := this.secrets[old]
-		if oldp.Contains(pname) {
-			logger.Infof("releasing secret %q for provider %q", old, pname)
-			if len(oldp) <= 1 {
-				r, err := provider.Object().Resources().Get(&corev1.Secret{})
-				if err != nil {
-					logger.Warnf("cannot release secret %q for provider %q: %s", old, pname, err)
-					return true, err
-				}
-				s, err := r.GetCached(old)
-				if err != nil {
-					if !errors.IsNotFound(err) {
-						logger.Warnf("cannot release secret %q for provider %q: %s", old, pname, err)
-						return true, err
-					}
-				} else {
-					logger.Infof("remove finalizer for unused secret %q", old)
-					err := this.RemoveFinalizer(s)
-					if err != nil && !errors.IsNotFound(err) {
-						logger.Warnf("cannot release secret %q for provider %q: %s", old, pname, err)
-						return true, err
-					}
-				}
-				delete(this.secrets, old)
-			} else {
-				delete(oldp, pname)
-			}
+	for o := range old {
+		if secrets.Contains(o) {
+			continue
+		}
+		if err := this.releaseSecret(logger, o, provider); err != nil {
+			return true, err
 		}
+		delete(old, o)
 	}
+	if len(secrets) == 0 {
+		delete(this.providersecrets, pname)
+		return false, nil
+	}
+
 	mod := false
-	if secret != nil {
-		if old != secret {
+	var result error
+	for secret := range secrets {
+		if !old.Contains(secret) {
 			logger.Infof("registering secret %q for provider %q", secret, pname)
-			this.providersecrets[pname] = secret
-
 			curp := this.secrets[secret]
 			if curp == nil {
 				curp = resources.ObjectNameSet{}
@@ -124,15 +107,48 @@ func (this *state) registerSecret(logger logger.LogContext, secret resources.Obj
 		if err == nil {
 			err = this.SetFinalizer(s)
 		}
-		if err != nil {
+		if err != nil && result == nil {
 			if errors.IsNotFound(err) {
-				return mod, fmt.Errorf("secret %q for provider %q not found", secret, pname)
+				result = fmt.Errorf("secret %q for provider %q not found", secret, pname)
 			} else {
-				return mod, fmt.Errorf("cannot set finalizer for secret %q for provider %q: %s", secret, pname, err)
+				result = fmt.Errorf("cannot set finalizer for secret %q for provider %q: %s", secret, pname, err)
 			}
 		}
+	}
+	this.providersecrets[pname] = secrets.Copy()
+	return mod, result
+}
+
+func (this *state) releaseSecret(logger logger.LogContext, old resources.ObjectName, provider *dnsProviderVersion) error {
+	pname := provider.ObjectName()
+	oldp := this.secrets[old]
+	if !oldp.Contains(pname) {
+		return nil
+	}
+	logger.Infof("releasing secret %q for provider %q", old, pname)
+	if len(oldp) <= 1 {
+		r, err := provider.Object().Resources().Get(&corev1.Secret{})
+		if err != nil {
+			logger.Warnf("cannot release secret %q for provider %q: %s", old, pname, err)
+			return err
+		}
+		s, err := r.GetCached(old)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				logger.Warnf("cannot release secret %q for provider %q: %s", old, pname, err)
+				return err
+			}
+		} else {
+			logger.Infof("remove finalizer for unused secret %q", old)
+			err := this.RemoveFinalizer(s)
+			if err != nil && !errors.IsNotFound(err) {
+				logger.Warnf("cannot release secret %q for provider %q: %s", old, pname, err)
+				return err
+			}
+		}
+		delete(this.secrets, old)
 	} else {
-		delete(this.providersecrets, pname)
+		delete(oldp, pname)
 	}
-	return mod, nil
+	return nil
 }
@@ -26,12 +26,16 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/ctxutil"
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/utils"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
+	"github.com/gardener/external-dns-management/pkg/server/tracing"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -55,6 +59,28 @@ func (this *state) TriggerHostedZonesByChangedOwners(logger logger.LogContext, c
 	}
 }
 
+// ownsZone returns true if the hosted zone is reconciled by this controller replica.
+func (this *state) ownsZone(zoneid dns.ZoneID) bool {
+	return this.sharding == nil || this.sharding.Owns(zoneid)
+}
+
+// takeoverZones triggers the hosted zones and DNS locks newly assigned to this controller replica.
+func (this *state) takeoverZones(gained func(zoneid dns.ZoneID) bool) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	for zoneid := range this.zones {
+		if gained(zoneid) {
+			this.context.Infof("taking over zone %s", zoneid)
+			this.triggerHostedZone(zoneid)
+		}
+	}
+	for _, e := range this.entries {
+		if e.Kind() == api.DNSLockKind && !e.ZoneId().IsEmpty() && gained(e.ZoneId()) {
+			this.triggerKey(e.ClusterKey())
+		}
+	}
+}
+
 func (this *state) GetZoneReconcilation(logger logger.LogContext, zoneid dns.ZoneID) (time.Duration, bool, *zoneReconciliation) {
 	req := &zoneReconciliation{
 		fhandler: this.context,
@@ -76,8 +102,13 @@ func (this *state) GetZoneReconcilation(logger logger.LogContext, zoneid dns.Zon
 		return next.Sub(now), hasProviders, req
 	}
 	req.entries, req.equivEntries, req.stale, req.deleting = this.addEntriesForZone(logger, nil, nil, zone)
+	req.merged = this.getMergedEntries(req.entries)
 	req.providers = this.getProvidersForZone(zoneid)
 	req.dnsTicker = this.dnsTicker
+	req.conflicts = this.conflicts
+	if this.config.PTRRecords && isReverseZone(zone.Domain()) {
+		req.ptrs = this.ptrRecordSetsForZone(zone)
+	}
 	return 0, hasProviders, req
 }
 
@@ -101,6 +132,12 @@ func (this *state) reconcileZoneBlockingEntries(logger logger.LogContext) int {
 func (this *state) ReconcileZone(logger logger.LogContext, zoneid dns.ZoneID) reconcile.Status {
 	logger.Infof("Initiate reconcilation of zone %s", zoneid)
 	defer logger.Infof("zone %s done", zoneid)
+	this.zoneTriggers.Started(zoneid)
+
+	if !this.ownsZone(zoneid) {
+		logger.Infof("zone %s is reconciled by controller replica %s -> skip", zoneid, this.sharding.Owner(zoneid))
+		return reconcile.Succeeded(logger)
+	}
 
 	blockingCount := this.reconcileZoneBlockingEntries(logger)
 	if blockingCount > 0 {
@@ -120,6 +157,12 @@ func (this *state) ReconcileZone(logger logger.LogContext, zoneid dns.ZoneID) re
 		logger.Infof("too early (required delay between two reconcilations: %s) -> skip and reschedule", this.config.Delay)
 		return reconcile.Succeeded(logger).RescheduleAfter(delay)
 	}
+	accounts := req.providers.accountHashes()
+	if !this.zoneSlots.TryAcquire(accounts) {
+		logger.Infof("maximum number of concurrently reconciled zones of provider account reached -> reschedule")
+		return reconcile.Succeeded(logger).RescheduleAfter(5 * time.Second)
+	}
+	defer this.zoneSlots.Release(accounts)
 	logger.Infof("precondition fulfilled for zone %s", zoneid)
 	if done, err := this.StartZoneReconcilation(logger, req); done {
 		if err != nil {
@@ -142,6 +185,22 @@ func (this *state) ReconcileZone(logger logger.LogContext, zoneid dns.ZoneID) re
 	return reconcile.Succeeded(logger).RescheduleAfter(10 * time.Second)
 }
 
+// zoneReconcileDelay returns the minimum delay until the next reconciliation of the zone.
+// It is stretched if the request quota of an account of the zone providers is nearly consumed.
+func (this *state) zoneReconcileDelay(logger logger.LogContext, req *zoneReconciliation) time.Duration {
+	usage := 0.0
+	for _, p := range req.providers {
+		if u := p.QuotaUsage(); u > usage {
+			usage = u
+		}
+	}
+	factor := quotaBackpressure(usage, this.config.QuotaBackpressure)
+	if factor > 1 {
+		logger.Infof("request quota usage %.0f%% -> stretching delay between zone reconciliations by factor %.1f", usage*100, factor)
+	}
+	return time.Duration(float64(this.config.Delay) * factor)
+}
+
 func (this *state) StartZoneReconcilation(logger logger.LogContext, req *zoneReconciliation) (bool, error) {
 	if req.deleting {
 		ctxutil.Tick(this.GetContext().GetContext(), controller.DeletionActivity)
@@ -153,6 +212,9 @@ func (this *state) StartZoneReconcilation(logger logger.LogContext, req *zoneRec
 		for _, e := range req.entries {
 			list = append(list, e)
 		}
+		for _, merged := range req.merged {
+			list = append(list, merged...)
+		}
 		for _, e := range req.stale {
 			if req.entries[e.ObjectName()] == nil {
 				list = append(list, e)
@@ -175,29 +237,85 @@ func (this *state) StartZoneReconcilation(logger logger.LogContext, req *zoneRec
 	return false, nil
 }
 
-func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliation) error {
+// skipZoneLoading returns true if the zone state of a hosted zone is not read with lazy zone loading.
+// The zone state is still needed if the zone is targeted by entries, its records are imported, or
+// the records of deactivated owner ids may have to be cleaned up.
+func (this *state) skipZoneLoading(req *zoneReconciliation) bool {
+	if !this.config.LazyZoneLoading || len(req.entries) > 0 || len(req.stale) > 0 || len(req.ptrs) > 0 {
+		return false
+	}
+	if retired, ok := req.ownership.(RetiredOwnership); ok && retired.HasRetiredIds() {
+		return false
+	}
+	return !requestsRecordImport(req.providers, req.zone.Id())
+}
+
+func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliation) (err error) {
 	zoneid := req.zone.Id()
-	req.zone.SetNext(time.Now().Add(this.config.Delay))
+	var span trace.Span
+	req.ctx, span = tracing.Start(this.context.GetContext(), "reconcile zone", trace.WithAttributes(zoneAttributes(zoneid)...), modifiedEntryLinks(req.entries))
+	defer func() { tracing.End(span, err) }()
+	logger = dnsutils.WithLogFields(logger, map[string]string{
+		"zone":          zoneid.ID,
+		"providerType":  zoneid.ProviderType,
+		"correlationId": correlationID(span),
+	})
+	req.zone.SetNext(time.Now().Add(this.zoneReconcileDelay(logger, req)))
 	metrics.ReportZoneEntries(zoneid, len(req.entries), len(req.stale))
 	logger.Infof("reconcile ZONE %s (%s) for %d dns entries (%d stale)", req.zone.Id(), req.zone.Domain(), len(req.entries), len(req.stale))
+	if this.skipZoneLoading(req) {
+		logger.Infof("no dns entries for zone %s -> skip loading zone state (orphaned records are not detected)", zoneid)
+		req.zone.nextTrigger = 0
+		return nil
+	}
 	logger.Debugf("    ownerids: %s", req.ownership.GetIds())
+	driftCheck := req.zone.StartDriftCheck(time.Now(), this.config.DriftCheckPeriod)
+	if driftCheck {
+		logger.Infof("verifying records of zone %s against DNS provider", zoneid)
+		this.zoneStates.CleanZoneState(zoneid)
+	}
 	changes := NewChangeModel(logger, req.ownership, req, this.config)
-	err := changes.Setup()
+	err = changes.Setup()
 	if err != nil {
+		if !req.zone.IsFailing() {
+			this.notifyZoneUnreachable(req, err)
+		}
 		req.zone.Failed()
 		return err
 	}
-	req.zone.nextTrigger = 0
+	req.zone.nextTrigger = this.config.DriftCheckPeriod
 	modified := false
+	drifted := 0
 	var conflictErr error
 	for _, e := range req.entries {
 		// TODO: err handling
 		var changeResult ChangeResult
 		spec := e.object.GetTargetSpec(e)
 		statusUpdate := NewStatusUpdate(logger, e, this.GetContext())
-		if e.IsDeleting() {
+		if merged := req.merged[e.ZonedDNSName()]; len(merged) > 0 && !e.IsDeleting() && !e.IsOutOfSchedule() {
+			logger.Infof("merging targets of %d duplicate entries into %s", len(merged), e.ObjectName())
+			spec = newMergedTargetSpec(spec, merged)
+			statusUpdate = newMergedStatusUpdate(logger, e, merged, this.GetContext())
+		}
+		if e.IsOutOfSchedule() {
+			logger.Infof("entry %q(%s) is out of schedule", e.ObjectName(), e.DNSName())
+			changeResult = changes.Delete(e.DNSSetName(), e.ObjectName().Namespace(), e.CreatedAt(), nil, &unscheduledTargetSpec{spec})
+		} else if e.IsDeleting() {
 			changeResult = changes.Delete(e.DNSSetName(), e.ObjectName().Namespace(), e.CreatedAt(), statusUpdate, spec)
 		} else {
+			if driftCheck && e.IsSettled() {
+				if changes.Check(e.DNSSetName(), e.ObjectName().Namespace(), e.CreatedAt(), statusUpdate, spec).Modified {
+					drifted++
+					if !this.config.DriftCorrection {
+						logger.Infof("drift detected for %s, correction disabled", e.ObjectName())
+						changes.PseudoApply(e.DNSSetName(), spec)
+						e.object.Event(corev1.EventTypeWarning, "drift", "records modified in DNS system, correction disabled")
+						continue
+					}
+					logger.Infof("drift detected for %s, correcting records", e.ObjectName())
+					e.object.Event(corev1.EventTypeNormal, "drift", "correcting records modified in DNS system")
+				}
+			}
 			if !e.NotRateLimited() {
 				changeResult = changes.Check(e.DNSSetName(), e.ObjectName().Namespace(), e.CreatedAt(), statusUpdate, spec)
 				if changeResult.Modified {
@@ -206,6 +324,7 @@ func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliati
 						changes.PseudoApply(e.DNSSetName(), spec)
 						logger.Infof("rate limited %s, delay %.1f s", e.ObjectName(), delay.Seconds())
 						statusUpdate.Throttled()
+						metrics.AddProviderThrottles(zoneid.ProviderType, zoneid.ID, metrics.THROTTLE_ENTRY_RATELIMIT, 1)
 						if delay.Seconds() > 2 {
 							e.object.Eventf(corev1.EventTypeNormal, "rate limit", "delayed for %1.fs", delay.Seconds())
 						}
@@ -216,14 +335,26 @@ func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliati
 			changeResult = changes.Apply(e.DNSSetName(), e.ObjectName().Namespace(), e.CreatedAt(), statusUpdate, spec)
 			if changeResult.Error != nil && changeResult.Retry {
 				conflictErr = changeResult.Error
+				metrics.AddProviderThrottles(zoneid.ProviderType, zoneid.ID, metrics.THROTTLE_CONFLICT_RETRY, 1)
 			}
 		}
 		modified = modified || changeResult.Modified
 	}
+	for _, ptr := range req.ptrs {
+		modified = changes.Apply(ptr.name, "", ptr.createdAt, nil, ptr.spec).Modified || modified
+	}
+	if driftCheck {
+		metrics.ReportDriftedEntries(zoneid, drifted)
+	}
 	modified = changes.Cleanup(logger) || modified
+	this.importRecords(logger, zoneid, changes)
 	if modified {
 		err = changes.Update(logger)
+		if this.config.PTRRecords && !isReverseZone(req.zone.Domain()) {
+			this.triggerReverseZones(logger)
+		}
 	}
+	this.reportOwnerConflicts(logger, zoneid)
 
 	outdatedEntries := EntryList{}
 	this.outdated.AddActiveZoneTo(zoneid, &outdatedEntries)
@@ -253,13 +384,16 @@ func (this *state) deleteZone(zoneid dns.ZoneID) {
 }
 
 func (this *state) CreateStateTTLGetter(defaultStateTTL time.Duration) StateTTLGetter {
-	return func(zoneid dns.ZoneID) time.Duration {
+	return func(zoneid dns.ZoneID, accountTTL time.Duration) time.Duration {
 		if value := this.zoneStateTTL.Load(); value != nil {
 			stateTTLMap := value.(map[dns.ZoneID]time.Duration)
 			if ttl, ok := stateTTLMap[zoneid]; ok {
 				return ttl
 			}
 		}
+		if accountTTL > 0 {
+			return accountTTL
+		}
 		return defaultStateTTL
 	}
 }
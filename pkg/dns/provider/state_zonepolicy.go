@@ -56,14 +56,21 @@ func (this *state) updateZonePolicyState(logger logger.LogContext, policy *dnsut
 
 	name := policy.GetName()
 	pol := this.zonePolicies[name]
+	var oldPolicy api.ZonePolicy
+	var oldZones []*dnsHostedZone
 	if pol == nil {
 		pol = newDNSHostedZonePolicy(name, policy.Spec())
 		this.zonePolicies[name] = pol
 	} else {
+		oldPolicy = pol.spec.Policy
+		oldZones = pol.zones
 		pol.spec = *policy.Spec()
 	}
 
 	var conflicts []string
+	if err := pol.Validate(); err != nil {
+		conflicts = append(conflicts, fmt.Sprintf("invalid policy: %s", err))
+	}
 	var zones []api.ZoneInfo
 	pol.zones = nil
 	pol.conflictingPolicyNames.Clear()
@@ -93,9 +100,40 @@ func (this *state) updateZonePolicyState(logger logger.LogContext, policy *dnsut
 		}
 	}
 	this.updateStateTTLMap()
+	if !reflect.DeepEqual(oldPolicy, pol.spec.Policy) || !reflect.DeepEqual(oldZones, pol.zones) {
+		if hasEntryConstraints(&oldPolicy) || hasEntryConstraints(&pol.spec.Policy) {
+			this.triggerAllEntries()
+		}
+	}
 	return zones, conflicts
 }
 
+// triggerAllEntries triggers all entries for revalidation.
+func (this *state) triggerAllEntries() {
+	for _, e := range this.entries {
+		this.TriggerEntry(nil, e)
+	}
+}
+
+// checkZonePolicy validates the given entry attributes against the
+// policy of the hosted zone.
+func (this *state) checkZonePolicy(zoneid dns.ZoneID, namespace, ownerId, dnsname string, ttl *int64, targets Targets) error {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	zone := this.zones[zoneid]
+	if zone == nil {
+		return nil
+	}
+	if pol := zone.Policy(); pol != nil {
+		if err := pol.CheckRestrictions(zone.Domain(), namespace, ownerId, dnsname); err != nil {
+			return err
+		}
+		return pol.Check(dnsname, ttl, targets)
+	}
+	return nil
+}
+
 func (this *state) updateStateTTLMap() {
 	new := map[dns.ZoneID]time.Duration{}
 	for _, zone := range this.zones {
@@ -122,6 +160,9 @@ func (this *state) ZonePolicyDeleted(logger logger.LogContext, key resources.Clu
 		for _, zone := range pol.zones {
 			zone.SetPolicy(nil)
 		}
+		if hasEntryConstraints(&pol.spec.Policy) {
+			this.triggerAllEntries()
+		}
 		for zname := range pol.conflictingPolicyNames {
 			key := this.createZonePolicyClusterKey(zname)
 			this.triggerKey(key)
@@ -151,6 +192,7 @@ func (this *state) updateZonePolicyStatus(policy *dnsutils.DNSHostedZonePolicyOb
 	status := policy.Status()
 	mod := &utils2.ModificationState{}
 	mod.AssureStringPtrPtr(&status.Message, pmsg)
+	mod.AssureInt64Value(&status.ObservedGeneration, policy.GetGeneration())
 	if !reflect.DeepEqual(status.Zones, zones) {
 		status.Zones = zones
 		n := len(zones)
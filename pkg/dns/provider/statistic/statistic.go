@@ -128,11 +128,38 @@ func (this OwnerStatistic) Walk(state WalkingState, walker OwnerWalker) WalkingS
 
 ////////////////////////////////////////////////////////////////////////////////
 
+// ZoneStatistic counts entries per hosted zone id.
+type ZoneStatistic map[string]int
+
+////////////////////////////////////////////////////////////////////////////////
+
+// OwnerZoneStatistic counts entries per owner id and hosted zone id.
+type OwnerZoneStatistic map[string]ZoneStatistic
+
+func (this OwnerZoneStatistic) Inc(owner, zoneid string) {
+	cur := this[owner]
+	if cur == nil {
+		cur = ZoneStatistic{}
+		this[owner] = cur
+	}
+	cur[zoneid]++
+}
+
+func (this OwnerZoneStatistic) Get(owner string) ZoneStatistic {
+	if zs := this[owner]; zs != nil {
+		return zs
+	}
+	return ZoneStatistic{}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type EntryStatistic struct {
 	Providers ProviderTypeStatistic
 	Owners    OwnerStatistic
+	Zones     OwnerZoneStatistic
 }
 
 func NewEntryStatistic() *EntryStatistic {
-	return &EntryStatistic{ProviderTypeStatistic{}, OwnerStatistic{}}
+	return &EntryStatistic{ProviderTypeStatistic{}, OwnerStatistic{}, OwnerZoneStatistic{}}
 }
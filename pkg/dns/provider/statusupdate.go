@@ -17,9 +17,14 @@
 package provider
 
 import (
+	"strings"
+
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/notification"
+	corev1 "k8s.io/api/core/v1"
 )
 
 type FinalizerHandler interface {
@@ -33,6 +38,8 @@ type StatusUpdate struct {
 	delete   bool
 	done     bool
 	fhandler FinalizerHandler
+	// records is the DNS set applied at the provider for the entry
+	records *dns.DNSSet
 }
 
 func NewStatusUpdate(logger logger.LogContext, e *Entry, f FinalizerHandler) DoneHandler {
@@ -61,29 +68,99 @@ func (this *StatusUpdate) Failed(err error) {
 		} else {
 			newState = api.STATE_STALE
 		}
-		_, err := this.UpdateStatus(this.logger, newState, err.Error())
+		oldState := this.Entry.status.State
+		msg := err.Error()
+		_, err := this.UpdateStatus(this.logger, newState, msg)
 		if err != nil {
 			this.logger.Errorf("cannot update: %s", err)
 		}
+		if oldState != newState {
+			this.Entry.notifyEntry(notification.EventEntryFailed, msg)
+		}
 	}
 }
 func (this *StatusUpdate) Succeeded() {
 	if !this.done {
 		this.done = true
 		this.modified = false
+		this.setPlannedChanges("")
 		if this.delete {
 			this.logger.Infof("removing finalizer for deleted entry %s", this.ZonedDNSName())
 			this.fhandler.RemoveFinalizer(this.Entry.Object())
 		} else {
 			this.Entry.activezone = this.ZoneId()
 			this.fhandler.SetFinalizer(this.Entry.Object())
+			this.Entry.records = this.records
+			oldState := this.Entry.status.State
 			_, err := this.UpdateStatus(this.logger, api.STATE_READY, "dns entry active")
 			if err != nil {
 				this.logger.Errorf("cannot update: %s", err)
 			}
+			if oldState != api.STATE_READY {
+				this.Entry.notifyEntry(notification.EventEntryReady, "dns entry active")
+			}
+			this.Entry.reportSpecApplied()
 		}
 	}
 }
+func (this *StatusUpdate) ChangeApplied(req *ChangeRequest) {
+	this.Entry.object.Event(corev1.EventTypeNormal, "dns change", req.Description())
+}
+
+// ChangeSkipped reports a change request intentionally not applied for the given reason.
+func (this *StatusUpdate) ChangeSkipped(req *ChangeRequest, reason string) {
+	this.Entry.object.Event(corev1.EventTypeNormal, reason, "skipped: "+req.PlannedDescription())
+}
+
+// Planned reports the changes planned for the entry in dry run mode.
+func (this *StatusUpdate) Planned(changes []string) {
+	if this.done {
+		return
+	}
+	this.done = true
+	this.modified = false
+	value := strings.Join(changes, "; ")
+	if this.setPlannedChanges(value) {
+		this.Entry.object.Event(corev1.EventTypeNormal, "dry run", "planned: "+value)
+	}
+	_, err := this.UpdateState(this.logger, api.STATE_PENDING, "dry run: planned "+value)
+	if err != nil {
+		this.logger.Errorf("cannot update: %s", err)
+	}
+}
+
+// setPlannedChanges sets or removes (empty value) the planned changes annotation.
+func (this *StatusUpdate) setPlannedChanges(value string) bool {
+	if this.Entry.object.GetAnnotations()[dns.PLANNED_CHANGES_ANNOTATION] == value {
+		return false
+	}
+	mod, err := this.Entry.object.Modify(func(data resources.ObjectData) (bool, error) {
+		if value == "" {
+			return resources.RemoveAnnotation(data, dns.PLANNED_CHANGES_ANNOTATION), nil
+		}
+		return resources.SetAnnotation(data, dns.PLANNED_CHANGES_ANNOTATION, value), nil
+	})
+	if err != nil {
+		this.logger.Errorf("cannot update planned changes annotation: %s", err)
+	}
+	return mod
+}
+
+// statusUpdateOf determines the status update of the entry a change request has been created for.
+func statusUpdateOf(done DoneHandler) *StatusUpdate {
+	switch h := done.(type) {
+	case *applyingDoneHandler:
+		return statusUpdateOf(h.inner)
+	case *changeModelDoneHandler:
+		return statusUpdateOf(h.inner)
+	case *StatusUpdate:
+		return h
+	case *mergedStatusUpdate:
+		return h.StatusUpdate
+	}
+	return nil
+}
+
 func (this *StatusUpdate) Throttled() {
 	_, err := this.UpdateState(this.logger, api.STATE_PENDING, MSG_THROTTLING)
 	if err != nil {
@@ -0,0 +1,296 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+// reasons of the condition Stuck of an entry
+const (
+	STUCK_FINALIZER_WAIT = "FinalizerWait"
+	STUCK_DUPLICATE_NAME = "DuplicateName"
+	STUCK_THROTTLED      = "Throttled"
+	STUCK_ZONE_BUSY      = "ZoneBusy"
+	STUCK_ERROR          = "ReconcileError"
+	STUCK_RECOVERED      = "Recovered"
+)
+
+var stuckReasons = []string{STUCK_FINALIZER_WAIT, STUCK_DUPLICATE_NAME, STUCK_THROTTLED, STUCK_ZONE_BUSY, STUCK_ERROR}
+
+// stuckEntryInfo is a snapshot of the entry state relevant for detecting stuck entries.
+type stuckEntryInfo struct {
+	name      resources.ObjectName
+	zoneid    dns.ZoneID
+	state     string
+	message   string
+	deleting  bool
+	duplicate bool
+	// zoneBusy is true if the hosted zone of the entry is currently reconciled or waiting for its next reconciliation
+	zoneBusy bool
+	// blocking is true if the entry blocks the reconciliation of its hosted zone
+	blocking bool
+}
+
+// stuckEntry describes an entry not ready for longer than the threshold.
+type stuckEntry struct {
+	name    resources.ObjectName
+	zoneid  dns.ZoneID
+	reason  string
+	message string
+	// changed is true if the entry is newly flagged or the reason has changed
+	changed bool
+}
+
+// stuckEntryWatchdog tracks entries staying in state Pending or Error (or waiting for the
+// removal of their finalizer) and flags them as stuck after the threshold.
+type stuckEntryWatchdog struct {
+	lock      sync.Mutex
+	threshold time.Duration
+	retrigger bool
+	// since is the time an entry was first seen not being ready
+	since map[resources.ObjectName]time.Time
+	// flagged contains the reasons of the entries currently reported as stuck
+	flagged map[resources.ObjectName]string
+}
+
+// newStuckEntryWatchdog creates a watchdog. It returns nil if the detection is disabled (threshold 0).
+func newStuckEntryWatchdog(threshold time.Duration, retrigger bool) *stuckEntryWatchdog {
+	if threshold <= 0 {
+		return nil
+	}
+	return &stuckEntryWatchdog{
+		threshold: threshold,
+		retrigger: retrigger,
+		since:     map[resources.ObjectName]time.Time{},
+		flagged:   map[resources.ObjectName]string{},
+	}
+}
+
+// interval returns the period of the watchdog checks.
+func (this *stuckEntryWatchdog) interval() time.Duration {
+	interval := this.threshold / 2
+	if interval > time.Minute {
+		interval = time.Minute
+	}
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return interval
+}
+
+// isUnfinished returns true if the entry may be stuck.
+func (this *stuckEntryInfo) isUnfinished() bool {
+	return this.deleting || this.state == api.STATE_PENDING || this.state == api.STATE_ERROR
+}
+
+// stuckReason determines the reason blocking an entry and a hint for its remediation.
+func stuckReason(info *stuckEntryInfo) (string, string) {
+	switch {
+	case info.deleting:
+		return STUCK_FINALIZER_WAIT, "entry is waiting for the deletion of its records before the finalizer is removed; check the provider of the hosted zone"
+	case info.duplicate:
+		return STUCK_DUPLICATE_NAME, "another entry already claims the same DNS name; delete or rename one of the entries"
+	case info.message == MSG_THROTTLING:
+		return STUCK_THROTTLED, "requests to the provider are throttled; consider increasing the rate limit of the provider"
+	case info.zoneBusy || info.blocking:
+		return STUCK_ZONE_BUSY, fmt.Sprintf("hosted zone %s is busy or its reconciliation is blocked", info.zoneid)
+	}
+	if info.message != "" {
+		return STUCK_ERROR, fmt.Sprintf("entry stays in state %s: %s", info.state, info.message)
+	}
+	return STUCK_ERROR, fmt.Sprintf("entry stays in state %s", info.state)
+}
+
+// check updates the tracked entries and returns the entries stuck longer than the threshold
+// and the previously flagged entries which are not stuck anymore.
+func (this *stuckEntryWatchdog) check(now time.Time, infos []*stuckEntryInfo) ([]*stuckEntry, []resources.ObjectName) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	var stuck []*stuckEntry
+	var recovered []resources.ObjectName
+	seen := resources.ObjectNameSet{}
+	for _, info := range infos {
+		if !info.isUnfinished() {
+			continue
+		}
+		seen.Add(info.name)
+		since, ok := this.since[info.name]
+		if !ok {
+			this.since[info.name] = now
+			continue
+		}
+		if now.Sub(since) < this.threshold {
+			continue
+		}
+		reason, message := stuckReason(info)
+		stuck = append(stuck, &stuckEntry{
+			name:    info.name,
+			zoneid:  info.zoneid,
+			reason:  reason,
+			message: message,
+			changed: this.flagged[info.name] != reason,
+		})
+		this.flagged[info.name] = reason
+	}
+	for name := range this.since {
+		if !seen.Contains(name) {
+			delete(this.since, name)
+		}
+	}
+	for name := range this.flagged {
+		if !seen.Contains(name) {
+			delete(this.flagged, name)
+			recovered = append(recovered, name)
+		}
+	}
+	sort.Slice(stuck, func(i, j int) bool { return stuck[i].name.String() < stuck[j].name.String() })
+	sort.Slice(recovered, func(i, j int) bool { return recovered[i].String() < recovered[j].String() })
+	return stuck, recovered
+}
+
+// retriggerZones returns the hosted zones to retrigger for the given stuck entries.
+func (this *stuckEntryWatchdog) retriggerZones(stuck []*stuckEntry) []dns.ZoneID {
+	if !this.retrigger {
+		return nil
+	}
+	var zones []dns.ZoneID
+	found := map[dns.ZoneID]struct{}{}
+	for _, s := range stuck {
+		if s.zoneid.IsEmpty() || (s.reason != STUCK_ZONE_BUSY && s.reason != STUCK_ERROR) {
+			continue
+		}
+		if _, ok := found[s.zoneid]; !ok {
+			found[s.zoneid] = struct{}{}
+			zones = append(zones, s.zoneid)
+		}
+	}
+	return zones
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (this *state) startStuckEntryWatchdog() {
+	if this.stuck == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(this.stuck.interval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-this.context.GetContext().Done():
+				return
+			case <-ticker.C:
+				if this.context.IsReady() {
+					this.checkStuckEntries()
+				}
+			}
+		}
+	}()
+}
+
+func (this *state) stuckEntryInfos() []*stuckEntryInfo {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	infos := make([]*stuckEntryInfo, 0, len(this.entries))
+	for name, e := range this.entries {
+		info := &stuckEntryInfo{
+			name:      name,
+			zoneid:    e.ZoneId(),
+			state:     e.State(),
+			message:   e.Message(),
+			deleting:  e.IsDeleting(),
+			duplicate: e.duplicate,
+		}
+		if _, ok := this.blockingEntries[name]; ok {
+			info.blocking = true
+		}
+		if zone := this.zones[info.zoneid]; zone != nil {
+			info.zoneBusy = zone.IsBusy() || zone.GetNext().After(time.Now())
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+func (this *state) checkStuckEntries() {
+	stuck, recovered := this.stuck.check(time.Now(), this.stuckEntryInfos())
+
+	counts := map[string]int{}
+	for _, s := range stuck {
+		counts[s.reason]++
+		if s.changed {
+			this.context.Infof("entry %s is stuck (%s): %s", s.name, s.reason, s.message)
+			this.reportStuck(s.name, metav1.ConditionTrue, s.reason, s.message, true)
+		}
+	}
+	for _, name := range recovered {
+		this.reportStuck(name, metav1.ConditionFalse, STUCK_RECOVERED, "entry is not stuck anymore", false)
+	}
+	for _, reason := range stuckReasons {
+		metrics.ReportStuckEntries(reason, counts[reason])
+	}
+	for _, zoneid := range this.stuck.retriggerZones(stuck) {
+		this.context.Infof("retriggering hosted zone %s for stuck entries", zoneid)
+		this.triggerHostedZone(zoneid)
+	}
+}
+
+func (this *state) reportStuck(name resources.ObjectName, status metav1.ConditionStatus, reason, message string, event bool) {
+	this.lock.RLock()
+	entry := this.entries[name]
+	this.lock.RUnlock()
+	if entry == nil {
+		return
+	}
+	if event {
+		entry.object.Eventf(corev1.EventTypeWarning, "stuck", "%s: %s", reason, message)
+	}
+	this.acknowledgeEntryCondition(entry, api.CONDITION_STUCK, status, reason, message)
+}
+
+// acknowledgeEntryCondition sets a condition in the status of an entry.
+func (this *state) acknowledgeEntryCondition(entry *Entry, ctype string, status metav1.ConditionStatus, reason, message string) {
+	_, err := entry.object.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		o := dnsutils.DNSObject(entry.object.GetResource().Wrap(data))
+		return o.AcknowledgeCondition(metav1.Condition{
+			Type:               ctype,
+			Status:             status,
+			ObservedGeneration: o.GetGeneration(),
+			Reason:             reason,
+			Message:            message,
+		}), nil
+	})
+	if err != nil {
+		this.context.Warnf("cannot update condition %s of %s: %s", ctype, entry.ObjectName(), err)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Stuck entry watchdog", func() {
+	zoneid := dns.NewZoneID("aws-route53", "Z1")
+	nameA := resources.NewObjectName("default", "a")
+	nameB := resources.NewObjectName("default", "b")
+
+	ginkgov2.It("is disabled without threshold", func() {
+		Ω(newStuckEntryWatchdog(0, true)).Should(BeNil())
+	})
+
+	ginkgov2.It("determines the blocking reason", func() {
+		reason, _ := stuckReason(&stuckEntryInfo{state: api.STATE_PENDING, deleting: true, duplicate: true})
+		Ω(reason).Should(Equal(STUCK_FINALIZER_WAIT))
+		reason, _ = stuckReason(&stuckEntryInfo{state: api.STATE_ERROR, duplicate: true, message: MSG_THROTTLING})
+		Ω(reason).Should(Equal(STUCK_DUPLICATE_NAME))
+		reason, _ = stuckReason(&stuckEntryInfo{state: api.STATE_PENDING, message: MSG_THROTTLING, zoneBusy: true})
+		Ω(reason).Should(Equal(STUCK_THROTTLED))
+		reason, msg := stuckReason(&stuckEntryInfo{state: api.STATE_PENDING, zoneid: zoneid, blocking: true})
+		Ω(reason).Should(Equal(STUCK_ZONE_BUSY))
+		Ω(msg).Should(ContainSubstring(zoneid.String()))
+		reason, msg = stuckReason(&stuckEntryInfo{state: api.STATE_ERROR, message: "access denied"})
+		Ω(reason).Should(Equal(STUCK_ERROR))
+		Ω(msg).Should(Equal("entry stays in state Error: access denied"))
+	})
+
+	ginkgov2.It("flags entries after the threshold and reports recovery", func() {
+		w := newStuckEntryWatchdog(time.Minute, false)
+		now := time.Now()
+		infos := []*stuckEntryInfo{
+			{name: nameA, zoneid: zoneid, state: api.STATE_PENDING, zoneBusy: true},
+			{name: nameB, state: api.STATE_READY},
+		}
+
+		stuck, recovered := w.check(now, infos)
+		Ω(stuck).Should(BeEmpty())
+		Ω(recovered).Should(BeEmpty())
+
+		stuck, _ = w.check(now.Add(30*time.Second), infos)
+		Ω(stuck).Should(BeEmpty())
+
+		stuck, _ = w.check(now.Add(2*time.Minute), infos)
+		Ω(stuck).Should(HaveLen(1))
+		Ω(stuck[0].name).Should(Equal(nameA))
+		Ω(stuck[0].reason).Should(Equal(STUCK_ZONE_BUSY))
+		Ω(stuck[0].changed).Should(BeTrue())
+
+		stuck, _ = w.check(now.Add(3*time.Minute), infos)
+		Ω(stuck).Should(HaveLen(1))
+		Ω(stuck[0].changed).Should(BeFalse())
+
+		infos[0].state = api.STATE_READY
+		stuck, recovered = w.check(now.Add(4*time.Minute), infos)
+		Ω(stuck).Should(BeEmpty())
+		Ω(recovered).Should(ConsistOf(nameA))
+		Ω(w.since).Should(BeEmpty())
+		Ω(w.flagged).Should(BeEmpty())
+	})
+
+	ginkgov2.It("retriggers only blocking zones if enabled", func() {
+		stuck := []*stuckEntry{
+			{name: nameA, zoneid: zoneid, reason: STUCK_ZONE_BUSY},
+			{name: nameB, zoneid: zoneid, reason: STUCK_ERROR},
+			{name: nameB, zoneid: dns.NewZoneID("aws-route53", "Z2"), reason: STUCK_THROTTLED},
+			{name: nameB, reason: STUCK_ERROR},
+		}
+		Ω(newStuckEntryWatchdog(time.Minute, false).retriggerZones(stuck)).Should(BeEmpty())
+		Ω(newStuckEntryWatchdog(time.Minute, true).retriggerZones(stuck)).Should(ConsistOf(zoneid))
+	})
+})
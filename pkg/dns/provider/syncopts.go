@@ -0,0 +1,121 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/config"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+// SyncConfig contains the settings for the synchronization with the DNS system.
+// Zero values are replaced by the global defaults.
+type SyncConfig struct {
+	ZonesCacheTTL     time.Duration
+	ZoneStateCacheTTL time.Duration
+	ResyncPeriod      time.Duration
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type SyncOptions struct {
+	ZonesCacheTTL       time.Duration
+	ZoneStateCacheTTL   time.Duration
+	ResyncPeriod        time.Duration
+	ConditionalRequests bool
+}
+
+var SyncOptionsDefaults = SyncOptions{}
+
+func (this *SyncOptions) AddOptionsToSet(set config.OptionSet) {
+	set.AddDurationOption(&this.ZonesCacheTTL, OPT_SYNC_ZONES_CACHE_TTL, "", this.ZonesCacheTTL, "default time-to-live of the cached list of hosted zones of the provider accounts (option --cache-ttl if 0)")
+	set.AddDurationOption(&this.ZoneStateCacheTTL, OPT_SYNC_ZONE_STATE_CACHE_TTL, "", this.ZoneStateCacheTTL, "default time-to-live of the cached records of the hosted zones (resync period of pool dns if 0)")
+	set.AddDurationOption(&this.ResyncPeriod, OPT_SYNC_RESYNC_PERIOD, "", this.ResyncPeriod, "default period of the reconciliation of the providers (resync period of pool providers if 0)")
+	set.AddBoolOption(&this.ConditionalRequests, OPT_SYNC_CONDITIONAL_REQUESTS, "", this.ConditionalRequests, "uses conditional requests (ETag/If-None-Match) to skip the download of unchanged resources from HTTP based provider APIs")
+}
+
+func (c *SyncOptions) GetSyncConfig() SyncConfig {
+	return SyncConfig{ZonesCacheTTL: c.ZonesCacheTTL, ZoneStateCacheTTL: c.ZoneStateCacheTTL, ResyncPeriod: c.ResyncPeriod}
+}
+
+// configuration helpers
+
+func (c SyncOptions) SetZonesCacheTTL(ttl time.Duration) SyncOptions {
+	c.ZonesCacheTTL = ttl
+	return c
+}
+
+func (c SyncOptions) SetZoneStateCacheTTL(ttl time.Duration) SyncOptions {
+	c.ZoneStateCacheTTL = ttl
+	return c
+}
+
+func (c SyncOptions) SetResyncPeriod(period time.Duration) SyncOptions {
+	c.ResyncPeriod = period
+	return c
+}
+
+func (c SyncOptions) SetConditionalRequests(enabled bool) SyncOptions {
+	c.ConditionalRequests = enabled
+	return c
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// syncConfigFromSpec returns the settings of the DNSProvider overriding the defaults of the provider type.
+func syncConfigFromSpec(spec *api.ProviderSync) SyncConfig {
+	c := SyncConfig{}
+	if spec == nil {
+		return c
+	}
+	if spec.ZonesCacheTTL != nil {
+		c.ZonesCacheTTL = spec.ZonesCacheTTL.Duration
+	}
+	if spec.ZoneStateCacheTTL != nil {
+		c.ZoneStateCacheTTL = spec.ZoneStateCacheTTL.Duration
+	}
+	if spec.ResyncPeriod != nil {
+		c.ResyncPeriod = spec.ResyncPeriod.Duration
+	}
+	return c
+}
+
+// Merge returns the settings with zero values replaced by the given defaults.
+func (c SyncConfig) Merge(defaults SyncConfig) SyncConfig {
+	if c.ZonesCacheTTL <= 0 {
+		c.ZonesCacheTTL = defaults.ZonesCacheTTL
+	}
+	if c.ZoneStateCacheTTL <= 0 {
+		c.ZoneStateCacheTTL = defaults.ZoneStateCacheTTL
+	}
+	if c.ResyncPeriod <= 0 {
+		c.ResyncPeriod = defaults.ResyncPeriod
+	}
+	return c
+}
+
+func (c SyncConfig) String() string {
+	return fmt.Sprintf("ZonesCacheTTL: %s, ZoneStateCacheTTL: %s, ResyncPeriod: %s", c.ZonesCacheTTL, c.ZoneStateCacheTTL, c.ResyncPeriod)
+}
+
+// syncConfigUser is implemented by the DNSAccount to get the effective synchronization settings.
+type syncConfigUser interface {
+	setSyncConfig(sync SyncConfig)
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Sync settings", func() {
+	ginkgov2.It("overrides the defaults of the provider type by the provider spec", func() {
+		defaults := (&SyncOptions{ZonesCacheTTL: 30 * time.Minute, ResyncPeriod: 5 * time.Minute}).GetSyncConfig()
+		spec := &api.ProviderSync{ZonesCacheTTL: &metav1.Duration{Duration: time.Minute}, ZoneStateCacheTTL: &metav1.Duration{Duration: 2 * time.Minute}}
+
+		Ω(syncConfigFromSpec(nil).Merge(defaults)).Should(Equal(defaults))
+		Ω(syncConfigFromSpec(spec).Merge(defaults)).Should(Equal(SyncConfig{
+			ZonesCacheTTL:     time.Minute,
+			ZoneStateCacheTTL: 2 * time.Minute,
+			ResyncPeriod:      5 * time.Minute,
+		}))
+	})
+
+	ginkgov2.It("uses the zone state ttl of the account if there is no zone policy", func() {
+		s := &state{}
+		getter := s.CreateStateTTLGetter(15 * time.Minute)
+		zone1 := dns.NewZoneID("aws-route53", "Z1")
+		zone2 := dns.NewZoneID("aws-route53", "Z2")
+		s.zoneStateTTL.Store(map[dns.ZoneID]time.Duration{zone1: time.Hour})
+
+		Ω(getter(zone1, time.Minute)).Should(Equal(time.Hour))
+		Ω(getter(zone2, time.Minute)).Should(Equal(time.Minute))
+		Ω(getter(zone2, 0)).Should(Equal(15 * time.Minute))
+	})
+
+	ginkgov2.It("separates accounts with different sync settings", func() {
+		cache := NewAccountCache(time.Minute, nil)
+		Ω(cache.Hash(nil, "aws-route53", nil, nil, SyncConfig{})).ShouldNot(Equal(cache.Hash(nil, "aws-route53", nil, nil, SyncConfig{ResyncPeriod: time.Minute})))
+	})
+})
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+func createTargetCIDRFilter(c controller.Interface) (*dns.CIDRFilter, error) {
+	allowed, _ := c.GetStringOption(OPT_ALLOWED_TARGET_CIDRS)
+	denied, _ := c.GetStringOption(OPT_DENIED_TARGET_CIDRS)
+	filter, err := dns.NewCIDRFilter(splitCIDRs(allowed), splitCIDRs(denied))
+	if err != nil {
+		return nil, fmt.Errorf("invalid target CIDRs: %w", err)
+	}
+	return filter, nil
+}
+
+func splitCIDRs(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// newProviderTargetCIDRFilter parses the target CIDRs of a provider spec.
+func newProviderTargetCIDRFilter(cidrs *api.TargetCIDRs) (*dns.CIDRFilter, error) {
+	if cidrs == nil {
+		return nil, nil
+	}
+	return dns.NewCIDRFilter(cidrs.Allowed, cidrs.Denied)
+}
+
+// checkTargetCIDRs validates the address targets of an entry against the target CIDRs
+// of the controller and of the responsible provider.
+func (this *state) checkTargetCIDRs(provider DNSProvider, targets Targets) error {
+	for _, t := range targets {
+		if t.GetRecordType() != dns.RS_A && t.GetRecordType() != dns.RS_AAAA {
+			continue
+		}
+		if err := this.config.TargetCIDRs.CheckAddress(t.GetHostName()); err != nil {
+			return fmt.Errorf("target not published: %s (target CIDRs of controller)", err)
+		}
+		if provider != nil {
+			if err := provider.TargetCIDRs().CheckAddress(t.GetHostName()); err != nil {
+				return fmt.Errorf("target not published: %s (target CIDRs of provider %s)", err, provider.ObjectName())
+			}
+		}
+	}
+	return nil
+}
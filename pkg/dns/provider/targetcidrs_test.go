@@ -0,0 +1,78 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgov2.Describe("Target CIDRs", func() {
+	newTargets := func(names ...string) Targets {
+		targets := Targets{}
+		for _, n := range names {
+			t, err := NewHostTargetFromEntryVersion(n, &EntryVersion{})
+			Ω(err).ShouldNot(HaveOccurred())
+			targets = append(targets, t)
+		}
+		return targets
+	}
+	newState := func(allowed, denied []string) *state {
+		filter, err := dns.NewCIDRFilter(allowed, denied)
+		Ω(err).ShouldNot(HaveOccurred())
+		return &state{config: Config{TargetCIDRs: filter}}
+	}
+
+	ginkgov2.It("accepts all targets without CIDRs", func() {
+		s := newState(nil, nil)
+		Ω(s.checkTargetCIDRs(nil, newTargets("10.1.2.3", "2001:db8::1", "www.example.com"))).Should(Succeed())
+	})
+
+	ginkgov2.It("rejects denied addresses of the controller", func() {
+		s := newState(nil, []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"})
+		Ω(s.checkTargetCIDRs(nil, newTargets("1.2.3.4", "www.example.com"))).Should(Succeed())
+		Ω(s.checkTargetCIDRs(nil, newTargets("1.2.3.4", "192.168.1.1"))).Should(MatchError(
+			"target not published: address 192.168.1.1 is in denied CIDR range 192.168.0.0/16 (target CIDRs of controller)"))
+	})
+
+	ginkgov2.It("rejects addresses outside of allowed ranges of the controller", func() {
+		s := newState([]string{"1.2.3.0/24"}, nil)
+		Ω(s.checkTargetCIDRs(nil, newTargets("1.2.3.4"))).Should(Succeed())
+		Ω(s.checkTargetCIDRs(nil, newTargets("1.2.4.4"))).Should(MatchError(ContainSubstring("is not in allowed CIDR ranges [1.2.3.0/24]")))
+	})
+
+	ginkgov2.It("applies the target CIDRs of the provider", func() {
+		filter, err := newProviderTargetCIDRFilter(&api.TargetCIDRs{Allowed: []string{"2001:db8::/32"}})
+		Ω(err).ShouldNot(HaveOccurred())
+		s := newState(nil, nil)
+		p := &dnsProviderVersion{targetCIDRs: filter}
+		Ω(s.checkTargetCIDRs(p, newTargets("2001:db8::1"))).Should(Succeed())
+		Ω(p.TargetCIDRs().CheckAddress("2001:db9::1")).ShouldNot(Succeed())
+
+		_, err = newProviderTargetCIDRFilter(&api.TargetCIDRs{Denied: []string{"10.0.0.0"}})
+		Ω(err).Should(MatchError("invalid CIDR \"10.0.0.0\""))
+	})
+
+	ginkgov2.It("ignores text records", func() {
+		s := newState([]string{"1.2.3.0/24"}, nil)
+		Ω(s.checkTargetCIDRs(nil, Targets{dnsutils.NewText("10.1.2.3", 300)})).Should(Succeed())
+	})
+})
@@ -0,0 +1,190 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/resources/access"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+const GatewayGroupName = "gateway.networking.k8s.io"
+
+var serviceGroupKind = resources.NewGroupKind("", "Service")
+var nodeGroupKind = resources.NewGroupKind("", "Node")
+var gatewayGroupKind = resources.NewGroupKind(GatewayGroupName, "Gateway")
+
+// targetRefGroupKind determines the group kind of the object referenced by a target reference.
+func targetRefGroupKind(ref *api.TargetReference) (schema.GroupKind, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return schema.GroupKind{}, fmt.Errorf("invalid apiVersion %q in target reference: %s", ref.APIVersion, err)
+	}
+	switch ref.Kind {
+	case serviceGroupKind.Kind, nodeGroupKind.Kind:
+		if gv.Group == "" {
+			return resources.NewGroupKind("", ref.Kind), nil
+		}
+	case entryGroupKind.Kind:
+		if gv.Group == "" || gv.Group == api.GroupName {
+			return entryGroupKind, nil
+		}
+	case gatewayGroupKind.Kind:
+		if gv.Group == GatewayGroupName {
+			return gatewayGroupKind, nil
+		}
+		if gv.Group == "" {
+			return schema.GroupKind{}, fmt.Errorf("apiVersion required for target reference of kind %s", ref.Kind)
+		}
+	}
+	return schema.GroupKind{}, fmt.Errorf("unsupported target reference kind %s (apiVersion %q)", ref.Kind, ref.APIVersion)
+}
+
+// isPolledTargetRef returns true if changes of the referenced object are not watched
+// and the entry must be checked periodically.
+func isPolledTargetRef(ref *api.TargetReference) bool {
+	if ref == nil {
+		return false
+	}
+	gk, err := targetRefGroupKind(ref)
+	return err == nil && gk == gatewayGroupKind
+}
+
+func completeByTargetRef(logger logger.LogContext, state *state, spec dnsutils.DNSSpecification, object resources.Object, prefix string) (dnsutils.DNSSpecification, error) {
+	ref := spec.GetTargetRef()
+	if r := spec.GetReference(); r != nil && r.Name != "" {
+		return nil, fmt.Errorf("%starget reference specified together with entry reference", prefix)
+	}
+	if spec.GetTargets() != nil {
+		return nil, fmt.Errorf("%stargets specified together with target reference", prefix)
+	}
+	if spec.GetText() != nil {
+		return nil, fmt.Errorf("%stext specified together with target reference", prefix)
+	}
+	if ref.Name == "" {
+		return nil, fmt.Errorf("%sname missing in target reference", prefix)
+	}
+	gk, err := targetRefGroupKind(ref)
+	if err != nil {
+		return nil, fmt.Errorf("%s%s", prefix, err)
+	}
+
+	ns := ref.Namespace
+	switch gk {
+	case nodeGroupKind:
+		ns = ""
+	case entryGroupKind:
+		if ns == "" {
+			ns = object.GetNamespace()
+		}
+	default:
+		if ns != "" && ns != object.GetNamespace() {
+			return nil, fmt.Errorf("%starget reference to %s in foreign namespace %q not allowed", prefix, gk.Kind, ns)
+		}
+		ns = object.GetNamespace()
+	}
+	name := resources.NewObjectName(ns, ref.Name)
+	logger.Infof("completing targets by target reference: %s%s %s", prefix, gk.Kind, name)
+
+	cur := object.ClusterKey()
+	state.references.AddRef(cur, resources.NewClusterKey(cur.Cluster(), gk, ns, ref.Name))
+
+	var resc resources.Interface
+	if gk == gatewayGroupKind {
+		resc, err = object.GetResource().Resources().GetUnstructuredByGVK(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind))
+	} else {
+		resc, err = object.GetResource().Resources().GetByGK(gk)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%starget reference kind %s not available: %s", prefix, gk.Kind, err)
+	}
+	target, err := resc.GetCached(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			err = fmt.Errorf("target reference %s%s %q not found", prefix, gk.Kind, name)
+		}
+		logger.Warn(err)
+		return nil, err
+	}
+
+	var targets utils.StringSet
+	switch data := target.Data().(type) {
+	case *corev1.Service:
+		targets = source.LoadBalancerTargets(data.Status.LoadBalancer.Ingress, false)
+	case *corev1.Node:
+		targets = nodeAddresses(data)
+	case *api.DNSEntry:
+		err = access.CheckAccessWithRealms(object, "use", target, state.realms)
+		if err != nil {
+			return nil, fmt.Errorf("%s%s", prefix, err)
+		}
+		targets = utils.NewStringSetByArray(data.Status.Targets)
+	case *unstructured.Unstructured:
+		targets = gatewayAddresses(data)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("%s%s %q provides no addresses (yet)", prefix, gk.Kind, name)
+	}
+	list := targets.AsArray()
+	sort.Strings(list)
+	return &dnsSpecModification{DNSSpecification: spec, targets: list}, nil
+}
+
+// nodeAddresses returns the external addresses of a node or, if there are none,
+// its internal addresses.
+func nodeAddresses(node *corev1.Node) utils.StringSet {
+	external := utils.StringSet{}
+	internal := utils.StringSet{}
+	for _, addr := range node.Status.Addresses {
+		switch addr.Type {
+		case corev1.NodeExternalIP:
+			external.Add(addr.Address)
+		case corev1.NodeInternalIP:
+			internal.Add(addr.Address)
+		}
+	}
+	if len(external) > 0 {
+		return external
+	}
+	return internal
+}
+
+// gatewayAddresses returns the addresses from the status of a Gateway API gateway.
+func gatewayAddresses(obj *unstructured.Unstructured) utils.StringSet {
+	targets := utils.StringSet{}
+	addresses, _, _ := unstructured.NestedSlice(obj.Object, "status", "addresses")
+	for _, a := range addresses {
+		if m, ok := a.(map[string]interface{}); ok {
+			if value, ok := m["value"].(string); ok && value != "" {
+				targets.Add(value)
+			}
+		}
+	}
+	return targets
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = ginkgov2.Describe("Target reference", func() {
+	ginkgov2.It("determines the group kind of supported references", func() {
+		gk, err := targetRefGroupKind(&api.TargetReference{Kind: "Service", Name: "svc"})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(gk).Should(Equal(serviceGroupKind))
+
+		gk, err = targetRefGroupKind(&api.TargetReference{APIVersion: "v1", Kind: "Node", Name: "node"})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(gk).Should(Equal(nodeGroupKind))
+
+		gk, err = targetRefGroupKind(&api.TargetReference{Kind: "DNSEntry", Name: "entry"})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(gk).Should(Equal(entryGroupKind))
+
+		gk, err = targetRefGroupKind(&api.TargetReference{APIVersion: "gateway.networking.k8s.io/v1beta1", Kind: "Gateway", Name: "gw"})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(gk).Should(Equal(gatewayGroupKind))
+	})
+
+	ginkgov2.It("rejects unsupported references", func() {
+		_, err := targetRefGroupKind(&api.TargetReference{Kind: "Gateway", Name: "gw"})
+		Ω(err).Should(HaveOccurred())
+		_, err = targetRefGroupKind(&api.TargetReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "d"})
+		Ω(err).Should(HaveOccurred())
+		_, err = targetRefGroupKind(&api.TargetReference{APIVersion: "apps/v1", Kind: "Service", Name: "svc"})
+		Ω(err).Should(HaveOccurred())
+	})
+
+	ginkgov2.It("prefers external node addresses", func() {
+		node := &corev1.Node{}
+		node.Status.Addresses = []corev1.NodeAddress{
+			{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+			{Type: corev1.NodeHostName, Address: "node1"},
+		}
+		Ω(nodeAddresses(node)).Should(Equal(utils.NewStringSet("10.0.0.1")))
+
+		node.Status.Addresses = append(node.Status.Addresses, corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "1.2.3.4"})
+		Ω(nodeAddresses(node)).Should(Equal(utils.NewStringSet("1.2.3.4")))
+	})
+
+	ginkgov2.It("reads gateway addresses", func() {
+		gw := &unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"addresses": []interface{}{
+					map[string]interface{}{"type": "IPAddress", "value": "1.2.3.4"},
+					map[string]interface{}{"type": "Hostname", "value": "lb.example.com"},
+				},
+			},
+		}}
+		Ω(gatewayAddresses(gw)).Should(Equal(utils.NewStringSet("1.2.3.4", "lb.example.com")))
+		Ω(isPolledTargetRef(&api.TargetReference{APIVersion: "gateway.networking.k8s.io/v1beta1", Kind: "Gateway", Name: "gw"})).Should(BeTrue())
+		Ω(isPolledTargetRef(&api.TargetReference{Kind: "Service", Name: "svc"})).Should(BeFalse())
+	})
+})
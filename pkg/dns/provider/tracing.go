@@ -0,0 +1,85 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/tracing"
+)
+
+func createTracingConfig(c controller.Interface, tlsPolicy *dnsutils.TLSPolicy) *tracing.Config {
+	endpoint, err := c.GetStringOption(OPT_TRACING_OTLP_ENDPOINT)
+	if err != nil || endpoint == "" {
+		return nil
+	}
+	insecure, _ := c.GetBoolOption(OPT_TRACING_OTLP_INSECURE)
+	config := &tracing.Config{Endpoint: endpoint, Insecure: insecure}
+	if tlsPolicy != nil {
+		config.TLSConfig = &tls.Config{}
+		tlsPolicy.Apply(config.TLSConfig)
+	}
+	return config
+}
+
+// zoneAttributes returns the span attributes of a hosted zone.
+func zoneAttributes(zoneid dns.ZoneID) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("dns.provider_type", zoneid.ProviderType),
+		attribute.String("dns.zone", zoneid.ID),
+	}
+}
+
+// correlationID returns an identifier for correlating the log lines of a reconciliation.
+// It is the trace id of the span if recorded, otherwise a random id.
+func correlationID(span trace.Span) string {
+	if span != nil && span.SpanContext().HasTraceID() {
+		return span.SpanContext().TraceID().String()
+	}
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+func providerName(provider DNSProvider) string {
+	if provider == nil {
+		return ""
+	}
+	return provider.ObjectName().String()
+}
+
+// modifiedEntryLinks returns links to the last reconciliation spans of the modified entries.
+func modifiedEntryLinks(entries Entries) trace.SpanStartOption {
+	var links []trace.Link
+	for _, e := range entries {
+		if e.IsModified() && e.spanContext.IsValid() {
+			links = append(links, trace.Link{
+				SpanContext: e.spanContext,
+				Attributes:  []attribute.KeyValue{attribute.String("dns.entry", e.ObjectName().String())},
+			})
+		}
+	}
+	return trace.WithLinks(links...)
+}
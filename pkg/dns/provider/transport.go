@@ -0,0 +1,165 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// TransportConfig describes the network settings used to reach the API of a DNS system
+// (provider specific egress proxy and additional trusted CAs, global TLS policy).
+// A nil TransportConfig means the defaults of the provider type are used.
+type TransportConfig struct {
+	proxyURL  *url.URL
+	caBundle  []byte
+	rootCAs   *x509.CertPool
+	tlsPolicy *dnsutils.TLSPolicy
+	// conditional enables conditional GET requests using cached responses
+	conditional bool
+	metrics     Metrics
+}
+
+// NewTransportConfig creates a transport configuration for the given proxy URL,
+// PEM encoded CA bundle and TLS policy. It returns nil if nothing is set.
+func NewTransportConfig(proxyURL *string, caBundle []byte, tlsPolicy *dnsutils.TLSPolicy) (*TransportConfig, error) {
+	if (proxyURL == nil || *proxyURL == "") && len(caBundle) == 0 && tlsPolicy == nil {
+		return nil, nil
+	}
+	cfg := &TransportConfig{tlsPolicy: tlsPolicy}
+	if proxyURL != nil && *proxyURL != "" {
+		u, err := ParseProxyURL(*proxyURL)
+		if err != nil {
+			return nil, err
+		}
+		cfg.proxyURL = u
+	}
+	if len(caBundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("caBundle contains no valid PEM encoded certificate")
+		}
+		cfg.caBundle = caBundle
+		cfg.rootCAs = pool
+	}
+	return cfg, nil
+}
+
+func createTLSPolicy(c controller.Interface) (*dnsutils.TLSPolicy, error) {
+	minVersion, _ := c.GetStringOption(OPT_TLS_MIN_VERSION)
+	cipherSuites, _ := c.GetStringOption(OPT_TLS_CIPHER_SUITES)
+	policy, err := dnsutils.ParseTLSPolicy(minVersion, cipherSuites)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS policy: %w", err)
+	}
+	return policy, nil
+}
+
+// ParseProxyURL parses and checks a proxy URL.
+func ParseProxyURL(proxyURL string) (*url.URL, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxyURL: %s", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxyURL %q: scheme must be http, https or socks5", proxyURL)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxyURL %q: host missing", proxyURL)
+	}
+	return u, nil
+}
+
+// WithConditionalRequests returns a copy of the transport configuration enabling
+// conditional GET requests for resources providing an ETag or Last-Modified header.
+func (this *TransportConfig) WithConditionalRequests(metrics Metrics) *TransportConfig {
+	cfg := &TransportConfig{}
+	if this != nil {
+		*cfg = *this
+	}
+	cfg.conditional = true
+	cfg.metrics = metrics
+	return cfg
+}
+
+// Proxy returns the proxy function to use for an http.Transport
+// or nil if no proxy is configured.
+func (this *TransportConfig) Proxy() func(*http.Request) (*url.URL, error) {
+	if this == nil || this.proxyURL == nil {
+		return nil
+	}
+	return http.ProxyURL(this.proxyURL)
+}
+
+// RootCAs returns the system cert pool extended by the configured CA bundle
+// or nil if no CA bundle is configured.
+func (this *TransportConfig) RootCAs() *x509.CertPool {
+	if this == nil {
+		return nil
+	}
+	return this.rootCAs
+}
+
+// HTTPClient returns an http client using the configured proxy and CAs
+// or nil if nothing is configured, so that the default client of the provider type is kept.
+func (this *TransportConfig) HTTPClient() *http.Client {
+	if this == nil {
+		return nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if this.proxyURL != nil {
+		transport.Proxy = http.ProxyURL(this.proxyURL)
+	}
+	transport.TLSClientConfig = &tls.Config{RootCAs: this.rootCAs}
+	this.tlsPolicy.Apply(transport.TLSClientConfig)
+	if this.conditional {
+		return &http.Client{Transport: newConditionalTransport(transport, this.metrics)}
+	}
+	return &http.Client{Transport: transport}
+}
+
+// ApplyTLSPolicy restricts the given TLS configuration according to the configured TLS policy.
+func (this *TransportConfig) ApplyTLSPolicy(config *tls.Config) {
+	if this == nil {
+		return
+	}
+	this.tlsPolicy.Apply(config)
+}
+
+func (this *TransportConfig) hash(w io.Writer) {
+	if this == nil {
+		return
+	}
+	if this.proxyURL != nil {
+		w.Write([]byte(this.proxyURL.String()))
+	}
+	w.Write(null)
+	w.Write(this.caBundle)
+}
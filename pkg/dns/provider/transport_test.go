@@ -0,0 +1,84 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Transport config", func() {
+	strptr := func(v string) *string { return &v }
+
+	ginkgov2.It("is nil without proxy and CA bundle", func() {
+		cfg, err := NewTransportConfig(nil, nil, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cfg).Should(BeNil())
+		Ω(cfg.HTTPClient()).Should(BeNil())
+		Ω(cfg.Proxy()).Should(BeNil())
+		Ω(cfg.RootCAs()).Should(BeNil())
+	})
+
+	ginkgov2.It("rejects invalid settings", func() {
+		_, err := NewTransportConfig(strptr("ftp://proxy:21"), nil, nil)
+		Ω(err).Should(HaveOccurred())
+		_, err = NewTransportConfig(strptr("http://"), nil, nil)
+		Ω(err).Should(HaveOccurred())
+		_, err = NewTransportConfig(nil, []byte("no certificate"), nil)
+		Ω(err).Should(HaveOccurred())
+	})
+
+	ginkgov2.It("trusts the CA bundle", func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+		cfg, err := NewTransportConfig(nil, bundle, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		resp, err := cfg.HTTPClient().Get(server.URL)
+		Ω(err).ShouldNot(HaveOccurred())
+		resp.Body.Close()
+
+		_, err = http.Get(server.URL)
+		Ω(err).Should(HaveOccurred())
+	})
+
+	ginkgov2.It("sends requests via the proxy", func() {
+		var requested string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = r.URL.String()
+		}))
+		defer proxy.Close()
+
+		cfg, err := NewTransportConfig(&proxy.URL, nil, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		resp, err := cfg.HTTPClient().Get("http://dns.example.com/zones")
+		Ω(err).ShouldNot(HaveOccurred())
+		resp.Body.Close()
+		Ω(requested).Should(Equal("http://dns.example.com/zones"))
+	})
+
+	ginkgov2.It("separates accounts by transport settings", func() {
+		cache := NewAccountCache(0, nil)
+		cfg, _ := NewTransportConfig(strptr("http://proxy:3128"), nil, nil)
+		Ω(cache.Hash(nil, "aws-route53", nil, cfg, SyncConfig{})).ShouldNot(Equal(cache.Hash(nil, "aws-route53", nil, nil, SyncConfig{})))
+	})
+})
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+const (
+	TTL_CLAMPED       = "Clamped"
+	TTL_WITHIN_BOUNDS = "WithinBounds"
+)
+
+func createTTLBounds(c controller.Interface) (int64, int64, error) {
+	min, _ := c.GetIntOption(OPT_MIN_TTL)
+	max, _ := c.GetIntOption(OPT_MAX_TTL)
+	if min < 0 || max < 0 {
+		return 0, 0, fmt.Errorf("TTL bounds must not be negative")
+	}
+	if min > 0 && max > 0 && min > max {
+		return 0, 0, fmt.Errorf("minimum TTL %d is greater than maximum TTL %d", min, max)
+	}
+	return int64(min), int64(max), nil
+}
+
+// clampTTL restricts the ttl to the given bounds, a bound of 0 is ignored.
+func clampTTL(ttl, min, max int64) int64 {
+	if min > 0 && ttl < min {
+		return min
+	}
+	if max > 0 && ttl > max {
+		return max
+	}
+	return ttl
+}
+
+// ttlBounds returns the bounds used to clamp the TTL of entries in the given zone.
+// The bounds of a zone policy with enabled clamping take precedence over the global bounds.
+func (this *state) ttlBounds(zoneid dns.ZoneID) (int64, int64) {
+	min, max := this.config.MinTTL, this.config.MaxTTL
+	this.lock.RLock()
+	zone := this.zones[zoneid]
+	this.lock.RUnlock()
+	if zone == nil {
+		return min, max
+	}
+	if pol := zone.Policy(); pol != nil && pol.spec.Policy.ClampTTL {
+		if pol.spec.Policy.MinTTL != nil {
+			min = *pol.spec.Policy.MinTTL
+		}
+		if pol.spec.Policy.MaxTTL != nil {
+			max = *pol.spec.Policy.MaxTTL
+		}
+	}
+	return min, max
+}
+
+// clampTTL restricts the TTL of the entry version to the bounds of the given zone.
+// The requested TTL is kept for reporting if it has been changed.
+func (this *EntryVersion) clampTTL(state *state, zoneid dns.ZoneID) {
+	this.requestedTTL = nil
+	if this.status.TTL == nil {
+		return
+	}
+	ttl := *this.status.TTL
+	min, max := state.ttlBounds(zoneid)
+	if clamped := clampTTL(ttl, min, max); clamped != ttl {
+		this.requestedTTL = &ttl
+		this.status.TTL = &clamped
+	}
+}
+
+// reportTTLClamping sets the condition TTLClamped of the entry if its TTL has been clamped.
+// The condition is only reset if it has been set before.
+func (this *EntryVersion) reportTTLClamping(state *state) {
+	condition := metav1.Condition{
+		Type:   api.CONDITION_TTL_CLAMPED,
+		Status: metav1.ConditionFalse,
+		Reason: TTL_WITHIN_BOUNDS,
+	}
+	if this.requestedTTL != nil {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = TTL_CLAMPED
+		condition.Message = fmt.Sprintf("requested TTL %d clamped to %d", *this.requestedTTL, this.TTL())
+	} else if !hasCondition(this.object, api.CONDITION_TTL_CLAMPED) {
+		return
+	}
+	mod, err := this.object.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		o := dnsutils.DNSObject(this.object.GetResource().Wrap(data))
+		condition.ObservedGeneration = o.GetGeneration()
+		return o.AcknowledgeCondition(condition), nil
+	})
+	if err != nil {
+		state.context.Warnf("cannot update condition %s of %s: %s", api.CONDITION_TTL_CLAMPED, this.ObjectName(), err)
+		return
+	}
+	if mod && this.requestedTTL != nil {
+		this.object.Event(corev1.EventTypeWarning, "ttl", condition.Message)
+	}
+}
+
+func hasCondition(object dnsutils.DNSSpecification, ctype string) bool {
+	if e, ok := object.Data().(*api.DNSEntry); ok {
+		return meta.FindStatusCondition(e.Status.Conditions, ctype) != nil
+	}
+	return false
+}
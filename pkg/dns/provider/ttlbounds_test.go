@@ -0,0 +1,74 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgov2.Describe("TTL bounds", func() {
+	int64ptr := func(v int64) *int64 { return &v }
+	zoneid := dns.NewZoneID("mock", "z1")
+	newState := func(min, max int64, policy *api.ZonePolicy) *state {
+		zone := newDNSHostedZone(time.Second, NewDNSHostedZone("mock", "z1", "example.com", "", nil, false))
+		if policy != nil {
+			zone.SetPolicy(newDNSHostedZonePolicy("zp", &api.DNSHostedZonePolicySpec{Policy: *policy}))
+		}
+		return &state{
+			config: Config{MinTTL: min, MaxTTL: max},
+			zones:  map[dns.ZoneID]*dnsHostedZone{zoneid: zone},
+		}
+	}
+
+	ginkgov2.It("clamps ttls to bounds", func() {
+		Ω(clampTTL(300, 0, 0)).Should(Equal(int64(300)))
+		Ω(clampTTL(10, 60, 0)).Should(Equal(int64(60)))
+		Ω(clampTTL(7200, 60, 3600)).Should(Equal(int64(3600)))
+		Ω(clampTTL(300, 60, 3600)).Should(Equal(int64(300)))
+	})
+
+	ginkgov2.It("uses global bounds", func() {
+		s := newState(60, 3600, nil)
+		min, max := s.ttlBounds(zoneid)
+		Ω(min).Should(Equal(int64(60)))
+		Ω(max).Should(Equal(int64(3600)))
+
+		s = newState(60, 3600, &api.ZonePolicy{MinTTL: int64ptr(120)})
+		min, _ = s.ttlBounds(zoneid)
+		Ω(min).Should(Equal(int64(60)))
+	})
+
+	ginkgov2.It("prefers bounds of clamping zone policy", func() {
+		s := newState(60, 3600, &api.ZonePolicy{MinTTL: int64ptr(120), ClampTTL: true})
+		min, max := s.ttlBounds(zoneid)
+		Ω(min).Should(Equal(int64(120)))
+		Ω(max).Should(Equal(int64(3600)))
+	})
+
+	ginkgov2.It("skips ttl check of clamping zone policy", func() {
+		aTargets := Targets{dnsutils.NewTarget(dns.RS_A, "1.2.3.4", 300)}
+		pol := newDNSHostedZonePolicy("zp", &api.DNSHostedZonePolicySpec{Policy: api.ZonePolicy{MinTTL: int64ptr(60), ClampTTL: true}})
+		Ω(pol.Check("www.example.com", int64ptr(30), aTargets)).Should(Succeed())
+	})
+})
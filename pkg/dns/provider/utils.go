@@ -35,6 +35,9 @@ func (m *NullMetrics) AddGenericRequests(requestType string, n int) {
 func (m *NullMetrics) AddZoneRequests(zoneid, requestType string, n int) {
 }
 
+func (m *NullMetrics) AddThrottledRequests(n int) {
+}
+
 func copyZones(src map[dns.ZoneID]*dnsHostedZone) dnsHostedZones {
 	dst := dnsHostedZones{}
 	for k, v := range src {
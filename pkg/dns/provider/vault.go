@@ -0,0 +1,377 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// VAULT_DEFAULT_REFRESH is the refresh period for credentials without lease (e.g. from the KV engine).
+const VAULT_DEFAULT_REFRESH = 10 * time.Minute
+
+type VaultConfig struct {
+	Address   string
+	AuthMount string
+	TokenFile string
+}
+
+func createVaultConfig(c controller.Interface) (*VaultConfig, error) {
+	address, err := c.GetStringOption(OPT_VAULT_ADDRESS)
+	if err != nil || address == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		return nil, fmt.Errorf("invalid vault address %q (expected http(s)://<host>[:<port>])", address)
+	}
+	config := &VaultConfig{Address: strings.TrimSuffix(address, "/")}
+	config.AuthMount, _ = c.GetStringOption(OPT_VAULT_AUTH_MOUNT)
+	config.TokenFile, _ = c.GetStringOption(OPT_VAULT_TOKEN_FILE)
+	if config.AuthMount == "" || config.TokenFile == "" {
+		return nil, fmt.Errorf("vault address requires options %s and %s", OPT_VAULT_AUTH_MOUNT, OPT_VAULT_TOKEN_FILE)
+	}
+	return config, nil
+}
+
+type vaultCredentials struct {
+	ref     api.VaultReference
+	props   utils.Properties
+	renewAt time.Time
+}
+
+// vaultToken is the client token of a Vault login for a role.
+// Its lock serializes logins and renewals for the role.
+type vaultToken struct {
+	lock      sync.Mutex
+	token     string
+	renewable bool
+	lease     time.Duration
+	renewAt   time.Time
+	expiresAt time.Time
+}
+
+func (this *vaultToken) update(now time.Time, auth *vaultAuth) {
+	this.token = auth.ClientToken
+	this.renewable = auth.Renewable
+	this.lease = time.Duration(auth.LeaseDuration) * time.Second
+	if this.lease > 0 {
+		this.renewAt = now.Add(this.lease * 2 / 3)
+		this.expiresAt = now.Add(this.lease)
+	} else {
+		this.renewAt = time.Time{}
+		this.expiresAt = time.Time{}
+	}
+}
+
+// valid checks whether the token is still usable without renewal.
+func (this *vaultToken) valid(now time.Time) bool {
+	return this.token != "" && (this.renewAt.IsZero() || now.Before(this.renewAt))
+}
+
+// vaultClient reads provider credentials from HashiCorp Vault.
+// The credentials are cached per provider until two thirds of their lease duration are elapsed.
+// The client tokens of the logins are cached per role and renewed accordingly.
+// The lock only guards the caches, requests to Vault are done without holding it.
+type vaultClient struct {
+	config *VaultConfig
+	client *http.Client
+	now    func() time.Time
+	lock   sync.Mutex
+	cache  map[resources.ObjectName]*vaultCredentials
+	tokens map[string]*vaultToken
+}
+
+func newVaultClient(config *VaultConfig, tlsPolicy *dnsutils.TLSPolicy) *vaultClient {
+	if config == nil {
+		return nil
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	if tlsPolicy != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{}
+		tlsPolicy.Apply(transport.TLSClientConfig)
+		client.Transport = transport
+	}
+	return &vaultClient{
+		config: config,
+		client: client,
+		now:    time.Now,
+		cache:  map[resources.ObjectName]*vaultCredentials{},
+		tokens: map[string]*vaultToken{},
+	}
+}
+
+type vaultResponse struct {
+	LeaseDuration int                    `json:"lease_duration"`
+	Data          map[string]interface{} `json:"data"`
+	Auth          *vaultAuth             `json:"auth"`
+	Errors        []string               `json:"errors"`
+}
+
+type vaultAuth struct {
+	ClientToken   string `json:"client_token"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+}
+
+// vaultError is the error of a failed Vault request.
+type vaultError struct {
+	status int
+	errors []string
+}
+
+func (this *vaultError) Error() string {
+	if len(this.errors) > 0 {
+		return fmt.Sprintf("status %d: %s", this.status, strings.Join(this.errors, ", "))
+	}
+	return fmt.Sprintf("status %d", this.status)
+}
+
+func isVaultForbidden(err error) bool {
+	var verr *vaultError
+	return errors.As(err, &verr) && verr.status == http.StatusForbidden
+}
+
+// GetCredentials returns the credential properties for a provider and the duration after which they must be renewed.
+func (this *vaultClient) GetCredentials(name resources.ObjectName, ref *api.VaultReference) (utils.Properties, time.Duration, error) {
+	if this == nil {
+		return nil, 0, fmt.Errorf("vault credentials require option --%s", OPT_VAULT_ADDRESS)
+	}
+	this.lock.Lock()
+	c := this.cache[name]
+	this.lock.Unlock()
+
+	now := this.now()
+	if c != nil && reflect.DeepEqual(c.ref, *ref) && now.Before(c.renewAt) {
+		return c.props, c.renewAt.Sub(now), nil
+	}
+	var data map[string]interface{}
+	var lease int
+	err := this.withToken(ref.Role, func(token string) (err error) {
+		data, lease, err = this.read(token, ref.Path)
+		return
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	refresh := VAULT_DEFAULT_REFRESH
+	if lease > 0 {
+		refresh = time.Duration(lease) * time.Second * 2 / 3
+	}
+	props := vaultProperties(data, ref.Keys)
+	if len(props) == 0 {
+		return nil, 0, fmt.Errorf("vault secret %s contains no credentials", ref.Path)
+	}
+	this.lock.Lock()
+	this.cache[name] = &vaultCredentials{ref: *ref.DeepCopy(), props: props, renewAt: now.Add(refresh)}
+	this.lock.Unlock()
+	return props, refresh, nil
+}
+
+// Release removes the cached credentials of a provider.
+func (this *vaultClient) Release(name resources.ObjectName) {
+	if this == nil {
+		return
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.cache, name)
+}
+
+// TransitDecrypt decrypts a ciphertext with a key of the transit secrets engine
+// and returns the base64 decoded plaintext.
+func (this *vaultClient) TransitDecrypt(role, enginePath, keyName, ciphertext string) ([]byte, error) {
+	if this == nil {
+		return nil, fmt.Errorf("vault transit decryption requires option --%s", OPT_VAULT_ADDRESS)
+	}
+	path := strings.Trim(enginePath, "/") + "/decrypt/" + keyName
+	var resp *vaultResponse
+	err := this.withToken(role, func(token string) (err error) {
+		resp, err = this.request(http.MethodPost, path, token, map[string]string{"ciphertext": ciphertext})
+		return
+	})
+	if err != nil {
+		return nil, fmt.Errorf("vault transit decryption with key %s failed: %w", keyName, err)
+	}
+	plaintext, _ := resp.Data["plaintext"].(string)
+	return base64.StdEncoding.DecodeString(plaintext)
+}
+
+// withToken calls the given function with a client token for the role.
+// If the token is rejected, e.g. because it has been revoked, it is retried once with a new login.
+func (this *vaultClient) withToken(role string, f func(token string) error) error {
+	token, err := this.token(role)
+	if err != nil {
+		return err
+	}
+	err = f(token)
+	if isVaultForbidden(err) {
+		this.invalidateToken(role, token)
+		if token, err = this.token(role); err != nil {
+			return err
+		}
+		err = f(token)
+	}
+	return err
+}
+
+func (this *vaultClient) roleToken(role string) *vaultToken {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	t := this.tokens[role]
+	if t == nil {
+		t = &vaultToken{}
+		this.tokens[role] = t
+	}
+	return t
+}
+
+// token returns a client token for the role. The cached token is renewed after two thirds of its lease duration.
+// A new login is done if there is no token yet or the renewal fails or does not extend the lease sufficiently
+// (maximum TTL reached). The replaced token is not revoked, because Vault would revoke all leases created
+// with it, too, including those of the cached credentials. It just expires.
+func (this *vaultClient) token(role string) (string, error) {
+	t := this.roleToken(role)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := this.now()
+	if t.valid(now) {
+		return t.token, nil
+	}
+	old := t.token
+	if old != "" && t.renewable && now.Before(t.expiresAt) {
+		resp, err := this.request(http.MethodPost, "auth/token/renew-self", old, map[string]string{})
+		if err == nil && resp.Auth != nil && resp.Auth.ClientToken == old &&
+			time.Duration(resp.Auth.LeaseDuration)*time.Second >= t.lease/2 {
+			t.update(now, resp.Auth)
+			return t.token, nil
+		}
+	}
+	auth, err := this.login(role)
+	if err != nil {
+		return "", err
+	}
+	t.update(now, auth)
+	return t.token, nil
+}
+
+// invalidateToken drops a cached client token rejected by Vault.
+func (this *vaultClient) invalidateToken(role, token string) {
+	t := this.roleToken(role)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.token == token {
+		t.token = ""
+	}
+}
+
+func (this *vaultClient) login(role string) (*vaultAuth, error) {
+	jwt, err := os.ReadFile(this.config.TokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read service account token for vault login: %w", err)
+	}
+	body := map[string]string{"role": role, "jwt": strings.TrimSpace(string(jwt))}
+	resp, err := this.request(http.MethodPost, "auth/"+this.config.AuthMount+"/login", "", body)
+	if err != nil {
+		return nil, fmt.Errorf("vault login with role %q failed: %w", role, err)
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return nil, fmt.Errorf("vault login with role %q failed: no client token", role)
+	}
+	return resp.Auth, nil
+}
+
+func (this *vaultClient) read(token, path string) (map[string]interface{}, int, error) {
+	resp, err := this.request(http.MethodGet, path, token, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot read vault secret %s: %w", path, err)
+	}
+	return resp.Data, resp.LeaseDuration, nil
+}
+
+func (this *vaultClient) request(method, path, token string, body interface{}) (*vaultResponse, error) {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequest(method, this.config.Address+"/v1/"+strings.TrimPrefix(path, "/"), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	res, err := this.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	resp := &vaultResponse{}
+	if err := json.NewDecoder(res.Body).Decode(resp); err != nil && res.StatusCode < 300 {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	if res.StatusCode >= 300 {
+		return nil, &vaultError{status: res.StatusCode, errors: resp.Errors}
+	}
+	return resp, nil
+}
+
+// vaultProperties converts the data of a Vault secret to credential properties.
+// Secrets of the KV version 2 engine are unwrapped, keys are mapped according to the given mapping.
+func vaultProperties(data map[string]interface{}, keys map[string]string) utils.Properties {
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = inner
+		}
+	}
+	props := utils.Properties{}
+	for k, v := range data {
+		if mapped, ok := keys[k]; ok {
+			k = mapped
+		}
+		switch value := v.(type) {
+		case string:
+			props[k] = value
+		case nil:
+		default:
+			props[k] = fmt.Sprintf("%v", value)
+		}
+	}
+	return props
+}
@@ -0,0 +1,228 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = ginkgov2.Describe("Vault credentials", func() {
+	var server *httptest.Server
+	var client *vaultClient
+	var reads, logins, renewals int
+	var loginTokens, revoked []string
+	var renewFails bool
+
+	ginkgov2.BeforeEach(func() {
+		reads, logins, renewals = 0, 0, 0
+		loginTokens, revoked = nil, nil
+		renewFails = false
+		mux := http.NewServeMux()
+		mux.HandleFunc("/v1/auth/kubernetes/login", func(w http.ResponseWriter, r *http.Request) {
+			body := map[string]string{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["role"] != "dns" || body["jwt"] != "sa-token" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+			logins++
+			token := "vault-token"
+			if len(loginTokens) > 0 {
+				token, loginTokens = loginTokens[0], loginTokens[1:]
+			}
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"` + token + `","lease_duration":600,"renewable":true}}`))
+		})
+		mux.HandleFunc("/v1/auth/token/renew-self", func(w http.ResponseWriter, r *http.Request) {
+			renewals++
+			if renewFails {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"` + r.Header.Get("X-Vault-Token") + `","lease_duration":600,"renewable":true}}`))
+		})
+		mux.HandleFunc("/v1/auth/token/revoke-self", func(w http.ResponseWriter, r *http.Request) {
+			revoked = append(revoked, r.Header.Get("X-Vault-Token"))
+			w.WriteHeader(http.StatusNoContent)
+		})
+		mux.HandleFunc("/v1/secret/data/dns", func(w http.ResponseWriter, r *http.Request) {
+			reads++
+			_, _ = w.Write([]byte(`{"data":{"data":{"access_key":"ak","region":"eu"},"metadata":{"version":1}}}`))
+		})
+		mux.HandleFunc("/v1/aws/creds/dns", func(w http.ResponseWriter, r *http.Request) {
+			reads++
+			if r.Header.Get("X-Vault-Token") != "vault-token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"lease_duration":300,"data":{"access_key":"ak","secret_key":"sk","security_token":null}}`))
+		})
+		mux.HandleFunc("/v1/transit/decrypt/sops", func(w http.ResponseWriter, r *http.Request) {
+			body := map[string]string{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if r.Header.Get("X-Vault-Token") != "vault-token" || body["ciphertext"] != "vault:v1:abc" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"plaintext":"ZGF0YWtleQ=="}}`))
+		})
+		server = httptest.NewServer(mux)
+
+		dir, err := os.MkdirTemp("", "vault")
+		Ω(err).ShouldNot(HaveOccurred())
+		ginkgov2.DeferCleanup(os.RemoveAll, dir)
+		tokenFile := filepath.Join(dir, "token")
+		Ω(os.WriteFile(tokenFile, []byte("sa-token\n"), 0600)).Should(Succeed())
+
+		client = newVaultClient(&VaultConfig{Address: server.URL, AuthMount: "kubernetes", TokenFile: tokenFile}, nil)
+	})
+
+	ginkgov2.AfterEach(func() {
+		server.Close()
+	})
+
+	ginkgov2.It("reads and caches dynamic credentials", func() {
+		name := resources.NewObjectName("ns", "p")
+		ref := &api.VaultReference{Path: "aws/creds/dns", Role: "dns", Keys: map[string]string{"access_key": "AWS_ACCESS_KEY_ID"}}
+		props, refresh, err := client.GetCredentials(name, ref)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(props).Should(Equal(utils.Properties{"AWS_ACCESS_KEY_ID": "ak", "secret_key": "sk"}))
+		Ω(refresh).Should(Equal(200 * time.Second))
+
+		_, refresh, err = client.GetCredentials(name, ref)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(refresh).Should(BeNumerically("<=", 200*time.Second))
+		Ω(reads).Should(Equal(1))
+
+		client.Release(name)
+		_, _, err = client.GetCredentials(name, ref)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(reads).Should(Equal(2))
+	})
+
+	ginkgov2.It("unwraps secrets of the KV version 2 engine", func() {
+		props, refresh, err := client.GetCredentials(resources.NewObjectName("ns", "p"), &api.VaultReference{Path: "secret/data/dns", Role: "dns"})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(props).Should(Equal(utils.Properties{"access_key": "ak", "region": "eu"}))
+		Ω(refresh).Should(Equal(VAULT_DEFAULT_REFRESH))
+	})
+
+	ginkgov2.It("reports login errors", func() {
+		_, _, err := client.GetCredentials(resources.NewObjectName("ns", "p"), &api.VaultReference{Path: "aws/creds/dns", Role: "other"})
+		Ω(err).Should(MatchError(`vault login with role "other" failed: status 403: permission denied`))
+	})
+
+	ginkgov2.It("decrypts with the transit secrets engine", func() {
+		plain, err := client.TransitDecrypt("dns", "/transit/", "sops", "vault:v1:abc")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(plain)).Should(Equal("datakey"))
+	})
+
+	ginkgov2.It("reuses and renews the client token of the login", func() {
+		now := time.Now()
+		client.now = func() time.Time { return now }
+		ref := &api.VaultReference{Path: "secret/data/dns", Role: "dns"}
+		get := func() {
+			_, _, err := client.GetCredentials(resources.NewObjectName("ns", "p"), ref)
+			Ω(err).ShouldNot(HaveOccurred())
+			client.Release(resources.NewObjectName("ns", "p"))
+		}
+
+		get()
+		get()
+		Ω(reads).Should(Equal(2))
+		Ω(logins).Should(Equal(1))
+		Ω(renewals).Should(Equal(0))
+
+		now = now.Add(401 * time.Second)
+		get()
+		Ω(logins).Should(Equal(1))
+		Ω(renewals).Should(Equal(1))
+
+		// failed renewal: login again, the replaced token just expires
+		loginTokens = []string{"vault-token-2"}
+		renewFails = true
+		now = now.Add(401 * time.Second)
+		get()
+		Ω(logins).Should(Equal(2))
+		Ω(renewals).Should(Equal(2))
+		Ω(revoked).Should(BeEmpty())
+	})
+
+	ginkgov2.It("keeps cached credentials valid if the client token is replaced", func() {
+		now := time.Now()
+		client.now = func() time.Time { return now }
+		dynamic := resources.NewObjectName("ns", "dynamic")
+		ref := &api.VaultReference{Path: "aws/creds/dns", Role: "dns"}
+		getKV := func() {
+			kv := resources.NewObjectName("ns", "kv")
+			_, _, err := client.GetCredentials(kv, &api.VaultReference{Path: "secret/data/dns", Role: "dns"})
+			Ω(err).ShouldNot(HaveOccurred())
+			client.Release(kv)
+		}
+
+		getKV()
+		Ω(logins).Should(Equal(1))
+
+		// dynamic credentials read with the first token shortly before its renewal
+		now = now.Add(300 * time.Second)
+		_, _, err := client.GetCredentials(dynamic, ref)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(logins).Should(Equal(1))
+
+		// another read replaces the token after a failed renewal
+		loginTokens = []string{"vault-token-2"}
+		renewFails = true
+		now = now.Add(101 * time.Second)
+		getKV()
+		Ω(logins).Should(Equal(2))
+
+		// the leases of the cached credentials must not be revoked with the old token
+		Ω(revoked).Should(BeEmpty())
+		props, _, err := client.GetCredentials(dynamic, ref)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(props).Should(HaveKeyWithValue("secret_key", "sk"))
+		Ω(reads).Should(Equal(3))
+	})
+
+	ginkgov2.It("logs in again if the client token is rejected", func() {
+		loginTokens = []string{"revoked-token"}
+		props, _, err := client.GetCredentials(resources.NewObjectName("ns", "p"), &api.VaultReference{Path: "aws/creds/dns", Role: "dns"})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(props).Should(HaveKeyWithValue("secret_key", "sk"))
+		Ω(logins).Should(Equal(2))
+		Ω(reads).Should(Equal(2))
+	})
+
+	ginkgov2.It("requires the vault address option", func() {
+		var none *vaultClient
+		_, _, err := none.GetCredentials(resources.NewObjectName("ns", "p"), &api.VaultReference{Path: "aws/creds/dns", Role: "dns"})
+		Ω(err).Should(HaveOccurred())
+	})
+})
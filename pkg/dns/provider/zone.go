@@ -18,6 +18,7 @@ package provider
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -38,6 +39,10 @@ type dnsHostedZone struct {
 	nextTrigger time.Duration
 	owners      utils.StringSet
 	policy      *dnsHostedZonePolicy
+	orphans     map[dns.DNSSetName]time.Time
+	lastDrift   time.Time
+	// requests are the change requests of the running reconciliation not executed yet
+	requests ChangeRequests
 }
 
 func newDNSHostedZone(min time.Duration, zone DNSHostedZone) *dnsHostedZone {
@@ -45,6 +50,7 @@ func newDNSHostedZone(min time.Duration, zone DNSHostedZone) *dnsHostedZone {
 		zone:        zone,
 		RateLimiter: dnsutils.NewRateLimiter(min, 10*time.Minute, min/2),
 		owners:      utils.StringSet{},
+		orphans:     map[dns.DNSSetName]time.Time{},
 	}
 }
 
@@ -59,6 +65,12 @@ func (this *dnsHostedZone) TestAndSetBusy() bool {
 	return true
 }
 
+func (this *dnsHostedZone) IsBusy() bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.busy
+}
+
 func (this *dnsHostedZone) String() string {
 	zone := this.getZone()
 	return fmt.Sprintf("%s: %s", zone.Id(), zone.Domain())
@@ -70,6 +82,18 @@ func (this *dnsHostedZone) Release() {
 	this.busy = false
 }
 
+func (this *dnsHostedZone) setOutstandingRequests(reqs ChangeRequests) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.requests = reqs
+}
+
+func (this *dnsHostedZone) getOutstandingRequests() ChangeRequests {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.requests
+}
+
 func (this *dnsHostedZone) getZone() DNSHostedZone {
 	this.lock.Lock()
 	defer this.lock.Unlock()
@@ -124,6 +148,43 @@ func (this *dnsHostedZone) SetNext(next time.Time) {
 	this.next = next
 }
 
+// StartDriftCheck checks whether a drift check is due for the given period and
+// records the start of a new one.
+func (this *dnsHostedZone) StartDriftCheck(now time.Time, period time.Duration) bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if period <= 0 || now.Before(this.lastDrift.Add(period)) {
+		return false
+	}
+	this.lastDrift = now
+	return true
+}
+
+// OrphanedSince returns the time the record set has been detected as orphaned for the first time.
+func (this *dnsHostedZone) OrphanedSince(name dns.DNSSetName, now time.Time) time.Time {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	since, ok := this.orphans[name]
+	if !ok {
+		since = now
+		this.orphans[name] = since
+	}
+	return since
+}
+
+// RetainOrphans forgets all orphaned record sets not contained in the given set
+// and returns the number of remaining ones.
+func (this *dnsHostedZone) RetainOrphans(names dns.DNSNameSet) int {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for name := range this.orphans {
+		if !names.Contains(name) {
+			delete(this.orphans, name)
+		}
+	}
+	return len(this.orphans)
+}
+
 func (this *dnsHostedZone) Policy() *dnsHostedZonePolicy {
 	this.lock.Lock()
 	defer this.lock.Unlock()
@@ -160,3 +221,84 @@ func newDNSHostedZonePolicy(name string, spec *dnsv1alpha1.DNSHostedZonePolicySp
 		conflictingPolicyNames: utils.StringSet{},
 	}
 }
+
+// Validate checks the entry constraints of the policy.
+func (this *dnsHostedZonePolicy) Validate() error {
+	pol := &this.spec.Policy
+	if pol.MinTTL != nil && pol.MaxTTL != nil && *pol.MinTTL > *pol.MaxTTL {
+		return fmt.Errorf("minTTL %d is greater than maxTTL %d", *pol.MinTTL, *pol.MaxTTL)
+	}
+	for _, t := range pol.AllowedRecordTypes {
+		if !policyRecordTypes[strings.ToUpper(t)] {
+			return fmt.Errorf("unsupported record type %q", t)
+		}
+	}
+	if pol.DuplicatePolicy != nil {
+		return validateDuplicatePolicy(*pol.DuplicatePolicy)
+	}
+	return nil
+}
+
+// hasEntryConstraints returns true if the policy restricts the DNS entries of its zones.
+func hasEntryConstraints(pol *dnsv1alpha1.ZonePolicy) bool {
+	return pol.MinTTL != nil || pol.MaxTTL != nil || len(pol.AllowedRecordTypes) > 0 || pol.ForbidWildcards ||
+		pol.DuplicatePolicy != nil || pol.Wildcards != nil || pol.Apex != nil
+}
+
+// CheckRestrictions validates the usage of wildcard and apex domain names by an entry
+// of the given namespace and owner id in a zone with the given base domain.
+func (this *dnsHostedZonePolicy) CheckRestrictions(domain, namespace, ownerId, dnsname string) error {
+	pol := &this.spec.Policy
+	name := normalizePolicyDomain(dnsname)
+	if strings.HasPrefix(name, "*.") && isRestricted(pol.Wildcards, namespace, ownerId) {
+		return this.errorf("wildcard domain name %q not allowed for namespace %q and owner id %q", dnsname, namespace, ownerId)
+	}
+	if name == normalizePolicyDomain(domain) && isRestricted(pol.Apex, namespace, ownerId) {
+		return this.errorf("apex domain name %q not allowed for namespace %q and owner id %q", dnsname, namespace, ownerId)
+	}
+	return nil
+}
+
+// isRestricted checks whether a name restriction forbids the usage for the given namespace and owner id.
+func isRestricted(r *dnsv1alpha1.NameRestriction, namespace, ownerId string) bool {
+	if r == nil || !r.Forbidden || containsString(r.AllowedNamespaces, namespace) {
+		return false
+	}
+	return ownerId == "" || !containsString(r.AllowedOwnerIds, ownerId)
+}
+
+// Check validates the given entry attributes against the entry constraints of the policy.
+// The ttl may be nil if it is not known yet.
+func (this *dnsHostedZonePolicy) Check(dnsname string, ttl *int64, targets Targets) error {
+	pol := &this.spec.Policy
+	if pol.ForbidWildcards && strings.HasPrefix(normalizePolicyDomain(dnsname), "*.") {
+		return this.errorf("wildcard domain name %q not allowed", dnsname)
+	}
+	if ttl != nil && !pol.ClampTTL {
+		if pol.MinTTL != nil && *ttl < *pol.MinTTL {
+			return this.errorf("TTL %d is lower than minimum %d", *ttl, *pol.MinTTL)
+		}
+		if pol.MaxTTL != nil && *ttl > *pol.MaxTTL {
+			return this.errorf("TTL %d is greater than maximum %d", *ttl, *pol.MaxTTL)
+		}
+	}
+	if len(pol.AllowedRecordTypes) > 0 {
+		for _, target := range targets {
+			allowed := false
+			for _, t := range pol.AllowedRecordTypes {
+				if strings.ToUpper(t) == target.GetRecordType() {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				return this.errorf("record type %s is not allowed", target.GetRecordType())
+			}
+		}
+	}
+	return nil
+}
+
+func (this *dnsHostedZonePolicy) errorf(msg string, args ...interface{}) error {
+	return fmt.Errorf("violates hosted zone policy %q: %s", this.name, fmt.Sprintf(msg, args...))
+}
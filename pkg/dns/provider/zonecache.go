@@ -32,7 +32,9 @@ import (
 	"github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 )
 
-type StateTTLGetter func(zoneid dns.ZoneID) time.Duration
+// StateTTLGetter returns the ttl of the cached zone state, the ttl configured for the
+// provider account of the zone (or 0) is used if there is no zone specific ttl.
+type StateTTLGetter func(zoneid dns.ZoneID, accountTTL time.Duration) time.Duration
 
 type ZoneCacheFactory struct {
 	context               context.Context
@@ -40,10 +42,22 @@ type ZoneCacheFactory struct {
 	zonesTTL              time.Duration
 	zoneStates            *zoneStates
 	disableZoneStateCache bool
+	sync                  SyncConfig
 }
 
 func (c ZoneCacheFactory) CreateZoneCache(cacheType ZoneCacheType, metrics Metrics, zonesUpdater ZoneCacheZoneUpdater, stateUpdater ZoneCacheStateUpdater) (ZoneCache, error) {
-	common := abstractZonesCache{zonesTTL: c.zonesTTL, logger: c.logger, zonesUpdater: zonesUpdater, stateUpdater: stateUpdater}
+	return c.CreateVersionedZoneCache(cacheType, metrics, zonesUpdater, stateUpdater, nil)
+}
+
+// CreateVersionedZoneCache creates a zone cache, which uses the version getter to skip the download
+// of zone states unchanged at the provider since the last update.
+func (c ZoneCacheFactory) CreateVersionedZoneCache(cacheType ZoneCacheType, metrics Metrics, zonesUpdater ZoneCacheZoneUpdater,
+	stateUpdater ZoneCacheStateUpdater, versionGetter ZoneCacheVersionGetter) (ZoneCache, error) {
+	zonesTTL := c.zonesTTL
+	if c.sync.ZonesCacheTTL > 0 {
+		zonesTTL = c.sync.ZonesCacheTTL
+	}
+	common := abstractZonesCache{zonesTTL: zonesTTL, logger: c.logger, zonesUpdater: zonesUpdater, stateUpdater: stateUpdater, versionGetter: versionGetter}
 	switch cacheType {
 	case CacheZonesOnly:
 		cache := &onlyZonesCache{abstractZonesCache: common}
@@ -53,7 +67,7 @@ func (c ZoneCacheFactory) CreateZoneCache(cacheType ZoneCacheType, metrics Metri
 			cache := &onlyZonesCache{abstractZonesCache: common}
 			return cache, nil
 		}
-		return newDefaultZoneCache(c.zoneStates, common, metrics)
+		return newDefaultZoneCache(c.zoneStates, common, metrics, c.sync.ZoneStateCacheTTL)
 	default:
 		return nil, fmt.Errorf("unknown zone cache type: %v", cacheType)
 	}
@@ -72,7 +86,7 @@ const (
 func NewTestZoneCacheFactory(zonesTTL, stateTTL time.Duration) *ZoneCacheFactory {
 	return &ZoneCacheFactory{
 		zonesTTL:   zonesTTL,
-		zoneStates: newZoneStates(func(id dns.ZoneID) time.Duration { return stateTTL }),
+		zoneStates: newZoneStates(func(dns.ZoneID, time.Duration) time.Duration { return stateTTL }),
 	}
 }
 
@@ -80,6 +94,10 @@ type ZoneCacheZoneUpdater func(cache ZoneCache) (DNSHostedZones, error)
 
 type ZoneCacheStateUpdater func(zone DNSHostedZone, cache ZoneCache) (DNSZoneState, error)
 
+// ZoneCacheVersionGetter returns the actual version of a zone at the provider (e.g. the serial number
+// of the zone), which changes with every change of the zone records. An empty version means unknown.
+type ZoneCacheVersionGetter func(zone DNSHostedZone, cache ZoneCache) (string, error)
+
 type ZoneCache interface {
 	GetZones() (DNSHostedZones, error)
 	GetZoneState(zone DNSHostedZone) (DNSZoneState, error)
@@ -128,13 +146,14 @@ func (hd *forwardedDomainsCacheImpl) DeleteZone(zoneID dns.ZoneID) {
 }
 
 type abstractZonesCache struct {
-	logger       logger.LogContext
-	zonesTTL     time.Duration
-	zones        DNSHostedZones
-	zonesErr     error
-	zonesNext    time.Time
-	zonesUpdater ZoneCacheZoneUpdater
-	stateUpdater ZoneCacheStateUpdater
+	logger        logger.LogContext
+	zonesTTL      time.Duration
+	zones         DNSHostedZones
+	zonesErr      error
+	zonesNext     time.Time
+	zonesUpdater  ZoneCacheZoneUpdater
+	stateUpdater  ZoneCacheStateUpdater
+	versionGetter ZoneCacheVersionGetter
 }
 
 type onlyZonesCache struct {
@@ -180,14 +199,15 @@ type defaultZoneCache struct {
 	logger     logger.LogContext
 	metrics    Metrics
 	zoneStates *zoneStates
+	stateTTL   time.Duration
 
 	backoffOnError time.Duration
 }
 
 var _ ZoneCache = &defaultZoneCache{}
 
-func newDefaultZoneCache(zoneStates *zoneStates, common abstractZonesCache, metrics Metrics) (*defaultZoneCache, error) {
-	cache := &defaultZoneCache{abstractZonesCache: common, logger: common.logger, metrics: metrics, zoneStates: zoneStates}
+func newDefaultZoneCache(zoneStates *zoneStates, common abstractZonesCache, metrics Metrics, stateTTL time.Duration) (*defaultZoneCache, error) {
+	cache := &defaultZoneCache{abstractZonesCache: common, logger: common.logger, metrics: metrics, zoneStates: zoneStates, stateTTL: stateTTL}
 	return cache, nil
 }
 
@@ -269,6 +289,8 @@ type zoneStateProxy struct {
 	lock            sync.Mutex
 	lastUpdateStart time.Time
 	lastUpdateEnd   time.Time
+	// version of the zone at the provider for the cached zone state
+	version string
 }
 
 type zoneStates struct {
@@ -278,6 +300,7 @@ type zoneStates struct {
 	proxies               map[dns.ZoneID]*zoneStateProxy
 	usedZones             map[ZoneCache][]dns.ZoneID
 	forwardedDomainsCache *forwardedDomainsCacheImpl
+	store                 *zoneStateStore
 }
 
 func newZoneStates(stateTTLGetter StateTTLGetter) *zoneStates {
@@ -307,13 +330,33 @@ func (s *zoneStates) GetZoneState(zone DNSHostedZone, cache *defaultZoneCache) (
 	defer proxy.lock.Unlock()
 
 	start := time.Now()
-	ttl := s.stateTTLGetter(zone.Id())
+	ttl := s.stateTTLGetter(zone.Id(), cache.stateTTL)
+	if proxy.lastUpdateEnd.IsZero() && s.store != nil {
+		s.restoreZoneState(zone, proxy, start, ttl)
+	}
 	if start.After(proxy.lastUpdateEnd.Add(ttl)) {
+		version := s.getZoneVersion(zone, cache)
+		if version != "" && version == proxy.version {
+			if state, err := s.inMemory.CloneZoneState(zone); err == nil {
+				// zone unchanged at the provider, the cached zone state is still valid
+				proxy.lastUpdateStart = start
+				proxy.lastUpdateEnd = time.Now()
+				if s.store != nil {
+					s.store.Save(zone.Id(), state.GetDNSSets(), proxy.lastUpdateEnd)
+				}
+				cache.metrics.AddZoneRequests(zone.Id().ID, M_UNCHANGED_GETZONESTATE, 1)
+				return state, true, nil
+			}
+		}
 		state, err := cache.stateUpdater(zone, cache)
 		if err == nil {
 			proxy.lastUpdateStart = start
 			proxy.lastUpdateEnd = time.Now()
+			proxy.version = version
 			s.inMemory.SetZone(zone, state)
+			if s.store != nil {
+				s.store.Save(zone.Id(), state.GetDNSSets(), proxy.lastUpdateEnd)
+			}
 		} else {
 			s.cleanZoneState(zone.Id(), proxy)
 		}
@@ -327,6 +370,40 @@ func (s *zoneStates) GetZoneState(zone DNSHostedZone, cache *defaultZoneCache) (
 	return state, true, nil
 }
 
+// getZoneVersion returns the actual version of the zone at the provider
+// or an empty string if the version is unknown.
+func (s *zoneStates) getZoneVersion(zone DNSHostedZone, cache *defaultZoneCache) string {
+	if cache.versionGetter == nil {
+		return ""
+	}
+	version, err := cache.versionGetter(zone, cache)
+	if err != nil {
+		if cache.logger != nil {
+			cache.logger.Warnf("cannot get version of zone %s: %s", zone.Id(), err)
+		}
+		return ""
+	}
+	return version
+}
+
+// restoreZoneState uses the persisted zone state of a previous controller run
+// if it has been read from the provider within the state ttl.
+func (s *zoneStates) restoreZoneState(zone DNSHostedZone, proxy *zoneStateProxy, now time.Time, ttl time.Duration) {
+	dnssets, timestamp, err := s.store.Load(zone.Id())
+	if err != nil {
+		s.store.logger.Warnf("cannot restore persisted zone state of %s: %s", zone.Id(), err)
+		s.store.Delete(zone.Id())
+		return
+	}
+	if dnssets == nil || now.After(timestamp.Add(ttl)) {
+		return
+	}
+	s.inMemory.SetZone(zone, NewDNSZoneState(dnssets))
+	proxy.lastUpdateStart = timestamp
+	proxy.lastUpdateEnd = timestamp
+	s.store.logger.Infof("restored persisted zone state of %s (%d dns sets, age %s)", zone.Id(), len(dnssets), now.Sub(timestamp).Truncate(time.Second))
+}
+
 func (s *zoneStates) ReportZoneStateConflict(zoneID dns.ZoneID, err error) bool {
 	proxy := s.getProxy(zoneID)
 	proxy.lock.Lock()
@@ -353,9 +430,11 @@ func (s *zoneStates) ExecuteRequests(zoneID dns.ZoneID, reqs []*ChangeRequest) {
 	defer proxy.lock.Unlock()
 
 	var err error
+	applied := false
 	nullMetrics := &NullMetrics{}
 	for _, req := range reqs {
 		if req.Applied {
+			applied = true
 			err = s.inMemory.Apply(zoneID, req, nullMetrics)
 			if err != nil {
 				break
@@ -363,8 +442,16 @@ func (s *zoneStates) ExecuteRequests(zoneID dns.ZoneID, reqs []*ChangeRequest) {
 		}
 	}
 
+	if applied {
+		// the own changes modify the version of the zone at the provider
+		proxy.version = ""
+	}
 	if err != nil {
 		s.cleanZoneState(zoneID, proxy)
+	} else if applied && s.store != nil {
+		if dnssets := s.inMemory.GetDNSSets(zoneID); dnssets != nil {
+			s.store.Save(zoneID, dnssets, proxy.lastUpdateEnd)
+		}
 	}
 }
 
@@ -382,6 +469,9 @@ func (s *zoneStates) CleanZoneState(zoneID dns.ZoneID) {
 
 func (s *zoneStates) cleanZoneState(zoneID dns.ZoneID, proxy *zoneStateProxy) {
 	s.inMemory.DeleteZone(zoneID)
+	if s.store != nil {
+		s.store.Delete(zoneID)
+	}
 	if s.forwardedDomainsCache != nil {
 		s.forwardedDomainsCache.DeleteZone(zoneID)
 	}
@@ -389,6 +479,7 @@ func (s *zoneStates) cleanZoneState(zoneID dns.ZoneID, proxy *zoneStateProxy) {
 		var zero time.Time
 		proxy.lastUpdateStart = zero
 		proxy.lastUpdateEnd = zero
+		proxy.version = ""
 	}
 }
 
@@ -0,0 +1,208 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/debugstate"
+)
+
+// ServeDiff compares the desired state of the hosted zone given by the query parameter zone (and optionally type)
+// with the record sets at the DNS provider for the DNS controllers whose token matches the bearer token of the request.
+func (this *debugStateHandler) ServeDiff(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	id := r.URL.Query().Get("zone")
+	if id == "" {
+		http.Error(w, "query parameter zone missing", http.StatusBadRequest)
+		return
+	}
+	ptype := r.URL.Query().Get("type")
+	authorized := false
+	result := []*debugstate.ZoneDiff{}
+	for _, s := range this.getStates() {
+		if !s.checkDebugStateToken(token) {
+			continue
+		}
+		authorized = true
+		for _, zone := range s.getZonesById(id, ptype) {
+			log := s.context.NewContext("zone-diff", zone.Id().String())
+			result = append(result, s.diffZone(log, zone))
+		}
+	}
+	if !authorized {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if len(result) == 0 {
+		http.Error(w, fmt.Sprintf("hosted zone %q not found", id), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(result)
+}
+
+// diffZone calculates the change requests a zone reconciliation would execute for a hosted zone
+// without executing them. In contrast to a zone reconciliation, the state of the entries is not changed.
+func (this *state) diffZone(logger logger.LogContext, zone *dnsHostedZone) *debugstate.ZoneDiff {
+	result := &debugstate.ZoneDiff{
+		Controller:   this.config.Ident,
+		ProviderType: zone.Id().ProviderType,
+		ID:           zone.Id().ID,
+		Domain:       zone.Domain(),
+		Changes:      []debugstate.Change{},
+	}
+	req := &zoneReconciliation{
+		zone:      zone,
+		fhandler:  this.context,
+		dnsTicker: this.dnsTicker,
+		ctx:       this.context.GetContext(),
+	}
+	this.lock.RLock()
+	req.ownership = this.ownerCache
+	req.entries, req.equivEntries, req.stale, req.deleting = this.addEntriesForZone(logger, nil, nil, zone)
+	req.providers = this.getProvidersForZone(zone.Id())
+	this.lock.RUnlock()
+
+	list := make(EntryList, 0, len(req.entries))
+	for _, e := range req.entries {
+		list = append(list, e)
+	}
+	if err := list.Lock(); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer list.Unlock()
+
+	changes := NewChangeModel(logger, req.ownership, req, this.config)
+	if err := changes.Setup(); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	entries := map[dns.DNSSetName]string{}
+	for _, e := range list {
+		entries[e.DNSSetName()] = e.ObjectName().String()
+		spec := e.object.GetTargetSpec(e)
+		r := changes.Exec(true, e.IsDeleting(), e.DNSSetName(), e.ObjectName().Namespace(), e.CreatedAt(), nil, spec)
+		if r.Error != nil {
+			result.Conflicts = append(result.Conflicts, debugstate.Conflict{Entry: e.ObjectName().String(), Message: r.Error.Error()})
+		}
+	}
+	changes.PlanCleanup()
+	for _, req := range changes.Requests() {
+		change := debugChange(req)
+		change.Entry = entries[req.dnsSetName()]
+		result.Changes = append(result.Changes, change)
+	}
+	sortDebugChanges(result.Changes)
+	sort.Slice(result.Conflicts, func(i, j int) bool { return result.Conflicts[i].Entry < result.Conflicts[j].Entry })
+	return result
+}
+
+// PlanCleanup adds the delete requests for the owned record sets without active entries and for the
+// record sets of deactivated owners like Cleanup, but without updating the status of stale entries and
+// without respecting the grace period of orphans.
+func (this *ChangeModel) PlanCleanup() {
+	for _, view := range this.groups() {
+		for _, s := range view.dnssets {
+			if _, ok := this.applied[s.Name]; ok || this.ExistsInEquivalentZone(s.Name) {
+				continue
+			}
+			if !s.IsOwnedBy(this.ownership) {
+				if policy := this.retiredPolicy(s); policy != nil {
+					view.cleanupRetired(s, *policy, nil)
+				}
+				continue
+			}
+			if this.IsStale(ZonedDNSSetName{ZoneID: this.ZoneId(), DNSSetName: s.Name}) != nil {
+				continue
+			}
+			for ty := range s.Sets {
+				view.addDeleteRequest(s, ty, nil)
+			}
+			for _, reg := range s.ExternalDNSRegistry {
+				view.addDeleteRequest(reg, dns.RS_TXT, nil)
+			}
+		}
+	}
+}
+
+// debugChange returns the description of a change request for the debug endpoints.
+func debugChange(req *ChangeRequest) debugstate.Change {
+	name := req.dnsSetName()
+	return debugstate.Change{
+		Action:        req.Action,
+		Type:          req.Type,
+		DNSName:       name.DNSName,
+		SetIdentifier: name.SetIdentifier,
+		Description:   req.PlannedDescription(),
+	}
+}
+
+func sortDebugChanges(changes []debugstate.Change) {
+	sort.SliceStable(changes, func(i, j int) bool {
+		ci, cj := changes[i], changes[j]
+		if ci.DNSName != cj.DNSName {
+			return ci.DNSName < cj.DNSName
+		}
+		if ci.SetIdentifier != cj.SetIdentifier {
+			return ci.SetIdentifier < cj.SetIdentifier
+		}
+		return ci.Type < cj.Type
+	})
+}
+
+// dnsSetName returns the name of the record set changed by the request.
+func (this *ChangeRequest) dnsSetName() dns.DNSSetName {
+	if this.Addition != nil {
+		return this.Addition.Name
+	}
+	return this.Deletion.Name
+}
+
+// Requests returns the change requests of all change groups.
+func (this *ChangeModel) Requests() ChangeRequests {
+	reqs := ChangeRequests{}
+	for _, view := range this.groups() {
+		reqs = append(reqs, view.requests...)
+	}
+	return reqs
+}
+
+func (this *ChangeModel) groups() []*ChangeGroup {
+	groups := make([]*ChangeGroup, 0, len(this.providergroups)+1)
+	for _, view := range this.providergroups {
+		groups = append(groups, view)
+	}
+	if this.dangling != nil {
+		groups = append(groups, this.dangling)
+	}
+	return groups
+}
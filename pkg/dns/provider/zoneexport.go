@@ -0,0 +1,181 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/server"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+const zoneExportPath = "/zones/export"
+
+// formats of the zone export
+const (
+	formatZoneFile  = "zonefile"
+	formatTerraform = "terraform"
+)
+
+var zoneExport = &zoneExportHandler{}
+
+// zoneExportHandler serves the desired state of the hosted zones of all
+// DNS controllers with enabled zone export as zone files.
+type zoneExportHandler struct {
+	lock   sync.Mutex
+	states []*state
+}
+
+func registerZoneExport(state *state) {
+	zoneExport.lock.Lock()
+	defer zoneExport.lock.Unlock()
+	if len(zoneExport.states) == 0 {
+		server.RegisterHandler(zoneExportPath, zoneExport)
+	}
+	zoneExport.states = append(zoneExport.states, state)
+}
+
+func (this *zoneExportHandler) getStates() []*state {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return append([]*state{}, this.states...)
+}
+
+// ServeHTTP lists the available hosted zones or renders the hosted zone
+// given by the query parameter zone (and optionally type) as zone file.
+// With the query parameter format=terraform the record sets are rendered as
+// Terraform import blocks and resources of the provider type of the zone.
+func (this *zoneExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	id := r.URL.Query().Get("zone")
+	ptype := r.URL.Query().Get("type")
+	format := r.URL.Query().Get("format")
+	if format != "" && format != formatZoneFile && format != formatTerraform {
+		http.Error(w, fmt.Sprintf("unknown format %q", format), http.StatusBadRequest)
+		return
+	}
+	if id == "" {
+		this.listZones(w)
+		return
+	}
+	for _, s := range this.getStates() {
+		for _, zone := range s.getZonesById(id, ptype) {
+			if format == formatTerraform && !dns.SupportsTerraform(zone.Id().ProviderType) {
+				http.Error(w, fmt.Sprintf("terraform export not supported for provider type %s", zone.Id().ProviderType), http.StatusBadRequest)
+				return
+			}
+			log := s.context.NewContext("zone-export", zone.Id().String())
+			sets, err := s.desiredZoneState(log, zone)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+				return
+			}
+			if format == formatTerraform {
+				w.Header().Set("Content-Type", "text/plain")
+				fmt.Fprintf(w, "# desired state of hosted zone %s (%s)\n\n", zone.Id(), zone.Domain())
+				err = dns.WriteTerraform(w, zone.Id(), zone.Domain(), sets)
+			} else {
+				w.Header().Set("Content-Type", "text/dns")
+				fmt.Fprintf(w, "; desired state of hosted zone %s (%s)\n", zone.Id(), zone.Domain())
+				err = dns.WriteZoneFile(w, zone.Domain(), sets)
+			}
+			if err != nil {
+				log.Warnf("zone export failed: %s", err)
+			}
+			return
+		}
+	}
+	http.Error(w, fmt.Sprintf("hosted zone %q not found", id), http.StatusNotFound)
+}
+
+func (this *zoneExportHandler) listZones(w http.ResponseWriter) {
+	lines := []string{}
+	for _, s := range this.getStates() {
+		for _, zone := range s.getZonesById("", "") {
+			lines = append(lines, fmt.Sprintf("%s\t%s\t%s\n", zone.Id().ProviderType, zone.Id().ID, zone.Domain()))
+		}
+	}
+	sort.Strings(lines)
+	w.Header().Set("Content-Type", "text/plain")
+	for _, l := range lines {
+		fmt.Fprint(w, l)
+	}
+}
+
+// getZonesById returns the hosted zones with the given id and provider type.
+// Empty values match all zones.
+func (this *state) getZonesById(id, ptype string) []*dnsHostedZone {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	result := []*dnsHostedZone{}
+	for zoneid, zone := range this.zones {
+		if (id == "" || zoneid.ID == id) && (ptype == "" || zoneid.ProviderType == ptype) {
+			result = append(result, zone)
+		}
+	}
+	return result
+}
+
+// desiredZoneState calculates the record sets for all active entries of a hosted zone
+// as they would be applied by a zone reconciliation.
+func (this *state) desiredZoneState(logger logger.LogContext, zone *dnsHostedZone) (dns.DNSSets, error) {
+	req := &zoneReconciliation{
+		zone:     zone,
+		fhandler: this.context,
+	}
+	this.lock.RLock()
+	req.ownership = this.ownerCache
+	req.entries, _, _, _ = this.addEntriesForZone(logger, nil, nil, zone)
+	req.providers = this.getProvidersForZone(zone.Id())
+	this.lock.RUnlock()
+
+	list := make(EntryList, 0, len(req.entries))
+	for _, e := range req.entries {
+		list = append(list, e)
+	}
+	if err := list.Lock(); err != nil {
+		return nil, err
+	}
+	defer list.Unlock()
+
+	changes := NewChangeModel(logger, req.ownership, req, this.config)
+	sets := dns.DNSSets{}
+	for _, e := range list {
+		if e.IsDeleting() {
+			continue
+		}
+		spec := e.object.GetTargetSpec(e)
+		if len(spec.Targets()) == 0 {
+			continue
+		}
+		p := req.providers.LookupFor(e.DNSSetName().DNSName)
+		if p == nil {
+			continue
+		}
+		set := dns.NewDNSSet(e.DNSSetName(), spec.RoutingPolicy())
+		sets[set.Name] = changes.ApplySpec(set, nil, p, spec)
+	}
+	return sets, nil
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+// HostedZoneSpec describes a hosted zone to be created or updated by a provider.
+type HostedZoneSpec struct {
+	Domain string
+	// Private is true for private hosted zones
+	Private bool
+	// PrivateNetworks are provider specific identifiers of networks for private hosted zones
+	PrivateNetworks []string
+	DNSSEC          bool
+	// Reference is a unique identifier of the requesting object, it is used
+	// to make the creation idempotent if supported by the provider
+	Reference string
+}
+
+// HostedZoneInfo describes a hosted zone created by a provider.
+type HostedZoneInfo struct {
+	ZoneID      string
+	NameServers []string
+}
+
+// DNSZoneManagement is an optional interface of a DNSHandler supporting
+// the creation and deletion of hosted zones.
+type DNSZoneManagement interface {
+	CreateHostedZone(logger logger.LogContext, spec *HostedZoneSpec) (*HostedZoneInfo, error)
+	UpdateHostedZone(logger logger.LogContext, zoneid string, spec *HostedZoneSpec) (*HostedZoneInfo, error)
+	DeleteHostedZone(logger logger.LogContext, zoneid string) error
+}
+
+// CheckHostedZoneDomain checks that the domain of an existing hosted zone matches the domain of the spec,
+// as the domain of a hosted zone cannot be changed.
+func CheckHostedZoneDomain(zoneid, domain string, spec *HostedZoneSpec) error {
+	if !strings.EqualFold(strings.TrimSuffix(domain, "."), strings.TrimSuffix(spec.Domain, ".")) {
+		return fmt.Errorf("domain name of hosted zone %s cannot be changed from %s to %s", zoneid, strings.TrimSuffix(domain, "."), spec.Domain)
+	}
+	return nil
+}
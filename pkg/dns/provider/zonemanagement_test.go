@@ -0,0 +1,32 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Hosted zone management", func() {
+	ginkgov2.It("rejects changes of the domain name", func() {
+		spec := &HostedZoneSpec{Domain: "example.com"}
+		Ω(CheckHostedZoneDomain("Z1", "example.com.", spec)).Should(Succeed())
+		Ω(CheckHostedZoneDomain("Z1", "Example.com", spec)).Should(Succeed())
+		Ω(CheckHostedZoneDomain("Z1", "other.com.", spec)).Should(MatchError(
+			"domain name of hosted zone Z1 cannot be changed from other.com to example.com"))
+	})
+})
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgov2.Describe("Hosted zone policy", func() {
+	int64ptr := func(v int64) *int64 { return &v }
+	aTargets := Targets{dnsutils.NewTarget(dns.RS_A, "1.2.3.4", 300)}
+	newPolicy := func(policy api.ZonePolicy) *dnsHostedZonePolicy {
+		return newDNSHostedZonePolicy("zp", &api.DNSHostedZonePolicySpec{Policy: policy})
+	}
+
+	ginkgov2.It("validates entry constraints", func() {
+		Ω(newPolicy(api.ZonePolicy{MinTTL: int64ptr(600), MaxTTL: int64ptr(60)}).Validate()).ShouldNot(Succeed())
+		Ω(newPolicy(api.ZonePolicy{AllowedRecordTypes: []string{"MX"}}).Validate()).ShouldNot(Succeed())
+		Ω(newPolicy(api.ZonePolicy{AllowedRecordTypes: []string{"a"}}).Validate()).Should(Succeed())
+		Ω(hasEntryConstraints(&api.ZonePolicy{})).Should(BeFalse())
+		Ω(hasEntryConstraints(&api.ZonePolicy{ForbidWildcards: true})).Should(BeTrue())
+	})
+
+	ginkgov2.It("checks ttl limits", func() {
+		pol := newPolicy(api.ZonePolicy{MinTTL: int64ptr(60), MaxTTL: int64ptr(600)})
+		Ω(pol.Check("www.example.com", int64ptr(300), aTargets)).Should(Succeed())
+		Ω(pol.Check("www.example.com", nil, aTargets)).Should(Succeed())
+		Ω(pol.Check("www.example.com", int64ptr(30), aTargets)).Should(MatchError(ContainSubstring("hosted zone policy \"zp\"")))
+		Ω(pol.Check("www.example.com", int64ptr(3600), aTargets)).ShouldNot(Succeed())
+	})
+
+	ginkgov2.It("checks allowed record types", func() {
+		pol := newPolicy(api.ZonePolicy{AllowedRecordTypes: []string{"a", "AAAA"}})
+		Ω(pol.Check("www.example.com", nil, aTargets)).Should(Succeed())
+		cname := Targets{dnsutils.NewTarget(dns.RS_CNAME, "other.example.com", 300)}
+		Ω(pol.Check("www.example.com", nil, cname)).ShouldNot(Succeed())
+	})
+
+	ginkgov2.It("checks forbidden wildcards", func() {
+		pol := newPolicy(api.ZonePolicy{ForbidWildcards: true})
+		Ω(pol.Check("www.example.com", nil, aTargets)).Should(Succeed())
+		Ω(pol.Check("*.example.com", nil, aTargets)).ShouldNot(Succeed())
+	})
+
+	ginkgov2.It("restricts wildcards to namespaces and owners", func() {
+		pol := newPolicy(api.ZonePolicy{Wildcards: &api.NameRestriction{
+			Forbidden:         true,
+			AllowedNamespaces: []string{"ingress"},
+			AllowedOwnerIds:   []string{"platform"},
+		}})
+		Ω(hasEntryConstraints(&pol.spec.Policy)).Should(BeTrue())
+		Ω(pol.CheckRestrictions("example.com", "team-a", "", "www.example.com")).Should(Succeed())
+		Ω(pol.CheckRestrictions("example.com", "team-a", "", "*.example.com")).ShouldNot(Succeed())
+		Ω(pol.CheckRestrictions("example.com", "team-a", "team-a", "*.Example.com.")).ShouldNot(Succeed())
+		Ω(pol.CheckRestrictions("example.com", "ingress", "", "*.example.com")).Should(Succeed())
+		Ω(pol.CheckRestrictions("example.com", "team-a", "platform", "*.sub.example.com")).Should(Succeed())
+	})
+
+	ginkgov2.It("restricts apex entries", func() {
+		pol := newPolicy(api.ZonePolicy{Apex: &api.NameRestriction{Forbidden: true, AllowedOwnerIds: []string{"platform"}}})
+		Ω(pol.CheckRestrictions("example.com", "team-a", "", "www.example.com")).Should(Succeed())
+		Ω(pol.CheckRestrictions("example.com", "team-a", "", "example.com")).Should(MatchError(ContainSubstring("apex domain name")))
+		Ω(pol.CheckRestrictions("example.com", "team-a", "platform", "Example.com")).Should(Succeed())
+
+		open := newPolicy(api.ZonePolicy{Apex: &api.NameRestriction{AllowedNamespaces: []string{"platform"}}})
+		Ω(open.CheckRestrictions("example.com", "team-a", "", "example.com")).Should(Succeed())
+	})
+})
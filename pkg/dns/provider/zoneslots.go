@@ -0,0 +1,83 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"sort"
+	"sync"
+)
+
+// zoneReconciliationSlots limits the number of hosted zones of a provider account
+// reconciled concurrently, so that the workers of the pool dns are shared between
+// the accounts and the account rate limiter is not exhausted by a single account.
+type zoneReconciliationSlots struct {
+	lock  sync.Mutex
+	limit int
+	used  map[string]int
+}
+
+func newZoneReconciliationSlots(limit int) *zoneReconciliationSlots {
+	return &zoneReconciliationSlots{limit: limit, used: map[string]int{}}
+}
+
+// TryAcquire acquires a slot for all given accounts or none of them.
+// It returns false if the limit is reached for one of the accounts.
+func (this *zoneReconciliationSlots) TryAcquire(accounts []string) bool {
+	if this.limit <= 0 {
+		return true
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for _, a := range accounts {
+		if this.used[a] >= this.limit {
+			return false
+		}
+	}
+	for _, a := range accounts {
+		this.used[a]++
+	}
+	return true
+}
+
+func (this *zoneReconciliationSlots) Release(accounts []string) {
+	if this.limit <= 0 {
+		return
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for _, a := range accounts {
+		if this.used[a] <= 1 {
+			delete(this.used, a)
+		} else {
+			this.used[a]--
+		}
+	}
+}
+
+// accountHashes returns the distinct accounts of the providers.
+func (this DNSProviders) accountHashes() []string {
+	set := map[string]struct{}{}
+	for _, p := range this {
+		set[p.AccountHash()] = struct{}{}
+	}
+	hashes := make([]string, 0, len(set))
+	for h := range set {
+		hashes = append(hashes, h)
+	}
+	sort.Strings(hashes)
+	return hashes
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Zone reconciliation slots", func() {
+	ginkgov2.It("limits the concurrent zone reconciliations per account", func() {
+		slots := newZoneReconciliationSlots(2)
+
+		Ω(slots.TryAcquire([]string{"a"})).Should(BeTrue())
+		Ω(slots.TryAcquire([]string{"a", "b"})).Should(BeTrue())
+		Ω(slots.TryAcquire([]string{"a"})).Should(BeFalse())
+		Ω(slots.TryAcquire([]string{"b", "a"})).Should(BeFalse())
+		Ω(slots.TryAcquire([]string{"b"})).Should(BeTrue())
+		Ω(slots.TryAcquire([]string{"b"})).Should(BeFalse())
+
+		slots.Release([]string{"a", "b"})
+		Ω(slots.TryAcquire([]string{"a"})).Should(BeTrue())
+		Ω(slots.TryAcquire([]string{"a"})).Should(BeFalse())
+	})
+
+	ginkgov2.It("is unlimited for limit 0", func() {
+		slots := newZoneReconciliationSlots(0)
+		for i := 0; i < 10; i++ {
+			Ω(slots.TryAcquire([]string{"a"})).Should(BeTrue())
+		}
+	})
+})
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// persistedZoneState is the file format of a zone state persisted by the zoneStateStore.
+type persistedZoneState struct {
+	ProviderType string        `json:"providerType"`
+	ID           string        `json:"id"`
+	Timestamp    time.Time     `json:"timestamp"`
+	DNSSets      []*dns.DNSSet `json:"dnssets"`
+}
+
+// zoneStateStore persists the cached zone states in a directory, so that a restarted
+// controller can reuse them until their ttl is expired instead of reading all zones at once.
+type zoneStateStore struct {
+	logger logger.LogContext
+	dir    string
+}
+
+func newZoneStateStore(logger logger.LogContext, dir string) (*zoneStateStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("cannot create zone state cache directory: %w", err)
+	}
+	return &zoneStateStore{logger: logger, dir: dir}, nil
+}
+
+func (s *zoneStateStore) filename(zoneID dns.ZoneID) string {
+	name := base64.RawURLEncoding.EncodeToString([]byte(zoneID.ProviderType + ":" + zoneID.ID))
+	return filepath.Join(s.dir, name+".json")
+}
+
+// Save persists the DNS sets of a zone state read from the provider at the given time.
+func (s *zoneStateStore) Save(zoneID dns.ZoneID, dnssets dns.DNSSets, timestamp time.Time) {
+	persisted := &persistedZoneState{ProviderType: zoneID.ProviderType, ID: zoneID.ID, Timestamp: timestamp}
+	for _, set := range dnssets {
+		persisted.DNSSets = append(persisted.DNSSets, set)
+	}
+	data, err := json.Marshal(persisted)
+	if err == nil {
+		filename := s.filename(zoneID)
+		tmp := filename + ".tmp"
+		if err = os.WriteFile(tmp, data, 0o600); err == nil {
+			err = os.Rename(tmp, filename)
+		}
+	}
+	if err != nil {
+		s.logger.Warnf("cannot persist zone state of %s: %s", zoneID, err)
+	}
+}
+
+// Load returns the persisted DNS sets of a zone and the time they have been read from the provider.
+// It returns nil if no zone state has been persisted.
+func (s *zoneStateStore) Load(zoneID dns.ZoneID) (dns.DNSSets, time.Time, error) {
+	data, err := os.ReadFile(s.filename(zoneID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return nil, time.Time{}, err
+	}
+	persisted := &persistedZoneState{}
+	if err := json.Unmarshal(data, persisted); err != nil {
+		return nil, time.Time{}, err
+	}
+	if persisted.ProviderType != zoneID.ProviderType || persisted.ID != zoneID.ID {
+		return nil, time.Time{}, fmt.Errorf("persisted zone state belongs to zone %s", dns.NewZoneID(persisted.ProviderType, persisted.ID))
+	}
+	dnssets := dns.DNSSets{}
+	for _, set := range persisted.DNSSets {
+		dnssets[set.Name] = set
+	}
+	return dnssets, persisted.Timestamp, nil
+}
+
+// Delete removes the persisted zone state.
+func (s *zoneStateStore) Delete(zoneID dns.ZoneID) {
+	if err := os.Remove(s.filename(zoneID)); err != nil && !os.IsNotExist(err) {
+		s.logger.Warnf("cannot delete persisted zone state of %s: %s", zoneID, err)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Zone state store", func() {
+	var (
+		store   *zoneStateStore
+		zone    = NewDNSHostedZone("test", "project/zone1", "example.com", "", nil, false)
+		dnssets dns.DNSSets
+	)
+
+	ginkgov2.BeforeEach(func() {
+		var err error
+		store, err = newZoneStateStore(logger.NewContext("", "test"), ginkgov2.GinkgoT().TempDir())
+		Ω(err).ShouldNot(HaveOccurred())
+		dnssets = dns.DNSSets{}
+		dnssets.AddRecordSetFromProvider("a.example.com", dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.2.3.4"}}))
+	})
+
+	ginkgov2.It("restores saved zone states", func() {
+		timestamp := time.Now().Add(-time.Minute).Truncate(time.Second)
+		store.Save(zone.Id(), dnssets, timestamp)
+
+		loaded, ts, err := store.Load(zone.Id())
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(ts.Equal(timestamp)).Should(BeTrue())
+		Ω(loaded).Should(Equal(dnssets))
+
+		store.Delete(zone.Id())
+		loaded, _, err = store.Load(zone.Id())
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(loaded).Should(BeNil())
+	})
+
+	ginkgov2.It("reuses persisted zone states within the ttl", func() {
+		reads := 0
+		states := newZoneStates(func(dns.ZoneID, time.Duration) time.Duration { return 10 * time.Minute })
+		states.store = store
+		cache := &defaultZoneCache{
+			abstractZonesCache: abstractZonesCache{stateUpdater: func(zone DNSHostedZone, cache ZoneCache) (DNSZoneState, error) {
+				reads++
+				return NewDNSZoneState(dns.DNSSets{}), nil
+			}},
+			metrics:    &NullMetrics{},
+			zoneStates: states,
+		}
+
+		store.Save(zone.Id(), dnssets, time.Now().Add(-time.Minute))
+		state, cached, err := states.GetZoneState(zone, cache)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cached).Should(BeTrue())
+		Ω(reads).Should(Equal(0))
+		Ω(state.GetDNSSets()).Should(Equal(dnssets))
+
+		states.CleanZoneState(zone.Id())
+		store.Save(zone.Id(), dnssets, time.Now().Add(-time.Hour))
+		state, cached, err = states.GetZoneState(zone, cache)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cached).Should(BeFalse())
+		Ω(reads).Should(Equal(1))
+		Ω(state.GetDNSSets()).Should(BeEmpty())
+
+		loaded, _, err := store.Load(zone.Id())
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(loaded).Should(BeEmpty())
+	})
+})
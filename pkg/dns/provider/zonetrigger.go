@@ -0,0 +1,53 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"sync"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// zoneTriggers keeps the hosted zones with a scheduled, but not yet started reconciliation,
+// so that further triggers within the debounce window are coalesced into this reconciliation.
+type zoneTriggers struct {
+	lock    sync.Mutex
+	pending map[dns.ZoneID]struct{}
+}
+
+func newZoneTriggers() *zoneTriggers {
+	return &zoneTriggers{pending: map[dns.ZoneID]struct{}{}}
+}
+
+// Add returns true if no reconciliation is scheduled for the hosted zone yet.
+func (this *zoneTriggers) Add(zoneid dns.ZoneID) bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if _, ok := this.pending[zoneid]; ok {
+		return false
+	}
+	this.pending[zoneid] = struct{}{}
+	return true
+}
+
+// Started removes the scheduled trigger of the hosted zone at the start of its reconciliation,
+// later triggers need a new reconciliation.
+func (this *zoneTriggers) Started(zoneid dns.ZoneID) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.pending, zoneid)
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Zone triggers", func() {
+	ginkgov2.It("coalesces triggers until the reconciliation starts", func() {
+		triggers := newZoneTriggers()
+		zone1 := dns.NewZoneID("aws-route53", "Z1")
+		zone2 := dns.NewZoneID("aws-route53", "Z2")
+
+		Ω(triggers.Add(zone1)).Should(BeTrue())
+		Ω(triggers.Add(zone1)).Should(BeFalse())
+		Ω(triggers.Add(zone2)).Should(BeTrue())
+
+		triggers.Started(zone1)
+		Ω(triggers.Add(zone1)).Should(BeTrue())
+		Ω(triggers.Add(zone2)).Should(BeFalse())
+	})
+})
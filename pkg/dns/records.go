@@ -28,6 +28,7 @@ const RS_TXT = "TXT"
 const RS_CNAME = "CNAME"
 const RS_A = "A"
 const RS_AAAA = "AAAA"
+const RS_PTR = "PTR"
 
 const RS_NS = "NS"
 
@@ -24,6 +24,14 @@ const (
 	RoutingPolicyWeighted = "weighted"
 )
 
+const (
+	// RoutingPolicyParamHealthCheck is the routing policy parameter referencing a DNSHealthCheck object,
+	// it is replaced by the parameter RoutingPolicyParamHealthCheckID before passing the policy to the provider.
+	RoutingPolicyParamHealthCheck = "healthCheck"
+	// RoutingPolicyParamHealthCheckID is the routing policy parameter for the provider specific id of a health check.
+	RoutingPolicyParamHealthCheckID = "healthCheckID"
+)
+
 type RoutingPolicy struct {
 	Type       string
 	Parameters map[string]string
@@ -48,16 +56,19 @@ func (p *RoutingPolicy) Clone() *RoutingPolicy {
 	return copy
 }
 
-func (p *RoutingPolicy) CheckParameterKeys(keys []string) error {
+// CheckParameterKeys checks that all given keys and no other keys than the given keys
+// and the optional keys are set.
+func (p *RoutingPolicy) CheckParameterKeys(keys []string, optional ...string) error {
 	for _, k := range keys {
 		if _, ok := p.Parameters[k]; !ok {
 			return fmt.Errorf("Missing parameter key %s", k)
 		}
 	}
 	if len(keys) != len(p.Parameters) {
+		allowed := append(append([]string{}, keys...), optional...)
 	outer:
 		for k := range p.Parameters {
-			for _, k2 := range keys {
+			for _, k2 := range allowed {
 				if k == k2 {
 					continue outer
 				}
@@ -31,6 +31,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile/reconcilers"
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
@@ -39,10 +40,23 @@ import (
 const CONTROLLER_GROUP_DNS_SOURCES = dns.CONTROLLER_GROUP_DNS_SOURCES
 const TARGET_CLUSTER = "target"
 
+// MAX_SOURCE_CLUSTERS is the number of additional source clusters which can be
+// watched by the source controllers besides the default cluster.
+const MAX_SOURCE_CLUSTERS = 4
+
+// SourceClusters are the names of the additional source clusters. A cluster
+// is only watched if it is configured with an own kubeconfig.
+var SourceClusters []string
+
 const DNS_ANNOTATION = dns.ANNOTATION_GROUP + "/dnsnames"
 const TTL_ANNOTATION = dns.ANNOTATION_GROUP + "/ttl"
 const PERIOD_ANNOTATION = dns.ANNOTATION_GROUP + "/cname-lookup-interval"
 const ROUTING_POLICY_ANNOTATION = dns.ANNOTATION_GROUP + "/routing-policy"
+const PROVIDER_ANNOTATION = dns.ANNOTATION_GROUP + "/provider"
+const ZONE_ANNOTATION = dns.ANNOTATION_GROUP + "/zone"
+const OWNER_ID_ANNOTATION = dns.ANNOTATION_GROUP + "/owner-id"
+const RESOLVE_TARGETS_ANNOTATION = dns.ANNOTATION_GROUP + "/resolve-targets-to-addresses"
+const PREFER_INTERNAL_ANNOTATION = dns.ANNOTATION_GROUP + "/prefer-internal-addresses"
 const CLASS_ANNOTATION = dns.CLASS_ANNOTATION
 
 const OPT_CLASS = "dns-class"
@@ -57,6 +71,10 @@ const OPT_TARGET_OWNER_ID = "target-owner-id"
 const OPT_TARGET_OWNER_OBJECT = "target-owner-object"
 const OPT_TARGET_SET_IGNORE_OWNERS = "target-set-ignore-owners"
 const OPT_TARGET_REALMS = "target-realms"
+const OPT_PREFER_INTERNAL = "prefer-internal-addresses"
+const OPT_DEFAULT_IP_STACK = "default-ip-stack"
+const OPT_LABEL_SELECTOR = "label-selector"
+const OPT_NAMESPACE_SELECTOR = "namespace-selector"
 
 var entryGroupKind = resources.NewGroupKind(api.GroupName, api.DNSEntryKind)
 var ownerGroupKind = resources.NewGroupKind(api.GroupName, api.DNSOwnerKind)
@@ -65,13 +83,18 @@ const KEY_STATE = "source-state"
 
 func init() {
 	cluster.Register(TARGET_CLUSTER, "target", "target cluster for dns requests")
+	for i := 1; i <= MAX_SOURCE_CLUSTERS; i++ {
+		name := fmt.Sprintf("source%d", i)
+		cluster.Register(name, name, fmt.Sprintf("additional source cluster %d for source objects", i))
+		SourceClusters = append(SourceClusters, name)
+	}
 
 	crds.AddToRegistry(apiextensions.DefaultRegistry())
 }
 
 func DNSSourceController(source DNSSourceType, reconcilerType controller.ReconcilerType) controller.Configuration {
 	gk := source.GroupKind()
-	return controller.Configure(source.Name()).
+	cfg := controller.Configure(source.Name()).
 		After(annotation.CONTROLLER).
 		RequireLease().
 		DefaultedStringOption(OPT_CLASS, dns.DEFAULT_CLASS, "identifier used to differentiate responsible controllers for entries").
@@ -86,6 +109,10 @@ func DNSSourceController(source DNSSourceType, reconcilerType controller.Reconci
 		StringOption(OPT_TARGET_OWNER_OBJECT, "owner object to use for generated DNS entries").
 		BoolOption(OPT_TARGET_SET_IGNORE_OWNERS, "mark generated DNS entries to omit owner based access control").
 		StringOption(OPT_TARGET_REALMS, "realm(s) to use for generated DNS entries").
+		BoolOption(OPT_PREFER_INTERNAL, "prefer internal load balancer or node addresses as targets (can be overwritten per object by annotation)").
+		StringOption(OPT_DEFAULT_IP_STACK, "default address families of load balancer or node addresses used as targets (ipv4, ipv6 or dual-stack, can be overwritten per object by annotation)").
+		StringOption(OPT_LABEL_SELECTOR, "label selector restricting the handled source objects").
+		StringOption(OPT_NAMESPACE_SELECTOR, "label selector restricting the namespaces of the handled source objects").
 		FinalizerDomain(api.GroupName).
 		Reconciler(SourceReconciler(source, reconcilerType)).
 		Cluster(cluster.DEFAULT). // first one used as MAIN cluster
@@ -93,7 +120,30 @@ func DNSSourceController(source DNSSourceType, reconcilerType controller.Reconci
 		MainResource(gk.Group, gk.Kind).
 		Reconciler(reconcilers.SlaveReconcilerTypeByFunction(SlaveReconcilerType, SlaveAccessSpecCreatorForSource(source)), "entries").
 		Reconciler(OwnerReconciler, "owner").
-		Cluster(TARGET_CLUSTER, cluster.DEFAULT).
+		Reconciler(NamespaceReconciler(source), "namespaces").
+		FlavoredReconcilerWatch("namespaces",
+			watches.Conditional(
+				OptionIsSet(OPT_NAMESPACE_SELECTOR),
+				watches.ResourceFlavorByGK(namespaceGroupKind),
+			),
+		)
+	for _, name := range SourceClusters {
+		cfg = cfg.Cluster(name).
+			FlavoredWatch(
+				watches.Conditional(
+					IsAdditionalSourceCluster(),
+					watches.ResourceFlavorByGK(gk),
+				),
+			).
+			FlavoredReconcilerWatch("namespaces",
+				watches.Conditional(
+					watches.And(IsAdditionalSourceCluster(), OptionIsSet(OPT_NAMESPACE_SELECTOR)),
+					watches.ResourceFlavorByGK(namespaceGroupKind),
+				),
+			)
+	}
+	return cfg.
+		Cluster(TARGET_CLUSTER, append([]string{cluster.DEFAULT}, SourceClusters...)...).
 		CustomResourceDefinitions(entryGroupKind).
 		WorkerPool("targets", 2, 0).
 		ReconcilerSelectedWatchesByGK("entries", controller.NamespaceByOptionSelection(OPT_NAMESPACE), entryGroupKind).
@@ -109,15 +159,49 @@ var SlaveResources = reconcilers.ClusterResources(TARGET_CLUSTER, entryGroupKind
 
 func MasterResourcesType(kind schema.GroupKind) reconcilers.Resources {
 	return func(c controller.Interface) []resources.Interface {
-		target := c.GetMainCluster()
-		res, err := target.Resources().GetByGK(kind)
-		if err != nil {
-			panic(err)
+		var result []resources.Interface
+		for _, source := range GetSourceClusters(c) {
+			res, err := source.Resources().GetByGK(kind)
+			if err != nil {
+				panic(err)
+			}
+			result = append(result, res)
 		}
-		return []resources.Interface{res}
+		return result
 	}
 }
 
+// GetSourceClusters returns the main cluster and all additional source clusters
+// configured with an own kubeconfig.
+func GetSourceClusters(c controller.Interface) []cluster.Interface {
+	main := c.GetMainCluster()
+	result := []cluster.Interface{main}
+	ids := utils.NewStringSet(main.GetId())
+	for _, name := range SourceClusters {
+		if source := c.GetCluster(name); source != nil && !ids.Contains(source.GetId()) {
+			ids.Add(source.GetId())
+			result = append(result, source)
+		}
+	}
+	return result
+}
+
+func isAdditionalSourceCluster(main, source cluster.Interface) bool {
+	return source != main && source.GetId() != main.GetId()
+}
+
+// IsAdditionalSourceCluster checks whether the cluster of a watch is an additional
+// source cluster differing from the main cluster.
+func IsAdditionalSourceCluster() watches.WatchConstraint {
+	return watches.NewFunctionWatchConstraint(
+		func(wctx watches.WatchContext) bool {
+			main := wctx.GetCluster(controller.CLUSTER_MAIN)
+			return main != nil && wctx.Cluster() != nil && isAdditionalSourceCluster(main, wctx.Cluster())
+		},
+		"additional source cluster",
+	)
+}
+
 func SlaveAccessSpecCreatorForSource(sourceType DNSSourceType) reconcilers.SlaveAccessSpecCreator {
 	return func(c controller.Interface) reconcilers.SlaveAccessSpec {
 		return NewSlaveAccessSpec(c, sourceType)
@@ -53,10 +53,23 @@ func (this *sourceReconciler) getDNSInfo(logger logger.LogContext, obj resources
 	if !this.classes.IsResponsibleFor(logger, obj) {
 		return nil, false, nil
 	}
+	if ok, err := this.selection.Matches(obj); !ok {
+		if err != nil {
+			return nil, true, err
+		}
+		logger.Debugf("not selected by %s", this.selection)
+		return nil, false, nil
+	}
 
 	annos := obj.GetAnnotations()
 	current.AnnotatedNames = utils.StringSet{}
-	current.AnnotatedNames.AddAllSplittedSelected(annos[DNS_ANNOTATION], utils.StandardNonEmptyStringElement)
+	for name := range utils.NewStringSet().AddAllSplittedSelected(annos[DNS_ANNOTATION], utils.StandardNonEmptyStringElement) {
+		expanded, err := expandDNSNameTemplate(obj, name)
+		if err != nil {
+			return nil, true, err
+		}
+		current.AnnotatedNames.Add(expanded)
+	}
 	current.AnnotatedRoutingPolicy = nil
 	if a := annos[ROUTING_POLICY_ANNOTATION]; a != "" {
 		policy := &v1alpha1.RoutingPolicy{}
@@ -65,6 +78,14 @@ func (this *sourceReconciler) getDNSInfo(logger logger.LogContext, obj resources
 		}
 		current.AnnotatedRoutingPolicy = policy
 	}
+	current.PreferInternalAddresses = this.preferInternal
+	if a := annos[PREFER_INTERNAL_ANNOTATION]; a != "" {
+		prefer, err := strconv.ParseBool(a)
+		if err != nil {
+			return nil, true, fmt.Errorf("invalid value for %s: %s", PREFER_INTERNAL_ANNOTATION, err)
+		}
+		current.PreferInternalAddresses = prefer
+	}
 
 	info, err := s.GetDNSInfo(logger, obj, current)
 	if info != nil && info.Names != nil {
@@ -104,14 +125,68 @@ func (this *sourceReconciler) getDNSInfo(logger logger.LogContext, obj resources
 			}
 		}
 	}
+	if info.Resolve == nil {
+		a := annos[RESOLVE_TARGETS_ANNOTATION]
+		if a != "" {
+			resolve, err := strconv.ParseBool(a)
+			if err != nil {
+				return info, true, fmt.Errorf("invalid value for %s: %s", RESOLVE_TARGETS_ANNOTATION, err)
+			}
+			info.Resolve = &resolve
+		}
+	}
 	if info.RoutingPolicy == nil {
 		info.RoutingPolicy = current.AnnotatedRoutingPolicy
 	}
+	if info.IPStack == "" {
+		stack, err := dns.ParseIPStack(annos[dns.IP_STACK_ANNOTATION])
+		if err != nil {
+			return info, true, err
+		}
+		if stack == "" {
+			stack = this.ipStack
+		}
+		info.IPStack = stack
+	}
+	for t := range info.Targets {
+		if !info.IPStack.AcceptsAddress(t) {
+			info.Targets.Remove(t)
+		}
+	}
+	if info.IPStack == dns.IP_STACK_DUAL_STACK {
+		if missing := dns.MissingAddressFamily(info.Targets); missing != "" {
+			logger.Infof("dual-stack requested, but no %s address available", missing)
+		}
+	}
+	if !info.DryRun {
+		if a := annos[dns.DRY_RUN_ANNOTATION]; a != "" {
+			dryrun, err := strconv.ParseBool(a)
+			if err != nil {
+				return info, true, fmt.Errorf("invalid value for %s: %s", dns.DRY_RUN_ANNOTATION, err)
+			}
+			info.DryRun = dryrun
+		}
+	}
+	if info.Provider == nil {
+		if a := strings.TrimSpace(annos[PROVIDER_ANNOTATION]); a != "" {
+			info.Provider = &a
+		}
+	}
+	if info.Zone == nil {
+		if a := strings.TrimSpace(annos[ZONE_ANNOTATION]); a != "" {
+			info.Zone = &a
+		}
+	}
+	if info.OwnerId == nil {
+		if a := strings.TrimSpace(annos[OWNER_ID_ANNOTATION]); a != "" {
+			info.OwnerId = &a
+		}
+	}
 	return info, true, nil
 }
 
 func (this *sourceReconciler) enrichAnnotations(logger logger.LogContext, obj resources.Object) resources.Object {
-	addons := this.annotations.GetInfoFor(obj.ClusterKey())
+	addons := this.annotations.GetInfoForObject(obj)
 	if len(addons) > 0 {
 		obj = obj.DeepCopy()
 		annos := obj.GetAnnotations()
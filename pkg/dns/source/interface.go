@@ -33,11 +33,17 @@ type DNSInfo struct {
 	Names         dns.DNSNameSet
 	TTL           *int64
 	Interval      *int64
+	Resolve       *bool
 	Targets       utils.StringSet
 	Text          utils.StringSet
 	OrigRef       *v1alpha1.EntryReference
 	TargetRef     *v1alpha1.EntryReference
 	RoutingPolicy *v1alpha1.RoutingPolicy
+	Provider      *string
+	Zone          *string
+	OwnerId       *string
+	IPStack       dns.IPStack
+	DryRun        bool
 }
 
 type DNSFeedback interface {
@@ -79,6 +85,8 @@ type DNSCurrentState struct {
 	Targets                utils.StringSet
 	AnnotatedNames         utils.StringSet
 	AnnotatedRoutingPolicy *v1alpha1.RoutingPolicy
+	// PreferInternalAddresses indicates to prefer internal load balancer or node addresses as targets
+	PreferInternalAddresses bool
 }
 
 func (s *DNSCurrentState) GetSetIdentifier() string {
@@ -62,6 +62,11 @@ func SourceReconciler(sourceType DNSSourceType, rtype controller.ReconcilerType)
 		c.SetFinalizerHandler(controller.NewFinalizerForClasses(c, c.GetDefinition().FinalizerName(), classes))
 		targetclasses := controller.NewTargetClassesByOption(c, OPT_TARGET_CLASS, dns.CLASS_ANNOTATION, classes)
 		slaves := reconcilers.NewSlaveAccessBySpec(c, NewSlaveAccessSpec(c, sourceType))
+		selection, err := NewSourceSelectionByOptions(c)
+		if err != nil {
+			return nil, err
+		}
+		c.Infof("source selection: %s", selection)
 		ownerState, err := getOrCreateSharedOwnerState(c, false)
 		if err != nil {
 			return nil, err
@@ -70,6 +75,7 @@ func SourceReconciler(sourceType DNSSourceType, rtype controller.ReconcilerType)
 		reconciler := &sourceReconciler{
 			SlaveAccess:   slaves,
 			classes:       classes,
+			selection:     selection,
 			targetclasses: targetclasses,
 			targetrealms:  realms,
 
@@ -87,6 +93,12 @@ func SourceReconciler(sourceType DNSSourceType, rtype controller.ReconcilerType)
 		reconciler.creatorLabelName, _ = c.GetStringOption(OPT_TARGET_CREATOR_LABEL_NAME)
 		reconciler.creatorLabelValue, _ = c.GetStringOption(OPT_TARGET_CREATOR_LABEL_VALUE)
 		reconciler.setIgnoreOwners, _ = c.GetBoolOption(OPT_TARGET_SET_IGNORE_OWNERS)
+		reconciler.preferInternal, _ = c.GetBoolOption(OPT_PREFER_INTERNAL)
+		stack, _ := c.GetStringOption(OPT_DEFAULT_IP_STACK)
+		reconciler.ipStack, err = dns.ParseIPStack(stack)
+		if err != nil {
+			return nil, fmt.Errorf("invalid option %s: %w", OPT_DEFAULT_IP_STACK, err)
+		}
 
 		excluded, _ := c.GetStringArrayOption(OPT_EXCLUDE)
 		reconciler.excluded = utils.NewStringSetByArray(excluded)
@@ -111,6 +123,7 @@ type sourceReconciler struct {
 	*reconcilers.SlaveAccess
 	excluded          utils.StringSet
 	classes           *controller.Classes
+	selection         *SourceSelection
 	targetclasses     *controller.Classes
 	targetrealms      *access.Realms
 	namespace         string
@@ -118,6 +131,8 @@ type sourceReconciler struct {
 	creatorLabelName  string
 	creatorLabelValue string
 	setIgnoreOwners   bool
+	preferInternal    bool
+	ipStack           dns.IPStack
 
 	state       *state
 	annotations *annotations.State
@@ -170,7 +185,7 @@ func (this *sourceReconciler) Reconcile(logger logger.LogContext, obj resources.
 	if info == nil {
 		if responsible {
 			logger.Debugf("no dns info found")
-			err2 := this.annotations.SetActive(obj.ClusterKey(), false)
+			err2 := this.annotations.SetActiveForObject(obj, false)
 			if err2 != nil {
 				err = err2
 			}
@@ -181,7 +196,7 @@ func (this *sourceReconciler) Reconcile(logger logger.LogContext, obj resources.
 		return reconcile.Succeeded(logger).Stop()
 	} else {
 		// if not responsible now  it was responsible, therefore cleanup the active state
-		err = this.annotations.SetActive(obj.ClusterKey(), responsible)
+		err = this.annotations.SetActiveForObject(obj, responsible)
 		if err != nil {
 			return reconcile.Delay(logger, err)
 		}
@@ -425,9 +440,16 @@ func (this *sourceReconciler) createEntryFor(logger logger.LogContext, obj resou
 	if this.creatorLabelName != "" && this.creatorLabelValue != "" {
 		resources.SetLabel(entry, this.creatorLabelName, this.creatorLabelValue)
 	}
-	if this.state.ownerState.ownerId != "" {
-		entry.Spec.OwnerId = &this.state.ownerState.ownerId
+	if info.IPStack != "" {
+		resources.SetAnnotation(entry, dns.IP_STACK_ANNOTATION, string(info.IPStack))
+	}
+	if info.DryRun {
+		resources.SetAnnotation(entry, dns.DRY_RUN_ANNOTATION, "true")
 	}
+	if cluster := this.sourceClusterOf(obj); cluster != "" {
+		resources.SetAnnotation(entry, dns.SOURCE_CLUSTER_ANNOTATION, cluster)
+	}
+	entry.Spec.OwnerId = this.ownerIdFor(info)
 	entry.Spec.DNSName = name.DNSName
 	this.mapRef(obj, info)
 	if info.TargetRef != nil {
@@ -451,6 +473,10 @@ func (this *sourceReconciler) createEntryFor(logger logger.LogContext, obj resou
 	}
 	entry.Spec.TTL = info.TTL
 	entry.Spec.RoutingPolicy = info.RoutingPolicy
+	entry.Spec.Provider = info.Provider
+	entry.Spec.Zone = info.Zone
+	entry.Spec.CNameLookupInterval = info.Interval
+	entry.Spec.ResolveTargetsToAddresses = info.Resolve
 
 	e, _ := this.SlaveResoures()[0].Wrap(entry)
 
@@ -506,17 +532,37 @@ func (this *sourceReconciler) updateEntryFor(logger logger.LogContext, obj resou
 			}
 			mod.Modify(changed)
 		}
-		var p *string
-		if this.state.ownerState.ownerId != "" {
-			p = &this.state.ownerState.ownerId
+		if info.IPStack != "" {
+			changed = resources.SetAnnotation(o, dns.IP_STACK_ANNOTATION, string(info.IPStack))
+		} else {
+			changed = resources.RemoveAnnotation(o, dns.IP_STACK_ANNOTATION)
 		}
-		mod.AssureStringPtrPtr(&spec.OwnerId, p)
+		mod.Modify(changed)
+		if info.DryRun {
+			changed = resources.SetAnnotation(o, dns.DRY_RUN_ANNOTATION, "true")
+		} else {
+			changed = resources.RemoveAnnotation(o, dns.DRY_RUN_ANNOTATION)
+		}
+		mod.Modify(changed)
+		if cluster := this.sourceClusterOf(obj); cluster != "" {
+			changed = resources.SetAnnotation(o, dns.SOURCE_CLUSTER_ANNOTATION, cluster)
+		} else {
+			changed = resources.RemoveAnnotation(o, dns.SOURCE_CLUSTER_ANNOTATION)
+		}
+		mod.Modify(changed)
+		mod.AssureStringPtrPtr(&spec.OwnerId, this.ownerIdFor(info))
 		mod.AssureInt64PtrPtr(&spec.TTL, info.TTL)
 		if !reflect.DeepEqual(spec.RoutingPolicy, info.RoutingPolicy) {
 			spec.RoutingPolicy = info.RoutingPolicy
 			mod.Modify(true)
 		}
 		mod.AssureInt64PtrPtr(&spec.CNameLookupInterval, info.Interval)
+		mod.AssureStringPtrPtr(&spec.Provider, info.Provider)
+		mod.AssureStringPtrPtr(&spec.Zone, info.Zone)
+		if !reflect.DeepEqual(spec.ResolveTargetsToAddresses, info.Resolve) {
+			spec.ResolveTargetsToAddresses = info.Resolve
+			mod.Modify(true)
+		}
 		targets := info.Targets
 		text := info.Text
 
@@ -563,3 +609,24 @@ func (this *sourceReconciler) deleteEntry(logger logger.LogContext, e resources.
 	}
 	return err
 }
+
+// ownerIdFor returns the owner id for generated entries. An owner id
+// given by annotation overwrites the one configured for the controller.
+// sourceClusterOf returns the id of the cluster of a source object if it is an additional source cluster.
+func (this *sourceReconciler) sourceClusterOf(obj resources.Object) string {
+	main := this.GetMainCluster()
+	if obj.GetCluster().GetId() == main.GetId() {
+		return ""
+	}
+	return obj.GetCluster().GetId()
+}
+
+func (this *sourceReconciler) ownerIdFor(info *DNSInfo) *string {
+	if info.OwnerId != nil {
+		return info.OwnerId
+	}
+	if this.state.ownerState.ownerId != "" {
+		return &this.state.ownerState.ownerId
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package source
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var namespaceGroupKind = resources.NewGroupKind("", "Namespace")
+
+// SourceSelection restricts the source objects handled by a source controller
+// by a label selector for the objects and a label selector for their namespaces.
+type SourceSelection struct {
+	objects    labels.Selector
+	namespaces labels.Selector
+}
+
+// NewSourceSelection creates a source selection for the given label selectors.
+// An empty selector matches all objects or namespaces.
+func NewSourceSelection(objectSelector, namespaceSelector string) (*SourceSelection, error) {
+	selection := &SourceSelection{}
+	if objectSelector != "" {
+		sel, err := labels.Parse(objectSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector %q: %s", objectSelector, err)
+		}
+		selection.objects = sel
+	}
+	if namespaceSelector != "" {
+		sel, err := labels.Parse(namespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace selector %q: %s", namespaceSelector, err)
+		}
+		selection.namespaces = sel
+	}
+	return selection, nil
+}
+
+// IsDefault returns true if all source objects are selected.
+func (this *SourceSelection) IsDefault() bool {
+	return this.objects == nil && this.namespaces == nil
+}
+
+func (this *SourceSelection) String() string {
+	if this.IsDefault() {
+		return "all objects"
+	}
+	s := ""
+	if this.objects != nil {
+		s = fmt.Sprintf("objects %q", this.objects.String())
+	}
+	if this.namespaces != nil {
+		if s != "" {
+			s += ", "
+		}
+		s += fmt.Sprintf("namespaces %q", this.namespaces.String())
+	}
+	return s
+}
+
+// MatchesLabels checks the labels of a source object and of its namespace.
+func (this *SourceSelection) MatchesLabels(objectLabels, namespaceLabels map[string]string) bool {
+	if this.objects != nil && !this.objects.Matches(labels.Set(objectLabels)) {
+		return false
+	}
+	if this.namespaces != nil && !this.namespaces.Matches(labels.Set(namespaceLabels)) {
+		return false
+	}
+	return true
+}
+
+// Matches checks whether a source object is selected. The namespace of the
+// object is taken from the cache of the cluster of the object.
+func (this *SourceSelection) Matches(obj resources.Object) (bool, error) {
+	if this.IsDefault() {
+		return true, nil
+	}
+	var nslabels map[string]string
+	if this.namespaces != nil && obj.GetNamespace() != "" {
+		res, err := obj.GetCluster().Resources().GetByGK(namespaceGroupKind)
+		if err != nil {
+			return false, err
+		}
+		ns, err := res.GetCached(resources.NewObjectName(obj.GetNamespace()))
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		nslabels = ns.GetLabels()
+	}
+	return this.MatchesLabels(obj.GetLabels(), nslabels), nil
+}
+
+func NewSourceSelectionByOptions(c controller.Interface) (*SourceSelection, error) {
+	objectSelector, _ := c.GetStringOption(OPT_LABEL_SELECTOR)
+	namespaceSelector, _ := c.GetStringOption(OPT_NAMESPACE_SELECTOR)
+	return NewSourceSelection(objectSelector, namespaceSelector)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// NamespaceReconciler triggers the source objects of a namespace if the labels
+// of the namespace are changed, to reevaluate the namespace selector.
+func NamespaceReconciler(sourceType DNSSourceType) controller.ReconcilerType {
+	return func(c controller.Interface) (reconcile.Interface, error) {
+		return &namespaceReconciler{controller: c, kind: sourceType.GroupKind(), labels: map[resources.ClusterObjectKey]labels.Set{}}, nil
+	}
+}
+
+var _ reconcile.Interface = &namespaceReconciler{}
+
+type namespaceReconciler struct {
+	reconcile.DefaultReconciler
+
+	lock       sync.Mutex
+	controller controller.Interface
+	kind       schema.GroupKind
+	labels     map[resources.ClusterObjectKey]labels.Set
+}
+
+func (r *namespaceReconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	current := labels.Set(obj.GetLabels())
+	r.lock.Lock()
+	old, ok := r.labels[obj.ClusterKey()]
+	r.labels[obj.ClusterKey()] = current
+	r.lock.Unlock()
+
+	// source objects are reconciled anyway for initially found namespaces
+	if ok && !labels.Equals(old, current) {
+		r.triggerSources(logger, obj.GetCluster(), obj.GetName())
+	}
+	return reconcile.Succeeded(logger)
+}
+
+func (r *namespaceReconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.labels, key)
+	return reconcile.Succeeded(logger)
+}
+
+func (r *namespaceReconciler) triggerSources(logger logger.LogContext, cluster resources.Cluster, namespace string) {
+	res, err := cluster.Resources().GetByGK(r.kind)
+	if err != nil {
+		logger.Warnf("cannot get resource %s: %s", r.kind, err)
+		return
+	}
+	list, err := res.Namespace(namespace).ListCached(labels.Everything())
+	if err != nil {
+		logger.Warnf("cannot list %s in namespace %s: %s", r.kind, namespace, err)
+		return
+	}
+	if len(list) > 0 {
+		logger.Infof("labels of namespace changed: trigger %d %s object(s)", len(list), r.kind.Kind)
+	}
+	for _, o := range list {
+		r.controller.EnqueueKey(o.ClusterKey())
+	}
+}
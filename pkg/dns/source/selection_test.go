@@ -0,0 +1,141 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package source
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+)
+
+type selectionObject struct {
+	resources.Object
+	namespace string
+	labels    map[string]string
+}
+
+func (this selectionObject) GetNamespace() string {
+	return this.namespace
+}
+
+func (this selectionObject) GetLabels() map[string]string {
+	return this.labels
+}
+
+func TestNewSourceSelection(t *testing.T) {
+	table := []struct {
+		objects    string
+		namespaces string
+		expected   string
+		err        string
+	}{
+		{"", "", "all objects", ""},
+		{"dns=enabled", "", `objects "dns=enabled"`, ""},
+		{"", "team in (a,b)", `namespaces "team in (a,b)"`, ""},
+		{"dns=enabled", "!private", `objects "dns=enabled", namespaces "!private"`, ""},
+		{"=enabled", "", "", `invalid label selector "=enabled"`},
+		{"", "team in (a", "", `invalid namespace selector "team in (a"`},
+	}
+	for _, entry := range table {
+		selection, err := NewSourceSelection(entry.objects, entry.namespaces)
+		if entry.err != "" {
+			if err == nil || !strings.HasPrefix(err.Error(), entry.err) {
+				t.Errorf("%q/%q: expected error %q, got %v", entry.objects, entry.namespaces, entry.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q/%q: unexpected error: %s", entry.objects, entry.namespaces, err)
+		} else if selection.String() != entry.expected {
+			t.Errorf("%q/%q: expected %q, got %q", entry.objects, entry.namespaces, entry.expected, selection.String())
+		}
+	}
+}
+
+func TestSourceSelectionMatchesLabels(t *testing.T) {
+	table := []struct {
+		objects         string
+		namespaces      string
+		objectLabels    map[string]string
+		namespaceLabels map[string]string
+		expected        bool
+	}{
+		{"", "", nil, nil, true},
+		{"", "", map[string]string{"dns": "disabled"}, map[string]string{"team": "c"}, true},
+		{"dns=enabled", "", map[string]string{"dns": "enabled"}, nil, true},
+		{"dns=enabled", "", map[string]string{"dns": "disabled"}, nil, false},
+		{"dns=enabled", "", nil, map[string]string{"dns": "enabled"}, false},
+		{"", "team in (a,b)", nil, map[string]string{"team": "b"}, true},
+		{"", "team in (a,b)", nil, map[string]string{"team": "c"}, false},
+		{"", "team in (a,b)", nil, nil, false},
+		// exclusions
+		{"dns!=disabled", "", nil, nil, true},
+		{"dns!=disabled", "", map[string]string{"dns": "disabled"}, nil, false},
+		{"", "!private", nil, map[string]string{"team": "a"}, true},
+		{"", "!private", nil, map[string]string{"private": "true"}, false},
+		{"", "team notin (c)", nil, map[string]string{"team": "c"}, false},
+		// both selectors must match
+		{"dns=enabled", "team=a", map[string]string{"dns": "enabled"}, map[string]string{"team": "a"}, true},
+		{"dns=enabled", "team=a", map[string]string{"dns": "enabled"}, map[string]string{"team": "b"}, false},
+		{"dns=enabled", "team=a", map[string]string{"dns": "disabled"}, map[string]string{"team": "a"}, false},
+	}
+	for _, entry := range table {
+		selection, err := NewSourceSelection(entry.objects, entry.namespaces)
+		if err != nil {
+			t.Errorf("%q/%q: unexpected error: %s", entry.objects, entry.namespaces, err)
+			continue
+		}
+		if result := selection.MatchesLabels(entry.objectLabels, entry.namespaceLabels); result != entry.expected {
+			t.Errorf("%q/%q: labels %v/%v: expected %t, got %t", entry.objects, entry.namespaces,
+				entry.objectLabels, entry.namespaceLabels, entry.expected, result)
+		}
+	}
+}
+
+func TestSourceSelectionMatches(t *testing.T) {
+	table := []struct {
+		objects    string
+		namespaces string
+		object     selectionObject
+		expected   bool
+	}{
+		// without selector all objects are selected
+		{"", "", selectionObject{namespace: "default"}, true},
+		{"", "", selectionObject{namespace: "default", labels: map[string]string{"dns": "disabled"}}, true},
+		// the namespace is not looked up without namespace selector
+		{"dns=enabled", "", selectionObject{namespace: "default", labels: map[string]string{"dns": "enabled"}}, true},
+		{"dns=enabled", "", selectionObject{namespace: "default"}, false},
+		// cluster scoped objects have no namespace labels
+		{"", "team=a", selectionObject{}, false},
+		{"", "!private", selectionObject{}, true},
+	}
+	for _, entry := range table {
+		selection, err := NewSourceSelection(entry.objects, entry.namespaces)
+		if err != nil {
+			t.Errorf("%q/%q: unexpected error: %s", entry.objects, entry.namespaces, err)
+			continue
+		}
+		result, err := selection.Matches(entry.object)
+		if err != nil {
+			t.Errorf("%q/%q: unexpected error: %s", entry.objects, entry.namespaces, err)
+		} else if result != entry.expected {
+			t.Errorf("%q/%q: object %s %v: expected %t, got %t", entry.objects, entry.namespaces,
+				entry.object.namespace, entry.object.labels, entry.expected, result)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package source
 import (
 	"fmt"
 
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile/reconcilers"
@@ -63,10 +64,12 @@ func (this *slaveReconciler) Setup() error {
 
 func (this *slaveReconciler) Start() {
 	this.controller.Infof("determining dangling dns entries...")
-	cluster := this.controller.GetMainCluster()
-	main := cluster.GetId()
+	sources := map[string]cluster.Interface{}
+	for _, c := range GetSourceClusters(this.controller) {
+		sources[c.GetId()] = c
+	}
 	for k := range this.slaves.GetMasters(false) {
-		if k.Cluster() == main {
+		if cluster := sources[k.Cluster()]; cluster != nil {
 			if _, err := cluster.GetCachedObject(k); errors.IsNotFound(err) {
 				this.controller.Infof("trigger vanished origin %s", k.ObjectKey())
 				this.controller.EnqueueKey(k)
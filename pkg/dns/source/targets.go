@@ -0,0 +1,85 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package source
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	core "k8s.io/api/core/v1"
+)
+
+// LoadBalancerTargets determines the targets from the load balancer ingress status.
+// By default an IP address is preferred over the hostname of an ingress point.
+// If preferInternal is set, only internal addresses (private IPs or internal
+// load balancer hostnames) are used, as far as available.
+func LoadBalancerTargets(ingresses []core.LoadBalancerIngress, preferInternal bool) utils.StringSet {
+	if preferInternal {
+		if internal := InternalLoadBalancerTargets(ingresses); len(internal) > 0 {
+			return internal
+		}
+	}
+	set := utils.StringSet{}
+	for _, i := range ingresses {
+		if i.Hostname != "" && i.IP == "" {
+			set.Add(i.Hostname)
+		} else {
+			if i.IP != "" {
+				set.Add(i.IP)
+			}
+		}
+	}
+	return set
+}
+
+// InternalLoadBalancerTargets returns only the internal addresses of the load balancer ingress status.
+func InternalLoadBalancerTargets(ingresses []core.LoadBalancerIngress) utils.StringSet {
+	set := utils.StringSet{}
+	for _, i := range ingresses {
+		if i.IP != "" && IsInternalIP(i.IP) {
+			set.Add(i.IP)
+		} else if i.Hostname != "" && IsInternalHostname(i.Hostname) {
+			set.Add(i.Hostname)
+		}
+	}
+	return set
+}
+
+// NodeInternalIPs returns the internal IP addresses of the given nodes.
+func NodeInternalIPs(nodes ...*core.Node) utils.StringSet {
+	set := utils.StringSet{}
+	for _, n := range nodes {
+		for _, a := range n.Status.Addresses {
+			if a.Type == core.NodeInternalIP && a.Address != "" {
+				set.Add(a.Address)
+			}
+		}
+	}
+	return set
+}
+
+// IsInternalIP checks for a private (RFC 1918 or RFC 4193) IP address.
+func IsInternalIP(addr string) bool {
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.IsPrivate()
+}
+
+// IsInternalHostname checks for hostnames of internal load balancers (e.g. AWS ELBs).
+func IsInternalHostname(hostname string) bool {
+	return strings.HasPrefix(hostname, "internal-")
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package source
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+)
+
+var templateVariable = regexp.MustCompile(`\{([a-z]+)(?:\.([^{}]+))?\}`)
+
+// expandDNSNameTemplate expands the variables of a templated dns name
+// (e.g. "{name}.{namespace}.apps.example.com") from the object metadata.
+// Supported variables are {name}, {namespace}, {kind} and {label.<key>}.
+func expandDNSNameTemplate(obj resources.Object, name string) (string, error) {
+	if !strings.Contains(name, "{") {
+		return name, nil
+	}
+	var err error
+	result := templateVariable.ReplaceAllStringFunc(name, func(v string) string {
+		m := templateVariable.FindStringSubmatch(v)
+		value := ""
+		switch m[1] {
+		case "name":
+			value = obj.GetName()
+		case "namespace":
+			value = obj.GetNamespace()
+		case "kind":
+			value = strings.ToLower(obj.GroupKind().Kind)
+		case "label":
+			value = obj.GetLabels()[m[2]]
+			if value == "" && err == nil {
+				err = fmt.Errorf("label %q required by dns name template %q not found", m[2], name)
+			}
+		default:
+			if err == nil {
+				err = fmt.Errorf("unknown variable %q in dns name template %q", v, name)
+			}
+		}
+		return strings.ToLower(value)
+	})
+	if err != nil {
+		return "", err
+	}
+	if strings.ContainsAny(result, "{}") {
+		return "", fmt.Errorf("invalid dns name template %q", name)
+	}
+	return result, nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package source
+
+import (
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type templateObject struct {
+	resources.Object
+}
+
+func (templateObject) GetName() string {
+	return "MyApp"
+}
+
+func (templateObject) GetNamespace() string {
+	return "default"
+}
+
+func (templateObject) GroupKind() schema.GroupKind {
+	return resources.NewGroupKind("", "Service")
+}
+
+func (templateObject) GetLabels() map[string]string {
+	return map[string]string{"team": "Blue"}
+}
+
+func TestExpandDNSNameTemplate(t *testing.T) {
+	table := []struct {
+		template string
+		expected string
+		err      string
+	}{
+		{"www.example.com", "www.example.com", ""},
+		{"{name}.{namespace}.apps.example.com", "myapp.default.apps.example.com", ""},
+		{"{kind}-{name}.example.com", "service-myapp.example.com", ""},
+		{"{label.team}.example.com", "blue.example.com", ""},
+		{"*.{namespace}.example.com", "*.default.example.com", ""},
+		{"{label.missing}.example.com", "", `label "missing" required by dns name template "{label.missing}.example.com" not found`},
+		{"{foo}.example.com", "", `unknown variable "{foo}" in dns name template "{foo}.example.com"`},
+		{"{name.example.com", "", `invalid dns name template "{name.example.com"`},
+		{"{Name}.example.com", "", `invalid dns name template "{Name}.example.com"`},
+		{"{}.example.com", "", `invalid dns name template "{}.example.com"`},
+		{"{name}}.example.com", "", `invalid dns name template "{name}}.example.com"`},
+		{"{{name}}.example.com", "", `invalid dns name template "{{name}}.example.com"`},
+	}
+	for _, entry := range table {
+		result, err := expandDNSNameTemplate(templateObject{}, entry.template)
+		if entry.err != "" {
+			if err == nil || err.Error() != entry.err {
+				t.Errorf("%s: expected error %q, got %q (%v)", entry.template, entry.err, result, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", entry.template, err)
+		} else if result != entry.expected {
+			t.Errorf("%s: expected %q, got %q", entry.template, entry.expected, result)
+		}
+	}
+}
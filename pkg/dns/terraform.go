@@ -0,0 +1,296 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// terraformResource is the Terraform resource managing a record set.
+type terraformResource struct {
+	Type string
+	// ImportID is the rendered HCL expression of the import id
+	ImportID string
+	Attrs    []hclAttr
+	Blocks   []hclBlock
+}
+
+type hclAttr struct {
+	Key   string
+	Value string
+}
+
+type hclBlock struct {
+	Name  string
+	Attrs []hclAttr
+}
+
+// terraformProvider maps record sets of a provider type to Terraform resources.
+type terraformProvider struct {
+	// variables are the input variables used by the import ids
+	variables []string
+	resource  func(zoneid ZoneID, domain string, name DNSSetName, policy *RoutingPolicy, rs *RecordSet) (*terraformResource, error)
+}
+
+var terraformProviders = map[string]*terraformProvider{
+	"aws-route53":       {resource: awsRoute53Record},
+	"google-clouddns":   {resource: googleDNSRecordSet},
+	"azure-dns":         {variables: []string{"subscription_id"}, resource: azureDNSRecord(false)},
+	"azure-private-dns": {variables: []string{"subscription_id"}, resource: azureDNSRecord(true)},
+}
+
+// SupportsTerraform returns true if record sets of the given provider type
+// can be exported with WriteTerraform.
+func SupportsTerraform(providerType string) bool {
+	return terraformProviders[providerType] != nil
+}
+
+// WriteTerraform renders the record sets of a hosted zone as Terraform import blocks and resource stubs
+// of the Terraform provider corresponding to the provider type of the zone. Meta records are omitted,
+// record sets which cannot be mapped to a Terraform resource are listed as comments.
+func WriteTerraform(w io.Writer, zoneid ZoneID, domain string, sets DNSSets) error {
+	tp := terraformProviders[zoneid.ProviderType]
+	if tp == nil {
+		return fmt.Errorf("terraform export not supported for provider type %s", zoneid.ProviderType)
+	}
+	for _, v := range tp.variables {
+		if _, err := fmt.Fprintf(w, "variable %q {\n  type = string\n}\n\n", v); err != nil {
+			return err
+		}
+	}
+	labels := map[string]bool{}
+	for _, name := range sortedSetNames(sets) {
+		set := sets[name]
+		for _, ty := range sortedRecordTypes(set) {
+			res, err := tp.resource(zoneid, domain, name, set.RoutingPolicy, set.Sets[ty])
+			if err != nil {
+				if _, err := fmt.Fprintf(w, "# %s %s not exported: %s\n\n", name, ty, err); err != nil {
+					return err
+				}
+				continue
+			}
+			label := terraformLabel(labels, relativeName(name.DNSName, domain), ty, name.SetIdentifier)
+			if err := writeTerraformResource(w, label, res); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeTerraformResource(w io.Writer, label string, res *terraformResource) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "import {\n  to = %s.%s\n  id = %s\n}\n\n", res.Type, label, res.ImportID)
+	fmt.Fprintf(b, "resource %q %q {\n", res.Type, label)
+	writeHCLAttrs(b, "  ", res.Attrs)
+	for _, block := range res.Blocks {
+		fmt.Fprintf(b, "\n  %s {\n", block.Name)
+		writeHCLAttrs(b, "    ", block.Attrs)
+		b.WriteString("  }\n")
+	}
+	b.WriteString("}\n\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeHCLAttrs writes attributes aligned like terraform fmt.
+func writeHCLAttrs(b *strings.Builder, indent string, attrs []hclAttr) {
+	width := 0
+	for _, a := range attrs {
+		if len(a.Key) > width {
+			width = len(a.Key)
+		}
+	}
+	for _, a := range attrs {
+		fmt.Fprintf(b, "%s%-*s = %s\n", indent, width, a.Key, a.Value)
+	}
+}
+
+var invalidLabelChars = regexp.MustCompile("[^A-Za-z0-9_-]")
+
+// terraformLabel returns a unique resource name for a record set.
+func terraformLabel(used map[string]bool, relname, rtype, setIdentifier string) string {
+	if relname == "@" {
+		relname = "apex"
+	}
+	label := relname + "_" + rtype
+	if setIdentifier != "" {
+		label += "_" + setIdentifier
+	}
+	label = invalidLabelChars.ReplaceAllString(strings.ReplaceAll(label, "*", "wildcard"), "_")
+	if c := label[0]; !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_') {
+		label = "_" + label
+	}
+	unique := label
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// relativeName returns the name relative to the domain, "@" for the domain itself.
+func relativeName(dnsName, domain string) string {
+	if dnsName == domain {
+		return "@"
+	}
+	return strings.TrimSuffix(dnsName, "."+domain)
+}
+
+// hclString renders a string as HCL quoted string without template interpolation.
+func hclString(s string) string {
+	q := strconv.Quote(s)
+	q = strings.ReplaceAll(q, "${", "$${")
+	return strings.ReplaceAll(q, "%{", "%%{")
+}
+
+func hclList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = hclString(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// recordValues returns the values of a record set, text records without quotes if requested.
+func recordValues(rs *RecordSet, unquoteText bool) []string {
+	values := make([]string, 0, len(rs.Records))
+	for _, r := range rs.Records {
+		v := r.Value
+		if unquoteText && rs.Type == RS_TXT {
+			if s, err := strconv.Unquote(v); err == nil {
+				v = s
+			}
+		}
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
+
+func awsRoute53Record(zoneid ZoneID, domain string, name DNSSetName, policy *RoutingPolicy, rs *RecordSet) (*terraformResource, error) {
+	if rs.Type == RS_ALIAS {
+		return nil, fmt.Errorf("alias records are not supported")
+	}
+	id := fmt.Sprintf("%s_%s_%s", zoneid.ID, name.DNSName, rs.Type)
+	if name.SetIdentifier != "" {
+		id += "_" + name.SetIdentifier
+	}
+	res := &terraformResource{
+		Type:     "aws_route53_record",
+		ImportID: hclString(id),
+		Attrs: []hclAttr{
+			{"zone_id", hclString(zoneid.ID)},
+			{"name", hclString(name.DNSName)},
+			{"type", hclString(rs.Type)},
+			{"ttl", strconv.FormatInt(rs.TTL, 10)},
+			{"records", hclList(recordValues(rs, true))},
+		},
+	}
+	if policy != nil {
+		if policy.Type != RoutingPolicyWeighted {
+			return nil, fmt.Errorf("routing policy %s is not supported", policy.Type)
+		}
+		weight, err := strconv.ParseInt(policy.Parameters["weight"], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight %q", policy.Parameters["weight"])
+		}
+		res.Attrs = append(res.Attrs, hclAttr{"set_identifier", hclString(name.SetIdentifier)})
+		res.Blocks = append(res.Blocks, hclBlock{"weighted_routing_policy", []hclAttr{{"weight", strconv.FormatInt(weight, 10)}}})
+	}
+	return res, nil
+}
+
+func googleDNSRecordSet(zoneid ZoneID, domain string, name DNSSetName, policy *RoutingPolicy, rs *RecordSet) (*terraformResource, error) {
+	if policy != nil {
+		return nil, fmt.Errorf("routing policies are not supported")
+	}
+	project, zone := splitZoneID(zoneid.ID)
+	fqdn := name.DNSName + "."
+	var values []string
+	for _, v := range recordValues(rs, false) {
+		if rs.Type == RS_CNAME || rs.Type == RS_NS {
+			v += "."
+		}
+		values = append(values, v)
+	}
+	return &terraformResource{
+		Type:     "google_dns_record_set",
+		ImportID: hclString(fmt.Sprintf("projects/%s/managedZones/%s/rrsets/%s/%s", project, zone, fqdn, rs.Type)),
+		Attrs: []hclAttr{
+			{"project", hclString(project)},
+			{"managed_zone", hclString(zone)},
+			{"name", hclString(fqdn)},
+			{"type", hclString(rs.Type)},
+			{"ttl", strconv.FormatInt(rs.TTL, 10)},
+			{"rrdatas", hclList(values)},
+		},
+	}, nil
+}
+
+func azureDNSRecord(private bool) func(zoneid ZoneID, domain string, name DNSSetName, policy *RoutingPolicy, rs *RecordSet) (*terraformResource, error) {
+	prefix, zones := "azurerm_dns_", "dnsZones"
+	if private {
+		prefix, zones = "azurerm_private_dns_", "privateDnsZones"
+	}
+	return func(zoneid ZoneID, domain string, name DNSSetName, policy *RoutingPolicy, rs *RecordSet) (*terraformResource, error) {
+		if policy != nil {
+			return nil, fmt.Errorf("routing policies are not supported")
+		}
+		group, zone := splitZoneID(zoneid.ID)
+		relname := relativeName(name.DNSName, domain)
+		res := &terraformResource{
+			Type: prefix + strings.ToLower(rs.Type) + "_record",
+			ImportID: "\"/subscriptions/${var.subscription_id}" + strings.TrimPrefix(hclString(fmt.Sprintf("/resourceGroups/%s/providers/Microsoft.Network/%s/%s/%s/%s",
+				group, zones, zone, rs.Type, relname)), "\""),
+			Attrs: []hclAttr{
+				{"name", hclString(relname)},
+				{"zone_name", hclString(zone)},
+				{"resource_group_name", hclString(group)},
+				{"ttl", strconv.FormatInt(rs.TTL, 10)},
+			},
+		}
+		values := recordValues(rs, true)
+		switch {
+		case rs.Type == RS_A || rs.Type == RS_AAAA || rs.Type == RS_NS && !private:
+			res.Attrs = append(res.Attrs, hclAttr{"records", hclList(values)})
+		case rs.Type == RS_CNAME && len(values) == 1:
+			res.Attrs = append(res.Attrs, hclAttr{"record", hclString(values[0])})
+		case rs.Type == RS_TXT:
+			for _, v := range values {
+				res.Blocks = append(res.Blocks, hclBlock{"record", []hclAttr{{"value", hclString(v)}}})
+			}
+		default:
+			return nil, fmt.Errorf("record type %s is not supported", rs.Type)
+		}
+		return res, nil
+	}
+}
+
+// splitZoneID splits a zone id of the form <project or resource group>/<zone name>.
+func splitZoneID(id string) (string, string) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 {
+		return "", id
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func terraformTestSets() DNSSets {
+	sets := DNSSets{}
+	www := NewDNSSet(DNSSetName{DNSName: "www.example.com"}, nil)
+	www.SetRecordSet(RS_A, 300, "1.2.3.5", "1.2.3.4")
+	www.SetMetaAttr(ATTR_OWNER, "owner")
+	sets[www.Name] = www
+	apex := NewDNSSet(DNSSetName{DNSName: "example.com"}, nil)
+	apex.SetRecordSet(RS_TXT, 60, "\"foo ${bar}\"")
+	sets[apex.Name] = apex
+	weighted := NewDNSSet(DNSSetName{DNSName: "w.example.com", SetIdentifier: "eu"}, NewRoutingPolicy("weighted", "weight", "10"))
+	weighted.SetRecordSet(RS_CNAME, 120, "lb.example.org")
+	sets[weighted.Name] = weighted
+	return sets
+}
+
+func TestWriteTerraformAWS(t *testing.T) {
+	RegisterTestingT(t)
+
+	buf := &bytes.Buffer{}
+	Ω(WriteTerraform(buf, NewZoneID("aws-route53", "Z123"), "example.com", terraformTestSets())).Should(Succeed())
+	Ω(buf.String()).Should(Equal(`import {
+  to = aws_route53_record.apex_TXT
+  id = "Z123_example.com_TXT"
+}
+
+resource "aws_route53_record" "apex_TXT" {
+  zone_id = "Z123"
+  name    = "example.com"
+  type    = "TXT"
+  ttl     = 60
+  records = ["foo $${bar}"]
+}
+
+import {
+  to = aws_route53_record.w_CNAME_eu
+  id = "Z123_w.example.com_CNAME_eu"
+}
+
+resource "aws_route53_record" "w_CNAME_eu" {
+  zone_id        = "Z123"
+  name           = "w.example.com"
+  type           = "CNAME"
+  ttl            = 120
+  records        = ["lb.example.org"]
+  set_identifier = "eu"
+
+  weighted_routing_policy {
+    weight = 10
+  }
+}
+
+import {
+  to = aws_route53_record.www_A
+  id = "Z123_www.example.com_A"
+}
+
+resource "aws_route53_record" "www_A" {
+  zone_id = "Z123"
+  name    = "www.example.com"
+  type    = "A"
+  ttl     = 300
+  records = ["1.2.3.4", "1.2.3.5"]
+}
+
+`))
+}
+
+func TestWriteTerraformGoogle(t *testing.T) {
+	RegisterTestingT(t)
+
+	buf := &bytes.Buffer{}
+	Ω(WriteTerraform(buf, NewZoneID("google-clouddns", "my-project/example-zone"), "example.com", terraformTestSets())).Should(Succeed())
+	Ω(buf.String()).Should(ContainSubstring(`import {
+  to = google_dns_record_set.apex_TXT
+  id = "projects/my-project/managedZones/example-zone/rrsets/example.com./TXT"
+}
+
+resource "google_dns_record_set" "apex_TXT" {
+  project      = "my-project"
+  managed_zone = "example-zone"
+  name         = "example.com."
+  type         = "TXT"
+  ttl          = 60
+  rrdatas      = ["\"foo $${bar}\""]
+}
+`))
+	Ω(buf.String()).Should(ContainSubstring("# w.example.com#eu CNAME not exported: routing policies are not supported\n"))
+}
+
+func TestWriteTerraformAzure(t *testing.T) {
+	RegisterTestingT(t)
+
+	buf := &bytes.Buffer{}
+	Ω(WriteTerraform(buf, NewZoneID("azure-dns", "my-group/example.com"), "example.com", terraformTestSets())).Should(Succeed())
+	Ω(buf.String()).Should(HavePrefix("variable \"subscription_id\" {\n  type = string\n}\n\n"))
+	Ω(buf.String()).Should(ContainSubstring(`import {
+  to = azurerm_dns_txt_record.apex_TXT
+  id = "/subscriptions/${var.subscription_id}/resourceGroups/my-group/providers/Microsoft.Network/dnsZones/example.com/TXT/@"
+}
+
+resource "azurerm_dns_txt_record" "apex_TXT" {
+  name                = "@"
+  zone_name           = "example.com"
+  resource_group_name = "my-group"
+  ttl                 = 60
+
+  record {
+    value = "foo $${bar}"
+  }
+}
+`))
+	Ω(buf.String()).Should(ContainSubstring(`resource "azurerm_dns_a_record" "www_A" {`))
+}
+
+func TestWriteTerraformUnsupported(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(SupportsTerraform("mock-inmemory")).Should(BeFalse())
+	Ω(WriteTerraform(&bytes.Buffer{}, NewZoneID("mock-inmemory", "z1"), "example.com", terraformTestSets())).ShouldNot(Succeed())
+}
+
+func TestTerraformLabel(t *testing.T) {
+	RegisterTestingT(t)
+
+	used := map[string]bool{}
+	Ω(terraformLabel(used, "*.apps", RS_A, "")).Should(Equal("wildcard_apps_A"))
+	Ω(terraformLabel(used, "1st", RS_A, "")).Should(Equal("_1st_A"))
+	Ω(terraformLabel(used, "a.b", RS_A, "")).Should(Equal("a_b_A"))
+	Ω(terraformLabel(used, "a_b", RS_A, "")).Should(Equal("a_b_A_2"))
+}
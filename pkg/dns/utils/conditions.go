@@ -0,0 +1,128 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+// StateCondition maps the state of an object to the condition type of the kstatus compatible
+// conditions which is true for this state.
+func StateCondition(state string) string {
+	switch state {
+	case api.STATE_READY, api.STATE_COMPLETED, api.STATE_INACTIVE:
+		return api.CONDITION_READY
+	case api.STATE_ERROR, api.STATE_INVALID, api.STATE_STALE, api.STATE_ABORTED:
+		return api.CONDITION_STALLED
+	default:
+		return api.CONDITION_RECONCILING
+	}
+}
+
+// SetStateConditions maintains the conditions `Ready`, `Reconciling` and `Stalled` for an object in the given state.
+// It returns true if the conditions have been modified.
+func SetStateConditions(conditions *[]metav1.Condition, generation int64, state string, message *string) bool {
+	msg := ""
+	if message != nil {
+		msg = *message
+	}
+	return SetReadyConditions(conditions, generation, StateCondition(state), state, msg)
+}
+
+// SetReadyConditions maintains the conditions `Ready`, `Reconciling` and `Stalled` following the
+// kstatus conventions: the condition `Ready` is always present, the abnormal-true conditions
+// `Reconciling` and `Stalled` are only present if they are true. The given condition type is set to true.
+// It returns true if the conditions have been modified.
+func SetReadyConditions(conditions *[]metav1.Condition, generation int64, ctype, reason, message string) bool {
+	if reason == "" {
+		reason = api.STATE_PENDING
+	}
+	status := metav1.ConditionFalse
+	if ctype == api.CONDITION_READY {
+		status = metav1.ConditionTrue
+	}
+	mod := AcknowledgeCondition(conditions, metav1.Condition{
+		Type:               api.CONDITION_READY,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	for _, t := range []string{api.CONDITION_RECONCILING, api.CONDITION_STALLED} {
+		if t == ctype {
+			mod = AcknowledgeCondition(conditions, metav1.Condition{
+				Type:               t,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: generation,
+				Reason:             reason,
+				Message:            message,
+			}) || mod
+		} else if meta.FindStatusCondition(*conditions, t) != nil {
+			meta.RemoveStatusCondition(conditions, t)
+			mod = true
+		}
+	}
+	return mod
+}
+
+// AcknowledgeCondition sets a condition and returns true if it has been modified.
+func AcknowledgeCondition(conditions *[]metav1.Condition, condition metav1.Condition) bool {
+	old := meta.FindStatusCondition(*conditions, condition.Type)
+	if old != nil && old.Status == condition.Status && old.Reason == condition.Reason &&
+		old.Message == condition.Message && old.ObservedGeneration == condition.ObservedGeneration {
+		return false
+	}
+	meta.SetStatusCondition(conditions, condition)
+	return true
+}
+
+// EntryReadyCondition determines the condition type, reason and message of the readiness of an entry.
+// An entry in state Ready is only ready if its records are propagated or the propagation is not checked.
+func EntryReadyCondition(entry *api.DNSEntry) (string, string, string) {
+	status := &entry.Status
+	msg := ""
+	if status.Message != nil {
+		msg = *status.Message
+	}
+	ctype := StateCondition(status.State)
+	if status.State != api.STATE_READY {
+		return ctype, status.State, msg
+	}
+	cond := meta.FindStatusCondition(status.Conditions, api.CONDITION_PROPAGATED)
+	if cond == nil || cond.ObservedGeneration != entry.Generation || cond.Status != metav1.ConditionFalse {
+		return ctype, status.State, msg
+	}
+	if cond.Reason == api.PROPAGATION_TIMEOUT {
+		return api.CONDITION_STALLED, api.REASON_NOT_PROPAGATED, cond.Message
+	}
+	return api.CONDITION_RECONCILING, api.REASON_PROPAGATING, cond.Message
+}
+
+// modifyStatusWithConditions wraps a status modifier to maintain the ready conditions afterwards.
+func modifyStatusWithConditions(obj resources.Object, modifier resources.Modifier, conditions func(data resources.ObjectData) bool) (bool, error) {
+	return obj.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		mod, err := modifier(data)
+		if err != nil {
+			return mod, err
+		}
+		return conditions(data) || mod, nil
+	})
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = Describe("Ready conditions", func() {
+	msg := "some message"
+
+	It("sets the conditions for the state", func() {
+		var conditions []metav1.Condition
+		Expect(SetStateConditions(&conditions, 1, api.STATE_PENDING, nil)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(conditions, api.CONDITION_READY)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(conditions, api.CONDITION_RECONCILING)).To(BeTrue())
+		Expect(meta.FindStatusCondition(conditions, api.CONDITION_STALLED)).To(BeNil())
+
+		Expect(SetStateConditions(&conditions, 1, api.STATE_ERROR, &msg)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(conditions, api.CONDITION_READY)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(conditions, api.CONDITION_STALLED)).To(BeTrue())
+		Expect(meta.FindStatusCondition(conditions, api.CONDITION_RECONCILING)).To(BeNil())
+		Expect(meta.FindStatusCondition(conditions, api.CONDITION_STALLED).Message).To(Equal(msg))
+
+		Expect(SetStateConditions(&conditions, 2, api.STATE_READY, &msg)).To(BeTrue())
+		Expect(conditions).To(HaveLen(1))
+		ready := meta.FindStatusCondition(conditions, api.CONDITION_READY)
+		Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+		Expect(ready.Reason).To(Equal(api.STATE_READY))
+		Expect(ready.ObservedGeneration).To(Equal(int64(2)))
+
+		Expect(SetStateConditions(&conditions, 2, api.STATE_READY, &msg)).To(BeFalse())
+	})
+
+	It("considers the propagation of an entry", func() {
+		entry := &api.DNSEntry{}
+		entry.Generation = 3
+		entry.Status.State = api.STATE_READY
+		ctype, reason, _ := EntryReadyCondition(entry)
+		Expect(ctype).To(Equal(api.CONDITION_READY))
+		Expect(reason).To(Equal(api.STATE_READY))
+
+		entry.Status.Conditions = []metav1.Condition{{
+			Type:               api.CONDITION_PROPAGATED,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: 3,
+			Reason:             api.PROPAGATION_PENDING,
+		}}
+		ctype, reason, _ = EntryReadyCondition(entry)
+		Expect(ctype).To(Equal(api.CONDITION_RECONCILING))
+		Expect(reason).To(Equal(api.REASON_PROPAGATING))
+
+		entry.Status.Conditions[0].Reason = api.PROPAGATION_TIMEOUT
+		ctype, reason, _ = EntryReadyCondition(entry)
+		Expect(ctype).To(Equal(api.CONDITION_STALLED))
+		Expect(reason).To(Equal(api.REASON_NOT_PROPAGATED))
+
+		entry.Status.Conditions[0].Status = metav1.ConditionTrue
+		entry.Status.Conditions[0].Reason = api.PROPAGATION_RESOLVED
+		ctype, _, _ = EntryReadyCondition(entry)
+		Expect(ctype).To(Equal(api.CONDITION_READY))
+
+		entry.Status.State = api.STATE_INVALID
+		ctype, reason, _ = EntryReadyCondition(entry)
+		Expect(ctype).To(Equal(api.CONDITION_STALLED))
+		Expect(reason).To(Equal(api.STATE_INVALID))
+	})
+})
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	LOG_FORMAT_TEXT = "text"
+	LOG_FORMAT_JSON = "json"
+)
+
+// jsonLogger is the logger for structured log lines, it is only set if the JSON log format is enabled.
+var jsonLogger atomic.Value
+
+// SetLogFormat sets the format of the log lines of reconciliations (text or json).
+func SetLogFormat(format string) error {
+	switch format {
+	case "", LOG_FORMAT_TEXT:
+		return nil
+	case LOG_FORMAT_JSON:
+		jsonLogger.Store(&logrus.Logger{
+			Out:       os.Stderr,
+			Level:     logrus.GetLevel(),
+			Formatter: &logrus.JSONFormatter{},
+		})
+		return nil
+	}
+	return fmt.Errorf("invalid log format %q (expected %s or %s)", format, LOG_FORMAT_TEXT, LOG_FORMAT_JSON)
+}
+
+// WithLogFields returns a log context attaching the given fields to every log line
+// if the JSON log format is enabled. Otherwise, the log context is returned unchanged.
+func WithLogFields(log logger.LogContext, fields map[string]string) logger.LogContext {
+	var entry *logrus.Entry
+	indent := ""
+	switch l := log.(type) {
+	case *jsonLogContext:
+		entry = l.entry
+		indent = l.indent
+	default:
+		value := jsonLogger.Load()
+		if value == nil {
+			return log
+		}
+		entry = logrus.NewEntry(value.(*logrus.Logger))
+	}
+	data := logrus.Fields{}
+	for k, v := range fields {
+		if v != "" {
+			data[k] = v
+		}
+	}
+	return &jsonLogContext{entry: entry.WithFields(data), indent: indent}
+}
+
+// jsonLogContext is a log context with structured fields instead of message prefixes.
+type jsonLogContext struct {
+	entry  *logrus.Entry
+	indent string
+}
+
+var _ logger.LogContext = &jsonLogContext{}
+
+func (this *jsonLogContext) NewContext(key, value string) logger.LogContext {
+	return &jsonLogContext{entry: this.entry.WithField(key, value), indent: this.indent}
+}
+
+func (this *jsonLogContext) AddIndent(indent string) logger.LogContext {
+	return &jsonLogContext{entry: this.entry, indent: this.indent + indent}
+}
+
+func (this *jsonLogContext) Info(msg ...interface{}) {
+	this.entry.Info(this.indent + fmt.Sprint(msg...))
+}
+
+func (this *jsonLogContext) Debug(msg ...interface{}) {
+	this.entry.Debug(this.indent + fmt.Sprint(msg...))
+}
+
+func (this *jsonLogContext) Warn(msg ...interface{}) {
+	this.entry.Warn(this.indent + fmt.Sprint(msg...))
+}
+
+func (this *jsonLogContext) Error(msg ...interface{}) {
+	this.entry.Error(this.indent + fmt.Sprint(msg...))
+}
+
+func (this *jsonLogContext) Infof(msgfmt string, args ...interface{}) {
+	this.entry.Infof(this.indent+msgfmt, args...)
+}
+
+func (this *jsonLogContext) Debugf(msgfmt string, args ...interface{}) {
+	this.entry.Debugf(this.indent+msgfmt, args...)
+}
+
+func (this *jsonLogContext) Warnf(msgfmt string, args ...interface{}) {
+	this.entry.Warnf(this.indent+msgfmt, args...)
+}
+
+func (this *jsonLogContext) Errorf(msgfmt string, args ...interface{}) {
+	this.entry.Errorf(this.indent+msgfmt, args...)
+}
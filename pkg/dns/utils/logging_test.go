@@ -0,0 +1,65 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("Log format", func() {
+	AfterEach(func() {
+		jsonLogger = atomic.Value{}
+	})
+
+	It("rejects unknown formats", func() {
+		Expect(SetLogFormat("xml")).NotTo(Succeed())
+		Expect(SetLogFormat(LOG_FORMAT_TEXT)).To(Succeed())
+	})
+
+	It("keeps the log context for the text format", func() {
+		log := logger.New()
+		Expect(WithLogFields(log, map[string]string{"zone": "z1"})).To(Equal(log))
+	})
+
+	It("attaches the fields for the json format", func() {
+		buf := &bytes.Buffer{}
+		jsonLogger.Store(&logrus.Logger{Out: buf, Level: logrus.InfoLevel, Formatter: &logrus.JSONFormatter{}})
+
+		log := WithLogFields(logger.New(), map[string]string{"entry": "ns/e1", "zone": "", "correlationId": "c1"})
+		log = WithLogFields(log.NewContext("type", "aws-route53"), map[string]string{"zone": "z1"})
+		log.Infof("reconcile %s", "entry")
+
+		line := map[string]string{}
+		Expect(json.Unmarshal(buf.Bytes(), &line)).To(Succeed())
+		Expect(line).To(Equal(map[string]string{
+			"level":         "info",
+			"msg":           "reconcile entry",
+			"time":          line["time"],
+			"entry":         "ns/e1",
+			"zone":          "z1",
+			"type":          "aws-route53",
+			"correlationId": "c1",
+		}))
+	})
+})
@@ -55,6 +55,11 @@ func (this *RateLimiter) RateLimit() time.Duration {
 	return rate
 }
 
+// IsFailing returns true if the last operation has failed.
+func (this *RateLimiter) IsFailing() bool {
+	return this.rate.Load() > 0
+}
+
 func (this *RateLimiter) Succeeded() {
 	this.rate.Store(0)
 }
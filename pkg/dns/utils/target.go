@@ -20,6 +20,7 @@ package utils
 import (
 	"fmt"
 
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
 )
 
@@ -93,22 +94,25 @@ type TargetSpec interface {
 	OwnerId() string
 	Targets() []Target
 	RoutingPolicy() *dns.RoutingPolicy
+	DeletionPolicy() *api.DeletionPolicy
 	Responsible(set *dns.DNSSet, ownership dns.Ownership) bool
 }
 
 type targetSpec struct {
-	kind          string
-	ownerId       string
-	targets       []Target
-	routingPolicy *dns.RoutingPolicy
+	kind           string
+	ownerId        string
+	targets        []Target
+	routingPolicy  *dns.RoutingPolicy
+	deletionPolicy *api.DeletionPolicy
 }
 
 func BaseTargetSpec(entry DNSSpecification, p TargetProvider) TargetSpec {
 	spec := &targetSpec{
-		kind:          entry.GroupKind().Kind,
-		ownerId:       p.OwnerId(),
-		targets:       p.Targets(),
-		routingPolicy: p.RoutingPolicy(),
+		kind:           entry.GroupKind().Kind,
+		ownerId:        p.OwnerId(),
+		targets:        p.Targets(),
+		routingPolicy:  p.RoutingPolicy(),
+		deletionPolicy: entry.GetDeletionPolicy(),
 	}
 	return spec
 }
@@ -132,3 +136,7 @@ func (this *targetSpec) Responsible(set *dns.DNSSet, ownership dns.Ownership) bo
 func (this *targetSpec) RoutingPolicy() *dns.RoutingPolicy {
 	return this.routingPolicy
 }
+
+func (this *targetSpec) DeletionPolicy() *api.DeletionPolicy {
+	return this.deletionPolicy
+}
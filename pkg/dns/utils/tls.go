@@ -0,0 +1,102 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSPolicy restricts the protocol versions and cipher suites of TLS connections.
+type TLSPolicy struct {
+	MinVersion   uint16
+	CipherSuites []uint16
+}
+
+// ParseTLSPolicy creates a TLS policy from a minimum TLS version (e.g. `1.2`) and a comma separated
+// list of cipher suite names (e.g. `TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256`).
+// It returns nil if neither is set. Only secure cipher suites are accepted.
+func ParseTLSPolicy(minVersion, cipherSuites string) (*TLSPolicy, error) {
+	if minVersion == "" && cipherSuites == "" {
+		return nil, nil
+	}
+	policy := &TLSPolicy{}
+	if minVersion != "" {
+		version, ok := tlsVersions[minVersion]
+		if !ok {
+			return nil, fmt.Errorf("invalid TLS version %q (expected 1.0, 1.1, 1.2 or 1.3)", minVersion)
+		}
+		policy.MinVersion = version
+	}
+	if cipherSuites != "" {
+		ids := map[string]uint16{}
+		for _, s := range tls.CipherSuites() {
+			ids[s.Name] = s.ID
+		}
+		for _, name := range strings.Split(cipherSuites, ",") {
+			name = strings.TrimSpace(name)
+			id, ok := ids[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown or insecure cipher suite %q", name)
+			}
+			policy.CipherSuites = append(policy.CipherSuites, id)
+		}
+	}
+	return policy, nil
+}
+
+// Apply restricts the given TLS configuration according to the policy.
+// The cipher suites only apply to TLS versions up to 1.2.
+func (this *TLSPolicy) Apply(config *tls.Config) {
+	if this == nil {
+		return
+	}
+	if this.MinVersion != 0 {
+		config.MinVersion = this.MinVersion
+	}
+	if len(this.CipherSuites) > 0 {
+		config.CipherSuites = this.CipherSuites
+	}
+}
+
+func (this *TLSPolicy) String() string {
+	if this == nil {
+		return "default"
+	}
+	names := []string{}
+	for _, id := range this.CipherSuites {
+		names = append(names, tls.CipherSuiteName(id))
+	}
+	version := "default"
+	for n, v := range tlsVersions {
+		if v == this.MinVersion {
+			version = n
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Sprintf("min version %s", version)
+	}
+	return fmt.Sprintf("min version %s, cipher suites %s", version, strings.Join(names, ","))
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"crypto/tls"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TLSPolicy", func() {
+	It("is nil if nothing is configured", func() {
+		policy, err := ParseTLSPolicy("", "")
+		Expect(err).To(BeNil())
+		Expect(policy).To(BeNil())
+
+		config := &tls.Config{MinVersion: tls.VersionTLS12}
+		policy.Apply(config)
+		Expect(config.MinVersion).To(Equal(uint16(tls.VersionTLS12)))
+	})
+
+	It("restricts version and cipher suites", func() {
+		policy, err := ParseTLSPolicy("1.3", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384")
+		Expect(err).To(BeNil())
+
+		config := &tls.Config{}
+		policy.Apply(config)
+		Expect(config.MinVersion).To(Equal(uint16(tls.VersionTLS13)))
+		Expect(config.CipherSuites).To(Equal([]uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384}))
+		Expect(policy.String()).To(Equal("min version 1.3, cipher suites TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"))
+	})
+
+	It("rejects invalid versions and insecure cipher suites", func() {
+		_, err := ParseTLSPolicy("1.4", "")
+		Expect(err).NotTo(BeNil())
+		_, err = ParseTLSPolicy("", "TLS_RSA_WITH_RC4_128_SHA")
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -22,6 +22,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type TargetProvider interface {
@@ -40,9 +41,17 @@ type DNSSpecification interface {
 	GetTargets() []string
 	GetText() []string
 	GetCNameLookupInterval() *int64
+	GetResolveTargetsToAddresses() *bool
 	GetReference() *api.EntryReference
+	GetTargetRef() *api.TargetReference
 	BaseStatus() *api.DNSBaseStatus
 	GetRoutingPolicy() *dns.RoutingPolicy
+	GetProvider() *string
+	GetZone() *string
+	GetDeletionPolicy() *api.DeletionPolicy
+	GetActivateAt() *metav1.Time
+	GetDeactivateAt() *metav1.Time
+	GetLifetimeSeconds() *int64
 
 	GetTargetSpec(TargetProvider) TargetSpec
 
@@ -50,6 +59,10 @@ type DNSSpecification interface {
 	ValidateSpecial() error
 	AcknowledgeTargets(targets []string) bool
 	AcknowledgeRoutingPolicy(policy *dns.RoutingPolicy) bool
+	// AcknowledgeProviderRecords reports the record sets applied at the provider in the given zone (nil to reset).
+	AcknowledgeProviderRecords(zoneid string, set *dns.DNSSet) bool
+	// AcknowledgeCondition sets the condition in the status, the transition time is only updated on status changes.
+	AcknowledgeCondition(condition metav1.Condition) bool
 }
 
 func DNSObject(data resources.Object, ign ...interface{}) DNSSpecification {
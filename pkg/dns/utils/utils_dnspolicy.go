@@ -0,0 +1,47 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var DNSPolicyType = (*api.DNSPolicy)(nil)
+
+type DNSPolicyObject struct {
+	resources.Object
+}
+
+func (this *DNSPolicyObject) DNSPolicy() *api.DNSPolicy {
+	return this.Data().(*api.DNSPolicy)
+}
+
+func DNSPolicy(o resources.Object) *DNSPolicyObject {
+	if o.IsA(DNSPolicyType) {
+		return &DNSPolicyObject{o}
+	}
+	return nil
+}
+
+func (this *DNSPolicyObject) Spec() *api.DNSPolicySpec {
+	return &this.DNSPolicy().Spec
+}
+
+func (this *DNSPolicyObject) Status() *api.DNSPolicyStatus {
+	return &this.DNSPolicy().Status
+}
@@ -18,10 +18,12 @@ package utils
 
 import (
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/external-dns-management/pkg/dns"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 )
@@ -85,9 +87,15 @@ func (this *DNSEntryObject) GetTTL() *int64 {
 func (this *DNSEntryObject) GetCNameLookupInterval() *int64 {
 	return this.DNSEntry().Spec.CNameLookupInterval
 }
+func (this *DNSEntryObject) GetResolveTargetsToAddresses() *bool {
+	return this.DNSEntry().Spec.ResolveTargetsToAddresses
+}
 func (this *DNSEntryObject) GetReference() *api.EntryReference {
 	return this.DNSEntry().Spec.Reference
 }
+func (this *DNSEntryObject) GetTargetRef() *api.TargetReference {
+	return this.DNSEntry().Spec.TargetRef
+}
 func (this *DNSEntryObject) GetRoutingPolicy() *dns.RoutingPolicy {
 	if policy := this.DNSEntry().Spec.RoutingPolicy; policy != nil {
 		return &dns.RoutingPolicy{
@@ -98,6 +106,30 @@ func (this *DNSEntryObject) GetRoutingPolicy() *dns.RoutingPolicy {
 	return nil
 }
 
+func (this *DNSEntryObject) GetProvider() *string {
+	return this.DNSEntry().Spec.Provider
+}
+
+func (this *DNSEntryObject) GetZone() *string {
+	return this.DNSEntry().Spec.Zone
+}
+
+func (this *DNSEntryObject) GetDeletionPolicy() *api.DeletionPolicy {
+	return this.DNSEntry().Spec.DeletionPolicy
+}
+
+func (this *DNSEntryObject) GetActivateAt() *metav1.Time {
+	return this.DNSEntry().Spec.ActivateAt
+}
+
+func (this *DNSEntryObject) GetDeactivateAt() *metav1.Time {
+	return this.DNSEntry().Spec.DeactivateAt
+}
+
+func (this *DNSEntryObject) GetLifetimeSeconds() *int64 {
+	return this.DNSEntry().Spec.LifetimeSeconds
+}
+
 func (this *DNSEntryObject) RefreshTime() time.Time {
 	return time.Time{}
 }
@@ -136,6 +168,62 @@ func (this *DNSEntryObject) AcknowledgeRoutingPolicy(policy *dns.RoutingPolicy)
 	return false
 }
 
+func (this *DNSEntryObject) AcknowledgeProviderRecords(zoneid string, set *dns.DNSSet) bool {
+	s := this.Status()
+	records := providerRecords(zoneid, set)
+	if reflect.DeepEqual(s.ProviderRecords, records) {
+		return false
+	}
+	s.ProviderRecords = records
+	return true
+}
+
+// providerRecords renders the record sets of a DNS set without the ownership records.
+func providerRecords(zoneid string, set *dns.DNSSet) *api.ProviderRecords {
+	if set == nil {
+		return nil
+	}
+	records := &api.ProviderRecords{
+		Zone:          zoneid,
+		DNSName:       set.Name.DNSName,
+		SetIdentifier: set.Name.SetIdentifier,
+	}
+	if policy := set.RoutingPolicy; policy != nil {
+		records.RoutingPolicy = &api.RoutingPolicy{
+			Type:          policy.Type,
+			SetIdentifier: set.Name.SetIdentifier,
+			Parameters:    policy.Parameters,
+		}
+	}
+	for rtype, rset := range set.Sets {
+		if rtype == dns.RS_META {
+			continue
+		}
+		values := make([]string, 0, len(rset.Records))
+		for _, r := range rset.Records {
+			values = append(values, r.Value)
+		}
+		sort.Strings(values)
+		records.RecordSets = append(records.RecordSets, api.ProviderRecordSet{Type: rtype, TTL: rset.TTL, Values: values})
+	}
+	sort.Slice(records.RecordSets, func(i, j int) bool { return records.RecordSets[i].Type < records.RecordSets[j].Type })
+	return records
+}
+
+func (this *DNSEntryObject) AcknowledgeCondition(condition metav1.Condition) bool {
+	return AcknowledgeCondition(&this.Status().Conditions, condition)
+}
+
+// ModifyStatus modifies the status and maintains the conditions `Ready`, `Reconciling` and `Stalled`
+// according to the resulting state and propagation of the entry.
+func (this *DNSEntryObject) ModifyStatus(modifier resources.Modifier) (bool, error) {
+	return modifyStatusWithConditions(this.Object, modifier, func(data resources.ObjectData) bool {
+		entry := data.(*api.DNSEntry)
+		ctype, reason, msg := EntryReadyCondition(entry)
+		return SetReadyConditions(&entry.Status.Conditions, entry.Status.ObservedGeneration, ctype, reason, msg)
+	})
+}
+
 func (this *DNSEntryObject) GetTargetSpec(p TargetProvider) TargetSpec {
 	return BaseTargetSpec(this, p)
 }
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+type entryData struct {
+	resources.Object
+	entry *api.DNSEntry
+}
+
+func (this *entryData) Data() resources.ObjectData {
+	return this.entry
+}
+
+var _ = Describe("DNSEntry provider records", func() {
+	name := dns.DNSSetName{DNSName: "a.example.com", SetIdentifier: "eu"}
+
+	newSet := func() *dns.DNSSet {
+		set := dns.NewDNSSet(name, dns.NewRoutingPolicy("weighted", "weight", "10"))
+		set.SetRecordSet(dns.RS_AAAA, 300, "2001:db8::1")
+		set.SetRecordSet(dns.RS_A, 300, "5.6.7.8", "1.2.3.4")
+		set.SetOwner("owner")
+		return set
+	}
+
+	It("renders record sets without ownership records", func() {
+		entry := &DNSEntryObject{&entryData{entry: &api.DNSEntry{}}}
+		Expect(entry.AcknowledgeProviderRecords("Z123", newSet())).To(BeTrue())
+		Expect(entry.Status().ProviderRecords).To(Equal(&api.ProviderRecords{
+			Zone:          "Z123",
+			DNSName:       "a.example.com",
+			SetIdentifier: "eu",
+			RoutingPolicy: &api.RoutingPolicy{Type: "weighted", SetIdentifier: "eu", Parameters: map[string]string{"weight": "10"}},
+			RecordSets: []api.ProviderRecordSet{
+				{Type: dns.RS_A, TTL: 300, Values: []string{"1.2.3.4", "5.6.7.8"}},
+				{Type: dns.RS_AAAA, TTL: 300, Values: []string{"2001:db8::1"}},
+			},
+		}))
+		Expect(entry.AcknowledgeProviderRecords("Z123", newSet())).To(BeFalse())
+		Expect(entry.AcknowledgeProviderRecords("", nil)).To(BeTrue())
+		Expect(entry.Status().ProviderRecords).To(BeNil())
+	})
+})
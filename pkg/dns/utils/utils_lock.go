@@ -26,6 +26,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ DNSSpecification = (*DNSLockObject)(nil)
@@ -63,6 +64,15 @@ func (this *DNSLockObject) BaseStatus() *api.DNSBaseStatus {
 	return &this.DNSLock().Status.DNSBaseStatus
 }
 
+// ModifyStatus modifies the status and maintains the conditions `Ready`, `Reconciling` and `Stalled`
+// according to the resulting state of the lock.
+func (this *DNSLockObject) ModifyStatus(modifier resources.Modifier) (bool, error) {
+	return modifyStatusWithConditions(this.Object, modifier, func(data resources.ObjectData) bool {
+		status := &data.(*api.DNSLock).Status
+		return SetStateConditions(&status.Conditions, status.ObservedGeneration, status.State, status.Message)
+	})
+}
+
 func (this *DNSLockObject) GetDNSName() string {
 	return this.DNSLock().Spec.DNSName
 }
@@ -76,11 +86,36 @@ func (this *DNSLockObject) GetTargets() []string {
 }
 
 func (this *DNSLockObject) GetText() []string {
+	return this.getText(this.Spec().Timestamp.Time, "")
+}
+
+// IsLease returns true if the lock uses lease semantics.
+func (this *DNSLockObject) IsLease() bool {
+	return this.Spec().Lease != nil && *this.Spec().Lease
+}
+
+// GetHolder returns the identity of the lease holder or the given default identity.
+func (this *DNSLockObject) GetHolder(def string) string {
+	if s := utils.StringValue(this.Spec().Holder); s != "" {
+		return s
+	}
+	return def
+}
+
+// GetLeaseText returns the TXT record values of a lease renewed by the holder at the given time.
+func (this *DNSLockObject) GetLeaseText(holder string, renewed time.Time) []string {
+	return this.getText(renewed, holder)
+}
+
+func (this *DNSLockObject) getText(timestamp time.Time, holder string) []string {
 	attrs := []string{}
 	if s := utils.StringValue(this.Spec().LockId); s != "" {
 		attrs = append(attrs, fmt.Sprintf("%s=%s", dns.ATTR_LOCKID, s))
 	}
-	attrs = append(attrs, fmt.Sprintf("%s=%d", dns.ATTR_TIMESTAMP, this.Spec().Timestamp.Unix()))
+	attrs = append(attrs, fmt.Sprintf("%s=%d", dns.ATTR_TIMESTAMP, timestamp.Unix()))
+	if holder != "" {
+		attrs = append(attrs, fmt.Sprintf("%s=%s", dns.ATTR_HOLDER, holder))
+	}
 	if this.Spec().Attributes != nil {
 		for k, v := range this.Spec().Attributes {
 			if strings.HasPrefix(k, "_") {
@@ -109,14 +144,46 @@ func (this *DNSLockObject) GetCNameLookupInterval() *int64 {
 	return nil
 }
 
+func (this *DNSLockObject) GetResolveTargetsToAddresses() *bool {
+	return nil
+}
+
 func (this *DNSLockObject) GetReference() *api.EntryReference {
 	return nil
 }
 
+func (this *DNSLockObject) GetTargetRef() *api.TargetReference {
+	return nil
+}
+
 func (this *DNSLockObject) GetRoutingPolicy() *dns.RoutingPolicy {
 	return nil
 }
 
+func (this *DNSLockObject) GetProvider() *string {
+	return nil
+}
+
+func (this *DNSLockObject) GetZone() *string {
+	return nil
+}
+
+func (this *DNSLockObject) GetDeletionPolicy() *api.DeletionPolicy {
+	return nil
+}
+
+func (this *DNSLockObject) GetActivateAt() *metav1.Time {
+	return nil
+}
+
+func (this *DNSLockObject) GetDeactivateAt() *metav1.Time {
+	return nil
+}
+
+func (this *DNSLockObject) GetLifetimeSeconds() *int64 {
+	return nil
+}
+
 func (this *DNSLockObject) RefreshTime() time.Time {
 	return this.Spec().Timestamp.Time
 }
@@ -125,6 +192,25 @@ func (this *DNSLockObject) ValidateSpecial() error {
 	if len(this.Spec().Attributes) == 0 {
 		return fmt.Errorf("no attributes defined")
 	}
+	if this.IsLease() && this.Spec().TTL <= 0 {
+		return fmt.Errorf("lease requires a positive ttl")
+	}
+	if strings.ContainsAny(utils.StringValue(this.Spec().Holder), " \t=\"") {
+		return fmt.Errorf("invalid holder %q", utils.StringValue(this.Spec().Holder))
+	}
+	if lookup := this.Spec().Lookup; lookup != nil {
+		for _, r := range lookup.Resolvers {
+			if _, err := NormalizeResolverAddress(r); err != nil {
+				return fmt.Errorf("invalid lookup: %w", err)
+			}
+		}
+		if lookup.Timeout != nil && lookup.Timeout.Duration <= 0 {
+			return fmt.Errorf("invalid lookup: timeout must be positive")
+		}
+		if lookup.Retries != nil && *lookup.Retries < 0 {
+			return fmt.Errorf("invalid lookup: retries must not be negative")
+		}
+	}
 	return nil
 }
 
@@ -136,6 +222,14 @@ func (this *DNSLockObject) AcknowledgeRoutingPolicy(policy *dns.RoutingPolicy) b
 	return false
 }
 
+func (this *DNSLockObject) AcknowledgeProviderRecords(zoneid string, set *dns.DNSSet) bool {
+	return false
+}
+
+func (this *DNSLockObject) AcknowledgeCondition(condition metav1.Condition) bool {
+	return false
+}
+
 func (this *DNSLockObject) GetTargetSpec(p TargetProvider) TargetSpec {
 	return &lockTargetSpec{
 		TargetSpec:  BaseTargetSpec(this, p),
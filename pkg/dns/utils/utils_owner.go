@@ -55,6 +55,10 @@ func (this *DNSOwnerObject) GetDNSActivation() *api.DNSActivation {
 	return this.DNSOwner().Spec.DNSActivation
 }
 
+func (this *DNSOwnerObject) GetDeactivationPolicy() *api.DeletionPolicy {
+	return this.DNSOwner().Spec.DeactivationPolicy
+}
+
 func (this *DNSOwnerObject) IsEnabled() bool {
 	a := this.DNSOwner().Spec.Active
 	return a == nil || *a
@@ -62,8 +66,7 @@ func (this *DNSOwnerObject) IsEnabled() bool {
 
 func (this *DNSOwnerObject) IsActive() bool {
 	if this.IsEnabled() {
-		valid := this.DNSOwner().Spec.ValidUntil
-		if valid != nil && !valid.After(time.Now()) {
+		if !this.IsValidAt(time.Now()) {
 			return false
 		}
 		return CheckDNSActivation(this.GetCluster().GetId(), this.GetDNSActivation())
@@ -71,10 +74,46 @@ func (this *DNSOwnerObject) IsActive() bool {
 	return false
 }
 
+// IsValidAt checks whether the given time is in the validity window of the owner.
+func (this *DNSOwnerObject) IsValidAt(t time.Time) bool {
+	spec := this.Spec()
+	if spec.ValidFrom != nil && t.Before(spec.ValidFrom.Time) {
+		return false
+	}
+	return spec.ValidUntil == nil || spec.ValidUntil.After(t)
+}
+
+// NextTransition returns the next time after the given time the validity of an
+// enabled owner changes, or nil if it does not change anymore.
+func (this *DNSOwnerObject) NextTransition(t time.Time) *metav1.Time {
+	if !this.IsEnabled() {
+		return nil
+	}
+	spec := this.Spec()
+	if spec.ValidFrom != nil && t.Before(spec.ValidFrom.Time) {
+		return spec.ValidFrom
+	}
+	if spec.ValidUntil != nil && spec.ValidUntil.After(t) {
+		return spec.ValidUntil
+	}
+	return nil
+}
+
+func (this *DNSOwnerObject) ValidFrom() *metav1.Time {
+	return this.DNSOwner().Spec.ValidFrom
+}
+
 func (this *DNSOwnerObject) ValidUntil() *metav1.Time {
 	return this.DNSOwner().Spec.ValidUntil
 }
 
+// IsHandover checks whether the owner takes part in a handover of its owner id
+// between clusters, either with a validity window or with a DNS activation.
+func (this *DNSOwnerObject) IsHandover() bool {
+	spec := this.Spec()
+	return spec.ValidFrom != nil || spec.ValidUntil != nil || spec.DNSActivation != nil
+}
+
 func (this *DNSOwnerObject) GetCounts() map[string]int {
 	return this.DNSOwner().Status.Entries.ByType
 }
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+type ownerData struct {
+	resources.Object
+	owner *api.DNSOwner
+}
+
+func (this *ownerData) Data() resources.ObjectData {
+	return this.owner
+}
+
+var _ = Describe("DNSOwner validity", func() {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	from := metav1.NewTime(now.Add(time.Hour))
+	until := metav1.NewTime(now.Add(2 * time.Hour))
+
+	newOwner := func(spec api.DNSOwnerSpec) *DNSOwnerObject {
+		return &DNSOwnerObject{&ownerData{owner: &api.DNSOwner{Spec: spec}}}
+	}
+
+	It("is always valid without window", func() {
+		owner := newOwner(api.DNSOwnerSpec{OwnerId: "id"})
+		Expect(owner.IsValidAt(now)).To(BeTrue())
+		Expect(owner.NextTransition(now)).To(BeNil())
+	})
+
+	It("is valid within the window", func() {
+		owner := newOwner(api.DNSOwnerSpec{OwnerId: "id", ValidFrom: &from, ValidUntil: &until})
+		Expect(owner.IsValidAt(now)).To(BeFalse())
+		Expect(owner.NextTransition(now)).To(Equal(&from))
+		Expect(owner.IsValidAt(from.Time)).To(BeTrue())
+		Expect(owner.NextTransition(from.Time)).To(Equal(&until))
+		Expect(owner.IsValidAt(until.Time)).To(BeFalse())
+		Expect(owner.NextTransition(until.Time)).To(BeNil())
+	})
+
+	It("is a handover with window or DNS activation", func() {
+		Expect(newOwner(api.DNSOwnerSpec{OwnerId: "id"}).IsHandover()).To(BeFalse())
+		Expect(newOwner(api.DNSOwnerSpec{OwnerId: "id", ValidFrom: &from}).IsHandover()).To(BeTrue())
+		Expect(newOwner(api.DNSOwnerSpec{OwnerId: "id", ValidUntil: &until}).IsHandover()).To(BeTrue())
+		Expect(newOwner(api.DNSOwnerSpec{OwnerId: "id", DNSActivation: &api.DNSActivation{DNSName: "owner.example.com"}}).IsHandover()).To(BeTrue())
+	})
+
+	It("has no transitions if disabled", func() {
+		active := false
+		owner := newOwner(api.DNSOwnerSpec{OwnerId: "id", Active: &active, ValidFrom: &from})
+		Expect(owner.NextTransition(now)).To(BeNil())
+	})
+})
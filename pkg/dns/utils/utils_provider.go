@@ -103,9 +103,20 @@ func (this *DNSProviderObject) SetState(state, message string, commonMessagePref
 	}
 	mod.AssureStringValue(&status.State, state)
 	mod.AssureInt64Value(&status.ObservedGeneration, this.DNSProvider().Generation)
+	mod.Modify(this.AcknowledgeReadyConditions())
 	return mod.IsModified()
 }
 
+// AcknowledgeReadyConditions maintains the conditions `Ready`, `Reconciling` and `Stalled` according
+// to the state of the provider. A provider is only ready if hosted zones have been discovered.
+func (this *DNSProviderObject) AcknowledgeReadyConditions() bool {
+	status := &this.DNSProvider().Status
+	if status.State == api.STATE_READY && len(status.Zones.Included) == 0 {
+		return SetReadyConditions(&status.Conditions, status.ObservedGeneration, api.CONDITION_STALLED, api.REASON_NO_HOSTED_ZONES, "no hosted zones discovered")
+	}
+	return SetStateConditions(&status.Conditions, status.ObservedGeneration, status.State, status.Message)
+}
+
 func (this *DNSProviderObject) SetSelection(included, excluded utils.StringSet, target *api.DNSSelectionStatus) bool {
 	modified := false
 	old_inc := utils.NewStringSetByArray(target.Included)
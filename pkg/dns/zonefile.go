@@ -0,0 +1,333 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ZoneFileRecord is a single resource record read from a zone file.
+type ZoneFileRecord struct {
+	// Name is the absolute domain name without trailing dot
+	Name string
+	// TTL is the time to live, or 0 if not specified
+	TTL  int64
+	Type string
+	// Data contains the fields of the record data. Names are absolute without
+	// trailing dot, character strings are unquoted.
+	Data []string
+}
+
+// WriteZoneFile renders the record sets of a hosted zone for the given domain
+// in the zone file format of RFC 1035. META record sets are omitted. Record
+// sets with routing policies and provider specific ALIAS records cannot be
+// represented and are written as comments.
+func WriteZoneFile(w io.Writer, domain string, sets DNSSets) error {
+	names := sortedSetNames(sets)
+
+	if _, err := fmt.Fprintf(w, "$ORIGIN %s\n", absoluteName(domain)); err != nil {
+		return err
+	}
+	for _, name := range names {
+		set := sets[name]
+		prefix := ""
+		if set.RoutingPolicy != nil {
+			prefix = "; "
+			if _, err := fmt.Fprintf(w, "; routing policy %s for set identifier %s: %s\n",
+				set.RoutingPolicy.Type, name.SetIdentifier, formatParameters(set.RoutingPolicy.Parameters)); err != nil {
+				return err
+			}
+		}
+		for _, ty := range sortedRecordTypes(set) {
+			rs := set.Sets[ty]
+			p := prefix
+			if ty == RS_ALIAS {
+				p = "; "
+			}
+			for _, r := range rs.Records {
+				value := r.Value
+				switch ty {
+				case RS_CNAME, RS_NS, RS_ALIAS:
+					value = absoluteName(value)
+				}
+				if _, err := fmt.Fprintf(w, "%s%s\t%d\tIN\t%s\t%s\n", p, absoluteName(name.DNSName), rs.TTL, ty, value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// sortedSetNames returns the names of the DNS sets ordered by DNS name and set identifier.
+func sortedSetNames(sets DNSSets) []DNSSetName {
+	names := make([]DNSSetName, 0, len(sets))
+	for name := range sets {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i].DNSName != names[j].DNSName {
+			return names[i].DNSName < names[j].DNSName
+		}
+		return names[i].SetIdentifier < names[j].SetIdentifier
+	})
+	return names
+}
+
+// sortedRecordTypes returns the record types of a DNS set without meta records.
+func sortedRecordTypes(set *DNSSet) []string {
+	types := make([]string, 0, len(set.Sets))
+	for ty := range set.Sets {
+		if ty != RS_META {
+			types = append(types, ty)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+func absoluteName(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+func formatParameters(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		keys[i] = k + "=" + params[k]
+	}
+	return strings.Join(keys, ",")
+}
+
+// ParseZoneFile reads the resource records of a zone file in the format of
+// RFC 1035. Relative names are completed with the origin, which can be changed
+// by $ORIGIN directives. Records without explicit TTL get the TTL of the
+// $TTL directive or the preceding record. $INCLUDE directives are not supported.
+func ParseZoneFile(r io.Reader, origin string) ([]ZoneFileRecord, error) {
+	p := &zoneFileParser{origin: strings.TrimSuffix(origin, ".")}
+	scanner := bufio.NewScanner(r)
+	var tokens []string
+	paren := 0
+	start := 0
+	for lineno := 1; scanner.Scan(); lineno++ {
+		if paren == 0 {
+			start = lineno
+		}
+		line, err := tokenizeZoneFileLine(scanner.Text(), &paren)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineno, err)
+		}
+		if paren < 0 {
+			return nil, fmt.Errorf("line %d: unbalanced parentheses", lineno)
+		}
+		if tokens == nil {
+			tokens = line
+		} else {
+			tokens = append(tokens, line[1:]...)
+		}
+		if paren > 0 {
+			continue
+		}
+		if err := p.parseEntry(tokens); err != nil {
+			return nil, fmt.Errorf("line %d: %w", start, err)
+		}
+		tokens = nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if paren != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", start)
+	}
+	return p.records, nil
+}
+
+type zoneFileParser struct {
+	origin  string
+	owner   string
+	ttl     int64
+	records []ZoneFileRecord
+}
+
+// tokenizeZoneFileLine splits a line into its fields. The first token is
+// always the owner field, which is empty if the line starts with white space.
+// Quoted character strings keep their quotes.
+func tokenizeZoneFileLine(line string, paren *int) ([]string, error) {
+	tokens := []string{""}
+	cur := strings.Builder{}
+	quoted := false
+	flush := func() {
+		if cur.Len() > 0 {
+			if len(tokens) == 1 && tokens[0] == "" && *paren == 0 && len(line) > 0 && line[0] != ' ' && line[0] != '\t' {
+				tokens[0] = cur.String()
+			} else {
+				tokens = append(tokens, cur.String())
+			}
+			cur.Reset()
+		}
+	}
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			cur.WriteByte(c)
+			i++
+			cur.WriteByte(line[i])
+		case c == '"':
+			cur.WriteByte(c)
+			quoted = !quoted
+		case quoted:
+			cur.WriteByte(c)
+		case c == ';':
+			i = len(line)
+		case c == ' ' || c == '\t':
+			flush()
+		case c == '(':
+			flush()
+			*paren++
+		case c == ')':
+			flush()
+			*paren--
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated character string")
+	}
+	flush()
+	return tokens, nil
+}
+
+func (this *zoneFileParser) parseEntry(tokens []string) error {
+	if len(tokens) == 1 && tokens[0] == "" {
+		return nil
+	}
+	switch strings.ToUpper(tokens[0]) {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return fmt.Errorf("invalid $ORIGIN directive")
+		}
+		this.origin = this.absolute(tokens[1])
+		return nil
+	case "$TTL":
+		if len(tokens) != 2 {
+			return fmt.Errorf("invalid $TTL directive")
+		}
+		ttl, err := parseZoneFileTTL(tokens[1])
+		if err != nil {
+			return err
+		}
+		this.ttl = ttl
+		return nil
+	case "$INCLUDE", "$GENERATE":
+		return fmt.Errorf("unsupported directive %s", tokens[0])
+	}
+
+	if tokens[0] != "" {
+		this.owner = this.absolute(tokens[0])
+	}
+	if this.owner == "" {
+		return fmt.Errorf("owner name missing")
+	}
+	ttl := this.ttl
+	fields := tokens[1:]
+	for len(fields) > 0 {
+		if t, err := parseZoneFileTTL(fields[0]); err == nil {
+			ttl = t
+			this.ttl = t
+		} else if f := strings.ToUpper(fields[0]); f != "IN" && f != "CH" && f != "HS" {
+			break
+		}
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("record type missing for %s", this.owner)
+	}
+	rec := ZoneFileRecord{Name: this.owner, TTL: ttl, Type: strings.ToUpper(fields[0])}
+	for _, f := range fields[1:] {
+		switch rec.Type {
+		case RS_CNAME, RS_NS:
+			f = this.absolute(f)
+		case RS_TXT:
+			if u, err := strconv.Unquote(f); err == nil {
+				f = u
+			}
+		}
+		rec.Data = append(rec.Data, f)
+	}
+	if len(rec.Data) == 0 {
+		return fmt.Errorf("record data missing for %s %s", rec.Name, rec.Type)
+	}
+	this.records = append(this.records, rec)
+	return nil
+}
+
+func (this *zoneFileParser) absolute(name string) string {
+	if name == "@" {
+		return this.origin
+	}
+	if strings.HasSuffix(name, ".") {
+		return strings.TrimSuffix(name, ".")
+	}
+	if this.origin == "" {
+		return name
+	}
+	return name + "." + this.origin
+}
+
+// parseZoneFileTTL parses a TTL given in seconds or with the BIND time units.
+func parseZoneFileTTL(s string) (int64, error) {
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return 0, fmt.Errorf("invalid TTL %q", s)
+	}
+	var ttl, value int64
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= '0' && c <= '9' {
+			value = value*10 + int64(c-'0')
+			continue
+		}
+		switch c | 0x20 {
+		case 's':
+		case 'm':
+			value *= 60
+		case 'h':
+			value *= 3600
+		case 'd':
+			value *= 86400
+		case 'w':
+			value *= 604800
+		default:
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+		ttl += value
+		value = 0
+	}
+	return ttl + value, nil
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWriteZoneFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	sets := DNSSets{}
+	www := NewDNSSet(DNSSetName{DNSName: "www.example.com"}, nil)
+	www.SetRecordSet(RS_A, 300, "1.2.3.4", "1.2.3.5")
+	www.SetMetaAttr(ATTR_OWNER, "owner")
+	sets[www.Name] = www
+	alias := NewDNSSet(DNSSetName{DNSName: "api.example.com"}, nil)
+	alias.SetRecordSet(RS_CNAME, 60, "lb.example.org")
+	alias.SetRecordSet(RS_TXT, 60, "\"foo bar\"")
+	sets[alias.Name] = alias
+	weighted := NewDNSSet(DNSSetName{DNSName: "w.example.com", SetIdentifier: "eu"}, NewRoutingPolicy("weighted", "weight", "10"))
+	weighted.SetRecordSet(RS_AAAA, 120, "::1")
+	sets[weighted.Name] = weighted
+
+	buf := &bytes.Buffer{}
+	Ω(WriteZoneFile(buf, "example.com", sets)).Should(Succeed())
+	Ω(buf.String()).Should(Equal(`$ORIGIN example.com.
+api.example.com.	60	IN	CNAME	lb.example.org.
+api.example.com.	60	IN	TXT	"foo bar"
+; routing policy weighted for set identifier eu: weight=10
+; w.example.com.	120	IN	AAAA	::1
+www.example.com.	300	IN	A	1.2.3.4
+www.example.com.	300	IN	A	1.2.3.5
+`))
+}
+
+func TestParseZoneFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	zone := `$TTL 1h
+@	IN	SOA	ns1.example.com. admin.example.com. (
+		2022010101 ; serial
+		7200       ; refresh
+		3600 1w 1h )
+	IN	NS	ns1
+www	300	IN	A	1.2.3.4
+	IN	A	1.2.3.5 ; second address
+api	CNAME	www
+info	IN	60	TXT	"foo bar" "baz;x"
+$ORIGIN sub.example.com.
+*	AAAA	::1
+`
+	records, err := ParseZoneFile(strings.NewReader(zone), "example.com.")
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(records).Should(Equal([]ZoneFileRecord{
+		{Name: "example.com", TTL: 3600, Type: "SOA", Data: []string{"ns1.example.com.", "admin.example.com.", "2022010101", "7200", "3600", "1w", "1h"}},
+		{Name: "example.com", TTL: 3600, Type: RS_NS, Data: []string{"ns1.example.com"}},
+		{Name: "www.example.com", TTL: 300, Type: RS_A, Data: []string{"1.2.3.4"}},
+		{Name: "www.example.com", TTL: 300, Type: RS_A, Data: []string{"1.2.3.5"}},
+		{Name: "api.example.com", TTL: 300, Type: RS_CNAME, Data: []string{"www.example.com"}},
+		{Name: "info.example.com", TTL: 60, Type: RS_TXT, Data: []string{"foo bar", "baz;x"}},
+		{Name: "*.sub.example.com", TTL: 60, Type: RS_AAAA, Data: []string{"::1"}},
+	}))
+
+	_, err = ParseZoneFile(strings.NewReader("www IN A (\n1.2.3.4\n"), "example.com")
+	Ω(err).Should(HaveOccurred())
+	_, err = ParseZoneFile(strings.NewReader("$INCLUDE other.zone\n"), "example.com")
+	Ω(err).Should(HaveOccurred())
+}
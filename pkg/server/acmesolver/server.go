@@ -0,0 +1,260 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package acmesolver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/server"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/gardener/external-dns-management/pkg/server/webhook"
+)
+
+const (
+	// ChallengePayloadAPIVersion is the API version of the challenge payloads sent by cert-manager
+	ChallengePayloadAPIVersion = "webhook.acme.cert-manager.io/v1alpha1"
+	// ChallengePayloadKind is the kind of the challenge payloads sent by cert-manager
+	ChallengePayloadKind = "ChallengePayload"
+	// SolverVersion is the version of the API group of the solver
+	SolverVersion = "v1alpha1"
+	// PathHealthz is the path of the health check endpoint
+	PathHealthz = "/healthz"
+)
+
+// ChallengeAction is the action requested for a challenge.
+type ChallengeAction string
+
+const (
+	// ActionPresent requests to present the challenge record
+	ActionPresent ChallengeAction = "Present"
+	// ActionCleanUp requests to remove the challenge record
+	ActionCleanUp ChallengeAction = "CleanUp"
+)
+
+// ChallengePayload is the payload exchanged with cert-manager for a DNS-01 challenge.
+// It mirrors the type of the cert-manager webhook API.
+type ChallengePayload struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *ChallengeRequest  `json:"request,omitempty"`
+	Response        *ChallengeResponse `json:"response,omitempty"`
+}
+
+// ChallengeRequest describes a DNS-01 challenge to present or clean up.
+type ChallengeRequest struct {
+	UID                     types.UID             `json:"uid"`
+	Action                  ChallengeAction       `json:"action"`
+	Type                    string                `json:"type"`
+	DNSName                 string                `json:"dnsName"`
+	Key                     string                `json:"key"`
+	ResourceNamespace       string                `json:"resourceNamespace"`
+	ResolvedFQDN            string                `json:"resolvedFQDN"`
+	ResolvedZone            string                `json:"resolvedZone"`
+	AllowAmbientCredentials bool                  `json:"allowAmbientCredentials"`
+	Config                  *apiextensionsv1.JSON `json:"config,omitempty"`
+}
+
+// ChallengeResponse is the result of a challenge request.
+type ChallengeResponse struct {
+	UID     types.UID      `json:"uid"`
+	Success bool           `json:"success"`
+	Result  *metav1.Status `json:"status,omitempty"`
+}
+
+// Solver presents and cleans up DNS-01 challenges.
+type Solver interface {
+	Present(req *ChallengeRequest) error
+	CleanUp(req *ChallengeRequest) error
+}
+
+// Config is the configuration of the solver webhook server.
+type Config struct {
+	// Port is the port of the https server
+	Port int
+	// CertDir is the directory containing the server certificate (tls.crt) and key (tls.key)
+	CertDir string
+	// ClientCA contains the PEM encoded CA certificates for verifying the client certificates of the kube-apiserver
+	ClientCA []byte
+	// AllowedNames are the accepted common names of the client certificates (all names if empty)
+	AllowedNames []string
+	// GroupName is the API group of the solver referenced by the issuers
+	GroupName string
+	// SolverName is the name of the solver referenced by the issuers
+	SolverName string
+}
+
+// StartServer starts the https server serving the solver as aggregated API for the kube-apiserver.
+func StartServer(ctx context.Context, logctx logger.LogContext, config *Config, solver Solver) error {
+	logctx = logctx.NewContext("server", "acmesolver")
+	source, err := webhook.NewCertificateSource(config.CertDir)
+	if err != nil {
+		return err
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(config.ClientCA) {
+		return fmt.Errorf("no client CA certificates found for the kube-apiserver")
+	}
+	srv := server.NewHTTPServer(ctx, logctx, "acme solver")
+	srv.RegisterHandler("/", newHandler(logctx, config, solver))
+	srv.Start(source, "", config.Port, func(cfg *tls.Config) {
+		cfg.MinVersion = tls.VersionTLS12
+		cfg.ClientCAs = clientCAs
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	})
+	return nil
+}
+
+type handler struct {
+	logger     logger.LogContext
+	groupName  string
+	solverName string
+	solver     Solver
+	// allowedNames restricts the common names of the verified client certificates (all names if empty)
+	allowedNames utils.StringSet
+}
+
+// newHandler returns the handler serving the discovery and challenge endpoints
+// of the solver API group. Only clients with a verified certificate are accepted.
+func newHandler(logctx logger.LogContext, config *Config, solver Solver) *handler {
+	return &handler{
+		logger:       logctx,
+		groupName:    config.GroupName,
+		solverName:   config.SolverName,
+		solver:       solver,
+		allowedNames: utils.NewStringSet(config.AllowedNames...),
+	}
+}
+
+func (this *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	groupPath := "/apis/" + this.groupName
+	versionPath := groupPath + "/" + SolverVersion
+	if err := this.authenticate(r); err != nil {
+		// only the kube-apiserver is allowed to access the solver
+		this.logger.Warnf("rejected request from %s: %s", r.RemoteAddr, err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if path == PathHealthz {
+		w.Write([]byte("ok"))
+		return
+	}
+	switch {
+	case path == "/apis" && r.Method == http.MethodGet:
+		this.write(w, &metav1.APIGroupList{
+			TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"},
+			Groups:   []metav1.APIGroup{this.apiGroup()},
+		})
+	case path == groupPath && r.Method == http.MethodGet:
+		group := this.apiGroup()
+		group.TypeMeta = metav1.TypeMeta{Kind: "APIGroup", APIVersion: "v1"}
+		this.write(w, &group)
+	case path == versionPath && r.Method == http.MethodGet:
+		this.write(w, &metav1.APIResourceList{
+			TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+			GroupVersion: this.groupName + "/" + SolverVersion,
+			APIResources: []metav1.APIResource{{
+				Name:         this.solverName,
+				SingularName: this.solverName,
+				Namespaced:   false,
+				Kind:         ChallengePayloadKind,
+				Verbs:        metav1.Verbs{"create"},
+			}},
+		})
+	case path == versionPath+"/"+this.solverName && r.Method == http.MethodPost:
+		payload := &ChallengePayload{}
+		if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+			http.Error(w, fmt.Sprintf("invalid challenge payload: %s", err), http.StatusBadRequest)
+			return
+		}
+		if payload.Request == nil {
+			http.Error(w, "challenge payload without request", http.StatusBadRequest)
+			return
+		}
+		payload.Response = Solve(payload.Request, this.solver)
+		if !payload.Response.Success {
+			this.logger.Warnf("%s of challenge %s for %s failed: %s", payload.Request.Action, payload.Request.UID,
+				payload.Request.ResolvedFQDN, payload.Response.Result.Message)
+		} else {
+			this.logger.Infof("%s of challenge %s for %s succeeded", payload.Request.Action, payload.Request.UID, payload.Request.ResolvedFQDN)
+		}
+		payload.Request = nil
+		payload.TypeMeta = metav1.TypeMeta{Kind: ChallengePayloadKind, APIVersion: ChallengePayloadAPIVersion}
+		this.write(w, payload)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// authenticate checks the verified client certificate of a request.
+func (this *handler) authenticate(r *http.Request) error {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return fmt.Errorf("client certificate required")
+	}
+	if name := r.TLS.VerifiedChains[0][0].Subject.CommonName; len(this.allowedNames) > 0 && !this.allowedNames.Contains(name) {
+		return fmt.Errorf("common name %q of client certificate not allowed", name)
+	}
+	return nil
+}
+
+func (this *handler) apiGroup() metav1.APIGroup {
+	version := metav1.GroupVersionForDiscovery{GroupVersion: this.groupName + "/" + SolverVersion, Version: SolverVersion}
+	return metav1.APIGroup{
+		Name:             this.groupName,
+		Versions:         []metav1.GroupVersionForDiscovery{version},
+		PreferredVersion: version,
+	}
+}
+
+func (this *handler) write(w http.ResponseWriter, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		this.logger.Warnf("cannot write response: %s", err)
+	}
+}
+
+// Solve evaluates a challenge request with the given solver.
+func Solve(req *ChallengeRequest, solver Solver) *ChallengeResponse {
+	response := &ChallengeResponse{UID: req.UID, Success: true}
+	var err error
+	switch req.Action {
+	case ActionPresent:
+		err = solver.Present(req)
+	case ActionCleanUp:
+		err = solver.CleanUp(req)
+	default:
+		err = fmt.Errorf("unsupported action %q", req.Action)
+	}
+	if err != nil {
+		response.Success = false
+		response.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: err.Error(),
+		}
+	}
+	return response
+}
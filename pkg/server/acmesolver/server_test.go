@@ -0,0 +1,151 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package acmesolver
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type testSolver struct {
+	presented map[string]string
+}
+
+func (this *testSolver) Present(req *ChallengeRequest) error {
+	if req.Key == "" {
+		return fmt.Errorf("key missing")
+	}
+	this.presented[req.ResolvedFQDN] = req.Key
+	return nil
+}
+
+func (this *testSolver) CleanUp(req *ChallengeRequest) error {
+	delete(this.presented, req.ResolvedFQDN)
+	return nil
+}
+
+var testConfig = &Config{GroupName: "acme.example.com", SolverName: "test"}
+
+// newRequest creates a request of a client with a verified certificate.
+func newRequest(method, target string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "front-proxy-client"}}
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	return req
+}
+
+func TestDiscovery(t *testing.T) {
+	RegisterTestingT(t)
+
+	handler := newHandler(logger.New(), testConfig, &testSolver{})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest(http.MethodGet, "/apis/acme.example.com/v1alpha1", nil))
+	Ω(w.Code).Should(Equal(http.StatusOK))
+	list := &metav1.APIResourceList{}
+	Ω(json.Unmarshal(w.Body.Bytes(), list)).Should(Succeed())
+	Ω(list.GroupVersion).Should(Equal("acme.example.com/v1alpha1"))
+	Ω(list.APIResources).Should(HaveLen(1))
+	Ω(list.APIResources[0].Name).Should(Equal("test"))
+	Ω(list.APIResources[0].Kind).Should(Equal(ChallengePayloadKind))
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest(http.MethodGet, "/apis/acme.example.com", nil))
+	group := &metav1.APIGroup{}
+	Ω(json.Unmarshal(w.Body.Bytes(), group)).Should(Succeed())
+	Ω(group.PreferredVersion.Version).Should(Equal(SolverVersion))
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest(http.MethodGet, "/apis/other.example.com/v1alpha1", nil))
+	Ω(w.Code).Should(Equal(http.StatusNotFound))
+}
+
+func TestChallenge(t *testing.T) {
+	RegisterTestingT(t)
+
+	solver := &testSolver{presented: map[string]string{}}
+	handler := newHandler(logger.New(), testConfig, solver)
+
+	post := func(req *ChallengeRequest) *ChallengeResponse {
+		body, err := json.Marshal(&ChallengePayload{Request: req})
+		Ω(err).ShouldNot(HaveOccurred())
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(http.MethodPost, "/apis/acme.example.com/v1alpha1/test", bytes.NewReader(body)))
+		Ω(w.Code).Should(Equal(http.StatusOK))
+		payload := &ChallengePayload{}
+		Ω(json.Unmarshal(w.Body.Bytes(), payload)).Should(Succeed())
+		Ω(payload.Kind).Should(Equal(ChallengePayloadKind))
+		Ω(payload.Request).Should(BeNil())
+		return payload.Response
+	}
+
+	resp := post(&ChallengeRequest{UID: "1", Action: ActionPresent, ResolvedFQDN: "_acme-challenge.example.com.", Key: "key"})
+	Ω(resp.UID).Should(BeEquivalentTo("1"))
+	Ω(resp.Success).Should(BeTrue())
+	Ω(solver.presented).Should(HaveKeyWithValue("_acme-challenge.example.com.", "key"))
+
+	resp = post(&ChallengeRequest{UID: "2", Action: ActionPresent, ResolvedFQDN: "_acme-challenge.example.com."})
+	Ω(resp.Success).Should(BeFalse())
+	Ω(resp.Result.Message).Should(Equal("key missing"))
+
+	resp = post(&ChallengeRequest{UID: "3", Action: ActionCleanUp, ResolvedFQDN: "_acme-challenge.example.com.", Key: "key"})
+	Ω(resp.Success).Should(BeTrue())
+	Ω(solver.presented).Should(BeEmpty())
+
+	resp = post(&ChallengeRequest{UID: "4", Action: "Unknown"})
+	Ω(resp.Success).Should(BeFalse())
+}
+
+func TestClientAuthentication(t *testing.T) {
+	RegisterTestingT(t)
+
+	config := *testConfig
+	config.AllowedNames = []string{"front-proxy-client"}
+	handler := newHandler(logger.New(), &config, &testSolver{})
+	get := func(cn *string) int {
+		req := httptest.NewRequest(http.MethodGet, "/apis/acme.example.com/v1alpha1", nil)
+		if cn != nil {
+			cert := &x509.Certificate{Subject: pkix.Name{CommonName: *cn}}
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	allowed := "front-proxy-client"
+	other := "system:anonymous"
+	Ω(get(nil)).Should(Equal(http.StatusUnauthorized))
+	Ω(get(&other)).Should(Equal(http.StatusUnauthorized))
+	Ω(get(&allowed)).Should(Equal(http.StatusOK))
+
+	handler.allowedNames = utils.StringSet{}
+	Ω(get(&other)).Should(Equal(http.StatusOK))
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package devdns
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/config"
+	"github.com/gardener/controller-manager-library/pkg/configmain"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+const OPTION_SOURCE = "dev-dns-server"
+
+type Config struct {
+	Address string
+}
+
+var _ config.OptionSource = (*Config)(nil)
+
+func init() {
+	configmain.RegisterExtension(func(cfg *configmain.Config) {
+		cfg.AddSource(OPTION_SOURCE, &Config{})
+	})
+}
+
+func (this *Config) AddOptionsToSet(set config.OptionSet) {
+	set.AddStringOption(&this.Address, "dev-dns-server-address", "", "", "address (e.g. 127.0.0.1:5353) of an embedded authoritative DNS server answering from the zones of all mock-inmemory providers (development only)")
+}
+
+func (this *Config) Evaluate() error {
+	if this.Address != "" {
+		server, err := StartServer(this.Address)
+		if err != nil {
+			return err
+		}
+		logger.New().Infof("started development DNS server on %s (udp and tcp)", server.Addr())
+	}
+	return nil
+}
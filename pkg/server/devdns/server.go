@@ -0,0 +1,231 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package devdns
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	mdns "github.com/miekg/dns"
+
+	"github.com/gardener/external-dns-management/pkg/controller/provider/mock"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// maxCNAMEChain limits following CNAME records inside a zone.
+const maxCNAMEChain = 8
+
+// Server is an authoritative DNS server answering queries
+// from the zones of all mock-inmemory providers.
+// It is intended for local development and CI only.
+type Server struct {
+	udp *mdns.Server
+	tcp *mdns.Server
+}
+
+// StartServer starts serving UDP and TCP queries on the given address.
+// If the port is 0, a free port is chosen (identical for UDP and TCP).
+func StartServer(address string) (*Server, error) {
+	pc, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen on udp %s: %w", address, err)
+	}
+	l, err := net.Listen("tcp", pc.LocalAddr().String())
+	if err != nil {
+		pc.Close()
+		return nil, fmt.Errorf("cannot listen on tcp %s: %w", pc.LocalAddr(), err)
+	}
+	handler := mdns.HandlerFunc(ServeDNS)
+	this := &Server{
+		udp: &mdns.Server{PacketConn: pc, Handler: handler},
+		tcp: &mdns.Server{Listener: l, Handler: handler},
+	}
+	go this.udp.ActivateAndServe()
+	go this.tcp.ActivateAndServe()
+	return this, nil
+}
+
+// Addr returns the address the server is listening on.
+func (this *Server) Addr() string {
+	return this.udp.PacketConn.LocalAddr().String()
+}
+
+func (this *Server) Shutdown() error {
+	err := this.udp.Shutdown()
+	if err2 := this.tcp.Shutdown(); err == nil {
+		err = err2
+	}
+	return err
+}
+
+// ServeDNS answers a query from the zone of a mock-inmemory provider with the longest matching domain.
+// Queries outside of all zones are refused.
+func ServeDNS(w mdns.ResponseWriter, req *mdns.Msg) {
+	m := new(mdns.Msg)
+	m.SetReply(req)
+	if len(req.Question) != 1 || req.Opcode != mdns.OpcodeQuery {
+		m.SetRcode(req, mdns.RcodeNotImplemented)
+	} else {
+		answer(m, req.Question[0])
+	}
+	w.WriteMsg(m)
+}
+
+func answer(m *mdns.Msg, q mdns.Question) {
+	qname := normalizeName(q.Name)
+	store, zone := findZone(qname)
+	if zone == nil {
+		m.Rcode = mdns.RcodeRefused
+		return
+	}
+	m.Authoritative = true
+
+	domain := normalizeName(zone.Domain())
+	records := zoneRecords(domain, store.GetDNSSets(zone.Id()))
+	soa := soaRecord(domain)
+
+	name := qname
+	for i := 0; i <= maxCNAMEChain; i++ {
+		rrs := lookup(records, name, domain)
+		if rrs == nil {
+			if i == 0 {
+				m.Rcode = mdns.RcodeNameError
+				m.Ns = append(m.Ns, soa)
+			}
+			return
+		}
+		found := filter(rrs, q.Qtype)
+		if len(found) == 0 && q.Qtype != mdns.TypeCNAME {
+			if cname := filter(rrs, mdns.TypeCNAME); len(cname) > 0 {
+				m.Answer = append(m.Answer, cname...)
+				target := normalizeName(cname[0].(*mdns.CNAME).Target)
+				if !inDomain(target, domain) {
+					return
+				}
+				name = target
+				continue
+			}
+		}
+		if len(found) == 0 && i == 0 {
+			m.Ns = append(m.Ns, soa)
+		}
+		m.Answer = append(m.Answer, found...)
+		return
+	}
+}
+
+func findZone(name string) (*provider.InMemory, provider.DNSHostedZone) {
+	var (
+		found     *provider.InMemory
+		foundZone provider.DNSHostedZone
+		length    = -1
+	)
+	for _, store := range mock.InMemories() {
+		for _, zone := range store.GetZones() {
+			domain := normalizeName(zone.Domain())
+			if inDomain(name, domain) && len(domain) > length {
+				found, foundZone, length = store, zone, len(domain)
+			}
+		}
+	}
+	return found, foundZone
+}
+
+// zoneRecords maps the DNS sets to the records a real provider would publish, including the owner TXT records.
+func zoneRecords(domain string, sets dns.DNSSets) map[string][]mdns.RR {
+	records := map[string][]mdns.RR{
+		domain: {soaRecord(domain), &mdns.NS{Hdr: header(domain, mdns.TypeNS, 3600), Ns: nsName(domain)}},
+	}
+	for _, set := range sets {
+		for rtype := range set.Sets {
+			name, rs := dns.MapToProvider(rtype, set, domain)
+			dnsName := normalizeName(name.DNSName)
+			for _, r := range rs.Records {
+				rr, err := mdns.NewRR(fmt.Sprintf("%s %d IN %s %s", mdns.Fqdn(dnsName), rs.TTL, rs.Type, r.Value))
+				if err != nil || rr == nil {
+					continue
+				}
+				records[dnsName] = append(records[dnsName], rr)
+			}
+		}
+	}
+	return records
+}
+
+// lookup returns the records of the name or of the closest wildcard.
+func lookup(records map[string][]mdns.RR, name, domain string) []mdns.RR {
+	if rrs, ok := records[name]; ok {
+		return rrs
+	}
+	for parent := name; parent != domain; {
+		idx := strings.Index(parent, ".")
+		if idx < 0 {
+			break
+		}
+		parent = parent[idx+1:]
+		if rrs, ok := records["*."+parent]; ok {
+			result := make([]mdns.RR, len(rrs))
+			for i, rr := range rrs {
+				result[i] = mdns.Copy(rr)
+				result[i].Header().Name = mdns.Fqdn(name)
+			}
+			return result
+		}
+	}
+	return nil
+}
+
+func filter(rrs []mdns.RR, qtype uint16) []mdns.RR {
+	var result []mdns.RR
+	for _, rr := range rrs {
+		if qtype == mdns.TypeANY || rr.Header().Rrtype == qtype {
+			result = append(result, rr)
+		}
+	}
+	return result
+}
+
+func soaRecord(domain string) mdns.RR {
+	return &mdns.SOA{
+		Hdr:     header(domain, mdns.TypeSOA, 60),
+		Ns:      nsName(domain),
+		Mbox:    mdns.Fqdn("hostmaster." + domain),
+		Serial:  1,
+		Refresh: 3600,
+		Retry:   600,
+		Expire:  86400,
+		Minttl:  60,
+	}
+}
+
+func nsName(domain string) string {
+	return mdns.Fqdn("ns." + domain)
+}
+
+func header(name string, rtype uint16, ttl uint32) mdns.RR_Header {
+	return mdns.RR_Header{Name: mdns.Fqdn(name), Rrtype: rtype, Class: mdns.ClassINET, Ttl: ttl}
+}
+
+func inDomain(name, domain string) bool {
+	return name == domain || strings.HasSuffix(name, "."+domain)
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
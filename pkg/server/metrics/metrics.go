@@ -35,18 +35,69 @@ func init() {
 	prometheus.MustRegister(Requests)
 	prometheus.MustRegister(ZoneRequests)
 	prometheus.MustRegister(ZoneCacheDiscardings)
+	prometheus.MustRegister(CoalescedZoneTriggers)
+	prometheus.MustRegister(ProviderRequestSeconds)
+	prometheus.MustRegister(ChangeBatchSize)
+	prometheus.MustRegister(ProviderThrottles)
+	prometheus.MustRegister(EntryApplySeconds)
+	prometheus.MustRegister(EntryPropagationSeconds)
+	prometheus.MustRegister(EntryPropagationTimeouts)
+	prometheus.MustRegister(EntryPropagationPending)
 	prometheus.MustRegister(Accounts)
+	prometheus.MustRegister(AccountQuotaLimit)
+	prometheus.MustRegister(AccountQuotaUsage)
 	prometheus.MustRegister(Entries)
 	prometheus.MustRegister(StaleEntries)
+	prometheus.MustRegister(PlannedChanges)
+	prometheus.MustRegister(OrphanedRecords)
+	prometheus.MustRegister(DriftedEntries)
+	prometheus.MustRegister(QuotaExceededEntries)
+	prometheus.MustRegister(StuckEntries)
+	prometheus.MustRegister(OwnerConflicts)
+	prometheus.MustRegister(ForeignRecordChanges)
+	prometheus.MustRegister(DeletedOrphanedRecords)
 	prometheus.MustRegister(Owners)
+	prometheus.MustRegister(Notifications)
 	prometheus.MustRegister(RemoteAccessLogins)
 	prometheus.MustRegister(RemoteAccessRequests)
 	prometheus.MustRegister(RemoteAccessSeconds)
 	prometheus.MustRegister(RemoteAccessCertificates)
+	prometheus.MustRegister(DNSLockState)
+	prometheus.MustRegister(DNSLockLookupAge)
+	prometheus.MustRegister(DNSLockResurrects)
+	prometheus.MustRegister(DNSLockTakeovers)
 
 	server.RegisterHandler("/metrics", promhttp.Handler())
 }
 
+const (
+	// operations of provider accounts observed for the request duration histogram
+	OP_GETZONES        = "get_zones"
+	OP_GETZONESTATE    = "get_zone_state"
+	OP_EXECUTEREQUESTS = "execute_requests"
+
+	// reasons of throttled or retried provider operations
+	THROTTLE_API_RATELIMIT   = "api_rate_limit"
+	THROTTLE_ENTRY_RATELIMIT = "entry_rate_limit"
+	THROTTLE_CONFLICT_RETRY  = "conflict_retry"
+	THROTTLE_PROVIDER        = "provider_response"
+
+	// results of notifications
+	NOTIFICATION_SENT    = "sent"
+	NOTIFICATION_FAILED  = "failed"
+	NOTIFICATION_DROPPED = "dropped"
+
+	// states of DNS locks
+	LOCK_STATE_HELD      = "held"
+	LOCK_STATE_CONTENDED = "contended"
+	LOCK_STATE_STALE     = "stale"
+	LOCK_STATE_INVALID   = "invalid"
+)
+
+var lockStates = []string{LOCK_STATE_HELD, LOCK_STATE_CONTENDED, LOCK_STATE_STALE, LOCK_STATE_INVALID}
+
+var entryLagBuckets = []float64{1, 2, 5, 10, 20, 30, 60, 120, 300, 600, 1200, 1800, 3600}
+
 var (
 	Requests = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -72,6 +123,74 @@ var (
 		[]string{"providertype", "zone"},
 	)
 
+	CoalescedZoneTriggers = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_zone_triggers_coalesced",
+			Help: "Total number of zone triggers coalesced into an already scheduled zone reconciliation per provider type and zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	ProviderRequestSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "external_dns_management_provider_request_seconds",
+			Help:    "Duration in seconds of provider operations per provider type, zone, and operation (zone state requests may be served by the zone state cache)",
+			Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"providertype", "zone", "operation", "error"},
+	)
+
+	ChangeBatchSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "external_dns_management_change_batch_size",
+			Help:    "Number of change requests per batch executed for a hosted zone",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 11),
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	ProviderThrottles = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_provider_throttles",
+			Help: "Total number of throttled or retried provider operations per provider type, zone, and reason",
+		},
+		[]string{"providertype", "zone", "reason"},
+	)
+
+	EntryApplySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "external_dns_management_entry_apply_seconds",
+			Help:    "Duration in seconds from an observed spec change of a dns entry until it is applied at the provider per provider type and zone",
+			Buckets: entryLagBuckets,
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	EntryPropagationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "external_dns_management_entry_propagation_seconds",
+			Help:    "Duration in seconds from an observed spec change of a dns entry until it is resolved by all authoritative name servers of the zone",
+			Buckets: entryLagBuckets,
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	EntryPropagationTimeouts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_entry_propagation_timeouts",
+			Help: "Total number of dns entries not resolved by the authoritative name servers of the zone within the propagation check timeout",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	EntryPropagationPending = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_entry_propagation_pending",
+			Help: "Number of dns entries per hosted zone waiting for the resolution by the name servers",
+		},
+		[]string{"providertype", "zone"},
+	)
+
 	Accounts = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "external_dns_management_account_providers",
@@ -80,6 +199,22 @@ var (
 		[]string{"providertype", "accounthash"},
 	)
 
+	AccountQuotaLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_account_quota_limit",
+			Help: "Request quota per second given by the rate limiter per provider type and credential set",
+		},
+		[]string{"providertype", "accounthash"},
+	)
+
+	AccountQuotaUsage = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_account_quota_usage",
+			Help: "Ratio of the requests in the last minute to the request quota per provider type and credential set",
+		},
+		[]string{"providertype", "accounthash"},
+	)
+
 	Entries = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "external_dns_management_dns_entries",
@@ -96,6 +231,70 @@ var (
 		[]string{"providertype", "zone"},
 	)
 
+	PlannedChanges = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_dry_run_planned_changes",
+			Help: "Number of change requests per hosted zone planned in the last reconciliation in dry run mode",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	OrphanedRecords = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_orphaned_records",
+			Help: "Number of orphaned record sets per hosted zone waiting for deletion",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	DeletedOrphanedRecords = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_orphaned_records_deleted",
+			Help: "Total number of orphaned record sets per hosted zone scheduled for deletion",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	DriftedEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_drifted_entries",
+			Help: "Number of dns entries per hosted zone with records modified in the DNS system found by the last drift check",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	QuotaExceededEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_quota_exceeded_entries",
+			Help: "Number of dns entries per namespace exceeding the quota of a dns policy",
+		},
+		[]string{"namespace"},
+	)
+
+	StuckEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_stuck_entries",
+			Help: "Number of dns entries not ready longer than the stuck entry threshold per blocking reason",
+		},
+		[]string{"reason"},
+	)
+
+	OwnerConflicts = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_owner_conflicts",
+			Help: "Number of record sets per hosted zone with suspended updates because of repeated changes by another owner (split-brain)",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	ForeignRecordChanges = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_foreign_record_changes",
+			Help: "Total number of changes by another owner of record sets written by this controller per hosted zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
 	Owners = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "external_dns_management_dns_owners",
@@ -104,6 +303,14 @@ var (
 		[]string{"owner", "providertype", "provider"},
 	)
 
+	Notifications = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_notifications",
+			Help: "Total number of notifications per event type and result (sent, failed or dropped)",
+		},
+		[]string{"type", "result"},
+	)
+
 	RemoteAccessLogins = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "external_dns_management_remoteaccess_logins",
@@ -135,6 +342,38 @@ var (
 			Help: "Number of server-side transport credentials of remote access",
 		},
 	)
+
+	DNSLockState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_dns_lock_state",
+			Help: "State of a dns lock (1 for the current state held, contended, stale or invalid)",
+		},
+		[]string{"namespace", "name", "state"},
+	)
+
+	DNSLockLookupAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_dns_lock_lookup_age_seconds",
+			Help: "Seconds since the last successful lookup of the records of a dns lock",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	DNSLockResurrects = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_dns_lock_resurrects",
+			Help: "Total number of attempts to resurrect a dns lock after failed lookups",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	DNSLockTakeovers = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_dns_lock_takeovers",
+			Help: "Total number of takeovers of a dns lock by this controller (acquired) or by another holder (lost)",
+		},
+		[]string{"namespace", "name", "direction"},
+	)
 )
 
 var theRequestLabels = &requestLabels{lock: sync.Mutex{}, known: map[ptypeAccount]utils.StringSet{}}
@@ -174,6 +413,8 @@ func (this *requestLabels) Delete(ptype, account string) utils.StringSet {
 
 func DeleteAccount(ptype, account string) {
 	Accounts.DeleteLabelValues(ptype, account)
+	AccountQuotaLimit.DeleteLabelValues(ptype, account)
+	AccountQuotaUsage.DeleteLabelValues(ptype, account)
 	requestTypes := theRequestLabels.Delete(ptype, account)
 	for rtype := range requestTypes {
 		Requests.DeleteLabelValues(ptype, account, rtype)
@@ -185,6 +426,14 @@ func ReportAccountProviders(ptype, account string, amount int) {
 	Accounts.WithLabelValues(ptype, account).Set(float64(amount))
 }
 
+func ReportAccountQuotaLimit(ptype, account string, qps float64) {
+	AccountQuotaLimit.WithLabelValues(ptype, account).Set(qps)
+}
+
+func ReportAccountQuotaUsage(ptype, account string, usage float64) {
+	AccountQuotaUsage.WithLabelValues(ptype, account).Set(usage)
+}
+
 func AddRequests(ptype, account, requestType string, no int, zone *string) {
 	theRequestLabels.AddRequestLabel(ptype, account, requestType)
 	Requests.WithLabelValues(ptype, account, requestType).Add(float64(no))
@@ -193,10 +442,46 @@ func AddRequests(ptype, account, requestType string, no int, zone *string) {
 	}
 }
 
+func ReportProviderRequestSeconds(ptype, zone, operation string, failed bool, duration time.Duration) {
+	ProviderRequestSeconds.WithLabelValues(ptype, zone, operation, strconv.FormatBool(failed)).Observe(duration.Seconds())
+}
+
+func ReportChangeBatchSize(zoneid dns.ZoneID, size int) {
+	ChangeBatchSize.WithLabelValues(zoneid.ProviderType, zoneid.ID).Observe(float64(size))
+}
+
+func AddProviderThrottles(ptype, zone, reason string, no int) {
+	ProviderThrottles.WithLabelValues(ptype, zone, reason).Add(float64(no))
+}
+
+func ReportEntryApplySeconds(zoneid dns.ZoneID, duration time.Duration) {
+	EntryApplySeconds.WithLabelValues(zoneid.ProviderType, zoneid.ID).Observe(duration.Seconds())
+}
+
+func ReportEntryPropagationSeconds(zoneid dns.ZoneID, duration time.Duration) {
+	EntryPropagationSeconds.WithLabelValues(zoneid.ProviderType, zoneid.ID).Observe(duration.Seconds())
+}
+
+func AddEntryPropagationTimeouts(zoneid dns.ZoneID) {
+	EntryPropagationTimeouts.WithLabelValues(zoneid.ProviderType, zoneid.ID).Add(float64(1))
+}
+
+func AddEntryPropagationPending(zoneid dns.ZoneID, no int) {
+	EntryPropagationPending.WithLabelValues(zoneid.ProviderType, zoneid.ID).Add(float64(no))
+}
+
+func AddNotifications(eventType, result string) {
+	Notifications.WithLabelValues(eventType, result).Inc()
+}
+
 func AddZoneCacheDiscarding(id dns.ZoneID) {
 	ZoneCacheDiscardings.WithLabelValues(id.ProviderType, id.ID).Add(float64(1))
 }
 
+func AddCoalescedZoneTrigger(id dns.ZoneID) {
+	CoalescedZoneTriggers.WithLabelValues(id.ProviderType, id.ID).Add(float64(1))
+}
+
 type ZoneProviderTypes struct {
 	lock      sync.Mutex
 	providers map[dns.ZoneID]struct{}
@@ -222,6 +507,42 @@ func ReportZoneEntries(zoneid dns.ZoneID, amount int, stale int) {
 	zoneProviders.Add(zoneid)
 }
 
+func ReportPlannedChanges(zoneid dns.ZoneID, amount int) {
+	PlannedChanges.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(float64(amount))
+}
+
+func ReportOrphanedRecords(zoneid dns.ZoneID, amount int) {
+	OrphanedRecords.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(float64(amount))
+}
+
+func ReportDriftedEntries(zoneid dns.ZoneID, amount int) {
+	DriftedEntries.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(float64(amount))
+}
+
+func ReportQuotaExceededEntries(namespace string, amount int) {
+	QuotaExceededEntries.WithLabelValues(namespace).Set(float64(amount))
+}
+
+func DeleteQuotaExceededEntries(namespace string) {
+	QuotaExceededEntries.DeleteLabelValues(namespace)
+}
+
+func ReportStuckEntries(reason string, amount int) {
+	StuckEntries.WithLabelValues(reason).Set(float64(amount))
+}
+
+func ReportOwnerConflicts(zoneid dns.ZoneID, amount int) {
+	OwnerConflicts.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(float64(amount))
+}
+
+func AddForeignRecordChanges(zoneid dns.ZoneID, amount int) {
+	ForeignRecordChanges.WithLabelValues(zoneid.ProviderType, zoneid.ID).Add(float64(amount))
+}
+
+func AddDeletedOrphanedRecords(zoneid dns.ZoneID, amount int) {
+	DeletedOrphanedRecords.WithLabelValues(zoneid.ProviderType, zoneid.ID).Add(float64(amount))
+}
+
 func ReportRemoteAccessLogins(namespace, client string, success bool) {
 	RemoteAccessLogins.WithLabelValues(namespace, client, strconv.FormatBool(success)).Add(float64(1))
 }
@@ -238,9 +559,66 @@ func ReportRemoteAccessCertificates(count int) {
 	RemoteAccessCertificates.Set(float64(count))
 }
 
+func ReportDNSLockState(name resources.ObjectName, state string) {
+	for _, s := range lockStates {
+		value := 0.0
+		if s == state {
+			value = 1
+		}
+		DNSLockState.WithLabelValues(name.Namespace(), name.Name(), s).Set(value)
+	}
+}
+
+func ReportDNSLockLookupAge(name resources.ObjectName, age time.Duration) {
+	DNSLockLookupAge.WithLabelValues(name.Namespace(), name.Name()).Set(age.Seconds())
+}
+
+func AddDNSLockResurrect(name resources.ObjectName) {
+	DNSLockResurrects.WithLabelValues(name.Namespace(), name.Name()).Inc()
+}
+
+// AddDNSLockTakeover counts a takeover of a dns lock, acquired by this controller or lost to another holder.
+func AddDNSLockTakeover(name resources.ObjectName, acquired bool) {
+	direction := "lost"
+	if acquired {
+		direction = "acquired"
+	}
+	DNSLockTakeovers.WithLabelValues(name.Namespace(), name.Name(), direction).Inc()
+}
+
+func DeleteDNSLock(name resources.ObjectName) {
+	for _, s := range lockStates {
+		DNSLockState.DeleteLabelValues(name.Namespace(), name.Name(), s)
+	}
+	DNSLockLookupAge.DeleteLabelValues(name.Namespace(), name.Name())
+	DNSLockResurrects.DeleteLabelValues(name.Namespace(), name.Name())
+	for _, direction := range []string{"acquired", "lost"} {
+		DNSLockTakeovers.DeleteLabelValues(name.Namespace(), name.Name(), direction)
+	}
+}
+
 func DeleteZone(zoneid dns.ZoneID) {
 	zoneProviders.Remove(zoneid)
 	Entries.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	PlannedChanges.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	OrphanedRecords.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	DeletedOrphanedRecords.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	DriftedEntries.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	OwnerConflicts.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	ForeignRecordChanges.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	ChangeBatchSize.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	CoalescedZoneTriggers.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	EntryApplySeconds.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	EntryPropagationSeconds.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	EntryPropagationTimeouts.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	for _, op := range []string{OP_GETZONESTATE, OP_EXECUTEREQUESTS} {
+		for _, failed := range []string{"false", "true"} {
+			ProviderRequestSeconds.DeleteLabelValues(zoneid.ProviderType, zoneid.ID, op, failed)
+		}
+	}
+	for _, reason := range []string{THROTTLE_ENTRY_RATELIMIT, THROTTLE_CONFLICT_RETRY, THROTTLE_PROVIDER} {
+		ProviderThrottles.DeleteLabelValues(zoneid.ProviderType, zoneid.ID, reason)
+	}
 }
 
 var currentStatistic = statistic.NewEntryStatistic()
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
 	atomic2 "go.uber.org/atomic"
 	"google.golang.org/grpc/credentials"
@@ -36,6 +37,7 @@ type dynamicTransportCredentials struct {
 	logctx               logger.LogContext
 	certPool             *x509.CertPool
 	serverSecretProvider ServerSecretProvider
+	tlsPolicy            *dnsutils.TLSPolicy
 	oldCertificates      atomic.Value // value type: []tls.Certificate
 	currentTLS           atomic.Value // value type: credentials.TransportCredentials
 	lastResourceVersion  atomic2.String
@@ -43,11 +45,12 @@ type dynamicTransportCredentials struct {
 
 var _ credentials.TransportCredentials = &dynamicTransportCredentials{}
 
-func newDynamicTransportCredentials(logctx logger.LogContext, certPool *x509.CertPool, provider ServerSecretProvider) credentials.TransportCredentials {
+func newDynamicTransportCredentials(logctx logger.LogContext, certPool *x509.CertPool, provider ServerSecretProvider, tlsPolicy *dnsutils.TLSPolicy) credentials.TransportCredentials {
 	dyn := &dynamicTransportCredentials{
 		logctx:               logctx.NewContext("tc", "transport-credentials"),
 		certPool:             certPool,
 		serverSecretProvider: provider,
+		tlsPolicy:            tlsPolicy,
 	}
 	dyn.oldCertificates.Store([]tls.Certificate{})
 	dyn.updateTLS(nil)
@@ -81,6 +84,7 @@ func (d *dynamicTransportCredentials) createTLS(secret *corev1.Secret) (credenti
 		ClientAuth:   tls.RequireAndVerifyClientCert,
 		ClientCAs:    d.certPool,
 	}
+	d.tlsPolicy.Apply(config)
 
 	ok := false
 	oldCredentials := d.oldCertificates.Load().([]tls.Certificate)
@@ -25,6 +25,7 @@ import (
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 	"github.com/gardener/external-dns-management/pkg/server/remote/common"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -45,6 +46,7 @@ type RemoteAccessServerConfig struct {
 	CACertFilename       string
 	SecretName           resources.ObjectName
 	ServerSecretProvider ServerSecretProvider
+	TLSPolicy            *dnsutils.TLSPolicy
 }
 
 type CreateServerFunc func(logctx logger.LogContext) common.RemoteProviderServer
@@ -67,7 +69,7 @@ func loadTLSCredentials(logctx logger.LogContext, cfg *RemoteAccessServerConfig)
 		return nil, fmt.Errorf("failed to add client CA's certificate")
 	}
 
-	return newDynamicTransportCredentials(logctx, certPool, cfg.ServerSecretProvider), nil
+	return newDynamicTransportCredentials(logctx, certPool, cfg.ServerSecretProvider, cfg.TLSPolicy), nil
 }
 
 func StartDNSHandlerServer(logctx logger.LogContext, config *RemoteAccessServerConfig) (common.RemoteProviderServer, error) {
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package tracing
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer used for all spans of the DNS controller manager.
+const TracerName = "github.com/gardener/external-dns-management"
+
+// ServiceName is the service name reported with the spans.
+const ServiceName = "dns-controller-manager"
+
+var setupOnce sync.Once
+
+// Config is the configuration of the OTLP/HTTP trace exporter.
+type Config struct {
+	// Endpoint is the host and port of the OTLP/HTTP receiver (e.g. otel-collector:4318)
+	Endpoint string
+	// Insecure disables TLS for the connection to the receiver
+	Insecure bool
+	// TLSConfig is the optional TLS configuration for the connection to the receiver
+	TLSConfig *tls.Config
+}
+
+// Setup installs the global tracer provider exporting the spans with OTLP/HTTP.
+// Without setup, spans are not recorded. The tracer provider is installed only once per process
+// and shut down when the context is done.
+func Setup(ctx context.Context, logger logger.LogContext, cfg *Config) error {
+	if cfg == nil || cfg.Endpoint == "" {
+		return nil
+	}
+	var err error
+	setupOnce.Do(func() {
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		} else if cfg.TLSConfig != nil {
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(cfg.TLSConfig))
+		}
+		exporter, e := otlptracehttp.New(ctx, opts...)
+		if e != nil {
+			err = e
+			return
+		}
+		provider := sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(ServiceName))),
+		)
+		otel.SetTracerProvider(provider)
+		logger.Infof("exporting traces to %s", cfg.Endpoint)
+		go func() {
+			<-ctx.Done()
+			if err := provider.Shutdown(context.Background()); err != nil {
+				logger.Warnf("shutdown of tracer provider failed: %s", err)
+			}
+		}()
+	})
+	return err
+}
+
+// Start starts a span as child of the span of the context (if any).
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(TracerName).Start(ctx, name, opts...)
+}
+
+// End ends the span and records the error (if any).
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,289 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/certmgmt"
+	"github.com/gardener/controller-manager-library/pkg/certs"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/server"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// PathValidateDNSEntry is the path of the validation webhook for DNSEntries
+	PathValidateDNSEntry = "/validate-dnsentry"
+	// PathValidateDNSProvider is the path of the validation webhook for DNSProviders
+	PathValidateDNSProvider = "/validate-dnsprovider"
+	// PathMutateDNSEntry is the path of the mutating webhook for DNSEntries
+	PathMutateDNSEntry = "/mutate-dnsentry"
+	// PathConvert is the path of the CRD conversion webhook
+	PathConvert = "/convert"
+)
+
+// ValidateFunc validates the object of an admission request.
+// A returned error denies the request with the error message.
+type ValidateFunc func(req *admissionv1.AdmissionRequest) error
+
+// MutateFunc determines the modifications of the object of an admission request
+// as JSON patch operations. A returned error denies the request with the error message.
+type MutateFunc func(req *admissionv1.AdmissionRequest) ([]PatchOperation, error)
+
+// ConvertFunc converts the serialized custom resource object to the desired API version.
+type ConvertFunc func(raw []byte, desiredAPIVersion string) ([]byte, error)
+
+// Handlers contains the webhook functions served by the server, mapped by their paths.
+type Handlers struct {
+	Validators map[string]ValidateFunc
+	Mutators   map[string]MutateFunc
+	Converters map[string]ConvertFunc
+}
+
+// PatchOperation is a JSON patch operation (RFC 6902)
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Config is the configuration of the admission webhook server.
+type Config struct {
+	// Port is the port of the https server
+	Port int
+	// CertDir is the directory containing the server certificate (tls.crt) and key (tls.key)
+	CertDir string
+}
+
+// StartServer starts the https server serving the given validating, mutating and conversion webhooks.
+func StartServer(ctx context.Context, logctx logger.LogContext, config *Config, handlers *Handlers) error {
+	logctx = logctx.NewContext("server", "admissionwebhook")
+	source, err := NewCertificateSource(config.CertDir)
+	if err != nil {
+		return err
+	}
+	srv := server.NewHTTPServer(ctx, logctx, "admission webhook")
+	for path, validate := range handlers.Validators {
+		validate := validate
+		srv.RegisterHandler(path, &admissionHandler{logger: logctx, review: func(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+			return Review(req, validate)
+		}})
+	}
+	for path, mutate := range handlers.Mutators {
+		mutate := mutate
+		srv.RegisterHandler(path, &admissionHandler{logger: logctx, review: func(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+			return ReviewMutation(req, mutate)
+		}})
+	}
+	for path, convert := range handlers.Converters {
+		srv.RegisterHandler(path, &conversionHandler{logger: logctx, convert: convert})
+	}
+	srv.Start(source, "", config.Port, func(cfg *tls.Config) {
+		cfg.MinVersion = tls.VersionTLS12
+	})
+	return nil
+}
+
+type admissionHandler struct {
+	logger logger.LogContext
+	review func(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse
+}
+
+func (this *admissionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &admissionv1.AdmissionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+		http.Error(w, fmt.Sprintf("invalid admission review: %s", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "admission review without request", http.StatusBadRequest)
+		return
+	}
+	review.Response = this.review(review.Request)
+	review.Request = nil
+	this.logger.Debugf("admission for %s: allowed=%t", review.Response.UID, review.Response.Allowed)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		this.logger.Warnf("cannot write admission response: %s", err)
+	}
+}
+
+// Review evaluates an admission request with the given validation function.
+func Review(req *admissionv1.AdmissionRequest, validate ValidateFunc) *admissionv1.AdmissionResponse {
+	response := &admissionv1.AdmissionResponse{UID: req.UID, Allowed: true}
+	if req.Operation == admissionv1.Delete {
+		return response
+	}
+	if err := validate(req); err != nil {
+		deny(response, err)
+	}
+	return response
+}
+
+// ReviewMutation evaluates an admission request with the given mutation function.
+// Created and updated objects are mutated, all other requests are allowed unchanged.
+func ReviewMutation(req *admissionv1.AdmissionRequest, mutate MutateFunc) *admissionv1.AdmissionResponse {
+	response := &admissionv1.AdmissionResponse{UID: req.UID, Allowed: true}
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return response
+	}
+	ops, err := mutate(req)
+	if err != nil {
+		deny(response, err)
+		return response
+	}
+	if len(ops) > 0 {
+		patch, err := json.Marshal(ops)
+		if err != nil {
+			deny(response, err)
+			return response
+		}
+		patchType := admissionv1.PatchTypeJSONPatch
+		response.Patch = patch
+		response.PatchType = &patchType
+	}
+	return response
+}
+
+func deny(response *admissionv1.AdmissionResponse, err error) {
+	response.Allowed = false
+	response.Result = &metav1.Status{
+		Status:  metav1.StatusFailure,
+		Reason:  metav1.StatusReasonInvalid,
+		Code:    http.StatusUnprocessableEntity,
+		Message: err.Error(),
+	}
+}
+
+type conversionHandler struct {
+	logger  logger.LogContext
+	convert ConvertFunc
+}
+
+func (this *conversionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &apiextensionsv1.ConversionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+		http.Error(w, fmt.Sprintf("invalid conversion review: %s", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "conversion review without request", http.StatusBadRequest)
+		return
+	}
+	review.Response = Convert(review.Request, this.convert)
+	review.Request = nil
+	if review.Response.Result.Status != metav1.StatusSuccess {
+		this.logger.Warnf("conversion %s failed: %s", review.Response.UID, review.Response.Result.Message)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		this.logger.Warnf("cannot write conversion response: %s", err)
+	}
+}
+
+// Convert evaluates a conversion request with the given conversion function.
+func Convert(req *apiextensionsv1.ConversionRequest, convert ConvertFunc) *apiextensionsv1.ConversionResponse {
+	response := &apiextensionsv1.ConversionResponse{UID: req.UID}
+	for _, obj := range req.Objects {
+		converted, err := convert(obj.Raw, req.DesiredAPIVersion)
+		if err != nil {
+			response.ConvertedObjects = nil
+			response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			return response
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	response.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return response
+}
+
+// NewCertificateSource returns a source for the server certificate (tls.crt) and
+// key (tls.key) in the given directory.
+func NewCertificateSource(certDir string) (certs.CertificateSource, error) {
+	source := &fileCertificateSource{
+		certFile: filepath.Join(certDir, corev1.TLSCertKey),
+		keyFile:  filepath.Join(certDir, corev1.TLSPrivateKeyKey),
+	}
+	if _, err := source.GetCertificate(nil); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+// fileCertificateSource provides the server certificate from files
+// and reloads it if the certificate file is modified.
+type fileCertificateSource struct {
+	certFile string
+	keyFile  string
+
+	lock    sync.Mutex
+	modTime time.Time
+	cert    *tls.Certificate
+	info    certmgmt.CertificateInfo
+}
+
+func (this *fileCertificateSource) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	fi, err := os.Stat(this.certFile)
+	if err != nil {
+		if this.cert != nil {
+			return this.cert, nil
+		}
+		return nil, err
+	}
+	if this.cert != nil && !fi.ModTime().After(this.modTime) {
+		return this.cert, nil
+	}
+	certPEM, err := ioutil.ReadFile(this.certFile)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := ioutil.ReadFile(this.keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server certificate in %s: %w", this.certFile, err)
+	}
+	this.cert = &cert
+	this.info = certmgmt.NewCertInfo(certPEM, keyPEM, nil, nil)
+	this.modTime = fi.ModTime()
+	return this.cert, nil
+}
+
+func (this *fileCertificateSource) GetCertificateInfo() certmgmt.CertificateInfo {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.info
+}
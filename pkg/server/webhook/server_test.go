@@ -0,0 +1,59 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+func TestReviewMutation(t *testing.T) {
+	RegisterTestingT(t)
+
+	calls := 0
+	mutate := func(req *admissionv1.AdmissionRequest) ([]PatchOperation, error) {
+		calls++
+		return []PatchOperation{{Op: "add", Path: "/spec/ttl", Value: 300}}, nil
+	}
+
+	response := ReviewMutation(&admissionv1.AdmissionRequest{UID: "1", Operation: admissionv1.Create}, mutate)
+	Ω(response.Allowed).Should(BeTrue())
+	Ω(string(response.Patch)).Should(Equal(`[{"op":"add","path":"/spec/ttl","value":300}]`))
+	Ω(*response.PatchType).Should(Equal(admissionv1.PatchTypeJSONPatch))
+
+	response = ReviewMutation(&admissionv1.AdmissionRequest{UID: "2", Operation: admissionv1.Update}, mutate)
+	Ω(response.Allowed).Should(BeTrue())
+	Ω(string(response.Patch)).Should(Equal(`[{"op":"add","path":"/spec/ttl","value":300}]`))
+
+	for _, op := range []admissionv1.Operation{admissionv1.Delete, admissionv1.Connect} {
+		response = ReviewMutation(&admissionv1.AdmissionRequest{UID: "2", Operation: op}, mutate)
+		Ω(response.Allowed).Should(BeTrue())
+		Ω(response.Patch).Should(BeNil())
+		Ω(response.PatchType).Should(BeNil())
+	}
+	Ω(calls).Should(Equal(2))
+
+	response = ReviewMutation(&admissionv1.AdmissionRequest{UID: "3", Operation: admissionv1.Create},
+		func(req *admissionv1.AdmissionRequest) ([]PatchOperation, error) {
+			return nil, fmt.Errorf("invalid label")
+		})
+	Ω(response.Allowed).Should(BeFalse())
+	Ω(response.Result.Message).Should(ContainSubstring("invalid label"))
+}